@@ -0,0 +1,121 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package alert provides a simple notification subsystem posting critical events to webhooks.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// postTimeout is the maximum duration of a single webhook request.
+const postTimeout = 10 * time.Second
+
+// Type is the type of critical event.
+type Type string
+
+// Alert types.
+const (
+	TypeMissedProposal        Type = "missed_proposal"
+	TypeValidatorSlashed      Type = "validator_slashed"
+	TypeQuorumLost            Type = "quorum_lost"
+	TypeBeaconNodeUnreachable Type = "beacon_node_unreachable"
+)
+
+// Alert is the generic webhook JSON payload of a critical event.
+type Alert struct {
+	Type    Type              `json:"type"`
+	Message string            `json:"message"`
+	Time    time.Time         `json:"time"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// New returns a new notifier posting alerts to the webhook URLs, including the labels identifying the node.
+// Alerts are dropped if no URLs are provided.
+func New(urls []string, labels map[string]string) *Notifier {
+	return &Notifier{
+		urls:   urls,
+		labels: labels,
+		client: &http.Client{Timeout: postTimeout},
+	}
+}
+
+// Notifier posts alerts to webhook URLs.
+type Notifier struct {
+	urls   []string
+	labels map[string]string
+	client *http.Client
+}
+
+// Notify asynchronously posts an alert of the type with the message and details to all webhook URLs.
+// Failures are logged but otherwise ignored.
+func (n *Notifier) Notify(ctx context.Context, typ Type, msg string, details map[string]string) {
+	if len(n.urls) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(Alert{
+		Type:    typ,
+		Message: msg,
+		Time:    time.Now().UTC(),
+		Labels:  n.labels,
+		Details: details,
+	})
+	if err != nil {
+		log.Warn(ctx, "Failed marshalling alert", err, z.Any("type", typ))
+		return
+	}
+
+	// Don't block the caller nor cancel the request when the caller completes.
+	ctx = context.WithoutCancel(ctx)
+
+	// Each webhook is posted to concurrently, so failing or slow webhooks don't block the others.
+	for _, webhook := range n.urls {
+		go func() {
+			if err := n.post(ctx, webhook, b); err != nil {
+				log.Warn(ctx, "Failed posting alert to webhook", err, z.Any("type", typ), z.Str("webhook", webhookHost(webhook)))
+			}
+		}()
+	}
+}
+
+// post posts the JSON payload to the webhook URL.
+func (n *Notifier) post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post alert")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("webhook responded with non-2xx status", z.Int("status_code", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// webhookHost returns the host of the webhook URL, since its path and query often contain secrets.
+func webhookHost(webhook string) string {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return "invalid"
+	}
+
+	return u.Host
+}
@@ -0,0 +1,107 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package alert_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/alert"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+func TestNotify(t *testing.T) {
+	received := make(chan alert.Alert, 2)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var a alert.Alert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&a))
+
+		received <- a
+	}))
+	defer srv.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	// A hanging webhook doesn't block the others.
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer hanging.Close()
+	defer close(release)
+
+	buf := new(syncBuffer)
+	log.InitLogfmtForT(t, buf)
+
+	labels := map[string]string{"cluster_name": "test"}
+	details := map[string]string{"pubkey": "0x1234"}
+
+	n := alert.New([]string{hanging.URL, failing.URL, srv.URL, srv.URL}, labels)
+	n.Notify(t.Context(), alert.TypeValidatorSlashed, "Validator slashed", details)
+
+	for range 2 {
+		var a alert.Alert
+		select {
+		case a = <-received:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "alert not received")
+		}
+
+		require.Equal(t, alert.TypeValidatorSlashed, a.Type)
+		require.Equal(t, "Validator slashed", a.Message)
+		require.Equal(t, labels, a.Labels)
+		require.Equal(t, details, a.Details)
+		require.False(t, a.Time.IsZero())
+	}
+
+	// The failing webhook is logged, identified by its host only.
+	failingURL, err := url.Parse(failing.URL)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "Failed posting alert to webhook") && strings.Contains(buf.String(), failingURL.Host)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Alerts are dropped without webhook URLs.
+	alert.New(nil, labels).Notify(t.Context(), alert.TypeQuorumLost, "Quorum lost", nil)
+}
+
+// syncBuffer is a goroutine-safe log writer since alerts are posted asynchronously.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Sync() error {
+	return nil
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
@@ -5,13 +5,19 @@
 package app
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -19,13 +25,15 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/obolnetwork/charon/app/alert"
+	"github.com/obolnetwork/charon/app/builderapi"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -33,12 +41,16 @@ import (
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/peerbackup"
 	"github.com/obolnetwork/charon/app/peerinfo"
 	"github.com/obolnetwork/charon/app/privkeylock"
+	"github.com/obolnetwork/charon/app/profiling"
 	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/app/retry"
+	"github.com/obolnetwork/charon/app/snapshot"
 	"github.com/obolnetwork/charon/app/sse"
 	"github.com/obolnetwork/charon/app/stacksnipe"
+	"github.com/obolnetwork/charon/app/telemetry"
 	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
@@ -51,22 +63,28 @@ import (
 	"github.com/obolnetwork/charon/core/consensus"
 	"github.com/obolnetwork/charon/core/consensus/protocols"
 	"github.com/obolnetwork/charon/core/consensus/qbft"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/core/fallback"
 	"github.com/obolnetwork/charon/core/fetcher"
 	"github.com/obolnetwork/charon/core/infosync"
 	"github.com/obolnetwork/charon/core/parsigdb"
 	"github.com/obolnetwork/charon/core/parsigex"
 	"github.com/obolnetwork/charon/core/priority"
+	"github.com/obolnetwork/charon/core/rehearsal"
+	"github.com/obolnetwork/charon/core/replay"
 	"github.com/obolnetwork/charon/core/scheduler"
 	"github.com/obolnetwork/charon/core/sigagg"
 	"github.com/obolnetwork/charon/core/tracker"
 	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
-	"github.com/obolnetwork/charon/testutil/beaconmock" // Allow testutil
+	"github.com/obolnetwork/charon/testutil/beaconmock"    // Allow testutil
+	"github.com/obolnetwork/charon/testutil/validatormock" // Allow testutil
 )
 
 type Config struct {
@@ -80,6 +98,10 @@ type Config struct {
 	PrivKeyLocking              bool
 	MonitoringAddr              string
 	DebugAddr                   string
+	DebugPprof                  bool
+	DebugDutyTimeline           bool
+	PprofCaptureDir             string
+	BLSBackend                  string
 	ValidatorAPIAddr            string
 	BeaconNodeAddrs             []string
 	BeaconNodeTimeout           time.Duration
@@ -92,13 +114,20 @@ type Config struct {
 	SimnetVMock                 bool
 	SimnetValidatorKeysDir      string
 	SimnetSlotDuration          time.Duration
+	SimnetSlotsPerEpoch         int
+	SimnetForkEpochs            []string
+	SimnetAllDuties             bool
 	SyntheticBlockProposals     bool
 	BuilderAPI                  bool
 	SimnetBMockFuzz             bool
 	TestnetConfig               eth2util.Network
+	ForceNetwork                bool
+	AttestationDataCrossCheck   bool
+	ProposalGuardFile           string
 	ProcDirectory               string
 	ConsensusProtocol           string
 	Nickname                    string
+	Labels                      []string
 	BeaconNodeHeaders           []string
 	TargetGasLimit              uint
 	FallbackBeaconNodeAddrs     []string
@@ -107,6 +136,53 @@ type Config struct {
 	GraffitiDisableClientAppend bool
 	VCTLSCertFile               string
 	VCTLSKeyFile                string
+	VCProposalTypeOverrides     []string
+	VCAuthTokensFile            string
+	VCMonitoringEndpoints       bool
+	VCMonitoringToken           string
+	MonitoringBasicAuth         string
+	VCConcurrencyLimits         []string
+	VCCORSAllowedOrigins        []string
+	VCCORSAllowedHeaders        []string
+	VCProxyRewritesFile         string
+	VCProxyBreakerFailures      int
+	VCProxyBreakerCooldown      time.Duration
+	VCProxyHedgeDelay           time.Duration
+	VCProxyMaxResponseSize      int64
+	AckSlashedValidators        []string
+	AlertWebhookURLs            []string
+	AlertBeaconNodeDownSlots    int
+	TelemetryEndpoint           string
+	AggregationNodes            int
+	OvercollectSignatures       int
+	OvercollectTimeout          time.Duration
+	AttestationTiming           string
+	SyncMessageFallbackKeysDir  string
+	SyncMessageFallbackDelay    time.Duration
+	AttestationFallbackKeysDir  string
+	AttestationFallbackDelay    time.Duration
+	ProposalRehearsalInterval   time.Duration
+	ProposalRehearsalKeysDir    string
+	ClockSkewThreshold          float64
+	MinPeerVersion              string
+	SandboxUser                 string
+	SandboxChroot               string
+	VerifyKeyPermissions        bool
+	PeerBackupDir               string
+	SlotOffsets                 []string
+	TrackerBackfillEpochs       uint64
+	BuilderRejectHeaderMismatch bool
+	BuilderMinBid               float64
+	BuilderRelayAddrs           []string
+	GasLimitRamp                []string
+	StartupWaitBeaconNode       bool
+	StartupWaitPeers            bool
+	StartupWaitTimeout          time.Duration
+	ShutdownDrainTimeout        time.Duration
+	ReplayRecordFile            string
+	ProxyRecordFile             string
+	ProposalRewardsFile         string
+	EpochWorkSpreadSlots        int
 
 	TestConfig TestConfig
 }
@@ -125,6 +201,8 @@ type TestConfig struct {
 	SimnetKeys []tbls.PrivateKey
 	// SimnetBMockOpts defines additional simnet beacon mock options.
 	SimnetBMockOpts []beaconmock.Option
+	// SimnetVMockOpts defines additional simnet validator mock options.
+	SimnetVMockOpts []validatormock.Option
 	// BroadcastCallback is called when a duty is completed and sent to the broadcast component.
 	BroadcastCallback func(context.Context, core.Duty, core.SignedDataSet) error
 	// PrioritiseCallback is called with priority protocol results.
@@ -136,6 +214,9 @@ type TestConfig struct {
 	// P2PFuzz enables peer to peer fuzzing of charon nodes in a cluster.
 	// If enabled, this node will send fuzzed data over p2p to its peers in the cluster.
 	P2PFuzz bool
+	// P2PChaos enables chaos testing hooks injecting latency, drops and reordering into p2p messages
+	// sent to peers, controlled at runtime via the debug API.
+	P2PChaos bool
 }
 
 // Run is the entrypoint for running a charon DVC instance.
@@ -152,11 +233,56 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	version.LogInfo(ctx, "Charon starting")
 
+	if conf.BLSBackend != "" {
+		if err := tbls.SetBackend(conf.BLSBackend); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "BLS backend selected", z.Str("backend", conf.BLSBackend))
+	}
+
+	privKeyLockFile := conf.PrivKeyFile + ".lock"
+	if conf.SandboxChroot != "" {
+		if err := sandboxPaths(&conf, &privKeyLockFile); err != nil {
+			return err
+		}
+
+		// Relative paths resolve to the same files before and after changing the root directory.
+		if err := os.Chdir(conf.SandboxChroot); err != nil {
+			return errors.Wrap(err, "chdir to sandbox chroot")
+		}
+	}
+
+	var sUser *sandboxUser
+	if conf.SandboxUser != "" {
+		u, err := resolveSandboxUser(conf.SandboxUser)
+		if err != nil {
+			return err
+		}
+
+		sUser = &u
+	}
+
+	if conf.VerifyKeyPermissions {
+		ownerUID := os.Geteuid()
+		if sUser != nil {
+			ownerUID = sUser.UID
+		}
+
+		if err := verifyKeyPermissions(keyPaths(conf), ownerUID); err != nil {
+			return err
+		}
+	}
+
+	// Delay stopping the core workflow on shutdown until in-flight duties are drained.
+	drain := newShutdownDrain(conf.ShutdownDrainTimeout)
+	ctx = drain.WorkflowContext(ctx)
+
 	// Wire processes and their dependencies
 	life := new(lifecycle.Manager)
 
 	if conf.PrivKeyLocking {
-		lockSvc, err := privkeylock.New(conf.PrivKeyFile+".lock", "charon run")
+		lockSvc, err := privkeylock.New(privKeyLockFile, "charon run")
 		if err != nil {
 			return err
 		}
@@ -168,6 +294,9 @@ func Run(ctx context.Context, conf Config) (err error) {
 	stackSniper := stacksnipe.New(conf.ProcDirectory, stackComponents)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartStackSnipe, lifecycle.HookFuncCtx(stackSniper.Run))
 
+	// Toggle debug logging on SIGUSR1, to capture debug logs of production nodes without restarting them.
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartLogLevelSignal, lifecycle.HookFuncCtx(log.ToggleDebugOnSignal))
+
 	if conf.TestnetConfig.IsNonZero() {
 		eth2util.AddTestNetwork(conf.TestnetConfig)
 	}
@@ -239,6 +368,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 	log.Info(ctx, "Lock file loaded",
 		z.Str("peer_name", p2p.PeerName(tcpNode.ID())),
 		z.Str("nickname", conf.Nickname),
+		z.Any("labels", conf.Labels),
 		z.Int("peer_index", nodeIdx.PeerIdx),
 		z.Str("cluster_name", cluster.GetName()),
 		z.Str("cluster_hash", lockHashHex),
@@ -255,8 +385,17 @@ func Run(ctx context.Context, conf Config) (err error) {
 		"cluster_network": network,
 		"charon_version":  version.Version.String(),
 	}
+
+	nodeLabels, err := parseNodeLabels(conf.Labels, labels)
+	if err != nil {
+		return err
+	}
+
+	maps.Copy(labels, nodeLabels)
 	log.SetLokiLabels(labels)
 
+	alerts := alert.New(conf.AlertWebhookURLs, labels)
+
 	promRegistry, err := promauto.NewRegistry(labels)
 	if err != nil {
 		return err
@@ -264,16 +403,39 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	initStartupMetrics(p2p.PeerName(tcpNode.ID()), int(cluster.GetThreshold()), len(cluster.GetOperators()), len(cluster.GetValidators()), network)
 
+	reporter := telemetry.New(conf.TelemetryEndpoint, telemetry.Info{
+		Version:       version.Version.String(),
+		Network:       network,
+		NumOperators:  len(cluster.GetOperators()),
+		NumValidators: len(cluster.GetValidators()),
+	}, promRegistry)
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTelemetry, lifecycle.HookFuncCtx(reporter.Run))
+
 	eth2Cl, subEth2Cl, err := newETH2Client(ctx, conf, life, cluster, cluster.GetForkVersion(), conf.BeaconNodeTimeout, conf.BeaconNodeSubmitTimeout)
 	if err != nil {
 		return err
 	}
 
+	if err := validateClusterOnChain(ctx, eth2Cl, cluster); err != nil {
+		return err
+	}
+
 	sseListener, err := sse.StartListener(ctx, eth2Cl, conf.BeaconNodeAddrs, conf.BeaconNodeHeaders)
 	if err != nil {
 		return err
 	}
 
+	// Serve recent beacon block headers and roots by slot from a cache fed by head events and invalidated on reorgs.
+	_, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	headerCache := eth2wrap.NewHeaderCache(slotsPerEpoch, eth2wrap.DefaultHeaderCacheSize)
+	sseListener.SubscribeHeadBlockEvent(headerCache.HeadReceived)
+	sseListener.SubscribeChainReorgEvent(headerCache.ChainReorg)
+	eth2Cl = eth2wrap.WithHeaderCache(eth2Cl, headerCache)
+
 	peerIDs, err := manifest.ClusterPeerIDs(cluster)
 	if err != nil {
 		return err
@@ -284,13 +446,29 @@ func Run(ctx context.Context, conf Config) (err error) {
 		p2p.SetFuzzerDefaultsUnsafe()
 	}
 
+	// Enable p2p chaos testing hooks controlled via the debug API if --p2p-chaos is set.
+	var chaos *p2p.Chaos
+	if conf.TestConfig.P2PChaos {
+		chaos = p2p.NewChaos(peerIDs)
+		p2p.SetChaosUnsafe(chaos)
+	}
+
 	sender := new(p2p.Sender)
 
 	if len(conf.Nickname) > 32 {
 		return errors.New("nickname can not exceed 32 characters")
 	}
 
-	wirePeerInfo(life, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, conf.BuilderAPI, conf.Nickname)
+	peerInfo, err := wirePeerInfo(ctx, life, conf, tcpNode, peerIDs, cluster.GetInitialMutationHash(), sender, eth2Cl, sseListener, nodeLabels)
+	if err != nil {
+		return err
+	}
+
+	if conf.PeerBackupDir != "" {
+		if err := wirePeerBackup(life, conf, tcpNode, peerIDs, p2pKey); err != nil {
+			return err
+		}
+	}
 
 	// seenPubkeys channel to send seen public keys from validatorapi to monitoringapi.
 	seenPubkeys := make(chan core.PubKey)
@@ -314,26 +492,158 @@ func Run(ctx context.Context, conf Config) (err error) {
 		return err
 	}
 
-	consensusDebugger := consensus.NewDebugger()
+	scoreboard := tracker.NewScoreboard(peers)
+	consensusDebugger := scoringDebugger{Debugger: consensus.NewDebugger(), scoreboard: scoreboard}
+	summarizer := newClusterSummarizer(eth2Cl)
+	mismatchedShares := validatorapi.NewMismatchedShares()
+	lastSeen := validatorapi.NewLastSeen(pubkeys)
+	pendingDuties := dutydb.NewPending()
 
-	wireMonitoringAPI(ctx, life, conf.MonitoringAddr, conf.DebugAddr, tcpNode, eth2Cl, peerIDs,
-		promRegistry, consensusDebugger, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()))
+	snapshots := snapshot.NewHandler()
+
+	beaconNodes, err := newBeaconNodesHandler(conf, cluster.GetForkVersion())
+	if err != nil {
+		return err
+	}
+
+	gate := newStartupGate(conf.StartupWaitBeaconNode, conf.StartupWaitPeers, conf.StartupWaitTimeout,
+		eth2Cl, tcpNode, peerIDs, clockwork.NewRealClock())
+
+	proposalRewards, err := newProposalRewards(ctx, conf, cluster, eth2Cl, eth1Cl)
+	if err != nil {
+		return err
+	}
+
+	var proposalRewardsHandler http.Handler
+	if proposalRewards != nil {
+		proposalRewardsHandler = proposalRewards
+	}
+
+	pipelineReady, err := wireMonitoringAPI(ctx, life, conf.MonitoringAddr, conf.DebugAddr, conf.MonitoringBasicAuth, conf.DebugPprof, sandboxed(conf), tcpNode, eth2Cl, peerIDs,
+		promRegistry, consensusDebugger, summarizer, qbft.NewLeaderHandler(peers), scoreboard, mismatchedShares, lastSeen, pendingDuties, p2p.NewNodeInfoHandler(tcpNode, p2pKey), peerInfo, snapshots, beaconNodes, proposalRewardsHandler, pubkeys, seenPubkeys, vapiCalls, len(cluster.GetValidators()), gate, chaos, alerts, conf.AlertBeaconNodeDownSlots)
+	if err != nil {
+		return err
+	}
+
+	var vapiMonitoring http.Handler
+	if conf.VCMonitoringEndpoints {
+		vapiMonitoring = newVAPIMonitoringHandler(promRegistry, conf.VCMonitoringToken)
+	}
 
 	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl, subEth2Cl,
-		peerIDs, sender, consensusDebugger, pubkeys, seenPubkeysFunc, sseListener, vapiCallsFunc)
+		peerIDs, sender, consensusDebugger, summarizer, scoreboard, mismatchedShares, lastSeen, pendingDuties, pubkeys, seenPubkeysFunc, sseListener, vapiCallsFunc, gate, drain, snapshots, alerts, proposalRewards, pipelineReady, vapiMonitoring, headerCache)
 	if err != nil {
 		return err
 	}
 
+	// Drop privileges after all ports are bound and keys are loaded.
+	if sandboxed(conf) {
+		if err := dropPrivileges(ctx, conf.SandboxChroot, sUser); err != nil {
+			return err
+		}
+	}
+
 	// Run life cycle manager
 	return life.Run(ctx)
 }
 
-// wirePeerInfo wires the peerinfo protocol.
-func wirePeerInfo(life *lifecycle.Manager, tcpNode host.Host, peers []peer.ID, lockHash []byte, sender *p2p.Sender, builderEnabled bool, nickname string) {
+// wirePeerInfo wires the peerinfo protocol, including peer and beacon node clock skew detection,
+// optional protocol feature negotiation and configuration consistency checks.
+func wirePeerInfo(ctx context.Context, life *lifecycle.Manager, conf Config, tcpNode host.Host, peers []peer.ID, lockHash []byte,
+	sender *p2p.Sender, eth2Cl eth2wrap.Client, sseListener sse.Listener,
+	labels map[string]string,
+) (*peerinfo.PeerInfo, error) {
+	genesisTime, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	var otherPeers []string
+	for _, p := range peers {
+		if p != tcpNode.ID() {
+			otherPeers = append(otherPeers, p2p.PeerName(p))
+		}
+	}
+
+	var negotiatorOpts []featureset.NegotiatorOption
+	if conf.MinPeerVersion != "" {
+		minVersion, err := version.Parse(conf.MinPeerVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse min peer version")
+		}
+
+		negotiatorOpts = append(negotiatorOpts, featureset.WithMinPeerVersion(minVersion))
+	}
+
+	negotiator := featureset.NewNegotiator(otherPeers, cluster.Threshold(len(peers)), negotiatorOpts...)
+	featureset.RegisterNegotiator(negotiator)
+
 	gitHash, _ := version.GitCommit()
-	peerInfo := peerinfo.New(tcpNode, peers, version.Version, lockHash, gitHash, sender.SendReceive, builderEnabled, nickname)
+	peerInfo := peerinfo.New(tcpNode, peers, version.Version, lockHash, gitHash, sender.SendReceive, conf.BuilderAPI, conf.Nickname,
+		peerinfo.WithClockSkewThreshold(time.Duration(conf.ClockSkewThreshold*float64(slotDuration))),
+		peerinfo.WithBeaconSlotClock(genesisTime, slotDuration),
+		peerinfo.WithFeatureNegotiator(negotiator),
+		peerinfo.WithConfigHashes(peerConfigHashes(ctx, conf, eth2Cl)),
+		peerinfo.WithLabels(labels),
+	)
+	sseListener.SubscribeHeadEvent(peerInfo.HeadReceived)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(peerInfo.Run))
+
+	return peerInfo, nil
+}
+
+// wirePeerBackup wires the peer backup protocol, storing encrypted backups of peers' cluster artifacts
+// and backing up this node's cluster artifacts to peers.
+func wirePeerBackup(life *lifecycle.Manager, conf Config, tcpNode host.Host, peers []peer.ID, p2pKey *k1.PrivateKey) error {
+	depositDataFiles, err := filepath.Glob(filepath.Join(filepath.Dir(conf.LockFile), "deposit-data*.json"))
+	if err != nil {
+		return errors.Wrap(err, "glob deposit data files")
+	}
+
+	artifactPaths := append([]string{conf.LockFile, conf.ManifestFile}, depositDataFiles...)
+
+	peerBackup, err := peerbackup.New(tcpNode, peers, p2pKey, artifactPaths, conf.PeerBackupDir)
+	if err != nil {
+		return err
+	}
+
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerBackup, lifecycle.HookFuncCtx(peerBackup.Run))
+
+	return nil
+}
+
+// peerConfigHashes returns the hashes of the non-sensitive configuration compared with peers.
+// Only node-local configuration is compared, cluster-wide configuration from the lock is identical by construction.
+func peerConfigHashes(ctx context.Context, conf Config, eth2Cl eth2wrap.Client) map[string][]byte {
+	resp := map[string][]byte{
+		peerinfo.ConfigVersion:  peerinfo.ConfigHash(version.Version.String()),
+		peerinfo.ConfigGasLimit: peerinfo.ConfigHash(conf.GasLimitRamp...),
+		peerinfo.ConfigBuilder: peerinfo.ConfigHash(
+			strconv.FormatBool(conf.BuilderAPI),
+			strconv.FormatFloat(conf.BuilderMinBid, 'f', -1, 64),
+			strconv.FormatBool(conf.BuilderRejectHeaderMismatch),
+		),
+	}
+
+	forks, err := eth2Cl.ForkSchedule(ctx, &eth2api.ForkScheduleOpts{})
+	if err != nil {
+		log.Warn(ctx, "Failed fetching fork schedule, not comparing it with peers", err)
+		return resp
+	}
+
+	var schedule []string
+	for _, fork := range forks.Data {
+		schedule = append(schedule, fmt.Sprintf("%#x:%d", fork.CurrentVersion, fork.Epoch))
+	}
+
+	resp[peerinfo.ConfigForkSchedule] = peerinfo.ConfigHash(schedule...)
+
+	return resp
 }
 
 // wireP2P constructs the p2p tcp (libp2p) and udp (discv5) nodes and registers it with the life cycle manager.
@@ -355,10 +665,16 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return nil, err
 	}
 
+	rcmgr, err := p2p.NewResourceManager(peerIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start libp2p TCP node.
 	opts := []libp2p.Option{
 		p2p.WithBandwidthReporter(peerIDs),
-		libp2p.ResourceManager(new(network.NullResourceManager)),
+		libp2p.ResourceManager(rcmgr),
+		libp2p.ConnectionManager(p2p.NewConnManager(peerIDs, relays)),
 	}
 	opts = append(opts, conf.TestConfig.LibP2POpts...)
 
@@ -392,8 +708,11 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	eth2Cl, submissionEth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
-	consensusDebugger consensus.Debugger, pubkeys []core.PubKey, seenPubkeys func(core.PubKey),
-	sseListener sse.Listener, vapiCalls func(),
+	consensusDebugger consensus.Debugger, summarizer *clusterSummarizer, scoreboard *tracker.Scoreboard,
+	mismatchedShares *validatorapi.MismatchedShares, lastSeen *validatorapi.LastSeen, pendingDuties *dutydb.Pending, pubkeys []core.PubKey,
+	seenPubkeys func(core.PubKey), sseListener sse.Listener, vapiCalls func(), gate *startupGate, drain *shutdownDrain, snapshots *snapshot.Handler,
+	alerts *alert.Notifier, proposalRewards *tracker.Rewards, pipelineReady func() bool, vapiMonitoring http.Handler,
+	headerCache *eth2wrap.HeaderCache,
 ) error {
 	// Convert and prep public keys and public shares
 	var (
@@ -457,7 +776,21 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return core.NewDeadliner(ctx, label, deadlineFunc)
 	}
 
-	sched, err := scheduler.New(corePubkeys, eth2Cl, conf.BuilderAPI)
+	slotOffsets, err := scheduler.ParseSlotOffsets(conf.SlotOffsets)
+	if err != nil {
+		return err
+	}
+
+	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	if err := scheduler.VerifySlotOffsets(slotOffsets, slotDuration); err != nil {
+		return err
+	}
+
+	sched, err := scheduler.New(corePubkeys, eth2Cl, conf.BuilderAPI, scheduler.WithSlotOffsets(slotOffsets))
 	if err != nil {
 		return err
 	}
@@ -467,11 +800,15 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	feeRecipientFunc := func(pubkey core.PubKey) string {
 		return feeRecipientAddrByCorePubkey[pubkey]
 	}
-	sched.SubscribeSlots(setFeeRecipient(eth2Cl, feeRecipientFunc))
+	sched.SubscribeSlots(spreadEpochStart(conf.EpochWorkSpreadSlots, setFeeRecipient(eth2Cl, feeRecipientFunc)))
+	sched.SubscribeNewValidators(func(ctx context.Context, _ core.Slot, _ []core.PubKey) error {
+		return submitProposalPreparations(ctx, eth2Cl, feeRecipientFunc)
+	})
 
 	// Setup validator cache, refreshing it every epoch.
 	valCache := eth2wrap.NewValidatorCache(eth2Cl, eth2Pubkeys)
 	eth2Cl.SetValidatorCache(valCache.GetByHead)
+	life.RegisterStop(lifecycle.StopScheduler, lifecycle.HookFuncMin(valCache.Close))
 
 	firstValCacheRefresh := true
 	refreshedBySlot := true
@@ -489,6 +826,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return true
 	}
 
+	// The validator cache refresh isn't spread since the scheduler resolves the epoch's duties from it.
 	sched.SubscribeSlots(func(ctx context.Context, slot core.Slot) error {
 		if !shouldUpdateCache(slot, &fvcrLock) {
 			return nil
@@ -520,9 +858,16 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		refreshedBySlot = refresh
 		firstValCacheRefresh = false
 
+		// Pick up validators activated since the epoch's duties were resolved.
+		if err := sched.ResolveNewValidators(ctx, slot); err != nil {
+			log.Warn(ctx, "Cannot resolve duties of newly active validators", err)
+		}
+
 		return nil
 	})
 
+	sched.SubscribeSlots(spreadEpochStart(conf.EpochWorkSpreadSlots, summarizer.SlotTicked))
+
 	gaterFunc, err := core.NewDutyGater(ctx, eth2Cl)
 	if err != nil {
 		return err
@@ -545,23 +890,59 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 
 	electraSlot := eth2p0.Slot(uint64(forkSchedule[eth2wrap.Electra].Epoch) * slotsPerEpoch)
 
-	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, conf.BuilderAPI, graffitiBuilder, electraSlot)
+	gasLimitRamp, err := newGasLimitRamp(ctx, uint64(cluster.GetTargetGasLimit()), conf.GasLimitRamp)
+	if err != nil {
+		return err
+	}
+
+	var relays *builderapi.Client
+	if len(conf.BuilderRelayAddrs) > 0 {
+		relays, err = builderapi.New(ctx, conf.BuilderRelayAddrs, conf.BeaconNodeSubmitTimeout)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Submitting validator registrations and unblinding proposals via relays directly", z.Int("relays", len(conf.BuilderRelayAddrs)))
+	}
+
+	fetchOpts := []fetcher.Option{fetcher.WithBuilderMinBid(conf.BuilderMinBid), fetcher.WithHeaderCache(headerCache)}
+	if conf.BuilderAPI {
+		fetchOpts = append(fetchOpts, fetcher.WithBuilderValidation(gasLimitRamp.GasLimit, slotsPerEpoch, conf.BuilderRejectHeaderMismatch))
+	}
+
+	if relays != nil {
+		fetchOpts = append(fetchOpts, fetcher.WithRelayBids(relays))
+	}
+
+	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, conf.BuilderAPI, graffitiBuilder, electraSlot, fetchOpts...)
 	if err != nil {
 		return err
 	}
 
 	dutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
 
+	pendingDuties.SetPubShares(nodePubShares(allPubSharesByKey, nodeIdx.ShareIdx))
+	dutyDB.RegisterPending(pendingDuties)
+
 	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc, conf.BuilderAPI, uint(cluster.GetTargetGasLimit()), seenPubkeys)
 	if err != nil {
 		return err
 	}
 
-	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls, &conf); err != nil {
+	vapi.RegisterMismatchedShares(mismatchedShares)
+	vapi.RegisterLastSeen(lastSeen)
+	vapi.Subscribe(pendingDuties.Submitted)
+
+	if len(conf.GasLimitRamp) > 0 {
+		vapi.RegisterGasLimitRamp(gasLimitRamp)
+	}
+
+	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls, &conf, gate, drain, pipelineReady, vapiMonitoring); err != nil {
 		return err
 	}
 
-	parSigDB := parsigdb.NewMemDB(int(cluster.GetThreshold()), deadlinerFunc("parsigdb"))
+	parSigDB := parsigdb.NewMemDB(int(cluster.GetThreshold()), deadlinerFunc("parsigdb"),
+		parsigdb.WithOvercollection(min(int(cluster.GetThreshold())+conf.OvercollectSignatures, len(cluster.GetOperators())), conf.OvercollectTimeout))
 
 	var parSigEx core.ParSigEx
 	if conf.TestConfig.ParSigExFunc != nil {
@@ -575,25 +956,64 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		parSigEx = parsigex.NewParSigEx(tcpNode, sender.SendAsync, nodeIdx.PeerIdx, peerIDs, verifyFunc, gaterFunc)
 	}
 
-	sigAgg, err := sigagg.New(int(cluster.GetThreshold()), sigagg.NewVerifier(eth2Cl))
+	parSigVerifyFunc, err := parsigex.NewEth2Verifier(eth2Cl, allPubSharesByKey)
 	if err != nil {
 		return err
 	}
 
-	var aggSigDB core.AggSigDB
+	peerNames := make(map[int]string)
+	for _, p := range peers {
+		peerNames[p.ShareIdx()] = p.Name
+	}
+
+	sigAgg, err := sigagg.New(int(cluster.GetThreshold()), sigagg.NewVerifier(eth2Cl),
+		sigagg.WithPartialVerification(parSigVerifyFunc, func(shareIdx int) string { return peerNames[shareIdx] }))
+	if err != nil {
+		return err
+	}
+
+	var (
+		aggSigDB         core.AggSigDB
+		aggSigDBSnapshot func(context.Context) ([]aggsigdb.Entry, error)
+	)
 	if featureset.Enabled(featureset.AggSigDBV2) {
-		aggSigDB = aggsigdb.NewMemDBV2(deadlinerFunc("aggsigdb"))
+		db := aggsigdb.NewMemDBV2(deadlinerFunc("aggsigdb"))
+		aggSigDB, aggSigDBSnapshot = db, db.Snapshot
 	} else {
-		aggSigDB = aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
+		db := aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
+		aggSigDB, aggSigDBSnapshot = db, db.Snapshot
 	}
 
+	snapshots.SetSources(snapshot.Sources{
+		DutyDB:     dutyDB.Snapshot,
+		AggSigDB:   aggSigDBSnapshot,
+		Scheduler:  sched.DutyDefinitions,
+		Validators: valCache.Cached,
+	})
+
 	submissionEth2Cl.SetValidatorCache(valCache.GetByHead)
 
-	broadcaster, err := bcast.New(ctx, submissionEth2Cl)
+	attTiming := bcast.AttestationTimingImmediate
+	if conf.AttestationTiming != "" {
+		attTiming, err = bcast.ParseAttestationTiming(conf.AttestationTiming)
+		if err != nil {
+			return err
+		}
+	}
+
+	bcastOpts := []bcast.Option{bcast.WithAttestationTiming(attTiming)}
+
+	if relays != nil {
+		bcastOpts = append(bcastOpts, bcast.WithRelays(relays))
+	}
+
+	broadcaster, err := bcast.New(ctx, submissionEth2Cl, bcastOpts...)
 	if err != nil {
 		return err
 	}
 
+	sseListener.SubscribeHeadEvent(broadcaster.HeadReceived)
+
 	retryer := retry.New(deadlineFunc)
 
 	// Consensus
@@ -612,34 +1032,113 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	// Priority protocol always uses QBFTv2.
 	err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.GetThreshold()),
 		sender.SendReceive, defaultConsensus, sched, p2pKey, deadlineFunc,
-		consensusController, cluster.GetConsensusProtocol())
+		consensusController, cluster.GetConsensusProtocol(), eth2Cl, fetch, nodeIdx.PeerIdx)
 	if err != nil {
 		return err
 	}
 
 	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.GetValidators(),
-		conf.BuilderAPI, conf.TestConfig.BroadcastCallback); err != nil {
+		conf.BuilderAPI, conf.EpochWorkSpreadSlots, conf.TestConfig.BroadcastCallback); err != nil {
 		return errors.Wrap(err, "wire recaster")
 	}
 
-	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
+	performance := tracker.NewPerformance(slotsPerEpoch)
+
+	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl, scoreboard, performance, alerts, conf.DebugDutyTimeline)
 	if err != nil {
 		return err
 	}
 
-	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked)
+	inclOpts := []tracker.InclusionOption{tracker.WithInclusionPerformance(performance), tracker.WithInclusionHeaderCache(headerCache)}
+	if proposalRewards != nil {
+		inclOpts = append(inclOpts, tracker.WithInclusionRewards(proposalRewards))
+	}
+
+	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked, inclOpts...)
+	if err != nil {
+		return err
+	}
+
+	ackedSlashed, err := parsePubKeys(conf.AckSlashedValidators)
 	if err != nil {
 		return err
 	}
 
+	slashingBreaker := newSlashingBreaker(eth2Cl, ackedSlashed, alerts)
+
+	latencyBudget := core.NewLatencyBudget(ctx, deadlinerFunc("latency_budget"), core.DefaultLatencyBudgets(slotDuration))
+	if conf.PprofCaptureDir != "" {
+		if err := wireProfileCapture(conf.PprofCaptureDir, latencyBudget); err != nil {
+			return err
+		}
+	}
+
 	// Core always uses the "current" consensus that is changed dynamically.
 	opts := []core.WireOption{
 		core.WithTracing(),
 		core.WithTracking(track, inclusion),
+		core.WithLatencyBudget(latencyBudget),
+	}
+
+	if conf.ReplayRecordFile != "" {
+		recorder, err := replay.NewFileRecorder(conf.ReplayRecordFile)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Recording core workflow inputs for replay", z.Str("file", conf.ReplayRecordFile))
+
+		life.RegisterStop(lifecycle.StopReplayRecorder, lifecycle.HookFuncErr(recorder.Close))
+		opts = append(opts, core.WithRecorder(recorder))
+	}
+
+	if drain.Enabled() {
+		drainer := core.NewDrainer(ctx, deadlinerFunc("drainer"))
+		drain.SetDrainer(drainer)
+		opts = append(opts, core.WithDrainer(drainer))
+	}
+
+	// Queues are applied before the async retryer, bounding the retried component calls, shed duties aren't retried.
+	opts = append(opts,
+		core.WithQueues(core.NewQueues(deadlineFunc)),
 		core.WithAsyncRetry(retryer),
+		core.WithSlashingBreaker(slashingBreaker),
+	)
+
+	if conf.ProposalGuardFile != "" {
+		guard, err := core.NewProposalGuard(conf.ProposalGuardFile)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, core.WithProposalGuard(guard))
 	}
+
 	core.Wire(sched, fetch, coreConsensus, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
 
+	if conf.SyncMessageFallbackKeysDir != "" {
+		err = wireSyncMessageFallback(conf, eth2Cl, nodeIdx.ShareIdx, allPubSharesByKey, sched, vapi, parSigDB, sseListener)
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.AttestationFallbackKeysDir != "" {
+		err = wireAttesterFallback(conf, eth2Cl, nodeIdx.ShareIdx, allPubSharesByKey, sched, vapi, dutyDB,
+			parSigDB, slashingBreaker, electraSlot)
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.ProposalRehearsalInterval > 0 {
+		err = wireProposalRehearsal(conf, eth2Cl, nodeIdx.ShareIdx, allPubSharesByKey, sched, coreConsensus,
+			deadlineFunc, slotDuration)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = wireValidatorMock(ctx, conf, eth2Cl, pubshares, sched)
 	if err != nil {
 		return err
@@ -654,6 +1153,7 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartAggSigDB, lifecycle.HookFuncCtx(aggSigDB.Run))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartParSigDB, lifecycle.HookFuncCtx(parSigDB.Trim))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTracker, lifecycle.HookFuncCtx(inclusion.Run))
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTracker, lifecycle.HookFuncCtx(tracker.NewBackfiller(eth2Cl, conf.TrackerBackfillEpochs)))
 	life.RegisterStop(lifecycle.StopScheduler, lifecycle.HookFuncMin(sched.Stop))
 	life.RegisterStop(lifecycle.StopDutyDB, lifecycle.HookFuncMin(dutyDB.Shutdown))
 	life.RegisterStop(lifecycle.StopRetryer, lifecycle.HookFuncCtx(retryer.Shutdown))
@@ -666,6 +1166,7 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	peers []peer.ID, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
 	sched core.Scheduler, p2pKey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
 	consensusController core.ConsensusController, clusterPreferredProtocol string,
+	eth2Cl eth2wrap.Client, fetch *fetcher.Fetcher, peerIdx int,
 ) error {
 	cons, ok := coreCons.(*qbft.Consensus)
 	if !ok {
@@ -697,11 +1198,21 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 		allProtocols = protocols.PrioritizeProtocolsByName(conf.ConsensusProtocol, allProtocols)
 	}
 
+	var isyncOpts []infosync.Option
+	if conf.AggregationNodes > 0 && conf.AggregationNodes < len(peers) {
+		isyncOpts = append(isyncOpts, infosync.WithAggregatorSelection(peerIdx, len(peers), conf.AggregationNodes, beaconNodeLatency(eth2Cl)))
+	}
+
 	isync := infosync.New(prio,
 		version.Supported(),
 		allProtocols,
 		ProposalTypes(conf.BuilderAPI, conf.SyntheticBlockProposals),
+		isyncOpts...,
 	)
+	// The first round consensus leader always fetches, since it must propose aggregate attestations for the duty to complete.
+	fetch.RegisterAggregatorSelection(func(slot uint64) bool {
+		return isync.Aggregator(slot) || qbft.LeaderIndex(core.NewAggregatorDuty(slot), 1, len(peers)) == peerIdx
+	})
 
 	// Trigger info syncs in last slot of the epoch (for the next epoch).
 	sched.SubscribeSlots(func(ctx context.Context, slot core.Slot) error {
@@ -741,10 +1252,26 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	return nil
 }
 
+// beaconNodeLatency returns a function that measures the beacon node latency, returning an error if it is syncing or down.
+func beaconNodeLatency(eth2Cl eth2wrap.Client) func(context.Context) (time.Duration, error) {
+	return func(ctx context.Context) (time.Duration, error) {
+		t0 := time.Now()
+
+		syncing, _, err := beaconNodeSyncing(ctx, eth2Cl)
+		if err != nil {
+			return 0, err
+		} else if syncing {
+			return 0, errReadyBeaconNodeSyncing
+		}
+
+		return time.Since(t0), nil
+	}
+}
+
 // wireRecaster wires the rebroadcaster component to scheduler, sigAgg and broadcaster.
 // This is not done in core.Wire since recaster isn't really part of the official core workflow (yet).
-func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
-	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool,
+func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched *scheduler.Scheduler, sigAgg core.SigAgg,
+	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool, epochSpreadSlots int,
 	callback func(context.Context, core.Duty, core.SignedDataSet) error,
 ) error {
 	recaster, err := bcast.NewRecaster(func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
@@ -765,7 +1292,8 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 		return errors.Wrap(err, "recaster init")
 	}
 
-	sched.SubscribeSlots(recaster.SlotTicked)
+	sched.SubscribeSlots(spreadEpochStart(epochSpreadSlots, recaster.SlotTicked))
+	sched.SubscribeNewValidators(recaster.NewValidators)
 	sigAgg.Subscribe(recaster.Store)
 	recaster.Subscribe(broadcaster.Broadcast)
 
@@ -811,9 +1339,100 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 	return nil
 }
 
+// wireSyncMessageFallback wires the sync committee message fallback producing and partially signing sync committee
+// messages with this node's key shares if validator clients do not submit them in time.
+// This is not done in core.Wire since the fallback is optional and requires key shares.
+func wireSyncMessageFallback(conf Config, eth2Cl eth2wrap.Client, shareIdx int,
+	allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey, sched *scheduler.Scheduler,
+	vapi *validatorapi.Component, parSigDB core.ParSigDB, sseListener sse.Listener,
+) error {
+	secrets, err := fallback.LoadSecrets(conf.SyncMessageFallbackKeysDir, nodePubShares(allPubSharesByKey, shareIdx))
+	if err != nil {
+		return errors.Wrap(err, "load sync message fallback key shares")
+	}
+
+	syncMsgFallback := fallback.NewSyncMessage(eth2Cl, shareIdx, secrets, sched.GetDutyDefinition, conf.SyncMessageFallbackDelay)
+
+	vapi.Subscribe(syncMsgFallback.Submitted)
+	sseListener.SubscribeHeadBlockEvent(syncMsgFallback.HeadReceived)
+	sched.SubscribeSlots(syncMsgFallback.HandleSlot)
+	syncMsgFallback.Subscribe(parSigDB.StoreInternal)
+
+	return nil
+}
+
+// wireAttesterFallback wires the failsafe attester producing and partially signing attestations with this node's
+// key shares from the cluster's decided attestation data if validator clients do not submit them in time.
+// This is not done in core.Wire since the fallback is optional and requires key shares.
+func wireAttesterFallback(conf Config, eth2Cl eth2wrap.Client, shareIdx int,
+	allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey, sched *scheduler.Scheduler,
+	vapi *validatorapi.Component, dutyDB core.DutyDB, parSigDB core.ParSigDB,
+	slashingBreaker func(context.Context, core.PubKey) bool, electraSlot eth2p0.Slot,
+) error {
+	secrets, err := fallback.LoadSecrets(conf.AttestationFallbackKeysDir, nodePubShares(allPubSharesByKey, shareIdx))
+	if err != nil {
+		return errors.Wrap(err, "load attestation fallback key shares")
+	}
+
+	attFallback := fallback.NewAttester(eth2Cl, shareIdx, secrets, sched.GetDutyDefinition, dutyDB.AwaitAttestation,
+		slashingBreaker, electraSlot, conf.AttestationFallbackDelay)
+
+	vapi.Subscribe(attFallback.Submitted)
+	sched.SubscribeSlots(attFallback.HandleSlot)
+	attFallback.Subscribe(parSigDB.StoreInternal)
+
+	return nil
+}
+
+// wireProposalRehearsal wires the opt-in proposal rehearsal periodically producing, deciding and optionally
+// partially signing a block proposal of the cluster's first validator that is discarded, never broadcast.
+// This is not done in core.Wire since the rehearsal is optional.
+func wireProposalRehearsal(conf Config, eth2Cl eth2wrap.Client, shareIdx int,
+	allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey, sched *scheduler.Scheduler,
+	cons core.Consensus, deadlineFunc func(core.Duty) (time.Time, bool), slotDuration time.Duration,
+) error {
+	pubShares := nodePubShares(allPubSharesByKey, shareIdx)
+	if len(pubShares) == 0 {
+		return errors.New("proposal rehearsal requires validators")
+	}
+
+	pubkeys := slices.Sorted(maps.Keys(pubShares))
+	pubkey := pubkeys[0]
+
+	var secret *tbls.PrivateKey
+	if conf.ProposalRehearsalKeysDir != "" {
+		secrets, err := fallback.LoadSecrets(conf.ProposalRehearsalKeysDir, map[core.PubKey]tbls.PublicKey{pubkey: pubShares[pubkey]})
+		if err != nil {
+			return errors.Wrap(err, "load proposal rehearsal key share")
+		}
+
+		s := secrets[pubkey]
+		secret = &s
+	}
+
+	interval := uint64(conf.ProposalRehearsalInterval / slotDuration)
+	reh := rehearsal.New(eth2Cl, interval, pubkey, pubShares[pubkey], secret, sched.GetDutyDefinition, cons.Propose, deadlineFunc)
+
+	cons.Subscribe(reh.Decided)
+	sched.SubscribeSlots(reh.HandleSlot)
+
+	return nil
+}
+
+// nodePubShares returns this node's public key shares by validator public key.
+func nodePubShares(allPubSharesByKey map[core.PubKey]map[int]tbls.PublicKey, shareIdx int) map[core.PubKey]tbls.PublicKey {
+	resp := make(map[core.PubKey]tbls.PublicKey)
+	for pubkey, shares := range allPubSharesByKey {
+		resp[pubkey] = shares[shareIdx]
+	}
+
+	return resp
+}
+
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	peers []p2p.Peer, eth2Cl eth2wrap.Client,
+	peers []p2p.Peer, eth2Cl eth2wrap.Client, scoreboard *tracker.Scoreboard, performance *tracker.Performance,
+	alerts *alert.Notifier, dutyTimeline bool,
 ) (core.Tracker, error) {
 	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
 	if err != nil {
@@ -837,12 +1456,35 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 		return nil, err
 	}
 
-	track := tracker.New(analyser, deleter, peers, trackFrom)
+	opts := []tracker.Option{tracker.WithScoreboard(scoreboard), tracker.WithPerformance(performance), tracker.WithAlerts(alerts)}
+	if dutyTimeline {
+		genesis, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, tracker.WithDutyTimeline(genesis, slotDuration))
+	}
+
+	track := tracker.New(analyser, deleter, peers, trackFrom, opts...)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartTracker, lifecycle.HookFunc(track.Run))
 
 	return track, nil
 }
 
+// scoringDebugger wraps a consensus debugger, also recording peer consensus participation in the scoreboard.
+type scoringDebugger struct {
+	consensus.Debugger
+
+	scoreboard *tracker.Scoreboard
+}
+
+// AddInstance adds the sniffed consensus instance to the debugger and the scoreboard.
+func (d scoringDebugger) AddInstance(instance *pbv1.SniffedConsensusInstance) {
+	d.Debugger.AddInstance(instance)
+	d.scoreboard.AddConsensusInstance(instance)
+}
+
 // calculateTrackerDelay returns the slot to start tracking from. This mitigates noisy failed duties on
 // startup due to downstream VC startup delays.
 func calculateTrackerDelay(ctx context.Context, cl eth2wrap.Client, now time.Time) (uint64, error) {
@@ -897,11 +1539,15 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 		return nil, nil, err
 	}
 
-	// Default to 1s slot duration if not set.
+	// Default to 1s slot duration and 16 slots per epoch if not set.
 	if conf.SimnetSlotDuration == 0 {
 		conf.SimnetSlotDuration = time.Second
 	}
 
+	if conf.SimnetSlotsPerEpoch == 0 {
+		conf.SimnetSlotsPerEpoch = 16
+	}
+
 	if conf.SimnetBMockFuzz {
 		log.Info(ctx, "Beaconmock fuzz configured!")
 
@@ -933,19 +1579,27 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 			return nil, nil, err
 		}
 
+		specOpts, err := simnetSpecOpts(conf.SimnetSlotDuration, conf.SimnetSlotsPerEpoch, conf.SimnetForkEpochs)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		const dutyFactor = 100 // Duty factor spreads duties deterministically in an epoch.
 
-		opts := []beaconmock.Option{
-			beaconmock.WithSlotDuration(conf.SimnetSlotDuration),
+		opts := append(specOpts,
 			beaconmock.WithGenesisTime(genesisTime),
 			beaconmock.WithDeterministicAttesterDuties(dutyFactor),
 			beaconmock.WithDeterministicSyncCommDuties(2, 8), // First 2 epochs of every 8
 			beaconmock.WithValidatorSet(createMockValidators(pubkeys)),
-		}
+		)
 		if !conf.SyntheticBlockProposals { // Only add deterministic proposals if synthetic duties are disabled.
 			opts = append(opts, beaconmock.WithDeterministicProposerDuties(dutyFactor))
 		}
 
+		if conf.SimnetAllDuties {
+			opts = append(opts, simnetAllDutiesOpts()...)
+		}
+
 		opts = append(opts, conf.TestConfig.SimnetBMockOpts...)
 
 		bmock, err := beaconmock.New(opts...)
@@ -989,12 +1643,18 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 		return nil, nil, err
 	}
 
-	eth2Cl, err = configureEth2Client(ctx, forkVersion, conf.FallbackBeaconNodeAddrs, conf.BeaconNodeAddrs, beaconNodeHeaders, bnTimeout, conf.SyntheticBlockProposals)
+	eth2Cl, err = configureEth2Client(ctx, forkVersion, conf.FallbackBeaconNodeAddrs, conf.BeaconNodeAddrs, beaconNodeHeaders, bnTimeout, conf.SyntheticBlockProposals, conf.ForceNetwork)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new eth2 http client")
 	}
 
-	submissionEth2Cl, err = configureEth2Client(ctx, forkVersion, conf.FallbackBeaconNodeAddrs, conf.BeaconNodeAddrs, beaconNodeHeaders, submissionBnTimeout, conf.SyntheticBlockProposals)
+	if conf.AttestationDataCrossCheck {
+		log.Info(ctx, "Attestation data cross-check enabled", z.Int("beacon_nodes", len(conf.BeaconNodeAddrs)))
+
+		eth2Cl = eth2wrap.WithAttestationDataCrossCheck(eth2Cl, eth2wrap.NewHTTPClients(bnTimeout, [4]byte(forkVersion), beaconNodeHeaders, conf.BeaconNodeAddrs))
+	}
+
+	submissionEth2Cl, err = configureEth2Client(ctx, forkVersion, conf.FallbackBeaconNodeAddrs, conf.BeaconNodeAddrs, beaconNodeHeaders, submissionBnTimeout, conf.SyntheticBlockProposals, conf.ForceNetwork)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "new submission eth2 http client")
 	}
@@ -1002,8 +1662,26 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager, cl
 	return eth2Cl, submissionEth2Cl, nil
 }
 
+// newBeaconNodesHandler returns the monitoring handler serving the status of each configured beacon node.
+// It uses dedicated clients per beacon node so its queries aren't included in the observed request stats.
+func newBeaconNodesHandler(conf Config, forkVersion []byte) (http.Handler, error) {
+	if conf.SimnetBMock || conf.SimnetBMockFuzz {
+		return eth2wrap.NewBeaconNodesHandler(nil, nil), nil
+	}
+
+	headers, err := eth2util.ParseBeaconNodeHeaders(conf.BeaconNodeHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	return eth2wrap.NewBeaconNodesHandler(
+		eth2wrap.NewHTTPClients(conf.BeaconNodeTimeout, [4]byte(forkVersion), headers, conf.BeaconNodeAddrs),
+		eth2wrap.NewHTTPClients(conf.BeaconNodeTimeout, [4]byte(forkVersion), headers, conf.FallbackBeaconNodeAddrs),
+	), nil
+}
+
 // configureEth2Client configures a beacon node client with the provided settings.
-func configureEth2Client(ctx context.Context, forkVersion []byte, fallbackAddrs []string, addrs []string, headers map[string]string, timeout time.Duration, syntheticBlockProposals bool) (eth2wrap.Client, error) {
+func configureEth2Client(ctx context.Context, forkVersion []byte, fallbackAddrs []string, addrs []string, headers map[string]string, timeout time.Duration, syntheticBlockProposals, forceNetwork bool) (eth2wrap.Client, error) {
 	eth2Cl, err := eth2wrap.NewMultiHTTP(timeout, [4]byte(forkVersion), headers, addrs, fallbackAddrs)
 	if err != nil {
 		return nil, errors.Wrap(err, "new eth2 http client")
@@ -1014,38 +1692,12 @@ func configureEth2Client(ctx context.Context, forkVersion []byte, fallbackAddrs
 	}
 
 	// Check BN chain/network.
-	eth2Resp, err := eth2Cl.ForkSchedule(ctx, &eth2api.ForkScheduleOpts{})
-	if err != nil {
-		return nil, errors.Wrap(err, "fetch fork schedule")
-	}
-
-	schedule := eth2Resp.Data
-
-	var ok bool
-
-	for _, fork := range schedule {
-		if bytes.Equal(fork.CurrentVersion[:], forkVersion) {
-			ok = true
-			break
-		}
-	}
-
-	if !ok {
-		lockNetwork, err := eth2util.ForkVersionToNetwork(forkVersion)
-		if err != nil {
-			return nil, errors.New("cannot parse lock file fork version")
-		}
-
-		bnNetwork, err := eth2util.ForkVersionToNetwork(schedule[0].CurrentVersion[:])
-		if err != nil {
-			return nil, errors.New("cannot parse network current fork version")
+	if err := eth2wrap.VerifyNetwork(ctx, eth2Cl, forkVersion); err != nil {
+		if !forceNetwork || !errors.Is(err, eth2wrap.ErrNetworkMismatch) {
+			return nil, err
 		}
 
-		return nil, errors.New(
-			"mismatch between lock file fork version and beacon node fork schedule. Ensure the beacon node is on the correct network",
-			z.Str("beacon_node", bnNetwork),
-			z.Str("lock_file", lockNetwork),
-		)
+		log.Warn(ctx, "Ignoring beacon node network mismatch due to --force-network, signatures may be invalid", err)
 	}
 
 	return eth2Cl, nil
@@ -1076,32 +1728,127 @@ func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 }
 
 // wireVAPIRouter constructs the validator API router and registers it with the life cycle manager.
+// The validator API is only served once the optional startup gate is open.
 func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr string, eth2Cl eth2wrap.Client,
-	handler validatorapi.Handler, vapiCalls func(), conf *Config,
+	handler validatorapi.Handler, vapiCalls func(), conf *Config, gate *startupGate, drain *shutdownDrain,
+	pipelineReady func() bool, monitoring http.Handler,
 ) error {
-	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl, conf.BuilderAPI)
+	proposalTypeOverrides, err := validatorapi.ParseProposalTypeOverrides(conf.VCProposalTypeOverrides)
+	if err != nil {
+		return err
+	}
+
+	concurrencyLimits, err := validatorapi.ParseConcurrencyLimits(conf.VCConcurrencyLimits)
+	if err != nil {
+		return err
+	}
+
+	routerOpts := []validatorapi.RouterOption{
+		validatorapi.WithProposalTypeOverrides(proposalTypeOverrides),
+		validatorapi.WithConcurrencyLimits(concurrencyLimits),
+		validatorapi.WithNodeStatus(pipelineReady),
+		validatorapi.WithProxyCircuitBreaker(conf.VCProxyBreakerFailures, conf.VCProxyBreakerCooldown),
+		validatorapi.WithProxyHedging(append(slices.Clone(conf.BeaconNodeAddrs), conf.FallbackBeaconNodeAddrs...), conf.VCProxyHedgeDelay),
+		validatorapi.WithProxyMaxResponseSize(conf.VCProxyMaxResponseSize),
+	}
+
+	if conf.VCAuthTokensFile != "" {
+		tokens, err := validatorapi.LoadVCTokens(conf.VCAuthTokensFile)
+		if err != nil {
+			return err
+		}
+
+		routerOpts = append(routerOpts, validatorapi.WithVCTokens(tokens))
+	}
+
+	if drain != nil && drain.Enabled() {
+		routerOpts = append(routerOpts, validatorapi.WithDraining(drain.Draining))
+	}
+
+	if conf.VCProxyRewritesFile != "" {
+		rewrites, err := validatorapi.LoadProxyRewrites(conf.VCProxyRewritesFile)
+		if err != nil {
+			return err
+		}
+
+		routerOpts = append(routerOpts, validatorapi.WithProxyRewrites(rewrites))
+	}
+
+	if len(conf.VCCORSAllowedOrigins) > 0 {
+		routerOpts = append(routerOpts, validatorapi.WithCORS(validatorapi.CORSConfig{
+			AllowedOrigins: conf.VCCORSAllowedOrigins,
+			AllowedHeaders: conf.VCCORSAllowedHeaders,
+		}))
+	}
+
+	if conf.ProxyRecordFile != "" {
+		recorder, err := validatorapi.NewProxyRecorder(conf.ProxyRecordFile)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Recording proxied beacon node requests", z.Str("file", conf.ProxyRecordFile))
+
+		life.RegisterStop(lifecycle.StopProxyRecorder, lifecycle.HookFuncErr(recorder.Close))
+		routerOpts = append(routerOpts, validatorapi.WithProxyRecorder(recorder))
+	}
+
+	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl, conf.BuilderAPI, routerOpts...)
 	if err != nil {
 		return errors.Wrap(err, "new monitoring server")
 	}
 
+	// With monitoring endpoints, the listener serves them while the startup gate is waiting,
+	// rejecting validator API requests until it opens.
+	var gateOpened atomic.Bool
+	gateMonitoring := gate != nil && monitoring != nil
+
 	server := &http.Server{
 		Addr: vapiAddr,
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Optional monitoring endpoints are path-gated, all other paths are served by the validator API router.
+			if monitoring != nil && slices.Contains(vapiMonitoringPaths, r.URL.Path) {
+				monitoring.ServeHTTP(w, r)
+				return
+			}
+
+			if gateMonitoring && !gateOpened.Load() {
+				writeResponse(w, http.StatusServiceUnavailable, "validator api waiting for startup gate")
+				return
+			}
+
 			vapiCalls()
 			vrouter.ServeHTTP(w, r)
 		}),
 		ReadHeaderTimeout: time.Second,
 	}
 
-	if conf.VCTLSCertFile != "" && conf.VCTLSKeyFile != "" {
-		listenAndServeTLS := func() error {
-			return server.ListenAndServeTLS(conf.VCTLSCertFile, conf.VCTLSKeyFile)
+	// Note that when sandboxed, the listener is bound immediately, so validator client
+	// connections are accepted but not served while the startup gate is waiting.
+	listenAndServe, err := serveFunc(server, sandboxed(*conf), conf.VCTLSCertFile, conf.VCTLSKeyFile)
+	if err != nil {
+		return err
+	}
+
+	serve := listenAndServe
+	if gateMonitoring {
+		serve = func() error {
+			go func() {
+				gate.Wait(ctx)
+				gateOpened.Store(true)
+			}()
+
+			return listenAndServe()
+		}
+	} else if gate != nil {
+		serve = func() error {
+			gate.Wait(ctx)
+			return listenAndServe()
 		}
-		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(listenAndServeTLS))
-	} else {
-		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(server.ListenAndServe))
 	}
 
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(serve))
+
 	life.RegisterStop(lifecycle.StopValidatorAPI, lifecycle.HookFunc(server.Shutdown))
 
 	return nil
@@ -1128,6 +1875,26 @@ func wireTracing(life *lifecycle.Manager, conf Config, clusterHash []byte) error
 	return nil
 }
 
+// newGasLimitRamp returns the gas limit ramp from the cluster target gas limit, defaulting to
+// registration.DefaultGasLimit if the cluster doesn't define a target gas limit and a ramp is configured.
+func newGasLimitRamp(ctx context.Context, clusterTargetGasLimit uint64, rampFlags []string) (registration.GasLimitRamp, error) {
+	if clusterTargetGasLimit == 0 && len(rampFlags) > 0 {
+		clusterTargetGasLimit = registration.DefaultGasLimit
+	}
+
+	ramp, err := registration.ParseGasLimitRamp(clusterTargetGasLimit, rampFlags)
+	if err != nil {
+		return registration.GasLimitRamp{}, err
+	}
+
+	if len(rampFlags) > 0 {
+		log.Info(ctx, "Target gas limit ramp configured",
+			z.U64("from", ramp.From), z.U64("to", ramp.To), z.U64("start_epoch", ramp.StartEpoch), z.U64("epochs", ramp.Epochs))
+	}
+
+	return ramp, nil
+}
+
 // setFeeRecipient returns a slot subscriber for scheduler which calls prepare_beacon_proposer endpoint at start of each epoch.
 func setFeeRecipient(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) func(ctx context.Context, slot core.Slot) error {
 	onStartup := true
@@ -1146,37 +1913,42 @@ func setFeeRecipient(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey)
 
 		osMutex.Unlock()
 
-		vals, err := eth2Cl.ActiveValidators(ctx)
-		if err != nil {
-			return err
-		}
-
-		if len(vals) == 0 {
-			return nil // No active validators.
-		}
+		return submitProposalPreparations(ctx, eth2Cl, feeRecipientFunc)
+	}
+}
 
-		var preps []*eth2v1.ProposalPreparation
+// submitProposalPreparations submits the fee recipients of all active validators to the beacon node.
+func submitProposalPreparations(ctx context.Context, eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) error {
+	vals, err := eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return err
+	}
 
-		for vIdx, pubkey := range vals {
-			feeRecipient := feeRecipientFunc(core.PubKeyFrom48Bytes(pubkey))
+	if len(vals) == 0 {
+		return nil // No active validators.
+	}
 
-			var addr bellatrix.ExecutionAddress
+	var preps []*eth2v1.ProposalPreparation
 
-			b, err := hex.DecodeString(strings.TrimPrefix(feeRecipient, "0x"))
-			if err != nil {
-				return errors.Wrap(err, "hex decode fee recipient address")
-			}
+	for vIdx, pubkey := range vals {
+		feeRecipient := feeRecipientFunc(core.PubKeyFrom48Bytes(pubkey))
 
-			copy(addr[:], b)
+		var addr bellatrix.ExecutionAddress
 
-			preps = append(preps, &eth2v1.ProposalPreparation{
-				ValidatorIndex: vIdx,
-				FeeRecipient:   addr,
-			})
+		b, err := hex.DecodeString(strings.TrimPrefix(feeRecipient, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "hex decode fee recipient address")
 		}
 
-		return eth2Cl.SubmitProposalPreparations(ctx, preps)
+		copy(addr[:], b)
+
+		preps = append(preps, &eth2v1.ProposalPreparation{
+			ValidatorIndex: vIdx,
+			FeeRecipient:   addr,
+		})
 	}
+
+	return eth2Cl.SubmitProposalPreparations(ctx, preps)
 }
 
 // getDVPubkeys returns DV public keys from given cluster.Lock.
@@ -1251,3 +2023,26 @@ func hex7(input []byte) string {
 
 	return resp[:7]
 }
+
+// wireProfileCapture captures heap and CPU profiles into the directory when duties violate their latency budgets.
+func wireProfileCapture(dir string, latencyBudget *core.LatencyBudget) error {
+	capturer, err := profiling.New(dir)
+	if err != nil {
+		return err
+	}
+
+	latencyBudget.Subscribe(func(ctx context.Context, report core.LatencyReport) {
+		if report.Met() {
+			return
+		}
+
+		reason := report.Duty.Type.String()
+		for _, phase := range report.Violated {
+			reason += "_" + string(phase)
+		}
+
+		capturer.Trigger(ctx, reason)
+	})
+
+	return nil
+}
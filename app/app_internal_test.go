@@ -52,7 +52,7 @@ func TestWireVAPIRouterForTLS(t *testing.T) {
 
 	port := testutil.GetFreePort(t)
 	endpoint := fmt.Sprintf("localhost:%v", port)
-	err := wireVAPIRouter(t.Context(), life, endpoint, client, handler, vapiCalls, conf)
+	err := wireVAPIRouter(t.Context(), life, endpoint, client, handler, vapiCalls, conf, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	ctx, cancel := context.WithCancel(t.Context())
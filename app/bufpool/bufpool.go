@@ -0,0 +1,62 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package bufpool provides a pool of reusable byte buffers for frequently decoded and marshalled versioned objects,
+// e.g., attestations and proposals, reducing allocations and GC pressure during epoch boundaries on large clusters.
+//
+// Pooled buffers may only be used for transient bytes not retained after Put,
+// i.e., bytes that are copied when decoded, hashed or written.
+package bufpool
+
+import (
+	"io"
+	"sync"
+)
+
+const (
+	// initialCap is the initial capacity of new buffers, large enough for most attestation submissions.
+	initialCap = 4 << 10
+	// maxCap is the maximum capacity of buffers returned to the pool, avoiding pinning memory of rare large objects.
+	maxCap = 4 << 20
+)
+
+var pool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, initialCap)
+		return &b
+	},
+}
+
+// Get returns an empty buffer from the pool. It must be returned via Put once its bytes are no longer used.
+func Get() *[]byte {
+	b := pool.Get().(*[]byte) //nolint:forcetypeassert // Only byte slices are pooled.
+	*b = (*b)[:0]
+
+	return b
+}
+
+// Put returns the buffer to the pool. Neither the buffer nor its bytes may be used afterwards.
+func Put(b *[]byte) {
+	if b == nil || cap(*b) > maxCap {
+		return
+	}
+
+	pool.Put(b)
+}
+
+// ReadAll reads from r until EOF appending to dst and returns the extended buffer, similar to io.ReadAll.
+func ReadAll(dst []byte, r io.Reader) ([]byte, error) {
+	for {
+		if len(dst) == cap(dst) {
+			dst = append(dst, 0)[:len(dst)] // Let append grow the buffer.
+		}
+
+		n, err := r.Read(dst[len(dst):cap(dst)])
+		dst = dst[:len(dst)+n]
+
+		if err == io.EOF {
+			return dst, nil
+		} else if err != nil {
+			return dst, err //nolint:wrapcheck // Reader errors are wrapped by callers.
+		}
+	}
+}
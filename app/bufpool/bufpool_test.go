@@ -0,0 +1,60 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bufpool_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/bufpool"
+)
+
+func TestReadAll(t *testing.T) {
+	data := bytes.Repeat([]byte("charon"), 10000)
+
+	b := bufpool.Get()
+	require.Empty(t, *b)
+
+	var err error
+	*b, err = bufpool.ReadAll(*b, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, data, *b)
+
+	bufpool.Put(b)
+
+	// Reused buffers are empty.
+	b = bufpool.Get()
+	require.Empty(t, *b)
+	bufpool.Put(b)
+}
+
+// BenchmarkReadAll compares reading a large request body, e.g. a block proposal, via io.ReadAll and pooled buffers.
+func BenchmarkReadAll(b *testing.B) {
+	data := bytes.Repeat([]byte("charon"), 50000)
+
+	b.Run("io.ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, err := io.ReadAll(bytes.NewReader(data))
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("bufpool", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			buf := bufpool.Get()
+
+			var err error
+			*buf, err = bufpool.ReadAll(*buf, bytes.NewReader(data))
+			require.NoError(b, err)
+
+			bufpool.Put(buf)
+		}
+	})
+}
@@ -0,0 +1,371 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package builderapi provides a client talking to MEV-Boost relays directly via the builder API,
+// removing the requirement of running mev-boost sidecars with identically configured relays.
+package builderapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	builderclient "github.com/attestantio/go-builder-client"
+	builderapiv0 "github.com/attestantio/go-builder-client/api"
+	builderapiv1 "github.com/attestantio/go-builder-client/api/v1"
+	builderhttp "github.com/attestantio/go-builder-client/http"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Relay is the subset of the builder API used to talk to a relay.
+type Relay interface {
+	builderclient.BuilderBidProvider
+	builderclient.ValidatorRegistrationsSubmitter
+	builderclient.UnblindedProposalProvider
+}
+
+// New returns a new client of the relays at the provided addresses.
+func New(ctx context.Context, addrs []string, timeout time.Duration) (*Client, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no relay addresses")
+	}
+
+	var relays []Relay
+	for _, addr := range addrs {
+		if _, err := url.ParseRequestURI(addr); err != nil {
+			return nil, errors.Wrap(err, "parse relay address", z.Str("address", redact(addr)))
+		}
+
+		svc, err := builderhttp.New(ctx,
+			builderhttp.WithAddress(addr),
+			builderhttp.WithTimeout(timeout),
+			builderhttp.WithLogLevel(zerolog.InfoLevel),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "new relay client", z.Str("address", redact(addr)))
+		}
+
+		relay, ok := svc.(Relay)
+		if !ok {
+			return nil, errors.New("invalid relay client")
+		}
+
+		relays = append(relays, relay)
+	}
+
+	return NewForT(relays), nil
+}
+
+// servedRetention is the number of slots the relays that served a bid are remembered.
+const servedRetention = 64
+
+// NewForT returns a new client of the provided relays, intended for testing.
+func NewForT(relays []Relay) *Client {
+	return &Client{
+		relays: relays,
+		served: make(map[eth2p0.Hash32]servedBid),
+	}
+}
+
+// Client talks to multiple relays in parallel.
+type Client struct {
+	relays []Relay
+
+	mu     sync.Mutex
+	served map[eth2p0.Hash32]servedBid // Relays that served bids by execution block hash.
+}
+
+// servedBid is a bid's slot and the relays that served it.
+type servedBid struct {
+	slot   eth2p0.Slot
+	relays []Relay
+}
+
+// SubmitValidatorRegistrations submits the threshold-signed validator registrations to all relays.
+// It only returns an error if all relays failed.
+func (c *Client) SubmitValidatorRegistrations(ctx context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error {
+	var regs []*builderapiv0.VersionedSignedValidatorRegistration
+	for _, reg := range registrations {
+		if reg == nil || reg.V1 == nil || reg.V1.Message == nil {
+			return errors.New("invalid validator registration")
+		}
+
+		regs = append(regs, &builderapiv0.VersionedSignedValidatorRegistration{
+			Version: builderspec.BuilderVersion(reg.Version),
+			V1: &builderapiv1.SignedValidatorRegistration{
+				Message: &builderapiv1.ValidatorRegistration{
+					FeeRecipient: reg.V1.Message.FeeRecipient,
+					GasLimit:     reg.V1.Message.GasLimit,
+					Timestamp:    reg.V1.Message.Timestamp,
+					Pubkey:       reg.V1.Message.Pubkey,
+				},
+				Signature: reg.V1.Signature,
+			},
+		})
+	}
+
+	_, err := all(ctx, c.relays, func(ctx context.Context, relay Relay) (struct{}, error) {
+		return struct{}{}, relay.SubmitValidatorRegistrations(ctx, &builderapiv0.SubmitValidatorRegistrationsOpts{
+			Registrations: regs,
+		})
+	})
+	if err != nil {
+		return errors.Wrap(err, "submit validator registrations to relays")
+	}
+
+	return nil
+}
+
+// BuilderBid returns the highest value builder bid of all relays for the slot, parent execution
+// payload hash and validator, or false if no relay provided a bid. The relays serving each bid are
+// remembered, since only they are able to unblind proposals of the bid.
+func (c *Client) BuilderBid(ctx context.Context, slot eth2p0.Slot, parentHash eth2p0.Hash32, pubkey eth2p0.BLSPubKey) (*builderspec.VersionedSignedBuilderBid, bool, error) {
+	type relayBid struct {
+		relay Relay
+		bid   *builderspec.VersionedSignedBuilderBid
+	}
+
+	bids, err := all(ctx, c.relays, func(ctx context.Context, relay Relay) (relayBid, error) {
+		resp, err := relay.BuilderBid(ctx, &builderapiv0.BuilderBidOpts{
+			Slot:       slot,
+			ParentHash: parentHash,
+			PubKey:     pubkey,
+		})
+		if err != nil {
+			return relayBid{}, err
+		}
+
+		return relayBid{relay: relay, bid: resp.Data}, nil
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "request builder bid from relays")
+	}
+
+	var best *builderspec.VersionedSignedBuilderBid
+	for _, relayBid := range bids {
+		bid := relayBid.bid
+		if bid == nil { // No bid from the relay.
+			continue
+		}
+
+		value, err := bid.Value()
+		if err != nil {
+			log.Warn(ctx, "Ignoring invalid builder bid", err, z.U64("slot", uint64(slot)))
+			continue
+		}
+
+		blockHash, err := bid.BlockHash()
+		if err != nil {
+			log.Warn(ctx, "Ignoring invalid builder bid", err, z.U64("slot", uint64(slot)))
+			continue
+		}
+
+		c.addServed(slot, blockHash, relayBid.relay)
+
+		if best == nil {
+			best = bid
+			continue
+		}
+
+		bestValue, err := best.Value()
+		if err != nil {
+			return nil, false, errors.Wrap(err, "builder bid value")
+		}
+
+		if value.Gt(bestValue) {
+			best = bid
+		}
+	}
+
+	return best, best != nil, nil
+}
+
+// UnblindProposal submits the signed blinded proposal to the relays that served its bid and returns the first
+// unblinded proposal. It returns an error if no relay served the bid, since only they are able to unblind it.
+func (c *Client) UnblindProposal(ctx context.Context, proposal *eth2api.VersionedSignedBlindedProposal) (*eth2api.VersionedSignedProposal, error) {
+	blockHash, err := proposal.ExecutionBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "get execution block hash")
+	}
+
+	c.mu.Lock()
+	relays := c.served[blockHash].relays
+	c.mu.Unlock()
+
+	if len(relays) == 0 {
+		return nil, errors.New("no relay served the bid", z.Str("block_hash", blockHash.String()))
+	}
+
+	results, err := all(ctx, relays, func(ctx context.Context, relay Relay) (*eth2api.VersionedSignedProposal, error) {
+		resp, err := relay.UnblindProposal(ctx, &builderapiv0.UnblindProposalOpts{Proposal: proposal})
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unblind proposal via relays")
+	}
+
+	for _, result := range results {
+		if result != nil {
+			return result, nil
+		}
+	}
+
+	return nil, errors.New("no relay unblinded the proposal")
+}
+
+// addServed remembers the relay serving the bid with the block hash, trimming bids older than the retention.
+func (c *Client) addServed(slot eth2p0.Slot, blockHash eth2p0.Hash32, relay Relay) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	served := c.served[blockHash]
+	served.slot = slot
+	served.relays = append(served.relays, relay)
+	c.served[blockHash] = served
+
+	for hash, served := range c.served {
+		if served.slot+servedRetention < slot {
+			delete(c.served, hash)
+		}
+	}
+}
+
+// DeliveredPayloadValue returns the value in wei paid to the proposer of the payload with the block hash delivered
+// at the slot, as reported by the relay data APIs of the relays at the provided addresses. It returns false if no
+// relay delivered the payload.
+func DeliveredPayloadValue(ctx context.Context, addrs []string, slot uint64, blockHash eth2p0.Hash32) (*big.Int, bool, error) {
+	var lastErr error
+
+	for _, addr := range addrs {
+		value, ok, err := deliveredPayloadValue(ctx, addr, slot, blockHash)
+		if err != nil {
+			log.Debug(ctx, "Relay data request failed", z.Str("relay", redact(addr)), z.Err(err))
+			lastErr = err
+
+			continue
+		} else if ok {
+			return value, true, nil
+		}
+	}
+
+	if lastErr != nil {
+		return nil, false, lastErr
+	}
+
+	return nil, false, nil
+}
+
+// deliveredPayloadValue returns the value of the payload with the block hash delivered by the relay at the slot.
+func deliveredPayloadValue(ctx context.Context, addr string, slot uint64, blockHash eth2p0.Hash32) (*big.Int, bool, error) {
+	endpoint, err := url.Parse(addr)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parse relay address")
+	}
+
+	endpoint.User = nil
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/relay/v1/data/bidtraces/proposer_payload_delivered"
+	endpoint.RawQuery = url.Values{"slot": {strconv.FormatUint(slot, 10)}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "create relay data request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "request relay data")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, errors.New("relay data request failed", z.Int("status", resp.StatusCode))
+	}
+
+	var traces []struct {
+		BlockHash string `json:"block_hash"`
+		Value     string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&traces); err != nil {
+		return nil, false, errors.Wrap(err, "decode relay data")
+	}
+
+	for _, trace := range traces {
+		if !strings.EqualFold(trace.BlockHash, blockHash.String()) {
+			continue
+		}
+
+		value, ok := new(big.Int).SetString(trace.Value, 10)
+		if !ok {
+			return nil, false, errors.New("invalid relay data value", z.Str("value", trace.Value))
+		}
+
+		return value, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// all calls the work function with all relays in parallel, returning the outputs of the successful relays.
+// Failing relays are logged, an error is only returned if all relays failed.
+func all[O any](ctx context.Context, relays []Relay, work func(context.Context, Relay) (O, error)) ([]O, error) {
+	results, cancel := forkjoin.NewWithInputs(ctx, work, relays,
+		forkjoin.WithoutFailFast(),
+		forkjoin.WithWorkers(len(relays)),
+	)
+	defer cancel()
+
+	var (
+		outputs []O
+		lastErr error
+	)
+
+	for res := range results {
+		if res.Err != nil {
+			log.Debug(ctx, "Relay request failed", z.Str("relay", redact(res.Input.Address())), z.Err(res.Err))
+			lastErr = res.Err
+
+			continue
+		}
+
+		outputs = append(outputs, res.Output)
+	}
+
+	if len(outputs) == 0 {
+		if lastErr == nil {
+			lastErr = errors.New("no relays")
+		}
+
+		return nil, lastErr
+	}
+
+	return outputs, nil
+}
+
+// redact returns the relay address without user info, i.e. the relay public key.
+func redact(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "<invalid>"
+	}
+
+	u.User = nil
+
+	return u.String()
+}
@@ -0,0 +1,155 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package builderapi_test
+
+import (
+	"context"
+	"testing"
+
+	builderapiv0 "github.com/attestantio/go-builder-client/api"
+	builderapideneb "github.com/attestantio/go-builder-client/api/deneb"
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2deneb "github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/builderapi"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+// testRelay is a stub relay.
+type testRelay struct {
+	bid           *builderspec.VersionedSignedBuilderBid
+	unblinded     *eth2api.VersionedSignedProposal
+	err           error
+	registrations []*builderapiv0.VersionedSignedValidatorRegistration
+}
+
+func (*testRelay) Name() string { return "test" }
+
+func (*testRelay) Address() string { return "http://relay.test" }
+
+func (*testRelay) Pubkey() *eth2p0.BLSPubKey { return nil }
+
+func (r *testRelay) withErr(err error) *testRelay {
+	r.err = err
+	return r
+}
+
+func (r *testRelay) BuilderBid(context.Context, *builderapiv0.BuilderBidOpts) (*builderapiv0.Response[*builderspec.VersionedSignedBuilderBid], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &builderapiv0.Response[*builderspec.VersionedSignedBuilderBid]{Data: r.bid}, nil
+}
+
+func (r *testRelay) SubmitValidatorRegistrations(_ context.Context, opts *builderapiv0.SubmitValidatorRegistrationsOpts) error {
+	r.registrations = opts.Registrations
+	return r.err
+}
+
+func (r *testRelay) UnblindProposal(context.Context, *builderapiv0.UnblindProposalOpts) (*builderapiv0.Response[*eth2api.VersionedSignedProposal], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &builderapiv0.Response[*eth2api.VersionedSignedProposal]{Data: r.unblinded}, nil
+}
+
+func newBid(value uint64, blockHash eth2p0.Hash32) *builderspec.VersionedSignedBuilderBid {
+	return &builderspec.VersionedSignedBuilderBid{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb: &builderapideneb.SignedBuilderBid{
+			Message: &builderapideneb.BuilderBid{
+				Header: &eth2deneb.ExecutionPayloadHeader{BlockHash: blockHash},
+				Value:  uint256.NewInt(value),
+			},
+		},
+	}
+}
+
+func TestBuilderBid(t *testing.T) {
+	low, high := newBid(1, eth2p0.Hash32{1}), newBid(2, eth2p0.Hash32{2})
+
+	cl := builderapi.NewForT([]builderapi.Relay{
+		&testRelay{bid: low},
+		&testRelay{bid: high},
+		&testRelay{}, // No bid.
+		(&testRelay{}).withErr(errors.New("relay down")),
+	})
+
+	bid, ok, err := cl.BuilderBid(t.Context(), 1, eth2p0.Hash32{1}, eth2p0.BLSPubKey{1})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, high, bid)
+
+	cl = builderapi.NewForT([]builderapi.Relay{&testRelay{}})
+
+	_, ok, err = cl.BuilderBid(t.Context(), 1, eth2p0.Hash32{1}, eth2p0.BLSPubKey{1})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cl = builderapi.NewForT([]builderapi.Relay{(&testRelay{}).withErr(errors.New("relay down"))})
+
+	_, _, err = cl.BuilderBid(t.Context(), 1, eth2p0.Hash32{1}, eth2p0.BLSPubKey{1})
+	require.ErrorContains(t, err, "relay down")
+}
+
+func TestSubmitValidatorRegistrations(t *testing.T) {
+	relay := &testRelay{}
+	registration := testutil.RandomCoreVersionedSignedValidatorRegistration(t).VersionedSignedValidatorRegistration
+
+	cl := builderapi.NewForT([]builderapi.Relay{relay, (&testRelay{}).withErr(errors.New("relay down"))})
+
+	err := cl.SubmitValidatorRegistrations(t.Context(), []*eth2api.VersionedSignedValidatorRegistration{&registration})
+	require.NoError(t, err)
+	require.Len(t, relay.registrations, 1)
+	require.Equal(t, registration.V1.Message.Pubkey, relay.registrations[0].V1.Message.Pubkey)
+	require.Equal(t, registration.V1.Message.FeeRecipient, relay.registrations[0].V1.Message.FeeRecipient)
+	require.Equal(t, registration.V1.Signature, relay.registrations[0].V1.Signature)
+}
+
+func TestUnblindProposal(t *testing.T) {
+	unblinded := &eth2api.VersionedSignedProposal{Version: eth2spec.DataVersionDeneb}
+	blinded := testutil.RandomDenebVersionedSignedBlindedProposal()
+
+	proposal, err := blinded.ToBlinded()
+	require.NoError(t, err)
+
+	blockHash, err := proposal.ExecutionBlockHash()
+	require.NoError(t, err)
+
+	other := &testRelay{bid: newBid(1, eth2p0.Hash32{1}), unblinded: &eth2api.VersionedSignedProposal{}}
+
+	cl := builderapi.NewForT([]builderapi.Relay{
+		other,
+		&testRelay{bid: newBid(2, blockHash), unblinded: unblinded},
+	})
+
+	// Proposals are only unblinded by relays that served their bid.
+	_, err = cl.UnblindProposal(t.Context(), &proposal)
+	require.ErrorContains(t, err, "no relay served the bid")
+
+	_, _, err = cl.BuilderBid(t.Context(), 1, eth2p0.Hash32{1}, eth2p0.BLSPubKey{1})
+	require.NoError(t, err)
+
+	resp, err := cl.UnblindProposal(t.Context(), &proposal)
+	require.NoError(t, err)
+	require.Equal(t, unblinded, resp)
+
+	failing := &testRelay{bid: newBid(1, blockHash)}
+	cl = builderapi.NewForT([]builderapi.Relay{failing})
+
+	_, _, err = cl.BuilderBid(t.Context(), 1, eth2p0.Hash32{1}, eth2p0.BLSPubKey{1})
+	require.NoError(t, err)
+
+	failing.err = errors.New("unknown payload")
+
+	_, err = cl.UnblindProposal(t.Context(), &proposal)
+	require.ErrorContains(t, err, "unknown payload")
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+)
+
+// Withdrawal credential prefixes containing an execution layer withdrawal address.
+const (
+	eth1WithdrawalPrefix        = 0x01
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// validateClusterOnChain validates the cluster's validators against the live beacon chain on startup.
+// Validators absent from the chain are expected to be pending deposits, but validators with withdrawal
+// credentials not matching the cluster lock indicate the beacon node is on the wrong network or the
+// cluster lock is wrong, in which case an error is returned. Note the cluster fork version is validated
+// against the beacon node fork schedule when connecting.
+//
+// Beacon node errors are only logged, to not prevent startup while the beacon node is unavailable.
+func validateClusterOnChain(ctx context.Context, eth2Cl eth2wrap.Client, cluster *manifestpb.Cluster) error {
+	var pubkeys []eth2p0.BLSPubKey
+
+	withdrawalAddrs := make(map[eth2p0.BLSPubKey]string)
+
+	for _, val := range cluster.GetValidators() {
+		pubkey := eth2p0.BLSPubKey(val.GetPublicKey())
+		pubkeys = append(pubkeys, pubkey)
+		withdrawalAddrs[pubkey] = val.GetWithdrawalAddress()
+	}
+
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	resp, err := eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{State: "head", PubKeys: pubkeys})
+	if err != nil {
+		log.Warn(ctx, "Failed to validate cluster validators on chain", err)
+		return nil
+	}
+
+	statuses := make(map[string]int)
+	for _, val := range resp.Data {
+		if val == nil || val.Validator == nil {
+			continue
+		}
+
+		pubkey := val.Validator.PublicKey
+
+		if err := verifyWithdrawalAddress(val.Validator.WithdrawalCredentials, withdrawalAddrs[pubkey]); err != nil {
+			return errors.Wrap(err, "cluster validator on chain doesn't match cluster lock. Ensure the beacon node is on the correct network",
+				z.Str("pubkey", pubkey.String()), z.U64("validator_index", uint64(val.Index)))
+		}
+
+		if val.Status.IsExited() || val.Validator.Slashed {
+			log.Warn(ctx, "Cluster validator exited or slashed on chain", nil, z.Str("pubkey", pubkey.String()),
+				z.Str("status", val.Status.String()), z.Bool("slashed", val.Validator.Slashed))
+		}
+
+		statuses[val.Status.String()]++
+	}
+
+	if absent := len(pubkeys) - len(resp.Data); absent > 0 {
+		statuses[eth2v1.ValidatorStateUnknown.String()] += absent
+	}
+
+	log.Info(ctx, "Cluster validators validated on chain", z.Int("validators", len(pubkeys)),
+		z.Int("on_chain", len(resp.Data)), z.Any("statuses", statuses))
+
+	return nil
+}
+
+// verifyWithdrawalAddress returns an error if the withdrawal credentials contain a withdrawal address
+// not matching the expected hex encoded address. Empty expected addresses and BLS withdrawal credentials
+// are not verified.
+func verifyWithdrawalAddress(creds []byte, expected string) error {
+	if expected == "" || len(creds) != 32 {
+		return nil
+	}
+
+	if creds[0] != eth1WithdrawalPrefix && creds[0] != compoundingWithdrawalPrefix {
+		return nil
+	}
+
+	addr, err := hex.DecodeString(strings.TrimPrefix(expected, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "decode withdrawal address")
+	}
+
+	if !bytes.Equal(creds[12:], addr) {
+		return errors.New("withdrawal address mismatch",
+			z.Str("expected", expected), z.Str("actual", "0x"+hex.EncodeToString(creds[12:])))
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestValidateClusterOnChain(t *testing.T) {
+	const addr = "0x000000000000000000000000000000000000dead"
+
+	pubkey1 := testutil.RandomEth2PubKey(t)
+	pubkey2 := testutil.RandomEth2PubKey(t)
+
+	cluster := &manifestpb.Cluster{
+		Validators: []*manifestpb.Validator{
+			{PublicKey: pubkey1[:], WithdrawalAddress: addr},
+			{PublicKey: pubkey2[:], WithdrawalAddress: addr},
+		},
+	}
+
+	creds := func(prefix byte, addr string) []byte {
+		resp := make([]byte, 32)
+		resp[0] = prefix
+		b, err := hex.DecodeString(strings.TrimPrefix(addr, "0x"))
+		require.NoError(t, err)
+		copy(resp[32-len(b):], b)
+
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		vals   map[eth2p0.ValidatorIndex]*eth2v1.Validator
+		valErr error
+		err    string
+	}{
+		{
+			name: "all absent",
+		},
+		{
+			name: "matching withdrawal address",
+			vals: map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+				1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: pubkey1, WithdrawalCredentials: creds(0x01, addr)}},
+			},
+		},
+		{
+			name: "bls withdrawal credentials",
+			vals: map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+				1: {Index: 1, Status: eth2v1.ValidatorStatePendingQueued, Validator: &eth2p0.Validator{PublicKey: pubkey1, WithdrawalCredentials: creds(0x00, "0x1234")}},
+			},
+		},
+		{
+			name: "mismatching withdrawal address",
+			vals: map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+				1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: pubkey1, WithdrawalCredentials: creds(0x01, addr)}},
+				2: {Index: 2, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{PublicKey: pubkey2, WithdrawalCredentials: creds(0x02, "0x000000000000000000000000000000000000beef")}},
+			},
+			err: "cluster validator on chain doesn't match cluster lock",
+		},
+		{
+			name:   "beacon node error",
+			valErr: errors.New("beacon node down"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+
+			bmock.ValidatorsFunc = func(_ context.Context, opts *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+				require.ElementsMatch(t, []eth2p0.BLSPubKey{pubkey1, pubkey2}, opts.PubKeys)
+				return test.vals, test.valErr
+			}
+
+			err = validateClusterOnChain(t.Context(), bmock, cluster)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
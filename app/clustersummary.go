@@ -0,0 +1,145 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+)
+
+// Cluster summary validator status groups.
+const (
+	summaryStatusPending   = "pending"
+	summaryStatusActive    = "active"
+	summaryStatusExiting   = "exiting"
+	summaryStatusSlashed   = "slashed"
+	summaryStatusExited    = "exited"
+	summaryStatusWithdrawn = "withdrawn"
+	summaryStatusUnknown   = "unknown"
+)
+
+// clusterSummary is the cluster-wide aggregate of validator balances and statuses.
+type clusterSummary struct {
+	Epoch                uint64         `json:"epoch"`
+	Validators           int            `json:"validators"`
+	BalanceGwei          uint64         `json:"balance_gwei"`
+	EffectiveBalanceGwei uint64         `json:"effective_balance_gwei"`
+	Statuses             map[string]int `json:"statuses"`
+}
+
+// newClusterSummarizer returns a new cluster summarizer.
+func newClusterSummarizer(eth2Cl eth2wrap.Client) *clusterSummarizer {
+	return &clusterSummarizer{
+		eth2Cl: eth2Cl,
+	}
+}
+
+// clusterSummarizer periodically sums the cluster validator balances and counts them by status,
+// exposing the result as metrics and serving it as JSON.
+type clusterSummarizer struct {
+	eth2Cl eth2wrap.Client
+
+	mu      sync.RWMutex
+	summary *clusterSummary
+}
+
+// SlotTicked refreshes the cluster summary on the first slot of every epoch, or if no summary is available yet.
+func (s *clusterSummarizer) SlotTicked(ctx context.Context, slot core.Slot) error {
+	if !slot.FirstInEpoch() && s.latest() != nil {
+		return nil
+	}
+
+	states, err := s.eth2Cl.ValidatorStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary := summarise(slot.Epoch(), states)
+
+	clusterBalanceGauge.Set(float64(summary.BalanceGwei))
+	clusterEffectiveBalanceGauge.Set(float64(summary.EffectiveBalanceGwei))
+	clusterValidatorStatusGauge.Reset()
+	for status, count := range summary.Statuses {
+		clusterValidatorStatusGauge.WithLabelValues(status).Set(float64(count))
+	}
+
+	s.mu.Lock()
+	s.summary = &summary
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ServeHTTP serves the latest cluster summary as JSON.
+func (s *clusterSummarizer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	summary := s.latest()
+	if summary == nil {
+		writeResponse(w, http.StatusServiceUnavailable, "cluster summary not available yet")
+		return
+	}
+
+	b, err := json.Marshal(summary)
+	if err != nil {
+		writeResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	writeResponse(w, http.StatusOK, string(b))
+}
+
+// latest returns the latest cluster summary or nil if not available.
+func (s *clusterSummarizer) latest() *clusterSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.summary
+}
+
+// summarise returns the cluster summary of the provided validator states.
+func summarise(epoch uint64, states eth2wrap.ValidatorStates) clusterSummary {
+	summary := clusterSummary{
+		Epoch:      epoch,
+		Validators: len(states),
+		Statuses:   make(map[string]int),
+	}
+
+	for _, state := range states {
+		summary.BalanceGwei += uint64(state.Balance)
+		summary.EffectiveBalanceGwei += uint64(state.EffectiveBalance)
+		summary.Statuses[summaryStatus(state)]++
+	}
+
+	return summary
+}
+
+// summaryStatus returns the status group of the validator.
+func summaryStatus(state eth2wrap.ValidatorState) string {
+	if state.Slashed {
+		return summaryStatusSlashed
+	}
+
+	switch state.Status {
+	case eth2v1.ValidatorStatePendingInitialized, eth2v1.ValidatorStatePendingQueued:
+		return summaryStatusPending
+	case eth2v1.ValidatorStateActiveOngoing:
+		return summaryStatusActive
+	case eth2v1.ValidatorStateActiveExiting:
+		return summaryStatusExiting
+	case eth2v1.ValidatorStateActiveSlashed, eth2v1.ValidatorStateExitedSlashed:
+		return summaryStatusSlashed
+	case eth2v1.ValidatorStateExitedUnslashed:
+		return summaryStatusExited
+	case eth2v1.ValidatorStateWithdrawalPossible, eth2v1.ValidatorStateWithdrawalDone:
+		return summaryStatusWithdrawn
+	default:
+		return summaryStatusUnknown
+	}
+}
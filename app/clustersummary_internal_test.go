@@ -0,0 +1,71 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestClusterSummarizer(t *testing.T) {
+	complete := make(eth2wrap.CompleteValidators)
+	for _, status := range []eth2v1.ValidatorState{
+		eth2v1.ValidatorStateActiveOngoing,
+		eth2v1.ValidatorStateActiveOngoing,
+		eth2v1.ValidatorStateActiveExiting,
+		eth2v1.ValidatorStateActiveSlashed,
+		eth2v1.ValidatorStatePendingQueued,
+	} {
+		val := testutil.RandomValidator(t)
+		val.Status = status
+		val.Balance = 32_000_000_000
+		val.Validator.EffectiveBalance = 31_000_000_000
+		val.Validator.Slashed = status == eth2v1.ValidatorStateActiveSlashed
+		complete[val.Index] = val
+	}
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	bmock.CachedValidatorsFunc = func(context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
+		return nil, complete, nil
+	}
+
+	summarizer := newClusterSummarizer(bmock)
+
+	rec := httptest.NewRecorder()
+	summarizer.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cluster/summary", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	require.NoError(t, summarizer.SlotTicked(t.Context(), core.Slot{Slot: 64, SlotsPerEpoch: 32}))
+
+	rec = httptest.NewRecorder()
+	summarizer.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cluster/summary", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var summary clusterSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	require.Equal(t, clusterSummary{
+		Epoch:                2,
+		Validators:           5,
+		BalanceGwei:          5 * 32_000_000_000,
+		EffectiveBalanceGwei: 5 * 31_000_000_000,
+		Statuses: map[string]int{
+			summaryStatusActive:  2,
+			summaryStatusExiting: 1,
+			summaryStatusSlashed: 1,
+			summaryStatusPending: 1,
+		},
+	}, summary)
+}
@@ -0,0 +1,44 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+// spreadEpochStart returns a slot subscriber that delays the first slot of each epoch by a random jitter
+// within the first spreadSlots slots before calling fn, spreading start-of-epoch work and reducing the
+// latency spike degrading duties in the first slots of the epoch. Other slots are not delayed.
+func spreadEpochStart(spreadSlots int, fn func(context.Context, core.Slot) error) func(context.Context, core.Slot) error {
+	return func(ctx context.Context, slot core.Slot) error {
+		delay := epochStartDelay(slot, spreadSlots, rand.Int64N)
+		if delay == 0 {
+			return fn(ctx, slot)
+		}
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		return fn(ctx, slot)
+	}
+}
+
+// epochStartDelay returns a random delay within the first spreadSlots slots for the first slot of
+// the epoch, or zero for other slots.
+func epochStartDelay(slot core.Slot, spreadSlots int, randFunc func(int64) int64) time.Duration {
+	if spreadSlots <= 0 || !slot.FirstInEpoch() {
+		return 0
+	}
+
+	return time.Duration(randFunc(int64(spreadSlots) * int64(slot.SlotDuration)))
+}
@@ -0,0 +1,49 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestEpochStartDelay(t *testing.T) {
+	slot := func(n uint64) core.Slot {
+		return core.Slot{Slot: n, SlotsPerEpoch: 32, SlotDuration: 12 * time.Second}
+	}
+
+	maxRand := func(n int64) int64 { return n - 1 }
+
+	require.Zero(t, epochStartDelay(slot(33), 3, maxRand))
+	require.Zero(t, epochStartDelay(slot(32), 0, maxRand))
+	require.Equal(t, 36*time.Second-1, epochStartDelay(slot(32), 3, maxRand))
+	require.Zero(t, epochStartDelay(slot(64), 3, func(int64) int64 { return 0 }))
+}
+
+func TestSpreadEpochStart(t *testing.T) {
+	var called []uint64
+
+	fn := spreadEpochStart(1, func(_ context.Context, slot core.Slot) error {
+		called = append(called, slot.Slot)
+		return nil
+	})
+
+	// Other slots are not delayed.
+	require.NoError(t, fn(t.Context(), core.Slot{Slot: 1, SlotsPerEpoch: 4, SlotDuration: time.Hour}))
+	require.Equal(t, []uint64{1}, called)
+
+	// Delayed slots are dropped when the context is cancelled.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.NoError(t, fn(ctx, core.Slot{Slot: 4, SlotsPerEpoch: 4, SlotDuration: time.Hour}))
+	require.Equal(t, []uint64{1}, called)
+
+	// The first slot of the epoch is delayed within the spread.
+	require.NoError(t, fn(t.Context(), core.Slot{Slot: 8, SlotsPerEpoch: 4, SlotDuration: time.Millisecond}))
+	require.Equal(t, []uint64{1, 8}, called)
+}
@@ -5,10 +5,13 @@ package eth1wrap
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -166,3 +169,59 @@ func TestNoopClientCreation(t *testing.T) {
 	require.NotNil(t, client, "Client should be created")
 	require.IsType(t, noopClient{}, client, "Client should be a noopClient")
 }
+
+func TestSafeOwners(t *testing.T) {
+	const safeAddr = "0x000000000000000000000000000000000000dEaD"
+
+	owners := []common.Address{
+		common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		common.HexToAddress("0x2222222222222222222222222222222222222222"),
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(safeABI))
+	require.NoError(t, err)
+
+	ret, err := parsed.Methods["getOwners"].Outputs.Pack(owners)
+	require.NoError(t, err)
+
+	t.Run("owners", func(t *testing.T) {
+		ecMock := mocks.NewEthClient(t)
+		ecMock.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(ret, nil).Once()
+
+		client := &client{
+			eth1client:  ecMock,
+			reconnectCh: make(chan struct{}, 1),
+		}
+
+		resp, err := client.SafeOwners(context.Background(), safeAddr)
+		require.NoError(t, err)
+		require.Equal(t, []string{owners[0].Hex(), owners[1].Hex()}, resp)
+	})
+
+	t.Run("not a safe", func(t *testing.T) {
+		ecMock := mocks.NewEthClient(t)
+		ecMock.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return([]byte{}, nil).Once()
+
+		client := &client{
+			eth1client:  ecMock,
+			reconnectCh: make(chan struct{}, 1),
+		}
+
+		_, err := client.SafeOwners(context.Background(), safeAddr)
+		require.ErrorContains(t, err, "ensure the address is a safe")
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		client := &client{reconnectCh: make(chan struct{}, 1)}
+
+		_, err := client.SafeOwners(context.Background(), "0xinvalid")
+		require.ErrorContains(t, err, "invalid safe address")
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		client := &client{reconnectCh: make(chan struct{}, 1)}
+
+		_, err := client.SafeOwners(context.Background(), safeAddr)
+		require.ErrorIs(t, err, ErrEthClientNotConnected)
+	})
+}
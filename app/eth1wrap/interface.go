@@ -72,6 +72,8 @@ type EthClientFactoryFn func(ctx context.Context, rawurl string) (EthClient, err
 type EthClientRunner interface {
 	Run(ctx context.Context)
 	VerifySmartContractBasedSignature(contractAddress string, hash [32]byte, sig []byte) (bool, error)
+	SafeOwners(ctx context.Context, safeAddress string) ([]string, error)
+	BalanceAt(ctx context.Context, address string, blockNumber uint64) (*big.Int, error)
 }
 
 type Erc1271FactoryFn func(contractAddress string, client EthClient) (Erc1271, error)
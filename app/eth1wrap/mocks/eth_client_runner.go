@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	big "math/big"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -15,11 +16,71 @@ type EthClientRunner struct {
 	mock.Mock
 }
 
+// BalanceAt provides a mock function with given fields: ctx, address, blockNumber
+func (_m *EthClientRunner) BalanceAt(ctx context.Context, address string, blockNumber uint64) (*big.Int, error) {
+	ret := _m.Called(ctx, address, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BalanceAt")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) (*big.Int, error)); ok {
+		return rf(ctx, address, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) *big.Int); ok {
+		r0 = rf(ctx, address, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, address, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Run provides a mock function with given fields: ctx
 func (_m *EthClientRunner) Run(ctx context.Context) {
 	_m.Called(ctx)
 }
 
+// SafeOwners provides a mock function with given fields: ctx, safeAddress
+func (_m *EthClientRunner) SafeOwners(ctx context.Context, safeAddress string) ([]string, error) {
+	ret := _m.Called(ctx, safeAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SafeOwners")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, safeAddress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, safeAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, safeAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifySmartContractBasedSignature provides a mock function with given fields: contractAddress, hash, sig
 func (_m *EthClientRunner) VerifySmartContractBasedSignature(contractAddress string, hash [32]byte, sig []byte) (bool, error) {
 	ret := _m.Called(contractAddress, hash, sig)
@@ -4,14 +4,19 @@ package eth1wrap
 
 import (
 	"context"
+	"math/big"
+	"strings"
 	"sync"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/obolnetwork/charon/app/errors"
 	erc1271 "github.com/obolnetwork/charon/app/eth1wrap/generated"
 	"github.com/obolnetwork/charon/app/expbackoff"
+	"github.com/obolnetwork/charon/app/z"
 )
 
 //go:generate abigen --abi=build/IERC1271.abi --pkg=erc1271 --out=generated/erc1271.go
@@ -22,6 +27,9 @@ var (
 	erc1271MagicValue        = [4]byte{0x16, 0x26, 0xba, 0x7e}
 )
 
+// safeABI is the subset of the Safe smart contract account ABI used to read its owners.
+const safeABI = `[{"inputs":[],"name":"getOwners","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"}]`
+
 // NewEthClientRunner returns an uninitialized EL client runner.
 func NewEthClientRunner(addr string, ethclientFactory EthClientFactoryFn, erc1271Factory Erc1271FactoryFn) EthClientRunner {
 	return &client{
@@ -134,6 +142,81 @@ func (cl *client) VerifySmartContractBasedSignature(contractAddress string, hash
 	return result == erc1271MagicValue, nil
 }
 
+// SafeOwners returns the checksummed owner addresses of the Safe smart contract account.
+func (cl *client) SafeOwners(ctx context.Context, safeAddress string) ([]string, error) {
+	if !common.IsHexAddress(safeAddress) {
+		return nil, errors.New("invalid safe address", z.Str("address", safeAddress))
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(safeABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse safe abi")
+	}
+
+	data, err := parsed.Pack("getOwners")
+	if err != nil {
+		return nil, errors.Wrap(err, "pack safe getOwners call")
+	}
+
+	cl.Lock()
+	defer cl.Unlock()
+
+	if cl.eth1client == nil {
+		return nil, ErrEthClientNotConnected
+	}
+
+	addr := common.HexToAddress(safeAddress)
+
+	ret, err := cl.eth1client.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, nil)
+	if err != nil {
+		cl.maybeReconnect()
+		return nil, errors.Wrap(err, "call safe getOwners")
+	}
+
+	values, err := parsed.Unpack("getOwners", ret)
+	if err != nil {
+		return nil, errors.Wrap(err, "unpack safe owners, ensure the address is a safe", z.Str("address", safeAddress))
+	}
+
+	if len(values) != 1 {
+		return nil, errors.New("unexpected safe owners response")
+	}
+
+	owners, ok := values[0].([]common.Address)
+	if !ok {
+		return nil, errors.New("unexpected safe owners type")
+	}
+
+	resp := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		resp = append(resp, owner.Hex())
+	}
+
+	return resp, nil
+}
+
+// BalanceAt returns the balance in wei of the address at the block number.
+func (cl *client) BalanceAt(ctx context.Context, address string, blockNumber uint64) (*big.Int, error) {
+	if !common.IsHexAddress(address) {
+		return nil, errors.New("invalid address", z.Str("address", address))
+	}
+
+	cl.Lock()
+	defer cl.Unlock()
+
+	if cl.eth1client == nil {
+		return nil, ErrEthClientNotConnected
+	}
+
+	balance, err := cl.eth1client.BalanceAt(ctx, common.HexToAddress(address), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		cl.maybeReconnect()
+		return nil, errors.Wrap(err, "get balance")
+	}
+
+	return balance, nil
+}
+
 // noopClient is a no-op implementation of EthClientRunner when address is not set.
 type noopClient struct{}
 
@@ -143,6 +226,14 @@ func (noopClient) VerifySmartContractBasedSignature(_ string, _ [32]byte, _ []by
 	return false, ErrNoExecutionEngineAddr
 }
 
+func (noopClient) SafeOwners(_ context.Context, _ string) ([]string, error) {
+	return nil, ErrNoExecutionEngineAddr
+}
+
+func (noopClient) BalanceAt(_ context.Context, _ string, _ uint64) (*big.Int, error) {
+	return nil, ErrNoExecutionEngineAddr
+}
+
 func (cl *client) maybeReconnect() {
 	cl.reconnectCh <- struct{}{}
 }
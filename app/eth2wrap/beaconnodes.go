@@ -0,0 +1,178 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+
+	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+// beaconNodeStatsWindow is the number of most recent requests per beacon node considered by the beacon node stats.
+const beaconNodeStatsWindow = 1024
+
+// bnStats is the global beacon node request stats registry, populated by all multi clients.
+var bnStats = newBeaconNodeStats()
+
+// BeaconNodeStatus is the status of a configured beacon node; its sync state and version queried on demand
+// and the latency and error stats of its most recent requests as observed by eth2wrap.
+type BeaconNodeStatus struct {
+	Address        string  `json:"address"`
+	Fallback       bool    `json:"fallback"`
+	Error          string  `json:"error,omitempty"`
+	Version        string  `json:"version,omitempty"`
+	HeadSlot       uint64  `json:"head_slot"`
+	SyncDistance   uint64  `json:"sync_distance"`
+	IsSyncing      bool    `json:"is_syncing"`
+	IsOptimistic   bool    `json:"is_optimistic"`
+	LatencyP50Ms   float64 `json:"latency_p50_ms"`
+	LatencyP90Ms   float64 `json:"latency_p90_ms"`
+	LatencyP99Ms   float64 `json:"latency_p99_ms"`
+	RecentRequests int     `json:"recent_requests"`
+	RecentErrors   int     `json:"recent_errors"`
+}
+
+// NewBeaconNodesHandler returns a http handler serving the status of each provided beacon node and fallback
+// beacon node as JSON, allowing operators to compare their redundant beacon nodes at a glance.
+func NewBeaconNodesHandler(clients []Client, fallbacks []Client) http.Handler {
+	type input struct {
+		idx      int
+		client   Client
+		fallback bool
+	}
+
+	var inputs []input
+	for _, cl := range clients {
+		inputs = append(inputs, input{idx: len(inputs), client: cl})
+	}
+
+	for _, cl := range fallbacks {
+		inputs = append(inputs, input{idx: len(inputs), client: cl, fallback: true})
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := make([]BeaconNodeStatus, len(inputs))
+		if len(inputs) > 0 {
+			results, cancel := forkjoin.NewWithInputs(r.Context(), func(ctx context.Context, in input) (BeaconNodeStatus, error) {
+				return queryBeaconNodeStatus(ctx, in.client, in.fallback), nil
+			}, inputs, forkjoin.WithWorkers(len(inputs)))
+			defer cancel()
+
+			for res := range results {
+				resp[res.Input.idx] = res.Output
+			}
+		}
+
+		b, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(r.Context(), "Failed marshalling beacon nodes status", err)
+			http.Error(w, "something went wrong", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+// queryBeaconNodeStatus returns the status of the beacon node, populating the error field if it can't be queried.
+func queryBeaconNodeStatus(ctx context.Context, cl Client, fallback bool) BeaconNodeStatus {
+	resp := BeaconNodeStatus{
+		Address:  cl.Address(),
+		Fallback: fallback,
+	}
+	resp.LatencyP50Ms, resp.LatencyP90Ms, resp.LatencyP99Ms, resp.RecentRequests, resp.RecentErrors = bnStats.summary(resp.Address)
+
+	syncing, err := cl.NodeSyncing(ctx, &eth2api.NodeSyncingOpts{})
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.HeadSlot = uint64(syncing.Data.HeadSlot)
+	resp.SyncDistance = uint64(syncing.Data.SyncDistance)
+	resp.IsSyncing = syncing.Data.IsSyncing
+	resp.IsOptimistic = syncing.Data.IsOptimistic
+
+	version, err := cl.NodeVersion(ctx, &eth2api.NodeVersionOpts{})
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.Version = version.Data
+
+	return resp
+}
+
+// beaconNodeObservation is the outcome of a single beacon node request.
+type beaconNodeObservation struct {
+	latency time.Duration
+	failed  bool
+}
+
+// newBeaconNodeStats returns a new beacon node stats registry.
+func newBeaconNodeStats() *beaconNodeStats {
+	return &beaconNodeStats{
+		observations: make(map[string][]beaconNodeObservation),
+	}
+}
+
+// beaconNodeStats records the most recent request outcomes per beacon node address.
+type beaconNodeStats struct {
+	mu           sync.Mutex
+	observations map[string][]beaconNodeObservation
+}
+
+// observe records the outcome of a request to the beacon node address.
+func (s *beaconNodeStats) observe(address string, latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obs := append(s.observations[address], beaconNodeObservation{latency: latency, failed: failed})
+	if len(obs) > beaconNodeStatsWindow {
+		obs = obs[len(obs)-beaconNodeStatsWindow:]
+	}
+
+	s.observations[address] = obs
+}
+
+// summary returns the p50, p90 and p99 latencies in milliseconds of the recent successful requests
+// as well as the number of recent requests and errors of the beacon node address.
+func (s *beaconNodeStats) summary(address string) (p50, p90, p99 float64, requests, errs int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latencies []time.Duration
+	for _, obs := range s.observations[address] {
+		if obs.failed {
+			errs++
+			continue
+		}
+
+		latencies = append(latencies, obs.latency)
+	}
+
+	slices.Sort(latencies)
+
+	percentile := func(p float64) float64 {
+		if len(latencies) == 0 {
+			return 0
+		}
+
+		idx := int(p * float64(len(latencies)-1))
+
+		return float64(latencies[idx].Microseconds()) / 1000
+	}
+
+	return percentile(0.5), percentile(0.9), percentile(0.99), len(s.observations[address]), errs
+}
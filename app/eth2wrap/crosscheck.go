@@ -0,0 +1,190 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// crossCheckGrace is how long to wait for the remaining beacon nodes after the first attestation data response,
+// limiting the impact of a slow beacon node on attestation latency.
+const crossCheckGrace = 500 * time.Millisecond
+
+// WithAttestationDataCrossCheck wraps the provided client fetching attestation data from all the provided
+// beacon node clients, comparing their head, source and target votes and preferring the majority vote.
+func WithAttestationDataCrossCheck(cl Client, clients []Client) Client {
+	return &crossCheckWrapper{
+		Client:         cl,
+		clients:        clients,
+		mismatchFilter: log.Filter(),
+		failedFilter:   log.Filter(),
+	}
+}
+
+var _ Client = &crossCheckWrapper{}
+
+// crossCheckWrapper wraps an eth2 client and cross-checks attestation data across beacon nodes.
+type crossCheckWrapper struct {
+	Client
+
+	clients        []Client
+	mismatchFilter z.Field
+	failedFilter   z.Field
+}
+
+// attDataResult is an attestation data response of a beacon node.
+type attDataResult struct {
+	idx  int // Index of the beacon node in the configured order.
+	addr string
+	resp *eth2api.Response[*eth2p0.AttestationData]
+	err  error
+}
+
+// AttestationData returns the attestation data agreed on by the majority of the beacon nodes responding in time.
+// Disagreements are logged and the majority vote is returned, ties are broken by the most recent source and target
+// checkpoints since a forked or lagging beacon node is usually behind the finalized chain, then by the configured
+// beacon node order.
+func (c *crossCheckWrapper) AttestationData(ctx context.Context, opts *eth2api.AttestationDataOpts) (*eth2api.Response[*eth2p0.AttestationData], error) {
+	if len(c.clients) < 2 {
+		return c.Client.AttestationData(ctx, opts)
+	}
+
+	results := c.fetchAll(ctx, opts)
+
+	var (
+		resps []attDataResult
+		err   error
+	)
+
+	for _, res := range results {
+		if res.err != nil {
+			err = res.err
+			continue
+		} else if res.resp == nil || res.resp.Data == nil || res.resp.Data.Source == nil || res.resp.Data.Target == nil {
+			err = errors.New("invalid attestation data response", z.Str("address", res.addr))
+			continue
+		}
+
+		resps = append(resps, res)
+	}
+
+	if len(resps) == 0 {
+		// Fall back to the wrapped client which also queries fallback beacon nodes.
+		log.Warn(ctx, "Attestation data cross-check failed, no beacon node responded successfully", err, z.Any("slot", opts.Slot), c.failedFilter)
+
+		return c.Client.AttestationData(ctx, opts)
+	} else if len(resps) == 1 {
+		log.Debug(ctx, "Attestation data cross-check skipped, only one beacon node responded in time",
+			z.Any("slot", opts.Slot), z.Str("address", resps[0].addr))
+
+		return resps[0].resp, nil
+	}
+
+	best, votes := majorityAttData(resps)
+	if votes == len(resps) {
+		return best.resp, nil
+	}
+
+	attDataMismatchCounter.Inc()
+
+	mismatches := make(map[string]string)
+	for _, res := range resps {
+		if attDataVote(res.resp.Data) != attDataVote(best.resp.Data) {
+			mismatches[res.addr] = attDataVote(res.resp.Data)
+		}
+	}
+
+	log.Warn(ctx, "Beacon nodes disagree on attestation data, ensure all beacon nodes are synced to the canonical chain", nil,
+		z.Any("slot", opts.Slot), z.Str("chosen_address", best.addr), z.Str("chosen_vote", attDataVote(best.resp.Data)),
+		z.Int("votes", votes), z.Int("responses", len(resps)), z.Any("mismatches", mismatches), c.mismatchFilter)
+
+	return best.resp, nil
+}
+
+// fetchAll fetches attestation data from all beacon nodes in parallel and returns the results received
+// before the grace period after the first successful response elapsed, in order of response.
+func (c *crossCheckWrapper) fetchAll(ctx context.Context, opts *eth2api.AttestationDataOpts) []attDataResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resultCh := make(chan attDataResult, len(c.clients))
+	for i, cl := range c.clients {
+		go func(i int, cl Client) {
+			resp, err := cl.AttestationData(ctx, opts)
+			resultCh <- attDataResult{idx: i, addr: cl.Address(), resp: resp, err: err}
+		}(i, cl)
+	}
+
+	var (
+		results []attDataResult
+		graceCh <-chan time.Time
+	)
+
+	for range c.clients {
+		select {
+		case <-ctx.Done():
+			return results
+		case <-graceCh:
+			return results
+		case res := <-resultCh:
+			results = append(results, res)
+			if res.err == nil && graceCh == nil {
+				graceCh = time.After(crossCheckGrace)
+			}
+		}
+	}
+
+	return results
+}
+
+// majorityAttData returns the attestation data response with the most votes and its number of votes.
+// The result is independent of the response order.
+func majorityAttData(resps []attDataResult) (attDataResult, int) {
+	votes := make(map[string]int)
+	for _, res := range resps {
+		votes[attDataVote(res.resp.Data)]++
+	}
+
+	best := resps[0]
+	for _, res := range resps[1:] {
+		resVotes, bestVotes := votes[attDataVote(res.resp.Data)], votes[attDataVote(best.resp.Data)]
+		if resVotes != bestVotes {
+			if resVotes > bestVotes {
+				best = res
+			}
+
+			continue
+		}
+
+		if newerCheckpoints(res.resp.Data, best.resp.Data) ||
+			(!newerCheckpoints(best.resp.Data, res.resp.Data) && res.idx < best.idx) {
+			best = res
+		}
+	}
+
+	return best, votes[attDataVote(best.resp.Data)]
+}
+
+// newerCheckpoints returns true if a has a more recent source, or the same source and a more recent target, than b.
+func newerCheckpoints(a, b *eth2p0.AttestationData) bool {
+	if a.Source.Epoch != b.Source.Epoch {
+		return a.Source.Epoch > b.Source.Epoch
+	}
+
+	return a.Target.Epoch > b.Target.Epoch
+}
+
+// attDataVote returns the head, source and target votes of the attestation data.
+func attDataVote(data *eth2p0.AttestationData) string {
+	return fmt.Sprintf("head=%#x source=%d/%#x target=%d/%#x", data.BeaconBlockRoot,
+		data.Source.Epoch, data.Source.Root, data.Target.Epoch, data.Target.Root)
+}
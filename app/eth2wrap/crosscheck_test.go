@@ -0,0 +1,105 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestAttestationDataCrossCheck(t *testing.T) {
+	canonical := testutil.RandomAttestationDataPhase0()
+	canonical.Source.Epoch = 10
+
+	forked := *canonical
+	forked.BeaconBlockRoot = testutil.RandomRoot()
+	forked.Source = &eth2p0.Checkpoint{Epoch: canonical.Source.Epoch - 1, Root: testutil.RandomRoot()}
+
+	otherHead := *canonical
+	otherHead.BeaconBlockRoot = testutil.RandomRoot()
+
+	newClient := func(t *testing.T, data *eth2p0.AttestationData, err error, delay time.Duration) eth2wrap.Client {
+		t.Helper()
+
+		bmock, err2 := beaconmock.New()
+		require.NoError(t, err2)
+
+		bmock.AttestationDataFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+			time.Sleep(delay)
+			return data, err
+		}
+
+		return bmock
+	}
+
+	tests := []struct {
+		name     string
+		datas    []*eth2p0.AttestationData
+		errs     []error
+		delays   []time.Duration
+		expected *eth2p0.AttestationData
+	}{
+		{
+			name:     "agree",
+			datas:    []*eth2p0.AttestationData{canonical, canonical},
+			expected: canonical,
+		},
+		{
+			name:     "majority",
+			datas:    []*eth2p0.AttestationData{&forked, canonical, canonical},
+			expected: canonical,
+		},
+		{
+			name:     "tie prefers newer source",
+			datas:    []*eth2p0.AttestationData{&forked, canonical},
+			expected: canonical,
+		},
+		{
+			name:     "tie with same checkpoints prefers configured order",
+			datas:    []*eth2p0.AttestationData{&otherHead, canonical},
+			delays:   []time.Duration{100 * time.Millisecond, 0},
+			expected: &otherHead,
+		},
+		{
+			name:     "single response",
+			datas:    []*eth2p0.AttestationData{nil, &forked},
+			errs:     []error{errors.New("error"), nil},
+			expected: &forked,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var clients []eth2wrap.Client
+			for i, data := range test.datas {
+				var err error
+				if len(test.errs) > 0 {
+					err = test.errs[i]
+				}
+
+				var delay time.Duration
+				if len(test.delays) > 0 {
+					delay = test.delays[i]
+				}
+
+				clients = append(clients, newClient(t, data, err, delay))
+			}
+
+			eth2Cl := eth2wrap.WithAttestationDataCrossCheck(clients[0], clients)
+
+			resp, err := eth2Cl.AttestationData(t.Context(), &eth2api.AttestationDataOpts{Slot: canonical.Slot, CommitteeIndex: canonical.Index})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, resp.Data)
+		})
+	}
+}
@@ -61,6 +61,20 @@ var (
 		Help:      "Indicates if client is using fallback (1) or primary (0) beacon node",
 	})
 
+	headerCacheCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "header_cache_total",
+		Help:      "Total number of beacon block header and root lookups by endpoint and cache result (hit or miss)",
+	}, []string{"endpoint", "result"})
+
+	attDataMismatchCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "attestation_data_mismatch_total",
+		Help:      "Total number of attestation data responses where beacon nodes disagreed on head, source or target votes",
+	})
+
 	// Interface assertions.
 	_ Client = (*httpAdapter)(nil)
 	_ Client = multi{}
@@ -106,6 +120,11 @@ func NewSimnetFallbacks(timeout time.Duration, forkVersion [4]byte, headers map[
 	return clients
 }
 
+// NewHTTPClients returns a slice of eth2 http clients, one per address, initialized with the provided settings.
+func NewHTTPClients(timeout time.Duration, forkVersion [4]byte, headers map[string]string, addresses []string) []Client {
+	return newClients(timeout, forkVersion, headers, addresses)
+}
+
 // newClients returns a slice of Client initialized with the provided settings.
 func newClients(timeout time.Duration, forkVersion [4]byte, headers map[string]string, addresses []string) []Client {
 	var clients []Client
@@ -127,7 +146,7 @@ func newBeaconClient(timeout time.Duration, forkVersion [4]byte, headers map[str
 		eth2http.WithExtraHeaders(headers),
 	}
 
-	cl := newLazy(func(ctx context.Context) (Client, error) {
+	cl := newLazy(address, func(ctx context.Context) (Client, error) {
 		eth2Svc, err := eth2http.New(ctx, parameters...)
 		if err != nil {
 			return nil, wrapError(ctx, err, "new eth2 client", z.Str("address", address))
@@ -148,7 +167,8 @@ func newBeaconClient(timeout time.Duration, forkVersion [4]byte, headers map[str
 }
 
 type provideArgs struct {
-	client Client
+	client  Client
+	address string
 }
 
 // provide calls the work function with each client in parallel, returning the
@@ -170,14 +190,14 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 			usingFallbackGauge.Set(0)
 		}
 
-		fork, join, cancel := forkjoin.New(ctx, work,
+		fork, join, cancel := forkjoin.New(ctx, observeWork(work),
 			forkjoin.WithoutFailFast(),
 			forkjoin.WithWorkers(len(clients)),
 		)
 		defer cancel()
 
 		for _, client := range clients {
-			fork(provideArgs{client: client})
+			fork(provideArgs{client: client, address: client.Address()})
 		}
 
 		var (
@@ -188,9 +208,11 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 		for res := range join() {
 			if ctx.Err() != nil {
 				return zero, ctx.Err()
-			} else if res.Err == nil && isSuccessFunc(res.Output) {
+			}
+
+			if res.Err == nil && isSuccessFunc(res.Output) {
 				if bestSelector != nil {
-					bestSelector.Increment(res.Input.client.Address())
+					bestSelector.Increment(res.Input.address)
 				}
 
 				return res.Output, nil
@@ -217,6 +239,24 @@ func provide[O any](ctx context.Context, clients []Client, fallbacks []Client,
 	return runForkJoin(fallbacks, true)
 }
 
+// observeWork returns the work function wrapped to record the outcome of each request in the beacon node stats.
+// This includes requests completing after provide already returned the first successful result,
+// but not requests aborted by cancelling the remaining workers, since those have no outcome.
+func observeWork[O any](work forkjoin.Work[provideArgs, O]) forkjoin.Work[provideArgs, O] {
+	return func(ctx context.Context, args provideArgs) (O, error) {
+		t0 := time.Now()
+		out, err := work(ctx, args)
+
+		if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+			return out, err
+		}
+
+		bnStats.observe(args.address, time.Since(t0), err != nil)
+
+		return out, err
+	}
+}
+
 type empty struct{}
 
 // submit proxies provide, but returns nil instead of a successful result.
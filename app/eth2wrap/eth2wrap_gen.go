@@ -38,6 +38,7 @@ type Client interface {
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
 	eth2client.AttesterDutiesProvider
+	eth2client.BeaconBlockHeadersProvider
 	eth2client.BeaconBlockRootProvider
 	eth2client.BeaconCommitteeSubscriptionsSubmitter
 	eth2client.BlindedProposalSubmitter
@@ -389,6 +390,27 @@ func (m multi) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Respo
 	return res0, err
 }
 
+// BeaconBlockHeader provides the block header of a given block ID.
+func (m multi) BeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+	const label = "beacon_block_header"
+	defer latency(ctx, label, false)()
+	defer incRequest(label)
+
+	res0, err := provide(ctx, m.clients, m.fallbacks,
+		func(ctx context.Context, args provideArgs) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+			return args.client.BeaconBlockHeader(ctx, opts)
+		},
+		nil, m.selector,
+	)
+
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res0, err
+}
+
 // BeaconBlockRoot fetches a block's root given a set of options.
 // Note this endpoint is cached in go-eth2-client.
 func (m multi) BeaconBlockRoot(ctx context.Context, opts *api.BeaconBlockRootOpts) (*api.Response[*phase0.Root], error) {
@@ -911,6 +933,16 @@ func (l *lazy) Proposal(ctx context.Context, opts *api.ProposalOpts) (res0 *api.
 	return cl.Proposal(ctx, opts)
 }
 
+// BeaconBlockHeader provides the block header of a given block ID.
+func (l *lazy) BeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (res0 *api.Response[*apiv1.BeaconBlockHeader], err error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return res0, err
+	}
+
+	return cl.BeaconBlockHeader(ctx, opts)
+}
+
 // BeaconBlockRoot fetches a block's root given a set of options.
 func (l *lazy) BeaconBlockRoot(ctx context.Context, opts *api.BeaconBlockRootOpts) (res0 *api.Response[*phase0.Root], err error) {
 	cl, err := l.getOrCreateClient(ctx)
@@ -0,0 +1,100 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// addressClient is a Client only implementing Address.
+type addressClient struct {
+	Client
+
+	address string
+}
+
+func (c addressClient) Address() string {
+	return c.address
+}
+
+func TestProvideObservesAllResults(t *testing.T) {
+	// Unique addresses since the beacon node stats are global.
+	id := time.Now().UnixNano()
+	fast := addressClient{address: fmt.Sprintf("http://fast-%d.test", id)}
+	slow := addressClient{address: fmt.Sprintf("http://slow-%d.test", id)}
+	cancelled := addressClient{address: fmt.Sprintf("http://cancelled-%d.test", id)}
+
+	release := make(chan struct{})
+
+	work := func(ctx context.Context, args provideArgs) (int, error) {
+		switch args.address {
+		case fast.address:
+			return 1, nil
+		case slow.address:
+			// Ignores cancellation, completing after the first successful result.
+			<-release
+			return 0, errors.New("slow failure")
+		default:
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+	}
+
+	out, err := provide(t.Context(), []Client{fast, slow, cancelled}, nil, work, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, out)
+
+	close(release)
+
+	require.Eventually(t, func() bool {
+		_, _, _, requests, errs := bnStats.summary(slow.address)
+		return requests == 1 && errs == 1
+	}, time.Second, time.Millisecond)
+
+	_, _, _, requests, errs := bnStats.summary(fast.address)
+	require.Equal(t, 1, requests)
+	require.Zero(t, errs)
+
+	_, _, _, requests, _ = bnStats.summary(cancelled.address)
+	require.Zero(t, requests)
+}
+
+func TestValidatorStatesCached(t *testing.T) {
+	complete := CompleteValidators{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{}},
+	}
+
+	cl := &httpAdapter{}
+	cl.SetValidatorCache(func(context.Context) (ActiveValidators, CompleteValidators, error) {
+		return nil, complete, nil
+	})
+
+	states1, err := cl.ValidatorStates(t.Context())
+	require.NoError(t, err)
+
+	states2, err := cl.ValidatorStates(t.Context())
+	require.NoError(t, err)
+
+	// The states are only derived once per cached complete validators.
+	require.Equal(t, reflect.ValueOf(states1).UnsafePointer(), reflect.ValueOf(states2).UnsafePointer())
+
+	// Refreshed complete validators are derived again.
+	complete = CompleteValidators{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveExiting, Validator: &eth2p0.Validator{}},
+	}
+
+	states3, err := cl.ValidatorStates(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, eth2v1.ValidatorStateActiveExiting, states3[1].Status)
+	require.Equal(t, eth2v1.ValidatorStateActiveOngoing, states1[1].Status)
+}
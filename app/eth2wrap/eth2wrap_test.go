@@ -675,3 +675,47 @@ func TestLazyDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestBeaconNodesHandler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	// Populate the observed request stats of the beacon node.
+	eth2Cl, err := eth2wrap.NewMultiHTTP(time.Second, [4]byte{}, nil, []string{bmock.Address()}, nil)
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = eth2Cl.NodeSyncing(t.Context(), &eth2api.NodeSyncingOpts{})
+		require.NoError(t, err)
+	}
+
+	handler := eth2wrap.NewBeaconNodesHandler(
+		eth2wrap.NewHTTPClients(time.Second, [4]byte{}, nil, []string{bmock.Address()}),
+		eth2wrap.NewHTTPClients(time.Second, [4]byte{}, nil, []string{srv.URL}),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/monitoring/beacon_nodes", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp []eth2wrap.BeaconNodeStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp, 2)
+
+	require.Equal(t, bmock.Address(), resp[0].Address)
+	require.False(t, resp[0].Fallback)
+	require.Empty(t, resp[0].Error)
+	require.NotEmpty(t, resp[0].Version)
+	require.GreaterOrEqual(t, resp[0].RecentRequests, 3)
+	require.Zero(t, resp[0].RecentErrors)
+	require.Positive(t, resp[0].LatencyP99Ms)
+
+	require.Equal(t, srv.URL, resp[1].Address)
+	require.True(t, resp[1].Fallback)
+	require.NotEmpty(t, resp[1].Error)
+}
@@ -110,6 +110,7 @@ type Client interface {
 		"AttestationsSubmitter":                 {Latency: true, Log: false},
 		"AttesterDutiesProvider":                {Latency: true, Log: false},
 		"ProposalProvider":                      {Latency: true, Log: true},
+		"BeaconBlockHeadersProvider":            {Latency: true, Log: false},
 		"BeaconBlockRootProvider":               {Latency: false, Log: false},
 		"ProposalSubmitter":                     {Latency: true, Log: false},
 		"BeaconCommitteeSubscriptionsSubmitter": {Latency: true, Log: false},
@@ -0,0 +1,304 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"container/list"
+	"context"
+	"encoding/hex"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// DefaultHeaderCacheSize is the default number of slots cached by the header cache, about two epochs.
+const DefaultHeaderCacheSize = 64
+
+// NewHeaderCache returns a new empty beacon block header cache of the provided size.
+func NewHeaderCache(slotsPerEpoch uint64, size int) *HeaderCache {
+	return &HeaderCache{
+		slotsPerEpoch: slotsPerEpoch,
+		size:          size,
+		entries:       make(map[eth2p0.Slot]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+// HeaderCache is a small LRU cache of canonical beacon block roots and headers by slot.
+// It is fed by SSE head events and beacon block header responses and invalidated on chain reorgs.
+type HeaderCache struct {
+	slotsPerEpoch uint64
+	size          int
+
+	mu      sync.Mutex
+	entries map[eth2p0.Slot]*list.Element
+	lru     *list.List // Front is most recently used.
+}
+
+// headerCacheEntry is a cached block root of a slot and its header if known.
+type headerCacheEntry struct {
+	slot     eth2p0.Slot
+	root     eth2p0.Root
+	header   *eth2v1.BeaconBlockHeader // Nil if only the root is known.
+	metadata map[string]any            // Response metadata like execution_optimistic and finalized.
+	queried  bool                      // True if the root and metadata are from a beacon node response rather than a head event.
+}
+
+// HeadReceived caches the block root of the new head, it implements sse.HeadBlockEventHandlerFunc.
+// Since the head is canonical, cached slots after it are evicted as they were reorged out.
+func (c *HeaderCache) HeadReceived(_ context.Context, slot uint64, root eth2p0.Root) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictFromLocked(eth2p0.Slot(slot) + 1)
+	c.setLocked(headerCacheEntry{slot: eth2p0.Slot(slot), root: root})
+}
+
+// ChainReorg evicts all cached slots from the start of the reorg epoch, it implements sse.ChainReorgEventHandlerFunc.
+func (c *HeaderCache) ChainReorg(_ context.Context, epoch eth2p0.Epoch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictFromLocked(eth2p0.Slot(uint64(epoch) * c.slotsPerEpoch))
+}
+
+// SetHeader caches the canonical beacon block header and its response metadata.
+func (c *HeaderCache) SetHeader(header *eth2v1.BeaconBlockHeader, metadata map[string]any) {
+	if header == nil || !header.Canonical || header.Header == nil || header.Header.Message == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setLocked(headerCacheEntry{
+		slot:     header.Header.Message.Slot,
+		root:     header.Root,
+		header:   header,
+		metadata: maps.Clone(metadata),
+		queried:  true,
+	})
+}
+
+// SetRoot caches the block root of the slot and its response metadata.
+func (c *HeaderCache) SetRoot(slot eth2p0.Slot, root eth2p0.Root, metadata map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setLocked(headerCacheEntry{slot: slot, root: root, metadata: maps.Clone(metadata), queried: true})
+}
+
+// Root returns the cached block root of the slot and true if present, including roots only known from head events.
+func (c *HeaderCache) Root(slot eth2p0.Slot) (eth2p0.Root, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.getLocked(slot)
+	if !ok {
+		return eth2p0.Root{}, false
+	}
+
+	return entry.root, true
+}
+
+// entry returns the cached entry of the slot and true if present.
+func (c *HeaderCache) entry(slot eth2p0.Slot) (headerCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.getLocked(slot)
+}
+
+// entryByRoot returns the cached entry with a header of the block root and true if present.
+func (c *HeaderCache) entryByRoot(root eth2p0.Root) (headerCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(headerCacheEntry) //nolint:forcetypeassert // Type is known.
+		if entry.root == root && entry.header != nil {
+			c.lru.MoveToFront(elem)
+			return entry, true
+		}
+	}
+
+	return headerCacheEntry{}, false
+}
+
+// getLocked returns the entry of the slot marking it as most recently used.
+// It must be called with the lock held.
+func (c *HeaderCache) getLocked(slot eth2p0.Slot) (headerCacheEntry, bool) {
+	elem, ok := c.entries[slot]
+	if !ok {
+		return headerCacheEntry{}, false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return elem.Value.(headerCacheEntry), true //nolint:forcetypeassert // Type is known.
+}
+
+// setLocked sets the entry of the slot, retaining a cached header and response metadata of the same root, and evicts
+// the least recently used entry if the cache is full. It must be called with the lock held.
+func (c *HeaderCache) setLocked(entry headerCacheEntry) {
+	if elem, ok := c.entries[entry.slot]; ok {
+		prev := elem.Value.(headerCacheEntry) //nolint:forcetypeassert // Type is known.
+		if prev.root == entry.root {
+			if entry.header == nil {
+				entry.header = prev.header
+			}
+
+			if !entry.queried {
+				entry.metadata = prev.metadata
+				entry.queried = prev.queried
+			}
+		}
+
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[entry.slot] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(headerCacheEntry).slot) //nolint:forcetypeassert // Type is known.
+	}
+}
+
+// evictFromLocked evicts all entries from the provided slot onwards. It must be called with the lock held.
+func (c *HeaderCache) evictFromLocked(from eth2p0.Slot) {
+	for slot, elem := range c.entries {
+		if slot < from {
+			continue
+		}
+
+		c.lru.Remove(elem)
+		delete(c.entries, slot)
+	}
+}
+
+// WithHeaderCache wraps the provided client serving beacon block headers and roots
+// requested by slot or root from the cache, populating it from upstream responses.
+func WithHeaderCache(cl Client, cache *HeaderCache) Client {
+	return &headerCacheWrapper{
+		Client: cl,
+		cache:  cache,
+	}
+}
+
+var _ Client = &headerCacheWrapper{}
+
+// headerCacheWrapper wraps an eth2 client and caches beacon block headers and roots.
+type headerCacheWrapper struct {
+	Client
+
+	cache *HeaderCache
+}
+
+// BeaconBlockHeader returns the cached beacon block header of the block ID if present, or queries and caches it.
+func (h *headerCacheWrapper) BeaconBlockHeader(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+	const label = "beacon_block_header"
+
+	if entry, ok := h.cachedHeader(opts.Block); ok {
+		headerCacheCount.WithLabelValues(label, "hit").Inc()
+		return &eth2api.Response[*eth2v1.BeaconBlockHeader]{Data: entry.header, Metadata: metadataOf(entry)}, nil
+	}
+
+	headerCacheCount.WithLabelValues(label, "miss").Inc()
+
+	resp, err := h.Client.BeaconBlockHeader(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	h.cache.SetHeader(resp.Data, resp.Metadata)
+
+	return resp, nil
+}
+
+// BeaconBlockRoot returns the cached block root of the slot if present, or queries and caches it.
+// Roots only known from head events are queried since their response metadata is unknown.
+func (h *headerCacheWrapper) BeaconBlockRoot(ctx context.Context, opts *eth2api.BeaconBlockRootOpts) (*eth2api.Response[*eth2p0.Root], error) {
+	const label = "beacon_block_root"
+
+	slot, isSlot := parseSlot(opts.Block)
+	if isSlot {
+		if entry, ok := h.cache.entry(slot); ok && entry.queried {
+			headerCacheCount.WithLabelValues(label, "hit").Inc()
+			root := entry.root
+
+			return &eth2api.Response[*eth2p0.Root]{Data: &root, Metadata: metadataOf(entry)}, nil
+		}
+	}
+
+	headerCacheCount.WithLabelValues(label, "miss").Inc()
+
+	resp, err := h.Client.BeaconBlockRoot(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if isSlot && resp.Data != nil {
+		h.cache.SetRoot(slot, *resp.Data, resp.Metadata)
+	}
+
+	return resp, nil
+}
+
+// cachedHeader returns the cached header of the block ID if it is a slot or a root.
+// Aliases like "head" or "finalized" are never served from the cache.
+func (h *headerCacheWrapper) cachedHeader(blockID string) (headerCacheEntry, bool) {
+	if slot, ok := parseSlot(blockID); ok {
+		entry, ok := h.cache.entry(slot)
+		return entry, ok && entry.header != nil
+	}
+
+	if root, ok := parseRoot(blockID); ok {
+		return h.cache.entryByRoot(root)
+	}
+
+	return headerCacheEntry{}, false
+}
+
+// metadataOf returns a copy of the entry's response metadata, so callers can't modify the cached metadata.
+func metadataOf(entry headerCacheEntry) map[string]any {
+	resp := maps.Clone(entry.metadata)
+	if resp == nil {
+		resp = make(map[string]any)
+	}
+
+	return resp
+}
+
+// parseSlot returns the slot of a numeric block ID and true, or false if the block ID isn't a slot.
+func parseSlot(blockID string) (eth2p0.Slot, bool) {
+	slot, err := strconv.ParseUint(blockID, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return eth2p0.Slot(slot), true
+}
+
+// parseRoot returns the root of a 0x-prefixed hex block ID and true, or false if the block ID isn't a root.
+func parseRoot(blockID string) (eth2p0.Root, bool) {
+	if !strings.HasPrefix(blockID, "0x") {
+		return eth2p0.Root{}, false
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(blockID, "0x"))
+	if err != nil || len(b) != len(eth2p0.Root{}) {
+		return eth2p0.Root{}, false
+	}
+
+	return eth2p0.Root(b), true
+}
@@ -0,0 +1,131 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestHeaderCache(t *testing.T) {
+	const slotsPerEpoch = 4
+
+	ctx := t.Context()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var queries int
+
+	bmock.BeaconBlockHeaderFunc = func(_ context.Context, blockID string) (*eth2v1.BeaconBlockHeader, error) {
+		queries++
+
+		slot, err := strconv.ParseUint(blockID, 10, 64)
+		require.NoError(t, err)
+
+		header := testutil.RandomBeaconBlockHeader()
+		header.Header.Message.Slot = eth2p0.Slot(slot)
+
+		return header, nil
+	}
+
+	cache := eth2wrap.NewHeaderCache(slotsPerEpoch, 3)
+	eth2Cl := eth2wrap.WithHeaderCache(metadataClient{Client: bmock}, cache)
+
+	getHeader := func(blockID string) *eth2v1.BeaconBlockHeader {
+		t.Helper()
+
+		resp, err := eth2Cl.BeaconBlockHeader(ctx, &eth2api.BeaconBlockHeaderOpts{Block: blockID})
+		require.NoError(t, err)
+
+		// Cached responses retain the response metadata.
+		require.Equal(t, testMetadata, resp.Metadata)
+
+		return resp.Data
+	}
+
+	// Repeated lookups by slot and root are served from the cache.
+	header1 := getHeader("1")
+	require.Equal(t, header1, getHeader("1"))
+	require.Equal(t, header1, getHeader(fmt.Sprintf("%#x", header1.Root)))
+	require.Equal(t, 1, queries)
+
+	resp, err := eth2Cl.BeaconBlockRoot(ctx, &eth2api.BeaconBlockRootOpts{Block: "1"})
+	require.NoError(t, err)
+	require.Equal(t, header1.Root, *resp.Data)
+	require.Equal(t, testMetadata, resp.Metadata)
+
+	// Head events populate roots, retaining headers of the same root.
+	root2 := testutil.RandomRoot()
+	cache.HeadReceived(ctx, 2, root2)
+
+	root, ok := cache.Root(2)
+	require.True(t, ok)
+	require.Equal(t, root2, root)
+
+	cache.HeadReceived(ctx, 1, header1.Root)
+	require.Equal(t, header1, getHeader("1"))
+	require.Equal(t, 1, queries)
+
+	// A head at a lower slot evicts later slots.
+	_, ok = cache.Root(2)
+	require.False(t, ok)
+
+	// Least recently used slots are evicted.
+	getHeader("3")
+	getHeader("4")
+	getHeader("5")
+	require.Equal(t, 4, queries)
+
+	_, ok = cache.Root(1)
+	require.False(t, ok)
+
+	// Reorgs evict slots from the start of the reorg epoch.
+	cache.ChainReorg(ctx, 1)
+
+	_, ok = cache.Root(3)
+	require.True(t, ok)
+	_, ok = cache.Root(4)
+	require.False(t, ok)
+
+	getHeader("4")
+	require.Equal(t, 5, queries)
+
+	// Aliases are never served from the cache.
+	bmock.BeaconBlockHeaderFunc = func(context.Context, string) (*eth2v1.BeaconBlockHeader, error) {
+		queries++
+		return testutil.RandomBeaconBlockHeader(), nil
+	}
+	eth2Cl = eth2wrap.WithHeaderCache(metadataClient{Client: bmock}, cache)
+
+	getHeader("head")
+	getHeader("head")
+	require.Equal(t, 7, queries)
+}
+
+var testMetadata = map[string]any{"execution_optimistic": true, "finalized": false}
+
+// metadataClient is a client returning beacon block headers with response metadata.
+type metadataClient struct {
+	eth2wrap.Client
+}
+
+func (c metadataClient) BeaconBlockHeader(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+	resp, err := c.Client.BeaconBlockHeader(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eth2api.Response[*eth2v1.BeaconBlockHeader]{Data: resp.Data, Metadata: testMetadata}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
@@ -92,6 +93,10 @@ type httpAdapter struct {
 	valCacheMu  sync.RWMutex
 	valCache    func(context.Context) (ActiveValidators, CompleteValidators, error)
 	forkVersion [4]byte
+
+	statesMu sync.Mutex
+	statesOf CompleteValidators // The cached complete validators the states were derived from.
+	states   ValidatorStates
 }
 
 func (h *httpAdapter) SetForkVersion(forkVersion [4]byte) {
@@ -130,6 +135,32 @@ func (h *httpAdapter) CompleteValidators(ctx context.Context) (CompleteValidator
 	return complete, err
 }
 
+// ValidatorStates returns the typed validator states of the cached complete validators. The validator cache returns
+// the same complete validators until it is refreshed, at least every epoch, so the states are only derived once per
+// refresh instead of per call. The returned states are shared and must not be modified.
+func (h *httpAdapter) ValidatorStates(ctx context.Context) (ValidatorStates, error) {
+	complete, err := h.CompleteValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.statesMu.Lock()
+	defer h.statesMu.Unlock()
+
+	if h.states != nil && reflect.ValueOf(h.statesOf).UnsafePointer() == reflect.ValueOf(complete).UnsafePointer() {
+		return h.states, nil
+	}
+
+	states, err := NewValidatorStates(complete)
+	if err != nil {
+		return nil, err
+	}
+
+	h.statesOf, h.states = complete, states
+
+	return states, nil
+}
+
 // Validators returns the validators as requested in opts.
 // If the amount of validators requested is greater than 200, exponentially increase the timeout: on crowded testnets
 // this HTTP call takes a long time.
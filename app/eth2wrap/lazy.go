@@ -25,15 +25,18 @@ func NewLazyForT(client Client) Client {
 	}
 }
 
-// newLazy creates a new lazy client.
-func newLazy(provider func(context.Context) (Client, error)) *lazy {
+// newLazy creates a new lazy client for the beacon node address.
+func newLazy(address string, provider func(context.Context) (Client, error)) *lazy {
 	return &lazy{
+		address:  address,
 		provider: provider,
 	}
 }
 
 // lazy is a client that is created on demand.
 type lazy struct {
+	address string // Address returned before the client is created.
+
 	providerMu sync.Mutex
 	provider   func(context.Context) (Client, error)
 
@@ -115,7 +118,7 @@ func (l *lazy) Name() string {
 func (l *lazy) Address() string {
 	cl, ok := l.getClient()
 	if !ok {
-		return ""
+		return l.address
 	}
 
 	return cl.Address()
@@ -157,6 +160,15 @@ func (l *lazy) CompleteValidators(ctx context.Context) (CompleteValidators, erro
 	return cl.CompleteValidators(ctx)
 }
 
+func (l *lazy) ValidatorStates(ctx context.Context) (ValidatorStates, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ValidatorStates(ctx)
+}
+
 func (l *lazy) SetValidatorCache(valCache func(context.Context) (ActiveValidators, CompleteValidators, error)) {
 	l.clientMu.Lock()
 	l.valCache = valCache
@@ -230,6 +230,36 @@ func (_m *Client) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOp
 	return r0, r1
 }
 
+// BeaconBlockHeader provides a mock function with given fields: ctx, opts
+func (_m *Client) BeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*v1.BeaconBlockHeader], error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeaconBlockHeader")
+	}
+
+	var r0 *api.Response[*v1.BeaconBlockHeader]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.BeaconBlockHeaderOpts) (*api.Response[*v1.BeaconBlockHeader], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.BeaconBlockHeaderOpts) *api.Response[*v1.BeaconBlockHeader]); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Response[*v1.BeaconBlockHeader])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.BeaconBlockHeaderOpts) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BeaconBlockRoot provides a mock function with given fields: ctx, opts
 func (_m *Client) BeaconBlockRoot(ctx context.Context, opts *api.BeaconBlockRootOpts) (*api.Response[*phase0.Root], error) {
 	ret := _m.Called(ctx, opts)
@@ -1176,6 +1206,36 @@ func (_m *Client) SyncCommitteeDuties(ctx context.Context, opts *api.SyncCommitt
 	return r0, r1
 }
 
+// ValidatorStates provides a mock function with given fields: ctx
+func (_m *Client) ValidatorStates(ctx context.Context) (eth2wrap.ValidatorStates, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatorStates")
+	}
+
+	var r0 eth2wrap.ValidatorStates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (eth2wrap.ValidatorStates, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) eth2wrap.ValidatorStates); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(eth2wrap.ValidatorStates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validators provides a mock function with given fields: ctx, opts
 func (_m *Client) Validators(ctx context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*v1.Validator], error) {
 	ret := _m.Called(ctx, opts)
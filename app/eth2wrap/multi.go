@@ -4,6 +4,7 @@ package eth2wrap
 
 import (
 	"context"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec"
 
@@ -14,20 +15,46 @@ import (
 // NewMultiForT creates a new mutil client for testing.
 func NewMultiForT(clients []Client, fallbacks []Client) Client {
 	return &multi{
-		clients:   clients,
-		fallbacks: fallbacks,
+		clients:   withCachedAddresses(clients),
+		fallbacks: withCachedAddresses(fallbacks),
 		selector:  newBestSelector(bestPeriod),
 	}
 }
 
 func newMulti(clients []Client, fallbacks []Client) Client {
 	return multi{
-		clients:   clients,
-		fallbacks: fallbacks,
+		clients:   withCachedAddresses(clients),
+		fallbacks: withCachedAddresses(fallbacks),
 		selector:  newBestSelector(bestPeriod),
 	}
 }
 
+// withCachedAddresses returns the clients wrapped to cache their addresses.
+func withCachedAddresses(clients []Client) []Client {
+	var resp []Client
+	for _, cl := range clients {
+		resp = append(resp, &addressedClient{Client: cl})
+	}
+
+	return resp
+}
+
+// addressedClient wraps a client caching its address, which is fixed, avoiding querying it on every request.
+type addressedClient struct {
+	Client
+
+	once    sync.Once
+	address string
+}
+
+func (c *addressedClient) Address() string {
+	c.once.Do(func() {
+		c.address = c.Client.Address()
+	})
+
+	return c.address
+}
+
 // multi implements Client by wrapping multiple clients, calling them in parallel
 // and returning the first successful response.
 // It also adds prometheus metrics and error wrapping.
@@ -125,6 +152,26 @@ func (m multi) CompleteValidators(ctx context.Context) (CompleteValidators, erro
 	return res0, err
 }
 
+func (m multi) ValidatorStates(ctx context.Context) (ValidatorStates, error) {
+	const label = "validator_states"
+	// No latency since this is a cached endpoint.
+
+	defer incRequest(label)
+
+	res0, err := provide(ctx, m.clients, m.fallbacks,
+		func(ctx context.Context, args provideArgs) (ValidatorStates, error) {
+			return args.client.ValidatorStates(ctx)
+		},
+		nil, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res0, err
+}
+
 func (m multi) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigResponse, error) {
 	const label = "proposer_config"
 	defer latency(ctx, label, false)()
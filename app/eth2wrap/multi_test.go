@@ -164,6 +164,7 @@ func TestMulti_ActiveValidators(t *testing.T) {
 	vals := make(eth2wrap.ActiveValidators)
 
 	client := mocks.NewClient(t)
+	client.On("Address").Return("test").Once()
 	client.On("ActiveValidators", mock.Anything).Return(vals, nil).Once()
 
 	m := eth2wrap.NewMultiForT([]eth2wrap.Client{client}, nil)
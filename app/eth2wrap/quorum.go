@@ -0,0 +1,167 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// farFutureEpoch is the exit epoch of validators that haven't initiated an exit.
+const farFutureEpoch = eth2p0.Epoch(1<<64 - 1)
+
+// WithValidatorsQuorum wraps the provided client fetching validators from all the provided beacon node clients,
+// only returning validators a majority of the beacon nodes agree on. This protects critical one-off flows,
+// like signing exits, against a single faulty or out of sync beacon node.
+func WithValidatorsQuorum(cl Client, clients []Client) Client {
+	return &quorumWrapper{
+		Client:  cl,
+		clients: clients,
+	}
+}
+
+var _ Client = &quorumWrapper{}
+
+// quorumWrapper wraps an eth2 client and requires a quorum of beacon nodes to agree on validators.
+type quorumWrapper struct {
+	Client
+
+	clients []Client
+}
+
+// Validators returns the validators response of the majority of all beacon nodes, comparing the index, status,
+// exit and withdrawable epochs of each validator. It returns an error listing the disagreeing
+// beacon nodes if no majority agrees.
+func (c *quorumWrapper) Validators(ctx context.Context, opts *eth2api.ValidatorsOpts) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
+	if len(c.clients) < 2 {
+		return c.Client.Validators(ctx, opts)
+	}
+
+	results, cancel := forkjoin.NewWithInputs(ctx, func(ctx context.Context, cl Client) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
+		return cl.Validators(ctx, opts)
+	}, c.clients, forkjoin.WithoutFailFast(), forkjoin.WithWorkers(len(c.clients)))
+	defer cancel()
+
+	var (
+		votes     = make(map[string]int)
+		responses = make(map[string]*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator])
+		addrVotes = make(map[string]string) // Beacon node address to vote, or error.
+	)
+
+	for res := range results {
+		addr := res.Input.Address()
+		if res.Err != nil {
+			addrVotes[addr] = "error: " + res.Err.Error()
+			continue
+		} else if res.Output == nil {
+			addrVotes[addr] = "error: nil response"
+			continue
+		}
+
+		vote := validatorsVote(opts, res.Output.Data)
+		votes[vote]++
+		responses[vote] = res.Output
+		addrVotes[addr] = vote
+	}
+
+	var (
+		best      string
+		bestVotes int
+	)
+
+	for vote, count := range votes {
+		if count > bestVotes {
+			best, bestVotes = vote, count
+		}
+	}
+
+	var disagreeing []string
+	for addr, vote := range addrVotes {
+		if vote != best {
+			disagreeing = append(disagreeing, addr)
+		}
+	}
+
+	slices.Sort(disagreeing)
+
+	if bestVotes <= len(c.clients)/2 {
+		return nil, errors.New("no quorum of beacon nodes agree on validators, ensure all beacon nodes are synced",
+			z.Int("votes", bestVotes), z.Int("beacon_nodes", len(c.clients)), z.Any("disagreeing_beacon_nodes", disagreeing))
+	}
+
+	if len(disagreeing) > 0 {
+		log.Warn(ctx, "Some beacon nodes disagree with the quorum on validators", nil,
+			z.Int("votes", bestVotes), z.Int("beacon_nodes", len(c.clients)), z.Any("disagreeing_beacon_nodes", disagreeing))
+	}
+
+	return responses[best], nil
+}
+
+// validatorsVote returns a deterministic string of the validator fields required to agree on. Requests for specific
+// validators vote on the fields of the requested validators only. Requests for all validators vote on aggregate
+// counts instead, since beacon nodes rarely agree on every validator of the whole set.
+func validatorsVote(opts *eth2api.ValidatorsOpts, vals map[eth2p0.ValidatorIndex]*eth2v1.Validator) string {
+	if len(opts.Indices) == 0 && len(opts.PubKeys) == 0 {
+		return validatorsAggregateVote(vals)
+	}
+
+	var entries []string
+	for idx, val := range vals {
+		if val == nil || val.Validator == nil {
+			if slices.Contains(opts.Indices, idx) {
+				entries = append(entries, fmt.Sprintf("%d:nil", idx))
+			}
+
+			continue
+		}
+
+		if !slices.Contains(opts.Indices, idx) && !slices.Contains(opts.PubKeys, val.Validator.PublicKey) {
+			continue
+		}
+
+		entries = append(entries, fmt.Sprintf("%d:%#x:%s:%d:%d", idx, val.Validator.PublicKey, val.Status,
+			val.Validator.ExitEpoch, val.Validator.WithdrawableEpoch))
+	}
+
+	slices.Sort(entries)
+
+	return strings.Join(entries, ",")
+}
+
+// validatorsAggregateVote returns a deterministic string of the number of validators per status
+// and the number of validators per exit epoch, which is what the exit queue is derived from.
+func validatorsAggregateVote(vals map[eth2p0.ValidatorIndex]*eth2v1.Validator) string {
+	counts := make(map[string]int)
+	for _, val := range vals {
+		if val == nil || val.Validator == nil {
+			counts["nil"]++
+			continue
+		}
+
+		counts["status:"+val.Status.String()]++
+
+		if val.Validator.ExitEpoch != farFutureEpoch {
+			counts[fmt.Sprintf("exit_epoch:%d", val.Validator.ExitEpoch)]++
+		}
+	}
+
+	var entries []string
+	for key, count := range counts {
+		entries = append(entries, fmt.Sprintf("%s=%d", key, count))
+	}
+
+	slices.Sort(entries)
+
+	return strings.Join(entries, ",")
+}
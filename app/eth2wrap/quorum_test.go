@@ -0,0 +1,129 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap_test
+
+import (
+	"context"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestValidatorsQuorum(t *testing.T) {
+	active := map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{ExitEpoch: 1 << 63}},
+	}
+	exiting := map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveExiting, Validator: &eth2p0.Validator{ExitEpoch: 100}},
+	}
+	// Only differs in the validator that isn't requested.
+	otherExiting := map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+		1: active[1],
+		2: exiting[1],
+	}
+	// Only differs in fields that aren't part of the aggregate counts.
+	otherWithdrawable := map[eth2p0.ValidatorIndex]*eth2v1.Validator{
+		1: {Index: 1, Status: eth2v1.ValidatorStateActiveOngoing, Validator: &eth2p0.Validator{ExitEpoch: 1 << 63, WithdrawableEpoch: 1}},
+	}
+
+	newClient := func(t *testing.T, vals map[eth2p0.ValidatorIndex]*eth2v1.Validator, err error) eth2wrap.Client {
+		t.Helper()
+
+		bmock, err2 := beaconmock.New()
+		require.NoError(t, err2)
+
+		bmock.ValidatorsFunc = func(context.Context, *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+			return vals, err
+		}
+
+		return bmock
+	}
+
+	tests := []struct {
+		name     string
+		vals     []map[eth2p0.ValidatorIndex]*eth2v1.Validator
+		errs     []error
+		indices  []eth2p0.ValidatorIndex
+		expected map[eth2p0.ValidatorIndex]*eth2v1.Validator
+		err      string
+	}{
+		{
+			name:     "agree",
+			vals:     []map[eth2p0.ValidatorIndex]*eth2v1.Validator{active, active},
+			expected: active,
+		},
+		{
+			name:     "majority",
+			vals:     []map[eth2p0.ValidatorIndex]*eth2v1.Validator{exiting, active, active},
+			expected: active,
+		},
+		{
+			name: "tie",
+			vals: []map[eth2p0.ValidatorIndex]*eth2v1.Validator{exiting, active},
+			err:  "no quorum of beacon nodes agree on validators",
+		},
+		{
+			name:     "requested subset agrees",
+			vals:     []map[eth2p0.ValidatorIndex]*eth2v1.Validator{otherExiting, active},
+			indices:  []eth2p0.ValidatorIndex{1},
+			expected: active,
+		},
+		{
+			name:    "requested subset disagrees",
+			vals:    []map[eth2p0.ValidatorIndex]*eth2v1.Validator{exiting, active},
+			indices: []eth2p0.ValidatorIndex{1},
+			err:     "no quorum of beacon nodes agree on validators",
+		},
+		{
+			name:     "aggregate counts agree",
+			vals:     []map[eth2p0.ValidatorIndex]*eth2v1.Validator{otherWithdrawable, active, exiting},
+			expected: nil, // Either agreeing response.
+		},
+		{
+			name: "errors count against quorum",
+			vals: []map[eth2p0.ValidatorIndex]*eth2v1.Validator{active, nil, nil},
+			errs: []error{nil, errors.New("error"), errors.New("error")},
+			err:  "no quorum of beacon nodes agree on validators",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var clients []eth2wrap.Client
+			for i, vals := range test.vals {
+				var err error
+				if len(test.errs) > 0 {
+					err = test.errs[i]
+				}
+
+				clients = append(clients, newClient(t, vals, err))
+			}
+
+			eth2Cl := eth2wrap.WithValidatorsQuorum(clients[0], clients)
+
+			resp, err := eth2Cl.Validators(t.Context(), &eth2api.ValidatorsOpts{State: "head", Indices: test.indices})
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if test.expected == nil {
+				require.Equal(t, eth2v1.ValidatorStateActiveOngoing, resp.Data[1].Status)
+				return
+			}
+
+			for idx, val := range test.expected {
+				require.Equal(t, val, resp.Data[idx])
+			}
+		})
+	}
+}
@@ -3,14 +3,19 @@
 package eth2wrap
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
 type ForkSchedule struct {
@@ -44,11 +49,58 @@ var forkLabels = map[Fork]string{
 	// Fulu:      "FULU",
 }
 
+var forkDataVersions = map[Fork]eth2spec.DataVersion{
+	Altair:    eth2spec.DataVersionAltair,
+	Bellatrix: eth2spec.DataVersionBellatrix,
+	Capella:   eth2spec.DataVersionCapella,
+	Deneb:     eth2spec.DataVersionDeneb,
+	Electra:   eth2spec.DataVersionElectra,
+}
+
 var (
 	errFetchNetworkSpec   = errors.New("fetch network spec")
 	errMissingNetworkSpec = errors.New("missing network spec")
+
+	// ErrNetworkMismatch indicates that the beacon node is connected to a different network
+	// than the cluster, so any signing domain derived from it would be invalid.
+	ErrNetworkMismatch = errors.New("mismatch between lock file fork version and beacon node fork schedule. Ensure the beacon node is on the correct network")
 )
 
+// VerifyNetwork returns ErrNetworkMismatch if the fork version isn't part of the beacon node's fork schedule.
+func VerifyNetwork(ctx context.Context, client eth2client.ForkScheduleProvider, forkVersion []byte) error {
+	eth2Resp, err := client.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return errors.Wrap(err, "fetch fork schedule")
+	}
+
+	schedule := eth2Resp.Data
+	if len(schedule) == 0 {
+		return errors.New("empty beacon node fork schedule")
+	}
+
+	for _, fork := range schedule {
+		if bytes.Equal(fork.CurrentVersion[:], forkVersion) {
+			return nil
+		}
+	}
+
+	return errors.Wrap(ErrNetworkMismatch, "verify network",
+		z.Str("beacon_node", networkName(schedule[0].CurrentVersion[:])),
+		z.Str("lock_file", networkName(forkVersion)),
+	)
+}
+
+// networkName returns the name of the network identified by the genesis fork version
+// or its hex representation if unknown.
+func networkName(forkVersion []byte) string {
+	network, err := eth2util.ForkVersionToNetwork(forkVersion)
+	if err != nil {
+		return fmt.Sprintf("%#x", forkVersion)
+	}
+
+	return network
+}
+
 func FetchGenesisTime(ctx context.Context, client eth2client.GenesisProvider) (time.Time, error) {
 	genesisTime, err := client.Genesis(ctx, &api.GenesisOpts{})
 	if err != nil {
@@ -115,6 +167,30 @@ func FetchForkConfig(ctx context.Context, client eth2client.SpecProvider) (fork
 	return res, nil
 }
 
+// FetchSlotDataVersion returns the data version of the slot's fork as per the network spec's fork epochs.
+func FetchSlotDataVersion(ctx context.Context, client eth2client.SpecProvider, slot eth2p0.Slot) (eth2spec.DataVersion, error) {
+	_, slotsPerEpoch, err := FetchSlotsConfig(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	forks, err := FetchForkConfig(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	epoch := eth2p0.Epoch(uint64(slot) / slotsPerEpoch)
+
+	resp := eth2spec.DataVersionPhase0
+	for _, fork := range []Fork{Altair, Bellatrix, Capella, Deneb, Electra} {
+		if forks[fork].Epoch <= epoch {
+			resp = forkDataVersions[fork]
+		}
+	}
+
+	return resp, nil
+}
+
 func fetchFork(forkName string, data map[string]any) (ForkSchedule, error) {
 	var ok bool
 
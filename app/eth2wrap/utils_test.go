@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -67,3 +69,39 @@ func TestFetchForkConfig(t *testing.T) {
 	// Matching beaconmock/static.json
 	require.Equal(t, forkConfig, ffs)
 }
+
+func TestFetchSlotDataVersion(t *testing.T) {
+	eth2Cl, err := beaconmock.New(beaconmock.WithForkEpoch("deneb", 1))
+	require.NoError(t, err)
+
+	for slot, version := range map[eth2p0.Slot]eth2spec.DataVersion{
+		0:              eth2spec.DataVersionCapella,
+		15:             eth2spec.DataVersionCapella,
+		16:             eth2spec.DataVersionDeneb,
+		2048*16 - 1:    eth2spec.DataVersionDeneb,
+		2048 * 16:      eth2spec.DataVersionElectra,
+		2048*16 + 1000: eth2spec.DataVersionElectra,
+	} {
+		resp, err := eth2wrap.FetchSlotDataVersion(t.Context(), eth2Cl, slot)
+		require.NoError(t, err)
+		require.Equal(t, version, resp, "slot %d", slot)
+	}
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	// Matching beaconmock/static.json fork schedule.
+	holesky, err := hex.DecodeString("01017000")
+	require.NoError(t, err)
+
+	err = eth2wrap.VerifyNetwork(t.Context(), eth2Cl, holesky)
+	require.NoError(t, err)
+
+	mainnet, err := hex.DecodeString("00000000")
+	require.NoError(t, err)
+
+	err = eth2wrap.VerifyNetwork(t.Context(), eth2Cl, mainnet)
+	require.ErrorIs(t, err, eth2wrap.ErrNetworkMismatch)
+}
@@ -11,7 +11,10 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
 )
 
 // ActiveValidators is a map of active validator indices to pubkeys.
@@ -20,6 +23,50 @@ type ActiveValidators map[eth2p0.ValidatorIndex]eth2p0.BLSPubKey
 // CompleteValidators represents the complete response of the beacon node validators endpoint.
 type CompleteValidators map[eth2p0.ValidatorIndex]*eth2v1.Validator
 
+// ValidatorState is a typed view of a validator's beacon chain state.
+type ValidatorState struct {
+	Index            eth2p0.ValidatorIndex
+	PubKey           eth2p0.BLSPubKey
+	Status           eth2v1.ValidatorState
+	ActivationEpoch  eth2p0.Epoch
+	ExitEpoch        eth2p0.Epoch
+	Balance          eth2p0.Gwei
+	EffectiveBalance eth2p0.Gwei
+	Slashed          bool
+}
+
+// ActiveOrActivating returns true if the validator is active or gets activated at the provided epoch.
+// The activation epoch needs to be checked in cases where this is called before the epoch starts.
+func (s ValidatorState) ActiveOrActivating(epoch eth2p0.Epoch) bool {
+	return s.Status.IsActive() || s.ActivationEpoch == epoch
+}
+
+// ValidatorStates is a map of validator indices to their beacon chain state.
+type ValidatorStates map[eth2p0.ValidatorIndex]ValidatorState
+
+// NewValidatorStates returns the typed validator states of the complete validators response.
+func NewValidatorStates(complete CompleteValidators) (ValidatorStates, error) {
+	resp := make(ValidatorStates, len(complete))
+	for index, val := range complete {
+		if val == nil || val.Validator == nil {
+			return nil, errors.New("validator data cannot be nil")
+		}
+
+		resp[index] = ValidatorState{
+			Index:            index,
+			PubKey:           val.Validator.PublicKey,
+			Status:           val.Status,
+			ActivationEpoch:  val.Validator.ActivationEpoch,
+			ExitEpoch:        val.Validator.ExitEpoch,
+			Balance:          val.Balance,
+			EffectiveBalance: val.Validator.EffectiveBalance,
+			Slashed:          val.Validator.Slashed,
+		}
+	}
+
+	return resp, nil
+}
+
 // Pubkeys returns a list of active validator pubkeys.
 func (m ActiveValidators) Pubkeys() []eth2p0.BLSPubKey {
 	var pubkeys []eth2p0.BLSPubKey
@@ -45,141 +92,211 @@ func (m ActiveValidators) Indices() []eth2p0.ValidatorIndex {
 type CachedValidatorsProvider interface {
 	ActiveValidators(context.Context) (ActiveValidators, error)
 	CompleteValidators(ctx context.Context) (CompleteValidators, error)
+	ValidatorStates(ctx context.Context) (ValidatorStates, error)
 }
 
 // NewValidatorCache creates a new validator cache.
+// Close should be called on shutdown to cancel upstream fetches shared by concurrent callers.
 func NewValidatorCache(eth2Cl Client, pubkeys []eth2p0.BLSPubKey) *ValidatorCache {
 	return &ValidatorCache{
-		eth2Cl:  eth2Cl,
-		pubkeys: pubkeys,
+		eth2Cl:   eth2Cl,
+		pubkeys:  pubkeys,
+		shutdown: make(chan struct{}),
 	}
 }
 
 // ValidatorCache caches active validators.
+// Concurrent fetches of the same state are deduplicated and stale data is served while being revalidated.
 type ValidatorCache struct {
-	eth2Cl  Client
-	pubkeys []eth2p0.BLSPubKey
+	eth2Cl    Client
+	pubkeys   []eth2p0.BLSPubKey
+	group     singleflight.Group
+	shutdown  chan struct{}
+	closeOnce sync.Once
 
 	mu       sync.RWMutex
 	active   ActiveValidators
 	complete CompleteValidators
+	stale    bool
+	gen      uint64 // Incremented by Trim, results of fetches started in previous generations aren't cached.
+	started  uint64 // Number of started fetches.
+	cachedBy uint64 // Number of the started fetch that populated the cache, results of earlier fetches aren't cached.
+}
+
+// valCacheResult is the result of a deduplicated validator fetch.
+type valCacheResult struct {
+	active          ActiveValidators
+	complete        CompleteValidators
+	refreshedBySlot bool
 }
 
-// Trim trims the cache.
+// Trim marks the cache as stale.
 // This should be called on epoch boundary.
 func (c *ValidatorCache) Trim() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.active = nil
-	c.complete = nil
+	c.stale = true
+	c.gen++
+}
+
+// Close cancels ongoing and future upstream fetches. It should be called on shutdown.
+func (c *ValidatorCache) Close() {
+	c.closeOnce.Do(func() {
+		close(c.shutdown)
+	})
 }
 
-// activeCached returns the cached active validators and true if they are available.
-func (c *ValidatorCache) activeCached() (ActiveValidators, bool) {
+// cached returns the cached active and complete validators, true if they are available and true if they are stale.
+func (c *ValidatorCache) cached() (ActiveValidators, CompleteValidators, bool, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	return c.active, c.active != nil
+	return c.active, c.complete, c.active != nil && c.complete != nil, c.stale
 }
 
-// cached returns the cached complete validators and true if they are available.
-func (c *ValidatorCache) cached() (CompleteValidators, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// Cached returns the cached complete validators without fetching them, and true if available.
+func (c *ValidatorCache) Cached() (CompleteValidators, bool) {
+	_, complete, ok, _ := c.cached()
 
-	return c.complete, c.complete != nil
+	return complete, ok
 }
 
-// Get returns the cached active validators, cached complete Validators response, or fetches them if not available populating the cache.
+// GetByHead returns the cached active validators, cached complete Validators response, or fetches them if not available populating the cache.
+// Stale cached validators are returned immediately while being refreshed in the background.
 func (c *ValidatorCache) GetByHead(ctx context.Context) (ActiveValidators, CompleteValidators, error) {
-	completeCached, completeOk := c.cached()
-	activeCached, activeOk := c.activeCached()
-
-	if completeOk && activeOk {
-		return activeCached, completeCached, nil
+	active, complete, ok, stale := c.cached()
+	if ok && !stale {
+		return active, complete, nil
+	} else if ok {
+		ch := c.fetch(ctx, "head", false)
+		go func() {
+			if res := <-ch; res.Err != nil {
+				log.Warn(ctx, "Failed to refresh stale validator cache", res.Err)
+			}
+		}()
+
+		return active, complete, nil
 	}
 
-	// This code is only ever invoked by scheduler's slot ticking method.
-	// It's fine locking this way.
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case res := <-c.fetch(ctx, "head", false):
+		if res.Err != nil {
+			return nil, nil, res.Err
+		}
+
+		val := res.Val.(valCacheResult) //nolint:forcetypeassert // Type is known.
 
-	opts := &eth2api.ValidatorsOpts{
-		State:   "head",
-		PubKeys: c.pubkeys,
+		return val.active, val.complete, nil
 	}
+}
 
-	eth2Resp, err := c.eth2Cl.Validators(ctx, opts)
-	if err != nil {
-		return nil, nil, err
+// GetBySlot fetches active and complete validator by slot populating the cache.
+// If it fails to fetch by slot, it falls back to head state and retries to fetch by slot next slot.
+func (c *ValidatorCache) GetBySlot(ctx context.Context, slot uint64) (ActiveValidators, CompleteValidators, bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, false, ctx.Err()
+	case res := <-c.fetch(ctx, strconv.FormatUint(slot, 10), true):
+		if res.Err != nil {
+			return nil, nil, false, res.Err
+		}
+
+		val := res.Val.(valCacheResult) //nolint:forcetypeassert // Type is known.
+
+		return val.active, val.complete, val.refreshedBySlot, nil
 	}
+}
 
-	vals := eth2Resp.Data
+// fetch returns a channel with the result of fetching the validators at the provided state populating the cache.
+// Concurrent fetches of the same state within a generation share a single upstream request, which isn't cancelled
+// by any single caller but is on Close. Results are only cached if no Trim or later started fetch populated the
+// cache in the meantime, so stale results never overwrite fresher ones.
+func (c *ValidatorCache) fetch(ctx context.Context, state string, headFallback bool) <-chan singleflight.Result {
+	c.mu.RLock()
+	key := state + "/" + strconv.FormatUint(c.gen, 10)
+	c.mu.RUnlock()
 
-	resp := make(ActiveValidators)
+	ctx = context.WithoutCancel(ctx)
 
-	for _, val := range vals {
-		if val == nil || val.Validator == nil {
-			return nil, nil, errors.New("validator data cannot be nil")
-		}
+	return c.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-		if !val.Status.IsActive() {
-			continue
-		}
+		go func() {
+			select {
+			case <-c.shutdown:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
 
-		resp[val.Index] = val.Validator.PublicKey
-	}
+		c.mu.Lock()
+		c.started++
+		gen, seq := c.gen, c.started
+		c.mu.Unlock()
 
-	c.active = resp
-	c.complete = eth2Resp.Data
+		refreshedBySlot := true
 
-	return resp, eth2Resp.Data, nil
-}
+		opts := &eth2api.ValidatorsOpts{
+			State:   state,
+			PubKeys: c.pubkeys,
+		}
 
-// GetBySlot fetches active and complete validator by slot populating the cache.
-// If it fails to fetch by slot, it falls back to head state and retries to fetch by slot next slot.
-func (c *ValidatorCache) GetBySlot(ctx context.Context, slot uint64) (ActiveValidators, CompleteValidators, bool, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+		eth2Resp, err := c.eth2Cl.Validators(ctx, opts)
+		if err != nil && headFallback {
+			// Failed to fetch by slot, fall back to head state
+			refreshedBySlot = false
+			opts.State = "head"
 
-	refreshedBySlot := true
+			eth2Resp, err = c.eth2Cl.Validators(ctx, opts)
+		}
 
-	opts := &eth2api.ValidatorsOpts{
-		State:   strconv.FormatUint(slot, 10),
-		PubKeys: c.pubkeys,
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	eth2Resp, err := c.eth2Cl.Validators(ctx, opts)
-	if err != nil {
-		// Failed to fetch by slot, fall back to head state
-		refreshedBySlot = false
-		opts.State = "head"
+		complete := CompleteValidators(eth2Resp.Data)
 
-		eth2Resp, err = c.eth2Cl.Validators(ctx, opts)
+		active, err := activeValidators(complete)
 		if err != nil {
-			return nil, nil, refreshedBySlot, err
+			return nil, err
 		}
-	}
 
-	complete := eth2Resp.Data
+		c.mu.Lock()
+		if gen == c.gen && seq > c.cachedBy {
+			c.active = active
+			c.complete = complete
+			c.stale = false
+			c.cachedBy = seq
+		}
+		c.mu.Unlock()
+
+		return valCacheResult{
+			active:          active,
+			complete:        complete,
+			refreshedBySlot: refreshedBySlot,
+		}, nil
+	})
+}
 
-	active := make(ActiveValidators)
+// activeValidators returns the active validators of the complete validators response.
+func activeValidators(complete CompleteValidators) (ActiveValidators, error) {
+	resp := make(ActiveValidators)
 
 	for _, val := range complete {
 		if val == nil || val.Validator == nil {
-			return nil, nil, refreshedBySlot, errors.New("validator data cannot be nil")
+			return nil, errors.New("validator data cannot be nil")
 		}
 
 		if !val.Status.IsActive() {
 			continue
 		}
 
-		active[val.Index] = val.Validator.PublicKey
+		resp[val.Index] = val.Validator.PublicKey
 	}
 
-	c.active = active
-	c.complete = complete
-
-	return active, complete, refreshedBySlot, nil
+	return resp, nil
 }
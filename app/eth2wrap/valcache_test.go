@@ -5,11 +5,15 @@ package eth2wrap_test
 import (
 	"context"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -47,10 +51,10 @@ func TestValidatorCache(t *testing.T) {
 	require.NoError(t, err)
 
 	// Configure it to return the set of validators if queried.
-	var queried int
+	var queried atomic.Int32
 
 	eth2Cl.ValidatorsFunc = func(ctx context.Context, opts *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
-		queried++
+		queried.Add(1)
 
 		require.Equal(t, "head", opts.State)
 		require.Equal(t, pubkeys, opts.PubKeys)
@@ -66,34 +70,203 @@ func TestValidatorCache(t *testing.T) {
 	actual, complete, err := valCache.GetByHead(ctx)
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
-	require.Equal(t, 1, queried)
+	require.EqualValues(t, 1, queried.Load())
 	require.Equal(t, completeExpected, complete)
 
 	// Check cache is used.
 	actual, complete, err = valCache.GetByHead(ctx)
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
-	require.Equal(t, 1, queried)
+	require.EqualValues(t, 1, queried.Load())
 	require.Equal(t, completeExpected, complete)
 
 	// Trim cache.
 	valCache.Trim()
 
-	// Check cache is populated again.
+	// Check stale cache is returned and populated again in the background.
 	actual, complete, err = valCache.GetByHead(ctx)
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
-	require.Equal(t, 2, queried)
 	require.Equal(t, completeExpected, complete)
+	require.Eventually(t, func() bool {
+		return queried.Load() == 2
+	}, time.Second, time.Millisecond)
 
 	// Check cache is used again.
 	actual, complete, err = valCache.GetByHead(ctx)
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
-	require.Equal(t, 2, queried)
+	require.EqualValues(t, 2, queried.Load())
 	require.Equal(t, completeExpected, complete)
 }
 
+func TestValidatorCacheSingleflight(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(context.Context, *eth2wrap.ValidatorCache) (eth2wrap.ActiveValidators, error)
+	}{
+		{
+			name: "by head",
+			get: func(ctx context.Context, valCache *eth2wrap.ValidatorCache) (eth2wrap.ActiveValidators, error) {
+				active, _, err := valCache.GetByHead(ctx)
+				return active, err
+			},
+		},
+		{
+			name: "by slot",
+			get: func(ctx context.Context, valCache *eth2wrap.ValidatorCache) (eth2wrap.ActiveValidators, error) {
+				active, _, _, err := valCache.GetBySlot(ctx, 32)
+				return active, err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			val := testutil.RandomValidator(t)
+
+			eth2Cl, err := beaconmock.New()
+			require.NoError(t, err)
+
+			var queried atomic.Int32
+
+			release := make(chan struct{})
+			eth2Cl.ValidatorsFunc = func(context.Context, *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+				queried.Add(1)
+				<-release
+
+				return beaconmock.ValidatorSet{val.Index: val}, nil
+			}
+
+			valCache := eth2wrap.NewValidatorCache(eth2Cl, []eth2p0.BLSPubKey{val.Validator.PublicKey})
+			defer valCache.Close()
+
+			const n = 10
+
+			var (
+				wg      sync.WaitGroup
+				waiting atomic.Int32
+			)
+			for range n {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					waiting.Add(1)
+					active, err := test.get(t.Context(), valCache)
+					assert.NoError(t, err)
+					assert.Len(t, active, 1)
+				}()
+			}
+
+			// Wait for all callers and the single upstream fetch to start before releasing it.
+			require.Eventually(t, func() bool {
+				return waiting.Load() == n && queried.Load() == 1
+			}, time.Second, time.Millisecond)
+			time.Sleep(10 * time.Millisecond) // Allow callers to join the ongoing fetch.
+			close(release)
+			wg.Wait()
+
+			require.EqualValues(t, 1, queried.Load())
+		})
+	}
+}
+
+func TestValidatorCacheTrimDuringFetch(t *testing.T) {
+	oldVal, newVal := testutil.RandomValidator(t), testutil.RandomValidator(t)
+
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var (
+		queried atomic.Int32
+		release = make(chan struct{})
+	)
+	eth2Cl.ValidatorsFunc = func(context.Context, *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+		if queried.Add(1) == 1 {
+			<-release // Block the first fetch until after Trim.
+			return beaconmock.ValidatorSet{oldVal.Index: oldVal}, nil
+		}
+
+		return beaconmock.ValidatorSet{newVal.Index: newVal}, nil
+	}
+
+	valCache := eth2wrap.NewValidatorCache(eth2Cl, nil)
+	defer valCache.Close()
+
+	// Fetch started before Trim.
+	oldCh := make(chan eth2wrap.ActiveValidators, 1)
+	go func() {
+		active, _, err := valCache.GetByHead(t.Context())
+		assert.NoError(t, err)
+		oldCh <- active
+	}()
+
+	require.Eventually(t, func() bool {
+		return queried.Load() == 1
+	}, time.Second, time.Millisecond)
+
+	valCache.Trim()
+
+	// Fetch started after Trim doesn't share the previous generation's fetch.
+	active, _, _, err := valCache.GetBySlot(t.Context(), 32)
+	require.NoError(t, err)
+	require.Contains(t, active, newVal.Index)
+
+	// The previous generation's fetch finishing later doesn't overwrite the cache.
+	close(release)
+	require.Contains(t, <-oldCh, oldVal.Index)
+
+	cached, ok := valCache.Cached()
+	require.True(t, ok)
+	require.Contains(t, cached, newVal.Index)
+	require.NotContains(t, cached, oldVal.Index)
+
+	// The cache isn't stale, so it is used without querying again.
+	active, _, err = valCache.GetByHead(t.Context())
+	require.NoError(t, err)
+	require.Contains(t, active, newVal.Index)
+	require.EqualValues(t, 2, queried.Load())
+}
+
+func TestValidatorCacheShutdown(t *testing.T) {
+	val := testutil.RandomValidator(t)
+
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	eth2Cl.ValidatorsFunc = func(ctx context.Context, _ *eth2api.ValidatorsOpts) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
+		<-ctx.Done() // Block until the upstream fetch is cancelled.
+
+		return nil, ctx.Err()
+	}
+
+	valCache := eth2wrap.NewValidatorCache(eth2Cl, []eth2p0.BLSPubKey{val.Validator.PublicKey})
+
+	// Cancelling a single caller doesn't cancel the shared upstream fetch.
+	callerCtx, callerCancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer callerCancel()
+
+	_, _, err = valCache.GetByHead(callerCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Close cancels the shared upstream fetch.
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := valCache.GetByHead(t.Context())
+		errCh <- err
+	}()
+
+	valCache.Close()
+
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		require.Fail(t, "upstream fetch not cancelled on shutdown")
+	}
+}
+
 func TestGetBySlot(t *testing.T) {
 	t.Run("successful fetch", func(t *testing.T) {
 		// Create a mock client.
@@ -237,3 +410,32 @@ func TestGetBySlot(t *testing.T) {
 		require.False(t, refreshedBySlot)
 	})
 }
+
+func TestNewValidatorStates(t *testing.T) {
+	val := testutil.RandomValidator(t)
+	val.Status = eth2v1.ValidatorStatePendingQueued
+	val.Validator.ActivationEpoch = 10
+	val.Validator.ExitEpoch = 20
+
+	states, err := eth2wrap.NewValidatorStates(eth2wrap.CompleteValidators{val.Index: val})
+	require.NoError(t, err)
+	require.Equal(t, eth2wrap.ValidatorStates{
+		val.Index: {
+			Index:            val.Index,
+			PubKey:           val.Validator.PublicKey,
+			Status:           val.Status,
+			ActivationEpoch:  10,
+			ExitEpoch:        20,
+			Balance:          val.Balance,
+			EffectiveBalance: val.Validator.EffectiveBalance,
+			Slashed:          val.Validator.Slashed,
+		},
+	}, states)
+
+	state := states[val.Index]
+	require.False(t, state.ActiveOrActivating(9))
+	require.True(t, state.ActiveOrActivating(10))
+
+	_, err = eth2wrap.NewValidatorStates(eth2wrap.CompleteValidators{val.Index: nil})
+	require.ErrorContains(t, err, "validator data cannot be nil")
+}
@@ -4,6 +4,9 @@ package featureset_test
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -69,3 +72,30 @@ func TestEnableGnosisBlockHotfixIfNotDisabled(t *testing.T) {
 		require.False(t, featureset.Enabled(featureset.GnosisBlockHotfix))
 	})
 }
+
+func TestStates(t *testing.T) {
+	setup(t)
+
+	featureset.EnableForT(t, featureset.Linear)
+
+	states := featureset.States()
+	for _, state := range states {
+		switch state.Feature {
+		case featureset.MockAlpha:
+			require.Equal(t, featureset.FeatureState{Feature: featureset.MockAlpha, Status: "alpha"}, state)
+		case featureset.Linear:
+			require.Equal(t, featureset.FeatureState{Feature: featureset.Linear, Status: "enable", Enabled: true}, state)
+		case featureset.EagerDoubleLinear:
+			require.Equal(t, featureset.FeatureState{Feature: featureset.EagerDoubleLinear, Status: "stable", Enabled: true}, state)
+		default:
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	featureset.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/features", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var served []featureset.FeatureState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, states, served)
+}
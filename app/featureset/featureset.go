@@ -3,7 +3,13 @@
 // Package featureset defines a set of global features and their rollout status.
 package featureset
 
-import "sync"
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
 
 //go:generate stringer -type=status -trimprefix=status
 
@@ -60,6 +66,9 @@ const (
 
 	// ProposalTimeout enables a longer first consensus round timeout of 1.5 seconds for proposal duty.
 	ProposalTimeout = "proposal_timeout"
+
+	// MockNegotiated is a mock optional protocol feature requiring cluster-wide negotiation for testing.
+	MockNegotiated Feature = "mock_negotiated"
 )
 
 var (
@@ -75,6 +84,7 @@ var (
 		SSEReorgDuties:       statusAlpha,
 		AttestationInclusion: statusAlpha,
 		ProposalTimeout:      statusAlpha,
+		MockNegotiated:       statusAlpha,
 		// Add all features and there status here.
 	}
 
@@ -91,3 +101,46 @@ func Enabled(feature Feature) bool {
 
 	return state[feature] >= minStatus
 }
+
+// FeatureState is the current state of a feature.
+type FeatureState struct {
+	Feature Feature `json:"feature"`
+	// Status is the rollout status of the feature, or enable/disable if explicitly overridden.
+	Status  string `json:"status"`
+	Enabled bool   `json:"enabled"`
+}
+
+// States returns the current state of all features ordered by name.
+func States() []FeatureState {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	var resp []FeatureState
+	for feature, s := range state {
+		resp = append(resp, FeatureState{
+			Feature: feature,
+			Status:  strings.ToLower(s.String()),
+			Enabled: s >= minStatus,
+		})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Feature < resp[j].Feature
+	})
+
+	return resp
+}
+
+// Handler returns a http handler serving the current state of all features as JSON.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.Marshal(States())
+		if err != nil {
+			http.Error(w, "something went wrong", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
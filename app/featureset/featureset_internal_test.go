@@ -19,6 +19,7 @@ func TestAllFeatureStatus(t *testing.T) {
 		Linear,
 		SSEReorgDuties,
 		AttestationInclusion,
+		MockNegotiated,
 	}
 
 	for _, feature := range features {
@@ -0,0 +1,25 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package featureset
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	clusterSupportGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "feature",
+		Name:      "cluster_support",
+		Help:      "Number of cluster peers (including this node) supporting the optional protocol feature",
+	}, []string{"feature"})
+
+	activeGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "feature",
+		Name:      "active",
+		Help:      "Set to 1 if the optional protocol feature is active since a quorum of cluster peers supports it, else 0",
+	}, []string{"feature"})
+)
@@ -0,0 +1,218 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package featureset
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// negotiated defines the optional protocol features that only activate once a quorum of the cluster supports them.
+// These are features changing the wire protocol between peers, which must therefore be supported by enough peers.
+// Protocols consuming these features must check Negotiated instead of Enabled.
+var negotiated = map[Feature]bool{
+	MockNegotiated: true,
+	// Add all optional protocol features here.
+}
+
+var (
+	// globalNegotiator is the negotiator of the running node, see RegisterNegotiator.
+	globalNegotiator   *Negotiator
+	globalNegotiatorMu sync.Mutex
+)
+
+// RegisterNegotiator registers the negotiator of the running node, which Negotiated consults.
+func RegisterNegotiator(n *Negotiator) {
+	globalNegotiatorMu.Lock()
+	defer globalNegotiatorMu.Unlock()
+
+	globalNegotiator = n
+}
+
+// Negotiated returns true if the optional protocol feature is active, i.e. enabled on this node and supported
+// by a quorum of cluster peers. It returns false if no negotiator is registered.
+func Negotiated(feature Feature) bool {
+	globalNegotiatorMu.Lock()
+	n := globalNegotiator
+	globalNegotiatorMu.Unlock()
+
+	if n == nil {
+		return false
+	}
+
+	return n.Active(feature)
+}
+
+// Advertised returns the optional protocol features enabled on this node, ordered by name.
+// These are advertised to peers for cluster-wide negotiation.
+func Advertised() []Feature {
+	var resp []Feature
+	for feature := range negotiated {
+		if Enabled(feature) {
+			resp = append(resp, feature)
+		}
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// NegotiatorOption configures a Negotiator.
+type NegotiatorOption func(*Negotiator)
+
+// WithMinPeerVersion returns an option that gates the activation of optional protocol features
+// until all cluster peers (including this node) run at least the minimum charon version.
+// This smooths rolling upgrades by deferring new wire features until the whole cluster is upgraded.
+func WithMinPeerVersion(minVersion version.SemVer) NegotiatorOption {
+	return func(n *Negotiator) {
+		n.minVersion = &minVersion
+	}
+}
+
+// NewNegotiator returns a new negotiator for the other cluster peers (by name) requiring quorum peers
+// (including this node) to support an optional protocol feature before activating it.
+func NewNegotiator(peers []string, quorum int, opts ...NegotiatorOption) *Negotiator {
+	n := &Negotiator{
+		peers:    peers,
+		quorum:   quorum,
+		features: make(map[string]map[Feature]bool),
+		versions: make(map[string]version.SemVer),
+		active:   make(map[Feature]bool),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.update(context.Background())
+
+	return n
+}
+
+// Negotiator tracks the optional protocol features supported by each peer in the cluster,
+// activating a feature only once a quorum of peers supports it.
+type Negotiator struct {
+	peers      []string // Other cluster peers, excluding this node.
+	quorum     int
+	minVersion *version.SemVer // Optional minimum version of all peers.
+
+	mu       sync.Mutex
+	features map[string]map[Feature]bool // Supported features by peer name.
+	versions map[string]version.SemVer   // Charon versions by peer name.
+	active   map[Feature]bool
+}
+
+// SetPeerFeatures sets the optional protocol features advertised by the peer.
+// Unknown features are ignored, since they are not supported by this node.
+func (n *Negotiator) SetPeerFeatures(ctx context.Context, peer string, features []string) {
+	supported := make(map[Feature]bool)
+	for _, f := range features {
+		if negotiated[Feature(f)] {
+			supported[Feature(f)] = true
+		}
+	}
+
+	n.mu.Lock()
+	n.features[peer] = supported
+	n.mu.Unlock()
+
+	n.update(ctx)
+}
+
+// SetPeerVersion sets the charon version of the peer, used to gate features on the minimum peer version.
+func (n *Negotiator) SetPeerVersion(ctx context.Context, peer string, peerVersion version.SemVer) {
+	n.mu.Lock()
+	if prev, ok := n.versions[peer]; ok && prev == peerVersion {
+		n.mu.Unlock()
+		return
+	}
+
+	n.versions[peer] = peerVersion
+	n.mu.Unlock()
+
+	n.update(ctx)
+}
+
+// Active returns true if the optional protocol feature is enabled on this node and supported by a quorum of peers.
+func (n *Negotiator) Active(feature Feature) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.active[feature]
+}
+
+// minVersionMet returns true if no minimum peer version is configured or if all peers
+// (including this node) are known to run at least the minimum version.
+// It must be called with the mutex held.
+func (n *Negotiator) minVersionMet() bool {
+	if n.minVersion == nil {
+		return true
+	}
+
+	if version.Compare(version.Version, *n.minVersion) < 0 {
+		return false
+	}
+
+	for _, peer := range n.peers {
+		peerVersion, ok := n.versions[peer]
+		if !ok || version.Compare(peerVersion, *n.minVersion) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// update recalculates the cluster support of all optional protocol features, logging activation changes.
+func (n *Negotiator) update(ctx context.Context) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	versionMet := n.minVersionMet()
+
+	for feature := range negotiated {
+		if !Enabled(feature) {
+			// Not supported by this node, so never active.
+			clusterSupportGauge.WithLabelValues(string(feature)).Set(0)
+			activeGauge.WithLabelValues(string(feature)).Set(0)
+
+			continue
+		}
+
+		support := 1 // This node.
+		for _, peer := range n.peers {
+			if n.features[peer][feature] {
+				support++
+			}
+		}
+
+		active := support >= n.quorum && versionMet
+		if active != n.active[feature] {
+			if active {
+				log.Info(ctx, "Optional protocol feature activated, supported by quorum peers", z.Str("feature", string(feature)), z.Int("support", support))
+			} else if !versionMet {
+				log.Warn(ctx, "Optional protocol feature deactivated, not all peers meet the minimum version", nil,
+					z.Str("feature", string(feature)), z.Str("min_version", n.minVersion.String()))
+			} else {
+				log.Warn(ctx, "Optional protocol feature deactivated, not supported by quorum peers", nil, z.Str("feature", string(feature)), z.Int("support", support))
+			}
+		}
+
+		n.active[feature] = active
+
+		clusterSupportGauge.WithLabelValues(string(feature)).Set(float64(support))
+
+		if active {
+			activeGauge.WithLabelValues(string(feature)).Set(1)
+		} else {
+			activeGauge.WithLabelValues(string(feature)).Set(0)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package featureset_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/version"
+)
+
+func TestNegotiator(t *testing.T) {
+	setup(t)
+
+	ctx := context.Background()
+
+	// Not enabled locally, so neither advertised nor activated.
+	require.Empty(t, featureset.Advertised())
+
+	negotiator := featureset.NewNegotiator([]string{"bravo", "charlie", "delta"}, 3)
+	negotiator.SetPeerFeatures(ctx, "bravo", []string{string(featureset.MockNegotiated)})
+	negotiator.SetPeerFeatures(ctx, "charlie", []string{string(featureset.MockNegotiated)})
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	featureset.EnableForT(t, featureset.MockNegotiated)
+	require.Equal(t, []featureset.Feature{featureset.MockNegotiated}, featureset.Advertised())
+
+	negotiator = featureset.NewNegotiator([]string{"bravo", "charlie", "delta"}, 3)
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	negotiator.SetPeerFeatures(ctx, "bravo", []string{string(featureset.MockNegotiated), "unknown"})
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	// Quorum of 3 including this node.
+	negotiator.SetPeerFeatures(ctx, "charlie", []string{string(featureset.MockNegotiated)})
+	require.True(t, negotiator.Active(featureset.MockNegotiated))
+
+	// Protocols consult the registered negotiator.
+	require.False(t, featureset.Negotiated(featureset.MockNegotiated))
+
+	featureset.RegisterNegotiator(negotiator)
+	t.Cleanup(func() { featureset.RegisterNegotiator(nil) })
+	require.True(t, featureset.Negotiated(featureset.MockNegotiated))
+
+	// Deactivated when a peer stops supporting it, e.g. after a downgrade.
+	negotiator.SetPeerFeatures(ctx, "bravo", nil)
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	// Non-negotiated features are never active.
+	negotiator.SetPeerFeatures(ctx, "delta", []string{string(featureset.Linear)})
+	require.False(t, negotiator.Active(featureset.Linear))
+}
+
+func TestNegotiatorMinPeerVersion(t *testing.T) {
+	setup(t)
+	featureset.EnableForT(t, featureset.MockNegotiated)
+
+	ctx := context.Background()
+	peers := []string{"bravo", "charlie", "delta"}
+	features := []string{string(featureset.MockNegotiated)}
+
+	minVersion := version.Version.Minor()
+	older, err := version.Parse("v1.0.0")
+	require.NoError(t, err)
+
+	negotiator := featureset.NewNegotiator(peers, 3, featureset.WithMinPeerVersion(minVersion))
+	negotiator.SetPeerFeatures(ctx, "bravo", features)
+	negotiator.SetPeerFeatures(ctx, "charlie", features)
+
+	// Quorum support, but versions of peers unknown.
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	negotiator.SetPeerVersion(ctx, "bravo", minVersion)
+	negotiator.SetPeerVersion(ctx, "charlie", minVersion)
+	negotiator.SetPeerVersion(ctx, "delta", older)
+
+	// All peers must meet the minimum version, not only quorum.
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+
+	// Activated once the last peer is upgraded.
+	negotiator.SetPeerVersion(ctx, "delta", version.Version)
+	require.True(t, negotiator.Active(featureset.MockNegotiated))
+
+	// Deactivated when a peer downgrades.
+	negotiator.SetPeerVersion(ctx, "bravo", older)
+	require.False(t, negotiator.Active(featureset.MockNegotiated))
+}
@@ -0,0 +1,49 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// maxNodeLabels is the maximum number of operator defined node labels.
+	maxNodeLabels = 16
+	// maxNodeLabelValueLen is the maximum length of operator defined node label values.
+	maxNodeLabelValueLen = 64
+)
+
+// nodeLabelName matches valid prometheus label names.
+var nodeLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// parseNodeLabels returns the operator defined node labels formatted as name=value,
+// ensuring they are valid metric labels that do not override the builtin labels.
+func parseNodeLabels(labels []string, builtin map[string]string) (map[string]string, error) {
+	if len(labels) > maxNodeLabels {
+		return nil, errors.New("too many labels", z.Int("max", maxNodeLabels))
+	}
+
+	resp := make(map[string]string)
+	for _, label := range labels {
+		name, value, ok := strings.Cut(label, "=")
+		if !ok || value == "" {
+			return nil, errors.New("invalid label, expected name=value", z.Str("label", label))
+		} else if !nodeLabelName.MatchString(name) || strings.HasPrefix(name, "__") {
+			return nil, errors.New("invalid label name", z.Str("name", name))
+		} else if len(value) > maxNodeLabelValueLen {
+			return nil, errors.New("label value too long", z.Str("name", name), z.Int("max", maxNodeLabelValueLen))
+		} else if _, ok := builtin[name]; ok {
+			return nil, errors.New("label name reserved", z.Str("name", name))
+		} else if _, ok := resp[name]; ok {
+			return nil, errors.New("duplicate label name", z.Str("name", name))
+		}
+
+		resp[name] = value
+	}
+
+	return resp, nil
+}
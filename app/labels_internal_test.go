@@ -0,0 +1,74 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeLabels(t *testing.T) {
+	builtin := map[string]string{"nickname": ""}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   map[string]string
+		err    string
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name:   "valid",
+			labels: []string{"region=eu-west", "fleet=a=b"},
+			want:   map[string]string{"region": "eu-west", "fleet": "a=b"},
+		},
+		{
+			name:   "missing value",
+			labels: []string{"region"},
+			err:    "invalid label, expected name=value",
+		},
+		{
+			name:   "invalid name",
+			labels: []string{"eu-region=a"},
+			err:    "invalid label name",
+		},
+		{
+			name:   "reserved prefix",
+			labels: []string{"__name__=a"},
+			err:    "invalid label name",
+		},
+		{
+			name:   "builtin",
+			labels: []string{"nickname=a"},
+			err:    "label name reserved",
+		},
+		{
+			name:   "duplicate",
+			labels: []string{"region=a", "region=b"},
+			err:    "duplicate label name",
+		},
+		{
+			name:   "value too long",
+			labels: []string{"region=" + strings.Repeat("a", maxNodeLabelValueLen+1)},
+			err:    "label value too long",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			labels, err := parseNodeLabels(test.labels, builtin)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, labels)
+		})
+	}
+}
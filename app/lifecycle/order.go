@@ -28,8 +28,11 @@ const (
 	StartScheduler
 	StartP2PEventCollector
 	StartPeerInfo
+	StartPeerBackup
 	StartParSigDB
 	StartStackSnipe
+	StartLogLevelSignal
+	StartTelemetry
 )
 
 // Global ordering of stop hooks; follows dependency tree from root to leaves.
@@ -40,6 +43,8 @@ const (
 	StopDutyDB
 	StopBeaconMock // Close this before validator API, since it can hold long-lived connections.
 	StopValidatorAPI
+	StopProxyRecorder
+	StopReplayRecorder
 	StopTracing // Low level services...
 	StopP2PPeerDB
 	StopP2PTCPNode
@@ -25,13 +25,16 @@ func _() {
 	_ = x[StartScheduler-12]
 	_ = x[StartP2PEventCollector-13]
 	_ = x[StartPeerInfo-14]
-	_ = x[StartParSigDB-15]
-	_ = x[StartStackSnipe-16]
+	_ = x[StartPeerBackup-15]
+	_ = x[StartParSigDB-16]
+	_ = x[StartStackSnipe-17]
+	_ = x[StartLogLevelSignal-18]
+	_ = x[StartTelemetry-19]
 }
 
-const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoParSigDBStackSnipe"
+const _OrderStart_name = "TrackerPrivkeyLockAggSigDBRelayMonitoringAPIDebugAPIValidatorAPIP2PPingP2PRoutersForceDirectConnsP2PConsensusSimulatorSchedulerP2PEventCollectorPeerInfoPeerBackupParSigDBStackSnipeLogLevelSignalTelemetry"
 
-var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 71, 81, 97, 109, 118, 127, 144, 152, 160, 170}
+var _OrderStart_index = [...]uint8{0, 7, 18, 26, 31, 44, 52, 64, 71, 81, 97, 109, 118, 127, 144, 152, 162, 170, 180, 194, 203}
 
 func (i OrderStart) String() string {
 	if i < 0 || i >= OrderStart(len(_OrderStart_index)-1) {
@@ -16,17 +16,19 @@ func _() {
 	_ = x[StopDutyDB-3]
 	_ = x[StopBeaconMock-4]
 	_ = x[StopValidatorAPI-5]
-	_ = x[StopTracing-6]
-	_ = x[StopP2PPeerDB-7]
-	_ = x[StopP2PTCPNode-8]
-	_ = x[StopP2PUDPNode-9]
-	_ = x[StopDebugAPI-10]
-	_ = x[StopMonitoringAPI-11]
+	_ = x[StopProxyRecorder-6]
+	_ = x[StopReplayRecorder-7]
+	_ = x[StopTracing-8]
+	_ = x[StopP2PPeerDB-9]
+	_ = x[StopP2PTCPNode-10]
+	_ = x[StopP2PUDPNode-11]
+	_ = x[StopDebugAPI-12]
+	_ = x[StopMonitoringAPI-13]
 }
 
-const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPI"
+const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPIProxyRecorderReplayRecorderTracingP2PPeerDBP2PTCPNodeP2PUDPNodeDebugAPIMonitoringAPI"
 
-var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 62, 71, 81, 91, 99, 112}
+var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 68, 82, 89, 98, 108, 118, 126, 139}
 
 func (i OrderStop) String() string {
 	if i < 0 || i >= OrderStop(len(_OrderStop_index)-1) {
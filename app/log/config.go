@@ -191,9 +191,11 @@ func InitLogger(config Config) error {
 		callerSkip++
 	}
 
+	levels.reset(level)
+
 	if config.Format == "console" {
 		cores := []zapcore.Core{
-			newConsoleLogger(level, color, writer),
+			newLevelCore(newConsoleLogger(zapcore.DebugLevel, color, writer)),
 		}
 
 		if config.LogOutputPath != "" {
@@ -207,10 +209,12 @@ func InitLogger(config Config) error {
 
 		logger = zap.New(zapcore.NewTee(cores...))
 	} else {
-		logger, err = newStructuredLogger(config.Format, level, color, writer, callerSkip)
+		structured, err := newStructuredLogger(config.Format, zapcore.DebugLevel, color, writer, callerSkip)
 		if err != nil {
 			return err
 		}
+
+		logger = structured.WithOptions(zap.WrapCore(newLevelCore))
 	}
 
 	if len(config.LokiAddresses) > 0 {
@@ -0,0 +1,293 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// levels is the global runtime log level state.
+var levels = newLevelState(zapcore.DebugLevel)
+
+// LevelState is the runtime log level state, the global level and the per topic overrides.
+type LevelState struct {
+	Level  string            `json:"level"`
+	Topics map[string]string `json:"topics,omitempty"`
+}
+
+// newLevelState returns a new level state with the provided configured level.
+func newLevelState(level zapcore.Level) *levelState {
+	return &levelState{
+		configured: level,
+		global:     level,
+		topics:     make(map[string]zapcore.Level),
+		min:        level,
+	}
+}
+
+// levelState stores the global log level and per topic overrides which can be changed at runtime.
+type levelState struct {
+	mu         sync.RWMutex
+	configured zapcore.Level // The level configured on startup.
+	global     zapcore.Level
+	topics     map[string]zapcore.Level
+	min        zapcore.Level // Minimum of global and topic levels.
+}
+
+// reset sets the configured and global level and clears topic overrides.
+func (s *levelState) reset(level zapcore.Level) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.configured = level
+	s.global = level
+	s.topics = make(map[string]zapcore.Level)
+	s.updateMinLocked()
+}
+
+// enabledMin returns true if the level is enabled for any topic.
+func (s *levelState) enabledMin(level zapcore.Level) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return level >= s.min
+}
+
+// enabled returns true if the level is enabled for the topic.
+func (s *levelState) enabled(topic string, level zapcore.Level) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if topicLevel, ok := s.topics[topic]; ok {
+		return level >= topicLevel
+	}
+
+	return level >= s.global
+}
+
+// updateMinLocked updates the minimum level. It must be called with the lock held.
+func (s *levelState) updateMinLocked() {
+	s.min = s.global
+	for _, level := range s.topics {
+		s.min = min(s.min, level)
+	}
+}
+
+// SetLevel sets the global log level at runtime.
+func SetLevel(level string) error {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return errors.Wrap(err, "parse level", z.Str("level", level))
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.global = lvl
+	levels.updateMinLocked()
+
+	return nil
+}
+
+// SetTopicLevel sets the log level of the topic at runtime, overriding the global level.
+// An empty level removes the override.
+func SetTopicLevel(topic string, level string) error {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if level == "" {
+		delete(levels.topics, topic)
+		levels.updateMinLocked()
+
+		return nil
+	}
+
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return errors.Wrap(err, "parse level", z.Str("level", level), z.Str("topic", topic))
+	}
+
+	levels.topics[topic] = lvl
+	levels.updateMinLocked()
+
+	return nil
+}
+
+// Levels returns the current runtime log level state.
+func Levels() LevelState {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	resp := LevelState{Level: levels.global.String()}
+	if len(levels.topics) > 0 {
+		resp.Topics = make(map[string]string)
+		for topic, level := range levels.topics {
+			resp.Topics[topic] = level.String()
+		}
+	}
+
+	return resp
+}
+
+// ToggleDebug toggles the global log level between debug and the configured level, returning the new level.
+func ToggleDebug() string {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if levels.global == zapcore.DebugLevel {
+		levels.global = levels.configured
+	} else {
+		levels.global = zapcore.DebugLevel
+	}
+
+	levels.updateMinLocked()
+
+	return levels.global.String()
+}
+
+// ToggleDebugOnSignal toggles the global log level between debug and the configured level
+// on every SIGUSR1 signal until the context is closed.
+func ToggleDebugOnSignal(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			Info(ctx, "Log level toggled by SIGUSR1", z.Str("level", ToggleDebug()))
+		}
+	}
+}
+
+// LevelHandler returns a http handler serving the runtime log level state on GET
+// and updating it on PUT or POST with a JSON LevelState body. Topics with empty levels are reset to the global level.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var req LevelState
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, "invalid json body", http.StatusBadRequest)
+				return
+			}
+
+			if err := updateLevels(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			Info(r.Context(), "Log level updated via API", z.Any("levels", Levels()))
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		b, err := json.Marshal(Levels())
+		if err != nil {
+			http.Error(w, "something went wrong", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+// updateLevels validates and applies the requested level state, leaving it unchanged on error.
+func updateLevels(req LevelState) error {
+	if req.Level != "" {
+		if _, err := zapcore.ParseLevel(req.Level); err != nil {
+			return errors.Wrap(err, "parse level", z.Str("level", req.Level))
+		}
+	}
+
+	for topic, level := range req.Topics {
+		if level == "" {
+			continue
+		}
+
+		if _, err := zapcore.ParseLevel(level); err != nil {
+			return errors.Wrap(err, "parse level", z.Str("level", level), z.Str("topic", topic))
+		}
+	}
+
+	if req.Level != "" {
+		if err := SetLevel(req.Level); err != nil {
+			return err
+		}
+	}
+
+	for topic, level := range req.Topics {
+		if err := SetTopicLevel(topic, level); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// levelCore wraps a zap core filtering entries by the runtime global and topic log levels.
+type levelCore struct {
+	zapcore.Core
+
+	topic string
+}
+
+// newLevelCore returns a core filtering entries by the runtime log levels.
+func newLevelCore(core zapcore.Core) zapcore.Core {
+	return levelCore{Core: core}
+}
+
+func (levelCore) Enabled(level zapcore.Level) bool {
+	return levels.enabledMin(level)
+}
+
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{
+		Core:  c.Core.With(fields),
+		topic: topicFromFields(fields, c.topic),
+	}
+}
+
+func (c levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+func (c levelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !levels.enabled(topicFromFields(fields, c.topic), ent.Level) {
+		return nil
+	}
+
+	return c.Core.Write(ent, fields)
+}
+
+// topicFromFields returns the topic field value or the default.
+func topicFromFields(fields []zapcore.Field, def string) string {
+	for _, f := range fields {
+		if f.Key == keyTopic {
+			return f.String
+		}
+	}
+
+	return def
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLevelCore(t *testing.T) {
+	levels.reset(zapcore.InfoLevel)
+	defer levels.reset(zapcore.DebugLevel)
+
+	obs, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLogger(context.Background(), zap.New(newLevelCore(obs)))
+
+	Debug(WithTopic(ctx, "bcast"), "dropped")
+	Info(WithTopic(ctx, "bcast"), "logged")
+	require.Equal(t, 1, logs.Len())
+
+	require.NoError(t, SetTopicLevel("bcast", "debug"))
+	Debug(WithTopic(ctx, "bcast"), "logged")
+	Debug(WithTopic(ctx, "sched"), "dropped")
+	require.Equal(t, 2, logs.Len())
+
+	require.NoError(t, SetTopicLevel("bcast", ""))
+	require.Equal(t, "debug", ToggleDebug())
+	Debug(WithTopic(ctx, "sched"), "logged")
+	require.Equal(t, 3, logs.Len())
+
+	require.Equal(t, "info", ToggleDebug())
+	Debug(WithTopic(ctx, "sched"), "dropped")
+	require.Equal(t, 3, logs.Len())
+
+	require.Error(t, SetLevel("invalid"))
+}
+
+func TestLevelHandler(t *testing.T) {
+	levels.reset(zapcore.InfoLevel)
+	defer levels.reset(zapcore.DebugLevel)
+
+	serve := func(method, body string) (int, LevelState) {
+		rec := httptest.NewRecorder()
+		LevelHandler().ServeHTTP(rec, httptest.NewRequest(method, "/debug/loglevel", strings.NewReader(body)))
+
+		var resp LevelState
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+
+		return rec.Code, resp
+	}
+
+	code, state := serve(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, LevelState{Level: "info"}, state)
+
+	code, state = serve(http.MethodPut, `{"level":"warn","topics":{"p2p":"debug"}}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, LevelState{Level: "warn", Topics: map[string]string{"p2p": "debug"}}, state)
+
+	// Invalid levels don't change the state.
+	code, _ = serve(http.MethodPut, `{"level":"debug","topics":{"p2p":"invalid"}}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, LevelState{Level: "warn", Topics: map[string]string{"p2p": "debug"}}, Levels())
+
+	code, state = serve(http.MethodPost, `{"topics":{"p2p":""}}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, LevelState{Level: "warn"}, state)
+
+	code, _ = serve(http.MethodDelete, "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
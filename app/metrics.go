@@ -3,10 +3,13 @@
 package app
 
 import (
+	"strings"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
 const (
@@ -39,6 +42,12 @@ var (
 		Help:      "Constant gauge with label set to current app version",
 	}, []string{"version"})
 
+	nodeInfoGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "charon",
+		Name:      "node_info",
+		Help:      "Constant gauge with labels set to the build info of the node; app version, git commit hash and comma-separated forks supported by the binary",
+	}, []string{"version", "git_hash", "supported_forks"})
+
 	peerNameGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "app",
 		Name:      "peer_name",
@@ -100,6 +109,30 @@ var (
 		Help:      "Number of validators in the cluster lock",
 	})
 
+	clusterBalanceGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cluster",
+		Name:      "balance_gwei",
+		Help:      "Sum of the balances of all validators in the cluster",
+	})
+
+	clusterEffectiveBalanceGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cluster",
+		Name:      "effective_balance_gwei",
+		Help:      "Sum of the effective balances of all validators in the cluster",
+	})
+
+	clusterValidatorStatusGauge = promauto.NewResetGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cluster",
+		Name:      "validators_by_status",
+		Help:      "Number of validators in the cluster by status group; pending, active, exiting, slashed, exited, withdrawn or unknown",
+	}, []string{"status"})
+
+	slashingBreakerGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Name:      "slashing_breaker_tripped",
+		Help:      "Set to 1 if the validator was observed as slashed and signing attestations and proposals was stopped until acknowledged by the operator",
+	}, []string{"pubkey"})
+
 	networkGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "cluster",
 		Name:      "network",
@@ -121,9 +154,20 @@ func initStartupMetrics(peerName string, threshold, numOperators, numValidators
 	hash, _ := version.GitCommit()
 	gitGauge.WithLabelValues(hash).Set(1)
 	versionGauge.WithLabelValues(version.Version.String()).Set(1)
+	nodeInfoGauge.WithLabelValues(version.Version.String(), hash, supportedForks()).Set(1)
 	peerNameGauge.WithLabelValues(peerName).Set(1)
 
 	thresholdGauge.Set(float64(threshold))
 	operatorsGauge.Set(float64(numOperators))
 	validatorsGauge.Set(float64(numValidators))
 }
+
+// supportedForks returns the comma-separated forks supported by this binary.
+func supportedForks() string {
+	var forks []string
+	for _, v := range eth2util.SupportedDataVersions() {
+		forks = append(forks, v.String())
+	}
+
+	return strings.Join(forks, ",")
+}
@@ -4,8 +4,12 @@ package app
 
 import (
 	"context"
+	"crypto/subtle"
 	"net/http"
 	"net/http/pprof"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,19 +22,39 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/obolnetwork/charon/app/alert"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/health"
 	"github.com/obolnetwork/charon/app/lifecycle"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
 )
 
 // bnFarBehindSlots is the no of slots that is considered to be too far behind the current beacon chain head.
 // Currently, it is set to 10 epochs (10 * 32 = 320 slots).
 const bnFarBehindSlots = 320
 
+// Limits of the monitoring and debug API servers.
+const (
+	monitoringReadTimeout    = 10 * time.Second
+	monitoringWriteTimeout   = time.Minute // Not applied to pprof profiles and traces, see monitoringStreamingPrefix.
+	monitoringIdleTimeout    = 2 * time.Minute
+	monitoringMaxHeaderBytes = 16 << 10
+	monitoringMaxBodyBytes   = 1 << 20
+)
+
+// monitoringStreamingPrefix is the path prefix of the pprof handlers exempt from the write timeout,
+// since CPU profiles and execution traces stream for the requested "seconds" duration.
+const monitoringStreamingPrefix = "/debug/pprof/"
+
+// monitoringProbePaths are the liveness and readiness probe endpoints not requiring basic auth.
+var monitoringProbePaths = []string{"/livez", "/readyz"}
+
 var (
 	errReadyUninitialised       = errors.New("ready check uninitialised")
 	errReadyInsufficientPeers   = errors.New("quorum peers not connected")
@@ -43,31 +67,79 @@ var (
 )
 
 // wireMonitoringAPI constructs the monitoring API and registers it with the life cycle manager.
-// It serves prometheus metrics, pprof profiling and the runtime enr.
-func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, debugAddr string,
+// It serves prometheus metrics, pprof profiling if enabled and the runtime enr.
+// It returns a function reporting whether charon's pipeline is ready, see pipelineReady.
+// If bindEarly, the listeners are bound immediately, allowing privileges to be dropped afterwards.
+// If basicAuth ("user:password") is not empty, it is required by both the monitoring and debug APIs, see newMonitoringServer.
+func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, debugAddr, basicAuth string, debugPprof, bindEarly bool,
 	tcpNode host.Host, eth2Cl eth2wrap.Client,
-	peerIDs []peer.ID, registry *prometheus.Registry, consensusDebugger http.Handler,
+	peerIDs []peer.ID, registry *prometheus.Registry, consensusDebugger, clusterSummary, consensusLeader, scoreboard, mismatchedShares, lastSeen, pendingDuties, nodeInfo, peerInfo, snapshots, beaconNodes, proposalRewards http.Handler,
 	pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
-	numValidators int,
-) {
+	numValidators int, gate *startupGate, chaos *p2p.Chaos, alerts *alert.Notifier, bnDownSlots int,
+) (func() bool, error) {
 	beaconNodeVersionMetric(ctx, eth2Cl, clockwork.NewRealClock())
 
 	mux := http.NewServeMux()
 
 	// Serve prometheus metrics wrapped with cluster and node identifiers.
-	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
-		registry, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
-	))
+	mux.Handle("/metrics", metricsHandler(registry))
 
 	// Serve monitoring endpoints
-	mux.Handle("/livez", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		writeResponse(w, http.StatusOK, "ok")
-	}))
+	mux.Handle("/livez", livezHandler())
+
+	// Serve cluster-wide aggregate validator balances and statuses.
+	mux.Handle("/cluster/summary", clusterSummary)
+
+	// Serve the deterministic consensus leader selection of duties, for auditing.
+	mux.Handle("/consensus/leader", consensusLeader)
+
+	// Serve the per-peer duty participation scoreboard.
+	mux.Handle("/peers/scoreboard", scoreboard)
+
+	// Serve the most recent validator client submissions of key shares belonging to other nodes.
+	mux.Handle("/validators/mismatched_keyshares", mismatchedShares)
+
+	// Serve when each validator client and validator public share was last seen in duties queries and submissions.
+	mux.Handle("/validators/last_seen", lastSeen)
+
+	// Serve the duties stored in the DutyDB still awaiting partial signatures from the validator client.
+	mux.Handle("/duties/pending", pendingDuties)
+
+	// Serve this node's ENR, addresses and relay reservations, for cluster bootstrapping support.
+	mux.Handle("/p2p/node", nodeInfo)
+
+	// Serve the nicknames and labels of all peers, for navigating clusters by name instead of ENR prefix.
+	mux.Handle("/peers/info", peerInfo)
+
+	// Serve the sync state, version and observed request stats of each configured beacon node, for comparing redundant beacon nodes.
+	mux.Handle("/monitoring/beacon_nodes", beaconNodes)
+
+	// Serve the realized execution rewards of the cluster's proposals by validator and month, for reporting rewards to delegators.
+	// Only tracked if an execution engine or builder relays are configured.
+	if proposalRewards != nil {
+		mux.Handle("/validators/proposal_rewards", proposalRewards)
+	}
+
+	// Serve the state of all feature flags, for auditing feature drift across nodes.
+	mux.Handle("/features", featureset.Handler())
+
+	// Serve pprof profiling on the monitoring port, for environments where the debug API isn't reachable.
+	if debugPprof {
+		registerPprof(mux)
+	}
 
 	readyErrFunc := startReadyChecker(ctx, tcpNode, eth2Cl, peerIDs, clockwork.NewRealClock(),
-		pubkeys, seenPubkeys, vapiCalls)
+		pubkeys, seenPubkeys, vapiCalls, alerts, bnDownSlots)
 
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		// The validator API isn't served while the startup gate is waiting.
+		if gate != nil {
+			if gateErr := gate.ReadyErr(); gateErr != nil {
+				writeResponse(w, http.StatusInternalServerError, gateErr.Error())
+				return
+			}
+		}
+
 		readyErr := readyErrFunc()
 		if readyErr != nil {
 			writeResponse(w, http.StatusInternalServerError, readyErr.Error())
@@ -77,11 +149,7 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		writeResponse(w, http.StatusOK, "ok")
 	})
 
-	server := &http.Server{
-		Addr:              promAddr,
-		Handler:           mux,
-		ReadHeaderTimeout: time.Second,
-	}
+	server := newMonitoringServer(ctx, promAddr, mux, basicAuth)
 
 	// Create and start health checker.
 	checker := health.NewChecker(health.Metadata{
@@ -96,31 +164,157 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, promAddr, d
 		// Serve sniffed consensus instances messages in gzipped protobuf format.
 		debugMux.Handle("/debug/consensus", consensusDebugger)
 
-		// Copied from net/http/pprof/pprof.go
-		debugMux.HandleFunc("/debug/pprof/", pprof.Index)
-		debugMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-		debugMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-		debugMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-		debugMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
-
-		debugServer := &http.Server{
-			Addr:              debugAddr,
-			Handler:           debugMux,
-			ReadHeaderTimeout: time.Second,
+		// Serve a JSON snapshot of the in-memory state of the core workflow components.
+		debugMux.Handle("/debug/snapshot", snapshots)
+
+		// Serve the p2p chaos testing rules, allowing them to be updated at runtime.
+		if chaos != nil {
+			debugMux.Handle("/debug/p2p/chaos", chaos)
+		}
+
+		// Serve the runtime log levels, allowing the global and per topic levels to be changed without restarting.
+		debugMux.Handle("/debug/loglevel", log.LevelHandler())
+
+		registerPprof(debugMux)
+
+		debugServer := newMonitoringServer(ctx, debugAddr, debugMux, basicAuth)
+
+		serveDebug, err := serveFunc(debugServer, bindEarly, "", "")
+		if err != nil {
+			return nil, err
 		}
 
-		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartDebugAPI, httpServeHook(debugServer.ListenAndServe))
+		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartDebugAPI, httpServeHook(serveDebug))
 		life.RegisterStop(lifecycle.StopDebugAPI, lifecycle.HookFunc(debugServer.Shutdown))
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, httpServeHook(server.ListenAndServe))
+	// The monitoring listener is optional, e.g. if the monitoring endpoints are served on the validator API port.
+	if promAddr != "" {
+		serve, err := serveFunc(server, bindEarly, "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, httpServeHook(serve))
+		life.RegisterStop(lifecycle.StopMonitoringAPI, lifecycle.HookFunc(server.Shutdown))
+	}
+
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartMonitoringAPI, lifecycle.HookFuncCtx(checker.Run))
-	life.RegisterStop(lifecycle.StopMonitoringAPI, lifecycle.HookFunc(server.Shutdown))
+
+	return func() bool {
+		return pipelineReady(readyErrFunc())
+	}, nil
+}
+
+// newMonitoringServer returns a monitoring or debug API server hardened against slow or oversized requests and
+// handler panics, since it is sometimes exposed cluster-wide. If basicAuth ("user:password") is not empty, it is
+// required by all endpoints except the liveness and readiness probes.
+func newMonitoringServer(ctx context.Context, addr string, handler http.Handler, basicAuth string) *http.Server {
+	user, password, _ := strings.Cut(basicAuth, ":")
+
+	return &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler { //nolint:errorlint // Sentinel panic value, not an error chain.
+						panic(rec)
+					}
+
+					log.Error(ctx, "Monitoring API handler panic recovered", errors.New("handler panic", z.Any("panic", rec)),
+						z.Str("path", r.URL.Path))
+					writeResponse(w, http.StatusInternalServerError, "internal server error")
+				}
+			}()
+
+			if basicAuth != "" && !slices.Contains(monitoringProbePaths, r.URL.Path) {
+				reqUser, reqPassword, ok := r.BasicAuth()
+				if !ok || subtle.ConstantTimeCompare([]byte(reqUser), []byte(user)) != 1 ||
+					subtle.ConstantTimeCompare([]byte(reqPassword), []byte(password)) != 1 {
+					w.Header().Set("WWW-Authenticate", `Basic realm="charon"`)
+					writeResponse(w, http.StatusUnauthorized, "unauthorized")
+
+					return
+				}
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, monitoringMaxBodyBytes)
+
+			if strings.HasPrefix(r.URL.Path, monitoringStreamingPrefix) {
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Time{}) // Clear the write timeout.
+			}
+
+			handler.ServeHTTP(w, r)
+		}),
+		ReadHeaderTimeout: time.Second,
+		ReadTimeout:       monitoringReadTimeout,
+		WriteTimeout:      monitoringWriteTimeout,
+		IdleTimeout:       monitoringIdleTimeout,
+		MaxHeaderBytes:    monitoringMaxHeaderBytes,
+	}
+}
+
+// metricsHandler returns the prometheus metrics handler of the registry.
+func metricsHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		registry, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+	)
+}
+
+// livezHandler returns the liveness handler, always responding ok.
+func livezHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeResponse(w, http.StatusOK, "ok")
+	})
+}
+
+// vapiMonitoringPaths are the monitoring endpoints optionally served on the validator API port.
+var vapiMonitoringPaths = []string{"/metrics", "/livez"}
+
+// newVAPIMonitoringHandler returns a handler serving the metrics and liveness endpoints on the validator API port,
+// for deployments exposing a single port. It requires the bearer token if not empty.
+func newVAPIMonitoringHandler(registry *prometheus.Registry, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metricsHandler(registry))
+	mux.Handle("/livez", livezHandler())
+
+	if token == "" {
+		return mux
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			writeResponse(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// pipelineReady returns true if the ready check error doesn't prevent charon's pipeline from performing duties.
+// Validator client readiness errors are ignored since the validator clients query charon's own readiness.
+func pipelineReady(readyErr error) bool {
+	return readyErr == nil ||
+		errors.Is(readyErr, errReadyVCNotConnected) ||
+		errors.Is(readyErr, errReadyVCMissingVals)
+}
+
+// registerPprof registers the pprof profiling handlers with the mux.
+func registerPprof(mux *http.ServeMux) {
+	// Copied from net/http/pprof/pprof.go
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 }
 
 // startReadyChecker returns function which returns an error resulting from ready checks periodically.
+// It also alerts when quorum peers are lost or when the beacon node is unreachable for bnDownSlots consecutive slots.
 func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.Client, peerIDs []peer.ID,
 	clock clockwork.Clock, pubkeys []core.PubKey, seenPubkeys <-chan core.PubKey, vapiCalls <-chan struct{},
+	alerts *alert.Notifier, bnDownSlots int,
 ) func() error {
 	const minNotConnected = 6 // Require 6 rounds (1min) of too few connected
 
@@ -154,7 +348,10 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 		ticker := clock.NewTicker(slotDuration)
 		peerCountTicker := clock.NewTicker(1 * time.Minute)
 
-		var bnPeerCount *int // Beacon node peer count value which is queried every minute
+		var (
+			bnPeerCount *int // Beacon node peer count value which is queried every minute
+			bnDownCount int  // Consecutive slots the beacon node was unreachable
+		)
 
 		currVAPICount := 0
 		prevVAPICount := 1 // Assume connected.
@@ -192,6 +389,12 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 					notConnectedRounds = 0
 				} else {
 					notConnectedRounds++
+					if notConnectedRounds == minNotConnected {
+						alerts.Notify(ctx, alert.TypeQuorumLost, "Quorum peers not connected", map[string]string{
+							"peers":  strconv.Itoa(len(peerIDs)),
+							"quorum": strconv.Itoa(cluster.Threshold(len(peerIDs))),
+						})
+					}
 				}
 
 				evaluatedEpoch := currentEpochFunc()
@@ -202,6 +405,18 @@ func startReadyChecker(ctx context.Context, tcpNode host.Host, eth2Cl eth2wrap.C
 				}
 
 				syncing, syncDistance, err := beaconNodeSyncing(ctx, eth2Cl)
+				if err != nil {
+					bnDownCount++
+					if bnDownCount == bnDownSlots {
+						alerts.Notify(ctx, alert.TypeBeaconNodeUnreachable, "Beacon node unreachable", map[string]string{
+							"slots": strconv.Itoa(bnDownCount),
+							"error": err.Error(),
+						})
+					}
+				} else {
+					bnDownCount = 0
+				}
+
 				//nolint:revive // skip max-control-nesting for monitoring
 				if err != nil {
 					err = errReadyBeaconNodeDown
@@ -3,7 +3,11 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -12,8 +16,10 @@ import (
 	"github.com/jonboulle/clockwork"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/alert"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/testutil"
@@ -149,7 +155,7 @@ func TestStartChecker(t *testing.T) {
 			seenPubkeys := make(chan core.PubKey)
 			vapiCalls := make(chan struct{})
 			readyErrFunc := startReadyChecker(ctx, hosts[0], bmock, peers, clock,
-				pubkeys, seenPubkeys, vapiCalls)
+				pubkeys, seenPubkeys, vapiCalls, alert.New(nil, nil), 0)
 
 			for _, pubkey := range tt.seenPubkeys {
 				seenPubkeys <- pubkey
@@ -203,3 +209,122 @@ func advanceClock(t *testing.T, ctx context.Context, clock *clockwork.FakeClock,
 	err = clock.BlockUntilContext(ctx, numTickers)
 	require.NoError(t, err)
 }
+
+func TestVAPIMonitoringHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_vapi_monitoring_total"})
+	registry.MustRegister(counter)
+	counter.Inc()
+
+	tests := []struct {
+		name   string
+		token  string
+		path   string
+		auth   string
+		status int
+		body   string
+	}{
+		{name: "metrics", path: "/metrics", status: http.StatusOK, body: "test_vapi_monitoring_total 1"},
+		{name: "livez", path: "/livez", status: http.StatusOK, body: "ok"},
+		{name: "other path", path: "/readyz", status: http.StatusNotFound},
+		{name: "missing token", token: "secret", path: "/metrics", status: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", path: "/livez", auth: "Bearer wrong", status: http.StatusUnauthorized},
+		{name: "valid token", token: "secret", path: "/livez", auth: "Bearer secret", status: http.StatusOK, body: "ok"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.auth != "" {
+				req.Header.Set("Authorization", test.auth)
+			}
+
+			rec := httptest.NewRecorder()
+			newVAPIMonitoringHandler(registry, test.token).ServeHTTP(rec, req)
+
+			require.Equal(t, test.status, rec.Code)
+			require.Contains(t, rec.Body.String(), test.body)
+		})
+	}
+}
+
+func TestMonitoringServer(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", livezHandler())
+	mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})
+	mux.HandleFunc("/body", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			writeResponse(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+
+		writeResponse(w, http.StatusOK, "ok")
+	})
+
+	server := newMonitoringServer(context.Background(), "", mux, "admin:secret")
+	require.Equal(t, monitoringWriteTimeout, server.WriteTimeout)
+	require.Equal(t, monitoringReadTimeout, server.ReadTimeout)
+
+	tests := []struct {
+		name     string
+		path     string
+		user     string
+		password string
+		body     int
+		status   int
+	}{
+		{name: "probe without auth", path: "/livez", status: http.StatusOK},
+		{name: "missing auth", path: "/body", status: http.StatusUnauthorized},
+		{name: "wrong password", path: "/body", user: "admin", password: "wrong", status: http.StatusUnauthorized},
+		{name: "valid auth", path: "/body", user: "admin", password: "secret", body: 1024, status: http.StatusOK},
+		{name: "body too large", path: "/body", user: "admin", password: "secret", body: monitoringMaxBodyBytes + 1, status: http.StatusRequestEntityTooLarge},
+		{name: "panic recovered", path: "/panic", user: "admin", password: "secret", status: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, test.path, bytes.NewReader(make([]byte, test.body)))
+			if test.user != "" {
+				req.SetBasicAuth(test.user, test.password)
+			}
+
+			rec := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rec, req)
+
+			require.Equal(t, test.status, rec.Code)
+		})
+	}
+}
+
+func TestMonitoringServerPprofWriteTimeout(t *testing.T) {
+	const delay = 200 * time.Millisecond
+
+	slow := func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(delay)
+		writeResponse(w, http.StatusOK, "ok")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/profile", slow)
+	mux.HandleFunc("/slow", slow)
+
+	server := newMonitoringServer(context.Background(), "", mux, "")
+	server.WriteTimeout = delay / 4
+
+	srv := httptest.NewUnstartedServer(server.Handler)
+	srv.Config = server
+	srv.Start()
+	defer srv.Close()
+
+	// Profiles aren't cut off by the write timeout.
+	res, err := http.Get(srv.URL + "/debug/pprof/profile")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, res.Body.Close())
+
+	// Other endpoints are.
+	_, err = http.Get(srv.URL + "/slow")
+	require.Error(t, err)
+}
@@ -24,6 +24,9 @@ const (
 	defaultTimeout = 10 * time.Second
 )
 
+// ErrClientStatus is returned if the Obol API rejected a request with a 4xx status code, retrying it won't succeed.
+var ErrClientStatus = errors.New("client error status")
+
 // New returns a new Client.
 func New(urlStr string, options ...func(*Client)) (Client, error) {
 	_, err := url.ParseRequestURI(urlStr) // check that urlStr is valid
@@ -106,6 +109,10 @@ func httpPost(ctx context.Context, url *url.URL, body []byte, headers map[string
 			return errors.Wrap(err, "read POST response", z.Int("status", res.StatusCode))
 		}
 
+		if res.StatusCode/100 == 4 {
+			return errors.Wrap(ErrClientStatus, "http POST failed", z.Int("status", res.StatusCode), z.Str("body", string(data)))
+		}
+
 		return errors.New("http POST failed", z.Int("status", res.StatusCode), z.Str("body", string(data)))
 	}
 
@@ -0,0 +1,345 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package peerbackup implements an opt-in protocol where nodes store encrypted backups of each other's
+// non-secret cluster artifacts, e.g. the cluster lock, manifest and deposit data. A node that loses its disk
+// but retains its p2p private key can recover its artifacts from any peer.
+package peerbackup
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	pbv1 "github.com/obolnetwork/charon/app/peerbackup/peerbackuppb/v1"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	storeProtocolID protocol.ID = "/charon/peerbackup/store/1.0.0"
+	fetchProtocolID protocol.ID = "/charon/peerbackup/fetch/1.0.0"
+
+	initialDelay = time.Minute
+	period       = time.Hour
+
+	// maxBackupSize is the maximum size of a peer's encrypted backup stored by this node.
+	maxBackupSize = 16 << 20
+
+	// keyDomain separates the backup encryption key derived from the p2p private key.
+	keyDomain = "charon/peerbackup/v1"
+)
+
+// Protocols returns the supported protocols of this package in order of precedence.
+func Protocols() []protocol.ID {
+	return []protocol.ID{storeProtocolID, fetchProtocolID}
+}
+
+// Artifacts are the non-secret cluster artifact files of a node.
+type Artifacts struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Files     map[string][]byte `json:"files"` // File contents by base name.
+}
+
+// LoadArtifacts returns the artifacts of the provided files, ignoring files that do not exist.
+func LoadArtifacts(paths []string) (Artifacts, error) {
+	resp := Artifacts{
+		CreatedAt: time.Now().UTC(),
+		Files:     make(map[string][]byte),
+	}
+
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return Artifacts{}, errors.Wrap(err, "read artifact", z.Str("path", path))
+		}
+
+		resp.Files[filepath.Base(path)] = b
+	}
+
+	return resp, nil
+}
+
+// WriteFiles writes the artifact files to the directory, refusing to overwrite existing files.
+func (a Artifacts) WriteFiles(dir string) error {
+	for name, b := range a.Files {
+		path := filepath.Join(dir, filepath.Base(name))
+
+		//nolint:gosec // Non-secret artifacts.
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return errors.Wrap(err, "create artifact file", z.Str("path", path))
+		}
+
+		if _, err := f.Write(b); err != nil {
+			_ = f.Close()
+			return errors.Wrap(err, "write artifact file", z.Str("path", path))
+		}
+
+		if err := f.Close(); err != nil {
+			return errors.Wrap(err, "close artifact file", z.Str("path", path))
+		}
+	}
+
+	return nil
+}
+
+// Encrypt returns the artifacts encrypted with a key derived from the p2p private key.
+func Encrypt(key *k1.PrivateKey, artifacts Artifacts) ([]byte, error) {
+	plaintext, err := json.Marshal(artifacts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal artifacts")
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt returns the artifacts decrypted with a key derived from the p2p private key.
+func Decrypt(key *k1.PrivateKey, ciphertext []byte) (Artifacts, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return Artifacts{}, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return Artifacts{}, errors.New("backup ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return Artifacts{}, errors.Wrap(err, "decrypt backup, encrypted with another key")
+	}
+
+	var resp Artifacts
+	if err := json.Unmarshal(plaintext, &resp); err != nil {
+		return Artifacts{}, errors.Wrap(err, "unmarshal artifacts")
+	}
+
+	return resp, nil
+}
+
+// newAEAD returns an AES-GCM cipher with a key derived from the p2p private key.
+func newAEAD(key *k1.PrivateKey) (cipher.AEAD, error) {
+	derived := sha256.Sum256(append([]byte(keyDomain), key.Serialize()...))
+
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new aes cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "new gcm cipher")
+	}
+
+	return aead, nil
+}
+
+// New returns a new peer backup instance storing the encrypted backups of peers in the directory
+// and sending this node's encrypted artifacts to peers when run.
+func New(tcpNode host.Host, peers []peer.ID, key *k1.PrivateKey, artifactPaths []string, dir string) (*PeerBackup, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create peer backup dir")
+	}
+
+	b := &PeerBackup{
+		tcpNode:       tcpNode,
+		peers:         peers,
+		key:           key,
+		artifactPaths: artifactPaths,
+		dir:           dir,
+		sendFunc:      p2p.Send,
+	}
+
+	p2p.RegisterHandler("peerbackup", tcpNode, storeProtocolID,
+		func() proto.Message { return new(pbv1.Backup) },
+		b.handleStore,
+	)
+
+	p2p.RegisterHandler("peerbackup", tcpNode, fetchProtocolID,
+		func() proto.Message { return new(pbv1.FetchRequest) },
+		b.handleFetch,
+	)
+
+	return b, nil
+}
+
+// PeerBackup stores encrypted backups of peers' artifacts and backs up this node's artifacts to peers.
+type PeerBackup struct {
+	tcpNode       host.Host
+	peers         []peer.ID
+	key           *k1.PrivateKey
+	artifactPaths []string
+	dir           string
+	sendFunc      p2p.SendFunc
+}
+
+// Run sends this node's encrypted artifacts to all peers periodically until the context is cancelled.
+func (b *PeerBackup) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "peerbackup")
+
+	timer := time.NewTimer(initialDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			b.sendOnce(ctx)
+			timer.Reset(period)
+		}
+	}
+}
+
+// sendOnce sends this node's encrypted artifacts to each peer.
+func (b *PeerBackup) sendOnce(ctx context.Context) {
+	artifacts, err := LoadArtifacts(b.artifactPaths)
+	if err != nil {
+		log.Warn(ctx, "Failed loading cluster artifacts for peer backup", err)
+		return
+	}
+
+	ciphertext, err := Encrypt(b.key, artifacts)
+	if err != nil {
+		log.Warn(ctx, "Failed encrypting cluster artifacts for peer backup", err)
+		return
+	}
+
+	for _, peerID := range b.peers {
+		if peerID == b.tcpNode.ID() {
+			continue
+		}
+
+		go func(peerID peer.ID) {
+			err := b.sendFunc(ctx, b.tcpNode, storeProtocolID, peerID, &pbv1.Backup{Ciphertext: ciphertext})
+			if err != nil {
+				log.Warn(ctx, "Failed sending peer backup", err, z.Str("peer", p2p.PeerName(peerID)))
+			}
+		}(peerID)
+	}
+}
+
+// handleStore stores the encrypted backup of a cluster peer.
+func (b *PeerBackup) handleStore(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
+	backup, ok := req.(*pbv1.Backup)
+	if !ok {
+		return nil, false, errors.New("invalid peer backup request")
+	}
+
+	if !slices.Contains(b.peers, peerID) {
+		return nil, false, errors.New("peer backup from unknown peer")
+	}
+
+	if len(backup.GetCiphertext()) > maxBackupSize {
+		return nil, false, errors.New("peer backup too large", z.Int("size", len(backup.GetCiphertext())))
+	}
+
+	path := b.backupPath(peerID)
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, backup.GetCiphertext(), 0o600); err != nil {
+		return nil, false, errors.Wrap(err, "write peer backup")
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, false, errors.Wrap(err, "rename peer backup")
+	}
+
+	log.Debug(ctx, "Stored peer backup", z.Int("size", len(backup.GetCiphertext())))
+
+	return nil, false, nil
+}
+
+// handleFetch returns the encrypted backup of the requesting cluster peer, or an empty backup if none is stored.
+func (b *PeerBackup) handleFetch(_ context.Context, peerID peer.ID, _ proto.Message) (proto.Message, bool, error) {
+	if !slices.Contains(b.peers, peerID) {
+		return nil, false, errors.New("peer backup fetch from unknown peer")
+	}
+
+	ciphertext, err := os.ReadFile(b.backupPath(peerID))
+	if errors.Is(err, os.ErrNotExist) {
+		return new(pbv1.Backup), true, nil
+	} else if err != nil {
+		return nil, false, errors.Wrap(err, "read peer backup")
+	}
+
+	return &pbv1.Backup{Ciphertext: ciphertext}, true, nil
+}
+
+// backupPath returns the path of the peer's stored backup.
+func (b *PeerBackup) backupPath(peerID peer.ID) string {
+	return filepath.Join(b.dir, peerID.String()+".backup")
+}
+
+// Fetch returns this node's most recent artifacts backed up by the peers. It returns an error if no peer stores a backup.
+func Fetch(ctx context.Context, tcpNode host.Host, peers []peer.ID, key *k1.PrivateKey) (Artifacts, error) {
+	var (
+		resp  Artifacts
+		found bool
+	)
+
+	for _, peerID := range peers {
+		if peerID == tcpNode.ID() {
+			continue
+		}
+
+		ctx := log.WithCtx(ctx, z.Str("peer", p2p.PeerName(peerID)))
+
+		backup := new(pbv1.Backup)
+		if err := p2p.SendReceive(ctx, tcpNode, peerID, new(pbv1.FetchRequest), backup, fetchProtocolID); err != nil {
+			log.Warn(ctx, "Failed fetching peer backup", err)
+			continue
+		} else if len(backup.GetCiphertext()) == 0 {
+			log.Info(ctx, "Peer stores no backup")
+			continue
+		}
+
+		artifacts, err := Decrypt(key, backup.GetCiphertext())
+		if err != nil {
+			log.Warn(ctx, "Failed decrypting peer backup", err)
+			continue
+		}
+
+		log.Info(ctx, "Fetched peer backup", z.Any("created_at", artifacts.CreatedAt), z.Int("files", len(artifacts.Files)))
+
+		if !found || artifacts.CreatedAt.After(resp.CreatedAt) {
+			resp = artifacts
+			found = true
+		}
+	}
+
+	if !found {
+		return Artifacts{}, errors.New("no peer backup found")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package peerbackup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+
+	artifacts := Artifacts{
+		CreatedAt: time.Unix(1700000000, 0).UTC(),
+		Files:     map[string][]byte{"cluster-lock.json": []byte(`{"lock_hash":"0x01"}`)},
+	}
+
+	ciphertext, err := Encrypt(key, artifacts)
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), "lock_hash")
+
+	decrypted, err := Decrypt(key, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, artifacts, decrypted)
+
+	_, err = Decrypt(testutil.GenerateInsecureK1Key(t, 1), ciphertext)
+	require.ErrorContains(t, err, "decrypt backup, encrypted with another key")
+
+	_, err = Decrypt(key, ciphertext[:4])
+	require.ErrorContains(t, err, "backup ciphertext too short")
+}
+
+func TestLoadWriteArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, []byte("lock"), 0o644))
+
+	artifacts, err := LoadArtifacts([]string{lockFile, filepath.Join(dir, "cluster-manifest.pb")})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"cluster-lock.json": []byte("lock")}, artifacts.Files)
+
+	outDir := t.TempDir()
+	require.NoError(t, artifacts.WriteFiles(outDir))
+
+	b, err := os.ReadFile(filepath.Join(outDir, "cluster-lock.json"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("lock"), b)
+
+	// Existing files are not overwritten.
+	require.ErrorContains(t, artifacts.WriteFiles(outDir), "create artifact file")
+}
+
+func TestPeerBackup(t *testing.T) {
+	ctx := context.Background()
+
+	const n = 3
+
+	var (
+		tcpNodes []host.Host
+		peers    []peer.ID
+	)
+
+	for i := range n {
+		tcpNode := testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), testutil.GenerateInsecureK1Key(t, i))
+		for _, other := range tcpNodes {
+			tcpNode.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			other.Peerstore().AddAddrs(tcpNode.ID(), tcpNode.Addrs(), peerstore.PermanentAddrTTL)
+		}
+
+		tcpNodes = append(tcpNodes, tcpNode)
+		peers = append(peers, tcpNode.ID())
+	}
+
+	dataDir := t.TempDir()
+	lockFile := filepath.Join(dataDir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, []byte("lock"), 0o644))
+
+	var (
+		backups    []*PeerBackup
+		backupDirs []string
+	)
+
+	for i := range n {
+		backupDir := t.TempDir()
+
+		backup, err := New(tcpNodes[i], peers, testutil.GenerateInsecureK1Key(t, i), []string{lockFile}, backupDir)
+		require.NoError(t, err)
+
+		backups = append(backups, backup)
+		backupDirs = append(backupDirs, backupDir)
+	}
+
+	// Node 0 has no backups stored by peers yet.
+	_, err := Fetch(ctx, tcpNodes[0], peers, testutil.GenerateInsecureK1Key(t, 0))
+	require.ErrorContains(t, err, "no peer backup found")
+
+	// Node 0 backs up its artifacts to all peers.
+	backups[0].sendOnce(ctx)
+
+	for _, dir := range backupDirs[1:] {
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(dir, peers[0].String()+".backup"))
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// Node 0 lost its disk, recovering its artifacts from peers.
+	require.NoError(t, os.Remove(lockFile))
+
+	artifacts, err := Fetch(ctx, tcpNodes[0], peers, testutil.GenerateInsecureK1Key(t, 0))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"cluster-lock.json": []byte("lock")}, artifacts.Files)
+
+	// Node 1 can't fetch node 0's backup, since peers only return the requesting peer's backup.
+	_, err = Fetch(ctx, tcpNodes[1], peers, testutil.GenerateInsecureK1Key(t, 1))
+	require.ErrorContains(t, err, "no peer backup found")
+}
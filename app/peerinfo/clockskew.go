@@ -0,0 +1,78 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package peerinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Option configures a PeerInfo instance.
+type Option func(*PeerInfo)
+
+// WithClockSkewThreshold returns an option that warns when a peer's clock offset exceeds the threshold.
+func WithClockSkewThreshold(threshold time.Duration) Option {
+	return func(p *PeerInfo) {
+		p.clockSkewThreshold = threshold
+	}
+}
+
+// WithBeaconSlotClock returns an option that enables measuring the local clock against the
+// beacon node's genesis time derived slot clock via HeadReceived.
+func WithBeaconSlotClock(genesisTime time.Time, slotDuration time.Duration) Option {
+	return func(p *PeerInfo) {
+		p.genesisTime = genesisTime
+		p.slotDuration = slotDuration
+	}
+}
+
+// checkPeerClockSkew instruments the peer clock offset and warns if it exceeds the threshold.
+// Peers with skewed clocks propose and time out consensus rounds at different times than the rest
+// of the cluster, a common silent cause of round changes.
+func (p *PeerInfo) checkPeerClockSkew(ctx context.Context, peerName string, clockOffset time.Duration) {
+	if p.clockSkewThreshold <= 0 || clockOffset.Abs() <= p.clockSkewThreshold {
+		return
+	}
+
+	peerClockSkewExceeded.WithLabelValues(peerName).Inc()
+
+	log.Warn(ctx, "Peer clock skew exceeds threshold, which causes consensus round changes; ensure peer clocks are synced via NTP", nil,
+		z.Str("peer", peerName),
+		z.Str("clock_offset", clockOffset.String()),
+		z.Str("threshold", p.clockSkewThreshold.String()),
+	)
+}
+
+// HeadReceived measures the local clock against the beacon node slot clock using the time a head event was received.
+// Since a block cannot become head before its slot starts, a head event received before its slot start (as per the
+// local clock) indicates the local clock lagging the beacon node slot clock by at least that much.
+func (p *PeerInfo) HeadReceived(ctx context.Context, slot uint64, received time.Time) {
+	if p.slotDuration <= 0 {
+		return
+	}
+
+	slotStart := p.genesisTime.Add(time.Duration(slot) * p.slotDuration)
+
+	var skew time.Duration
+	if received.Before(slotStart) {
+		skew = slotStart.Sub(received)
+	}
+
+	beaconClockSkew.Set(skew.Seconds())
+
+	if p.clockSkewThreshold <= 0 || skew <= p.clockSkewThreshold {
+		return
+	}
+
+	beaconClockSkewExceeded.Inc()
+
+	log.Warn(ctx, "Local clock lags beacon node slot clock beyond threshold, which causes consensus round changes; ensure the clock is synced via NTP", nil,
+		z.U64("slot", slot),
+		z.Str("clock_skew", skew.String()),
+		z.Str("threshold", p.clockSkewThreshold.String()),
+		p.beaconSkewFilter,
+	)
+}
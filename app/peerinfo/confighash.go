@@ -0,0 +1,81 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package peerinfo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// Names of the non-sensitive configuration compared with peers.
+const (
+	ConfigBuilder      = "builder"
+	ConfigGasLimit     = "gas_limit"
+	ConfigVersion      = "charon_version"
+	ConfigForkSchedule = "fork_schedule"
+)
+
+// ConfigHash returns the hash of the non-sensitive configuration values.
+func ConfigHash(values ...string) []byte {
+	h := sha256.New()
+	for _, value := range values {
+		_, _ = fmt.Fprintf(h, "%d:%s,", len(value), value)
+	}
+
+	return h.Sum(nil)
+}
+
+// WithConfigHashes returns an option that gossips the hashes of non-sensitive configuration with peers,
+// warning when peers disagree. Mixed configurations, e.g. builder settings or fork schedules, cause subtle duty failures.
+func WithConfigHashes(hashes map[string][]byte) Option {
+	return func(p *PeerInfo) {
+		p.configHashes = hashes
+		p.configFilters = make(map[string]z.Field)
+
+		for _, peerID := range p.peers {
+			p.configFilters[p2p.PeerName(peerID)] = log.Filter(log.WithFilterRateLimit(rate.Every(time.Hour)))
+		}
+	}
+}
+
+// checkPeerConfig instruments and warns about the peer's config hashes that differ from ours.
+// Config only known by one side, e.g. from older versions, isn't compared.
+func (p *PeerInfo) checkPeerConfig(ctx context.Context, peerName string, peerHashes map[string][]byte) {
+	var mismatches []string
+	for name, hash := range p.configHashes {
+		peerHash, ok := peerHashes[name]
+		if !ok {
+			continue
+		}
+
+		if bytes.Equal(hash, peerHash) {
+			peerConfigMismatchGauge.WithLabelValues(peerName, name).Set(0)
+			continue
+		}
+
+		peerConfigMismatchGauge.WithLabelValues(peerName, name).Set(1)
+		mismatches = append(mismatches, name)
+	}
+
+	if len(mismatches) == 0 {
+		return
+	}
+
+	slices.Sort(mismatches)
+
+	log.Warn(ctx, "Mismatching peer configuration; coordinate with the operator to align configuration", nil,
+		z.Str("peer", peerName),
+		z.Any("config", mismatches),
+		p.configFilters[peerName],
+	)
+}
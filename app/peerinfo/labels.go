@@ -0,0 +1,97 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package peerinfo
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// Peer is the human-friendly identity and version of a peer served by the peer info handler.
+type Peer struct {
+	Peer        string            `json:"peer"`
+	Nickname    string            `json:"nickname"`
+	Labels      map[string]string `json:"labels"`
+	Version     string            `json:"version"`
+	VersionSkew bool              `json:"version_skew"`
+}
+
+// WithLabels returns an option that exchanges the operator defined node labels with peers,
+// so peers can be identified by name and labels instead of ENR prefix.
+func WithLabels(labels map[string]string) Option {
+	return func(p *PeerInfo) {
+		p.labels = labels
+
+		p.nicknamesMu.Lock()
+		p.peerLabels[p2p.PeerName(p.tcpNode.ID())] = labels
+		p.nicknamesMu.Unlock()
+	}
+}
+
+// setPeerLabels stores the peer's labels, logging changes.
+func (p *PeerInfo) setPeerLabels(ctx context.Context, peerName string, labels map[string]string) {
+	p.nicknamesMu.Lock()
+	defer p.nicknamesMu.Unlock()
+
+	prev, ok := p.peerLabels[peerName]
+	p.peerLabels[peerName] = labels
+
+	if (!ok && len(labels) > 0) || (ok && !maps.Equal(prev, labels)) {
+		log.Info(ctx, "Peer labels", z.Str("peer", peerName), z.Any("labels", labels))
+	}
+}
+
+// Peers returns the nicknames, labels and versions of all peers in cluster order, including this node.
+// Version skew is true if the peer runs a different minor version than this node.
+func (p *PeerInfo) Peers() []Peer {
+	p.nicknamesMu.RLock()
+	defer p.nicknamesMu.RUnlock()
+
+	resp := make([]Peer, 0, len(p.peers))
+	for _, peerID := range p.peers {
+		name := p2p.PeerName(peerID)
+
+		labels := maps.Clone(p.peerLabels[name])
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+
+		peerVersion := p.peerVersions[name]
+
+		var skew bool
+		if peerSemVer, err := version.Parse(peerVersion); err == nil {
+			skew = versionSkew(p.version, peerSemVer)
+		}
+
+		resp = append(resp, Peer{
+			Peer:        name,
+			Nickname:    p.nicknames[name],
+			Labels:      labels,
+			Version:     peerVersion,
+			VersionSkew: skew,
+		})
+	}
+
+	return resp
+}
+
+// ServeHTTP serves the nicknames, labels and versions of all peers as JSON.
+func (p *PeerInfo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(p.Peers())
+	if err != nil {
+		log.Warn(r.Context(), "Error serving peer info", err)
+		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
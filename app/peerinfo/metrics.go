@@ -17,6 +17,27 @@ var (
 		ConstLabels: nil,
 	}, []string{"peer"})
 
+	peerClockSkewExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "clock_skew_exceeded_total",
+		Help:      "Total number of times a peer's clock offset exceeded the configured clock skew threshold",
+	}, []string{"peer"})
+
+	beaconClockSkew = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "beacon_clock_skew_seconds",
+		Help:      "Lower bound of the local clock lagging the beacon node's genesis time derived slot clock in seconds, measured via head events",
+	})
+
+	beaconClockSkewExceeded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "beacon_clock_skew_exceeded_total",
+		Help:      "Total number of times the local clock lagged the beacon node slot clock by more than the configured clock skew threshold",
+	})
+
 	peerVersion = promauto.NewResetGaugeVec(prometheus.GaugeOpts{
 		Namespace:   "app",
 		Subsystem:   "peerinfo",
@@ -54,6 +75,13 @@ var (
 		Help:      "Set to 1 if the peer's version is supported by (compatible with) the current version, else 0 if unsupported.",
 	}, []string{"peer"})
 
+	peerVersionSkewGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "version_skew",
+		Help:      "Set to 1 if the peer runs a different minor version than the current version, else 0.",
+	}, []string{"peer"})
+
 	peerBuilderAPIEnabledGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "app",
 		Subsystem: "peerinfo",
@@ -68,4 +96,11 @@ var (
 		Help:        "Constant gauge with nickname label set to peer's charon nickname.",
 		ConstLabels: nil,
 	}, []string{"peer", "peer_nickname"})
+
+	peerConfigMismatchGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "app",
+		Subsystem: "peerinfo",
+		Name:      "config_mismatch",
+		Help:      "Set to 1 if the peer's non-sensitive configuration hash differs from this node's, else 0. The config label is one of builder, gas_limit, charon_version or fork_schedule.",
+	}, []string{"peer", "config"})
 )
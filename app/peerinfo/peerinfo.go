@@ -14,10 +14,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	pbv1 "github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1"
 	"github.com/obolnetwork/charon/app/version"
@@ -45,7 +47,7 @@ type (
 
 // New returns a new peer info protocol instance.
 func New(tcpNode host.Host, peers []peer.ID, version version.SemVer, lockHash []byte, gitHash string,
-	sendFunc p2p.SendReceiveFunc, builderEnabled bool, nickname string,
+	sendFunc p2p.SendReceiveFunc, builderEnabled bool, nickname string, opts ...Option,
 ) *PeerInfo {
 	// Set own version, git hash and nickname and start time and metrics.
 	name := p2p.PeerName(tcpNode.ID())
@@ -70,7 +72,7 @@ func New(tcpNode host.Host, peers []peer.ID, version version.SemVer, lockHash []
 	}
 
 	return newInternal(tcpNode, peers, version, lockHash, gitHash, sendFunc, p2p.RegisterHandler,
-		tickerProvider, time.Now, newMetricsSubmitter(), builderEnabled, nickname)
+		tickerProvider, time.Now, newMetricsSubmitter(), builderEnabled, nickname, opts...)
 }
 
 // NewForT returns a new peer info protocol instance for testing only.
@@ -87,26 +89,10 @@ func NewForT(_ *testing.T, tcpNode host.Host, peers []peer.ID, version version.S
 func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, lockHash []byte, gitHash string,
 	sendFunc p2p.SendReceiveFunc, registerHandler p2p.RegisterHandlerFunc,
 	tickerProvider tickerProvider, nowFunc nowFunc, metricSubmitter metricSubmitter,
-	builderAPIEnabled bool, nickname string,
+	builderAPIEnabled bool, nickname string, opts ...Option,
 ) *PeerInfo {
 	startTime := timestamppb.New(nowFunc())
 
-	// Register a simple handler that returns our info and ignores the request.
-	registerHandler("peerinfo", tcpNode, protocolID2,
-		func() proto.Message { return new(pbv1.PeerInfo) },
-		func(context.Context, peer.ID, proto.Message) (proto.Message, bool, error) {
-			return &pbv1.PeerInfo{
-				CharonVersion:     version.String(),
-				LockHash:          lockHash,
-				GitHash:           gitHash,
-				SentAt:            timestamppb.New(nowFunc()),
-				StartedAt:         startTime,
-				BuilderApiEnabled: builderAPIEnabled,
-				Nickname:          nickname,
-			}, true, nil
-		},
-	)
-
 	// Maps peers to their nickname
 	nicknames := map[string]string{p2p.PeerName(tcpNode.ID()): nickname}
 
@@ -119,7 +105,7 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 		versionFilters[peerID] = log.Filter()
 	}
 
-	return &PeerInfo{
+	p := &PeerInfo{
 		sendFunc:          sendFunc,
 		tcpNode:           tcpNode,
 		peers:             peers,
@@ -133,7 +119,36 @@ func newInternal(tcpNode host.Host, peers []peer.ID, version version.SemVer, loc
 		lockHashFilters:   lockHashFilters,
 		versionFilters:    versionFilters,
 		nicknames:         nicknames,
+		peerVersions:      map[string]string{p2p.PeerName(tcpNode.ID()): version.String()},
+		peerLabels:        make(map[string]map[string]string),
+		beaconSkewFilter:  log.Filter(log.WithFilterRateLimit(rate.Every(time.Minute))),
 	}
+
+	// Apply options before registering the handler, since it reads the configured fields.
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	// Register a simple handler that returns our info and ignores the request.
+	registerHandler("peerinfo", tcpNode, protocolID2,
+		func() proto.Message { return new(pbv1.PeerInfo) },
+		func(context.Context, peer.ID, proto.Message) (proto.Message, bool, error) {
+			return &pbv1.PeerInfo{
+				CharonVersion:     version.String(),
+				LockHash:          lockHash,
+				GitHash:           gitHash,
+				SentAt:            timestamppb.New(nowFunc()),
+				StartedAt:         startTime,
+				BuilderApiEnabled: builderAPIEnabled,
+				Nickname:          nickname,
+				Features:          advertisedFeatures(),
+				ConfigHashes:      p.configHashes,
+				Labels:            p.labels,
+			}, true, nil
+		},
+	)
+
+	return p
 }
 
 type PeerInfo struct {
@@ -151,7 +166,20 @@ type PeerInfo struct {
 	lockHashFilters   map[peer.ID]z.Field
 	versionFilters    map[peer.ID]z.Field
 	nicknames         map[string]string
+	peerLabels        map[string]map[string]string // Also protected by nicknamesMu.
+	peerVersions      map[string]string            // Also protected by nicknamesMu.
 	nicknamesMu       sync.RWMutex
+	labels            map[string]string
+
+	clockSkewThreshold time.Duration
+	genesisTime        time.Time
+	slotDuration       time.Duration
+	beaconSkewFilter   z.Field
+
+	negotiator *featureset.Negotiator
+
+	configHashes  map[string][]byte
+	configFilters map[string]z.Field
 }
 
 // Run runs the peer info protocol until the context is cancelled.
@@ -186,6 +214,9 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			StartedAt:         p.startTime,
 			BuilderApiEnabled: p.builderAPIEnabled,
 			Nickname:          p.nicknames[p2p.PeerName(p.tcpNode.ID())],
+			Features:          advertisedFeatures(),
+			ConfigHashes:      p.configHashes,
+			Labels:            p.labels,
 		}
 
 		go func(peerID peer.ID) {
@@ -217,6 +248,8 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 
 			p.nicknamesMu.Unlock()
 
+			p.setPeerLabels(ctx, name, resp.GetLabels())
+
 			// Validator git hash with regex.
 			if !gitHashMatch.MatchString(resp.GetGitHash()) {
 				log.Warn(ctx, "Invalid peer git hash", nil, z.Str("peer", name))
@@ -227,6 +260,8 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			actualSentAt := resp.GetSentAt().AsTime()
 			clockOffset := actualSentAt.Sub(expectedSentAt)
 
+			p.checkPeerVersion(ctx, name, resp.GetCharonVersion())
+
 			if err := supportedPeerVersion(resp.GetCharonVersion(), version.Supported()); err != nil {
 				peerCompatibleGauge.WithLabelValues(name).Set(0) // Set to false
 
@@ -244,6 +279,14 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 			// Set peer compatibility to true.
 			peerCompatibleGauge.WithLabelValues(name).Set(1)
 
+			p.checkPeerClockSkew(ctx, name, clockOffset)
+
+			if p.negotiator != nil {
+				p.negotiator.SetPeerFeatures(ctx, name, resp.GetFeatures())
+			}
+
+			p.checkPeerConfig(ctx, name, resp.GetConfigHashes())
+
 			p.metricSubmitter(peerID, clockOffset, resp.GetCharonVersion(), resp.GetGitHash(), resp.GetStartedAt().AsTime(), resp.GetBuilderApiEnabled(), resp.GetNickname())
 
 			// Log unexpected lock hash
@@ -267,6 +310,23 @@ func (p *PeerInfo) sendOnce(ctx context.Context, now time.Time) {
 	}
 }
 
+// WithFeatureNegotiator returns an option that negotiates optional protocol features with peers.
+func WithFeatureNegotiator(negotiator *featureset.Negotiator) Option {
+	return func(p *PeerInfo) {
+		p.negotiator = negotiator
+	}
+}
+
+// advertisedFeatures returns the optional protocol features advertised to peers.
+func advertisedFeatures() []string {
+	var resp []string
+	for _, feature := range featureset.Advertised() {
+		resp = append(resp, string(feature))
+	}
+
+	return resp
+}
+
 // instrumentPeerVersion instruments the peer version.
 func supportedPeerVersion(peerVersion string, supported []version.SemVer) error {
 	peerSemVer, err := version.Parse(peerVersion)
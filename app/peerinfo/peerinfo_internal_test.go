@@ -3,14 +3,20 @@
 package peerinfo
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/testutil"
@@ -109,3 +115,135 @@ func semvers(s ...string) []version.SemVer {
 
 	return resp
 }
+
+func TestClockSkew(t *testing.T) {
+	genesis := time.Unix(1_600_000_000, 0)
+	p := &PeerInfo{beaconSkewFilter: log.Filter()}
+	WithClockSkewThreshold(time.Second)(p)
+	WithBeaconSlotClock(genesis, 12*time.Second)(p)
+
+	ctx := context.Background()
+	peerName := "skewed_peer"
+
+	p.checkPeerClockSkew(ctx, peerName, 500*time.Millisecond)
+	p.checkPeerClockSkew(ctx, peerName, -500*time.Millisecond)
+	require.InDelta(t, 0, promtestutil.ToFloat64(peerClockSkewExceeded.WithLabelValues(peerName)), 0)
+
+	p.checkPeerClockSkew(ctx, peerName, 2*time.Second)
+	p.checkPeerClockSkew(ctx, peerName, -2*time.Second)
+	require.InDelta(t, 2, promtestutil.ToFloat64(peerClockSkewExceeded.WithLabelValues(peerName)), 0)
+
+	slotStart := genesis.Add(10 * 12 * time.Second)
+
+	// Head received after slot start is not skewed.
+	p.HeadReceived(ctx, 10, slotStart.Add(4*time.Second))
+	require.InDelta(t, 0, promtestutil.ToFloat64(beaconClockSkew), 0)
+
+	// Head received before slot start indicates a lagging local clock.
+	before := promtestutil.ToFloat64(beaconClockSkewExceeded)
+	p.HeadReceived(ctx, 10, slotStart.Add(-500*time.Millisecond))
+	require.InDelta(t, 0.5, promtestutil.ToFloat64(beaconClockSkew), 0)
+	require.InDelta(t, before, promtestutil.ToFloat64(beaconClockSkewExceeded), 0)
+
+	p.HeadReceived(ctx, 10, slotStart.Add(-3*time.Second))
+	require.InDelta(t, 3, promtestutil.ToFloat64(beaconClockSkew), 0)
+	require.InDelta(t, before+1, promtestutil.ToFloat64(beaconClockSkewExceeded), 0)
+}
+
+func TestCheckPeerConfig(t *testing.T) {
+	peerID := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+	peerName := p2p.PeerName(peerID)
+
+	p := &PeerInfo{peers: []peer.ID{peerID}}
+	WithConfigHashes(map[string][]byte{
+		ConfigBuilder:      ConfigHash("true"),
+		ConfigGasLimit:     ConfigHash("30000000"),
+		ConfigForkSchedule: ConfigHash("0x00000000:0"),
+	})(p)
+
+	require.NotEqual(t, ConfigHash("ab", "c"), ConfigHash("a", "bc"))
+
+	p.checkPeerConfig(context.Background(), peerName, map[string][]byte{
+		ConfigBuilder:  ConfigHash("false"),
+		ConfigGasLimit: ConfigHash("30000000"),
+		ConfigVersion:  ConfigHash("v1.0.0"), // Not compared, unknown locally.
+	})
+
+	require.InDelta(t, 1, promtestutil.ToFloat64(peerConfigMismatchGauge.WithLabelValues(peerName, ConfigBuilder)), 0)
+	require.InDelta(t, 0, promtestutil.ToFloat64(peerConfigMismatchGauge.WithLabelValues(peerName, ConfigGasLimit)), 0)
+
+	p.checkPeerConfig(context.Background(), peerName, map[string][]byte{
+		ConfigBuilder: ConfigHash("true"),
+	})
+
+	require.InDelta(t, 0, promtestutil.ToFloat64(peerConfigMismatchGauge.WithLabelValues(peerName, ConfigBuilder)), 0)
+}
+
+func TestPeerLabels(t *testing.T) {
+	tcpNode := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	otherID := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+
+	self, other := p2p.PeerName(tcpNode.ID()), p2p.PeerName(otherID)
+
+	p := &PeerInfo{
+		tcpNode:    tcpNode,
+		peers:      []peer.ID{tcpNode.ID(), otherID},
+		nicknames:  map[string]string{self: "alice"},
+		peerLabels: make(map[string]map[string]string),
+	}
+	WithLabels(map[string]string{"region": "eu"})(p)
+
+	p.setPeerLabels(context.Background(), other, map[string]string{"region": "us", "fleet": "b"})
+
+	require.Equal(t, []Peer{
+		{Peer: self, Nickname: "alice", Labels: map[string]string{"region": "eu"}},
+		{Peer: other, Labels: map[string]string{"region": "us", "fleet": "b"}},
+	}, p.Peers())
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/peers/info", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var served []Peer
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, p.Peers(), served)
+}
+
+func TestCheckPeerVersion(t *testing.T) {
+	tcpNode := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	otherID := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+
+	self, other := p2p.PeerName(tcpNode.ID()), p2p.PeerName(otherID)
+
+	ownVersion, err := version.Parse("v1.5.1")
+	require.NoError(t, err)
+
+	p := &PeerInfo{
+		tcpNode:      tcpNode,
+		peers:        []peer.ID{tcpNode.ID(), otherID},
+		version:      ownVersion,
+		nicknames:    make(map[string]string),
+		peerLabels:   make(map[string]map[string]string),
+		peerVersions: map[string]string{self: ownVersion.String()},
+	}
+
+	ctx := context.Background()
+
+	// Different patch version isn't skew.
+	p.checkPeerVersion(ctx, other, "v1.5.0")
+	require.InDelta(t, 0, promtestutil.ToFloat64(peerVersionSkewGauge.WithLabelValues(other)), 0)
+
+	// Different minor version is skew.
+	p.checkPeerVersion(ctx, other, "v1.4.2")
+	require.InDelta(t, 1, promtestutil.ToFloat64(peerVersionSkewGauge.WithLabelValues(other)), 0)
+
+	require.Equal(t, []Peer{
+		{Peer: self, Labels: map[string]string{}, Version: "v1.5.1"},
+		{Peer: other, Labels: map[string]string{}, Version: "v1.4.2", VersionSkew: true},
+	}, p.Peers())
+
+	// Upgraded peer.
+	p.checkPeerVersion(ctx, other, "v1.5.1")
+	require.InDelta(t, 0, promtestutil.ToFloat64(peerVersionSkewGauge.WithLabelValues(other)), 0)
+	require.False(t, p.Peers()[1].VersionSkew)
+}
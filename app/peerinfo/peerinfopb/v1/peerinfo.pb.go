@@ -31,6 +31,9 @@ type PeerInfo struct {
 	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3,oneof" json:"started_at,omitempty"`
 	BuilderApiEnabled bool                   `protobuf:"varint,6,opt,name=builder_api_enabled,json=builderApiEnabled,proto3" json:"builder_api_enabled,omitempty"`
 	Nickname          string                 `protobuf:"bytes,7,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Features          []string               `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`                                                                                                       // Optional protocol features supported by the peer, see featureset.Negotiator.
+	ConfigHashes      map[string][]byte      `protobuf:"bytes,9,rep,name=config_hashes,json=configHashes,proto3" json:"config_hashes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Hashes of non-sensitive configuration by name, see peerinfo.WithConfigHashes.
+	Labels            map[string]string      `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                // Operator defined node labels, see peerinfo.WithLabels.
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -114,11 +117,32 @@ func (x *PeerInfo) GetNickname() string {
 	return ""
 }
 
+func (x *PeerInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *PeerInfo) GetConfigHashes() map[string][]byte {
+	if x != nil {
+		return x.ConfigHashes
+	}
+	return nil
+}
+
+func (x *PeerInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 var File_app_peerinfo_peerinfopb_v1_peerinfo_proto protoreflect.FileDescriptor
 
 const file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDesc = "" +
 	"\n" +
-	")app/peerinfo/peerinfopb/v1/peerinfo.proto\x12\x1aapp.peerinfo.peerinfopb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x02\n" +
+	")app/peerinfo/peerinfopb/v1/peerinfo.proto\x12\x1aapp.peerinfo.peerinfopb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x05\n" +
 	"\bPeerInfo\x12%\n" +
 	"\x0echaron_version\x18\x01 \x01(\tR\rcharonVersion\x12\x1b\n" +
 	"\tlock_hash\x18\x02 \x01(\fR\blockHash\x128\n" +
@@ -127,7 +151,17 @@ const file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\tstartedAt\x88\x01\x01\x12.\n" +
 	"\x13builder_api_enabled\x18\x06 \x01(\bR\x11builderApiEnabled\x12\x1a\n" +
-	"\bnickname\x18\a \x01(\tR\bnicknameB\n" +
+	"\bnickname\x18\a \x01(\tR\bnickname\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\tR\bfeatures\x12[\n" +
+	"\rconfig_hashes\x18\t \x03(\v26.app.peerinfo.peerinfopb.v1.PeerInfo.ConfigHashesEntryR\fconfigHashes\x12H\n" +
+	"\x06labels\x18\n" +
+	" \x03(\v20.app.peerinfo.peerinfopb.v1.PeerInfo.LabelsEntryR\x06labels\x1a?\n" +
+	"\x11ConfigHashesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
 	"\n" +
 	"\b_sent_atB\r\n" +
 	"\v_started_atB:Z8github.com/obolnetwork/charon/app/peerinfo/peerinfopb/v1b\x06proto3"
@@ -144,19 +178,23 @@ func file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDescGZIP() []byte {
 	return file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDescData
 }
 
-var file_app_peerinfo_peerinfopb_v1_peerinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_app_peerinfo_peerinfopb_v1_peerinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_app_peerinfo_peerinfopb_v1_peerinfo_proto_goTypes = []any{
 	(*PeerInfo)(nil),              // 0: app.peerinfo.peerinfopb.v1.PeerInfo
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	nil,                           // 1: app.peerinfo.peerinfopb.v1.PeerInfo.ConfigHashesEntry
+	nil,                           // 2: app.peerinfo.peerinfopb.v1.PeerInfo.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_app_peerinfo_peerinfopb_v1_peerinfo_proto_depIdxs = []int32{
-	1, // 0: app.peerinfo.peerinfopb.v1.PeerInfo.sent_at:type_name -> google.protobuf.Timestamp
-	1, // 1: app.peerinfo.peerinfopb.v1.PeerInfo.started_at:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: app.peerinfo.peerinfopb.v1.PeerInfo.sent_at:type_name -> google.protobuf.Timestamp
+	3, // 1: app.peerinfo.peerinfopb.v1.PeerInfo.started_at:type_name -> google.protobuf.Timestamp
+	1, // 2: app.peerinfo.peerinfopb.v1.PeerInfo.config_hashes:type_name -> app.peerinfo.peerinfopb.v1.PeerInfo.ConfigHashesEntry
+	2, // 3: app.peerinfo.peerinfopb.v1.PeerInfo.labels:type_name -> app.peerinfo.peerinfopb.v1.PeerInfo.LabelsEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_app_peerinfo_peerinfopb_v1_peerinfo_proto_init() }
@@ -171,7 +209,7 @@ func file_app_peerinfo_peerinfopb_v1_peerinfo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDesc), len(file_app_peerinfo_peerinfopb_v1_peerinfo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
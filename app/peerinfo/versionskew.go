@@ -0,0 +1,50 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package peerinfo
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// checkPeerVersion stores the peer's charon version and instruments version skew, i.e., the peer running
+// a different minor version than this node. Skew is expected during rolling upgrades, but
+// indicates an incomplete upgrade if it persists.
+func (p *PeerInfo) checkPeerVersion(ctx context.Context, peerName string, peerVersion string) {
+	p.nicknamesMu.Lock()
+	prev, ok := p.peerVersions[peerName]
+	p.peerVersions[peerName] = peerVersion
+	p.nicknamesMu.Unlock()
+
+	peerSemVer, err := version.Parse(peerVersion)
+	if err != nil {
+		return // Logging handled by supportedPeerVersion.
+	}
+
+	if p.negotiator != nil {
+		p.negotiator.SetPeerVersion(ctx, peerName, peerSemVer)
+	}
+
+	if !versionSkew(p.version, peerSemVer) {
+		peerVersionSkewGauge.WithLabelValues(peerName).Set(0)
+		return
+	}
+
+	peerVersionSkewGauge.WithLabelValues(peerName).Set(1)
+
+	if !ok || prev != peerVersion {
+		log.Warn(ctx, "Peer runs a different minor version, complete rolling upgrade of all nodes", nil,
+			z.Str("peer", peerName),
+			z.Str("peer_version", peerVersion),
+			z.Str("version", p.version.String()),
+		)
+	}
+}
+
+// versionSkew returns true if the versions have different minor versions.
+func versionSkew(a, b version.SemVer) bool {
+	return version.Compare(a.Minor(), b.Minor()) != 0
+}
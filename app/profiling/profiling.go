@@ -0,0 +1,195 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package profiling captures heap and CPU profiles into a ring buffer directory when triggered,
+// allowing performance regressions in production to be diagnosed post-hoc.
+package profiling
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/pprof"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	defaultMaxCaptures = 10
+	defaultCPUDuration = 10 * time.Second
+	defaultMinInterval = 5 * time.Minute
+
+	heapFile = "heap.pprof"
+	cpuFile  = "cpu.pprof"
+)
+
+// invalidChars matches characters not allowed in capture directory names.
+var invalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Option configures a Capturer.
+type Option func(*Capturer)
+
+// WithMaxCaptures configures the maximum number of captures retained in the ring buffer directory.
+func WithMaxCaptures(n int) Option {
+	return func(c *Capturer) {
+		c.maxCaptures = n
+	}
+}
+
+// WithCPUDuration configures the duration of captured CPU profiles.
+func WithCPUDuration(d time.Duration) Option {
+	return func(c *Capturer) {
+		c.cpuDuration = d
+	}
+}
+
+// WithMinInterval configures the minimum interval between captures.
+func WithMinInterval(d time.Duration) Option {
+	return func(c *Capturer) {
+		c.minInterval = d
+	}
+}
+
+// New returns a new Capturer writing profiles to the directory, creating it if it doesn't exist.
+func New(dir string, opts ...Option) (*Capturer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create profiling directory", z.Str("dir", dir))
+	}
+
+	c := &Capturer{
+		dir:         dir,
+		maxCaptures: defaultMaxCaptures,
+		cpuDuration: defaultCPUDuration,
+		minInterval: defaultMinInterval,
+		nowFunc:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Capturer captures heap and CPU profiles into a ring buffer directory, retaining the most recent captures.
+// Each capture is a subdirectory named by its timestamp and reason.
+type Capturer struct {
+	dir         string
+	maxCaptures int
+	cpuDuration time.Duration
+	minInterval time.Duration
+	nowFunc     func() time.Time
+
+	mu        sync.Mutex
+	capturing bool
+	last      time.Time
+}
+
+// Trigger starts capturing profiles in the background labelled with the reason. It returns false
+// if a capture is already in progress or the previous capture started less than the minimum interval ago.
+func (c *Capturer) Trigger(ctx context.Context, reason string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFunc()
+	if c.capturing || (!c.last.IsZero() && now.Sub(c.last) < c.minInterval) {
+		return false
+	}
+
+	c.capturing = true
+	c.last = now
+
+	name := now.UTC().Format("20060102T150405.000Z") + "-" + invalidChars.ReplaceAllString(reason, "_")
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			c.capturing = false
+			c.mu.Unlock()
+		}()
+
+		ctx := context.WithoutCancel(ctx)
+
+		if err := c.capture(ctx, name); err != nil {
+			log.Warn(ctx, "Profile capture failed", err, z.Str("reason", reason))
+			return
+		}
+
+		log.Info(ctx, "Captured heap and CPU profiles", z.Str("reason", reason), z.Str("dir", filepath.Join(c.dir, name)))
+	}()
+
+	return true
+}
+
+// capture writes heap and CPU profiles to the named capture directory and prunes old captures.
+func (c *Capturer) capture(ctx context.Context, name string) error {
+	dir := filepath.Join(c.dir, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "create capture directory")
+	}
+
+	if err := c.prune(); err != nil {
+		return err
+	}
+
+	heap, err := os.Create(filepath.Join(dir, heapFile))
+	if err != nil {
+		return errors.Wrap(err, "create heap profile")
+	}
+	defer heap.Close()
+
+	if err := pprof.Lookup("heap").WriteTo(heap, 0); err != nil {
+		return errors.Wrap(err, "write heap profile")
+	}
+
+	cpu, err := os.Create(filepath.Join(dir, cpuFile))
+	if err != nil {
+		return errors.Wrap(err, "create cpu profile")
+	}
+	defer cpu.Close()
+
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		return errors.Wrap(err, "start cpu profile, another may be in progress")
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(c.cpuDuration):
+	}
+
+	pprof.StopCPUProfile()
+
+	return nil
+}
+
+// prune deletes the oldest captures exceeding the maximum number of captures.
+func (c *Capturer) prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return errors.Wrap(err, "read profiling directory")
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	slices.Sort(names) // Names are prefixed by sortable timestamps.
+
+	for len(names) > c.maxCaptures {
+		if err := os.RemoveAll(filepath.Join(c.dir, names[0])); err != nil {
+			return errors.Wrap(err, "delete old capture")
+		}
+
+		names = names[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package profiling
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+
+	c, err := New(dir, WithMaxCaptures(2), WithCPUDuration(time.Millisecond), WithMinInterval(time.Minute))
+	require.NoError(t, err)
+
+	now := time.Unix(0, 0)
+	c.nowFunc = func() time.Time { return now }
+
+	waitIdle := func() {
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			return !c.capturing
+		}, time.Second*5, time.Millisecond)
+	}
+
+	require.True(t, c.Trigger(t.Context(), "attester/consensus"))
+	waitIdle()
+
+	// Rate limited by the minimum interval.
+	require.False(t, c.Trigger(t.Context(), "attester"))
+
+	for range 3 {
+		now = now.Add(time.Minute)
+		require.True(t, c.Trigger(t.Context(), "attester"))
+		waitIdle()
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2) // Oldest captures are pruned.
+
+	for _, entry := range entries {
+		require.FileExists(t, filepath.Join(dir, entry.Name(), heapFile))
+		require.FileExists(t, filepath.Join(dir, entry.Name(), cpuFile))
+	}
+}
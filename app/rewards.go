@@ -0,0 +1,156 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/builderapi"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+)
+
+const (
+	rewardSourceBalance = "fee_recipient_balance"
+	rewardSourceRelay   = "relay"
+)
+
+// newProposalRewards returns a tracker of the realized execution rewards of the cluster's proposals,
+// or nil if neither an execution engine nor builder relays are configured to determine them.
+func newProposalRewards(ctx context.Context, conf Config, cluster *manifestpb.Cluster, eth2Cl eth2wrap.Client,
+	eth1Cl eth1wrap.EthClientRunner,
+) (*tracker.Rewards, error) {
+	if conf.ExecutionEngineAddr == "" && len(conf.BuilderRelayAddrs) == 0 {
+		return nil, nil //nolint:nilnil // Rewards not tracked.
+	}
+
+	genesis, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch slots config")
+	}
+
+	feeRecipients := make(map[core.PubKey]string)
+
+	for _, val := range cluster.GetValidators() {
+		pubkey, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, err
+		}
+
+		corePubkey, err := core.PubKeyFromBytes(pubkey[:])
+		if err != nil {
+			return nil, err
+		}
+
+		feeRecipients[corePubkey] = val.GetFeeRecipientAddress()
+	}
+
+	var balanceCl eth1wrap.EthClientRunner
+	if conf.ExecutionEngineAddr != "" {
+		balanceCl = eth1Cl
+	}
+
+	return tracker.NewRewards(genesis, slotDuration,
+		proposalRewardFunc(feeRecipients, balanceCl, conf.BuilderRelayAddrs), conf.ProposalRewardsFile)
+}
+
+// proposalRewardFunc returns a function determining the realized execution reward of a proposal.
+// Builder payloads prefer the value reported by the relays that delivered them. Locally built payloads prefer
+// the balance delta of the validator's fee recipient over the payload's block, excluding the payload's withdrawals
+// to it, if an execution client is provided. Either falls back to the other source if it fails.
+func proposalRewardFunc(feeRecipients map[core.PubKey]string, eth1Cl eth1wrap.EthClientRunner, relayAddrs []string) tracker.RewardFunc {
+	balanceReward := func(ctx context.Context, pubkey core.PubKey, payload tracker.ExecutionPayload) (*big.Int, string, error) {
+		if eth1Cl == nil {
+			return nil, "", errors.New("no execution engine configured")
+		}
+
+		feeRecipient, ok := feeRecipients[pubkey]
+		if !ok || feeRecipient == "" {
+			feeRecipient = payload.FeeRecipient
+		}
+
+		delta, err := balanceDelta(ctx, eth1Cl, feeRecipient, payload.BlockNumber)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Withdrawals to the fee recipient are consensus layer rewards, not execution rewards.
+		for _, withdrawal := range payload.Withdrawals {
+			if strings.EqualFold(withdrawal.Address, feeRecipient) {
+				amount := new(big.Int).SetUint64(withdrawal.AmountGwei)
+				delta.Sub(delta, amount.Mul(amount, big.NewInt(1e9)))
+			}
+		}
+
+		return delta, rewardSourceBalance, nil
+	}
+
+	relayReward := func(ctx context.Context, slot uint64, payload tracker.ExecutionPayload) (*big.Int, string, error) {
+		if len(relayAddrs) == 0 {
+			return nil, "", errors.New("no builder relays configured")
+		}
+
+		reward, ok, err := builderapi.DeliveredPayloadValue(ctx, relayAddrs, slot, payload.BlockHash)
+		if err != nil {
+			return nil, "", err
+		} else if !ok {
+			return nil, "", errors.New("payload not delivered by builder relays")
+		}
+
+		return reward, rewardSourceRelay, nil
+	}
+
+	return func(ctx context.Context, pubkey core.PubKey, slot uint64, payload tracker.ExecutionPayload) (*big.Int, string, error) {
+		if len(relayAddrs) == 0 && eth1Cl == nil {
+			return nil, "", errors.New("no execution reward source, configure an execution engine or builder relays")
+		}
+
+		if payload.Blinded {
+			reward, source, err := relayReward(ctx, slot, payload)
+			if err == nil || eth1Cl == nil {
+				return reward, source, err
+			}
+
+			return balanceReward(ctx, pubkey, payload)
+		}
+
+		reward, source, err := balanceReward(ctx, pubkey, payload)
+		if err == nil || len(relayAddrs) == 0 {
+			return reward, source, err
+		}
+
+		return relayReward(ctx, slot, payload)
+	}
+}
+
+// balanceDelta returns the balance change of the address by the block. It is negative if the address
+// spent more than it received in the block.
+func balanceDelta(ctx context.Context, eth1Cl eth1wrap.EthClientRunner, address string, blockNumber uint64) (*big.Int, error) {
+	if blockNumber == 0 {
+		return nil, errors.New("genesis block has no balance delta")
+	}
+
+	before, err := eth1Cl.BalanceAt(ctx, address, blockNumber-1)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := eth1Cl.BalanceAt(ctx, address, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Sub(after, before), nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth1wrap/mocks"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/tracker"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestProposalRewardFunc(t *testing.T) {
+	const (
+		feeRecipient = "0x000000000000000000000000000000000000dEaD"
+		slot         = 123
+	)
+
+	ctx := context.Background()
+	pubkey := testutil.RandomCorePubKey(t)
+	feeRecipients := map[core.PubKey]string{pubkey: feeRecipient}
+
+	payload := tracker.ExecutionPayload{
+		BlockNumber:  100,
+		BlockHash:    testutil.RandomArray32(),
+		FeeRecipient: "0x0000000000000000000000000000000000000001", // Builder fee recipient.
+	}
+
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/relay/v1/data/bidtraces/proposer_payload_delivered", r.URL.Path)
+		require.Equal(t, fmt.Sprint(slot), r.URL.Query().Get("slot"))
+
+		_, _ = fmt.Fprintf(w, `[{"slot":"%d","block_hash":"%s","value":"42"}]`, slot, payload.BlockHash)
+	}))
+	defer relay.Close()
+
+	t.Run("fee recipient balance", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(99)).Return(big.NewInt(1000), nil).Once()
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(100)).Return(big.NewInt(1500), nil).Once()
+
+		reward, source, err := proposalRewardFunc(feeRecipients, eth1Cl, []string{relay.URL})(ctx, pubkey, slot, payload)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceBalance, source)
+		require.Equal(t, big.NewInt(500), reward)
+	})
+
+	t.Run("withdrawals excluded", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(99)).Return(big.NewInt(1000), nil).Once()
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(100)).Return(big.NewInt(2e9+1500), nil).Once()
+
+		withWithdrawals := payload
+		withWithdrawals.Withdrawals = []tracker.Withdrawal{
+			{Address: "0x000000000000000000000000000000000000dead", AmountGwei: 2},
+			{Address: "0x0000000000000000000000000000000000000002", AmountGwei: 5},
+		}
+
+		reward, source, err := proposalRewardFunc(feeRecipients, eth1Cl, nil)(ctx, pubkey, slot, withWithdrawals)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceBalance, source)
+		require.Equal(t, big.NewInt(500), reward)
+	})
+
+	t.Run("negative balance delta", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(99)).Return(big.NewInt(1500), nil).Once()
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(100)).Return(big.NewInt(1000), nil).Once()
+
+		reward, source, err := proposalRewardFunc(feeRecipients, eth1Cl, nil)(ctx, pubkey, slot, payload)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceBalance, source)
+		require.Equal(t, big.NewInt(-500), reward)
+	})
+
+	t.Run("blinded prefers relay", func(t *testing.T) {
+		blinded := payload
+		blinded.Blinded = true
+
+		reward, source, err := proposalRewardFunc(feeRecipients, mocks.NewEthClientRunner(t), []string{relay.URL})(ctx, pubkey, slot, blinded)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceRelay, source)
+		require.Equal(t, big.NewInt(42), reward)
+	})
+
+	t.Run("blinded balance fallback", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(99)).Return(big.NewInt(1000), nil).Once()
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(100)).Return(big.NewInt(1500), nil).Once()
+
+		blinded := payload
+		blinded.Blinded = true
+		blinded.BlockHash = testutil.RandomArray32()
+
+		reward, source, err := proposalRewardFunc(feeRecipients, eth1Cl, []string{relay.URL})(ctx, pubkey, slot, blinded)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceBalance, source)
+		require.Equal(t, big.NewInt(500), reward)
+	})
+
+	t.Run("relay fallback", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("BalanceAt", mock.Anything, feeRecipient, uint64(99)).Return(nil, errors.New("rpc down")).Once()
+
+		reward, source, err := proposalRewardFunc(feeRecipients, eth1Cl, []string{relay.URL})(ctx, pubkey, slot, payload)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceRelay, source)
+		require.Equal(t, big.NewInt(42), reward)
+	})
+
+	t.Run("relay only", func(t *testing.T) {
+		reward, source, err := proposalRewardFunc(feeRecipients, nil, []string{relay.URL})(ctx, pubkey, slot, payload)
+		require.NoError(t, err)
+		require.Equal(t, rewardSourceRelay, source)
+		require.Equal(t, big.NewInt(42), reward)
+	})
+
+	t.Run("not delivered by relay", func(t *testing.T) {
+		other := payload
+		other.BlockHash = testutil.RandomArray32()
+
+		_, _, err := proposalRewardFunc(feeRecipients, nil, []string{relay.URL})(ctx, pubkey, slot, other)
+		require.ErrorContains(t, err, "payload not delivered by builder relays")
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		_, _, err := proposalRewardFunc(feeRecipients, nil, nil)(ctx, pubkey, slot, payload)
+		require.ErrorContains(t, err, "no execution reward source")
+	})
+}
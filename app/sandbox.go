@@ -0,0 +1,325 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// sandboxed returns true if privileges are dropped after binding ports.
+func sandboxed(conf Config) bool {
+	return conf.SandboxUser != "" || conf.SandboxChroot != ""
+}
+
+// sandboxUser identifies the user and group privileges are dropped to.
+type sandboxUser struct {
+	UID int
+	GID int
+}
+
+// resolveSandboxUser returns the sandbox user of the "<user>[:<group>]" spec, where user and group
+// are names or numeric ids. The group defaults to the user's primary group.
+func resolveSandboxUser(spec string) (sandboxUser, error) {
+	userStr, groupStr, hasGroup := strings.Cut(spec, ":")
+
+	u, err := user.Lookup(userStr)
+	if err != nil {
+		var idErr error
+		if u, idErr = user.LookupId(userStr); idErr != nil {
+			return sandboxUser{}, errors.Wrap(err, "lookup sandbox user", z.Str("user", userStr))
+		}
+	}
+
+	gidStr := u.Gid
+	if hasGroup {
+		g, err := user.LookupGroup(groupStr)
+		if err != nil {
+			var idErr error
+			if g, idErr = user.LookupGroupId(groupStr); idErr != nil {
+				return sandboxUser{}, errors.Wrap(err, "lookup sandbox group", z.Str("group", groupStr))
+			}
+		}
+
+		gidStr = g.Gid
+	}
+
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return sandboxUser{}, errors.Wrap(err, "parse sandbox user id")
+	}
+
+	gid, err := strconv.Atoi(gidStr)
+	if err != nil {
+		return sandboxUser{}, errors.Wrap(err, "parse sandbox group id")
+	}
+
+	return sandboxUser{UID: uid, GID: gid}, nil
+}
+
+// verifyKeyPermissions returns an error if any of the key files or the files in the key directories
+// are accessible by other users or not owned by the expected user. Key directories may not be writable by other users.
+func verifyKeyPermissions(paths []string, ownerUID int) error {
+	verify := func(path string, info fs.FileInfo) error {
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if ok && int(stat.Uid) != ownerUID {
+			return errors.New("key file not owned by expected user, refusing to start",
+				z.Str("path", path), z.U64("owner", uint64(stat.Uid)), z.Int("expected_owner", ownerUID))
+		}
+
+		if info.IsDir() {
+			if info.Mode().Perm()&0o002 != 0 {
+				return errors.New("key directory writable by other users, refusing to start", z.Str("path", path), z.Str("mode", info.Mode().String()))
+			}
+
+			return nil
+		}
+
+		if info.Mode().Perm()&0o007 != 0 {
+			return errors.New("key file accessible by other users, refusing to start", z.Str("path", path), z.Str("mode", info.Mode().String()))
+		}
+
+		return nil
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrap(err, "stat key path", z.Str("path", path))
+		}
+
+		if err := verify(path, info); err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return errors.Wrap(err, "read key directory", z.Str("path", path))
+		}
+
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+
+			entryInfo, err := os.Stat(entryPath)
+			if err != nil {
+				return errors.Wrap(err, "stat key path", z.Str("path", entryPath))
+			}
+
+			if entryInfo.IsDir() {
+				continue
+			}
+
+			if err := verify(entryPath, entryInfo); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// keyPaths returns the configured key files and directories.
+func keyPaths(conf Config) []string {
+	resp := []string{conf.PrivKeyFile}
+	for _, dir := range []string{conf.SimnetValidatorKeysDir, conf.SyncMessageFallbackKeysDir, conf.AttestationFallbackKeysDir, conf.ProposalRehearsalKeysDir} {
+		if dir != "" {
+			resp = append(resp, dir)
+		}
+	}
+
+	return resp
+}
+
+// sandboxChrootFiles are the system files resolving DNS names that must exist within the chroot directory,
+// since they are read after changing the root directory.
+var sandboxChrootFiles = []string{"etc/resolv.conf", "etc/hosts"}
+
+// sandboxPaths prepares the configured paths for changing the root directory to the chroot directory,
+// before which the working directory must be changed to the chroot directory.
+// Paths only accessed before changing the root directory, e.g. keys, are made absolute.
+// Paths accessed afterwards are made relative to the chroot directory, so they resolve to the same files
+// before and after changing the root directory. It returns an error if any of them lie outside the chroot directory
+// or if the system files resolving DNS names are missing from the chroot directory.
+func sandboxPaths(conf *Config, privKeyLockFile *string) error {
+	chroot, err := filepath.Abs(conf.SandboxChroot)
+	if err != nil {
+		return errors.Wrap(err, "absolute sandbox chroot path")
+	}
+
+	for _, file := range sandboxChrootFiles {
+		if _, err := os.Stat(filepath.Join(chroot, file)); err != nil {
+			return errors.Wrap(err, "system file missing in sandbox-chroot, copy it into the chroot directory",
+				z.Str("file", "/"+file), z.Str("chroot", chroot))
+		}
+	}
+
+	conf.SandboxChroot = chroot
+
+	chrootPaths := []*string{
+		privKeyLockFile,
+		&conf.ProposalGuardFile,
+		&conf.ProcDirectory,
+		&conf.PprofCaptureDir,
+		&conf.PeerBackupDir,
+		&conf.ReplayRecordFile,
+		&conf.ProxyRecordFile,
+		&conf.ProposalRewardsFile,
+	}
+
+	absPaths := []*string{
+		&conf.PrivKeyFile,
+		&conf.SimnetValidatorKeysDir,
+		&conf.VCTLSCertFile,
+		&conf.VCTLSKeyFile,
+		&conf.VCAuthTokensFile,
+		&conf.VCProxyRewritesFile,
+		&conf.SyncMessageFallbackKeysDir,
+		&conf.AttestationFallbackKeysDir,
+		&conf.ProposalRehearsalKeysDir,
+	}
+
+	// Cluster artifacts are periodically read by peer backup.
+	if conf.PeerBackupDir != "" {
+		chrootPaths = append(chrootPaths, &conf.LockFile, &conf.ManifestFile)
+	} else {
+		absPaths = append(absPaths, &conf.LockFile, &conf.ManifestFile)
+	}
+
+	for _, path := range absPaths {
+		if *path == "" {
+			continue
+		}
+
+		if *path, err = filepath.Abs(*path); err != nil {
+			return errors.Wrap(err, "absolute path", z.Str("path", *path))
+		}
+	}
+
+	for _, path := range chrootPaths {
+		if *path == "" {
+			continue
+		}
+
+		abs, err := filepath.Abs(*path)
+		if err != nil {
+			return errors.Wrap(err, "absolute path", z.Str("path", *path))
+		}
+
+		rel, err := filepath.Rel(chroot, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return errors.New("path accessed after changing root directory lies outside sandbox-chroot",
+				z.Str("path", *path), z.Str("chroot", chroot))
+		}
+
+		*path = rel
+	}
+
+	return nil
+}
+
+// dropPrivileges changes the root directory to the chroot directory if not empty
+// and changes the user and group of the process to the sandbox user if not nil.
+// Both require running as root and must be done after binding privileged ports and loading keys.
+func dropPrivileges(ctx context.Context, chrootDir string, sUser *sandboxUser) error {
+	if os.Geteuid() != 0 {
+		return errors.New("dropping privileges requires running as root")
+	}
+
+	if chrootDir != "" {
+		// System TLS roots are loaded once on first use, so load them before they become inaccessible.
+		if _, err := x509.SystemCertPool(); err != nil {
+			return errors.Wrap(err, "load system tls roots")
+		}
+
+		if err := syscall.Chroot(chrootDir); err != nil {
+			return errors.Wrap(err, "chroot", z.Str("dir", chrootDir))
+		}
+
+		if err := os.Chdir("/"); err != nil {
+			return errors.Wrap(err, "chdir to chroot")
+		}
+	}
+
+	if sUser != nil {
+		// Group ids must be changed before the user id, which drops the privilege to do so.
+		if err := syscall.Setgroups([]int{sUser.GID}); err != nil {
+			return errors.Wrap(err, "set supplementary groups")
+		}
+
+		if err := syscall.Setgid(sUser.GID); err != nil {
+			return errors.Wrap(err, "set group id")
+		}
+
+		if err := syscall.Setuid(sUser.UID); err != nil {
+			return errors.Wrap(err, "set user id")
+		}
+	}
+
+	log.Info(ctx, "Dropped privileges after binding ports",
+		z.Str("chroot", chrootDir), z.Int("uid", os.Getuid()), z.Int("gid", os.Getgid()))
+
+	return nil
+}
+
+// serveFunc returns the server's serve function using TLS if the cert and key files are provided.
+// If bindEarly, the listener is bound and the TLS certificate is loaded immediately instead of when serving,
+// allowing privileges to be dropped after binding privileged ports.
+func serveFunc(server *http.Server, bindEarly bool, certFile, keyFile string) (func() error, error) {
+	useTLS := certFile != "" && keyFile != ""
+
+	if !bindEarly {
+		if useTLS {
+			return func() error {
+				return server.ListenAndServeTLS(certFile, keyFile)
+			}, nil
+		}
+
+		return server.ListenAndServe, nil
+	}
+
+	if useTLS {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key pair")
+		}
+
+		if server.TLSConfig == nil {
+			server.TLSConfig = new(tls.Config)
+		}
+
+		server.TLSConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	ln, err := new(net.ListenConfig).Listen(context.Background(), "tcp", server.Addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "bind listener", z.Str("addr", server.Addr))
+	}
+
+	if useTLS {
+		return func() error {
+			return server.ServeTLS(ln, "", "")
+		}, nil
+	}
+
+	return func() error {
+		return server.Serve(ln)
+	}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestResolveSandboxUser(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	uid, err := strconv.Atoi(current.Uid)
+	require.NoError(t, err)
+
+	gid, err := strconv.Atoi(current.Gid)
+	require.NoError(t, err)
+
+	for _, spec := range []string{current.Username, current.Uid, current.Username + ":" + current.Gid} {
+		u, err := resolveSandboxUser(spec)
+		require.NoError(t, err)
+		require.Equal(t, sandboxUser{UID: uid, GID: gid}, u)
+	}
+
+	_, err = resolveSandboxUser("charon-unknown-user")
+	require.ErrorContains(t, err, "lookup sandbox user")
+
+	_, err = resolveSandboxUser(current.Username + ":charon-unknown-group")
+	require.ErrorContains(t, err, "lookup sandbox group")
+}
+
+func TestVerifyKeyPermissions(t *testing.T) {
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "keys")
+	require.NoError(t, os.Mkdir(keysDir, 0o750))
+
+	keyFile := filepath.Join(dir, "charon-enr-private-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0o600))
+
+	keystore := filepath.Join(keysDir, "keystore-0.json")
+	require.NoError(t, os.WriteFile(keystore, []byte("{}"), 0o640))
+
+	owner := os.Geteuid()
+	paths := []string{keyFile, keysDir}
+
+	require.NoError(t, verifyKeyPermissions(paths, owner))
+
+	err := verifyKeyPermissions(paths, owner+1)
+	require.ErrorContains(t, err, "key file not owned by expected user")
+
+	require.NoError(t, os.Chmod(keystore, 0o644))
+	err = verifyKeyPermissions(paths, owner)
+	require.ErrorContains(t, err, "key file accessible by other users")
+
+	require.NoError(t, os.Chmod(keystore, 0o600))
+	require.NoError(t, os.Chmod(keysDir, 0o777))
+	err = verifyKeyPermissions(paths, owner)
+	require.ErrorContains(t, err, "key directory writable by other users")
+
+	err = verifyKeyPermissions([]string{filepath.Join(dir, "missing")}, owner)
+	require.ErrorContains(t, err, "stat key path")
+}
+
+func TestServeFuncBindEarly(t *testing.T) {
+	addr := testutil.AvailableAddr(t).String()
+
+	server := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	serve, err := serveFunc(server, true, "", "")
+	require.NoError(t, err)
+
+	// Address already bound before serving.
+	_, err = serveFunc(&http.Server{Addr: addr, ReadHeaderTimeout: time.Second}, true, "", "")
+	require.ErrorContains(t, err, "bind listener")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serve()
+	}()
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+addr, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, server.Close())
+	require.ErrorIs(t, <-errCh, http.ErrServerClosed)
+}
+
+func TestSandboxPaths(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	chroot := filepath.Join(dir, "chroot")
+	require.NoError(t, os.MkdirAll(filepath.Join(chroot, ".charon"), 0o755))
+
+	// Relative data dir within the chroot directory, keys outside it.
+	conf := Config{
+		SandboxChroot:          "chroot",
+		PrivKeyFile:            ".charon/charon-enr-private-key",
+		SimnetValidatorKeysDir: ".charon/validator_keys",
+		LockFile:               "chroot/.charon/cluster-lock.json",
+		ProposalGuardFile:      "chroot/.charon/proposal-guard.json",
+		PeerBackupDir:          "chroot/.charon/peer-backups",
+	}
+	privKeyLockFile := conf.PrivKeyFile + ".lock"
+
+	err := sandboxPaths(&conf, &privKeyLockFile)
+	require.ErrorContains(t, err, "system file missing in sandbox-chroot")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(chroot, "etc"), 0o755))
+	for _, file := range sandboxChrootFiles {
+		require.NoError(t, os.WriteFile(filepath.Join(chroot, file), nil, 0o644))
+	}
+
+	conf.SandboxChroot = "chroot"
+	err = sandboxPaths(&conf, &privKeyLockFile)
+	require.ErrorContains(t, err, "lies outside sandbox-chroot")
+
+	conf.SandboxChroot = "chroot"
+	privKeyLockFile = "chroot/.charon/charon-enr-private-key.lock"
+	require.NoError(t, sandboxPaths(&conf, &privKeyLockFile))
+
+	require.Equal(t, chroot, conf.SandboxChroot)
+	require.Equal(t, filepath.Join(dir, ".charon/charon-enr-private-key"), conf.PrivKeyFile)
+	require.Equal(t, filepath.Join(dir, ".charon/validator_keys"), conf.SimnetValidatorKeysDir)
+	require.Equal(t, ".charon/charon-enr-private-key.lock", privKeyLockFile)
+	require.Equal(t, ".charon/proposal-guard.json", conf.ProposalGuardFile)
+	require.Equal(t, ".charon/peer-backups", conf.PeerBackupDir)
+	require.Equal(t, ".charon/cluster-lock.json", conf.LockFile)
+	require.Empty(t, conf.ReplayRecordFile)
+
+	// Paths accessed after changing the root directory resolve within the chroot directory.
+	t.Chdir(conf.SandboxChroot)
+	require.NoError(t, os.WriteFile(conf.ProposalGuardFile, []byte("{}"), 0o600))
+	require.FileExists(t, filepath.Join(chroot, ".charon/proposal-guard.json"))
+
+	require.Contains(t, keyPaths(conf), conf.SimnetValidatorKeysDir)
+}
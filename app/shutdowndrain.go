@@ -0,0 +1,77 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// newShutdownDrain returns a shutdown drain with the timeout, a zero timeout disables draining.
+func newShutdownDrain(timeout time.Duration) *shutdownDrain {
+	return &shutdownDrain{timeout: timeout}
+}
+
+// shutdownDrain delays stopping the core workflow on shutdown until in-flight duties are broadcast
+// or the drain timeout elapsed, while rejecting new validator client requests.
+type shutdownDrain struct {
+	timeout  time.Duration
+	drainer  atomic.Pointer[core.Drainer]
+	draining atomic.Bool
+}
+
+// Enabled returns true if draining on shutdown is enabled.
+func (d *shutdownDrain) Enabled() bool {
+	return d.timeout > 0
+}
+
+// SetDrainer sets the drainer tracking in-flight duties.
+func (d *shutdownDrain) SetDrainer(drainer *core.Drainer) {
+	d.drainer.Store(drainer)
+}
+
+// Draining returns true once shutdown was signalled.
+func (d *shutdownDrain) Draining() bool {
+	return d.draining.Load()
+}
+
+// WorkflowContext returns a context that is only cancelled once the app context is cancelled and
+// in-flight duties are drained or the drain timeout elapsed. It returns the app context if draining is disabled.
+func (d *shutdownDrain) WorkflowContext(appCtx context.Context) context.Context {
+	if !d.Enabled() {
+		return appCtx
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(appCtx))
+
+	go func() {
+		defer cancel()
+
+		<-appCtx.Done()
+		d.draining.Store(true)
+
+		drainer := d.drainer.Load()
+		if drainer == nil {
+			return
+		}
+
+		log.Info(ctx, "Shutdown signal detected, draining in-flight duties",
+			z.Int("inflight", drainer.Inflight()), z.Str("timeout", d.timeout.String()))
+
+		drainCtx, drainCancel := context.WithTimeout(ctx, d.timeout)
+		defer drainCancel()
+
+		if inflight := drainer.Drain(drainCtx); inflight > 0 {
+			log.Warn(ctx, "Drain timeout elapsed, dropping in-flight duties", nil, z.Int("inflight", inflight))
+		} else {
+			log.Info(ctx, "In-flight duties drained")
+		}
+	}()
+
+	return ctx
+}
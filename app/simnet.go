@@ -0,0 +1,118 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+// simnetForks are the forks of which the simnet beacon mock epochs are configurable, in fork order.
+var simnetForks = []string{"altair", "bellatrix", "capella", "deneb", "electra", "fulu"}
+
+// simnetSpecOpts returns the simnet beacon mock options of the configured slot duration, slots per epoch
+// and fork epochs. The beacon mock spec is the single source of the simnet chain config, from which the
+// scheduler and validatormock read it and the beacon mock derives the versions of its proposals and aggregates.
+func simnetSpecOpts(slotDuration time.Duration, slotsPerEpoch int, forkEpochs []string) ([]beaconmock.Option, error) {
+	if slotDuration < time.Second || slotDuration%time.Second != 0 {
+		return nil, errors.New("simnet slot duration must be a whole number of seconds", z.Any("duration", slotDuration))
+	}
+
+	if slotsPerEpoch <= 0 {
+		return nil, errors.New("simnet slots per epoch must be positive", z.Int("slots_per_epoch", slotsPerEpoch))
+	}
+
+	epochs, err := parseSimnetForkEpochs(forkEpochs)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []beaconmock.Option{
+		beaconmock.WithSlotDuration(slotDuration),
+		beaconmock.WithSlotsPerEpoch(slotsPerEpoch),
+	}
+
+	for _, fork := range simnetForks {
+		if epoch, ok := epochs[fork]; ok {
+			opts = append(opts, beaconmock.WithForkEpoch(fork, epoch))
+		}
+	}
+
+	return opts, nil
+}
+
+// simnetAllDutiesOpts returns the simnet beacon mock options assigning sync committee duties in every epoch
+// and selecting all validators as attestation and sync committee aggregators, so the validatormock exercises
+// the aggregation and sync committee contribution flows in every epoch.
+func simnetAllDutiesOpts() []beaconmock.Option {
+	const (
+		// aggDutyFactor spreads attester duties in an epoch with committee lengths not exceeding
+		// TARGET_AGGREGATORS_PER_COMMITTEE (16), selecting all attesters as aggregators.
+		aggDutyFactor = 15
+		// syncCommSize selects all sync committee members as aggregators since
+		// SYNC_COMMITTEE_SIZE/SYNC_COMMITTEE_SUBNET_COUNT (4)/TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE (16) is 1.
+		// It supports up to 64 validators.
+		syncCommSize = 64
+	)
+
+	return []beaconmock.Option{
+		beaconmock.WithDeterministicAttesterDuties(aggDutyFactor),
+		beaconmock.WithDeterministicSyncCommDuties(2, 2), // All epochs
+		beaconmock.WithSyncCommitteeSize(syncCommSize),
+	}
+}
+
+// parseSimnetForkEpochs returns the fork epochs by fork name of the provided "<fork>=<epoch>" pairs.
+// It returns an error for unknown forks or epochs of later forks preceding those of earlier forks.
+func parseSimnetForkEpochs(pairs []string) (map[string]uint64, error) {
+	resp := make(map[string]uint64)
+
+	for _, pair := range pairs {
+		fork, epochStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, errors.New("invalid simnet fork epoch, expected <fork>=<epoch>", z.Str("value", pair))
+		}
+
+		fork = strings.ToLower(strings.TrimSpace(fork))
+		if !slices.Contains(simnetForks, fork) {
+			return nil, errors.New("unknown simnet fork", z.Str("fork", fork), z.Any("supported", simnetForks))
+		}
+
+		if _, ok := resp[fork]; ok {
+			return nil, errors.New("duplicate simnet fork epoch", z.Str("fork", fork))
+		}
+
+		epoch, err := strconv.ParseUint(strings.TrimSpace(epochStr), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse simnet fork epoch", z.Str("fork", fork))
+		}
+
+		resp[fork] = epoch
+	}
+
+	var (
+		prevFork  string
+		prevEpoch uint64
+	)
+	for _, fork := range simnetForks {
+		epoch, ok := resp[fork]
+		if !ok {
+			continue
+		}
+
+		if prevFork != "" && epoch < prevEpoch {
+			return nil, errors.New("simnet fork epoch precedes that of an earlier fork",
+				z.Str("fork", fork), z.U64("epoch", epoch), z.Str("earlier_fork", prevFork), z.U64("earlier_epoch", prevEpoch))
+		}
+
+		prevFork, prevEpoch = fork, epoch
+	}
+
+	return resp, nil
+}
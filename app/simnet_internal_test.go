@@ -0,0 +1,155 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestParseSimnetForkEpochs(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Pairs  []string
+		Epochs map[string]uint64
+		ErrMsg string
+	}{
+		{
+			Name:   "empty",
+			Epochs: map[string]uint64{},
+		},
+		{
+			Name:   "valid",
+			Pairs:  []string{"Deneb=0", " electra = 2 ", "fulu=10"},
+			Epochs: map[string]uint64{"deneb": 0, "electra": 2, "fulu": 10},
+		},
+		{
+			Name:   "missing epoch",
+			Pairs:  []string{"electra"},
+			ErrMsg: "invalid simnet fork epoch, expected <fork>=<epoch>",
+		},
+		{
+			Name:   "unknown fork",
+			Pairs:  []string{"phase0=0"},
+			ErrMsg: "unknown simnet fork",
+		},
+		{
+			Name:   "duplicate fork",
+			Pairs:  []string{"electra=0", "electra=1"},
+			ErrMsg: "duplicate simnet fork epoch",
+		},
+		{
+			Name:   "invalid epoch",
+			Pairs:  []string{"electra=-1"},
+			ErrMsg: "parse simnet fork epoch",
+		},
+		{
+			Name:   "out of order",
+			Pairs:  []string{"fulu=1", "electra=2"},
+			ErrMsg: "simnet fork epoch precedes that of an earlier fork",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			epochs, err := parseSimnetForkEpochs(test.Pairs)
+			if test.ErrMsg != "" {
+				require.ErrorContains(t, err, test.ErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.Epochs, epochs)
+		})
+	}
+}
+
+func TestSimnetSpecOpts(t *testing.T) {
+	_, err := simnetSpecOpts(1500*time.Millisecond, 16, nil)
+	require.ErrorContains(t, err, "simnet slot duration must be a whole number of seconds")
+
+	_, err = simnetSpecOpts(time.Second, 0, nil)
+	require.ErrorContains(t, err, "simnet slots per epoch must be positive")
+
+	opts, err := simnetSpecOpts(2*time.Second, 4, []string{"electra=0"})
+	require.NoError(t, err)
+
+	bmock, err := beaconmock.New(opts...)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+
+	ctx := context.Background()
+
+	slotDuration, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, bmock)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, slotDuration)
+	require.EqualValues(t, 4, slotsPerEpoch)
+
+	spec, err := bmock.Spec(ctx, &eth2api.SpecOpts{})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, spec.Data["ELECTRA_FORK_EPOCH"])
+
+	// Duty responses follow the configured fork epochs.
+	proposal, err := bmock.Proposal(ctx, &eth2api.ProposalOpts{Slot: 1})
+	require.NoError(t, err)
+	require.Equal(t, eth2spec.DataVersionElectra, proposal.Data.Version)
+}
+
+func TestSimnetAllDutiesOpts(t *testing.T) {
+	ctx := context.Background()
+
+	const numVals = 4
+
+	var pubkeys []eth2p0.BLSPubKey
+	for range numVals {
+		pubkeys = append(pubkeys, testutil.RandomEth2PubKey(t))
+	}
+
+	opts := append([]beaconmock.Option{beaconmock.WithValidatorSet(createMockValidators(pubkeys))}, simnetAllDutiesOpts()...)
+
+	bmock, err := beaconmock.New(opts...)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, bmock.Close())
+	}()
+
+	var indices []eth2p0.ValidatorIndex
+	for i := range numVals {
+		indices = append(indices, eth2p0.ValidatorIndex(i))
+	}
+
+	sig := testutil.RandomEth2Signature()
+
+	for epoch := range eth2p0.Epoch(4) {
+		attDuties, err := bmock.AttesterDuties(ctx, &eth2api.AttesterDutiesOpts{Epoch: epoch, Indices: indices})
+		require.NoError(t, err)
+		require.Len(t, attDuties.Data, numVals)
+
+		for _, duty := range attDuties.Data {
+			ok, err := eth2exp.IsAttAggregator(ctx, bmock, duty.CommitteeLength, sig)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+
+		syncDuties, err := bmock.SyncCommitteeDuties(ctx, &eth2api.SyncCommitteeDutiesOpts{Epoch: epoch, Indices: indices})
+		require.NoError(t, err)
+		require.Len(t, syncDuties.Data, numVals)
+	}
+
+	ok, err := eth2exp.IsSyncCommAggregator(ctx, bmock, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
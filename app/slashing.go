@@ -0,0 +1,103 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/alert"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// newSlashingBreaker returns a function that returns true if the validator was observed as slashed and
+// its attestations and proposals must not be signed. Once tripped, the breaker stays open for the
+// validator until it is explicitly acknowledged by the operator. Tripping the breaker is alerted.
+func newSlashingBreaker(eth2Cl eth2wrap.Client, acked []core.PubKey, alerts *alert.Notifier) func(context.Context, core.PubKey) bool {
+	ackedMap := make(map[core.PubKey]bool)
+	for _, pubkey := range acked {
+		ackedMap[pubkey] = true
+	}
+
+	var (
+		mu      sync.Mutex
+		tripped = make(map[core.PubKey]bool)
+	)
+
+	return func(ctx context.Context, pubkey core.PubKey) bool {
+		if ackedMap[pubkey] {
+			return false
+		}
+
+		mu.Lock()
+		open := tripped[pubkey]
+		mu.Unlock()
+
+		if open {
+			return true
+		}
+
+		// Fetch states without holding the lock, so concurrent duties of other validators don't queue behind the beacon node.
+		states, err := eth2Cl.ValidatorStates(ctx)
+		if err != nil {
+			log.Warn(ctx, "Failed to check validator slashing status", err, z.Any("pubkey", pubkey))
+			return false
+		}
+
+		for _, state := range states {
+			if core.PubKeyFrom48Bytes(state.PubKey) != pubkey || !state.Slashed {
+				continue
+			}
+
+			mu.Lock()
+			alreadyTripped := tripped[pubkey]
+			tripped[pubkey] = true
+			mu.Unlock()
+
+			if alreadyTripped {
+				return true
+			}
+
+			log.Error(ctx, "Validator slashed, stopped signing attestations and proposals until acknowledged with --acknowledge-slashed-validators", nil,
+				z.Any("pubkey", pubkey), z.U64("validator_index", uint64(state.Index)), z.Str("status", state.Status.String()))
+
+			slashingBreakerGauge.WithLabelValues(pubkey.String()).Set(1)
+			alerts.Notify(ctx, alert.TypeValidatorSlashed, "Validator slashed", map[string]string{
+				"pubkey":          pubkey.String(),
+				"validator_index": strconv.FormatUint(uint64(state.Index), 10),
+				"status":          state.Status.String(),
+			})
+
+			return true
+		}
+
+		return false
+	}
+}
+
+// parsePubKeys returns the core public keys of the provided hex encoded validator public keys.
+func parsePubKeys(pubkeys []string) ([]core.PubKey, error) {
+	var resp []core.PubKey
+	for _, pubkey := range pubkeys {
+		b, err := hex.DecodeString(strings.TrimPrefix(pubkey, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode public key", z.Str("pubkey", pubkey))
+		}
+
+		pk, err := core.PubKeyFromBytes(b)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse public key", z.Str("pubkey", pubkey))
+		}
+
+		resp = append(resp, pk)
+	}
+
+	return resp, nil
+}
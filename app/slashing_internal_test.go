@@ -0,0 +1,60 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/alert"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestSlashingBreaker(t *testing.T) {
+	slashedVal := testutil.RandomValidator(t)
+	slashedVal.Status = eth2v1.ValidatorStateActiveSlashed
+	slashedVal.Validator.Slashed = true
+
+	ackedVal := testutil.RandomValidator(t)
+	ackedVal.Status = eth2v1.ValidatorStateActiveSlashed
+	ackedVal.Validator.Slashed = true
+
+	healthyVal := testutil.RandomValidator(t)
+	healthyVal.Status = eth2v1.ValidatorStateActiveOngoing
+
+	complete := eth2wrap.CompleteValidators{
+		slashedVal.Index: slashedVal,
+		ackedVal.Index:   ackedVal,
+		healthyVal.Index: healthyVal,
+	}
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	bmock.CachedValidatorsFunc = func(context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
+		return nil, complete, nil
+	}
+
+	acked, err := parsePubKeys([]string{core.PubKeyFrom48Bytes(ackedVal.Validator.PublicKey).String()})
+	require.ErrorContains(t, err, "decode public key")
+	require.Empty(t, acked)
+
+	acked, err = parsePubKeys([]string{string(core.PubKeyFrom48Bytes(ackedVal.Validator.PublicKey))})
+	require.NoError(t, err)
+
+	blocked := newSlashingBreaker(bmock, acked, alert.New(nil, nil))
+
+	require.True(t, blocked(t.Context(), core.PubKeyFrom48Bytes(slashedVal.Validator.PublicKey)))
+	require.False(t, blocked(t.Context(), core.PubKeyFrom48Bytes(ackedVal.Validator.PublicKey)))
+	require.False(t, blocked(t.Context(), core.PubKeyFrom48Bytes(healthyVal.Validator.PublicKey)))
+
+	// Breaker stays open even if the slashed status is no longer observed.
+	complete = eth2wrap.CompleteValidators{}
+	require.True(t, blocked(t.Context(), core.PubKeyFrom48Bytes(slashedVal.Validator.PublicKey)))
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package snapshot
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/log"
+)
+
+// NewHandler returns a new snapshot http handler without sources.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Handler serves snapshots of the node's in-memory state as JSON.
+type Handler struct {
+	mu      sync.Mutex
+	sources Sources
+}
+
+// SetSources sets the in-memory state sources included in snapshots.
+func (h *Handler) SetSources(sources Sources) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sources = sources
+}
+
+// ServeHTTP serves a snapshot of the sources as JSON.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	sources := h.sources
+	h.mu.Unlock()
+
+	snapshot, err := Take(r.Context(), sources)
+	if err != nil {
+		log.Warn(r.Context(), "Failed taking snapshot", err)
+		http.Error(w, "failed taking snapshot", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		log.Warn(r.Context(), "Failed writing snapshot", err)
+	}
+}
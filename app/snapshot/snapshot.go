@@ -0,0 +1,254 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package snapshot provides point-in-time dumps of the in-memory state of the core workflow
+// components of a running charon node, and tooling to load them for offline reproduction of incidents.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/aggsigdb"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/dutydb"
+)
+
+// Snapshot is a point-in-time dump of the in-memory state of a charon node.
+type Snapshot struct {
+	Timestamp  time.Time           `json:"timestamp"`
+	DutyDB     dutydb.Snapshot     `json:"duty_db"`
+	AggSigDB   []AggSigEntry       `json:"agg_sig_db"`
+	Scheduler  []SchedulerEntry    `json:"scheduler"`
+	Validators []*eth2v1.Validator `json:"validators"`
+}
+
+// AggSigEntry is an aggregated signed data entry of the AggSigDB.
+type AggSigEntry struct {
+	Duty   core.Duty       `json:"duty"`
+	PubKey core.PubKey     `json:"pubkey"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// SchedulerEntry is a duty definition of the scheduler timetable.
+type SchedulerEntry struct {
+	Duty       core.Duty       `json:"duty"`
+	PubKey     core.PubKey     `json:"pubkey"`
+	Definition json.RawMessage `json:"definition"`
+}
+
+// Sources provides the in-memory state included in snapshots.
+// Nil sources are omitted from snapshots.
+type Sources struct {
+	DutyDB     func() dutydb.Snapshot
+	AggSigDB   func(context.Context) ([]aggsigdb.Entry, error)
+	Scheduler  func() map[core.Duty]core.DutyDefinitionSet
+	Validators func() (eth2wrap.CompleteValidators, bool)
+}
+
+// Take returns a snapshot of the sources.
+func Take(ctx context.Context, sources Sources) (Snapshot, error) {
+	resp := Snapshot{Timestamp: time.Now()}
+
+	if sources.DutyDB != nil {
+		resp.DutyDB = sources.DutyDB()
+	}
+
+	if sources.AggSigDB != nil {
+		entries, err := sources.AggSigDB(ctx)
+		if err != nil {
+			return Snapshot{}, errors.Wrap(err, "snapshot aggsigdb")
+		}
+
+		for _, entry := range entries {
+			data, err := json.Marshal(entry.Data)
+			if err != nil {
+				return Snapshot{}, errors.Wrap(err, "marshal aggregated signed data")
+			}
+
+			resp.AggSigDB = append(resp.AggSigDB, AggSigEntry{
+				Duty:   entry.Duty,
+				PubKey: entry.PubKey,
+				Data:   data,
+			})
+		}
+	}
+
+	if sources.Scheduler != nil {
+		for duty, defSet := range sources.Scheduler() {
+			for pubkey, def := range defSet {
+				b, err := json.Marshal(def)
+				if err != nil {
+					return Snapshot{}, errors.Wrap(err, "marshal duty definition")
+				}
+
+				resp.Scheduler = append(resp.Scheduler, SchedulerEntry{
+					Duty:       duty,
+					PubKey:     pubkey,
+					Definition: b,
+				})
+			}
+		}
+	}
+
+	if sources.Validators != nil {
+		if complete, ok := sources.Validators(); ok {
+			for _, val := range complete {
+				resp.Validators = append(resp.Validators, val)
+			}
+		}
+	}
+
+	sortEntries(resp)
+
+	return resp, nil
+}
+
+// sortEntries sorts the snapshot entries by slot for readability and deterministic output.
+func sortEntries(s Snapshot) {
+	sort.Slice(s.AggSigDB, func(i, j int) bool {
+		return dutyLess(s.AggSigDB[i].Duty, s.AggSigDB[j].Duty, s.AggSigDB[i].PubKey, s.AggSigDB[j].PubKey)
+	})
+	sort.Slice(s.Scheduler, func(i, j int) bool {
+		return dutyLess(s.Scheduler[i].Duty, s.Scheduler[j].Duty, s.Scheduler[i].PubKey, s.Scheduler[j].PubKey)
+	})
+	sort.Slice(s.Validators, func(i, j int) bool {
+		return s.Validators[i].Index < s.Validators[j].Index
+	})
+}
+
+func dutyLess(a, b core.Duty, pkA, pkB core.PubKey) bool {
+	if a.Slot != b.Slot {
+		return a.Slot < b.Slot
+	}
+
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+
+	return pkA < pkB
+}
+
+// Write writes the snapshot as JSON to the file.
+func Write(path string, snapshot Snapshot) error {
+	b, err := json.MarshalIndent(snapshot, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal snapshot")
+	}
+
+	if err := os.WriteFile(path, b, 0o644); err != nil { //nolint:gosec // Snapshots aren't secret.
+		return errors.Wrap(err, "write snapshot", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// Load returns the snapshot read from the JSON file.
+func Load(path string) (Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, errors.Wrap(err, "read snapshot", z.Str("path", path))
+	}
+
+	var resp Snapshot
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return Snapshot{}, errors.Wrap(err, "unmarshal snapshot", z.Str("path", path))
+	}
+
+	return resp, nil
+}
+
+// NewDutyDB returns a new in-memory dutyDB populated with the snapshot state.
+// Restored duties never expire.
+func (s Snapshot) NewDutyDB() *dutydb.MemDB {
+	db := dutydb.NewMemDB(noExpiry{})
+	db.Restore(s.DutyDB)
+
+	return db
+}
+
+// NewAggSigDB returns a new in-memory AggSigDB populated with the snapshot state.
+// Restored duties never expire.
+func (s Snapshot) NewAggSigDB(ctx context.Context) (*aggsigdb.MemDBV2, error) {
+	db := aggsigdb.NewMemDBV2(noExpiry{})
+
+	for _, entry := range s.AggSigDB {
+		parSig, err := core.ParSignedDataFromProto(entry.Duty.Type, &pbv1.ParSignedData{Data: entry.Data})
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal aggregated signed data", z.Any("duty", entry.Duty))
+		}
+
+		err = db.Store(ctx, entry.Duty, core.SignedDataSet{entry.PubKey: parSig.SignedData})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// DutyDefinitions returns the scheduler timetable duty definitions of the snapshot.
+func (s Snapshot) DutyDefinitions() (map[core.Duty]core.DutyDefinitionSet, error) {
+	resp := make(map[core.Duty]core.DutyDefinitionSet)
+
+	for _, entry := range s.Scheduler {
+		def, err := unmarshalDefinition(entry.Duty.Type, entry.Definition)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal duty definition", z.Any("duty", entry.Duty))
+		}
+
+		defSet, ok := resp[entry.Duty]
+		if !ok {
+			defSet = make(core.DutyDefinitionSet)
+			resp[entry.Duty] = defSet
+		}
+
+		defSet[entry.PubKey] = def
+	}
+
+	return resp, nil
+}
+
+// unmarshalDefinition returns the duty definition of the duty type unmarshalled from JSON.
+func unmarshalDefinition(typ core.DutyType, data []byte) (core.DutyDefinition, error) {
+	switch typ {
+	case core.DutyProposer:
+		duty := new(eth2v1.ProposerDuty)
+		if err := json.Unmarshal(data, duty); err != nil {
+			return nil, errors.Wrap(err, "unmarshal proposer duty")
+		}
+
+		return core.NewProposerDefinition(duty), nil
+	case core.DutyAttester, core.DutyAggregator, core.DutyPrepareAggregator:
+		duty := new(eth2v1.AttesterDuty)
+		if err := json.Unmarshal(data, duty); err != nil {
+			return nil, errors.Wrap(err, "unmarshal attester duty")
+		}
+
+		return core.NewAttesterDefinition(duty), nil
+	case core.DutySyncMessage, core.DutySyncContribution, core.DutyPrepareSyncContribution:
+		duty := new(eth2v1.SyncCommitteeDuty)
+		if err := json.Unmarshal(data, duty); err != nil {
+			return nil, errors.Wrap(err, "unmarshal sync committee duty")
+		}
+
+		return core.NewSyncCommitteeDefinition(duty), nil
+	default:
+		return nil, errors.New("unsupported duty type", z.Str("type", typ.String()))
+	}
+}
+
+// noExpiry is a deadliner that never expires duties.
+type noExpiry struct{}
+
+func (noExpiry) Add(core.Duty) bool { return true }
+
+func (noExpiry) C() <-chan core.Duty { return nil }
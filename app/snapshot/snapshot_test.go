@@ -0,0 +1,115 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package snapshot_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/snapshot"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/aggsigdb"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := t.Context()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	// Populate the DutyDB with an attestation.
+	attData := testutil.RandomCoreAttestationData(t)
+	attData.Data.Slot = attData.Duty.Slot
+	attData.Data.Index = attData.Duty.CommitteeIndex
+	attDuty := core.NewAttesterDuty(uint64(attData.Duty.Slot))
+
+	dutyDB := dutydb.NewMemDB(noopDeadliner{})
+	require.NoError(t, dutyDB.Store(ctx, attDuty, core.UnsignedDataSet{pubkey: attData}))
+
+	// Populate the AggSigDB with a signature.
+	sigDuty := core.NewSignatureDuty(attDuty.Slot)
+	sig := testutil.RandomCoreSignature()
+
+	aggSigDB := aggsigdb.NewMemDBV2(noopDeadliner{})
+	require.NoError(t, aggSigDB.Store(ctx, sigDuty, core.SignedDataSet{pubkey: sig}))
+
+	// Scheduler duty definitions and validators.
+	proDuty := testutil.RandomProposerDuty(t)
+	definitions := map[core.Duty]core.DutyDefinitionSet{
+		core.NewProposerDuty(uint64(proDuty.Slot)): {pubkey: core.NewProposerDefinition(proDuty)},
+	}
+
+	val := testutil.RandomValidator(t)
+
+	snap, err := snapshot.Take(ctx, snapshot.Sources{
+		DutyDB:    dutyDB.Snapshot,
+		AggSigDB:  aggSigDB.Snapshot,
+		Scheduler: func() map[core.Duty]core.DutyDefinitionSet { return definitions },
+		Validators: func() (eth2wrap.CompleteValidators, bool) {
+			return eth2wrap.CompleteValidators{val.Index: val}, true
+		},
+	})
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, snapshot.Write(file, snap))
+
+	loaded, err := snapshot.Load(file)
+	require.NoError(t, err)
+
+	// Restored DutyDB.
+	restoredDutyDB := loaded.NewDutyDB()
+
+	data, err := restoredDutyDB.AwaitAttestation(ctx, uint64(attData.Data.Slot), uint64(attData.Data.Index))
+	require.NoError(t, err)
+	require.Equal(t, attData.Data, *data)
+
+	pk, err := restoredDutyDB.PubKeyByAttestation(ctx, uint64(attData.Data.Slot), uint64(attData.Duty.CommitteeIndex), uint64(attData.Duty.ValidatorIndex))
+	require.NoError(t, err)
+	require.Equal(t, pubkey, pk)
+
+	// Restored AggSigDB.
+	restoredAggSigDB, err := loaded.NewAggSigDB(ctx)
+	require.NoError(t, err)
+
+	signed, err := restoredAggSigDB.Await(ctx, sigDuty, pubkey)
+	require.NoError(t, err)
+	require.Equal(t, sig, signed)
+
+	// Restored scheduler duty definitions.
+	restoredDefinitions, err := loaded.DutyDefinitions()
+	require.NoError(t, err)
+	require.Equal(t, definitions, restoredDefinitions)
+
+	require.Equal(t, []*eth2v1.Validator{val}, loaded.Validators)
+}
+
+func TestHandlerError(t *testing.T) {
+	handler := snapshot.NewHandler()
+	handler.SetSources(snapshot.Sources{
+		AggSigDB: func(context.Context) ([]aggsigdb.Entry, error) {
+			return nil, errors.New("internal details")
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/snapshot", nil))
+
+	// Errors aren't exposed to the client.
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.NotContains(t, rec.Body.String(), "internal details")
+}
+
+type noopDeadliner struct{}
+
+func (noopDeadliner) Add(core.Duty) bool { return true }
+
+func (noopDeadliner) C() <-chan core.Duty { return nil }
@@ -4,10 +4,12 @@ package sse
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,8 +24,16 @@ import (
 
 type ChainReorgEventHandlerFunc func(ctx context.Context, epoch eth2p0.Epoch)
 
+// HeadEventHandlerFunc is called with the slot of a new head and the time the head event was received.
+type HeadEventHandlerFunc func(ctx context.Context, slot uint64, received time.Time)
+
+// HeadBlockEventHandlerFunc is called with the slot and block root of a new head.
+type HeadBlockEventHandlerFunc func(ctx context.Context, slot uint64, root eth2p0.Root)
+
 type Listener interface {
 	SubscribeChainReorgEvent(ChainReorgEventHandlerFunc)
+	SubscribeHeadEvent(HeadEventHandlerFunc)
+	SubscribeHeadBlockEvent(HeadBlockEventHandlerFunc)
 }
 
 type listener struct {
@@ -31,6 +41,8 @@ type listener struct {
 
 	chainReorgSubs []ChainReorgEventHandlerFunc
 	lastReorgEpoch eth2p0.Epoch
+	headSubs       []HeadEventHandlerFunc
+	headBlockSubs  []HeadBlockEventHandlerFunc
 
 	// immutable fields
 	genesisTime   time.Time
@@ -94,6 +106,20 @@ func (p *listener) SubscribeChainReorgEvent(handler ChainReorgEventHandlerFunc)
 	p.chainReorgSubs = append(p.chainReorgSubs, handler)
 }
 
+func (p *listener) SubscribeHeadEvent(handler HeadEventHandlerFunc) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.headSubs = append(p.headSubs, handler)
+}
+
+func (p *listener) SubscribeHeadBlockEvent(handler HeadBlockEventHandlerFunc) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.headBlockSubs = append(p.headBlockSubs, handler)
+}
+
 func (p *listener) eventHandler(ctx context.Context, event *event, addr string) error {
 	switch event.Event {
 	case sseHeadEvent:
@@ -131,6 +157,15 @@ func (p *listener) handleHeadEvent(ctx context.Context, event *event, addr strin
 
 	sseHeadSlotGauge.WithLabelValues(addr).Set(float64(slot))
 
+	p.notifyHead(ctx, slot, event.Timestamp)
+
+	root, err := parseRoot(head.Block)
+	if err != nil {
+		return errors.Wrap(err, "parse head block root", z.Str("addr", addr))
+	}
+
+	p.notifyHeadBlock(ctx, slot, root)
+
 	log.Debug(ctx, "SSE head event",
 		z.U64("slot", slot),
 		z.Str("delay", delay.String()),
@@ -194,6 +229,36 @@ func (p *listener) notifyChainReorg(ctx context.Context, epoch eth2p0.Epoch) {
 	}
 }
 
+func (p *listener) notifyHead(ctx context.Context, slot uint64, received time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, sub := range p.headSubs {
+		sub(ctx, slot, received)
+	}
+}
+
+func (p *listener) notifyHeadBlock(ctx context.Context, slot uint64, root eth2p0.Root) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, sub := range p.headBlockSubs {
+		sub(ctx, slot, root)
+	}
+}
+
+// parseRoot returns the root from its 0x-prefixed hex representation.
+func parseRoot(s string) (eth2p0.Root, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "decode hex")
+	} else if len(b) != len(eth2p0.Root{}) {
+		return eth2p0.Root{}, errors.New("invalid root length", z.Int("length", len(b)))
+	}
+
+	return eth2p0.Root(b), nil
+}
+
 // Compute delay between start of the slot and receiving the head update event.
 func (p *listener) computeDelay(slot uint64, eventTS time.Time) (time.Duration, bool) {
 	slotStartTime := p.genesisTime.Add(time.Duration(slot) * p.slotDuration)
@@ -47,6 +47,15 @@ func TestHandleEvents(t *testing.T) {
 			},
 			err: errors.New("parse slot to uint64"),
 		},
+		{
+			name: "head parse block root",
+			event: &event{
+				Event:     sseHeadEvent,
+				Data:      []byte(`{"slot":"10", "block":"0x9a2f", "state":"0x600e852a08c1200654ddf11025f1ceacb3c2e74bdd5c630cde0838b2591b69f9", "epoch_transition":false, "previous_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91", "current_duty_dependent_root":"0x5e0043f107cb57913498fbf2f99ff55e730bf1e151f02f221e977c91a90a0e91", "execution_optimistic": false}`),
+				Timestamp: time.Now(),
+			},
+			err: errors.New("parse head block root"),
+		},
 		{
 			name: "chain_reorg happy path",
 			event: &event{
@@ -133,6 +142,52 @@ func TestSubscribeNotifyChainReorg(t *testing.T) {
 	require.Equal(t, eth2p0.Epoch(10), reportedEpochs[1])
 }
 
+func TestSubscribeNotifyHead(t *testing.T) {
+	l := &listener{}
+
+	var reportedSlots []uint64
+
+	l.SubscribeHeadEvent(func(_ context.Context, slot uint64, _ time.Time) {
+		reportedSlots = append(reportedSlots, slot)
+	})
+
+	l.notifyHead(t.Context(), 5, time.Now())
+	l.notifyHead(t.Context(), 6, time.Now())
+
+	require.Equal(t, []uint64{5, 6}, reportedSlots)
+}
+
+func TestSubscribeNotifyHeadBlock(t *testing.T) {
+	l := &listener{
+		slotDuration:  12 * time.Second,
+		slotsPerEpoch: 32,
+		genesisTime:   time.Date(2020, 12, 1, 12, 0, 23, 0, time.UTC),
+	}
+
+	var (
+		reportedSlot uint64
+		reportedRoot eth2p0.Root
+	)
+
+	l.SubscribeHeadBlockEvent(func(_ context.Context, slot uint64, root eth2p0.Root) {
+		reportedSlot = slot
+		reportedRoot = root
+	})
+
+	err := l.eventHandler(t.Context(), &event{
+		Event:     sseHeadEvent,
+		Data:      []byte(`{"slot":"10", "block":"0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf"}`),
+		Timestamp: time.Now(),
+	}, "test")
+	require.NoError(t, err)
+
+	expected, err := parseRoot("0x9a2fefd2fdb57f74993c7780ea5b9030d2897b615b89f808011ca5aebed54eaf")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), reportedSlot)
+	require.Equal(t, expected, reportedRoot)
+	require.Equal(t, byte(0x9a), reportedRoot[0])
+}
+
 func TestComputeDelay(t *testing.T) {
 	genesisTimeString := "2020-12-01T12:00:23+00:00"
 	genesisTime, err := time.Parse(time.RFC3339, genesisTimeString)
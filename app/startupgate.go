@@ -0,0 +1,142 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// startupGatePollPeriod is the period at which the startup gate conditions are evaluated.
+const startupGatePollPeriod = time.Second
+
+// Startup gate states.
+const (
+	gateWaitingBeaconNode = "waiting_for_beacon_node"
+	gateWaitingPeers      = "waiting_for_peers"
+	gateOpen              = "open"
+	gateTimedOut          = "timed_out"
+)
+
+var (
+	errReadyGateWaitingBeaconNode = errors.New("startup gate waiting for beacon node")
+	errReadyGateWaitingPeers      = errors.New("startup gate waiting for quorum peers")
+)
+
+// newStartupGate returns a startup gate delaying opening the validator API on startup until the beacon node
+// is synced and/or quorum peers are connected, or the timeout elapsed. It returns nil if neither is waited for.
+func newStartupGate(waitBeaconNode, waitPeers bool, timeout time.Duration, eth2Cl eth2wrap.Client,
+	tcpNode host.Host, peerIDs []peer.ID, clock clockwork.Clock,
+) *startupGate {
+	if !waitBeaconNode && !waitPeers {
+		return nil
+	}
+
+	return &startupGate{
+		waitBeaconNode: waitBeaconNode,
+		waitPeers:      waitPeers,
+		timeout:        timeout,
+		eth2Cl:         eth2Cl,
+		tcpNode:        tcpNode,
+		peerIDs:        peerIDs,
+		clock:          clock,
+		state:          gateWaitingBeaconNode,
+	}
+}
+
+// startupGate avoids validator client error storms during cold cluster startup by delaying
+// opening the validator API until the node is able to perform duties.
+type startupGate struct {
+	waitBeaconNode bool
+	waitPeers      bool
+	timeout        time.Duration
+	eth2Cl         eth2wrap.Client
+	tcpNode        host.Host
+	peerIDs        []peer.ID
+	clock          clockwork.Clock
+
+	mu    sync.Mutex
+	state string
+}
+
+// Wait blocks until the gate conditions are met, the timeout elapsed or the context is cancelled.
+func (g *startupGate) Wait(ctx context.Context) {
+	timeout := g.clock.After(g.timeout)
+	ticker := g.clock.NewTicker(startupGatePollPeriod)
+	defer ticker.Stop()
+
+	for {
+		state := g.evaluate(ctx)
+		g.setState(state)
+
+		if state == gateOpen {
+			log.Info(ctx, "Startup gate open, starting validator API")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout:
+			log.Warn(ctx, "Startup gate timed out, starting validator API anyway", nil,
+				z.Str("state", state), z.Str("timeout", g.timeout.String()))
+			g.setState(gateTimedOut)
+
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// evaluate returns the state of the gate conditions.
+func (g *startupGate) evaluate(ctx context.Context) string {
+	if g.waitBeaconNode {
+		syncing, _, err := beaconNodeSyncing(ctx, g.eth2Cl)
+		if err != nil || syncing {
+			return gateWaitingBeaconNode
+		}
+	}
+
+	if g.waitPeers && !quorumPeersConnected(g.peerIDs, g.tcpNode) {
+		return gateWaitingPeers
+	}
+
+	return gateOpen
+}
+
+func (g *startupGate) setState(state string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.state = state
+}
+
+// State returns the current state of the gate.
+func (g *startupGate) State() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.state
+}
+
+// ReadyErr returns an error if the gate is still waiting, nil otherwise.
+func (g *startupGate) ReadyErr() error {
+	switch g.State() {
+	case gateWaitingBeaconNode:
+		return errReadyGateWaitingBeaconNode
+	case gateWaitingPeers:
+		return errReadyGateWaitingPeers
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestStartupGate(t *testing.T) {
+	require.Nil(t, newStartupGate(false, false, time.Minute, nil, nil, nil, nil))
+
+	const timeout = time.Minute
+
+	tests := []struct {
+		name      string
+		syncing   bool
+		connected bool
+		state     string
+		readyErr  error
+	}{
+		{name: "beacon node syncing", syncing: true, connected: true, state: gateWaitingBeaconNode, readyErr: errReadyGateWaitingBeaconNode},
+		{name: "peers not connected", syncing: false, connected: false, state: gateWaitingPeers, readyErr: errReadyGateWaitingPeers},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			var syncing atomic.Bool
+			syncing.Store(test.syncing)
+
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+
+			bmock.NodeSyncingFunc = func(context.Context, *eth2api.NodeSyncingOpts) (*eth2v1.SyncState, error) {
+				return &eth2v1.SyncState{IsSyncing: syncing.Load()}, nil
+			}
+
+			self := testutil.CreateHost(t, testutil.AvailableAddr(t))
+			other1 := testutil.CreateHost(t, testutil.AvailableAddr(t))
+			other2 := testutil.CreateHost(t, testutil.AvailableAddr(t))
+			peerIDs := []peer.ID{self.ID(), other1.ID(), other2.ID()}
+
+			connect := func() {
+				require.NoError(t, self.Connect(ctx, peer.AddrInfo{ID: other1.ID(), Addrs: other1.Addrs()}))
+			}
+
+			if test.connected {
+				connect()
+			}
+
+			clock := clockwork.NewFakeClock()
+			gate := newStartupGate(true, true, timeout, bmock, self, peerIDs, clock)
+
+			done := make(chan struct{})
+			go func() {
+				gate.Wait(ctx)
+				close(done)
+			}()
+
+			require.Eventually(t, func() bool {
+				return gate.State() == test.state
+			}, time.Second, time.Millisecond)
+			require.ErrorIs(t, gate.ReadyErr(), test.readyErr)
+
+			// Conditions met.
+			syncing.Store(false)
+
+			if !test.connected {
+				connect()
+			}
+
+			clock.Advance(startupGatePollPeriod)
+			<-done
+
+			require.Equal(t, gateOpen, gate.State())
+			require.NoError(t, gate.ReadyErr())
+		})
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		bmock, err := beaconmock.New()
+		require.NoError(t, err)
+
+		bmock.NodeSyncingFunc = func(context.Context, *eth2api.NodeSyncingOpts) (*eth2v1.SyncState, error) {
+			return &eth2v1.SyncState{IsSyncing: true}, nil
+		}
+
+		clock := clockwork.NewFakeClock()
+		gate := newStartupGate(true, false, timeout, bmock, nil, nil, clock)
+
+		done := make(chan struct{})
+		go func() {
+			gate.Wait(ctx)
+			close(done)
+		}()
+
+		require.NoError(t, clock.BlockUntilContext(ctx, 2))
+		require.Equal(t, gateWaitingBeaconNode, gate.State())
+
+		clock.Advance(timeout)
+		<-done
+
+		require.Equal(t, gateTimedOut, gate.State())
+		require.NoError(t, gate.ReadyErr())
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package telemetry provides opt-in reporting of coarse, anonymized node health data to a configurable endpoint.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// reportInterval is the period between reports.
+	reportInterval = time.Hour
+	// postTimeout is the maximum duration of a single report request.
+	postTimeout = 30 * time.Second
+
+	successMetric = "core_tracker_success_duties_total"
+	failedMetric  = "core_tracker_failed_duties_total"
+)
+
+var (
+	// clusterSizeBounds are the upper bounds of the cluster size (number of operators) buckets.
+	clusterSizeBounds = []int{4, 7, 10, 16}
+	// validatorsBounds are the upper bounds of the number of validators buckets.
+	validatorsBounds = []int{1, 10, 100, 1000}
+)
+
+// Info is the static information of the node included in reports.
+type Info struct {
+	Version       string
+	Network       string
+	NumOperators  int
+	NumValidators int
+}
+
+// Report is the anonymized JSON payload posted to the telemetry endpoint.
+// It deliberately excludes any identifiers of the node, cluster, validators or operator.
+type Report struct {
+	Version          string             `json:"version"`
+	Network          string             `json:"network"`
+	ClusterSize      string             `json:"cluster_size"`
+	Validators       string             `json:"validators"`
+	DutySuccessRates map[string]float64 `json:"duty_success_rates"` // Success rate by duty type since the previous report.
+	Time             time.Time          `json:"time"`               // Truncated to the hour.
+}
+
+// New returns a new reporter posting reports to the endpoint, using the gatherer to calculate duty success rates.
+// Reporting is disabled if the endpoint is empty.
+func New(endpoint string, info Info, gatherer prometheus.Gatherer) *Reporter {
+	return &Reporter{
+		endpoint: endpoint,
+		info:     info,
+		gatherer: gatherer,
+		interval: reportInterval,
+		client:   &http.Client{Timeout: postTimeout},
+		prev:     make(map[string]dutyCounts),
+	}
+}
+
+// Reporter periodically posts anonymized telemetry reports.
+type Reporter struct {
+	endpoint string
+	info     Info
+	gatherer prometheus.Gatherer
+	interval time.Duration
+	client   *http.Client
+	prev     map[string]dutyCounts
+}
+
+// dutyCounts are the successful and failed duty counts of a duty type.
+type dutyCounts struct {
+	Success float64
+	Failed  float64
+}
+
+// Run posts a report every interval until the context is canceled.
+func (r *Reporter) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "telemetry")
+
+	if r.endpoint == "" {
+		return
+	}
+
+	log.Info(ctx, "Anonymized telemetry reporting enabled", z.Str("endpoint", r.endpoint), z.Str("interval", r.interval.String()))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.report(ctx); err != nil {
+				log.Warn(ctx, "Failed reporting telemetry", err)
+			}
+		}
+	}
+}
+
+// report posts a single report to the endpoint.
+func (r *Reporter) report(ctx context.Context) error {
+	rates, err := r.successRates()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(Report{
+		Version:          r.info.Version,
+		Network:          r.info.Network,
+		ClusterSize:      bucket(r.info.NumOperators, clusterSizeBounds),
+		Validators:       bucket(r.info.NumValidators, validatorsBounds),
+		DutySuccessRates: rates,
+		Time:             time.Now().UTC().Truncate(time.Hour),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal report")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post report")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.New("telemetry endpoint responded with non-2xx status", z.Int("status_code", resp.StatusCode))
+	}
+
+	return nil
+}
+
+// successRates returns the duty success rates by duty type since the previous call.
+// Duty types without any successful or failed duties since the previous call are omitted.
+func (r *Reporter) successRates() (map[string]float64, error) {
+	families, err := r.gatherer.Gather()
+	if err != nil {
+		return nil, errors.Wrap(err, "gather metrics")
+	}
+
+	counts := make(map[string]dutyCounts)
+
+	for _, fam := range families {
+		if fam.GetName() != successMetric && fam.GetName() != failedMetric {
+			continue
+		}
+
+		for _, metric := range fam.GetMetric() {
+			var duty string
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "duty" {
+					duty = label.GetValue()
+				}
+			}
+
+			c := counts[duty]
+			if fam.GetName() == successMetric {
+				c.Success += metric.GetCounter().GetValue()
+			} else {
+				c.Failed += metric.GetCounter().GetValue()
+			}
+			counts[duty] = c
+		}
+	}
+
+	rates := make(map[string]float64)
+
+	for duty, c := range counts {
+		prev := r.prev[duty]
+		success, failed := c.Success-prev.Success, c.Failed-prev.Failed
+
+		if success+failed > 0 {
+			rates[duty] = success / (success + failed)
+		}
+	}
+
+	r.prev = counts
+
+	return rates, nil
+}
+
+// bucket returns the coarse bucket of n given the ascending upper bounds, e.g. "5-7" or "17+".
+func bucket(n int, bounds []int) string {
+	lower := 1
+	for _, upper := range bounds {
+		if n <= upper {
+			if lower == upper {
+				return fmt.Sprint(upper)
+			}
+
+			return fmt.Sprintf("%d-%d", lower, upper)
+		}
+
+		lower = upper + 1
+	}
+
+	return fmt.Sprintf("%d+", lower)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	require.Equal(t, "1-4", bucket(4, clusterSizeBounds))
+	require.Equal(t, "5-7", bucket(6, clusterSizeBounds))
+	require.Equal(t, "17+", bucket(32, clusterSizeBounds))
+	require.Equal(t, "1", bucket(1, validatorsBounds))
+	require.Equal(t, "101-1000", bucket(500, validatorsBounds))
+	require.Equal(t, "1001+", bucket(5000, validatorsBounds))
+}
+
+func TestReport(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	success := prometheus.NewCounterVec(prometheus.CounterOpts{Name: successMetric}, []string{"duty"})
+	failed := prometheus.NewCounterVec(prometheus.CounterOpts{Name: failedMetric}, []string{"duty"})
+	registry.MustRegister(success, failed)
+
+	reports := make(chan Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report Report
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		reports <- report
+	}))
+	defer srv.Close()
+
+	reporter := New(srv.URL, Info{Version: "v1.0.0", Network: "mainnet", NumOperators: 6, NumValidators: 20}, registry)
+
+	success.WithLabelValues("attester").Add(3)
+	failed.WithLabelValues("attester").Add(1)
+	success.WithLabelValues("proposer").Add(1)
+
+	require.NoError(t, reporter.report(context.Background()))
+
+	report := <-reports
+	require.Equal(t, "v1.0.0", report.Version)
+	require.Equal(t, "mainnet", report.Network)
+	require.Equal(t, "5-7", report.ClusterSize)
+	require.Equal(t, "11-100", report.Validators)
+	require.Equal(t, map[string]float64{"attester": 0.75, "proposer": 1}, report.DutySuccessRates)
+
+	// Success rates only cover duties since the previous report.
+	failed.WithLabelValues("attester").Add(1)
+
+	require.NoError(t, reporter.report(context.Background()))
+
+	report = <-reports
+	require.Equal(t, map[string]float64{"attester": 0}, report.DutySuccessRates)
+}
@@ -4,11 +4,7 @@ package app
 
 import (
 	"context"
-	"sync"
-	"time"
 
-	eth2client "github.com/attestantio/go-eth2-client"
-	eth2http "github.com/attestantio/go-eth2-client/http"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -40,61 +36,15 @@ func wireValidatorMock(ctx context.Context, conf Config, eth2Cl eth2wrap.Client,
 		return err
 	}
 
-	vmock := validatormock.New(ctx, newVMockEth2Provider(conf, pubshares), signer, pubshares, genesisTime, slotDuration, slotsPerEpoch, conf.BuilderAPI)
+	opts := conf.TestConfig.SimnetVMockOpts
+	eth2ClProvider := validatormock.NewHTTPProvider(conf.ValidatorAPIAddr, pubshares, opts...)
+
+	vmock := validatormock.New(ctx, eth2ClProvider, signer, pubshares, genesisTime, slotDuration, slotsPerEpoch, conf.BuilderAPI, opts...)
 	sched.SubscribeSlots(vmock.SlotTicked)
 
 	return nil
 }
 
-// newVMockEth2Provider returns a function that returns a cached validator mock eth2 client.
-func newVMockEth2Provider(conf Config, pubshares []eth2p0.BLSPubKey) func() (eth2wrap.Client, error) {
-	var (
-		cached eth2wrap.Client
-		mu     sync.Mutex
-	)
-
-	const timeout = time.Second * 10
-
-	return func() (eth2wrap.Client, error) {
-		mu.Lock()
-		defer mu.Unlock()
-
-		if cached != nil {
-			return cached, nil
-		}
-
-		// Try three times to reduce test startup issues.
-		var err error
-
-		for range 3 {
-			var eth2Svc eth2client.Service
-
-			eth2Svc, err = eth2http.New(context.Background(),
-				eth2http.WithLogLevel(1),
-				eth2http.WithAddress("http://"+conf.ValidatorAPIAddr),
-				eth2http.WithTimeout(timeout), // Allow sufficient time to block while fetching duties.
-			)
-			if err != nil {
-				time.Sleep(time.Millisecond * 100) // Test startup backoff
-				continue
-			}
-
-			eth2Http, ok := eth2Svc.(*eth2http.Service)
-			if !ok {
-				return nil, errors.New("invalid eth2 http service")
-			}
-
-			cached = eth2wrap.AdaptEth2HTTP(eth2Http, nil, timeout)
-			valCache := eth2wrap.NewValidatorCache(cached, pubshares)
-			cached.SetValidatorCache(valCache.GetByHead)
-
-			break
-		}
-
-		return cached, err
-	}
-}
-
 // newVMockSigner returns a validator mock sign function using keystore loaded from disk.
 func newVMockSigner(conf Config, pubshares []eth2p0.BLSPubKey) (validatormock.SignFunc, error) {
 	secrets := conf.TestConfig.SimnetKeys
@@ -0,0 +1,277 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cmd/backup"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+)
+
+type backupCreateConfig struct {
+	ValidatorKeysDir string
+	RecoveryKeys     []string
+	Threshold        int
+	OutputFile       string
+}
+
+type backupRestoreConfig struct {
+	BackupFile             string
+	RecoveryPrivateKeyFile string
+	CeremonyDir            string
+	OutputDir              string
+}
+
+func newBackupCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "backup",
+		Short: "Create and restore threshold-encrypted backups of validator key shares",
+		Long: `Create and restore threshold-encrypted backups of this node's validator key shares. Each key share is split ` +
+			`into recovery shares encrypted to operator-held recovery keys, a threshold of which recovers the key shares ` +
+			`after losing the node's disk.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newBackupCreateCmd(runFunc func(context.Context, io.Writer, backupCreateConfig) error) *cobra.Command {
+	var config backupCreateConfig
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a threshold-encrypted backup of validator key shares",
+		Long: `Creates a backup file of the validator key shares, split into recovery shares encrypted to the recovery keys. ` +
+			`Recovery keys are secp256k1 keys held by operators, e.g. created via "charon create enr --data-dir=<recovery-dir>". ` +
+			`The backup file alone reveals no key shares and may be stored off-site.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Directory containing the validator key shares to backup.")
+	cmd.Flags().StringSliceVar(&config.RecoveryKeys, "recovery-keys", nil, "Comma-separated list of recovery public keys, as ENRs or hex encoded compressed secp256k1 public keys. [REQUIRED]")
+	cmd.Flags().IntVar(&config.Threshold, "threshold", 0, "Number of recovery keys required to restore the key shares. Defaults to the BFT threshold of the number of recovery keys.")
+	cmd.Flags().StringVar(&config.OutputFile, "output-file", "charon-key-backup.json", "The path to the backup file to write.")
+
+	mustMarkFlagRequired(cmd, "recovery-keys")
+
+	return cmd
+}
+
+func newBackupRestoreCmd(runFunc func(context.Context, io.Writer, backupRestoreConfig) error) *cobra.Command {
+	var config backupRestoreConfig
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore validator key shares in a guided recovery ceremony",
+		Long: `Restores validator key shares from a backup file in a guided recovery ceremony. Each recovery key holder runs ` +
+			`this command with their --recovery-private-key-file, decrypting their recovery shares into the ceremony directory. ` +
+			`Once a threshold of recovery shares is collected, the key shares are restored and verified against the backup.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.BackupFile, "backup-file", "charon-key-backup.json", "The path to the backup file.")
+	cmd.Flags().StringVar(&config.RecoveryPrivateKeyFile, "recovery-private-key-file", "", "The path to the recovery private key file of this recovery key holder. If empty, only previously decrypted recovery shares in the ceremony directory are used.")
+	cmd.Flags().StringVar(&config.CeremonyDir, "ceremony-dir", "recovery-ceremony", "Directory collecting the decrypted recovery shares of the recovery key holders. Delete it after restoring.")
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon/validator_keys", "Directory to write the restored validator key shares to. It must not contain key shares.")
+
+	return cmd
+}
+
+func runBackupCreate(_ context.Context, out io.Writer, config backupCreateConfig) error {
+	var recoveryKeys []*k1.PublicKey
+
+	for _, s := range config.RecoveryKeys {
+		pubkey, err := parseRecoveryKey(s)
+		if err != nil {
+			return err
+		}
+
+		recoveryKeys = append(recoveryKeys, pubkey)
+	}
+
+	threshold := config.Threshold
+	if threshold == 0 {
+		threshold = cluster.Threshold(len(recoveryKeys))
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := keyFiles.SequencedKeys()
+	if err != nil {
+		return err
+	}
+
+	b, err := backup.Create(secrets, recoveryKeys, threshold)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal backup")
+	}
+
+	//nolint:gosec // Recovery shares are encrypted.
+	if err := os.WriteFile(config.OutputFile, data, 0o644); err != nil {
+		return errors.Wrap(err, "write backup file")
+	}
+
+	_, err = fmt.Fprintf(out, "Backup of %d key shares written to %s, recoverable by %d of %d recovery keys.\n",
+		len(secrets), config.OutputFile, threshold, len(recoveryKeys))
+
+	return err
+}
+
+func runBackupRestore(_ context.Context, out io.Writer, config backupRestoreConfig) error {
+	b, err := backup.Load(config.BackupFile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.CeremonyDir, 0o700); err != nil {
+		return errors.Wrap(err, "create ceremony dir")
+	}
+
+	if config.RecoveryPrivateKeyFile != "" {
+		key, err := k1util.Load(config.RecoveryPrivateKeyFile)
+		if err != nil {
+			return errors.Wrap(err, "load recovery private key")
+		}
+
+		share, err := backup.DecryptShare(b, key)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(share)
+		if err != nil {
+			return errors.Wrap(err, "marshal recovery share")
+		}
+
+		filename := filepath.Join(config.CeremonyDir, fmt.Sprintf("recovery-share-%d.json", share.Index))
+		if err := os.WriteFile(filename, data, 0o600); err != nil {
+			return errors.Wrap(err, "write recovery share")
+		}
+
+		if _, err := fmt.Fprintf(out, "Decrypted recovery share %d to %s.\n", share.Index, filename); err != nil {
+			return err
+		}
+	}
+
+	shares, err := loadRecoveryShares(config.CeremonyDir, b.ID())
+	if err != nil {
+		return err
+	}
+
+	if len(shares) < b.Threshold {
+		_, err = fmt.Fprintf(out, "Collected %d of %d required recovery shares. Next, another recovery key holder runs:\n"+
+			"  charon backup restore --backup-file=%s --ceremony-dir=%s --recovery-private-key-file=<recovery-private-key-file>\n",
+			len(shares), b.Threshold, config.BackupFile, config.CeremonyDir)
+
+		return err
+	}
+
+	secrets, err := backup.Recover(b, shares)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := filepath.Glob(filepath.Join(config.OutputDir, "keystore-*.json")); err != nil {
+		return errors.Wrap(err, "glob output dir")
+	} else if len(existing) > 0 {
+		return errors.New("output dir already contains key shares", z.Str("output_dir", config.OutputDir))
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0o700); err != nil {
+		return errors.Wrap(err, "create output dir")
+	}
+
+	if err := keystore.StoreKeys(secrets, config.OutputDir); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "Restored and verified %d key shares to %s. Delete the ceremony directory %s.\n",
+		len(secrets), config.OutputDir, config.CeremonyDir)
+
+	return err
+}
+
+// loadRecoveryShares returns the recovery shares of the backup in the ceremony directory.
+func loadRecoveryShares(dir string, backupID string) ([]backup.RecoveryShare, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "recovery-share-*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "glob recovery shares")
+	}
+
+	var resp []backup.RecoveryShare
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "read recovery share", z.Str("file", file))
+		}
+
+		var share backup.RecoveryShare
+		if err := json.Unmarshal(data, &share); err != nil {
+			return nil, errors.Wrap(err, "unmarshal recovery share", z.Str("file", file))
+		}
+
+		if share.BackupID != backupID {
+			return nil, errors.New("recovery share of another backup in ceremony dir", z.Str("file", file))
+		}
+
+		resp = append(resp, share)
+	}
+
+	return resp, nil
+}
+
+// parseRecoveryKey returns the recovery public key of an ENR or hex encoded compressed public key.
+func parseRecoveryKey(s string) (*k1.PublicKey, error) {
+	if strings.HasPrefix(s, "enr:") {
+		record, err := enr.Parse(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse recovery key enr")
+		}
+
+		return record.PubKey, nil
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode recovery key", z.Str("key", s))
+	}
+
+	pubkey, err := k1.ParsePubKey(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse recovery key", z.Str("key", s))
+	}
+
+	return pubkey, nil
+}
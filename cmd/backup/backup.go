@@ -0,0 +1,312 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package backup implements threshold-encrypted backups of a node's validator key shares.
+//
+// Each key share is split into recovery shares, one per operator-held recovery key, of which a threshold
+// recovers the key share. Each recovery share is encrypted to its recovery key, so no single recovery key
+// holder, nor the backup file alone, reveals a key share.
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	version = "v1"
+
+	// keyDomain separates the symmetric keys derived from the ECDH shared secrets.
+	keyDomain = "charon/backup/v1"
+)
+
+// Backup is a threshold-encrypted backup of a node's validator key shares.
+type Backup struct {
+	Version      string    `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	Threshold    int       `json:"threshold"`
+	RecoveryKeys []string  `json:"recovery_keys"` // Hex encoded compressed secp256k1 public keys.
+	Keys         []Key     `json:"keys"`
+}
+
+// Key is the backup of a single validator key share.
+type Key struct {
+	PublicShare     string   `json:"public_share"`
+	EncryptedShares []string `json:"encrypted_shares"` // Hex encoded encrypted recovery shares by recovery key.
+}
+
+// ID returns a hex encoded identifier of the backup, binding recovery shares to the backup.
+func (b Backup) ID() string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(b.Version))
+
+	for _, key := range b.RecoveryKeys {
+		_, _ = h.Write([]byte(key))
+	}
+
+	for _, key := range b.Keys {
+		_, _ = h.Write([]byte(key.PublicShare))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RecoveryShare contains the decrypted recovery shares of a single recovery key, by validator key share.
+type RecoveryShare struct {
+	BackupID string   `json:"backup_id"`
+	Index    int      `json:"index"`  // 1-indexed recovery key index.
+	Shares   []string `json:"shares"` // Hex encoded recovery share secrets by key share.
+}
+
+// Create returns a backup of the key shares that the threshold of the recovery keys recovers.
+func Create(secrets []tbls.PrivateKey, recoveryKeys []*k1.PublicKey, threshold int) (Backup, error) {
+	if len(secrets) == 0 {
+		return Backup{}, errors.New("no key shares to backup")
+	} else if threshold < 1 || threshold > len(recoveryKeys) {
+		return Backup{}, errors.New("threshold must be between 1 and the number of recovery keys",
+			z.Int("threshold", threshold), z.Int("recovery_keys", len(recoveryKeys)))
+	}
+
+	resp := Backup{
+		Version:   version,
+		CreatedAt: time.Now().UTC(),
+		Threshold: threshold,
+	}
+
+	for _, key := range recoveryKeys {
+		resp.RecoveryKeys = append(resp.RecoveryKeys, hex.EncodeToString(key.SerializeCompressed()))
+	}
+
+	for _, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return Backup{}, err
+		}
+
+		splits, err := tbls.ThresholdSplit(secret, uint(len(recoveryKeys)), uint(threshold))
+		if err != nil {
+			return Backup{}, errors.Wrap(err, "split key share")
+		}
+
+		key := Key{PublicShare: hex.EncodeToString(pubshare[:])}
+
+		for i, recoveryKey := range recoveryKeys {
+			split, ok := splits[i+1]
+			if !ok {
+				return Backup{}, errors.New("missing recovery share", z.Int("index", i+1))
+			}
+
+			ciphertext, err := encrypt(recoveryKey, split[:])
+			if err != nil {
+				return Backup{}, err
+			}
+
+			key.EncryptedShares = append(key.EncryptedShares, hex.EncodeToString(ciphertext))
+		}
+
+		resp.Keys = append(resp.Keys, key)
+	}
+
+	return resp, nil
+}
+
+// DecryptShare returns the recovery shares of the backup encrypted to the recovery private key.
+func DecryptShare(backup Backup, key *k1.PrivateKey) (RecoveryShare, error) {
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+
+	index := -1
+
+	for i, recoveryKey := range backup.RecoveryKeys {
+		if strings.EqualFold(recoveryKey, pubkey) {
+			index = i
+			break
+		}
+	}
+
+	if index < 0 {
+		return RecoveryShare{}, errors.New("recovery key not part of backup", z.Str("pubkey", pubkey))
+	}
+
+	resp := RecoveryShare{
+		BackupID: backup.ID(),
+		Index:    index + 1,
+	}
+
+	for _, k := range backup.Keys {
+		if len(k.EncryptedShares) != len(backup.RecoveryKeys) {
+			return RecoveryShare{}, errors.New("invalid backup, encrypted shares mismatch recovery keys", z.Str("public_share", k.PublicShare))
+		}
+
+		ciphertext, err := hex.DecodeString(k.EncryptedShares[index])
+		if err != nil {
+			return RecoveryShare{}, errors.Wrap(err, "decode encrypted share")
+		}
+
+		plaintext, err := decrypt(key, ciphertext)
+		if err != nil {
+			return RecoveryShare{}, err
+		}
+
+		resp.Shares = append(resp.Shares, hex.EncodeToString(plaintext))
+	}
+
+	return resp, nil
+}
+
+// Recover returns the key shares recovered from at least a threshold of recovery shares,
+// verifying them against the public shares in the backup.
+func Recover(backup Backup, shares []RecoveryShare) ([]tbls.PrivateKey, error) {
+	byIndex := make(map[int]RecoveryShare)
+
+	for _, share := range shares {
+		if share.BackupID != backup.ID() {
+			return nil, errors.New("recovery share of another backup", z.Int("index", share.Index))
+		} else if share.Index < 1 || share.Index > len(backup.RecoveryKeys) {
+			return nil, errors.New("invalid recovery share index", z.Int("index", share.Index))
+		} else if len(share.Shares) != len(backup.Keys) {
+			return nil, errors.New("recovery share count mismatch", z.Int("index", share.Index))
+		}
+
+		byIndex[share.Index] = share
+	}
+
+	if len(byIndex) < backup.Threshold {
+		return nil, errors.New("insufficient recovery shares", z.Int("shares", len(byIndex)), z.Int("threshold", backup.Threshold))
+	}
+
+	var resp []tbls.PrivateKey
+
+	for i, key := range backup.Keys {
+		splits := make(map[int]tbls.PrivateKey)
+
+		for index, share := range byIndex {
+			b, err := hex.DecodeString(share.Shares[i])
+			if err != nil {
+				return nil, errors.Wrap(err, "decode recovery share")
+			} else if len(b) != len(tbls.PrivateKey{}) {
+				return nil, errors.New("invalid recovery share length", z.Int("index", index))
+			}
+
+			splits[index] = tbls.PrivateKey(b)
+		}
+
+		secret, err := tbls.RecoverSecret(splits, uint(len(backup.RecoveryKeys)), uint(backup.Threshold))
+		if err != nil {
+			return nil, errors.Wrap(err, "recover key share")
+		}
+
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if hex.EncodeToString(pubshare[:]) != key.PublicShare {
+			return nil, errors.New("recovered key share mismatches public share", z.Str("public_share", key.PublicShare))
+		}
+
+		resp = append(resp, secret)
+	}
+
+	return resp, nil
+}
+
+// Load returns the backup stored in the file.
+func Load(filename string) (Backup, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return Backup{}, errors.Wrap(err, "read backup file")
+	}
+
+	var resp Backup
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return Backup{}, errors.Wrap(err, "unmarshal backup file")
+	}
+
+	if resp.Version != version {
+		return Backup{}, errors.New("unsupported backup version", z.Str("version", resp.Version))
+	}
+
+	return resp, nil
+}
+
+// encrypt returns the plaintext encrypted to the public key using an ephemeral ECDH key and AES-GCM.
+// The ciphertext is prefixed by the compressed ephemeral public key and the nonce.
+func encrypt(pubkey *k1.PublicKey, plaintext []byte) ([]byte, error) {
+	ephemeral, err := k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate ephemeral key")
+	}
+
+	aead, err := newAEAD(ephemeral, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+
+	resp := append(ephemeral.PubKey().SerializeCompressed(), nonce...)
+
+	return aead.Seal(resp, nonce, plaintext, nil), nil
+}
+
+// decrypt returns the ciphertext decrypted with the private key.
+func decrypt(key *k1.PrivateKey, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < k1.PubKeyBytesLenCompressed {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	ephemeral, err := k1.ParsePubKey(ciphertext[:k1.PubKeyBytesLenCompressed])
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ephemeral public key")
+	}
+
+	ciphertext = ciphertext[k1.PubKeyBytesLenCompressed:]
+
+	aead, err := newAEAD(key, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt recovery share")
+	}
+
+	return plaintext, nil
+}
+
+// newAEAD returns an AES-GCM cipher keyed by the ECDH shared secret of the private and public key.
+func newAEAD(key *k1.PrivateKey, pubkey *k1.PublicKey) (cipher.AEAD, error) {
+	derived := sha256.Sum256(append([]byte(keyDomain), k1.GenerateSharedSecret(key, pubkey)...))
+
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new aes cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "new gcm cipher")
+	}
+
+	return aead, nil
+}
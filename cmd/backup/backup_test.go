@@ -0,0 +1,99 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package backup_test
+
+import (
+	"testing"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cmd/backup"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestBackupRecover(t *testing.T) {
+	const (
+		numKeys     = 2
+		numRecovery = 4
+		threshold   = 3
+	)
+
+	var secrets []tbls.PrivateKey
+	for range numKeys {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+	}
+
+	var (
+		recoveryKeys []*k1.PrivateKey
+		pubkeys      []*k1.PublicKey
+	)
+	for i := range numRecovery {
+		key := testutil.GenerateInsecureK1Key(t, i)
+		recoveryKeys = append(recoveryKeys, key)
+		pubkeys = append(pubkeys, key.PubKey())
+	}
+
+	b, err := backup.Create(secrets, pubkeys, threshold)
+	require.NoError(t, err)
+	require.Len(t, b.Keys, numKeys)
+
+	var shares []backup.RecoveryShare
+	for i, key := range recoveryKeys {
+		share, err := backup.DecryptShare(b, key)
+		require.NoError(t, err)
+		require.Equal(t, i+1, share.Index)
+
+		shares = append(shares, share)
+	}
+
+	// Below threshold fails.
+	_, err = backup.Recover(b, shares[:threshold-1])
+	require.ErrorContains(t, err, "insufficient recovery shares")
+
+	// Duplicate shares don't count towards the threshold.
+	_, err = backup.Recover(b, []backup.RecoveryShare{shares[0], shares[0], shares[1]})
+	require.ErrorContains(t, err, "insufficient recovery shares")
+
+	// Any threshold subset recovers the key shares.
+	recovered, err := backup.Recover(b, shares[1:])
+	require.NoError(t, err)
+	require.Equal(t, secrets, recovered)
+
+	recovered, err = backup.Recover(b, []backup.RecoveryShare{shares[0], shares[2], shares[3]})
+	require.NoError(t, err)
+	require.Equal(t, secrets, recovered)
+
+	// Tampered recovery shares fail verification against the public shares.
+	tampered := shares[1]
+	tampered.Shares = []string{shares[0].Shares[0], shares[0].Shares[1]}
+	_, err = backup.Recover(b, []backup.RecoveryShare{shares[0], tampered, shares[2]})
+	require.ErrorContains(t, err, "recovered key share mismatches public share")
+
+	// Unknown recovery keys can't decrypt.
+	_, err = backup.DecryptShare(b, testutil.GenerateInsecureK1Key(t, numRecovery))
+	require.ErrorContains(t, err, "recovery key not part of backup")
+
+	// Recovery shares are bound to their backup.
+	other, err := backup.Create(secrets[:1], pubkeys, threshold)
+	require.NoError(t, err)
+	_, err = backup.Recover(other, shares)
+	require.ErrorContains(t, err, "recovery share of another backup")
+}
+
+func TestCreateInvalidThreshold(t *testing.T) {
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubkeys := []*k1.PublicKey{testutil.GenerateInsecureK1Key(t, 0).PubKey()}
+
+	_, err = backup.Create([]tbls.PrivateKey{secret}, pubkeys, 2)
+	require.ErrorContains(t, err, "threshold must be between 1 and the number of recovery keys")
+
+	_, err = backup.Create(nil, pubkeys, 1)
+	require.ErrorContains(t, err, "no key shares to backup")
+}
@@ -0,0 +1,102 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestBackupCeremony(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	keysDir := filepath.Join(dir, "validator_keys")
+	require.NoError(t, os.Mkdir(keysDir, 0o755))
+
+	var secrets []tbls.PrivateKey
+	for range 2 {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+	}
+
+	require.NoError(t, keystore.StoreKeysInsecure(secrets, keysDir, keystore.ConfirmInsecureKeys))
+
+	// Recovery keys are provided both as ENRs and hex public keys.
+	var (
+		recoveryKeys     []string
+		recoveryKeyFiles []string
+	)
+	for i := range 3 {
+		key := testutil.GenerateInsecureK1Key(t, i)
+
+		file := filepath.Join(dir, fmt.Sprintf("recovery-key-%d", i))
+		require.NoError(t, k1util.Save(key, file))
+		recoveryKeyFiles = append(recoveryKeyFiles, file)
+
+		if i == 0 {
+			record, err := enr.New(key)
+			require.NoError(t, err)
+			recoveryKeys = append(recoveryKeys, record.String())
+
+			continue
+		}
+
+		recoveryKeys = append(recoveryKeys, "0x"+hex.EncodeToString(key.PubKey().SerializeCompressed()))
+	}
+
+	backupFile := filepath.Join(dir, "backup.json")
+
+	var buf bytes.Buffer
+	err := runBackupCreate(ctx, &buf, backupCreateConfig{
+		ValidatorKeysDir: keysDir,
+		RecoveryKeys:     recoveryKeys,
+		OutputFile:       backupFile,
+	})
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "recoverable by 2 of 3 recovery keys")
+
+	restoreConf := backupRestoreConfig{
+		BackupFile:  backupFile,
+		CeremonyDir: filepath.Join(dir, "ceremony"),
+		OutputDir:   filepath.Join(dir, "restored"),
+	}
+
+	// First recovery key holder.
+	buf.Reset()
+	restoreConf.RecoveryPrivateKeyFile = recoveryKeyFiles[2]
+	require.NoError(t, runBackupRestore(ctx, &buf, restoreConf))
+	require.Contains(t, buf.String(), "Collected 1 of 2 required recovery shares")
+
+	// Second recovery key holder completes the ceremony.
+	buf.Reset()
+	restoreConf.RecoveryPrivateKeyFile = recoveryKeyFiles[0]
+	require.NoError(t, runBackupRestore(ctx, &buf, restoreConf))
+	require.Contains(t, buf.String(), "Restored and verified 2 key shares")
+
+	keyFiles, err := keystore.LoadFilesUnordered(restoreConf.OutputDir)
+	require.NoError(t, err)
+	restored, err := keyFiles.SequencedKeys()
+	require.NoError(t, err)
+	require.Equal(t, secrets, restored)
+
+	// Existing key shares are never overwritten.
+	restoreConf.RecoveryPrivateKeyFile = ""
+	err = runBackupRestore(ctx, &buf, restoreConf)
+	require.ErrorContains(t, err, "output dir already contains key shares")
+}
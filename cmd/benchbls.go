@@ -0,0 +1,193 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	// benchBLSBatchSize is the number of signatures per batch verification, similar to a parsigex attestation batch.
+	benchBLSBatchSize = 64
+	// benchBLSNodes and benchBLSThreshold define the cluster size of threshold aggregations.
+	benchBLSNodes     = 4
+	benchBLSThreshold = 3
+)
+
+type benchBLSConfig struct {
+	Backends   []string
+	Iterations int
+}
+
+// benchBLSResult is the benchmark result of a BLS operation on a backend.
+type benchBLSResult struct {
+	Backend   string
+	Operation string
+	Duration  time.Duration // Average duration per operation.
+}
+
+func newBenchBLSCmd(runFunc func(context.Context, io.Writer, benchBLSConfig) error) *cobra.Command {
+	var config benchBLSConfig
+
+	cmd := &cobra.Command{
+		Use:   "bench-bls",
+		Short: "Benchmark BLS backends on this host",
+		Long: `Benchmarks the sign, verify, batch verify and threshold aggregate performance of the supported BLS backends on this host. ` +
+			`Crypto performance differs significantly between backends and CPU architectures, use the fastest backend via the --bls-backend run flag.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&config.Backends, "backends", tbls.Backends(), "Comma-separated list of BLS backends to benchmark.")
+	cmd.Flags().IntVar(&config.Iterations, "iterations", 1000, "Number of iterations of each operation.")
+
+	return cmd
+}
+
+func runBenchBLS(ctx context.Context, out io.Writer, config benchBLSConfig) error {
+	if config.Iterations < 1 {
+		return errors.New("iterations must be at least 1", z.Int("iterations", config.Iterations))
+	}
+
+	for _, backend := range config.Backends {
+		impl, err := tbls.BackendImplementation(backend)
+		if err != nil {
+			return err
+		}
+
+		results, err := benchBLS(ctx, backend, impl, config.Iterations)
+		if err != nil {
+			return errors.Wrap(err, "benchmark bls backend", z.Str("backend", backend))
+		}
+
+		for _, result := range results {
+			_, err := fmt.Fprintf(out, "backend=%s operation=%s avg=%s ops_per_sec=%.0f\n",
+				result.Backend, result.Operation, result.Duration, float64(time.Second)/float64(result.Duration))
+			if err != nil {
+				return errors.Wrap(err, "write benchmark result")
+			}
+		}
+	}
+
+	return nil
+}
+
+// benchBLS returns the average duration of each BLS operation of the implementation.
+func benchBLS(ctx context.Context, backend string, impl tbls.Implementation, iterations int) ([]benchBLSResult, error) {
+	secret, err := impl.GenerateSecretKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pubkey, err := impl.SecretToPublicKey(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, 32) // Eth2 signing roots are 32 bytes.
+
+	sig, err := impl.Sign(secret, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare a batch of signatures of different keys on different messages.
+	var (
+		batchPubkeys []tbls.PublicKey
+		batchMsgs    [][]byte
+		batchSigs    []tbls.Signature
+	)
+
+	for i := range benchBLSBatchSize {
+		secret, err := impl.GenerateSecretKey()
+		if err != nil {
+			return nil, err
+		}
+
+		pubkey, err := impl.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := make([]byte, 32)
+		msg[0] = byte(i)
+
+		sig, err := impl.Sign(secret, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		batchPubkeys = append(batchPubkeys, pubkey)
+		batchMsgs = append(batchMsgs, msg)
+		batchSigs = append(batchSigs, sig)
+	}
+
+	// Prepare threshold partial signatures.
+	shares, err := impl.ThresholdSplit(secret, benchBLSNodes, benchBLSThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	partialSigs := make(map[int]tbls.Signature)
+	for idx := 1; idx <= benchBLSThreshold; idx++ {
+		partialSigs[idx], err = impl.Sign(shares[idx], msg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ops := []struct {
+		Name string
+		Func func() error
+	}{
+		{Name: "sign", Func: func() error {
+			_, err := impl.Sign(secret, msg)
+			return err
+		}},
+		{Name: "verify", Func: func() error {
+			return impl.Verify(pubkey, msg, sig)
+		}},
+		{Name: fmt.Sprintf("batch_verify_%d", benchBLSBatchSize), Func: func() error {
+			return impl.BatchVerify(batchPubkeys, batchMsgs, batchSigs)
+		}},
+		{Name: fmt.Sprintf("threshold_aggregate_%d_of_%d", benchBLSThreshold, benchBLSNodes), Func: func() error {
+			_, err := impl.ThresholdAggregate(partialSigs)
+			return err
+		}},
+	}
+
+	var resp []benchBLSResult
+
+	for _, op := range ops {
+		t0 := time.Now()
+
+		for range iterations {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			if err := op.Func(); err != nil {
+				return nil, errors.Wrap(err, "bls operation", z.Str("operation", op.Name))
+			}
+		}
+
+		resp = append(resp, benchBLSResult{
+			Backend:   backend,
+			Operation: op.Name,
+			Duration:  time.Since(t0) / time.Duration(iterations),
+		})
+	}
+
+	return resp, nil
+}
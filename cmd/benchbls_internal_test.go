@@ -0,0 +1,37 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestBenchBLS(t *testing.T) {
+	config := benchBLSConfig{
+		Backends:   tbls.Backends(),
+		Iterations: 2,
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runBenchBLS(context.Background(), &out, config))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 8)
+	require.Contains(t, lines[0], "backend=herumi operation=sign")
+	require.Contains(t, lines[4], "backend=blst operation=sign")
+	require.Contains(t, lines[7], "backend=blst operation=threshold_aggregate_3_of_4")
+
+	config.Backends = []string{"invalid"}
+	require.ErrorContains(t, runBenchBLS(context.Background(), &out, config), "unknown bls backend")
+
+	config.Backends = tbls.Backends()
+	config.Iterations = 0
+	require.ErrorContains(t, runBenchBLS(context.Background(), &out, config), "iterations must be at least 1")
+}
@@ -0,0 +1,17 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import "github.com/spf13/cobra"
+
+func newCheckCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "check",
+		Short: "Check local distributed validator artifacts",
+		Long:  "Check local distributed validator artifacts for consistency before the node goes live.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
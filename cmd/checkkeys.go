@@ -0,0 +1,194 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// checkKeysSigningData is the data signed to verify key shares.
+var checkKeysSigningData = []byte("charon check keys")
+
+type checkKeysConfig struct {
+	PrivateKeyPath   string
+	LockFilePath     string
+	ValidatorKeysDir string
+	VerifySignature  bool
+}
+
+func newCheckKeysCmd(runFunc func(io.Writer, checkKeysConfig) error) *cobra.Command {
+	var config checkKeysConfig
+
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Check the validator key shares of this node",
+		Long: `Checks that the local validator key shares decrypt with their keystore passwords, ` +
+			`match the public shares of this node in the cluster lock and that no key shares of other nodes are present.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.PrivateKeyPath, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "Path to the directory containing the validator private key share files and passwords.")
+	cmd.Flags().BoolVar(&config.VerifySignature, "verify-signature", false, "Additionally sign test data with each key share and verify the partial signature against the validator's public share in the cluster lock.")
+
+	return cmd
+}
+
+func runCheckKeys(out io.Writer, config checkKeysConfig) error {
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return err
+	}
+
+	identityKey, err := k1util.Load(config.PrivateKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_path", config.PrivateKeyPath))
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *identityKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "determine share index of this node")
+	}
+
+	var failures int
+
+	report := func(ok bool, format string, args ...any) error {
+		status := "PASS"
+		if !ok {
+			status = "FAIL"
+			failures++
+		}
+
+		_, err := fmt.Fprintf(out, "%s  %s\n", status, fmt.Sprintf(format, args...))
+		if err != nil {
+			return errors.Wrap(err, "write check result")
+		}
+
+		return nil
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(config.ValidatorKeysDir)
+	if err != nil {
+		_ = report(false, "keystores decrypt with their passwords: %v", err)
+		return errors.New("key material check failed")
+	}
+
+	if err := report(true, "%d keystores decrypt with their passwords", len(keyFiles)); err != nil {
+		return err
+	}
+
+	secrets := make(map[tbls.PublicKey]keystore.KeyFile)
+	for _, keyFile := range keyFiles {
+		pubshare, err := tbls.SecretToPublicKey(keyFile.PrivateKey)
+		if err != nil {
+			return errors.Wrap(err, "secret to public share", z.Str("filename", keyFile.Filename))
+		}
+
+		secrets[pubshare] = keyFile
+	}
+
+	matched := make(map[tbls.PublicKey]bool)
+	for i, val := range cl.GetValidators() {
+		valHex := fmt.Sprintf("%#x", val.GetPublicKey())
+
+		if len(val.GetPubShares()) < int(shareIdx) {
+			return errors.New("missing public share of this node in cluster lock", z.Int("validator", i))
+		}
+
+		// Share index is 1-indexed.
+		pubshare := tbls.PublicKey(val.GetPubShares()[shareIdx-1])
+
+		for j, b := range val.GetPubShares() {
+			share := tbls.PublicKey(b)
+			if _, ok := secrets[share]; !ok {
+				continue
+			}
+
+			matched[share] = true
+
+			if j+1 != int(shareIdx) {
+				err := report(false, "validator %d (%s): key share %s belongs to share index %d, expected share index %d of this node",
+					i, valHex, secrets[share].Filename, j+1, shareIdx)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		keyFile, ok := secrets[pubshare]
+		if !ok {
+			if err := report(false, "validator %d (%s): key share of share index %d missing", i, valHex, shareIdx); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !config.VerifySignature {
+			if err := report(true, "validator %d (%s): key share %s matches lock public share", i, valHex, keyFile.Filename); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		sig, err := tbls.Sign(keyFile.PrivateKey, checkKeysSigningData)
+		if err != nil {
+			return errors.Wrap(err, "sign test data")
+		}
+
+		// Verify against the public share of this node in the cluster lock.
+		if err := tbls.Verify(pubshare, checkKeysSigningData, sig); err != nil {
+			err = report(false, "validator %d (%s): partial signature of key share %s invalid: %v", i, valHex, keyFile.Filename, err)
+		} else {
+			err = report(true, "validator %d (%s): key share %s matches lock public share, partial signature verified", i, valHex, keyFile.Filename)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var unmatched []keystore.KeyFile
+	for pubshare, keyFile := range secrets {
+		if !matched[pubshare] {
+			unmatched = append(unmatched, keyFile)
+		}
+	}
+
+	// Report in keystore index order for stable output.
+	slices.SortFunc(unmatched, func(a, b keystore.KeyFile) int {
+		if a.FileIndex != b.FileIndex {
+			return a.FileIndex - b.FileIndex
+		}
+
+		return strings.Compare(a.Filename, b.Filename)
+	})
+
+	for _, keyFile := range unmatched {
+		if err := report(false, "key share %s does not belong to any validator in the cluster lock", keyFile.Filename); err != nil {
+			return err
+		}
+	}
+
+	if failures > 0 {
+		return errors.New("key material check failed", z.Int("failures", failures))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestCheckKeys(t *testing.T) {
+	const (
+		valAmt      = 3
+		operatorAmt = 4
+		opIdx       = 1
+	)
+
+	random := rand.New(rand.NewSource(1))
+	lock, enrs, keyShares := cluster.NewForT(t, valAmt, operatorAmt, operatorAmt, 1, random)
+
+	lockBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockPath, lockBytes, 0o644))
+
+	keyPath := filepath.Join(dir, "charon-enr-private-key")
+	require.NoError(t, k1util.Save(enrs[opIdx], keyPath))
+
+	writeKeys := func(t *testing.T, shares ...tbls.PrivateKey) string {
+		t.Helper()
+
+		keysDir := t.TempDir()
+		require.NoError(t, keystore.StoreKeysInsecure(shares, keysDir, keystore.ConfirmInsecureKeys))
+
+		return keysDir
+	}
+
+	run := func(keysDir string) (string, error) {
+		var out bytes.Buffer
+		err := runCheckKeys(&out, checkKeysConfig{
+			PrivateKeyPath:   keyPath,
+			LockFilePath:     lockPath,
+			ValidatorKeysDir: keysDir,
+			VerifySignature:  true,
+		})
+
+		return out.String(), err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		out, err := run(writeKeys(t, keyShares[0][opIdx], keyShares[1][opIdx], keyShares[2][opIdx]))
+		require.NoError(t, err)
+		require.Equal(t, valAmt+1, strings.Count(out, "PASS"))
+		require.NotContains(t, out, "FAIL")
+		require.Contains(t, out, "partial signature verified")
+	})
+
+	t.Run("wrong share index", func(t *testing.T) {
+		out, err := run(writeKeys(t, keyShares[0][opIdx], keyShares[1][opIdx+1], keyShares[2][opIdx]))
+		require.ErrorContains(t, err, "key material check failed")
+		require.Contains(t, out, "belongs to share index 3, expected share index 2 of this node")
+		require.Contains(t, out, "key share of share index 2 missing")
+	})
+
+	t.Run("unknown share", func(t *testing.T) {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		secret2, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		out, err := run(writeKeys(t, keyShares[0][opIdx], keyShares[1][opIdx], keyShares[2][opIdx], secret, secret2))
+		require.ErrorContains(t, err, "key material check failed")
+		require.Contains(t, out, "does not belong to any validator in the cluster lock")
+
+		// Unknown key shares are reported in keystore index order.
+		require.Less(t, strings.Index(out, "keystore-insecure-3.json"), strings.Index(out, "keystore-insecure-4.json"))
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		keysDir := writeKeys(t, keyShares[0][opIdx], keyShares[1][opIdx], keyShares[2][opIdx])
+		passwordFile := filepath.Join(keysDir, "keystore-insecure-0.txt")
+		require.NoError(t, os.Remove(passwordFile))
+		require.NoError(t, os.WriteFile(passwordFile, []byte("wrong"), 0o644))
+
+		out, err := run(keysDir)
+		require.ErrorContains(t, err, "key material check failed")
+		require.Contains(t, out, "FAIL  keystores decrypt with their passwords")
+	})
+}
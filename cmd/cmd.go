@@ -36,7 +36,7 @@ const (
 func New() *cobra.Command {
 	return newRootCmd(
 		newVersionCmd(runVersionCmd),
-		newEnrCmd(runNewENR),
+		newEnrCmd(runNewENR, runWatchENR),
 		newRunCmd(app.Run, false),
 		newRelayCmd(relay.Run),
 		newDKGCmd(dkg.Run),
@@ -44,15 +44,36 @@ func New() *cobra.Command {
 			newCreateDKGCmd(runCreateDKG),
 			newCreateEnrCmd(runCreateEnrCmd),
 			newCreateClusterCmd(runCreateCluster),
+			newCreateDepositDataCmd(runCreateDepositData),
 		),
 		newCombineCmd(newCombineFunc),
+		newBackupCmd(
+			newBackupCreateCmd(runBackupCreate),
+			newBackupRestoreCmd(runBackupRestore),
+		),
+		newPublishLockCmd(runPublishLock),
+		newCheckCmd(
+			newCheckKeysCmd(runCheckKeys),
+		),
 		newAlphaCmd(
 			newViewClusterManifestCmd(runViewClusterManifest),
+			newConsolidationRequestsCmd(runConsolidationRequests),
+			newExplainLeaderCmd(runExplainLeader),
+			newSnapshotCmd(runSnapshot),
+			newReplayCmd(runReplay),
+			newBenchBLSCmd(runBenchBLS),
+			newRestorePeerBackupCmd(runRestorePeerBackup),
+			newImportValidatorsCmd(
+				newImportValidatorsSplitCmd(runImportValidatorsSplit),
+				newImportValidatorsApproveCmd(runImportValidatorsApprove),
+				newImportValidatorsAddCmd(runImportValidatorsAdd),
+			),
 			newTestCmd(
 				newTestAllCmd(runTestAll),
 				newTestPeersCmd(runTestPeers),
 				newTestBeaconCmd(runTestBeacon),
 				newTestValidatorCmd(runTestValidator),
+				newTestValidatorAPICmd(runTestValidatorAPI),
 				newTestMEVCmd(runTestMEV),
 				newTestInfraCmd(runTestInfra),
 			),
@@ -61,6 +82,7 @@ func New() *cobra.Command {
 			newListActiveValidatorsCmd(runListActiveValidatorsCmd),
 			newSignPartialExitCmd(runSignPartialExit),
 			newBcastFullExitCmd(runBcastFullExit),
+			newVerifyExitCmd(runVerifyExit),
 			newFetchExitCmd(runFetchExit),
 			newDeleteExitCmd(runDeleteExit),
 		),
@@ -211,7 +233,8 @@ func flagsToLogFields(flags *pflag.FlagSet) []z.Field {
 // redact returns a redacted version of the given flag value. It currently supports redacting
 // passwords in valid URLs provided in ".*address.*" flags and redacting auth tokens.
 func redact(flag, val string) string {
-	if strings.Contains(flag, "auth-token") {
+	if strings.Contains(flag, "auth-token") || strings.HasSuffix(flag, "-token") || strings.Contains(flag, "basic-auth") ||
+		strings.Contains(flag, "webhook") {
 		return "xxxxx"
 	}
 
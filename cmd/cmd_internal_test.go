@@ -70,19 +70,31 @@ func TestCmdFlags(t *testing.T) {
 					Enabled:   nil,
 					Disabled:  nil,
 				},
-				LockFile:                ".charon/cluster-lock.json",
-				ManifestFile:            ".charon/cluster-manifest.pb",
-				PrivKeyFile:             ".charon/charon-enr-private-key",
-				PrivKeyLocking:          false,
-				SimnetValidatorKeysDir:  ".charon/validator_keys",
-				SimnetSlotDuration:      time.Second,
-				MonitoringAddr:          "127.0.0.1:3620",
-				ValidatorAPIAddr:        "127.0.0.1:3600",
-				OTLPAddress:             "",
-				OTLPServiceName:         "charon",
-				BeaconNodeAddrs:         []string{"http://beacon.node"},
-				BeaconNodeTimeout:       2 * time.Second,
-				BeaconNodeSubmitTimeout: 2 * time.Second,
+				LockFile:                 ".charon/cluster-lock.json",
+				ManifestFile:             ".charon/cluster-manifest.pb",
+				PrivKeyFile:              ".charon/charon-enr-private-key",
+				PrivKeyLocking:           false,
+				SimnetValidatorKeysDir:   ".charon/validator_keys",
+				SimnetSlotDuration:       time.Second,
+				SimnetSlotsPerEpoch:      16,
+				MonitoringAddr:           "127.0.0.1:3620",
+				ValidatorAPIAddr:         "127.0.0.1:3600",
+				OTLPAddress:              "",
+				OTLPServiceName:          "charon",
+				BeaconNodeAddrs:          []string{"http://beacon.node"},
+				BeaconNodeTimeout:        2 * time.Second,
+				BeaconNodeSubmitTimeout:  2 * time.Second,
+				AttestationTiming:        "immediate",
+				SyncMessageFallbackDelay: 6 * time.Second,
+				AttestationFallbackDelay: 8 * time.Second,
+				ClockSkewThreshold:       0.1,
+				TrackerBackfillEpochs:    2,
+				StartupWaitTimeout:       5 * time.Minute,
+				AlertBeaconNodeDownSlots: 5,
+				OvercollectTimeout:       500 * time.Millisecond,
+				VCProxyBreakerCooldown:   10 * time.Second,
+				ShutdownDrainTimeout:     8 * time.Second,
+				BLSBackend:               "herumi",
 			},
 		},
 		{
@@ -121,19 +133,31 @@ func TestCmdFlags(t *testing.T) {
 					Enabled:   nil,
 					Disabled:  nil,
 				},
-				LockFile:                ".charon/cluster-lock.json",
-				ManifestFile:            ".charon/cluster-manifest.pb",
-				PrivKeyFile:             ".charon/charon-enr-private-key",
-				PrivKeyLocking:          false,
-				SimnetValidatorKeysDir:  ".charon/validator_keys",
-				SimnetSlotDuration:      time.Second,
-				MonitoringAddr:          "127.0.0.1:3620",
-				ValidatorAPIAddr:        "127.0.0.1:3600",
-				OTLPAddress:             "",
-				OTLPServiceName:         "charon",
-				BeaconNodeAddrs:         []string{"http://beacon.node"},
-				BeaconNodeTimeout:       2 * time.Second,
-				BeaconNodeSubmitTimeout: 2 * time.Second,
+				LockFile:                 ".charon/cluster-lock.json",
+				ManifestFile:             ".charon/cluster-manifest.pb",
+				PrivKeyFile:              ".charon/charon-enr-private-key",
+				PrivKeyLocking:           false,
+				SimnetValidatorKeysDir:   ".charon/validator_keys",
+				SimnetSlotDuration:       time.Second,
+				SimnetSlotsPerEpoch:      16,
+				MonitoringAddr:           "127.0.0.1:3620",
+				ValidatorAPIAddr:         "127.0.0.1:3600",
+				OTLPAddress:              "",
+				OTLPServiceName:          "charon",
+				BeaconNodeAddrs:          []string{"http://beacon.node"},
+				BeaconNodeTimeout:        2 * time.Second,
+				BeaconNodeSubmitTimeout:  2 * time.Second,
+				AttestationTiming:        "immediate",
+				SyncMessageFallbackDelay: 6 * time.Second,
+				AttestationFallbackDelay: 8 * time.Second,
+				ClockSkewThreshold:       0.1,
+				TrackerBackfillEpochs:    2,
+				StartupWaitTimeout:       5 * time.Minute,
+				AlertBeaconNodeDownSlots: 5,
+				OvercollectTimeout:       500 * time.Millisecond,
+				VCProxyBreakerCooldown:   10 * time.Second,
+				ShutdownDrainTimeout:     8 * time.Second,
+				BLSBackend:               "herumi",
 				TestConfig: app.TestConfig{
 					P2PFuzz: true,
 				},
@@ -149,9 +173,11 @@ func TestCmdFlags(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			root := newRootCmd(
-				newVersionCmd(func(_ io.Writer, config versionConfig) {
+				newVersionCmd(func(_ context.Context, _ io.Writer, config versionConfig) error {
 					require.NotNil(t, test.VersionConfig)
 					require.Equal(t, *test.VersionConfig, config)
+
+					return nil
 				}),
 				newRunCmd(func(_ context.Context, config app.Config) error {
 					require.NotNil(t, test.AppConfig)
@@ -230,6 +256,24 @@ func TestRedact(t *testing.T) {
 			value:    "api-token-abcdef12345",
 			expected: "xxxxx",
 		},
+		{
+			name:     "redact monitoring tokens",
+			flag:     "vc-monitoring-token",
+			value:    "monitoring-token-abcdef12345",
+			expected: "xxxxx",
+		},
+		{
+			name:     "redact basic auth",
+			flag:     "monitoring-basic-auth",
+			value:    "admin:secret",
+			expected: "xxxxx",
+		},
+		{
+			name:     "redact webhook urls",
+			flag:     "alert-webhook-urls",
+			value:    "[https://hooks.slack.com/services/T000/B000/secret]",
+			expected: "xxxxx",
+		},
 		{
 			name:     "redact passwords in URL addresses",
 			flag:     "api-address",
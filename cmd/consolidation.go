@@ -0,0 +1,164 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+)
+
+type consolidationConfig struct {
+	LockFilePath   string
+	TargetPubKey   string
+	OutputFilePath string
+}
+
+func newConsolidationRequestsCmd(runFunc func(io.Writer, consolidationConfig) error) *cobra.Command {
+	var config consolidationConfig
+
+	cmd := &cobra.Command{
+		Use:   "consolidation-requests",
+		Short: "Create consolidation requests migrating validators to compounding withdrawal credentials",
+		Long: `Creates the EIP-7251 consolidation requests required to switch the validators of an existing cluster from 0x01 to compounding 0x02 withdrawal credentials. ` +
+			`By default a self-consolidation request is created for each validator. If a target validator is specified, all validators sharing its withdrawal address are consolidated into it. ` +
+			`The resulting requests must be submitted to the consolidation contract from the validators' withdrawal address.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().StringVar(&config.TargetPubKey, "target-public-key", "", "Optional public key of the validator to consolidate all other validators sharing its withdrawal address into.")
+	cmd.Flags().StringVar(&config.OutputFilePath, "output-file", "consolidation-requests.json", "The path to write the consolidation requests to.")
+
+	return cmd
+}
+
+func runConsolidationRequests(out io.Writer, config consolidationConfig) error {
+	lockBytes, err := os.ReadFile(config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "read lock file", z.Str("path", config.LockFilePath))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(lockBytes, &lock); err != nil {
+		return errors.Wrap(err, "unmarshal lock json", z.Str("path", config.LockFilePath))
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return errors.Wrap(err, "cluster lock hash verification failed")
+	}
+
+	reqs, err := consolidationRequestsFromLock(lock, config.TargetPubKey)
+	if err != nil {
+		return err
+	}
+
+	reqsJSON, err := json.MarshalIndent(reqs, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal consolidation requests")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(config.OutputFilePath, reqsJSON, 0o444); err != nil {
+		return errors.Wrap(err, "write consolidation requests", z.Str("path", config.OutputFilePath))
+	}
+
+	if _, err := fmt.Fprintf(out, "Created %d consolidation requests: %s\n", len(reqs), config.OutputFilePath); err != nil {
+		return errors.Wrap(err, "output write")
+	}
+
+	return nil
+}
+
+// consolidationRequestsFromLock returns the consolidation requests migrating the lock's validators to
+// compounding withdrawal credentials, optionally consolidating them into the provided target validator.
+func consolidationRequestsFromLock(lock cluster.Lock, targetPubKey string) ([]electra.ConsolidationRequest, error) {
+	if lock.Compounding {
+		return nil, errors.New("cluster validators already use compounding withdrawal credentials")
+	}
+
+	if len(lock.ValidatorAddresses) != len(lock.Validators) {
+		return nil, errors.New("validator addresses and validators count mismatch")
+	}
+
+	var sources []deposit.ConsolidationSource
+	for i, val := range lock.Validators {
+		var pubkey eth2p0.BLSPubKey
+		if len(val.PubKey) != len(pubkey) {
+			return nil, errors.New("invalid validator public key length", z.Int("validator_index", i))
+		}
+		copy(pubkey[:], val.PubKey)
+
+		sources = append(sources, deposit.ConsolidationSource{
+			PubKey:            pubkey,
+			WithdrawalAddress: lock.ValidatorAddresses[i].WithdrawalAddress,
+			Amount:            depositedAmount(val),
+		})
+	}
+
+	if targetPubKey == "" {
+		return deposit.NewSelfConsolidationRequests(sources)
+	}
+
+	targetBytes, err := hex.DecodeString(strings.TrimPrefix(targetPubKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode target public key")
+	}
+
+	var (
+		target      deposit.ConsolidationSource
+		foundTarget bool
+		others      []deposit.ConsolidationSource
+	)
+	for _, source := range sources {
+		if bytes.Equal(source.PubKey[:], targetBytes) {
+			target = source
+			foundTarget = true
+		}
+	}
+
+	if !foundTarget {
+		return nil, errors.New("target validator not found in cluster lock", z.Str("pubkey", targetPubKey))
+	}
+
+	for _, source := range sources {
+		if source.PubKey == target.PubKey || !strings.EqualFold(source.WithdrawalAddress, target.WithdrawalAddress) {
+			continue
+		}
+
+		others = append(others, source)
+	}
+
+	return deposit.NewConsolidationRequests(target, others)
+}
+
+// depositedAmount returns the total amount deposited for the validator, defaulting to 32ETH
+// for locks without partial deposit data.
+func depositedAmount(val cluster.DistValidator) eth2p0.Gwei {
+	var sum eth2p0.Gwei
+	for _, dd := range val.PartialDepositData {
+		sum += eth2p0.Gwei(dd.Amount)
+	}
+
+	if sum == 0 {
+		return deposit.DefaultDepositAmount
+	}
+
+	return sum
+}
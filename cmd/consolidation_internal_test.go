@@ -0,0 +1,75 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestConsolidationRequests(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 3, 3, 4, seed, random)
+
+	t.Run("self consolidation", func(t *testing.T) {
+		lockPath := filepath.Join(t.TempDir(), "cluster-lock.json")
+		lockJSON, err := json.Marshal(lock)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(lockPath, lockJSON, 0o644))
+
+		outPath := filepath.Join(t.TempDir(), "consolidation-requests.json")
+
+		var output bytes.Buffer
+		require.NoError(t, runConsolidationRequests(&output, consolidationConfig{
+			LockFilePath:   lockPath,
+			OutputFilePath: outPath,
+		}))
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+
+		var reqs []electra.ConsolidationRequest
+		require.NoError(t, json.Unmarshal(b, &reqs))
+		require.Len(t, reqs, len(lock.Validators))
+
+		for i, req := range reqs {
+			require.EqualValues(t, lock.Validators[i].PubKey, req.SourcePubkey[:])
+			require.Equal(t, req.SourcePubkey, req.TargetPubkey)
+		}
+	})
+
+	t.Run("consolidate into target", func(t *testing.T) {
+		lock := lock
+		lock.ValidatorAddresses = slices.Clone(lock.ValidatorAddresses)
+		for i := range lock.ValidatorAddresses {
+			lock.ValidatorAddresses[i].WithdrawalAddress = lock.ValidatorAddresses[0].WithdrawalAddress
+		}
+
+		reqs, err := consolidationRequestsFromLock(lock, lock.Validators[0].PublicKeyHex())
+		require.NoError(t, err)
+		require.Len(t, reqs, len(lock.Validators))
+
+		for _, req := range reqs {
+			require.EqualValues(t, lock.Validators[0].PubKey, req.TargetPubkey[:])
+		}
+	})
+
+	t.Run("already compounding", func(t *testing.T) {
+		lock := lock
+		lock.Compounding = true
+
+		_, err := consolidationRequestsFromLock(lock, "")
+		require.ErrorContains(t, err, "cluster validators already use compounding withdrawal credentials")
+	})
+}
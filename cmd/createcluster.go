@@ -74,7 +74,8 @@ type clusterConfig struct {
 	Network           string
 	NumDVs            int
 
-	DepositAmounts []int // Amounts specified in ETH (integers).
+	DepositAmounts          []int // Amounts specified in ETH (integers).
+	ValidatorDepositAmounts []int // Per-validator amounts specified in ETH (integers).
 
 	SplitKeys    bool
 	SplitKeysDir string
@@ -95,6 +96,8 @@ type clusterConfig struct {
 	ExecutionEngineAddr string
 
 	Zipped bool
+
+	ComposeVCs []string
 }
 
 func newCreateClusterCmd(runFunc func(context.Context, io.Writer, clusterConfig) error) *cobra.Command {
@@ -154,11 +157,13 @@ func bindClusterFlags(flags *pflag.FlagSet, config *clusterConfig) {
 	flags.Uint64Var(&config.testnetConfig.ChainID, "testnet-chain-id", 0, "Chain ID of the custom test network.")
 	flags.Int64Var(&config.testnetConfig.GenesisTimestamp, "testnet-genesis-timestamp", 0, "Genesis timestamp of the custom test network.")
 	flags.IntSliceVar(&config.DepositAmounts, "deposit-amounts", nil, "List of partial deposit amounts (integers) in ETH. Values must sum up to at least 32ETH.")
+	flags.IntSliceVar(&config.ValidatorDepositAmounts, "validator-deposit-amounts", nil, "List of deposit amounts (integers) in ETH, one per validator, written to a single deposit-data-mixed.json file. Enables bootstrapping validators at different initial balances. Incompatible with --deposit-amounts.")
 	flags.StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the cluster. Selected automatically when not specified.")
 	flags.UintVar(&config.TargetGasLimit, "target-gas-limit", 60000000, "Preferred target gas limit for transactions.")
 	flags.BoolVar(&config.Compounding, "compounding", false, "Enable compounding rewards for validators by using 0x02 withdrawal credentials.")
 	flags.StringVar(&config.ExecutionEngineAddr, "execution-client-rpc-endpoint", "", "The address of the execution engine JSON-RPC API.")
 	flags.BoolVar(&config.Zipped, "zipped", false, "Create a tar archive compressed with gzip of the cluster directory after creation.")
+	flags.StringSliceVar(&config.ComposeVCs, "compose-vcs", nil, "Comma separated list of external validator client types to generate a docker-compose.yml for, running the cluster with a validator client per node assigned in round-robin. Options: lighthouse, teku, nimbus, lodestar, vouch.")
 }
 
 func bindInsecureFlags(flags *pflag.FlagSet, insecureKeys *bool) {
@@ -224,6 +229,11 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 			return err
 		}
 
+		if conf.Network != "" && conf.Network != network {
+			log.Warn(ctx, "Ignoring --network flag, deposit data is generated for the network of the cluster definition", nil,
+				z.Str("flag_network", conf.Network), z.Str("definition_network", network))
+		}
+
 		conf.Network = network
 		depositAmounts = def.DepositAmounts
 	} else { // Create new definition from cluster config
@@ -288,14 +298,29 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
-	depositDatas, err := createDepositDatas(def.WithdrawalAddresses(), network, secrets, depositAmounts, def.Compounding)
-	if err != nil {
-		return err
-	}
+	var depositDatas [][]eth2p0.DepositData
+	if len(conf.ValidatorDepositAmounts) > 0 {
+		mixed, err := createMixedDepositDatas(def.WithdrawalAddresses(), network, secrets, deposit.EthsToGweis(conf.ValidatorDepositAmounts), def.Compounding)
+		if err != nil {
+			return err
+		}
 
-	// Write deposit-data files
-	if err = deposit.WriteClusterDepositDataFiles(depositDatas, network, conf.ClusterDir, numNodes); err != nil {
-		return err
+		// Write the deposit-data-mixed.json files
+		if err = deposit.WriteClusterMixedDepositDataFiles(mixed, network, conf.ClusterDir, numNodes); err != nil {
+			return err
+		}
+
+		depositDatas = [][]eth2p0.DepositData{mixed}
+	} else {
+		depositDatas, err = createDepositDatas(def.WithdrawalAddresses(), network, secrets, depositAmounts, def.Compounding)
+		if err != nil {
+			return err
+		}
+
+		// Write deposit-data files
+		if err = deposit.WriteClusterDepositDataFiles(depositDatas, network, conf.ClusterDir, numNodes); err != nil {
+			return err
+		}
 	}
 
 	valRegs, err := createValidatorRegistrations(ctx, def.FeeRecipientAddresses(), secrets, def.ForkVersion, conf.SplitKeys, conf.TargetGasLimit)
@@ -348,6 +373,12 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		return err
 	}
 
+	if len(conf.ComposeVCs) > 0 {
+		if err = writeCompose(conf.ClusterDir, numNodes, conf.ComposeVCs, network, def.FeeRecipientAddresses()[0]); err != nil {
+			return err
+		}
+	}
+
 	if conf.Zipped {
 		if err = bundleOutput(conf.ClusterDir, numNodes); err != nil {
 			return err
@@ -358,7 +389,7 @@ func runCreateCluster(ctx context.Context, w io.Writer, conf clusterConfig) erro
 		writeWarning(w)
 	}
 
-	if err := writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, keysToDisk, conf.Zipped); err != nil {
+	if err := writeOutput(w, conf.SplitKeys, conf.ClusterDir, numNodes, keysToDisk, conf.Zipped, len(conf.ComposeVCs) > 0); err != nil {
 		return err
 	}
 
@@ -397,6 +428,15 @@ func validateCreateConfig(ctx context.Context, conf clusterConfig) error {
 		}
 	}
 
+	if len(conf.ValidatorDepositAmounts) > 0 {
+		if len(conf.DepositAmounts) > 0 {
+			return errors.New("--validator-deposit-amounts and --deposit-amounts are mutually exclusive")
+		} else if conf.NumDVs > 0 && len(conf.ValidatorDepositAmounts) != conf.NumDVs {
+			return errors.New("number of --validator-deposit-amounts doesn't match the number of validators",
+				z.Int("amounts", len(conf.ValidatorDepositAmounts)), z.Int("validators", conf.NumDVs))
+		}
+	}
+
 	for _, addr := range conf.KeymanagerAddrs {
 		keymanagerURL, err := url.ParseRequestURI(addr)
 		if err != nil {
@@ -427,6 +467,10 @@ func validateCreateConfig(ctx context.Context, conf clusterConfig) error {
 		return errors.New("unsupported consensus protocol", z.Str("protocol", conf.ConsensusProtocol))
 	}
 
+	if err := validateComposeVCs(conf); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -694,6 +738,28 @@ func writeLock(lock cluster.Lock, clusterDir string, numNodes int) error {
 }
 
 // getValidators returns distributed validators from the provided dv public keys and keyshares.
+// createMixedDepositDatas creates a deposit data per validator with the deposit amount at the same index.
+func createMixedDepositDatas(withdrawalAddresses []string, network string, secrets []tbls.PrivateKey, depositAmounts []eth2p0.Gwei, compounding bool) ([]eth2p0.DepositData, error) {
+	if len(secrets) != len(withdrawalAddresses) {
+		return nil, errors.New("insufficient withdrawal addresses")
+	} else if len(secrets) != len(depositAmounts) {
+		return nil, errors.New("number of validator deposit amounts doesn't match the number of validators",
+			z.Int("amounts", len(depositAmounts)), z.Int("validators", len(secrets)))
+	}
+
+	var resp []eth2p0.DepositData
+	for i, secret := range secrets {
+		dd, err := signDepositDatas([]tbls.PrivateKey{secret}, withdrawalAddresses[i:i+1], network, depositAmounts[i:i+1], compounding)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, dd[0][0])
+	}
+
+	return resp, nil
+}
+
 // It creates new peers from the provided config and saves validator keys to disk for each peer.
 func getValidators(
 	dvsPubkeys []tbls.PublicKey,
@@ -939,7 +1005,7 @@ func newPeer(clusterDir string, peerIdx int) (enr.Record, *k1.PrivateKey, error)
 }
 
 // writeOutput writes the cluster generation output.
-func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, keysToDisk, zipped bool) error {
+func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int, keysToDisk, zipped, compose bool) error {
 	absClusterDir, err := filepath.Abs(clusterDir)
 	if err != nil {
 		return errors.Wrap(err, "absolute path retrieval")
@@ -962,6 +1028,11 @@ func writeOutput(out io.Writer, splitKeys bool, clusterDir string, numNodes int,
 		_, _ = sb.WriteString("│  │  ├─ keystore-*.txt\t\tKeystore password files for keystore-*.json\n")
 	}
 
+	if compose {
+		_, _ = sb.WriteString("├─ docker-compose.yml\t\tDocker compose running the cluster with external validator clients\n")
+		_, _ = sb.WriteString("├─ vc/\t\t\t\tValidator client entrypoint scripts\n")
+	}
+
 	if zipped {
 		_, _ = sb.WriteString(fmt.Sprintf("\nFiles compressed and archived to:\n%s/cluster.tar.gz\n", absClusterDir))
 	}
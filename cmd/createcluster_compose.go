@@ -0,0 +1,345 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/template"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// composeVC is an external validator client type of the docker compose output.
+type composeVC string
+
+const (
+	composeLighthouse composeVC = "lighthouse"
+	composeTeku       composeVC = "teku"
+	composeNimbus     composeVC = "nimbus"
+	composeLodestar   composeVC = "lodestar"
+	composeVouch      composeVC = "vouch"
+)
+
+// composeVCs are the supported external validator client types of the docker compose output.
+var composeVCs = []composeVC{composeLighthouse, composeTeku, composeNimbus, composeLodestar, composeVouch}
+
+// composeScripts are the entrypoint scripts of the validator clients requiring keystores to be imported
+// or converted before starting, by script file name.
+var composeScripts = map[composeVC]map[string]string{
+	composeLighthouse: {"lighthouse.sh": lighthouseScript},
+	composeNimbus:     {"nimbus.sh": nimbusScript},
+	composeLodestar:   {"lodestar.sh": lodestarScript},
+	composeVouch:      {"vouch-keys.sh": vouchKeysScript, "vouch.yml": vouchConfig},
+}
+
+// validateComposeVCs returns an error if the docker compose output validator client types are not supported
+// or the cluster config is incompatible with docker compose output.
+func validateComposeVCs(conf clusterConfig) error {
+	if len(conf.ComposeVCs) == 0 {
+		return nil
+	}
+
+	for _, vc := range conf.ComposeVCs {
+		if !slices.Contains(composeVCs, composeVC(vc)) {
+			return errors.New("unsupported compose validator client", z.Str("vc", vc), z.Any("supported", composeVCs))
+		}
+	}
+
+	if len(conf.KeymanagerAddrs) > 0 {
+		return errors.New("--compose-vcs requires validator keys on disk, it cannot be used with --keymanager-addresses")
+	}
+
+	if conf.Zipped {
+		return errors.New("--compose-vcs cannot be used with --zipped")
+	}
+
+	if conf.Network == "" {
+		return errors.New("--compose-vcs is not supported for custom test networks")
+	}
+
+	return nil
+}
+
+// composeNode is a charon node and its validator client of the docker compose output.
+type composeNode struct {
+	Index int
+	VC    composeVC
+}
+
+// writeCompose writes a docker-compose.yml to the cluster directory running the cluster's charon nodes, a local relay
+// and the provided external validator clients, assigned to the nodes in round-robin. Each validator client is connected
+// to its node's validator API and loads the node's validator key shares. It also writes the validator clients'
+// entrypoint scripts to the "vc" folder.
+func writeCompose(clusterDir string, numNodes int, vcs []string, network string, feeRecipient string) error {
+	var (
+		nodes   []composeNode
+		volumes []string
+	)
+	for i := range numNodes {
+		vc := composeVC(vcs[i%len(vcs)])
+		nodes = append(nodes, composeNode{Index: i, VC: vc})
+
+		if vc == composeVouch {
+			volumes = append(volumes, fmt.Sprintf("vc%d-vouch", i))
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err := template.Must(template.New("compose").Parse(composeTemplate)).Execute(&buf, struct {
+		Nodes        []composeNode
+		Volumes      []string
+		Network      string
+		FeeRecipient string
+	}{
+		Nodes:        nodes,
+		Volumes:      volumes,
+		Network:      network,
+		FeeRecipient: feeRecipient,
+	})
+	if err != nil {
+		return errors.Wrap(err, "execute compose template")
+	}
+
+	if err := os.WriteFile(filepath.Join(clusterDir, "docker-compose.yml"), buf.Bytes(), 0o644); err != nil { //nolint:gosec // Readable by docker.
+		return errors.Wrap(err, "write docker-compose.yml")
+	}
+
+	vcDir := filepath.Join(clusterDir, "vc")
+	if err := os.MkdirAll(vcDir, 0o755); err != nil {
+		return errors.Wrap(err, "mkdir vc")
+	}
+
+	for _, vc := range composeVCs {
+		if !slices.Contains(vcs, string(vc)) {
+			continue
+		}
+
+		for name, content := range composeScripts[vc] {
+			if err := os.WriteFile(filepath.Join(vcDir, name), []byte(content), 0o755); err != nil { //nolint:gosec // Executable scripts.
+				return errors.Wrap(err, "write vc script", z.Str("file", name))
+			}
+		}
+	}
+
+	return nil
+}
+
+const composeTemplate = `# Generated by charon create cluster. Runs the cluster's charon nodes, a local relay and external validator clients.
+# Provide the {{.Network}} beacon node endpoints to run: CHARON_BEACON_NODE_ENDPOINTS=http://... docker compose up
+
+x-node-base: &node-base
+  image: obolnetwork/charon:${CHARON_VERSION:-latest}
+  command: run
+  restart: unless-stopped
+  depends_on: [relay]
+
+services:
+  relay:
+    image: obolnetwork/charon:${CHARON_VERSION:-latest}
+    command: relay
+    restart: unless-stopped
+    environment:
+      CHARON_HTTP_ADDRESS: 0.0.0.0:3640
+      CHARON_DATA_DIR: /opt/charon/relay
+      CHARON_P2P_RELAYS: ""
+      CHARON_P2P_EXTERNAL_HOSTNAME: relay
+      CHARON_P2P_TCP_ADDRESS: 0.0.0.0:3610
+      CHARON_P2P_ADVERTISE_PRIVATE_ADDRESSES: "true"
+{{range .Nodes}}
+  node{{.Index}}:
+    <<: *node-base
+    environment:
+      CHARON_BEACON_NODE_ENDPOINTS: ${CHARON_BEACON_NODE_ENDPOINTS:?set to the beacon node endpoints}
+      CHARON_LOCK_FILE: /opt/charon/node/cluster-lock.json
+      CHARON_PRIVATE_KEY_FILE: /opt/charon/node/charon-enr-private-key
+      CHARON_VALIDATOR_API_ADDRESS: 0.0.0.0:3600
+      CHARON_MONITORING_ADDRESS: 0.0.0.0:3620
+      CHARON_P2P_RELAYS: http://relay:3640/enr
+      CHARON_P2P_EXTERNAL_HOSTNAME: node{{.Index}}
+      CHARON_P2P_TCP_ADDRESS: 0.0.0.0:3610
+    volumes:
+      - ./node{{.Index}}:/opt/charon/node
+{{if eq .VC "teku"}}
+  vc{{.Index}}-teku:
+    image: consensys/teku:25.4.1
+    restart: unless-stopped
+    depends_on: [node{{.Index}}]
+    command: |
+      validator-client
+      --network={{$.Network}}
+      --beacon-node-api-endpoint=http://node{{.Index}}:3600
+      --validator-keys=/opt/charon/validator_keys:/opt/charon/validator_keys
+      --validators-proposer-default-fee-recipient={{$.FeeRecipient}}
+    volumes:
+      - ./node{{.Index}}/validator_keys:/opt/charon/validator_keys:ro
+{{else if eq .VC "vouch"}}
+  vc{{.Index}}-vouch-keys:
+    image: wealdtech/ethdo:1.35.2
+    entrypoint: ["/bin/sh", "/opt/charon/vc/vouch-keys.sh"]
+    volumes:
+      - ./node{{.Index}}/validator_keys:/opt/charon/validator_keys:ro
+      - ./vc:/opt/charon/vc:ro
+      - vc{{.Index}}-vouch:/opt/vouch
+
+  vc{{.Index}}-vouch:
+    image: attestant/vouch:1.10.3
+    restart: unless-stopped
+    depends_on:
+      node{{.Index}}:
+        condition: service_started
+      vc{{.Index}}-vouch-keys:
+        condition: service_completed_successfully
+    command: --base-dir=/opt/vouch --beacon-node-address=http://node{{.Index}}:3600
+    volumes:
+      - ./vc/vouch.yml:/opt/vouch/vouch.yml:ro
+      - vc{{.Index}}-vouch:/opt/vouch
+{{else}}
+  vc{{.Index}}-{{.VC}}:
+    {{- if eq .VC "lighthouse"}}
+    image: sigp/lighthouse:v7.1.0
+    {{- else if eq .VC "nimbus"}}
+    image: statusim/nimbus-validator-client:multiarch-latest
+    {{- else if eq .VC "lodestar"}}
+    image: chainsafe/lodestar:v1.32.0
+    {{- end}}
+    restart: unless-stopped
+    depends_on: [node{{.Index}}]
+    entrypoint: ["/bin/sh", "/opt/charon/vc/{{.VC}}.sh"]
+    environment:
+      NODE: node{{.Index}}
+      NETWORK: {{$.Network}}
+      FEE_RECIPIENT: "{{$.FeeRecipient}}"
+    volumes:
+      - ./node{{.Index}}/validator_keys:/opt/charon/validator_keys:ro
+      - ./vc:/opt/charon/vc:ro
+{{end}}{{end}}
+{{- if .Volumes}}
+volumes:
+{{- range .Volumes}}
+  {{.}}: {}
+{{- end}}
+{{end -}}
+`
+
+const lighthouseScript = `#!/bin/sh
+
+# Imports the node's keystores and starts the lighthouse validator client.
+for f in /opt/charon/validator_keys/keystore-*.json; do
+  echo "Importing key ${f}"
+  lighthouse account validator import \
+    --network "${NETWORK}" \
+    --datadir /opt/data \
+    --reuse-password \
+    --keystore "${f}" \
+    --password-file "${f%.json}.txt"
+done
+
+exec lighthouse validator \
+  --network "${NETWORK}" \
+  --datadir /opt/data \
+  --beacon-nodes "http://${NODE}:3600" \
+  --suggested-fee-recipient "${FEE_RECIPIENT}" \
+  --distributed
+`
+
+const nimbusScript = `#!/bin/sh
+
+# Converts the node's keystores into the nimbus layout of validators/<pubkey>/keystore.json
+# with passwords in secrets/<pubkey>, and starts the nimbus validator client.
+DATA_DIR=/home/user/data
+
+rm -rf "${DATA_DIR}/validators" "${DATA_DIR}/secrets"
+mkdir -p "${DATA_DIR}/validators" "${DATA_DIR}/secrets"
+chmod 700 "${DATA_DIR}/validators" "${DATA_DIR}/secrets"
+
+for f in /opt/charon/validator_keys/keystore-*.json; do
+  echo "Importing key ${f}"
+  pubkey="0x$(sed -n 's/.*"pubkey": *"\(0x\)\{0,1\}\([0-9a-fA-F]*\)".*/\2/p' "${f}")"
+  mkdir -p "${DATA_DIR}/validators/${pubkey}"
+  cp "${f}" "${DATA_DIR}/validators/${pubkey}/keystore.json"
+  cp "${f%.json}.txt" "${DATA_DIR}/secrets/${pubkey}"
+  chmod 600 "${DATA_DIR}/validators/${pubkey}/keystore.json" "${DATA_DIR}/secrets/${pubkey}"
+done
+
+exec /home/user/nimbus_validator_client \
+  --data-dir="${DATA_DIR}" \
+  --validators-dir="${DATA_DIR}/validators" \
+  --secrets-dir="${DATA_DIR}/secrets" \
+  --beacon-node="http://${NODE}:3600" \
+  --suggested-fee-recipient="${FEE_RECIPIENT}" \
+  --doppelganger-detection=off \
+  --distributed
+`
+
+const lodestarScript = `#!/bin/sh
+
+# Imports the node's keystores and starts the lodestar validator client.
+for f in /opt/charon/validator_keys/keystore-*.json; do
+  echo "Importing key ${f}"
+  node /usr/app/packages/cli/bin/lodestar validator import \
+    --network="${NETWORK}" \
+    --dataDir=/opt/data \
+    --importKeystores="${f}" \
+    --importKeystoresPassword="${f%.json}.txt"
+done
+
+exec node /usr/app/packages/cli/bin/lodestar validator \
+  --network="${NETWORK}" \
+  --dataDir=/opt/data \
+  --beaconNodes="http://${NODE}:3600" \
+  --suggestedFeeRecipient="${FEE_RECIPIENT}" \
+  --distributed
+`
+
+const vouchKeysScript = `#!/bin/sh
+
+# Imports the node's keystores into an ethdo wallet loaded by vouch.
+KEYS_DIR=/opt/vouch/keys
+WALLET=validators
+
+rm -rf "${KEYS_DIR}"
+/app/ethdo --base-dir="${KEYS_DIR}" wallet create --wallet="${WALLET}"
+
+account=0
+for f in /opt/charon/validator_keys/keystore-*.json; do
+  echo "Importing key ${f}"
+  /app/ethdo --base-dir="${KEYS_DIR}" account import \
+    --account="${WALLET}/account-${account}" \
+    --keystore="${f}" \
+    --keystore-passphrase="$(cat "${f%.json}.txt")" \
+    --passphrase=secret \
+    --allow-weak-passphrases
+  account=$((account + 1))
+done
+`
+
+const vouchConfig = `# Vouch configuration loading the ethdo wallet created by vouch-keys.sh.
+# Refer: https://github.com/attestantio/vouch/blob/master/docs/configuration.md.
+accountmanager:
+  wallet:
+    locations: /opt/vouch/keys
+    accounts: validators
+    passphrases: secret
+
+# Allow sufficient time (10s) to block while fetching duties for DVT.
+strategies:
+  beaconblockproposal:
+    timeout: 10s
+  blindedbeaconblockproposal:
+    timeout: 10s
+  attestationdata:
+    timeout: 10s
+  aggregateattestation:
+    timeout: 10s
+  synccommitteecontribution:
+    timeout: 10s
+
+blockrelay:
+  fallback-fee-recipient: '0x0000000000000000000000000000000000000001'
+`
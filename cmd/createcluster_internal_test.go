@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
@@ -758,6 +759,77 @@ func TestTargetGasLimit(t *testing.T) {
 	}
 }
 
+func TestValidatorDepositAmounts(t *testing.T) {
+	newConf := func() clusterConfig {
+		return clusterConfig{
+			Name:              t.Name(),
+			ClusterDir:        t.TempDir(),
+			NumNodes:          4,
+			Threshold:         3,
+			NumDVs:            3,
+			Network:           defaultNetwork,
+			WithdrawalAddrs:   []string{zeroAddress},
+			FeeRecipientAddrs: []string{zeroAddress},
+			InsecureKeys:      true,
+			TargetGasLimit:    36000000,
+			Compounding:       true,
+		}
+	}
+
+	t.Run("mixed amounts", func(t *testing.T) {
+		conf := newConf()
+		conf.ValidatorDepositAmounts = []int{32, 64, 2048}
+
+		var buf bytes.Buffer
+		require.NoError(t, runCreateCluster(context.Background(), &buf, conf))
+
+		for i := range conf.NumNodes {
+			require.FileExists(t, deposit.GetMixedDepositFilePath(nodeDir(conf.ClusterDir, i)))
+		}
+
+		b, err := os.ReadFile(path.Join(nodeDir(conf.ClusterDir, 0), "cluster-lock.json"))
+		require.NoError(t, err)
+
+		var lock cluster.Lock
+		require.NoError(t, json.Unmarshal(b, &lock))
+		require.NoError(t, lock.VerifyHashes())
+		require.NoError(t, lock.VerifySignatures(nil))
+
+		var amounts []eth2p0.Gwei
+		for _, val := range lock.Validators {
+			require.Len(t, val.PartialDepositData, 1)
+			amounts = append(amounts, eth2p0.Gwei(val.PartialDepositData[0].Amount))
+		}
+
+		require.ElementsMatch(t, deposit.EthsToGweis(conf.ValidatorDepositAmounts), amounts)
+	})
+
+	t.Run("mismatching number of amounts", func(t *testing.T) {
+		conf := newConf()
+		conf.ValidatorDepositAmounts = []int{32, 64}
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "number of --validator-deposit-amounts doesn't match the number of validators")
+	})
+
+	t.Run("with partial deposit amounts", func(t *testing.T) {
+		conf := newConf()
+		conf.ValidatorDepositAmounts = []int{32, 64, 2048}
+		conf.DepositAmounts = []int{16, 16}
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "mutually exclusive")
+	})
+
+	t.Run("amount exceeding maximum", func(t *testing.T) {
+		conf := newConf()
+		conf.ValidatorDepositAmounts = []int{32, 64, 2049}
+
+		err := runCreateCluster(context.Background(), io.Discard, conf)
+		require.ErrorContains(t, err, "maximum amount exceeded")
+	})
+}
+
 // TestKeymanager tests keymanager support by letting create cluster command split a single secret and then receiving those keyshares using test
 // keymanager servers. These shares are then combined to create the combined share which is then compared to the original secret that was split.
 func TestKeymanager(t *testing.T) {
@@ -1169,3 +1241,47 @@ func isAnyVersion(version string, versions ...string) bool {
 
 	return false
 }
+
+func TestComposeVCs(t *testing.T) {
+	ctx := t.Context()
+	conf := clusterConfig{
+		Name:              "test",
+		NumNodes:          6,
+		NumDVs:            2,
+		Threshold:         4,
+		TargetGasLimit:    30000000,
+		Network:           eth2util.Hoodi.Name,
+		WithdrawalAddrs:   []string{zeroAddress},
+		FeeRecipientAddrs: []string{zeroAddress},
+		InsecureKeys:      true,
+		ComposeVCs:        []string{"lighthouse", "teku", "nimbus", "lodestar", "vouch"},
+	}
+
+	conf.ClusterDir = t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, runCreateCluster(ctx, &buf, conf))
+	require.Contains(t, buf.String(), "docker-compose.yml")
+
+	b, err := os.ReadFile(filepath.Join(conf.ClusterDir, "docker-compose.yml"))
+	require.NoError(t, err)
+	testutil.RequireGoldenBytes(t, b)
+
+	for _, script := range []string{"lighthouse.sh", "nimbus.sh", "lodestar.sh", "vouch-keys.sh", "vouch.yml"} {
+		require.FileExists(t, filepath.Join(conf.ClusterDir, "vc", script))
+	}
+
+	t.Run("unsupported vc", func(t *testing.T) {
+		conf.ClusterDir = t.TempDir()
+		conf.ComposeVCs = []string{"prysm"}
+		require.ErrorContains(t, runCreateCluster(ctx, &buf, conf), "unsupported compose validator client")
+	})
+
+	t.Run("keymanager", func(t *testing.T) {
+		conf.ClusterDir = t.TempDir()
+		conf.ComposeVCs = []string{"teku"}
+		conf.KeymanagerAddrs = []string{"https://keymanager"}
+		conf.KeymanagerAuthTokens = []string{"token"}
+		require.ErrorContains(t, runCreateCluster(ctx, &buf, conf), "--compose-vcs requires validator keys on disk")
+	})
+}
@@ -0,0 +1,257 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+type createDepositDataConfig struct {
+	LockFilePath      string
+	ValidatorKeysDirs []string
+	DepositAmounts    []int // Amounts specified in ETH (integers).
+	OutputDir         string
+}
+
+func newCreateDepositDataCmd(runFunc func(io.Writer, createDepositDataConfig) error) *cobra.Command {
+	var config createDepositDataConfig
+
+	cmd := &cobra.Command{
+		Use:   "deposit-data",
+		Short: "Create deposit data for the validators of an existing cluster",
+		Long: `Creates fresh deposit data files for the validators of an existing cluster, e.g. when the original files are lost or new partial deposits are required. ` +
+			`Each validator's deposit message is partially signed with the key shares found in the provided validator keys directories, ` +
+			`which must contain the key shares of at least threshold operators. The partial signatures are aggregated, so the validator private keys are never reconstructed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().StringSliceVar(&config.ValidatorKeysDirs, "validator-keys-dirs", []string{".charon/validator_keys"}, "Comma separated list of directories containing the validator key shares of at least threshold operators.")
+	cmd.Flags().IntSliceVar(&config.DepositAmounts, "amounts", nil, "List of deposit amounts (integers) in ETH. Defaults to the cluster lock's deposit amounts.")
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".", "The directory to write the deposit data files to.")
+
+	return cmd
+}
+
+func runCreateDepositData(out io.Writer, config createDepositDataConfig) error {
+	lockBytes, err := os.ReadFile(config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "read lock file", z.Str("path", config.LockFilePath))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(lockBytes, &lock); err != nil {
+		return errors.Wrap(err, "unmarshal lock json", z.Str("path", config.LockFilePath))
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return errors.Wrap(err, "cluster lock hash verification failed")
+	}
+
+	amounts := deposit.EthsToGweis(config.DepositAmounts)
+	if len(amounts) == 0 {
+		amounts = lock.DepositAmounts
+	}
+
+	if len(amounts) == 0 {
+		amounts = []eth2p0.Gwei{deposit.DefaultDepositAmount}
+	}
+
+	amounts = deposit.DedupAmounts(amounts)
+
+	var shareSets [][]tbls.PrivateKey
+	for _, dir := range config.ValidatorKeysDirs {
+		keyFiles, err := keystore.LoadFilesUnordered(dir)
+		if err != nil {
+			return errors.Wrap(err, "load validator key shares", z.Str("dir", dir))
+		}
+
+		shares, err := keyFiles.SequencedKeys()
+		if err != nil {
+			return errors.Wrap(err, "order validator key shares", z.Str("dir", dir))
+		}
+
+		shareSets = append(shareSets, shares)
+	}
+
+	depositDatas, err := signDepositDatasFromShares(lock, shareSets, amounts)
+	if err != nil {
+		return err
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return err
+	}
+
+	for _, dd := range depositDatas {
+		if err := deposit.WriteDepositDataFile(dd, network, config.OutputDir); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(out, "Created deposit data file: %s\n", deposit.GetDepositFilePath(config.OutputDir, dd[0].Amount)); err != nil {
+			return errors.Wrap(err, "output write")
+		}
+	}
+
+	return nil
+}
+
+// signDepositDatasFromShares returns a list of DepositData for each deposit amount by threshold aggregating
+// the deposit message signatures of the provided key shares. Each share set contains the key shares
+// of one operator ordered by validator.
+func signDepositDatasFromShares(lock cluster.Lock, shareSets [][]tbls.PrivateKey, amounts []eth2p0.Gwei) ([][]eth2p0.DepositData, error) {
+	if len(lock.ValidatorAddresses) != len(lock.Validators) {
+		return nil, errors.New("validator addresses and validators count mismatch")
+	}
+
+	network, err := eth2util.ForkVersionToNetwork(lock.ForkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// shareIdxs contains the share index of each share set.
+	var shareIdxs []int
+	for i, shares := range shareSets {
+		if len(shares) != len(lock.Validators) {
+			return nil, errors.New("key shares and validators count mismatch",
+				z.Int("shares", len(shares)), z.Int("validators", len(lock.Validators)))
+		}
+
+		shareIdx, err := shareIdxFromLock(lock, shares[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, prevIdx := range shareIdxs {
+			if prevIdx == shareIdx {
+				return nil, errors.New("duplicate operator key shares", z.Int("share_idx", shareIdx), z.Int("dir_index", i))
+			}
+		}
+
+		shareIdxs = append(shareIdxs, shareIdx)
+	}
+
+	if len(shareIdxs) < lock.Threshold {
+		return nil, errors.New("insufficient operator key shares",
+			z.Int("operators", len(shareIdxs)), z.Int("threshold", lock.Threshold))
+	}
+
+	var resp [][]eth2p0.DepositData
+	for _, amount := range amounts {
+		var datas []eth2p0.DepositData
+
+		for i, val := range lock.Validators {
+			withdrawalAddr, err := eth2util.ChecksumAddress(lock.ValidatorAddresses[i].WithdrawalAddress)
+			if err != nil {
+				return nil, err
+			}
+
+			pubkey, err := val.PublicKey()
+			if err != nil {
+				return nil, err
+			}
+
+			msg, err := deposit.NewMessage(eth2p0.BLSPubKey(pubkey), withdrawalAddr, amount, lock.Compounding)
+			if err != nil {
+				return nil, err
+			}
+
+			sigRoot, err := deposit.GetMessageSigningRoot(msg, network)
+			if err != nil {
+				return nil, err
+			}
+
+			partials := make(map[int]tbls.Signature)
+			for j, shares := range shareSets {
+				if err := verifyShareIdx(val, shares[i], shareIdxs[j]); err != nil {
+					return nil, errors.Wrap(err, "verify key share", z.Int("validator_index", i))
+				}
+
+				sig, err := tbls.Sign(shares[i], sigRoot[:])
+				if err != nil {
+					return nil, err
+				}
+
+				partials[shareIdxs[j]] = sig
+			}
+
+			sig, err := tbls.ThresholdAggregate(partials)
+			if err != nil {
+				return nil, errors.Wrap(err, "aggregate deposit signatures")
+			}
+
+			if err := tbls.Verify(pubkey, sigRoot[:], sig); err != nil {
+				return nil, errors.Wrap(err, "verify aggregate deposit signature", z.Int("validator_index", i))
+			}
+
+			datas = append(datas, eth2p0.DepositData{
+				PublicKey:             msg.PublicKey,
+				WithdrawalCredentials: msg.WithdrawalCredentials,
+				Amount:                msg.Amount,
+				Signature:             tblsconv.SigToETH2(sig),
+			})
+		}
+
+		resp = append(resp, datas)
+	}
+
+	return resp, nil
+}
+
+// shareIdxFromLock returns the 1-indexed share index of the first validator's key share.
+func shareIdxFromLock(lock cluster.Lock, share tbls.PrivateKey) (int, error) {
+	if len(lock.Validators) == 0 {
+		return 0, errors.New("no validators in cluster lock")
+	}
+
+	pubshare, err := tbls.SecretToPublicKey(share)
+	if err != nil {
+		return 0, errors.Wrap(err, "private share to public share")
+	}
+
+	for i := range lock.Validators[0].PubShares {
+		if pubshare == tbls.PublicKey(lock.Validators[0].PubShares[i]) {
+			return i + 1, nil
+		}
+	}
+
+	return 0, errors.New("key share not found in cluster lock")
+}
+
+// verifyShareIdx returns an error if the key share doesn't match the validator's public share at the share index.
+func verifyShareIdx(val cluster.DistValidator, share tbls.PrivateKey, shareIdx int) error {
+	pubshare, err := tbls.SecretToPublicKey(share)
+	if err != nil {
+		return errors.Wrap(err, "private share to public share")
+	}
+
+	expected, err := val.PublicShare(shareIdx - 1)
+	if err != nil {
+		return err
+	}
+
+	if pubshare != expected {
+		return errors.New("key share doesn't match cluster lock public share", z.Int("share_idx", shareIdx))
+	}
+
+	return nil
+}
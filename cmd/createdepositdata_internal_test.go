@@ -0,0 +1,89 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/deposit"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+func TestCreateDepositData(t *testing.T) {
+	const (
+		numVals  = 2
+		numNodes = 4
+		thresh   = 3
+	)
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, valShares := cluster.NewForT(t, numVals, thresh, numNodes, seed, random)
+
+	lockPath := filepath.Join(t.TempDir(), "cluster-lock.json")
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockPath, lockJSON, 0o644))
+
+	// Store the key shares of the last threshold operators.
+	var keysDirs []string
+	for node := numNodes - thresh; node < numNodes; node++ {
+		var shares []tbls.PrivateKey
+		for val := range numVals {
+			shares = append(shares, valShares[val][node])
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, keystore.StoreKeysInsecure(shares, dir, keystore.ConfirmInsecureKeys))
+
+		keysDirs = append(keysDirs, dir)
+	}
+
+	t.Run("threshold operators", func(t *testing.T) {
+		outDir := t.TempDir()
+
+		var output bytes.Buffer
+		require.NoError(t, runCreateDepositData(&output, createDepositDataConfig{
+			LockFilePath:      lockPath,
+			ValidatorKeysDirs: keysDirs,
+			DepositAmounts:    []int{1, 31},
+			OutputDir:         outDir,
+		}))
+
+		for _, amount := range deposit.EthsToGweis([]int{1, 31}) {
+			b, err := os.ReadFile(deposit.GetDepositFilePath(outDir, amount))
+			require.NoError(t, err)
+
+			var datas []map[string]any
+			require.NoError(t, json.Unmarshal(b, &datas))
+			require.Len(t, datas, numVals)
+		}
+	})
+
+	t.Run("insufficient operators", func(t *testing.T) {
+		err := runCreateDepositData(&bytes.Buffer{}, createDepositDataConfig{
+			LockFilePath:      lockPath,
+			ValidatorKeysDirs: keysDirs[:thresh-1],
+			OutputDir:         t.TempDir(),
+		})
+		require.ErrorContains(t, err, "insufficient operator key shares")
+	})
+
+	t.Run("duplicate operator", func(t *testing.T) {
+		err := runCreateDepositData(&bytes.Buffer{}, createDepositDataConfig{
+			LockFilePath:      lockPath,
+			ValidatorKeysDirs: []string{keysDirs[0], keysDirs[0], keysDirs[1]},
+			OutputDir:         t.TempDir(),
+		})
+		require.ErrorContains(t, err, "duplicate operator key shares")
+	})
+}
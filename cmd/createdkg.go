@@ -16,6 +16,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/app/expbackoff"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/version"
@@ -27,6 +28,9 @@ import (
 	"github.com/obolnetwork/charon/eth2util/enr"
 )
 
+// safeOwnersTimeout is the maximum duration to wait for reading the owners of a Safe.
+const safeOwnersTimeout = 30 * time.Second
+
 type createDKGConfig struct {
 	OutputDir           string
 	Name                string
@@ -45,6 +49,7 @@ type createDKGConfig struct {
 	Publish             bool
 	PublishAddress      string
 	OperatorsAddresses  []string
+	FromSafe            string
 }
 
 func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobra.Command {
@@ -76,7 +81,15 @@ func newCreateDKGCmd(runFunc func(context.Context, createDKGConfig) error) *cobr
 			}
 		}
 
-		if config.Publish {
+		if config.FromSafe != "" {
+			if !config.Publish {
+				return errors.New("--from-safe requires --publish since operator addresses are populated")
+			} else if len(config.OperatorENRs) != 0 || len(config.OperatorsAddresses) != 0 {
+				return errors.New("cannot provide --from-safe with --operator-enrs or --operator-addresses")
+			}
+
+			mustMarkFlagRequired(cmd, "execution-client-rpc-endpoint")
+		} else if config.Publish {
 			mustMarkFlagRequired(cmd, "operator-addresses")
 		} else {
 			mustMarkFlagRequired(cmd, "operator-enrs")
@@ -110,6 +123,7 @@ func bindCreateDKGFlags(cmd *cobra.Command, config *createDKGConfig) {
 	cmd.Flags().BoolVar(&config.Publish, "publish", false, "Creates an invitation to the DKG ceremony on the DV Launchpad. Terms and conditions apply.")
 	cmd.Flags().StringVar(&config.PublishAddress, "publish-address", "https://api.obol.tech/v1", "The URL to publish the cluster to.")
 	cmd.Flags().StringSliceVar(&config.OperatorsAddresses, "operator-addresses", nil, "Comma-separated list of each operator's Ethereum address.")
+	cmd.Flags().StringVar(&config.FromSafe, "from-safe", "", "Address of a Safe smart contract account whose owners are used as the operator addresses, instead of --operator-addresses. Requires --publish and --execution-client-rpc-endpoint.")
 }
 
 func mustMarkFlagRequired(cmd *cobra.Command, flag string) {
@@ -124,6 +138,18 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		conf.Network = eth2util.Goerli.Name
 	}
 
+	eth1Cl := eth1wrap.NewDefaultEthClientRunner(conf.ExecutionEngineAddr)
+	go eth1Cl.Run(ctx)
+
+	if conf.FromSafe != "" {
+		conf.OperatorsAddresses, err = safeOwners(ctx, eth1Cl, conf.FromSafe)
+		if err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Populated operator addresses from safe owners", z.Str("safe", conf.FromSafe), z.Any("operators", conf.OperatorsAddresses))
+	}
+
 	var operatorsLen int
 	if len(conf.OperatorENRs) > 0 {
 		operatorsLen = len(conf.OperatorENRs)
@@ -235,9 +261,6 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 		}
 	}
 
-	eth1Cl := eth1wrap.NewDefaultEthClientRunner(conf.ExecutionEngineAddr)
-	go eth1Cl.Run(ctx)
-
 	if !conf.Publish {
 		if err := def.VerifySignatures(eth1Cl); err != nil {
 			return err
@@ -264,6 +287,30 @@ func runCreateDKG(ctx context.Context, conf createDKGConfig) (err error) {
 	return nil
 }
 
+// safeOwners returns the owner addresses of the Safe smart contract account, waiting for the execution client to connect.
+func safeOwners(ctx context.Context, eth1Cl eth1wrap.EthClientRunner, safeAddr string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, safeOwnersTimeout)
+	defer cancel()
+
+	backoff := expbackoff.New(ctx, expbackoff.WithFastConfig())
+
+	for {
+		owners, err := eth1Cl.SafeOwners(ctx, safeAddr)
+		if errors.Is(err, eth1wrap.ErrEthClientNotConnected) && ctx.Err() == nil {
+			backoff()
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read safe owners", z.Str("safe", safeAddr))
+		}
+
+		if len(owners) == 0 {
+			return nil, errors.New("safe has no owners", z.Str("safe", safeAddr))
+		}
+
+		return owners, nil
+	}
+}
+
 // validateWithdrawalAddrs returns an error if any of the provided withdrawal addresses is invalid.
 func validateWithdrawalAddrs(addrs []string, network string) error {
 	for _, addr := range addrs {
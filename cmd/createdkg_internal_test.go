@@ -9,8 +9,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/eth1wrap"
+	"github.com/obolnetwork/charon/app/eth1wrap/mocks"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/testutil"
 )
@@ -307,3 +310,57 @@ func TestDKGCLI(t *testing.T) {
 		})
 	}
 }
+
+func TestFromSafeFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "without publish",
+			args: []string{"dkg", "--from-safe=" + validEthAddr},
+			err:  "--from-safe requires --publish since operator addresses are populated",
+		},
+		{
+			name: "with operator addresses",
+			args: []string{"dkg", "--from-safe=" + validEthAddr, "--publish", "--operator-addresses=" + validEthAddr},
+			err:  "cannot provide --from-safe with --operator-enrs or --operator-addresses",
+		},
+		{
+			name: "without execution client",
+			args: []string{"dkg", "--from-safe=" + validEthAddr, "--publish"},
+			err:  "required flag(s) \"execution-client-rpc-endpoint\" not set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := newCreateCmd(newCreateDKGCmd(runCreateDKG))
+			cmd.SetArgs(test.args)
+			require.EqualError(t, cmd.Execute(), test.err)
+		})
+	}
+}
+
+func TestSafeOwners(t *testing.T) {
+	owners := []string{validEthAddr, "0x3D4e7B2a5d1A4b2C6a8E9f0a1B2c3D4e5F6a7B8c"}
+
+	t.Run("waits for connection", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("SafeOwners", mock.Anything, validEthAddr).Return(nil, eth1wrap.ErrEthClientNotConnected).Once()
+		eth1Cl.On("SafeOwners", mock.Anything, validEthAddr).Return(owners, nil).Once()
+
+		resp, err := safeOwners(context.Background(), eth1Cl, validEthAddr)
+		require.NoError(t, err)
+		require.Equal(t, owners, resp)
+	})
+
+	t.Run("no owners", func(t *testing.T) {
+		eth1Cl := mocks.NewEthClientRunner(t)
+		eth1Cl.On("SafeOwners", mock.Anything, validEthAddr).Return([]string{}, nil).Once()
+
+		_, err := safeOwners(context.Background(), eth1Cl, validEthAddr)
+		require.ErrorContains(t, err, "safe has no owners")
+	})
+}
@@ -3,10 +3,15 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
+	"reflect"
 	"strings"
+	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/spf13/cobra"
@@ -18,24 +23,39 @@ import (
 	"github.com/obolnetwork/charon/p2p"
 )
 
-func newEnrCmd(runFunc func(io.Writer, string, bool) error) *cobra.Command {
+// enrWatchConfig is the config of watching a running node's ENR and peer discovery state.
+type enrWatchConfig struct {
+	MonitoringAddr string
+	Interval       time.Duration
+}
+
+func newEnrCmd(runFunc func(io.Writer, string, bool) error, watchFunc func(context.Context, io.Writer, enrWatchConfig) error) *cobra.Command {
 	var (
-		dataDir string
-		verbose bool
+		dataDir     string
+		verbose     bool
+		watch       bool
+		watchConfig enrWatchConfig
 	)
 
 	cmd := &cobra.Command{
 		Use:   "enr",
 		Short: "Print the ENR that identifies this client",
-		Long:  `Prints an Ethereum Node Record (ENR) from this client's charon-enr-private-key. This serves as a public key that identifies this client to its peers.`,
+		Long:  `Prints an Ethereum Node Record (ENR) from this client's charon-enr-private-key. This serves as a public key that identifies this client to its peers. With --watch, displays the ENR, advertised addresses, relay reservations and external address candidates of the running node in real time.`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if watch {
+				return watchFunc(cmd.Context(), cmd.OutOrStdout(), watchConfig)
+			}
+
 			return runFunc(cmd.OutOrStdout(), dataDir, verbose)
 		},
 	}
 
 	bindDataDirFlag(cmd.Flags(), &dataDir)
 	bindEnrFlags(cmd.Flags(), &verbose)
+	cmd.Flags().BoolVar(&watch, "watch", false, "Watches the running node's ENR and peer discovery state (advertised addresses, relay reservations and external address candidates) via its monitoring API.")
+	cmd.Flags().StringVar(&watchConfig.MonitoringAddr, "monitoring-address", "127.0.0.1:3620", "Monitoring API address (ip and port) of the running node to watch.")
+	cmd.Flags().DurationVar(&watchConfig.Interval, "watch-interval", 5*time.Second, "Interval at which the running node is polled when watching.")
 
 	return cmd
 }
@@ -65,6 +85,104 @@ func runNewENR(w io.Writer, dataDir string, verbose bool) error {
 	return nil
 }
 
+// runWatchENR polls the running node's monitoring API, printing its ENR and peer discovery state whenever it changes,
+// until the context is cancelled.
+func runWatchENR(ctx context.Context, w io.Writer, config enrWatchConfig) error {
+	if config.Interval <= 0 {
+		return errors.New("watch interval must be positive")
+	}
+
+	endpoint := "http://" + config.MonitoringAddr + "/p2p/node"
+
+	var (
+		prev    p2p.NodeInfo
+		prevErr string
+		first   = true
+	)
+
+	for {
+		info, err := fetchNodeInfo(ctx, endpoint)
+		if ctx.Err() != nil {
+			return nil //nolint:nilerr // Context cancelled, stop watching.
+		}
+
+		if err != nil {
+			if err.Error() != prevErr {
+				_, _ = fmt.Fprintf(w, "%s Failed fetching node info from %s: %v\n", time.Now().Format(time.TimeOnly), endpoint, err)
+			}
+
+			prevErr, first = err.Error(), true
+		} else if first || !reflect.DeepEqual(info, prev) {
+			writeNodeInfo(w, info)
+
+			prev, prevErr, first = info, "", false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(config.Interval):
+		}
+	}
+}
+
+// fetchNodeInfo returns the node info served by the monitoring API endpoint.
+func fetchNodeInfo(ctx context.Context, endpoint string) (p2p.NodeInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return p2p.NodeInfo{}, errors.Wrap(err, "new request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return p2p.NodeInfo{}, errors.Wrap(err, "get node info")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return p2p.NodeInfo{}, errors.New("unexpected node info status", z.Int("status", resp.StatusCode))
+	}
+
+	var info p2p.NodeInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return p2p.NodeInfo{}, errors.Wrap(err, "decode node info")
+	}
+
+	return info, nil
+}
+
+// writeNodeInfo writes the node info to the terminal.
+func writeNodeInfo(w io.Writer, info p2p.NodeInfo) {
+	var sb strings.Builder
+
+	list := func(title string, items []string) {
+		_, _ = sb.WriteString(title + ":\n")
+		if len(items) == 0 {
+			_, _ = sb.WriteString("  none\n")
+		}
+
+		for _, item := range items {
+			_, _ = sb.WriteString("  " + item + "\n")
+		}
+	}
+
+	var relays []string
+	for _, resv := range info.RelayReservations {
+		relays = append(relays, fmt.Sprintf("%s (expires %s) %s", resv.Relay, resv.Expiration.Format(time.RFC3339), strings.Join(resv.Addrs, ",")))
+	}
+
+	_, _ = sb.WriteString(fmt.Sprintf("***************** Node info at %s ****************************************************\n", time.Now().Format(time.TimeOnly)))
+	_, _ = sb.WriteString(fmt.Sprintf("ENR: %s\n", info.ENR))
+	_, _ = sb.WriteString(fmt.Sprintf("Peer: %s (%s)\n", info.PeerName, info.PeerID))
+	list("Listen addresses", info.ListenAddrs)
+	list("Advertised addresses", info.AdvertisedAddrs)
+	list("External address candidates", info.ExternalAddrCandidates)
+	list("Relay reservations", relays)
+	_, _ = sb.WriteString("\n")
+
+	_, _ = w.Write([]byte(sb.String()))
+}
+
 // writeExpandedEnr writes the expanded form of ENR to the terminal.
 func writeExpandedEnr(w io.Writer, r enr.Record, privKey *k1.PrivateKey) {
 	var sb strings.Builder
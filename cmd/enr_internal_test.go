@@ -3,8 +3,15 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -20,3 +27,39 @@ func TestRunNewEnr(t *testing.T) {
 	expected := errors.New("private key not found. If this is your first time running this client, create one with `charon create enr`.", z.Str("enr_path", p2p.KeyPath(temp)))
 	require.Equal(t, expected.Error(), got.Error())
 }
+
+func TestRunWatchENR(t *testing.T) {
+	info := p2p.NodeInfo{
+		ENR:               "enr:-HW4QEp",
+		PeerID:            "16Uiu2HAm",
+		PeerName:          "happy-bird",
+		ListenAddrs:       []string{"/ip4/0.0.0.0/tcp/3610"},
+		AdvertisedAddrs:   []string{"/ip4/1.2.3.4/tcp/3610"},
+		RelayReservations: []p2p.RelayReservation{{Relay: "relay-peer", Addrs: []string{"/ip4/5.6.7.8/tcp/3640"}}},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/p2p/node", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode(info))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	require.NoError(t, runWatchENR(ctx, &out, enrWatchConfig{
+		MonitoringAddr: strings.TrimPrefix(srv.URL, "http://"),
+		Interval:       10 * time.Millisecond,
+	}))
+
+	// Unchanged node info is only printed once.
+	require.Equal(t, 1, strings.Count(out.String(), "Node info at"))
+	require.Contains(t, out.String(), "ENR: enr:-HW4QEp")
+	require.Contains(t, out.String(), "Peer: happy-bird (16Uiu2HAm)")
+	require.Contains(t, out.String(), "Advertised addresses:\n  /ip4/1.2.3.4/tcp/3610\n")
+	require.Contains(t, out.String(), "External address candidates:\n  none\n")
+	require.Contains(t, out.String(), "relay-peer (expires")
+
+	require.ErrorContains(t, runWatchENR(ctx, &out, enrWatchConfig{}), "watch interval must be positive")
+}
@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"context"
+	"io"
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -37,9 +38,16 @@ type exitConfig struct {
 	ExitFromFileDir         string
 	Log                     log.Config
 	All                     bool
+	ValidatorsFilePath      string
+	SigningParallelism      int
+	PartialExitsOutputPath  string
+	ArtifactFilePaths       []string
 	testnetConfig           eth2util.Network
 	BeaconNodeHeaders       []string
 	FallbackBeaconNodeAddrs []string
+	ForceNetwork            bool
+	SkipConfirmation        bool
+	confirmIn               io.Reader
 }
 
 func newExitCmd(cmds ...*cobra.Command) *cobra.Command {
@@ -78,6 +86,12 @@ const (
 	testnetCapellaHardFork
 	beaconNodeHeaders
 	fallbackBeaconNodeAddrs
+	validatorsFile
+	signingParallelism
+	partialExitsOutput
+	artifactFiles
+	forceNetwork
+	skipConfirmation
 )
 
 func (ef exitFlag) String() string {
@@ -124,6 +138,18 @@ func (ef exitFlag) String() string {
 		return "beacon-node-headers"
 	case fallbackBeaconNodeAddrs:
 		return "fallback-beacon-node-endpoints"
+	case validatorsFile:
+		return "validators-file"
+	case signingParallelism:
+		return "signing-parallelism"
+	case partialExitsOutput:
+		return "partial-exits-output-file"
+	case artifactFiles:
+		return "artifact-files"
+	case forceNetwork:
+		return "force-network"
+	case skipConfirmation:
+		return "yes"
 	default:
 		return "unknown"
 	}
@@ -189,6 +215,18 @@ func bindExitFlags(cmd *cobra.Command, config *exitConfig, flags []exitCLIFlag)
 			cmd.Flags().StringSliceVar(&config.BeaconNodeHeaders, "beacon-node-headers", nil, "Comma separated list of headers formatted as header=value")
 		case fallbackBeaconNodeAddrs:
 			cmd.Flags().StringSliceVar(&config.FallbackBeaconNodeAddrs, "fallback-beacon-node-endpoints", nil, "A list of beacon nodes to use if the primary list are offline or unhealthy.")
+		case validatorsFile:
+			cmd.Flags().StringVar(&config.ValidatorsFilePath, validatorsFile.String(), "", maybeRequired("Path to a file listing the public keys of the validators to exit, one per line. Validators must be present in the cluster lock manifest."))
+		case signingParallelism:
+			cmd.Flags().IntVar(&config.SigningParallelism, signingParallelism.String(), 8, "Number of partial exit messages signed in parallel when exiting multiple validators.")
+		case partialExitsOutput:
+			cmd.Flags().StringVar(&config.PartialExitsOutputPath, partialExitsOutput.String(), "", "Optional path to write signed partial exit messages to as a single versioned exit artifact, for later verification, aggregation and broadcast.")
+		case artifactFiles:
+			cmd.Flags().StringSliceVar(&config.ArtifactFilePaths, artifactFiles.String(), nil, maybeRequired("Comma separated list of exit artifact files containing partial exit signatures of one or more operators."))
+		case forceNetwork:
+			cmd.Flags().BoolVar(&config.ForceNetwork, forceNetwork.String(), false, "Ignores a mismatch between the cluster lock's network and the beacon node's network, logging a warning instead of refusing to sign, verify or broadcast exits. Exit signatures are then likely invalid.")
+		case skipConfirmation:
+			cmd.Flags().BoolVar(&config.SkipConfirmation, skipConfirmation.String(), false, "Skips the interactive confirmation prompt before broadcasting exits.")
 		}
 
 		if f.required {
@@ -207,9 +245,29 @@ func eth2Client(ctx context.Context, fallbackAddresses []string, headers map[str
 		return nil, errors.Wrap(err, "connect to beacon node")
 	}
 
+	if len(u) > 1 {
+		// Require a quorum of the beacon nodes to agree on validator indices and statuses before signing or broadcasting.
+		cl = eth2wrap.WithValidatorsQuorum(cl, eth2wrap.NewHTTPClients(timeout, forkVersion, headers, u))
+	}
+
 	return cl, nil
 }
 
+// verifyExitNetwork returns an error if the beacon node is on a different network than the cluster,
+// since exits signed with its domain would be invalid. The mismatch is only logged if forced.
+func verifyExitNetwork(ctx context.Context, eth2Cl eth2wrap.Client, forkVersion []byte, force bool) error {
+	err := eth2wrap.VerifyNetwork(ctx, eth2Cl, forkVersion)
+	if err == nil {
+		return nil
+	} else if !force || !errors.Is(err, eth2wrap.ErrNetworkMismatch) {
+		return err
+	}
+
+	log.Warn(ctx, "Ignoring beacon node network mismatch due to --force-network, exit signatures may be invalid", err)
+
+	return nil
+}
+
 // signExit signs a voluntary exit message for valIdx with the given keyShare.
 func signExit(ctx context.Context, eth2Cl eth2wrap.Client, valIdx eth2p0.ValidatorIndex, keyShare tbls.PrivateKey, exitEpoch eth2p0.Epoch) (eth2p0.SignedVoluntaryExit, error) {
 	exit := &eth2p0.VoluntaryExit{
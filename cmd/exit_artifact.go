@@ -0,0 +1,307 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// exitArtifactVersion is the current version of the exit artifact format.
+const exitArtifactVersion = "v1"
+
+// exitArtifact is a versioned collection of partial exit signatures of one or more operators
+// for a set of cluster validators, to be aggregated and broadcast once complete.
+type exitArtifact struct {
+	Version    string                  `json:"version"`
+	LockHash   string                  `json:"lock_hash"`
+	Epoch      uint64                  `json:"epoch"`
+	Validators []exitArtifactValidator `json:"validators"`
+}
+
+// exitArtifactValidator contains the partial exit signatures of a validator.
+type exitArtifactValidator struct {
+	PublicKey         string                `json:"public_key"`
+	ValidatorIndex    uint64                `json:"validator_index"`
+	PartialSignatures []exitArtifactPartial `json:"partial_signatures"`
+}
+
+// exitArtifactPartial is a partial exit signature of the operator with the share index (1-indexed).
+type exitArtifactPartial struct {
+	ShareIdx  uint64 `json:"share_index"`
+	Signature string `json:"signature"`
+}
+
+// exitArtifactResult is the verification result of a validator in an exit artifact.
+type exitArtifactResult struct {
+	PublicKey     string
+	ValidSigs     int
+	MissingShares []uint64
+	Complete      bool
+	Err           error
+}
+
+// newExitArtifact returns a new exit artifact containing the operator's partial exits.
+func newExitArtifact(lockHash []byte, epoch uint64, shareIdx uint64, exitBlobs []obolapi.ExitBlob) exitArtifact {
+	artifact := exitArtifact{
+		Version:  exitArtifactVersion,
+		LockHash: fmt.Sprintf("%#x", lockHash),
+		Epoch:    epoch,
+	}
+
+	for _, blob := range exitBlobs {
+		artifact.Validators = append(artifact.Validators, exitArtifactValidator{
+			PublicKey:      blob.PublicKey,
+			ValidatorIndex: uint64(blob.SignedExitMessage.Message.ValidatorIndex),
+			PartialSignatures: []exitArtifactPartial{{
+				ShareIdx:  shareIdx,
+				Signature: blob.SignedExitMessage.Signature.String(),
+			}},
+		})
+	}
+
+	sortExitArtifact(&artifact)
+
+	return artifact
+}
+
+// loadExitArtifact reads an exit artifact from disk.
+func loadExitArtifact(path string) (exitArtifact, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return exitArtifact{}, errors.Wrap(err, "read exit artifact", z.Str("path", path))
+	}
+
+	var artifact exitArtifact
+	if err := json.Unmarshal(b, &artifact); err != nil {
+		return exitArtifact{}, errors.Wrap(err, "unmarshal exit artifact", z.Str("path", path))
+	}
+
+	if artifact.Version != exitArtifactVersion {
+		return exitArtifact{}, errors.New("unsupported exit artifact version", z.Str("path", path), z.Str("version", artifact.Version))
+	}
+
+	return artifact, nil
+}
+
+// writeExitArtifact writes the exit artifact to disk.
+func writeExitArtifact(path string, artifact exitArtifact) error {
+	b, err := json.MarshalIndent(artifact, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal exit artifact")
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	if err := os.WriteFile(path, b, 0o444); err != nil {
+		return errors.Wrap(err, "write exit artifact", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// mergeExitArtifacts merges the partial signatures of the provided exit artifacts into a single artifact.
+// All artifacts must be of the same cluster lock and exit epoch.
+func mergeExitArtifacts(artifacts ...exitArtifact) (exitArtifact, error) {
+	if len(artifacts) == 0 {
+		return exitArtifact{}, errors.New("no exit artifacts")
+	}
+
+	merged := exitArtifact{
+		Version:  exitArtifactVersion,
+		LockHash: artifacts[0].LockHash,
+		Epoch:    artifacts[0].Epoch,
+	}
+
+	byPubkey := make(map[string]*exitArtifactValidator)
+
+	for _, artifact := range artifacts {
+		if !strings.EqualFold(artifact.LockHash, merged.LockHash) {
+			return exitArtifact{}, errors.New("mismatching exit artifact lock hash", z.Str("expected", merged.LockHash), z.Str("actual", artifact.LockHash))
+		} else if artifact.Epoch != merged.Epoch {
+			return exitArtifact{}, errors.New("mismatching exit artifact epoch", z.U64("expected", merged.Epoch), z.U64("actual", artifact.Epoch))
+		}
+
+		for _, val := range artifact.Validators {
+			pubkey := strings.ToLower(val.PublicKey)
+
+			existing, ok := byPubkey[pubkey]
+			if !ok {
+				existing = &exitArtifactValidator{
+					PublicKey:      val.PublicKey,
+					ValidatorIndex: val.ValidatorIndex,
+				}
+				byPubkey[pubkey] = existing
+			} else if existing.ValidatorIndex != val.ValidatorIndex {
+				return exitArtifact{}, errors.New("mismatching validator index", z.Str("pubkey", val.PublicKey))
+			}
+
+			for _, partial := range val.PartialSignatures {
+				idx := slices.IndexFunc(existing.PartialSignatures, func(p exitArtifactPartial) bool {
+					return p.ShareIdx == partial.ShareIdx
+				})
+				if idx < 0 {
+					existing.PartialSignatures = append(existing.PartialSignatures, partial)
+				} else if !strings.EqualFold(existing.PartialSignatures[idx].Signature, partial.Signature) {
+					return exitArtifact{}, errors.New("conflicting partial signatures", z.Str("pubkey", val.PublicKey), z.U64("share_index", partial.ShareIdx))
+				}
+			}
+		}
+	}
+
+	for _, val := range byPubkey {
+		merged.Validators = append(merged.Validators, *val)
+	}
+
+	sortExitArtifact(&merged)
+
+	return merged, nil
+}
+
+// sortExitArtifact sorts the artifact's validators by index and their partial signatures by share index.
+func sortExitArtifact(artifact *exitArtifact) {
+	slices.SortFunc(artifact.Validators, func(a, b exitArtifactValidator) int {
+		return int(a.ValidatorIndex) - int(b.ValidatorIndex)
+	})
+
+	for _, val := range artifact.Validators {
+		slices.SortFunc(val.PartialSignatures, func(a, b exitArtifactPartial) int {
+			return int(a.ShareIdx) - int(b.ShareIdx)
+		})
+	}
+}
+
+// verifyExitArtifact verifies the exit artifact against the cluster lock, returning the result per validator.
+// A validator is complete if its valid partial signatures satisfy the cluster threshold and aggregate
+// to a valid exit signature.
+func verifyExitArtifact(ctx context.Context, eth2Cl eth2wrap.Client, cl *manifestpb.Cluster, artifact exitArtifact) ([]exitArtifactResult, error) {
+	lockHash, err := hex.DecodeString(strings.TrimPrefix(artifact.LockHash, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode exit artifact lock hash")
+	} else if !bytes.Equal(lockHash, cl.GetInitialMutationHash()) {
+		return nil, errors.New("exit artifact lock hash doesn't match cluster lock", z.Str("lock_hash", artifact.LockHash))
+	}
+
+	var results []exitArtifactResult
+	for _, val := range artifact.Validators {
+		results = append(results, verifyExitArtifactValidator(ctx, eth2Cl, cl, artifact.Epoch, val))
+	}
+
+	return results, nil
+}
+
+// verifyExitArtifactValidator verifies the partial signatures of a single validator in an exit artifact.
+func verifyExitArtifactValidator(ctx context.Context, eth2Cl eth2wrap.Client, cl *manifestpb.Cluster, epoch uint64, val exitArtifactValidator) exitArtifactResult {
+	result := exitArtifactResult{PublicKey: val.PublicKey}
+
+	pubkeyBytes, err := hex.DecodeString(strings.TrimPrefix(val.PublicKey, "0x"))
+	if err != nil {
+		result.Err = errors.Wrap(err, "decode validator public key")
+		return result
+	}
+
+	var clusterVal *manifestpb.Validator
+	for _, v := range cl.GetValidators() {
+		if bytes.Equal(v.GetPublicKey(), pubkeyBytes) {
+			clusterVal = v
+			break
+		}
+	}
+
+	if clusterVal == nil {
+		result.Err = errors.New("validator not present in cluster lock")
+		return result
+	}
+
+	exit := eth2p0.VoluntaryExit{
+		Epoch:          eth2p0.Epoch(epoch),
+		ValidatorIndex: eth2p0.ValidatorIndex(val.ValidatorIndex),
+	}
+
+	sigData, err := sigDataForExit(ctx, exit, eth2Cl, exit.Epoch)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	validSigs := make(map[int]tbls.Signature)
+
+	for _, partial := range val.PartialSignatures {
+		if partial.ShareIdx == 0 || partial.ShareIdx > uint64(len(clusterVal.GetPubShares())) {
+			result.Err = errors.New("invalid share index", z.U64("share_index", partial.ShareIdx))
+			return result
+		}
+
+		pubshare, err := tblsconv.PubkeyFromBytes(clusterVal.GetPubShares()[partial.ShareIdx-1])
+		if err != nil {
+			result.Err = errors.Wrap(err, "parse public share")
+			return result
+		}
+
+		sigBytes, err := hex.DecodeString(strings.TrimPrefix(partial.Signature, "0x"))
+		if err != nil {
+			result.Err = errors.Wrap(err, "decode partial signature", z.U64("share_index", partial.ShareIdx))
+			return result
+		}
+
+		sig, err := tblsconv.SignatureFromBytes(sigBytes)
+		if err != nil {
+			result.Err = errors.Wrap(err, "parse partial signature", z.U64("share_index", partial.ShareIdx))
+			return result
+		}
+
+		if err := tbls.Verify(pubshare, sigData[:], sig); err != nil {
+			result.Err = errors.Wrap(err, "invalid partial signature", z.U64("share_index", partial.ShareIdx))
+			return result
+		}
+
+		validSigs[int(partial.ShareIdx)] = sig
+	}
+
+	for shareIdx := 1; shareIdx <= len(clusterVal.GetPubShares()); shareIdx++ {
+		if _, ok := validSigs[shareIdx]; !ok {
+			result.MissingShares = append(result.MissingShares, uint64(shareIdx))
+		}
+	}
+
+	result.ValidSigs = len(validSigs)
+	if result.ValidSigs < int(cl.GetThreshold()) {
+		return result
+	}
+
+	aggSig, err := tbls.ThresholdAggregate(validSigs)
+	if err != nil {
+		result.Err = errors.Wrap(err, "aggregate partial signatures")
+		return result
+	}
+
+	pubkey, err := tblsconv.PubkeyFromBytes(pubkeyBytes)
+	if err != nil {
+		result.Err = errors.Wrap(err, "parse validator public key")
+		return result
+	}
+
+	if err := tbls.Verify(pubkey, sigData[:], aggSig); err != nil {
+		result.Err = errors.Wrap(err, "aggregate exit signature not verified")
+		return result
+	}
+
+	result.Complete = true
+
+	return result
+}
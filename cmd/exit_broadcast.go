@@ -47,6 +47,8 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 
 			printFlags(cmd.Context(), cmd.Flags())
 
+			config.confirmIn = cmd.InOrStdin()
+
 			return runFunc(cmd.Context(), config)
 		},
 	}
@@ -59,6 +61,8 @@ func newBcastFullExitCmd(runFunc func(context.Context, exitConfig) error) *cobra
 		{exitEpoch, false},
 		{validatorPubkey, false},
 		{beaconNodeEndpoints, true},
+		{forceNetwork, false},
+		{skipConfirmation, false},
 		{exitFromFile, false},
 		{exitFromDir, false},
 		{beaconNodeTimeout, false},
@@ -138,6 +142,10 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
 	}
 
+	if err := verifyExitNetwork(ctx, eth2Cl, cl.GetForkVersion(), config.ForceNetwork); err != nil {
+		return err
+	}
+
 	fullExits := make(map[core.PubKey]eth2p0.SignedVoluntaryExit)
 
 	if config.All {
@@ -211,6 +219,20 @@ func runBcastFullExit(ctx context.Context, config exitConfig) error {
 		fullExits[validatorPubKey] = exit
 	}
 
+	estimates, err := checkExitSafety(ctx, eth2Cl, fullExits)
+	if err != nil {
+		return errors.Wrap(err, "exit safety check")
+	}
+
+	confirmIn := config.confirmIn
+	if confirmIn == nil {
+		confirmIn = os.Stdin
+	}
+
+	if err := confirmExits(ctx, confirmIn, os.Stdout, estimates, config.SkipConfirmation); err != nil {
+		return err
+	}
+
 	return broadcastExitsToBeacon(ctx, eth2Cl, fullExits)
 }
 
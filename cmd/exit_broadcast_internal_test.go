@@ -136,6 +136,7 @@ func testRunBcastFullExitCmdFlow(t *testing.T, fromFile bool, all bool) {
 
 		config := exitConfig{
 			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			ForceNetwork:        true,
 			PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 			ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
 			LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
@@ -158,6 +159,7 @@ func testRunBcastFullExitCmdFlow(t *testing.T, fromFile bool, all bool) {
 
 	config := exitConfig{
 		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		ForceNetwork:        true,
 		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 		ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
 		LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
@@ -203,6 +205,8 @@ func testRunBcastFullExitCmdFlow(t *testing.T, fromFile bool, all bool) {
 		}
 	}
 
+	config.SkipConfirmation = true
+
 	require.NoError(t, runBcastFullExit(ctx, config))
 }
 
@@ -334,6 +338,7 @@ func Test_runBcastFullExitCmd_Config(t *testing.T) {
 
 			config := exitConfig{
 				BeaconNodeEndpoints: []string{bnURL},
+				ForceNetwork:        true,
 				ValidatorPubkey:     valAddr,
 				PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 				ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
@@ -518,6 +523,7 @@ func TestExitBcastFullExitNotActivated(t *testing.T) {
 
 			config := exitConfig{
 				BeaconNodeEndpoints: []string{beaconMock.Address()},
+				ForceNetwork:        true,
 				PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 				ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
 				LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
@@ -537,6 +543,7 @@ func TestExitBcastFullExitNotActivated(t *testing.T) {
 
 	config := exitConfig{
 		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		ForceNetwork:        true,
 		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 		ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
 		LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
@@ -548,6 +555,7 @@ func TestExitBcastFullExitNotActivated(t *testing.T) {
 
 	// exit all and do not fail on non-existing keys
 	config.All = true
+	config.SkipConfirmation = true
 
 	require.NoError(t, runBcastFullExit(ctx, config))
 }
@@ -107,6 +107,7 @@ func testRunDeleteExitFullFlow(t *testing.T, all bool) {
 
 	config := exitConfig{
 		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		ForceNetwork:        true,
 		ValidatorPubkey:     lock.Validators[0].PublicKeyHex(),
 		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 		ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
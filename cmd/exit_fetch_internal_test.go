@@ -109,6 +109,7 @@ func testRunFetchExitFullFlow(t *testing.T, all bool) {
 
 		config := exitConfig{
 			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			ForceNetwork:        true,
 			ValidatorPubkey:     lock.Validators[0].PublicKeyHex(),
 			PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 			ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
@@ -324,6 +325,7 @@ func TestFetchExitFullFlowNotActivated(t *testing.T) {
 
 			config := exitConfig{
 				BeaconNodeEndpoints: []string{beaconMock.Address()},
+				ForceNetwork:        true,
 				ValidatorPubkey:     lock.Validators[0].PublicKeyHex(),
 				PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 				ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
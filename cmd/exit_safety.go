@@ -0,0 +1,246 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// farFutureEpoch is the exit epoch of validators that haven't initiated an exit.
+const farFutureEpoch = eth2p0.Epoch(1<<64 - 1)
+
+// exitEstimate is the expected outcome of broadcasting a validator's voluntary exit.
+type exitEstimate struct {
+	PubKey            core.PubKey
+	ValidatorIndex    eth2p0.ValidatorIndex
+	ExitEpoch         eth2p0.Epoch
+	WithdrawableEpoch eth2p0.Epoch
+	ExitMessageEpoch  eth2p0.Epoch
+	Status            string
+}
+
+// exitChurnSpec contains the spec values required to estimate the exit queue.
+type exitChurnSpec struct {
+	SlotDuration                     time.Duration
+	SlotsPerEpoch                    uint64
+	ShardCommitteePeriod             uint64
+	MinPerEpochChurnLimit            uint64
+	ChurnLimitQuotient               uint64
+	MaxSeedLookahead                 uint64
+	MinValidatorWithdrawabilityDelay uint64
+}
+
+// checkExitSafety verifies the exits can be broadcast safely: validators must be active, not already exiting and
+// past the shard committee period, and exit messages mustn't be from a future epoch. It returns the expected exit
+// and withdrawable epochs of each validator, estimated with the Deneb exit churn limit.
+func checkExitSafety(ctx context.Context, eth2Cl eth2wrap.Client, exits map[core.PubKey]eth2p0.SignedVoluntaryExit) ([]exitEstimate, error) {
+	spec, err := fetchExitChurnSpec(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	currentEpoch := eth2p0.Epoch(uint64(time.Since(genesis)/spec.SlotDuration) / spec.SlotsPerEpoch)
+
+	var indices []eth2p0.ValidatorIndex
+	for pubkey, exit := range exits {
+		if exit.Message == nil {
+			return nil, errors.New("invalid exit message", z.Str("validator", pubkey.String()))
+		}
+
+		indices = append(indices, exit.Message.ValidatorIndex)
+	}
+
+	resp, err := queryBeaconForValidator(ctx, eth2Cl, nil, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	var estimates []exitEstimate
+	for pubkey, exit := range exits {
+		val, ok := resp.Data[exit.Message.ValidatorIndex]
+		if !ok || val.Validator == nil {
+			return nil, errors.New("validator not found on beacon node", z.Str("validator", pubkey.String()),
+				z.U64("validator_index", uint64(exit.Message.ValidatorIndex)))
+		}
+
+		if err := verifyExitable(val, pubkey, exit.Message.Epoch, currentEpoch, spec); err != nil {
+			return nil, err
+		}
+
+		estimates = append(estimates, exitEstimate{
+			PubKey:           pubkey,
+			ValidatorIndex:   val.Index,
+			ExitMessageEpoch: exit.Message.Epoch,
+			Status:           val.Status.String(),
+		})
+	}
+
+	// Exits are processed in block order, assume validator index order.
+	slices.SortFunc(estimates, func(a, b exitEstimate) int {
+		return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+	})
+
+	// Estimating the exit queue requires all validators, an empty queue would underestimate the exit epochs.
+	allVals, err := eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{State: "head"})
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch all validators to estimate the exit queue")
+	}
+
+	estimateExitEpochs(allVals.Data, estimates, currentEpoch, spec)
+
+	return estimates, nil
+}
+
+// verifyExitable returns an error if the validator can't or shouldn't exit at the current epoch.
+func verifyExitable(val *eth2v1.Validator, pubkey core.PubKey, msgEpoch, currentEpoch eth2p0.Epoch, spec exitChurnSpec) error {
+	if core.PubKeyFrom48Bytes(val.Validator.PublicKey) != pubkey {
+		return errors.New("exit message validator index doesn't match validator public key",
+			z.Str("validator", pubkey.String()), z.U64("validator_index", uint64(val.Index)))
+	}
+
+	switch {
+	case val.Status == eth2v1.ValidatorStateActiveExiting || val.Status == eth2v1.ValidatorStateActiveSlashed || val.Status.HasExited():
+		return errors.New("validator is already exiting", z.Str("validator", pubkey.String()), z.Str("status", val.Status.String()))
+	case !val.Status.IsActive():
+		return errors.New("validator is not active", z.Str("validator", pubkey.String()), z.Str("status", val.Status.String()))
+	case uint64(currentEpoch) < uint64(val.Validator.ActivationEpoch)+spec.ShardCommitteePeriod:
+		return errors.New("validator can't exit before it was active for the shard committee period",
+			z.Str("validator", pubkey.String()), z.U64("activation_epoch", uint64(val.Validator.ActivationEpoch)),
+			z.U64("earliest_exit_epoch", uint64(val.Validator.ActivationEpoch)+spec.ShardCommitteePeriod))
+	case msgEpoch > currentEpoch:
+		return errors.New("exit message epoch is in the future, beacon nodes will reject it",
+			z.Str("validator", pubkey.String()), z.U64("exit_message_epoch", uint64(msgEpoch)), z.U64("current_epoch", uint64(currentEpoch)))
+	}
+
+	return nil
+}
+
+// estimateExitEpochs populates the expected exit and withdrawable epochs of the ordered estimates
+// by simulating the Deneb exit queue. Note that from Electra the exit churn is balance based,
+// so the estimate is a lower bound for validators with large effective balances.
+func estimateExitEpochs(vals map[eth2p0.ValidatorIndex]*eth2v1.Validator, estimates []exitEstimate, currentEpoch eth2p0.Epoch, spec exitChurnSpec) {
+	var active uint64
+	queueEpoch := currentEpoch + 1 + eth2p0.Epoch(spec.MaxSeedLookahead)
+
+	for _, val := range vals {
+		if val.Status.IsActive() {
+			active++
+		}
+
+		if val.Validator != nil && val.Validator.ExitEpoch != farFutureEpoch && val.Validator.ExitEpoch > queueEpoch {
+			queueEpoch = val.Validator.ExitEpoch
+		}
+	}
+
+	var queueChurn uint64
+	for _, val := range vals {
+		if val.Validator != nil && val.Validator.ExitEpoch == queueEpoch {
+			queueChurn++
+		}
+	}
+
+	churnLimit := max(spec.MinPerEpochChurnLimit, active/max(spec.ChurnLimitQuotient, 1))
+
+	for i := range estimates {
+		if queueChurn >= churnLimit {
+			queueEpoch++
+			queueChurn = 0
+		}
+
+		queueChurn++
+		estimates[i].ExitEpoch = queueEpoch
+		estimates[i].WithdrawableEpoch = queueEpoch + eth2p0.Epoch(spec.MinValidatorWithdrawabilityDelay)
+	}
+}
+
+// fetchExitChurnSpec returns the spec values required to estimate the exit queue.
+func fetchExitChurnSpec(ctx context.Context, eth2Cl eth2wrap.Client) (exitChurnSpec, error) {
+	slotDuration, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return exitChurnSpec{}, err
+	}
+
+	resp, err := eth2Cl.Spec(ctx, &eth2api.SpecOpts{})
+	if err != nil {
+		return exitChurnSpec{}, errors.Wrap(err, "fetch spec")
+	}
+
+	spec := exitChurnSpec{
+		SlotDuration:  slotDuration,
+		SlotsPerEpoch: slotsPerEpoch,
+	}
+
+	for key, field := range map[string]*uint64{
+		"SHARD_COMMITTEE_PERIOD":              &spec.ShardCommitteePeriod,
+		"MIN_PER_EPOCH_CHURN_LIMIT":           &spec.MinPerEpochChurnLimit,
+		"CHURN_LIMIT_QUOTIENT":                &spec.ChurnLimitQuotient,
+		"MAX_SEED_LOOKAHEAD":                  &spec.MaxSeedLookahead,
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": &spec.MinValidatorWithdrawabilityDelay,
+	} {
+		val, ok := resp.Data[key].(uint64)
+		if !ok {
+			return exitChurnSpec{}, errors.New("fetch spec value", z.Str("key", key))
+		}
+
+		*field = val
+	}
+
+	return spec, nil
+}
+
+// confirmExits prints the expected exit and withdrawable epochs and prompts the user to confirm broadcasting the exits.
+// It returns an error if the user doesn't confirm.
+func confirmExits(ctx context.Context, in io.Reader, out io.Writer, estimates []exitEstimate, skipPrompt bool) error {
+	for _, est := range estimates {
+		log.Info(ctx, "Expected validator exit",
+			z.Str("validator", est.PubKey.String()),
+			z.U64("validator_index", uint64(est.ValidatorIndex)),
+			z.Str("status", est.Status),
+			z.U64("exit_message_epoch", uint64(est.ExitMessageEpoch)),
+			z.U64("expected_exit_epoch", uint64(est.ExitEpoch)),
+			z.U64("expected_withdrawable_epoch", uint64(est.WithdrawableEpoch)),
+		)
+	}
+
+	if skipPrompt {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(out, "Broadcasting %d voluntary exits is irreversible, continue? [y/N]: ", len(estimates)); err != nil {
+		return errors.Wrap(err, "output write")
+	}
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrap(err, "read confirmation")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errors.New("exit broadcast not confirmed, use --yes to skip the confirmation prompt")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+var testExitChurnSpec = exitChurnSpec{
+	SlotsPerEpoch:                    32,
+	ShardCommitteePeriod:             256,
+	MinPerEpochChurnLimit:            4,
+	ChurnLimitQuotient:               65536,
+	MaxSeedLookahead:                 4,
+	MinValidatorWithdrawabilityDelay: 256,
+}
+
+func TestVerifyExitable(t *testing.T) {
+	pubkey := core.PubKeyFrom48Bytes(eth2p0.BLSPubKey{1})
+
+	newVal := func(status eth2v1.ValidatorState, activation eth2p0.Epoch) *eth2v1.Validator {
+		return &eth2v1.Validator{
+			Index:  1,
+			Status: status,
+			Validator: &eth2p0.Validator{
+				PublicKey:       eth2p0.BLSPubKey{1},
+				ActivationEpoch: activation,
+				ExitEpoch:       farFutureEpoch,
+			},
+		}
+	}
+
+	require.NoError(t, verifyExitable(newVal(eth2v1.ValidatorStateActiveOngoing, 0), pubkey, 1000, 1000, testExitChurnSpec))
+
+	err := verifyExitable(newVal(eth2v1.ValidatorStateActiveExiting, 0), pubkey, 1000, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "validator is already exiting")
+
+	err = verifyExitable(newVal(eth2v1.ValidatorStateWithdrawalPossible, 0), pubkey, 1000, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "validator is already exiting")
+
+	err = verifyExitable(newVal(eth2v1.ValidatorStatePendingQueued, 0), pubkey, 1000, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "validator is not active")
+
+	err = verifyExitable(newVal(eth2v1.ValidatorStateActiveOngoing, 800), pubkey, 1000, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "validator can't exit before it was active for the shard committee period")
+
+	err = verifyExitable(newVal(eth2v1.ValidatorStateActiveOngoing, 0), pubkey, 1001, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "exit message epoch is in the future")
+
+	err = verifyExitable(newVal(eth2v1.ValidatorStateActiveOngoing, 0), core.PubKeyFrom48Bytes(eth2p0.BLSPubKey{2}), 1000, 1000, testExitChurnSpec)
+	require.ErrorContains(t, err, "exit message validator index doesn't match validator public key")
+}
+
+func TestEstimateExitEpochs(t *testing.T) {
+	const current = eth2p0.Epoch(1000)
+
+	vals := make(map[eth2p0.ValidatorIndex]*eth2v1.Validator)
+	for i := range 10 {
+		vals[eth2p0.ValidatorIndex(i)] = &eth2v1.Validator{
+			Index:     eth2p0.ValidatorIndex(i),
+			Status:    eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{ExitEpoch: farFutureEpoch},
+		}
+	}
+
+	// Three validators already exiting in the queue's last epoch.
+	for i := range 3 {
+		vals[eth2p0.ValidatorIndex(i)].Status = eth2v1.ValidatorStateActiveExiting
+		vals[eth2p0.ValidatorIndex(i)].Validator.ExitEpoch = current + 10
+	}
+
+	estimates := make([]exitEstimate, 3)
+	estimateExitEpochs(vals, estimates, current, testExitChurnSpec)
+
+	// The minimum churn limit of 4 allows one more exit in the queue's last epoch.
+	require.Equal(t, current+10, estimates[0].ExitEpoch)
+	require.Equal(t, current+11, estimates[1].ExitEpoch)
+	require.Equal(t, current+11, estimates[2].ExitEpoch)
+	require.Equal(t, current+11+256, estimates[2].WithdrawableEpoch)
+
+	// An empty queue exits at the activation exit epoch.
+	estimates = make([]exitEstimate, 1)
+	estimateExitEpochs(nil, estimates, current, testExitChurnSpec)
+	require.Equal(t, current+5, estimates[0].ExitEpoch)
+}
+
+func TestConfirmExits(t *testing.T) {
+	estimates := []exitEstimate{{PubKey: core.PubKeyFrom48Bytes(eth2p0.BLSPubKey{1})}}
+
+	require.NoError(t, confirmExits(t.Context(), strings.NewReader(""), &bytes.Buffer{}, estimates, true))
+
+	var out bytes.Buffer
+	require.NoError(t, confirmExits(t.Context(), strings.NewReader("y\n"), &out, estimates, false))
+	require.Contains(t, out.String(), "Broadcasting 1 voluntary exits is irreversible")
+
+	err := confirmExits(t.Context(), strings.NewReader("n\n"), &bytes.Buffer{}, estimates, false)
+	require.ErrorContains(t, err, "exit broadcast not confirmed")
+
+	err = confirmExits(t.Context(), strings.NewReader(""), &bytes.Buffer{}, estimates, false)
+	require.ErrorContains(t, err, "exit broadcast not confirmed")
+}
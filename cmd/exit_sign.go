@@ -4,13 +4,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	libp2plog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -52,9 +57,13 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		{validatorPubkey, false},
 		{validatorIndex, false},
 		{beaconNodeEndpoints, true},
+		{forceNetwork, false},
 		{beaconNodeTimeout, false},
 		{publishTimeout, false},
 		{all, false},
+		{validatorsFile, false},
+		{signingParallelism, false},
+		{partialExitsOutput, false},
 		{testnetName, false},
 		{testnetForkVersion, false},
 		{testnetChainID, false},
@@ -70,7 +79,9 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 		valIdxPresent := cmd.Flags().Lookup(validatorIndex.String()).Changed
 		valPubkPresent := cmd.Flags().Lookup(validatorPubkey.String()).Changed
 
-		if !valPubkPresent && !valIdxPresent && !config.All {
+		batch := config.All || config.ValidatorsFilePath != ""
+
+		if !valPubkPresent && !valIdxPresent && !batch {
 			//nolint:revive // we use our own version of the errors package.
 			return errors.New(fmt.Sprintf("either %s or %s must be specified at least when exiting single validator.", validatorIndex.String(), validatorPubkey.String()))
 		}
@@ -80,6 +91,21 @@ func newSignPartialExitCmd(runFunc func(context.Context, exitConfig) error) *cob
 			return errors.New(fmt.Sprintf("%s or %s should not be specified when %s is, as they are obsolete and misleading.", validatorIndex.String(), validatorPubkey.String(), all.String()))
 		}
 
+		if config.ValidatorsFilePath != "" && (valIdxPresent || valPubkPresent) {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s or %s should not be specified when %s is, as they are obsolete and misleading.", validatorIndex.String(), validatorPubkey.String(), validatorsFile.String()))
+		}
+
+		if config.All && config.ValidatorsFilePath != "" {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s and %s are mutually exclusive.", all.String(), validatorsFile.String()))
+		}
+
+		if config.SigningParallelism < 1 {
+			//nolint:revive // we use our own version of the errors package.
+			return errors.New(fmt.Sprintf("%s must be at least 1.", signingParallelism.String()))
+		}
+
 		err := eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
 		if err != nil {
 			return err
@@ -146,6 +172,10 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
 	}
 
+	if err := verifyExitNetwork(ctx, eth2Cl, cl.GetForkVersion(), config.ForceNetwork); err != nil {
+		return err
+	}
+
 	if config.ValidatorIndexPresent {
 		ctx = log.WithCtx(ctx, z.U64("validator_index", config.ValidatorIndex))
 	}
@@ -160,10 +190,20 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 
 	var exitBlobs []obolapi.ExitBlob
 	if config.All {
-		exitBlobs, err = signAllValidatorsExits(ctx, config, eth2Cl, shares)
+		exitBlobs, err = signValidatorsExits(ctx, config, eth2Cl, shares)
 		if err != nil {
 			return errors.Wrap(err, "sign exits for all validators")
 		}
+	} else if config.ValidatorsFilePath != "" {
+		selected, err := selectValidatorShares(config.ValidatorsFilePath, shares)
+		if err != nil {
+			return err
+		}
+
+		exitBlobs, err = signValidatorsExits(ctx, config, eth2Cl, selected)
+		if err != nil {
+			return errors.Wrap(err, "sign exits for validators file", z.Str("validators_file", config.ValidatorsFilePath))
+		}
 	} else {
 		exitBlobs, err = signSingleValidatorExit(ctx, config, eth2Cl, shares)
 		if err != nil {
@@ -171,6 +211,15 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 		}
 	}
 
+	if config.PartialExitsOutputPath != "" {
+		artifact := newExitArtifact(cl.GetInitialMutationHash(), config.ExitEpoch, shareIdx, exitBlobs)
+		if err := writeExitArtifact(config.PartialExitsOutputPath, artifact); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Stored signed partial exit messages", z.Str("partial_exits_output_file", config.PartialExitsOutputPath), z.Int("count", len(exitBlobs)))
+	}
+
 	if err := oAPI.PostPartialExits(ctx, cl.GetInitialMutationHash(), shareIdx, identityKey, exitBlobs...); err != nil {
 		return errors.Wrap(err, "http POST partial exit message to Obol API")
 	}
@@ -178,6 +227,47 @@ func runSignPartialExit(ctx context.Context, config exitConfig) error {
 	return nil
 }
 
+// selectValidatorShares returns the key shares of the validators listed in the validators file.
+// The file contains one validator public key per line, empty lines and lines starting with '#' are ignored.
+func selectValidatorShares(path string, shares keystore.ValidatorShares) (keystore.ValidatorShares, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read validators file", z.Str("validators_file", path))
+	}
+
+	selected := make(keystore.ValidatorShares)
+
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pkBytes, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode validator public key", z.Str("validator_public_key", line))
+		}
+
+		pubkey, err := core.PubKeyFromBytes(pkBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator public key", z.Str("validator_public_key", line))
+		}
+
+		share, ok := shares[pubkey]
+		if !ok {
+			return nil, errors.New("validator not present in cluster lock", z.Str("validator_public_key", line))
+		}
+
+		selected[pubkey] = share
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no validators in validators file", z.Str("validators_file", path))
+	}
+
+	return selected, nil
+}
+
 func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
 	valEth2, err := fetchValidatorBLSPubKey(ctx, config, eth2Cl)
 	if err != nil {
@@ -211,7 +301,8 @@ func signSingleValidatorExit(ctx context.Context, config exitConfig, eth2Cl eth2
 	}, nil
 }
 
-func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
+// signValidatorsExits signs partial exit messages for all the provided validator shares in parallel.
+func signValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client, shares keystore.ValidatorShares) ([]obolapi.ExitBlob, error) {
 	var valsEth2 []eth2p0.BLSPubKey
 
 	for pk := range shares {
@@ -230,7 +321,9 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 
 	for _, val := range rawValData.Data {
 		share, ok := shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)]
-		if !ok {
+		if !ok && config.ValidatorsFilePath != "" {
+			continue // Only a subset of the cluster's validators is exited.
+		} else if !ok {
 			return nil, errors.New("validator public key not found in cluster lock", z.Str("validator_public_key", val.Validator.PublicKey.String()))
 		}
 
@@ -238,33 +331,79 @@ func signAllValidatorsExits(ctx context.Context, config exitConfig, eth2Cl eth2w
 		shares[core.PubKeyFrom48Bytes(val.Validator.PublicKey)] = share
 	}
 
-	log.Info(ctx, "Signing partial exit message for all active validators")
+	log.Info(ctx, "Signing partial exit messages for validators", z.Int("count", len(shares)), z.Int("parallelism", config.SigningParallelism))
 
-	var exitBlobs []obolapi.ExitBlob
+	var (
+		mu        sync.Mutex
+		exitBlobs []obolapi.ExitBlob
+		progress  = newExitProgress(len(shares))
+	)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(config.SigningParallelism, 1))
 
 	for pk, share := range shares {
-		exitMsg, err := signExit(ctx, eth2Cl, eth2p0.ValidatorIndex(share.Index), share.Share, eth2p0.Epoch(config.ExitEpoch))
-		if err != nil {
-			return nil, errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index), z.Int("exit_epoch", int(config.ExitEpoch)))
-		}
+		eg.Go(func() error {
+			exitMsg, err := signExit(egCtx, eth2Cl, eth2p0.ValidatorIndex(share.Index), share.Share, eth2p0.Epoch(config.ExitEpoch))
+			if err != nil {
+				return errors.Wrap(err, "sign partial exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index), z.Int("exit_epoch", int(config.ExitEpoch)))
+			}
 
-		eth2PK, err := pk.ToETH2()
-		if err != nil {
-			return nil, errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("core_pubkey", pk.String()))
-		}
+			eth2PK, err := pk.ToETH2()
+			if err != nil {
+				return errors.Wrap(err, "convert core pubkey to eth2 pubkey", z.Str("core_pubkey", pk.String()))
+			}
 
-		exitBlob := obolapi.ExitBlob{
-			PublicKey:         eth2PK.String(),
-			SignedExitMessage: exitMsg,
-		}
-		exitBlobs = append(exitBlobs, exitBlob)
+			mu.Lock()
+			exitBlobs = append(exitBlobs, obolapi.ExitBlob{
+				PublicKey:         eth2PK.String(),
+				SignedExitMessage: exitMsg,
+			})
+			mu.Unlock()
+
+			log.Debug(ctx, "Successfully signed exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index))
+			progress.Signed(ctx)
 
-		log.Info(ctx, "Successfully signed exit message", z.Str("validator_public_key", pk.String()), z.Int("validator_index", share.Index))
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
 	}
 
 	return exitBlobs, nil
 }
 
+// newExitProgress returns a new progress tracker for signing the provided number of exits.
+func newExitProgress(total int) *exitProgress {
+	return &exitProgress{
+		total: total,
+		step:  max(total/10, 1),
+	}
+}
+
+// exitProgress logs the progress of signing many exits, roughly every 10%.
+type exitProgress struct {
+	mu     sync.Mutex
+	total  int
+	step   int
+	signed int
+}
+
+// Signed records a signed exit, logging the progress if a step was completed.
+func (p *exitProgress) Signed(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.signed++
+	if p.signed%p.step != 0 && p.signed != p.total {
+		return
+	}
+
+	log.Info(ctx, fmt.Sprintf("Signed %d/%d partial exit messages", p.signed, p.total))
+}
+
 func fetchValidatorBLSPubKey(ctx context.Context, config exitConfig, eth2Cl eth2wrap.Client) (eth2p0.BLSPubKey, error) {
 	if config.ValidatorPubkey != "" {
 		valEth2, err := core.PubKey(config.ValidatorPubkey).ToETH2()
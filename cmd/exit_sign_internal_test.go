@@ -131,13 +131,42 @@ func Test_runSubmitPartialExit(t *testing.T) {
 	t.Run("main flow with all mode", func(t *testing.T) {
 		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", true)
 	})
+	t.Run("main flow with validators file", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "partial-exits.json")
+
+		runSubmitPartialExitFlowTest(t, false, false, "", 0, "", false, func(config *exitConfig, lock cluster.Lock) {
+			validatorsPath := filepath.Join(t.TempDir(), "validators.txt")
+			content := "# validators to exit\n" + lock.Validators[1].PublicKeyHex() + "\n\n" + lock.Validators[2].PublicKeyHex() + "\n"
+			require.NoError(t, os.WriteFile(validatorsPath, []byte(content), 0o644))
+
+			config.ValidatorPubkey = ""
+			config.ValidatorsFilePath = validatorsPath
+			config.SigningParallelism = 2
+			config.PartialExitsOutputPath = outputPath
+		})
+
+		b, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+
+		var artifact exitArtifact
+		require.NoError(t, json.Unmarshal(b, &artifact))
+		require.Equal(t, exitArtifactVersion, artifact.Version)
+		require.Len(t, artifact.Validators, 2)
+
+		for _, val := range artifact.Validators {
+			require.Len(t, val.PartialSignatures, 1)
+		}
+	})
 
 	t.Run("config", Test_runSubmitPartialExit_Config)
 }
 
-func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCheck bool, valPubkey string, valIndex uint64, errString string, all bool) {
+func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCheck bool, valPubkey string, valIndex uint64, errString string, all bool, configure ...func(*exitConfig, cluster.Lock)) {
 	t.Helper()
-	t.Parallel()
+
+	if len(configure) == 0 {
+		t.Parallel()
+	}
 
 	ctx := context.Background()
 
@@ -207,6 +236,7 @@ func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCh
 
 	config := exitConfig{
 		BeaconNodeEndpoints: []string{beaconMock.Address()},
+		ForceNetwork:        true,
 		PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 		ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
 		LockFilePath:        filepath.Join(baseDir, "cluster-lock.json"),
@@ -242,6 +272,10 @@ func runSubmitPartialExitFlowTest(t *testing.T, useValIdx bool, skipBeaconNodeCh
 		}
 	}
 
+	for _, fn := range configure {
+		fn(&config, lock)
+	}
+
 	if errString != "" {
 		require.ErrorContains(t, runSignPartialExit(ctx, config), errString)
 		return
@@ -379,6 +413,7 @@ func Test_runSubmitPartialExit_Config(t *testing.T) {
 
 			config := exitConfig{
 				BeaconNodeEndpoints: []string{bnURL},
+				ForceNetwork:        true,
 				ValidatorPubkey:     valAddr,
 				PrivateKeyPath:      filepath.Join(baseDir, "charon-enr-private-key"),
 				ValidatorKeysDir:    filepath.Join(baseDir, "validator_keys"),
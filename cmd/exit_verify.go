@@ -0,0 +1,148 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+func newVerifyExitCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify exit artifacts before broadcasting",
+		Long:  `Merges one or more exit artifacts containing partial exit signatures and verifies, for each validator, that all partial signatures are valid and that the cluster threshold is satisfied, before the exits are aggregated and broadcast.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitFlags(cmd, &config, []exitCLIFlag{
+		{artifactFiles, true},
+		{lockFilePath, false},
+		{beaconNodeEndpoints, true},
+		{forceNetwork, false},
+		{beaconNodeTimeout, false},
+		{partialExitsOutput, false},
+		{testnetName, false},
+		{testnetForkVersion, false},
+		{testnetChainID, false},
+		{testnetGenesisTimestamp, false},
+		{testnetCapellaHardFork, false},
+		{beaconNodeHeaders, false},
+		{fallbackBeaconNodeAddrs, false},
+	})
+
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	wrapPreRunE(cmd, func(*cobra.Command, []string) error {
+		return eth2util.ValidateBeaconNodeHeaders(config.BeaconNodeHeaders)
+	})
+
+	return cmd
+}
+
+func runVerifyExit(ctx context.Context, config exitConfig) error {
+	// Check if custom testnet configuration is provided.
+	if config.testnetConfig.IsNonZero() {
+		// Add testnet config to supported networks.
+		eth2util.AddTestNetwork(config.testnetConfig)
+	}
+
+	cl, err := loadClusterManifest("", config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "load cluster lock", z.Str("lock_file_path", config.LockFilePath))
+	}
+
+	var artifacts []exitArtifact
+	for _, path := range config.ArtifactFilePaths {
+		artifact, err := loadExitArtifact(path)
+		if err != nil {
+			return err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	merged, err := mergeExitArtifacts(artifacts...)
+	if err != nil {
+		return err
+	}
+
+	beaconNodeHeaders, err := eth2util.ParseBeaconNodeHeaders(config.BeaconNodeHeaders)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := eth2Client(ctx, config.FallbackBeaconNodeAddrs, beaconNodeHeaders, config.BeaconNodeEndpoints, config.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", config.BeaconNodeEndpoints))
+	}
+
+	if err := verifyExitNetwork(ctx, eth2Cl, cl.GetForkVersion(), config.ForceNetwork); err != nil {
+		return err
+	}
+
+	results, err := verifyExitArtifact(ctx, eth2Cl, cl, merged)
+	if err != nil {
+		return err
+	}
+
+	var incomplete int
+	for _, result := range results {
+		valCtx := log.WithCtx(ctx, z.Str("validator_public_key", result.PublicKey))
+
+		switch {
+		case result.Err != nil:
+			incomplete++
+			log.Error(valCtx, "Invalid exit artifact entry", result.Err)
+		case !result.Complete:
+			incomplete++
+			log.Warn(valCtx, "Exit threshold not satisfied", nil,
+				z.Int("partial_signatures", result.ValidSigs),
+				z.U64("threshold", uint64(cl.GetThreshold())),
+				z.Any("missing_share_indices", result.MissingShares),
+			)
+		default:
+			log.Info(valCtx, "Exit verified",
+				z.Int("partial_signatures", result.ValidSigs),
+				z.Any("missing_share_indices", result.MissingShares),
+			)
+		}
+	}
+
+	if config.PartialExitsOutputPath != "" {
+		if err := writeExitArtifact(config.PartialExitsOutputPath, merged); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "Stored merged exit artifact", z.Str("partial_exits_output_file", config.PartialExitsOutputPath))
+	}
+
+	if incomplete > 0 {
+		//nolint:revive // we use our own version of the errors package.
+		return errors.New(fmt.Sprintf("%d of %d validator exits are invalid or incomplete", incomplete, len(results)))
+	}
+
+	log.Info(ctx, "All validator exits verified and ready for broadcast", z.Int("validators", len(results)))
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestVerifyExit(t *testing.T) {
+	const (
+		valAmt      = 3
+		threshold   = 3
+		operatorAmt = 4
+		epoch       = 194048
+	)
+
+	ctx := context.Background()
+	random := rand.New(rand.NewSource(int64(0)))
+
+	lock, _, keyShares := cluster.NewForT(t, valAmt, threshold, operatorAmt, 0, random)
+
+	dag, err := manifest.NewDAGFromLockForT(t, lock)
+	require.NoError(t, err)
+	cl, err := manifest.Materialise(dag)
+	require.NoError(t, err)
+
+	validatorSet := beaconmock.ValidatorSet{}
+	for idx, v := range lock.Validators {
+		validatorSet[eth2p0.ValidatorIndex(idx)] = &eth2v1.Validator{
+			Index:  eth2p0.ValidatorIndex(idx),
+			Status: eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             eth2p0.BLSPubKey(v.PubKey),
+				WithdrawalCredentials: testutil.RandomBytes32(),
+			},
+		}
+	}
+
+	beaconMock, err := beaconmock.New(beaconmock.WithValidatorSet(validatorSet))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	eth2Cl, err := eth2Client(ctx, nil, nil, []string{beaconMock.Address()}, 10*time.Second, [4]byte(lock.ForkVersion))
+	require.NoError(t, err)
+
+	// Each operator signs partial exits of all validators.
+	artifacts := make([]exitArtifact, operatorAmt)
+	for opIdx := range operatorAmt {
+		var exitBlobs []obolapi.ExitBlob
+		for valIdx, v := range lock.Validators {
+			exit, err := signExit(ctx, eth2Cl, eth2p0.ValidatorIndex(valIdx), keyShares[valIdx][opIdx], epoch)
+			require.NoError(t, err)
+
+			exitBlobs = append(exitBlobs, obolapi.ExitBlob{
+				PublicKey:         v.PublicKeyHex(),
+				SignedExitMessage: exit,
+			})
+		}
+
+		artifacts[opIdx] = newExitArtifact(cl.GetInitialMutationHash(), epoch, uint64(opIdx+1), exitBlobs)
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		merged, err := mergeExitArtifacts(artifacts[0], artifacts[1], artifacts[3])
+		require.NoError(t, err)
+		require.Len(t, merged.Validators, valAmt)
+
+		results, err := verifyExitArtifact(ctx, eth2Cl, cl, merged)
+		require.NoError(t, err)
+		require.Len(t, results, valAmt)
+
+		for _, result := range results {
+			require.NoError(t, result.Err)
+			require.True(t, result.Complete)
+			require.Equal(t, threshold, result.ValidSigs)
+			require.Equal(t, []uint64{3}, result.MissingShares)
+		}
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		merged, err := mergeExitArtifacts(artifacts[0], artifacts[1])
+		require.NoError(t, err)
+
+		results, err := verifyExitArtifact(ctx, eth2Cl, cl, merged)
+		require.NoError(t, err)
+
+		for _, result := range results {
+			require.NoError(t, result.Err)
+			require.False(t, result.Complete)
+			require.Equal(t, []uint64{3, 4}, result.MissingShares)
+		}
+	})
+
+	t.Run("invalid partial signature", func(t *testing.T) {
+		// Claim operator 2's signatures as operator 3's.
+		wrongShare := artifacts[1]
+		wrongShare.Validators = cloneArtifactValidators(wrongShare.Validators)
+		for i := range wrongShare.Validators {
+			wrongShare.Validators[i].PartialSignatures[0].ShareIdx = 3
+		}
+
+		merged, err := mergeExitArtifacts(artifacts[0], wrongShare, artifacts[3])
+		require.NoError(t, err)
+
+		results, err := verifyExitArtifact(ctx, eth2Cl, cl, merged)
+		require.NoError(t, err)
+
+		for _, result := range results {
+			require.ErrorContains(t, result.Err, "invalid partial signature")
+			require.False(t, result.Complete)
+		}
+	})
+
+	t.Run("conflicting partial signatures", func(t *testing.T) {
+		conflicting := artifacts[1]
+		conflicting.Validators = cloneArtifactValidators(conflicting.Validators)
+		for i := range conflicting.Validators {
+			conflicting.Validators[i].PartialSignatures[0].ShareIdx = 1
+		}
+
+		_, err := mergeExitArtifacts(artifacts[0], conflicting)
+		require.ErrorContains(t, err, "conflicting partial signatures")
+	})
+
+	t.Run("mismatching epoch", func(t *testing.T) {
+		other := artifacts[1]
+		other.Epoch++
+
+		_, err := mergeExitArtifacts(artifacts[0], other)
+		require.ErrorContains(t, err, "mismatching exit artifact epoch")
+	})
+
+	t.Run("mismatching lock hash", func(t *testing.T) {
+		other := artifacts[0]
+		other.LockHash = fmt.Sprintf("%#x", testutil.RandomBytes32())
+
+		_, err := verifyExitArtifact(ctx, eth2Cl, cl, other)
+		require.ErrorContains(t, err, "exit artifact lock hash doesn't match cluster lock")
+	})
+
+	t.Run("run", func(t *testing.T) {
+		root := t.TempDir()
+
+		lockBytes, err := json.Marshal(lock)
+		require.NoError(t, err)
+
+		lockPath := filepath.Join(root, "cluster-lock.json")
+		require.NoError(t, os.WriteFile(lockPath, lockBytes, 0o644))
+
+		var paths []string
+		for i, artifact := range artifacts {
+			path := filepath.Join(root, fmt.Sprintf("artifact-%d.json", i))
+			require.NoError(t, writeExitArtifact(path, artifact))
+
+			paths = append(paths, path)
+		}
+
+		config := exitConfig{
+			LockFilePath:        lockPath,
+			BeaconNodeEndpoints: []string{beaconMock.Address()},
+			ForceNetwork:        true,
+			BeaconNodeTimeout:   10 * time.Second,
+		}
+
+		config.ArtifactFilePaths = paths[:2]
+		require.ErrorContains(t, runVerifyExit(ctx, config), "3 of 3 validator exits are invalid or incomplete")
+
+		config.ArtifactFilePaths = paths
+		config.PartialExitsOutputPath = filepath.Join(root, "merged.json")
+		require.NoError(t, runVerifyExit(ctx, config))
+
+		merged, err := loadExitArtifact(config.PartialExitsOutputPath)
+		require.NoError(t, err)
+
+		for _, val := range merged.Validators {
+			require.Len(t, val.PartialSignatures, operatorAmt)
+		}
+	})
+}
+
+func cloneArtifactValidators(vals []exitArtifactValidator) []exitArtifactValidator {
+	var resp []exitArtifactValidator
+	for _, val := range vals {
+		val.PartialSignatures = append([]exitArtifactPartial(nil), val.PartialSignatures...)
+		resp = append(resp, val)
+	}
+
+	return resp
+}
+
+func TestVerifyExitNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	beaconMock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, beaconMock.Close())
+	}()
+
+	goerli, err := eth2util.NetworkToForkVersionBytes(eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	eth2Cl, err := eth2Client(ctx, nil, nil, []string{beaconMock.Address()}, 10*time.Second, [4]byte(goerli))
+	require.NoError(t, err)
+
+	err = verifyExitNetwork(ctx, eth2Cl, goerli, false)
+	require.ErrorIs(t, err, eth2wrap.ErrNetworkMismatch)
+
+	err = verifyExitNetwork(ctx, eth2Cl, goerli, true)
+	require.NoError(t, err)
+
+	err = verifyExitNetwork(ctx, eth2Cl, []byte{0x01, 0x01, 0x70, 0x00}, false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus/qbft"
+)
+
+type explainLeaderConfig struct {
+	ManifestFilePath string
+	LockFilePath     string
+	Duty             string
+	Slot             uint64
+	Rounds           int64
+}
+
+func newExplainLeaderCmd(runFunc func(io.Writer, explainLeaderConfig) error) *cobra.Command {
+	var config explainLeaderConfig
+
+	cmd := &cobra.Command{
+		Use:   "explain-leader",
+		Short: "Explain the consensus leader selection of a duty",
+		Long: `Explains offline which cluster node led each consensus round of the specified duty. ` +
+			`Leader selection is deterministic, based only on the duty slot, duty type, round and number of nodes in the cluster.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ManifestFilePath, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	cmd.Flags().StringVar(&config.Duty, "duty", "", "The duty type to explain, e.g. proposer, attester, aggregator. [REQUIRED]")
+	cmd.Flags().Uint64Var(&config.Slot, "slot", 0, "The slot of the duty to explain. [REQUIRED]")
+	cmd.Flags().Int64Var(&config.Rounds, "rounds", 3, "The number of consensus rounds to explain.")
+
+	mustMarkFlagRequired(cmd, "duty")
+	mustMarkFlagRequired(cmd, "slot")
+
+	return cmd
+}
+
+func runExplainLeader(out io.Writer, config explainLeaderConfig) error {
+	typ, ok := core.DutyTypeFromString(config.Duty)
+	if !ok {
+		return errors.New("invalid duty type", z.Str("duty", config.Duty))
+	}
+
+	if config.Rounds < 1 {
+		return errors.New("rounds must be at least 1", z.I64("rounds", config.Rounds))
+	}
+
+	cl, err := loadClusterManifest(config.ManifestFilePath, config.LockFilePath)
+	if err != nil {
+		return err
+	}
+
+	peers, err := manifest.ClusterPeers(cl)
+	if err != nil {
+		return err
+	}
+
+	duty := core.Duty{Slot: config.Slot, Type: typ}
+	for _, selection := range qbft.ExplainLeaderRounds(duty, config.Rounds, peers) {
+		_, err := fmt.Fprintf(out, "duty=%s round=%d leader_index=%d leader_name=%s inputs=%q\n",
+			duty, selection.Round, selection.LeaderIndex, selection.LeaderName, selection.Formula)
+		if err != nil {
+			return errors.Wrap(err, "write leader selection")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestExplainLeader(t *testing.T) {
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, seed, random)
+
+	lockBytes, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockPath := filepath.Join(t.TempDir(), "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockPath, lockBytes, 0o644))
+
+	config := explainLeaderConfig{
+		LockFilePath: lockPath,
+		Duty:         "proposer",
+		Slot:         10,
+		Rounds:       2,
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runExplainLeader(&out, config))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "duty=10/proposer round=1 leader_index=0")
+	require.Contains(t, lines[1], "duty=10/proposer round=2 leader_index=1")
+
+	config.Duty = "invalid"
+	require.ErrorContains(t, runExplainLeader(&out, config), "invalid duty type")
+
+	config.Duty = "proposer"
+	config.Rounds = 0
+	require.ErrorContains(t, runExplainLeader(&out, config), "rounds must be at least 1")
+}
@@ -0,0 +1,527 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	libp2plog "github.com/ipfs/go-log/v2"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+type importValidatorsConfig struct {
+	ManifestFile      string
+	LockFile          string
+	KeystoresDir      string
+	FeeRecipientAddrs []string
+	WithdrawalAddrs   []string
+	OutputDir         string
+	GenValidatorsFile string
+	ValidatorKeysDir  string
+	PrivateKeyFile    string
+	ApprovalFiles     []string
+	OutputFile        string
+	Log               log.Config
+
+	BeaconNodeEndpoints    []string
+	BeaconNodeTimeout      time.Duration
+	SlashingProtectionFile string
+	WaitEpochs             uint64
+	ValidatorsStopped      bool
+}
+
+func newImportValidatorsCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "import-validators",
+		Short: "Import existing validators into a distributed validator cluster.",
+		Long: "Migrate existing (solo) validators into a distributed validator cluster without exiting them. " +
+			"The validator keys are split into key shares for all operators (split), each operator verifies its key shares " +
+			"and approves the import (approve), after which the approvals are combined into an updated cluster manifest (add).",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+// newImportValidatorsCmdFunc returns a new import validators subcommand binding the flags.
+func newImportValidatorsCmdFunc(use, short, long string, runFunc func(context.Context, io.Writer, importValidatorsConfig) error,
+	bindFlags func(cmd *cobra.Command, config *importValidatorsConfig),
+) *cobra.Command {
+	var config importValidatorsConfig
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+			libp2plog.SetPrimaryCore(log.LoggerCore()) // Set libp2p logger to use charon logger
+
+			printFlags(cmd.Context(), cmd.Flags())
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining the distributed validator cluster.")
+	bindFlags(cmd, &config)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func newImportValidatorsSplitCmd(runFunc func(context.Context, io.Writer, importValidatorsConfig) error) *cobra.Command {
+	return newImportValidatorsCmdFunc("split",
+		"Split existing validator keys into key shares of the cluster's operators.",
+		"Splits existing EIP-2335 validator keystores into threshold key shares for all operators of the cluster "+
+			"and creates the unsigned generate validators mutation that all operators need to approve.",
+		runFunc,
+		func(cmd *cobra.Command, config *importValidatorsConfig) {
+			cmd.Flags().StringVar(&config.KeystoresDir, "keystores-dir", "", "Directory containing the keys to import. Expects keys in keystore-*.json and passwords in keystore-*.txt.")
+			cmd.Flags().StringSliceVar(&config.FeeRecipientAddrs, "fee-recipient-addresses", nil, "Comma separated list of Ethereum addresses of the fee recipient for each validator. Either provide a single fee recipient address or fee recipient addresses for each validator.")
+			cmd.Flags().StringSliceVar(&config.WithdrawalAddrs, "withdrawal-addresses", nil, "Comma separated list of the existing Ethereum withdrawal addresses of each validator. Either provide a single withdrawal address or withdrawal addresses for each validator.")
+			cmd.Flags().StringVar(&config.OutputDir, "output-dir", "import-validators", "The directory to write the generate validators mutation and the key shares of each operator to.")
+			cmd.Flags().StringVar(&config.SlashingProtectionFile, "slashing-protection-file", "", "The EIP-3076 slashing protection interchange file exported from the stopped solo validator client. It is converted to the public shares of each operator.")
+			cmd.Flags().Uint64Var(&config.WaitEpochs, "wait-epochs", 2, "The number of epochs none of the validators may have signed in, according to the slashing protection interchange.")
+			cmd.Flags().BoolVar(&config.ValidatorsStopped, "confirm-validators-stopped", false, "Confirm the solo validator client of the validators is stopped and will not be restarted.")
+			bindImportBeaconNodeFlags(cmd, config)
+			mustMarkFlagRequired(cmd, "keystores-dir")
+			mustMarkFlagRequired(cmd, "slashing-protection-file")
+			mustMarkFlagRequired(cmd, "fee-recipient-addresses")
+			mustMarkFlagRequired(cmd, "withdrawal-addresses")
+		})
+}
+
+func newImportValidatorsApproveCmd(runFunc func(context.Context, io.Writer, importValidatorsConfig) error) *cobra.Command {
+	return newImportValidatorsCmdFunc("approve",
+		"Verify and approve the import of validators into the cluster.",
+		"Verifies that the operator's key shares match the generate validators mutation of the cluster "+
+			"and signs a node approval of the import with the operator's charon identity key.",
+		runFunc,
+		func(cmd *cobra.Command, config *importValidatorsConfig) {
+			cmd.Flags().StringVar(&config.GenValidatorsFile, "gen-validators-file", "import-validators/gen-validators.pb", "The path to the generate validators mutation created by the split command.")
+			cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", "", "Directory containing this operator's key shares of the imported validators.")
+			cmd.Flags().StringVar(&config.PrivateKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+			cmd.Flags().StringVar(&config.OutputFile, "output-file", "node-approval.pb", "The path to write the signed node approval to.")
+			bindImportBeaconNodeFlags(cmd, config)
+			mustMarkFlagRequired(cmd, "validator-keys-dir")
+		})
+}
+
+func newImportValidatorsAddCmd(runFunc func(context.Context, io.Writer, importValidatorsConfig) error) *cobra.Command {
+	return newImportValidatorsCmdFunc("add",
+		"Add the approved validators to the cluster manifest.",
+		"Combines the generate validators mutation and the node approvals of all operators into an add validators "+
+			"mutation and writes the resulting cluster manifest.",
+		runFunc,
+		func(cmd *cobra.Command, config *importValidatorsConfig) {
+			cmd.Flags().StringVar(&config.GenValidatorsFile, "gen-validators-file", "import-validators/gen-validators.pb", "The path to the generate validators mutation created by the split command.")
+			cmd.Flags().StringSliceVar(&config.ApprovalFiles, "approval-files", nil, "Comma separated list of the signed node approvals of all operators.")
+			cmd.Flags().StringVar(&config.OutputFile, "output-file", "cluster-manifest.pb", "The path to write the updated cluster manifest to.")
+			mustMarkFlagRequired(cmd, "approval-files")
+		})
+}
+
+// bindImportBeaconNodeFlags binds the beacon node flags used to verify the validators to import.
+func bindImportBeaconNodeFlags(cmd *cobra.Command, config *importValidatorsConfig) {
+	cmd.Flags().StringSliceVar(&config.BeaconNodeEndpoints, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs used to verify the validators to import.")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 30*time.Second, "Timeout for beacon node HTTP calls.")
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+}
+
+// runImportValidatorsSplit splits the keys to import into key shares for all operators
+// and writes the generate validators mutation. The solo validator client must be stopped and its slashing protection
+// history is converted to the key shares, since the cluster signs for the same validators.
+func runImportValidatorsSplit(ctx context.Context, w io.Writer, conf importValidatorsConfig) error {
+	if !conf.ValidatorsStopped {
+		return errors.New("stop the solo validator client and confirm with --confirm-validators-stopped, running both results in slashing")
+	}
+
+	dag, cl, err := loadImportCluster(conf)
+	if err != nil {
+		return err
+	}
+
+	interchange, err := loadSlashingInterchange(conf.SlashingProtectionFile)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := eth2Client(ctx, nil, nil, conf.BeaconNodeEndpoints, conf.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", conf.BeaconNodeEndpoints))
+	}
+
+	secrets, err := getKeys(conf.KeystoresDir, false)
+	if err != nil {
+		return err
+	}
+
+	feeRecipients, withdrawalAddrs, err := validateAddresses(len(secrets), conf.FeeRecipientAddrs, conf.WithdrawalAddrs)
+	if err != nil {
+		return err
+	}
+
+	numNodes := len(cl.GetOperators())
+
+	pubkeys, shareSets, err := getTSSShares(secrets, int(cl.GetThreshold()), numNodes)
+	if err != nil {
+		return err
+	}
+
+	if err := verifySoloValidatorsStopped(ctx, eth2Cl, interchange, pubkeys, conf.WaitEpochs); err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, val := range cl.GetValidators() {
+		existing[hex.EncodeToString(val.GetPublicKey())] = true
+	}
+
+	regs, err := createValidatorRegistrations(ctx, feeRecipients, secrets, cl.GetForkVersion(), true, uint(cl.GetTargetGasLimit()))
+	if err != nil {
+		return err
+	}
+
+	var vals []*manifestpb.Validator
+	for i, pubkey := range pubkeys {
+		if existing[hex.EncodeToString(pubkey[:])] {
+			return errors.New("validator already part of the cluster", z.Str("pubkey", fmt.Sprintf("%#x", pubkey)))
+		}
+
+		var pubshares [][]byte
+		for _, share := range shareSets[i] {
+			pubshare, err := tbls.SecretToPublicKey(share)
+			if err != nil {
+				return err
+			}
+
+			pubshares = append(pubshares, pubshare[:])
+		}
+
+		regJSON, err := json.Marshal(regs[i].VersionedSignedValidatorRegistration)
+		if err != nil {
+			return errors.Wrap(err, "marshal builder registration")
+		}
+
+		vals = append(vals, &manifestpb.Validator{
+			PublicKey:               pubkey[:],
+			PubShares:               pubshares,
+			FeeRecipientAddress:     feeRecipients[i],
+			WithdrawalAddress:       withdrawalAddrs[i],
+			BuilderRegistrationJson: regJSON,
+		})
+	}
+
+	if err := verifyWithdrawalCredentials(ctx, eth2Cl, vals); err != nil {
+		return err
+	}
+
+	genVals, err := manifest.NewGenValidators(cl.GetLatestMutationHash(), vals)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(conf.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir", z.Str("dir", conf.OutputDir))
+	}
+
+	for i := range numNodes {
+		if err := os.MkdirAll(nodeDir(conf.OutputDir, i), 0o755); err != nil {
+			return errors.Wrap(err, "create node dir")
+		}
+	}
+
+	if err := writeKeysToDisk(numNodes, conf.OutputDir, false, shareSets); err != nil {
+		return err
+	}
+
+	if err := writeShareInterchanges(conf.OutputDir, interchange, vals); err != nil {
+		return err
+	}
+
+	genValsFile := filepath.Join(conf.OutputDir, "gen-validators.pb")
+	if err := writeProtoFile(genValsFile, genVals); err != nil {
+		return err
+	}
+
+	writeWarning(w)
+
+	_, _ = fmt.Fprintf(w, "Split %d validators into key shares of %d operators (cluster %s, %d existing mutations)\n\n",
+		len(vals), numNodes, hex.EncodeToString(cl.GetInitialMutationHash()), len(dag.GetMutations()))
+	_, _ = fmt.Fprintf(w, "Distribute %s, the key shares in %s and the slashing protection in %s to the operator of node N, who approves the import with:\n",
+		genValsFile, nodeDir(conf.OutputDir, 0)+"/validator_keys", nodeDir(conf.OutputDir, 0)+"/slashing-protection.json")
+	_, _ = fmt.Fprintf(w, "  charon alpha import-validators approve --gen-validators-file=%s --validator-keys-dir=<node key shares> --beacon-node-endpoints=<beacon nodes>\n\n", genValsFile)
+	_, _ = fmt.Fprintf(w, "Operators must import the slashing protection into their validator clients before adding the key shares.\n\n")
+	_, _ = fmt.Fprintf(w, "Then combine all node approvals with:\n")
+	_, _ = fmt.Fprintf(w, "  charon alpha import-validators add --gen-validators-file=%s --approval-files=<approvals>\n", genValsFile)
+
+	return nil
+}
+
+// runImportValidatorsApprove verifies the operator's key shares of the validators to import and signs a node approval.
+// The public shares must recombine to the validators' public keys and the withdrawal addresses must match the
+// validators' withdrawal credentials on the beacon node.
+func runImportValidatorsApprove(ctx context.Context, w io.Writer, conf importValidatorsConfig) error {
+	_, cl, err := loadImportCluster(conf)
+	if err != nil {
+		return err
+	}
+
+	genVals, vals, err := loadGenValidators(conf.GenValidatorsFile, cl)
+	if err != nil {
+		return err
+	}
+
+	eth2Cl, err := eth2Client(ctx, nil, nil, conf.BeaconNodeEndpoints, conf.BeaconNodeTimeout, [4]byte(cl.GetForkVersion()))
+	if err != nil {
+		return errors.Wrap(err, "create eth2 client for specified beacon node(s)", z.Any("beacon_nodes_endpoints", conf.BeaconNodeEndpoints))
+	}
+
+	if err := verifyWithdrawalCredentials(ctx, eth2Cl, vals); err != nil {
+		return err
+	}
+
+	key, err := k1util.Load(conf.PrivateKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "load identity key", z.Str("private_key_file", conf.PrivateKeyFile))
+	}
+
+	shareIdx, err := keystore.ShareIdxForCluster(cl, *key.PubKey())
+	if err != nil {
+		return err
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(conf.ValidatorKeysDir)
+	if err != nil {
+		return err
+	}
+
+	// Ensure this operator holds the key share of every imported validator.
+	pubshares := make(map[tbls.PublicKey]bool)
+	for _, share := range keyFiles.Keys() {
+		pubshare, err := tbls.SecretToPublicKey(share)
+		if err != nil {
+			return err
+		}
+
+		pubshares[pubshare] = true
+	}
+
+	for _, val := range vals {
+		if len(val.GetPubShares()) != len(cl.GetOperators()) {
+			return errors.New("invalid number of validator public shares", z.Str("pubkey", fmt.Sprintf("%#x", val.GetPublicKey())))
+		}
+
+		if err := verifyPubShares(val, int(cl.GetThreshold())); err != nil {
+			return err
+		}
+
+		if !pubshares[tbls.PublicKey(val.GetPubShares()[shareIdx-1])] {
+			return errors.New("key share of imported validator not found", z.Str("pubkey", fmt.Sprintf("%#x", val.GetPublicKey())),
+				z.Str("validator_keys_dir", conf.ValidatorKeysDir))
+		}
+
+		_, _ = fmt.Fprintf(w, "Approving validator %#x (fee recipient %s, withdrawal address %s)\n",
+			val.GetPublicKey(), val.GetFeeRecipientAddress(), val.GetWithdrawalAddress())
+	}
+
+	genHash, err := manifest.Hash(genVals)
+	if err != nil {
+		return err
+	}
+
+	approval, err := manifest.SignNodeApproval(genHash, key)
+	if err != nil {
+		return err
+	}
+
+	if err := writeProtoFile(conf.OutputFile, approval); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Signed node approval of imported validators",
+		z.Int("validators", len(vals)), z.U64("share_index", shareIdx), z.Str("output_file", conf.OutputFile))
+
+	return nil
+}
+
+// runImportValidatorsAdd combines the generate validators mutation with the node approvals of all operators
+// and writes the resulting cluster manifest.
+func runImportValidatorsAdd(ctx context.Context, _ io.Writer, conf importValidatorsConfig) error {
+	dag, cl, err := loadImportCluster(conf)
+	if err != nil {
+		return err
+	}
+
+	genVals, vals, err := loadGenValidators(conf.GenValidatorsFile, cl)
+	if err != nil {
+		return err
+	}
+
+	peers, err := manifest.ClusterPeers(cl)
+	if err != nil {
+		return err
+	}
+
+	// Order the node approvals by peer index.
+	approvals := make([]*manifestpb.SignedMutation, len(peers))
+	for _, file := range conf.ApprovalFiles {
+		approval := new(manifestpb.SignedMutation)
+		if err := readProtoFile(file, approval); err != nil {
+			return err
+		}
+
+		found := false
+		for i, p := range peers {
+			pubkey, err := p.PublicKey()
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(pubkey.SerializeCompressed(), approval.GetSigner()) {
+				approvals[i] = approval
+				found = true
+			}
+		}
+
+		if !found {
+			return errors.New("node approval not signed by cluster operator", z.Str("file", file))
+		}
+	}
+
+	for i, approval := range approvals {
+		if approval == nil {
+			return errors.New("missing node approval", z.Int("peer_index", i), z.Str("peer", peers[i].Name))
+		}
+	}
+
+	nodeApprovals, err := manifest.NewNodeApprovalsComposite(approvals)
+	if err != nil {
+		return err
+	}
+
+	addVals, err := manifest.NewAddValidators(genVals, nodeApprovals)
+	if err != nil {
+		return err
+	}
+
+	dag.Mutations = append(dag.Mutations, addVals)
+
+	updated, err := manifest.Materialise(dag)
+	if err != nil {
+		return errors.Wrap(err, "materialise updated cluster manifest")
+	}
+
+	if err := writeProtoFile(conf.OutputFile, dag); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Imported validators into cluster manifest",
+		z.Int("imported", len(vals)), z.Int("validators", len(updated.GetValidators())), z.Str("output_file", conf.OutputFile))
+
+	return nil
+}
+
+// loadImportCluster returns the raw cluster DAG and the cluster manifest to import validators into.
+func loadImportCluster(conf importValidatorsConfig) (*manifestpb.SignedMutationList, *manifestpb.Cluster, error) {
+	verifyLock := func(lock cluster.Lock) error {
+		if err := lock.VerifyHashes(); err != nil {
+			return errors.Wrap(err, "cluster lock hash verification failed")
+		}
+
+		if err := lock.VerifySignatures(nil); err != nil {
+			return errors.Wrap(err, "cluster lock signature verification failed")
+		}
+
+		return nil
+	}
+
+	dag, err := manifest.LoadDAG(conf.ManifestFile, conf.LockFile, verifyLock)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "load cluster dag from disk")
+	}
+
+	cl, err := manifest.Materialise(dag)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "materialise cluster dag")
+	}
+
+	return dag, cl, nil
+}
+
+// loadGenValidators returns the generate validators mutation and its validators,
+// ensuring the mutation applies to the cluster.
+func loadGenValidators(file string, cl *manifestpb.Cluster) (*manifestpb.SignedMutation, []*manifestpb.Validator, error) {
+	genVals := new(manifestpb.SignedMutation)
+	if err := readProtoFile(file, genVals); err != nil {
+		return nil, nil, err
+	}
+
+	if manifest.MutationType(genVals.GetMutation().GetType()) != manifest.TypeGenValidators {
+		return nil, nil, errors.New("invalid generate validators mutation type", z.Str("type", genVals.GetMutation().GetType()))
+	}
+
+	if !bytes.Equal(genVals.GetMutation().GetParent(), cl.GetLatestMutationHash()) {
+		return nil, nil, errors.New("generate validators mutation not based on the latest cluster state")
+	}
+
+	list := new(manifestpb.ValidatorList)
+	if err := genVals.GetMutation().GetData().UnmarshalTo(list); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal validators")
+	}
+
+	return genVals, list.GetValidators(), nil
+}
+
+// writeProtoFile writes the protobuf message to the file.
+func writeProtoFile(file string, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal proto")
+	}
+
+	if err := os.WriteFile(file, b, 0o644); err != nil { //nolint:gosec // Public data.
+		return errors.Wrap(err, "write file", z.Str("file", file))
+	}
+
+	return nil
+}
+
+// readProtoFile reads the protobuf message from the file.
+func readProtoFile(file string, msg proto.Message) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrap(err, "read file", z.Str("file", file))
+	}
+
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return errors.Wrap(err, "unmarshal proto", z.Str("file", file))
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestImportValidators(t *testing.T) {
+	const numNodes = 4
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	seed := 1
+	random := rand.New(rand.NewSource(int64(seed)))
+	lock, p2pKeys, _ := cluster.NewForT(t, 1, 3, numNodes, seed, random)
+
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, lockJSON, 0o644))
+
+	// Store the existing validator keys to import.
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	keystoresDir := filepath.Join(dir, "keystores")
+	require.NoError(t, os.Mkdir(keystoresDir, 0o755))
+	require.NoError(t, keystore.StoreKeysInsecure([]tbls.PrivateKey{secret}, keystoresDir, keystore.ConfirmInsecureKeys))
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	// The beacon node knows the existing validator with execution withdrawal credentials.
+	withdrawalAddr := testutil.RandomChecksummedETHAddress(t, seed)
+	newBeaconMock := func(withdrawalAddr string) string {
+		t.Helper()
+
+		creds := append([]byte{0x01}, make([]byte, 11)...)
+		creds = append(creds, mustDecodeHex(t, withdrawalAddr)...)
+
+		bmock, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSet{
+			1: {
+				Index:  1,
+				Status: eth2v1.ValidatorStateActiveOngoing,
+				Validator: &eth2p0.Validator{
+					PublicKey:             eth2p0.BLSPubKey(pubkey),
+					WithdrawalCredentials: creds,
+				},
+			},
+		}))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, bmock.Close()) })
+
+		return bmock.Address()
+	}
+	bnAddr := newBeaconMock(withdrawalAddr)
+
+	genesis, err := newTestEth2Client(t, bnAddr).Genesis(ctx, &eth2api.GenesisOpts{})
+	require.NoError(t, err)
+
+	// Export the slashing protection history of the stopped solo validator client.
+	writeInterchange := func(targetEpoch uint64) string {
+		t.Helper()
+
+		var interchange slashingInterchange
+		interchange.Metadata.InterchangeFormatVersion = interchangeFormatVersion
+		interchange.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", genesis.Data.GenesisValidatorsRoot)
+		interchange.Data = []interchangeValidator{{
+			PubKey:             fmt.Sprintf("%#x", pubkey),
+			SignedBlocks:       []interchangeBlock{{Slot: 10}},
+			SignedAttestations: []interchangeAttestation{{SourceEpoch: targetEpoch - 1, TargetEpoch: targetEpoch}},
+		}}
+
+		b, err := json.Marshal(interchange)
+		require.NoError(t, err)
+
+		file := filepath.Join(dir, fmt.Sprintf("slashing-protection-%d.json", targetEpoch))
+		require.NoError(t, os.WriteFile(file, b, 0o644))
+
+		return file
+	}
+
+	conf := importValidatorsConfig{
+		ManifestFile:           filepath.Join(dir, "cluster-manifest.pb"),
+		LockFile:               lockFile,
+		KeystoresDir:           keystoresDir,
+		FeeRecipientAddrs:      []string{testutil.RandomChecksummedETHAddress(t, seed)},
+		WithdrawalAddrs:        []string{withdrawalAddr},
+		OutputDir:              filepath.Join(dir, "import-validators"),
+		GenValidatorsFile:      filepath.Join(dir, "import-validators", "gen-validators.pb"),
+		BeaconNodeEndpoints:    []string{bnAddr},
+		BeaconNodeTimeout:      time.Second,
+		SlashingProtectionFile: writeInterchange(1),
+		WaitEpochs:             2,
+		ValidatorsStopped:      true,
+	}
+
+	// The solo validator client must be stopped.
+	unconfirmedConf := conf
+	unconfirmedConf.ValidatorsStopped = false
+	require.ErrorContains(t, runImportValidatorsSplit(ctx, io.Discard, unconfirmedConf), "stop the solo validator client")
+
+	// Validators that signed recently are rejected.
+	recentConf := conf
+	recentConf.SlashingProtectionFile = writeInterchange(1 << 40)
+	require.ErrorContains(t, runImportValidatorsSplit(ctx, io.Discard, recentConf), "validator signed too recently")
+
+	// Withdrawal addresses must match the withdrawal credentials.
+	wrongAddrConf := conf
+	wrongAddrConf.WithdrawalAddrs = []string{testutil.RandomChecksummedETHAddress(t, seed+1)}
+	require.ErrorContains(t, runImportValidatorsSplit(ctx, io.Discard, wrongAddrConf), "withdrawal address doesn't match")
+
+	var out bytes.Buffer
+	require.NoError(t, runImportValidatorsSplit(ctx, &out, conf))
+	require.Contains(t, out.String(), "Split 1 validators into key shares of 4 operators")
+
+	_, vals, err := loadGenValidators(conf.GenValidatorsFile, mustLoadImportCluster(t, conf))
+	require.NoError(t, err)
+
+	// Each node's slashing protection history is keyed by its public share.
+	for i := range numNodes {
+		interchange, err := loadSlashingInterchange(filepath.Join(nodeDir(conf.OutputDir, i), "slashing-protection.json"))
+		require.NoError(t, err)
+		require.Len(t, interchange.Data, 1)
+		require.Equal(t, fmt.Sprintf("%#x", vals[0].GetPubShares()[i]), interchange.Data[0].PubKey)
+		require.Len(t, interchange.Data[0].SignedAttestations, 1)
+	}
+
+	// Public shares that don't recombine to the public key are rejected.
+	vals[0].PubShares[0], vals[0].PubShares[1] = vals[0].PubShares[1], vals[0].PubShares[0]
+	tampered, err := manifest.NewGenValidators(mustLoadImportCluster(t, conf).GetLatestMutationHash(), vals)
+	require.NoError(t, err)
+
+	tamperedConf := conf
+	tamperedConf.GenValidatorsFile = filepath.Join(dir, "tampered-gen-validators.pb")
+	require.NoError(t, writeProtoFile(tamperedConf.GenValidatorsFile, tampered))
+
+	// Each operator approves the import of its key shares.
+	for i, key := range p2pKeys {
+		approveConf := conf
+		approveConf.ValidatorKeysDir = filepath.Join(nodeDir(conf.OutputDir, i), "validator_keys")
+		approveConf.PrivateKeyFile = filepath.Join(dir, fmt.Sprintf("charon-enr-private-key-%d", i))
+		approveConf.OutputFile = filepath.Join(dir, fmt.Sprintf("node-approval-%d.pb", i))
+		require.NoError(t, k1util.Save(key, approveConf.PrivateKeyFile))
+
+		if i == 0 {
+			// Key shares of another operator are rejected.
+			wrongConf := approveConf
+			wrongConf.ValidatorKeysDir = filepath.Join(nodeDir(conf.OutputDir, 1), "validator_keys")
+			require.ErrorContains(t, runImportValidatorsApprove(ctx, io.Discard, wrongConf), "key share of imported validator not found")
+
+			tamperedApproveConf := approveConf
+			tamperedApproveConf.GenValidatorsFile = tamperedConf.GenValidatorsFile
+			require.ErrorContains(t, runImportValidatorsApprove(ctx, io.Discard, tamperedApproveConf), "don't recombine to the public key")
+
+			// Withdrawal credentials are verified against the operator's beacon node.
+			wrongBNConf := approveConf
+			wrongBNConf.BeaconNodeEndpoints = []string{newBeaconMock(testutil.RandomChecksummedETHAddress(t, seed+1))}
+			require.ErrorContains(t, runImportValidatorsApprove(ctx, io.Discard, wrongBNConf), "withdrawal address doesn't match")
+		}
+
+		require.NoError(t, runImportValidatorsApprove(ctx, io.Discard, approveConf))
+		conf.ApprovalFiles = append(conf.ApprovalFiles, approveConf.OutputFile)
+	}
+
+	// All operators must approve the import.
+	missingConf := conf
+	missingConf.ApprovalFiles = conf.ApprovalFiles[1:]
+	missingConf.OutputFile = filepath.Join(dir, "missing-manifest.pb")
+	require.ErrorContains(t, runImportValidatorsAdd(ctx, io.Discard, missingConf), "missing node approval")
+
+	conf.OutputFile = conf.ManifestFile
+	require.NoError(t, runImportValidatorsAdd(ctx, io.Discard, conf))
+
+	cl := mustLoadImportCluster(t, conf)
+	require.Len(t, cl.GetValidators(), 2)
+	require.Equal(t, pubkey[:], cl.GetValidators()[1].GetPublicKey())
+
+	// Importing the same validator again is rejected.
+	conf.OutputDir = filepath.Join(dir, "import-again")
+	require.ErrorContains(t, runImportValidatorsSplit(ctx, io.Discard, conf), "validator already part of the cluster")
+}
+
+func mustLoadImportCluster(t *testing.T, conf importValidatorsConfig) *manifestpb.Cluster {
+	t.Helper()
+
+	_, cl, err := loadImportCluster(conf)
+	require.NoError(t, err)
+
+	return cl
+}
+
+func newTestEth2Client(t *testing.T, addr string) eth2wrap.Client {
+	t.Helper()
+
+	eth2Cl, err := eth2Client(t.Context(), nil, nil, []string{addr}, time.Second, [4]byte{})
+	require.NoError(t, err)
+
+	return eth2Cl
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	require.NoError(t, err)
+
+	return b
+}
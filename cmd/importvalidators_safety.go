@@ -0,0 +1,251 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// interchangeFormatVersion is the supported EIP-3076 slashing protection interchange format version.
+const interchangeFormatVersion = "5"
+
+// slashingInterchange is an EIP-3076 slashing protection interchange, see https://eips.ethereum.org/EIPS/eip-3076.
+type slashingInterchange struct {
+	Metadata struct {
+		InterchangeFormatVersion string `json:"interchange_format_version"`
+		GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+	} `json:"metadata"`
+	Data []interchangeValidator `json:"data"`
+}
+
+// interchangeValidator is the slashing protection history of a validator.
+type interchangeValidator struct {
+	PubKey             string                   `json:"pubkey"`
+	SignedBlocks       []interchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []interchangeAttestation `json:"signed_attestations"`
+}
+
+type interchangeBlock struct {
+	Slot        uint64 `json:"slot,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type interchangeAttestation struct {
+	SourceEpoch uint64 `json:"source_epoch,string"`
+	TargetEpoch uint64 `json:"target_epoch,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// loadSlashingInterchange returns the EIP-3076 slashing protection interchange from the file.
+func loadSlashingInterchange(file string) (slashingInterchange, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return slashingInterchange{}, errors.Wrap(err, "read slashing protection file", z.Str("file", file))
+	}
+
+	var resp slashingInterchange
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return slashingInterchange{}, errors.Wrap(err, "unmarshal slashing protection interchange", z.Str("file", file))
+	}
+
+	if resp.Metadata.InterchangeFormatVersion != interchangeFormatVersion {
+		return slashingInterchange{}, errors.New("unsupported slashing protection interchange format version",
+			z.Str("version", resp.Metadata.InterchangeFormatVersion))
+	}
+
+	return resp, nil
+}
+
+// validatorHistory returns the slashing protection history of the validator or false if not present.
+func (s slashingInterchange) validatorHistory(pubkey tbls.PublicKey) (interchangeValidator, bool) {
+	for _, val := range s.Data {
+		if strings.EqualFold(strings.TrimPrefix(val.PubKey, "0x"), hex.EncodeToString(pubkey[:])) {
+			return val, true
+		}
+	}
+
+	return interchangeValidator{}, false
+}
+
+// lastSignedEpoch returns the latest epoch of any signed block or attestation in the history.
+func (v interchangeValidator) lastSignedEpoch(slotsPerEpoch uint64) uint64 {
+	var resp uint64
+	for _, block := range v.SignedBlocks {
+		resp = max(resp, block.Slot/slotsPerEpoch)
+	}
+
+	for _, att := range v.SignedAttestations {
+		resp = max(resp, att.TargetEpoch)
+	}
+
+	return resp
+}
+
+// verifySoloValidatorsStopped returns an error unless the slashing protection interchange matches the network and
+// contains the history of all validators to import, and none of them signed within the last waitEpochs epochs.
+// This ensures the solo validator client was stopped long enough for the cluster to safely take over its duties.
+func verifySoloValidatorsStopped(ctx context.Context, eth2Cl eth2wrap.Client, interchange slashingInterchange,
+	pubkeys []tbls.PublicKey, waitEpochs uint64,
+) error {
+	genesis, err := eth2Cl.Genesis(ctx, &eth2api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "fetch genesis")
+	}
+
+	if !strings.EqualFold(interchange.Metadata.GenesisValidatorsRoot, fmt.Sprintf("%#x", genesis.Data.GenesisValidatorsRoot)) {
+		return errors.New("slashing protection interchange of a different network",
+			z.Str("interchange_genesis_validators_root", interchange.Metadata.GenesisValidatorsRoot),
+			z.Str("genesis_validators_root", fmt.Sprintf("%#x", genesis.Data.GenesisValidatorsRoot)))
+	}
+
+	slotDuration, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	currentEpoch := uint64(time.Since(genesis.Data.GenesisTime)/slotDuration) / slotsPerEpoch
+
+	for _, pubkey := range pubkeys {
+		history, ok := interchange.validatorHistory(pubkey)
+		if !ok {
+			return errors.New("slashing protection history of validator not found", z.Str("pubkey", fmt.Sprintf("%#x", pubkey)))
+		}
+
+		if last := history.lastSignedEpoch(slotsPerEpoch); last+waitEpochs > currentEpoch {
+			return errors.New("validator signed too recently, stop the solo validator client and wait before importing",
+				z.Str("pubkey", fmt.Sprintf("%#x", pubkey)), z.U64("last_signed_epoch", last),
+				z.U64("current_epoch", currentEpoch), z.U64("wait_epochs", waitEpochs))
+		}
+	}
+
+	return nil
+}
+
+// writeShareInterchanges writes the slashing protection history of the imported validators to each node's directory,
+// keyed by the node's public shares which its validator client signs with.
+func writeShareInterchanges(outputDir string, interchange slashingInterchange, vals []*manifestpb.Validator) error {
+	if len(vals) == 0 {
+		return nil
+	}
+
+	for i := range vals[0].GetPubShares() {
+		shareInterchange := slashingInterchange{Metadata: interchange.Metadata}
+
+		for _, val := range vals {
+			history, ok := interchange.validatorHistory(tbls.PublicKey(val.GetPublicKey()))
+			if !ok {
+				return errors.New("slashing protection history of validator not found", z.Str("pubkey", fmt.Sprintf("%#x", val.GetPublicKey())))
+			}
+
+			history.PubKey = fmt.Sprintf("%#x", val.GetPubShares()[i])
+			shareInterchange.Data = append(shareInterchange.Data, history)
+		}
+
+		b, err := json.MarshalIndent(shareInterchange, "", " ")
+		if err != nil {
+			return errors.Wrap(err, "marshal slashing protection interchange")
+		}
+
+		file := filepath.Join(nodeDir(outputDir, i), "slashing-protection.json")
+		if err := os.WriteFile(file, b, 0o644); err != nil { //nolint:gosec // Public data.
+			return errors.Wrap(err, "write slashing protection file", z.Str("file", file))
+		}
+	}
+
+	return nil
+}
+
+// verifyPubShares returns an error unless every threshold of consecutive public shares recombines to the validator's
+// public key. Overlapping windows of threshold shares only agree if all shares lie on the same polynomial.
+func verifyPubShares(val *manifestpb.Validator, threshold int) error {
+	pubshares := val.GetPubShares()
+	if threshold <= 0 || len(pubshares) < threshold {
+		return errors.New("insufficient validator public shares", z.Str("pubkey", fmt.Sprintf("%#x", val.GetPublicKey())))
+	}
+
+	for start := 0; start+threshold <= len(pubshares); start++ {
+		window := make(map[int]tbls.PublicKey)
+		for i := start; i < start+threshold; i++ {
+			window[i+1] = tbls.PublicKey(pubshares[i])
+		}
+
+		pubkey, err := tbls.ThresholdAggregatePublicKeys(window)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(pubkey[:], val.GetPublicKey()) {
+			return errors.New("validator public shares don't recombine to the public key",
+				z.Str("pubkey", fmt.Sprintf("%#x", val.GetPublicKey())), z.Int("first_share_index", start+1))
+		}
+	}
+
+	return nil
+}
+
+// verifyWithdrawalCredentials returns an error unless the validators exist on the beacon node with execution
+// withdrawal credentials of their withdrawal address.
+func verifyWithdrawalCredentials(ctx context.Context, eth2Cl eth2wrap.Client, vals []*manifestpb.Validator) error {
+	var pubkeys []eth2p0.BLSPubKey
+	for _, val := range vals {
+		pubkeys = append(pubkeys, eth2p0.BLSPubKey(val.GetPublicKey()))
+	}
+
+	resp, err := eth2Cl.Validators(ctx, &eth2api.ValidatorsOpts{State: "head", PubKeys: pubkeys})
+	if err != nil {
+		return errors.Wrap(err, "fetch validators")
+	}
+
+	credentials := make(map[eth2p0.BLSPubKey][]byte)
+	for _, val := range resp.Data {
+		if val == nil || val.Validator == nil {
+			continue
+		}
+
+		credentials[val.Validator.PublicKey] = val.Validator.WithdrawalCredentials
+	}
+
+	for _, val := range vals {
+		pubkey := fmt.Sprintf("%#x", val.GetPublicKey())
+
+		creds, ok := credentials[eth2p0.BLSPubKey(val.GetPublicKey())]
+		if !ok {
+			return errors.New("imported validator not found on beacon node", z.Str("pubkey", pubkey))
+		}
+
+		addr, err := hex.DecodeString(strings.TrimPrefix(val.GetWithdrawalAddress(), "0x"))
+		if err != nil {
+			return errors.Wrap(err, "decode withdrawal address", z.Str("pubkey", pubkey))
+		}
+
+		// Execution withdrawal credentials are the 0x01 or 0x02 prefix, 11 zero bytes and the 20 byte address.
+		if len(creds) != 32 || (creds[0] != 0x01 && creds[0] != 0x02) || !bytes.Equal(creds[1:12], make([]byte, 11)) {
+			return errors.New("validator doesn't have execution withdrawal credentials", z.Str("pubkey", pubkey),
+				z.Str("withdrawal_credentials", fmt.Sprintf("%#x", creds)))
+		}
+
+		if !bytes.Equal(creds[12:], addr) {
+			return errors.New("withdrawal address doesn't match the validator's withdrawal credentials", z.Str("pubkey", pubkey),
+				z.Str("withdrawal_address", val.GetWithdrawalAddress()), z.Str("withdrawal_credentials", fmt.Sprintf("%#x", creds)))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+// defaultPublishAddr is the Obol API address the cluster lock is published to by default.
+const defaultPublishAddr = "https://api.obol.tech/v1"
+
+type publishLockConfig struct {
+	LockFilePath    string
+	PublishAddr     string
+	PublishTimeout  time.Duration
+	PublishAttempts int
+}
+
+func newPublishLockCmd(runFunc func(context.Context, io.Writer, publishLockConfig) error) *cobra.Command {
+	var config publishLockConfig
+
+	cmd := &cobra.Command{
+		Use:   "publish-lock",
+		Short: "Publish a cluster lock to the Obol API",
+		Long: `Publishes an existing cluster lock file to the Obol API. Intended for publishing the cluster lock of a DKG ceremony ` +
+			`after publishing it at the end of the ceremony failed, e.g. due to an API outage.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.LockFilePath, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file to publish.")
+	cmd.Flags().StringVar(&config.PublishAddr, "publish-address", "", "The URL to publish the cluster lock to. Defaults to the address the DKG ceremony failed publishing to if pending, otherwise "+defaultPublishAddr+".")
+	cmd.Flags().DurationVar(&config.PublishTimeout, "publish-timeout", 30*time.Second, "Timeout for each attempt publishing the cluster lock, consider increasing if the cluster contains more than 200 validators.")
+	cmd.Flags().IntVar(&config.PublishAttempts, "publish-attempts", 5, "Number of attempts publishing the cluster lock with exponential backoff.")
+
+	return cmd
+}
+
+func runPublishLock(ctx context.Context, out io.Writer, config publishLockConfig) error {
+	lockBytes, err := os.ReadFile(config.LockFilePath)
+	if err != nil {
+		return errors.Wrap(err, "read lock file", z.Str("path", config.LockFilePath))
+	}
+
+	var lock cluster.Lock
+	if err := json.Unmarshal(lockBytes, &lock); err != nil {
+		return errors.Wrap(err, "unmarshal lock json", z.Str("path", config.LockFilePath))
+	}
+
+	if err := lock.VerifyHashes(); err != nil {
+		return errors.Wrap(err, "cluster lock hash verification failed")
+	}
+
+	// Publish to the address of the failed publish during the DKG ceremony, if any, unless overridden.
+	pendingPath := filepath.Join(filepath.Dir(config.LockFilePath), dkg.PendingPublishFile)
+
+	publishAddr := config.PublishAddr
+	if publishAddr == "" {
+		publishAddr, err = pendingPublishAddr(pendingPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	dashboardURL, err := dkg.PublishLock(ctx, publishAddr, lock, config.PublishTimeout, config.PublishAttempts)
+	if err != nil {
+		return err
+	}
+
+	// Remove the offline artifact of the failed publish during the DKG ceremony, if any.
+	if err := os.Remove(pendingPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warn(ctx, "Couldn't remove pending lock file publish", err, z.Str("path", pendingPath))
+	}
+
+	if _, err := fmt.Fprintf(out, "Published cluster lock, you can find your cluster dashboard here: %s\n", dashboardURL); err != nil {
+		return errors.Wrap(err, "output write")
+	}
+
+	return nil
+}
+
+// pendingPublishAddr returns the publish address of the pending publish artifact at the path,
+// or the default publish address if there is none.
+func pendingPublishAddr(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultPublishAddr, nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "read pending lock file publish", z.Str("path", path))
+	}
+
+	var pending dkg.PendingPublish
+	if err := json.Unmarshal(b, &pending); err != nil {
+		return "", errors.Wrap(err, "unmarshal pending lock file publish", z.Str("path", path))
+	}
+
+	if pending.PublishAddr == "" {
+		return defaultPublishAddr, nil
+	}
+
+	return pending.PublishAddr, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/dkg"
+)
+
+func TestRunPublishLock(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	var published cluster.Lock
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/lock", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&published))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "cluster-lock.json")
+	pendingPath := filepath.Join(dir, dkg.PendingPublishFile)
+
+	b, err := json.Marshal(lock)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockPath, b, 0o644))
+	require.NoError(t, os.WriteFile(pendingPath, []byte("{}"), 0o644))
+
+	var out bytes.Buffer
+
+	err = runPublishLock(t.Context(), &out, publishLockConfig{
+		LockFilePath:    lockPath,
+		PublishAddr:     srv.URL,
+		PublishTimeout:  time.Second,
+		PublishAttempts: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, lock.LockHash, published.LockHash)
+	require.Contains(t, out.String(), "/launchpad")
+	require.NoFileExists(t, pendingPath)
+
+	// The address of the pending publish is used by default.
+	published = cluster.Lock{}
+	pending, err := json.Marshal(dkg.PendingPublish{PublishAddr: srv.URL})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pendingPath, pending, 0o644))
+
+	err = runPublishLock(t.Context(), &out, publishLockConfig{
+		LockFilePath:    lockPath,
+		PublishTimeout:  time.Second,
+		PublishAttempts: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, lock.LockHash, published.LockHash)
+	require.NoFileExists(t, pendingPath)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -155,8 +156,8 @@ func Run(ctx context.Context, config Config) error {
 func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey, config p2p.Config) func(ctx context.Context) ([]byte, error) {
 	// Resolve external hostname periodically.
 	var (
-		extHostMu sync.Mutex
-		extHostIP net.IP
+		extHostMu  sync.Mutex
+		extHostIPs []net.IP
 	)
 
 	go func() {
@@ -173,7 +174,7 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 
 			extHostMu.Lock()
 
-			extHostIP = ip[0]
+			extHostIPs = firstIPPerFamily(ip)
 
 			extHostMu.Unlock()
 		}
@@ -193,12 +194,12 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 		}
 	}()
 
-	// getExtHostIP returns the external host IP and true if it is set.
-	getExtHostIP := func() (net.IP, bool) {
+	// getExtHostIPs returns the external host IPs (one per IP family).
+	getExtHostIPs := func() []net.IP {
 		extHostMu.Lock()
 		defer extHostMu.Unlock()
 
-		return extHostIP, len(extHostIP) != 0
+		return extHostIPs
 	}
 
 	return func(context.Context) ([]byte, error) {
@@ -208,42 +209,125 @@ func newENRHandler(ctx context.Context, tcpNode host.Host, p2pKey *k1.PrivateKey
 			return nil, errors.New("no addresses")
 		}
 
-		// Order public addresses first.
-		sort.SliceStable(addrs, func(i, j int) bool {
-			iPublic, jPublic := manet.IsPublicAddr(addrs[i]), manet.IsPublicAddr(addrs[j])
-			if jPublic && !iPublic {
-				return true // Only swap if j is public and i is not.
+		// Override IPs with external IP or external hostname IPs if set.
+		var extIPs []net.IP
+		if config.ExternalIP != "" {
+			extIPs = []net.IP{net.ParseIP(config.ExternalIP)}
+		} else {
+			extIPs = getExtHostIPs()
+		}
+
+		tcpAddrs, err := advertisedTCPAddrs(addrs, extIPs)
+		if err != nil {
+			return nil, err
+		}
+
+		opts := []enr.Option{enr.WithUDP(9999)} // Include invalid dummy UDP port so v0.13 can parse the ENR.
+		for i, tcpAddr := range tcpAddrs {
+			opts = append(opts, enr.WithIP(tcpAddr.IP))
+			if i == 0 {
+				opts = append(opts, enr.WithTCP(tcpAddr.Port)) // The IPv6 TCP port defaults to this if IPv6-only.
+			} else {
+				opts = append(opts, enr.WithTCP6(tcpAddr.Port))
 			}
+		}
+
+		// Build the ENR
+		r, err := enr.New(p2pKey, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(r.String()), nil
+	}
+}
 
-			return false
-		})
+// advertisedTCPAddrs returns the first (public addresses ordered first) TCP address of each IP family to advertise,
+// IPv4 first. External IPs override the IP of the address of the same family, in which case addresses of the
+// other family are only advertised if public.
+func advertisedTCPAddrs(addrs []ma.Multiaddr, extIPs []net.IP) ([]*net.TCPAddr, error) {
+	// Order public addresses first.
+	addrs = slices.Clone(addrs)
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return manet.IsPublicAddr(addrs[i]) && !manet.IsPublicAddr(addrs[j])
+	})
 
-		// Use first address (ip and port).
-		addr, err := manet.ToNetAddr(addrs[0])
+	var (
+		tcp4, tcp6       *net.TCPAddr
+		public4, public6 bool
+	)
+
+	for _, maddr := range addrs {
+		addr, err := manet.ToNetAddr(maddr)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to convert multiaddr to net addr")
+			continue // Skip non-IP addresses.
 		}
 
 		tcpAddr, ok := addr.(*net.TCPAddr)
 		if !ok {
-			return nil, errors.New("invalid TCP address")
+			continue // Skip non-TCP addresses.
 		}
 
-		// Override IP with external IP or external hostname if set.
-		if config.ExternalIP != "" {
-			tcpAddr.IP = net.ParseIP(config.ExternalIP)
-		} else if extHostIP, ok := getExtHostIP(); ok {
-			tcpAddr.IP = extHostIP
+		if tcpAddr.IP.To4() != nil && tcp4 == nil {
+			tcp4, public4 = tcpAddr, manet.IsPublicAddr(maddr)
+		} else if tcpAddr.IP.To4() == nil && tcp6 == nil {
+			tcp6, public6 = tcpAddr, manet.IsPublicAddr(maddr)
+		}
+	}
+
+	if tcp4 == nil && tcp6 == nil {
+		return nil, errors.New("no TCP addresses")
+	}
+
+	// Port of the external IP defaults to the port of the other family.
+	defaultPort := func(addr, other *net.TCPAddr) int {
+		if addr != nil {
+			return addr.Port
 		}
 
-		// Build the ENR
-		r, err := enr.New(p2pKey, enr.WithIP(tcpAddr.IP), enr.WithTCP(tcpAddr.Port), enr.WithUDP(9999)) // Include invalid dummy UDP port so v0.13 can parse the ENR.
-		if err != nil {
-			return nil, err
+		return other.Port
+	}
+
+	var ext4, ext6 bool
+	for _, ip := range firstIPPerFamily(extIPs) {
+		if ip.To4() != nil {
+			tcp4, ext4 = &net.TCPAddr{IP: ip, Port: defaultPort(tcp4, tcp6)}, true
+		} else {
+			tcp6, ext6 = &net.TCPAddr{IP: ip, Port: defaultPort(tcp6, tcp4)}, true
 		}
+	}
 
-		return []byte(r.String()), nil
+	var resp []*net.TCPAddr
+	if tcp4 != nil && (ext4 || public4 || !ext6) {
+		resp = append(resp, tcp4)
 	}
+
+	if tcp6 != nil && (ext6 || public6 || !ext4) {
+		resp = append(resp, tcp6)
+	}
+
+	return resp, nil
+}
+
+// firstIPPerFamily returns the first IPv4 and the first IPv6 address of the IPs.
+func firstIPPerFamily(ips []net.IP) []net.IP {
+	var ip4, ip6 net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil && ip4 == nil {
+			ip4 = ip
+		} else if ip.To4() == nil && ip.To16() != nil && ip6 == nil {
+			ip6 = ip
+		}
+	}
+
+	var resp []net.IP
+	for _, ip := range []net.IP{ip4, ip6} {
+		if ip != nil {
+			resp = append(resp, ip)
+		}
+	}
+
+	return resp
 }
 
 // newMultiaddrHandler returns a handler that returns the nodes multiaddrs (as json array).
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"testing"
@@ -276,3 +277,75 @@ func TestRelayMetricsExported(t *testing.T) {
 
 	cancel()
 }
+
+func TestAdvertisedTCPAddrs(t *testing.T) {
+	maddrs := func(addrs ...string) []ma.Multiaddr {
+		var resp []ma.Multiaddr
+		for _, addr := range addrs {
+			maddr, err := ma.NewMultiaddr(addr)
+			require.NoError(t, err)
+
+			resp = append(resp, maddr)
+		}
+
+		return resp
+	}
+
+	tests := []struct {
+		Name   string
+		Addrs  []ma.Multiaddr
+		ExtIPs []net.IP
+		Expect []string
+	}{
+		{
+			Name:   "ipv4 public first",
+			Addrs:  maddrs("/ip4/10.0.0.1/tcp/3640", "/ip4/1.2.3.4/tcp/3640"),
+			Expect: []string{"1.2.3.4:3640"},
+		},
+		{
+			Name:   "ipv6 only",
+			Addrs:  maddrs("/ip6/2001:db8::1/tcp/3640"),
+			Expect: []string{"[2001:db8::1]:3640"},
+		},
+		{
+			Name:   "dual-stack",
+			Addrs:  maddrs("/ip6/2600::1/tcp/3641", "/ip4/1.2.3.4/tcp/3640"),
+			Expect: []string{"1.2.3.4:3640", "[2600::1]:3641"},
+		},
+		{
+			Name:   "external ipv4 drops private ipv6",
+			Addrs:  maddrs("/ip4/10.0.0.1/tcp/3640", "/ip6/fd00::1/tcp/3640"),
+			ExtIPs: []net.IP{net.IPv4(1, 2, 3, 4)},
+			Expect: []string{"1.2.3.4:3640"},
+		},
+		{
+			Name:   "external ipv6 on ipv4 listener",
+			Addrs:  maddrs("/ip4/10.0.0.1/tcp/3640"),
+			ExtIPs: []net.IP{net.ParseIP("2600::1")},
+			Expect: []string{"[2600::1]:3640"},
+		},
+		{
+			Name:   "external dual-stack",
+			Addrs:  maddrs("/ip4/10.0.0.1/tcp/3640"),
+			ExtIPs: []net.IP{net.ParseIP("2600::1"), net.IPv4(1, 2, 3, 4), net.IPv4(5, 6, 7, 8)},
+			Expect: []string{"1.2.3.4:3640", "[2600::1]:3640"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			addrs, err := advertisedTCPAddrs(test.Addrs, test.ExtIPs)
+			require.NoError(t, err)
+
+			var addrStrs []string
+			for _, addr := range addrs {
+				addrStrs = append(addrStrs, addr.String())
+			}
+
+			require.Equal(t, test.Expect, addrStrs)
+		})
+	}
+
+	_, err := advertisedTCPAddrs(maddrs("/ip4/1.2.3.4/udp/3640"), nil)
+	require.ErrorContains(t, err, "no TCP addresses")
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core/replay"
+)
+
+type replayConfig struct {
+	RecordFile string
+	Threshold  int
+}
+
+func newReplayCmd(runFunc func(context.Context, io.Writer, replayConfig) error) *cobra.Command {
+	var config replayConfig
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay recorded core workflow inputs",
+		Long: `Deterministically replays core workflow inputs recorded by a charon node via --replay-record-file ` +
+			`through the DutyDB, ParSigDB and SigAgg components, printing the outcome of each event. ` +
+			`Intended for debugging consensus or aggregation bugs offline.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.RecordFile, "record-file", "", "The path to the replay record file. [REQUIRED]")
+	cmd.Flags().IntVar(&config.Threshold, "threshold", 0, "The cluster threshold of partial signatures required for aggregation. [REQUIRED]")
+
+	mustMarkFlagRequired(cmd, "record-file")
+	mustMarkFlagRequired(cmd, "threshold")
+
+	return cmd
+}
+
+func runReplay(ctx context.Context, out io.Writer, config replayConfig) error {
+	if config.Threshold < 1 {
+		return errors.New("threshold must be at least 1", z.Int("threshold", config.Threshold))
+	}
+
+	events, err := replay.Load(config.RecordFile)
+	if err != nil {
+		return err
+	}
+
+	steps, err := replay.Replay(ctx, events, config.Threshold, nil)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		result := "ok"
+		if step.Err != nil {
+			result = "error: " + step.Err.Error()
+		} else if len(step.Aggregated) > 0 {
+			result = fmt.Sprintf("aggregated=%d", len(step.Aggregated))
+		}
+
+		_, err := fmt.Fprintf(out, "event=%d time=%s kind=%s duty=%s result=%q\n",
+			i, step.Event.Time.Format("15:04:05.000"), step.Event.Kind, step.Event.Duty, result)
+		if err != nil {
+			return errors.Wrap(err, "write replay step")
+		}
+	}
+
+	return nil
+}
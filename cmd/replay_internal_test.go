@@ -0,0 +1,36 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/replay"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRunReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "replay.jsonl")
+
+	recorder, err := replay.NewFileRecorder(file)
+	require.NoError(t, err)
+
+	duty := core.NewRandaoDuty(1)
+	recorder.RecordParSigInternal(duty, core.ParSignedDataSet{
+		testutil.RandomCorePubKey(t): core.NewPartialSignedRandao(0, testutil.RandomEth2Signature(), 1),
+	})
+	require.NoError(t, recorder.Close())
+
+	var out bytes.Buffer
+	require.NoError(t, runReplay(t.Context(), &out, replayConfig{RecordFile: file, Threshold: 3}))
+	require.Contains(t, out.String(), `event=0`)
+	require.Contains(t, out.String(), `kind=parsig_internal duty=1/randao result="ok"`)
+
+	err = runReplay(t.Context(), &out, replayConfig{RecordFile: file, Threshold: 0})
+	require.ErrorContains(t, err, "threshold must be at least 1")
+}
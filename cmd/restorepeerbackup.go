@@ -0,0 +1,171 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/peerbackup"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+type restorePeerBackupConfig struct {
+	PrivateKeyFile string
+	PeerENRs       []string
+	LockHash       string
+	OutputDir      string
+	Timeout        time.Duration
+	P2P            p2p.Config
+	Log            log.Config
+}
+
+func newRestorePeerBackupCmd(runFunc func(context.Context, io.Writer, restorePeerBackupConfig) error) *cobra.Command {
+	var config restorePeerBackupConfig
+
+	cmd := &cobra.Command{
+		Use:   "restore-peer-backup",
+		Short: "Restore cluster artifacts backed up by peers",
+		Long: `Restores this node's cluster lock, manifest and deposit data from the encrypted backups stored by peers ` +
+			`running with --peer-backup-dir. Requires the node's original p2p private key, the backups are encrypted with a key derived from it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.PrivateKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	cmd.Flags().StringSliceVar(&config.PeerENRs, "peer-enrs", nil, "Comma-separated list of the ENRs of the other cluster peers. [REQUIRED]")
+	cmd.Flags().StringVar(&config.LockHash, "lock-hash", "", "Optional hex encoded cluster lock hash, enabling connecting to peers via relays routing by cluster. Defaults to a hash of the peer ENRs.")
+	cmd.Flags().StringVar(&config.OutputDir, "output-dir", ".charon", "The directory to write the restored cluster artifacts to. Existing files are never overwritten.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 2*time.Minute, "Timeout for connecting to peers and fetching backups.")
+
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "peer-enrs")
+
+	return cmd
+}
+
+func runRestorePeerBackup(ctx context.Context, out io.Writer, config restorePeerBackupConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	key, err := k1util.Load(config.PrivateKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "load private key")
+	}
+
+	meENR, err := enr.New(key)
+	if err != nil {
+		return err
+	}
+
+	var (
+		peers   []p2p.Peer
+		peerIDs []peer.ID
+	)
+
+	allENRs := append(slices.Clone(config.PeerENRs), meENR.String())
+	for i, enrStr := range allENRs {
+		record, err := enr.Parse(enrStr)
+		if err != nil {
+			return errors.Wrap(err, "decode enr", z.Str("enr", enrStr))
+		}
+
+		p2pPeer, err := p2p.NewPeerFromENR(record, i)
+		if err != nil {
+			return err
+		}
+
+		peers = append(peers, p2pPeer)
+		peerIDs = append(peerIDs, p2pPeer.ID)
+	}
+
+	clusterHash, err := restoreClusterHash(config.LockHash, allENRs)
+	if err != nil {
+		return err
+	}
+
+	tcpNode, shutdown, err := setupP2P(ctx, key, config.P2P, peers, clusterHash)
+	if err != nil {
+		return err
+	}
+	defer shutdown()
+
+	var artifacts peerbackup.Artifacts
+
+	// Retry until peers are connected via relays or direct addresses.
+	for {
+		artifacts, err = peerbackup.Fetch(ctx, tcpNode, peerIDs, key)
+		if err == nil {
+			break
+		}
+
+		log.Info(ctx, "Peer backup not fetched yet, retrying", z.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "fetch peer backup timeout")
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return errors.Wrap(err, "create output dir")
+	}
+
+	if err := artifacts.WriteFiles(config.OutputDir); err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range artifacts.Files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	_, err = fmt.Fprintf(out, "Restored peer backup created at %s to %s: %s\n",
+		artifacts.CreatedAt.Format(time.RFC3339), config.OutputDir, strings.Join(names, ", "))
+
+	return err
+}
+
+// restoreClusterHash returns the decoded lock hash if provided, otherwise a hash of the sorted ENRs.
+func restoreClusterHash(lockHash string, enrs []string) ([]byte, error) {
+	if lockHash != "" {
+		b, err := hex.DecodeString(strings.TrimPrefix(lockHash, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode lock hash")
+		}
+
+		return b, nil
+	}
+
+	sorted := slices.Clone(enrs)
+	slices.Sort(sorted)
+	hash := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+
+	return hash[:], nil
+}
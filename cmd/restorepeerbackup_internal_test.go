@@ -0,0 +1,26 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreClusterHash(t *testing.T) {
+	hash, err := restoreClusterHash("0x0102", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, hash)
+
+	_, err = restoreClusterHash("0xzz", nil)
+	require.ErrorContains(t, err, "decode lock hash")
+
+	// The ENR hash is independent of the ENR order.
+	hash1, err := restoreClusterHash("", []string{"enr:-a", "enr:-b"})
+	require.NoError(t, err)
+	hash2, err := restoreClusterHash("", []string{"enr:-b", "enr:-a"})
+	require.NoError(t, err)
+	require.Equal(t, hash1, hash2)
+	require.Len(t, hash1, 32)
+}
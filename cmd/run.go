@@ -4,7 +4,10 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/url"
+	"strings"
 	"time"
 
 	libp2plog "github.com/ipfs/go-log/v2"
@@ -15,9 +18,15 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/core/scheduler"
+	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
 )
 
 // eth2ClientTimeout is the default timeout for charon <> beacon node API interactions.
@@ -77,7 +86,7 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
 	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", eth2ClientTimeout, "Timeout for the HTTP requests Charon makes to the configured beacon nodes.")
 	cmd.Flags().DurationVar(&config.BeaconNodeSubmitTimeout, "beacon-node-submit-timeout", eth2ClientTimeout, "Timeout for the submission-related HTTP requests Charon makes to the configured beacon nodes.")
-	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
+	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API. IPv6 addresses must be enclosed in square brackets, e.g. \"[::1]:3600\", \"[::]:3600\" binds dual-stack to all IPv4 and IPv6 interfaces.")
 	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "[DISABLED] Listening address for jaeger tracing.")
 	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "", "[DISABLED] Service name used for jaeger tracing.")
 	cmd.Flags().StringVar(&config.OTLPAddress, "otlp-address", "", "Listening address for OTLP gRPC tracing backend.")
@@ -86,8 +95,15 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().BoolVar(&config.SimnetVMock, "simnet-validator-mock", false, "Enables an internal mock validator client when running a simnet. Requires simnet-beacon-mock.")
 	cmd.Flags().StringVar(&config.SimnetValidatorKeysDir, "simnet-validator-keys-dir", ".charon/validator_keys", "The directory containing the simnet validator key shares.")
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
+	cmd.Flags().StringSliceVar(&config.BuilderRelayAddrs, "builder-relay-endpoints", nil, "Comma separated list of MEV-Boost relay URLs, including the relay public key, e.g. https://0xpubkey@relay.example. Builder bids of fetched blinded proposals are requested from the relays, validator registrations are submitted to the relays instead of the beacon node, and blinded proposals are unblinded via the relays that served their bid, removing the requirement of a mev-boost sidecar connected to the beacon node.")
+	cmd.Flags().BoolVar(&config.BuilderRejectHeaderMismatch, "builder-reject-header-mismatch", false, "Enables replacing fetched builder blocks by locally built blocks if the bid value isn't positive, the proposer index doesn't match the duty, or the execution payload header doesn't match the parent block hash or the expected gas limit. Discrepancies are always logged and counted when the builder api is enabled, this additionally protects against relay equivocation at the cost of the builder bid.")
+	cmd.Flags().Float64Var(&config.BuilderMinBid, "builder-min-bid", 0, "Minimum builder block bid in ETH. Builder blocks with a lower execution value are replaced by locally built blocks, protecting against relays returning dust bids. Zero disables the minimum bid. Requires builder-api.")
+	cmd.Flags().StringSliceVar(&config.GasLimitRamp, "gas-limit-ramp", nil, "Comma-separated list of key=value pairs progressively changing the target gas limit from the cluster's target gas limit, e.g. \"target=60000000,start_epoch=350000,epochs=225\". The target gas limit of the proposer configuration moves linearly to the target over the number of epochs from the start epoch. Epochs defaults to zero, changing the target gas limit at the start epoch.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
-	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
+	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock. Must be a whole number of seconds.")
+	cmd.Flags().IntVar(&config.SimnetSlotsPerEpoch, "simnet-slots-per-epoch", 16, "Configures slots per epoch in simnet beacon mock.")
+	cmd.Flags().StringSliceVar(&config.SimnetForkEpochs, "simnet-fork-epochs", nil, "Comma separated list of <fork>=<epoch> pairs overriding fork epochs in simnet beacon mock, e.g. 'electra=0'. Supported forks: altair, bellatrix, capella, deneb, electra, fulu.")
+	cmd.Flags().BoolVar(&config.SimnetAllDuties, "simnet-all-duties", false, "Configures simnet beacon mock to assign sync committee duties in every epoch and select all validators as aggregators, exercising all validator mock duty flows.")
 	cmd.Flags().BoolVar(&config.SimnetBMockFuzz, "simnet-beacon-mock-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
 	cmd.Flags().StringVar(&config.TestnetConfig.Name, "testnet-name", "", "Name of the custom test network.")
 	cmd.Flags().StringVar(&config.TestnetConfig.GenesisForkVersionHex, "testnet-fork-version", "", "Genesis fork version in hex of the custom test network.")
@@ -97,6 +113,7 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringVar(&config.ProcDirectory, "proc-directory", "", "Directory to look into in order to detect other stack components running on the host.")
 	cmd.Flags().StringVar(&config.ConsensusProtocol, "consensus-protocol", "", "Preferred consensus protocol name for the node. Selected automatically when not specified.")
 	cmd.Flags().StringVar(&config.Nickname, "nickname", "", "Human friendly peer nickname. Maximum 32 characters.")
+	cmd.Flags().StringSliceVar(&config.Labels, "labels", nil, "Comma separated list of node labels formatted as name=value, attached to metrics, logs, peer info and the monitoring API.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeHeaders, "beacon-node-headers", nil, "Comma separated list of headers formatted as header=value")
 	cmd.Flags().StringSliceVar(&config.FallbackBeaconNodeAddrs, "fallback-beacon-node-endpoints", nil, "A list of beacon nodes to use if the primary list are offline or unhealthy.")
 	cmd.Flags().StringVar(&config.ExecutionEngineAddr, "execution-client-rpc-endpoint", "", "The address of the execution engine JSON-RPC API.")
@@ -104,12 +121,66 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().BoolVar(&config.GraffitiDisableClientAppend, "graffiti-disable-client-append", false, "Disables appending \"OB<CL_TYPE>\" suffix to graffiti. Increases maximum bytes per graffiti to 32.")
 	cmd.Flags().StringVar(&config.VCTLSCertFile, "vc-tls-cert-file", "", "The path to the TLS certificate file used by charon for the validator client API endpoint.")
 	cmd.Flags().StringVar(&config.VCTLSKeyFile, "vc-tls-key-file", "", "The path to the TLS private key file associated with the provided TLS certificate.")
+	cmd.Flags().StringSliceVar(&config.VCProposalTypeOverrides, "vc-proposal-type-overrides", nil, "Comma-separated list of client=type pairs forcing full or blinded block proposals for specific validator clients, e.g. \"teku=full\". Client matches a User-Agent substring or the bearer token of the request. Full proposals are converted to blinded, blinded proposals cannot be converted to full and are rejected.")
+	cmd.Flags().StringVar(&config.VCAuthTokensFile, "vc-auth-tokens-file", "", "The path to a JSON file of validator client bearer tokens, formatted as [{\"token\":\"...\",\"name\":\"...\",\"pubshares\":[\"0x...\"]}]. If set, validator clients must authenticate with one of the tokens. Requests are logged and instrumented by name, optional pubshares restrict the validators a client may submit for.")
+	cmd.Flags().BoolVar(&config.VCMonitoringEndpoints, "vc-monitoring-endpoints", false, "Enables serving the /metrics and /livez monitoring endpoints on the validator API port, for deployments that can only expose a single port. Only these paths are served, also while the startup gate delays serving the validator API. Set an empty --monitoring-address to disable the separate monitoring listener.")
+	cmd.Flags().StringVar(&config.VCMonitoringToken, "vc-monitoring-token", "", "Optional bearer token required for the monitoring endpoints served on the validator API port. Requires vc-monitoring-endpoints.")
+	cmd.Flags().StringSliceVar(&config.VCConcurrencyLimits, "vc-concurrency-limits", nil, "Comma-separated list of class=limit pairs overriding the maximum concurrent validator API requests by endpoint class, e.g. \"proposal=4\". Classes are proposal, attestation_data, aggregation, duties and validators. As many requests may queue, further requests are rejected with 429. Zero disables the limit.")
+	cmd.Flags().StringSliceVar(&config.VCCORSAllowedOrigins, "vc-cors-allowed-origins", nil, "Comma-separated list of origins, e.g. \"https://dashboard.example.com\", allowed to call the validator API cross-origin from browser-based tooling. \"*\" allows all origins. Cross-origin requests are not allowed by default.")
+	cmd.Flags().StringSliceVar(&config.VCCORSAllowedHeaders, "vc-cors-allowed-headers", nil, "Comma-separated list of request headers allowed in cross-origin validator API requests. Defaults to Accept, Authorization, Content-Type and Eth-Consensus-Version. Requires vc-cors-allowed-origins.")
+	cmd.Flags().StringVar(&config.VCProxyRewritesFile, "vc-proxy-rewrites-file", "", "The path to a JSON file of rewrites applied to validator API requests proxied to the beacon node, formatted as [{\"path_prefix\":\"/eth/v1/\",\"rewrite_path_prefix\":\"/gateway/eth/v1/\",\"set_headers\":{\"X-Api-Key\":\"...\"},\"remove_headers\":[\"...\"],\"host\":\"...\"}]. Rewrites are applied in order, each matching the request path as rewritten by the previous rewrites. Intended for beacon node gateways requiring non-standard paths or headers.")
+	cmd.Flags().IntVar(&config.VCProxyBreakerFailures, "vc-proxy-breaker-failures", 0, "Number of consecutive failed validator API requests proxied to a beacon node after which its circuit breaker opens. Proxied requests then fail fast with 503, or fail over to another beacon node, until a single probe request succeeds after vc-proxy-breaker-cooldown. Zero disables the circuit breaker.")
+	cmd.Flags().DurationVar(&config.VCProxyBreakerCooldown, "vc-proxy-breaker-cooldown", 10*time.Second, "Duration a beacon node circuit breaker stays open before allowing a probe request. Requires vc-proxy-breaker-failures.")
+	cmd.Flags().DurationVar(&config.VCProxyHedgeDelay, "vc-proxy-hedge-delay", 0, "Enables hedging of GET requests proxied to the primary beacon node: if no response is received within this delay, the request is also sent to a secondary beacon node and the first successful response is used. Requires multiple beacon node endpoints. Zero disables hedging.")
+	cmd.Flags().Int64Var(&config.VCProxyMaxResponseSize, "vc-proxy-max-response-size", 0, "Maximum size in bytes of beacon node responses proxied to validator clients, larger responses are rejected. Proxied responses are streamed without buffering in memory, except responses of unknown size which are buffered up to this size. Zero allows any size.")
+	cmd.Flags().IntVar(&config.OvercollectSignatures, "overcollect-signatures", 0, "Number of matching partial signatures beyond threshold to wait for, up to the number of nodes, before aggregating and broadcasting each validator's duty. Improves resilience against invalid partial signatures detected during aggregation at the cost of latency. Zero aggregates as soon as threshold is reached.")
+	cmd.Flags().DurationVar(&config.OvercollectTimeout, "overcollect-timeout", 500*time.Millisecond, "Maximum duration to wait for additional partial signatures after threshold is reached before aggregating anyway. Requires overcollect-signatures.")
+	cmd.Flags().IntVar(&config.AggregationNodes, "aggregation-nodes", 0, "Number of best-connected nodes fetching aggregate attestations from their beacon node for each aggregation duty, selected cluster wide by recent beacon node latency, in addition to the first round consensus leader. Zero means all nodes.")
+	cmd.Flags().StringVar(&config.SyncMessageFallbackKeysDir, "sync-message-fallback-keys-dir", "", "Enables charon producing and signing sync committee messages for scheduled validators if no validator client submission is seen in time, using the key shares in this directory. Intended to protect sync committee rewards against flaky validator clients, note that the key shares are then also used by charon.")
+	cmd.Flags().DurationVar(&config.SyncMessageFallbackDelay, "sync-message-fallback-delay", 6*time.Second, "Delay into the slot after which charon produces sync committee messages for validators without validator client submissions. Requires sync-message-fallback-keys-dir.")
+	cmd.Flags().StringVar(&config.AttestationFallbackKeysDir, "attestation-fallback-keys-dir", "", "Enables the non-default failsafe attester mode: charon produces and signs attestations of scheduled validators from the cluster's decided attestation data if no validator client submission is seen in time, using the key shares in this directory. Attestations of slashed validators or slashable according to the attestations signed since startup are never produced.")
+	cmd.Flags().DurationVar(&config.AttestationFallbackDelay, "attestation-fallback-delay", 8*time.Second, "Delay into the slot after which the failsafe attester produces attestations for validators without validator client submissions. Requires attestation-fallback-keys-dir.")
+	cmd.Flags().DurationVar(&config.ProposalRehearsalInterval, "proposal-rehearsal-interval", 0, "Enables the non-default proposal rehearsal at this interval, e.g. 24h: the cluster produces, decides and optionally partially signs a block proposal that is discarded, never broadcast, validating the proposal infrastructure before a real proposal. All nodes must enable it with the same interval.")
+	cmd.Flags().StringVar(&config.ProposalRehearsalKeysDir, "proposal-rehearsal-keys-dir", "", "Directory containing the key share of the cluster's first validator used to partially sign proposal rehearsals. Rehearsals are signed over a non-beacon domain, never producing valid block signatures. Requires proposal-rehearsal-interval.")
+	cmd.Flags().StringVar(&config.SandboxUser, "sandbox-user", "", "User, with optional group, e.g. \"charon:charon\", to switch to after binding ports and loading keys, allowing binding privileged ports without running as root. Requires starting as root.")
+	cmd.Flags().StringVar(&config.SandboxChroot, "sandbox-chroot", "", "Directory to change the root directory to after binding ports and loading keys. Files accessed afterwards, e.g. the private key lock file and proposal guard file, must lie within it, as must copies of /etc/resolv.conf and /etc/hosts for resolving DNS names. System TLS roots are loaded before changing the root directory. Requires starting as root.")
+	cmd.Flags().BoolVar(&config.VerifyKeyPermissions, "verify-key-permissions", false, "Refuse to start if the private key file or key directories are accessible by other users or not owned by the running (or sandbox) user.")
+	cmd.Flags().StringVar(&config.PeerBackupDir, "peer-backup-dir", "", "Enables the opt-in peer backup protocol: encrypted backups of peers' cluster artifacts are stored in this directory, and this node's cluster lock, manifest and deposit data are backed up, encrypted with a key derived from its p2p private key, to peers. Recover them with \"charon alpha restore-peer-backup\" after disk loss.")
+	cmd.Flags().StringVar(&config.MinPeerVersion, "min-peer-version", "", "Minimum charon version, e.g. v1.6.0, all peers must run before optional protocol features negotiated with peers are activated. Smooths rolling upgrades by deferring new wire features until all nodes are upgraded. Empty disables the version gate.")
+	cmd.Flags().Float64Var(&config.ClockSkewThreshold, "clock-skew-threshold", 0.1, "Fraction of the slot duration beyond which a peer's clock offset, or the local clock lagging the beacon node slot clock, is warned about. Skewed clocks are a common silent cause of consensus round changes. Zero disables the warnings.")
+	cmd.Flags().StringSliceVar(&config.SlotOffsets, "slot-offsets", nil, "Comma-separated list of duty=duration pairs overriding when within the slot duties are triggered, e.g. \"attester=3s,aggregator=7s\". Supported duties are attester (default 1/3 slot), aggregator and sync_contribution (default 2/3 slot). Offsets must be within the slot and aggregation may not be triggered before attestation. Intended for tuning clusters with slow links or beacon nodes.")
+	cmd.Flags().Uint64Var(&config.TrackerBackfillEpochs, "tracker-backfill-epochs", 2, "Number of epochs before startup for which the on-chain outcome of the cluster validators' attestations and block proposals is reconstructed on startup, so metrics cover the restart window. Zero disables backfilling.")
+	cmd.Flags().BoolVar(&config.StartupWaitBeaconNode, "startup-wait-beacon-node", false, "Enables waiting for the beacon node to be synced on startup before opening the validator API, avoiding validator client error storms during cold cluster startup.")
+	cmd.Flags().BoolVar(&config.StartupWaitPeers, "startup-wait-peers", false, "Enables waiting for a quorum of peers to be connected on startup before opening the validator API, avoiding validator client error storms during cold cluster startup.")
+	cmd.Flags().IntVar(&config.EpochWorkSpreadSlots, "epoch-work-spread-slots", 0, "Enables spreading non-critical start-of-epoch work (proposer preparations, registration rebroadcasts and cluster summaries) with a random jitter across this many first slots of the epoch, reducing the latency spike of the first slots. Zero does all work in the first slot.")
+	cmd.Flags().DurationVar(&config.StartupWaitTimeout, "startup-wait-timeout", 5*time.Minute, "Maximum duration to wait on startup for the beacon node and peers before opening the validator API anyway. Requires startup-wait-beacon-node or startup-wait-peers.")
+	cmd.Flags().DurationVar(&config.ShutdownDrainTimeout, "shutdown-drain-timeout", 8*time.Second, "Maximum duration to wait on shutdown for in-flight duties partially signed by the validator client to be aggregated and broadcast before stopping. New validator client requests are rejected with 503 while draining. Keep below the container stop grace period, 10s by default for docker, so shutdown completes before charon is killed. Zero disables draining.")
+	cmd.Flags().BoolVar(&config.DebugPprof, "debug-pprof", false, "Enables serving pprof profiling endpoints on the monitoring API address.")
+	cmd.Flags().StringVar(&config.MonitoringBasicAuth, "monitoring-basic-auth", "", "Optional \"user:password\" credentials required via HTTP basic auth by the monitoring and debug APIs, except by the /livez and /readyz probes. Recommended if the monitoring API is exposed beyond the host.")
+	cmd.Flags().BoolVar(&config.DebugDutyTimeline, "debug-duty-timeline", false, "Enables logging a single consolidated debug line per duty summarizing when each core workflow step completed relative to the slot start, e.g. \"t0 scheduled, +120ms fetched, +310ms consensus decided, +450ms threshold reached, +520ms broadcast\". Requires debug log level for the tracker topic.")
+	cmd.Flags().StringVar(&config.PprofCaptureDir, "pprof-capture-dir", "", "Directory to capture heap and CPU profiles into when duties exceed their latency budgets. Only the most recent captures are retained. It is not enabled by default.")
+	cmd.Flags().StringVar(&config.BLSBackend, "bls-backend", tbls.BackendHerumi, fmt.Sprintf("BLS cryptography backend, one of: %s. Use 'charon alpha bench-bls' to compare backend performance on this host.", strings.Join(tbls.Backends(), ", ")))
+	cmd.Flags().StringVar(&config.ProposalGuardFile, "proposal-guard-file", "", "Enables the opt-in proposal guard: blocks partially signed by this node are persisted to the file, e.g. \".charon/proposal-guard.json\", refusing to sign a different block for the same validator and slot even after a restart. The file must be writable.")
+	cmd.Flags().BoolVar(&config.AttestationDataCrossCheck, "attestation-data-cross-check", false, "Enables fetching attestation data from all beacon nodes and comparing their head, source and target votes. Disagreements are logged and the majority vote is used, protecting against a single forked or buggy beacon node. Requires at least two beacon node endpoints.")
+	cmd.Flags().BoolVar(&config.ForceNetwork, "force-network", false, "Ignores a mismatch between the cluster lock's network and the beacon node's network, logging a warning instead of refusing to start. Signatures are then likely invalid, only use for custom test networks with non-standard fork versions.")
+	cmd.Flags().StringVar(&config.ReplayRecordFile, "replay-record-file", "", "Enables recording core workflow inputs, i.e. decided unsigned data, validator client submissions and peer partial signatures, to the file for deterministic replay via charon alpha replay. Intended for debugging, the file grows unbounded.")
+	cmd.Flags().StringVar(&config.ProxyRecordFile, "proxy-record-file", "", "Enables recording sanitized beacon node request/response pairs proxied by the validator API to the file, so beacon node quirks can be reproduced as test fixtures. Credentials are stripped. Intended for compatibility testing, the file grows unbounded.")
+	cmd.Flags().StringVar(&config.ProposalRewardsFile, "proposal-rewards-file", "", "The path to the file persisting the realized execution rewards, i.e. priority fees and MEV payments, of the cluster's included proposals across restarts. Builder blocks' rewards are determined from the builder relays' data and local blocks' rewards from the validator's fee recipient balance delta via the execution engine, excluding withdrawals. Rewards are reported per validator and month by the monitoring API's /validators/proposal_rewards endpoint, which is only served if an execution engine or builder relays are configured. Empty keeps rewards in memory only.")
+	cmd.Flags().StringVar(&config.AttestationTiming, "attestation-timing", string(bcast.AttestationTimingImmediate), "Strategy controlling when aggregated attestations are submitted to the beacon node; immediate on threshold, one_third at 1/3 into the slot, or adaptive on head arrival based on recent head arrival timing.")
+	cmd.Flags().StringSliceVar(&config.AckSlashedValidators, "acknowledge-slashed-validators", nil, "Comma-separated list of slashed validator public keys acknowledged by the operator. Signing attestations and proposals is stopped for validators observed as slashed unless acknowledged.")
+	cmd.Flags().StringSliceVar(&config.AlertWebhookURLs, "alert-webhook-urls", nil, "Comma-separated list of webhook URLs to POST a generic JSON alert payload to on critical events; missed block proposals, slashed validators, lost quorum peer connectivity and an unreachable beacon node.")
+	cmd.Flags().IntVar(&config.AlertBeaconNodeDownSlots, "alert-beacon-node-down-slots", 5, "Number of consecutive slots the beacon node must be unreachable before alerting via --alert-webhook-urls.")
+	cmd.Flags().StringVar(&config.TelemetryEndpoint, "telemetry-endpoint", "", "Opt-in URL to POST an hourly anonymized telemetry report to, containing only the charon version, network, cluster size and validator count buckets and duty success rates. Intended for pointing at an own collector. Telemetry is disabled by default.")
 
 	wrapPreRunE(cmd, func(cc *cobra.Command, _ []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
 			return errors.New("either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified")
 		}
 
+		if config.AttestationDataCrossCheck && len(config.BeaconNodeAddrs) < 2 && !config.SimnetBMock {
+			return errors.New("flag 'attestation-data-cross-check' requires at least two 'beacon-node-endpoints'")
+		}
+
 		if len(config.Nickname) > 32 {
 			return errors.New("flag 'nickname' can not exceed 32 characters")
 		}
@@ -137,6 +208,67 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 			return errors.New("both vc-tls-cert-file and vc-tls-key-file must be set or both must be empty")
 		}
 
+		if _, _, err := net.SplitHostPort(config.ValidatorAPIAddr); err != nil {
+			return errors.Wrap(err, "invalid flag 'validator-api-address', IPv6 addresses must be enclosed in square brackets", z.Str("address", config.ValidatorAPIAddr))
+		}
+
+		if config.BuilderRejectHeaderMismatch && !config.BuilderAPI {
+			return errors.New("flag 'builder-reject-header-mismatch' requires flag 'builder-api'")
+		}
+
+		if len(config.BuilderRelayAddrs) > 0 && !config.BuilderAPI {
+			return errors.New("flag 'builder-relay-endpoints' requires flag 'builder-api'")
+		}
+
+		if config.BuilderMinBid < 0 {
+			return errors.New("flag 'builder-min-bid' can not be negative")
+		} else if config.BuilderMinBid > 0 && !config.BuilderAPI {
+			return errors.New("flag 'builder-min-bid' requires flag 'builder-api'")
+		}
+
+		if _, err := registration.ParseGasLimitRamp(registration.DefaultGasLimit, config.GasLimitRamp); err != nil {
+			return err
+		}
+
+		if config.StartupWaitTimeout <= 0 {
+			return errors.New("flag 'startup-wait-timeout' must be positive")
+		}
+
+		if _, err := tbls.BackendImplementation(config.BLSBackend); err != nil {
+			return err
+		}
+
+		if config.ShutdownDrainTimeout < 0 {
+			return errors.New("flag 'shutdown-drain-timeout' can not be negative")
+		}
+
+		if config.AggregationNodes < 0 {
+			return errors.New("flag 'aggregation-nodes' can not be negative")
+		}
+
+		if _, err := bcast.ParseAttestationTiming(config.AttestationTiming); err != nil {
+			return err
+		}
+
+		if _, err := scheduler.ParseSlotOffsets(config.SlotOffsets); err != nil {
+			return err
+		}
+
+		if _, err := validatorapi.ParseProposalTypeOverrides(config.VCProposalTypeOverrides); err != nil {
+			return err
+		}
+
+		if _, err := validatorapi.ParseConcurrencyLimits(config.VCConcurrencyLimits); err != nil {
+			return err
+		}
+
+		if err := validatorapi.ValidateCORSConfig(validatorapi.CORSConfig{
+			AllowedOrigins: config.VCCORSAllowedOrigins,
+			AllowedHeaders: config.VCCORSAllowedHeaders,
+		}); err != nil {
+			return err
+		}
+
 		if config.VCTLSCertFile != "" && !app.FileExists(config.VCTLSCertFile) {
 			return errors.New("file vc-tls-cert-file does not exist", z.Str("file", config.VCTLSCertFile))
 		}
@@ -145,12 +277,75 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 			return errors.New("file vc-tls-key-file does not exist", z.Str("file", config.VCTLSKeyFile))
 		}
 
+		if user, _, ok := strings.Cut(config.MonitoringBasicAuth, ":"); config.MonitoringBasicAuth != "" && (!ok || user == "") {
+			return errors.New("flag 'monitoring-basic-auth' must be formatted as user:password")
+		}
+
+		if config.VCMonitoringToken != "" && !config.VCMonitoringEndpoints {
+			return errors.New("flag 'vc-monitoring-token' requires flag 'vc-monitoring-endpoints'")
+		}
+
+		if config.VCAuthTokensFile != "" {
+			if _, err := validatorapi.LoadVCTokens(config.VCAuthTokensFile); err != nil {
+				return err
+			}
+		}
+
+		if config.VCProxyRewritesFile != "" {
+			if _, err := validatorapi.LoadProxyRewrites(config.VCProxyRewritesFile); err != nil {
+				return err
+			}
+		}
+
+		if config.SyncMessageFallbackKeysDir != "" && !app.FileExists(config.SyncMessageFallbackKeysDir) {
+			return errors.New("directory sync-message-fallback-keys-dir does not exist", z.Str("dir", config.SyncMessageFallbackKeysDir))
+		}
+
+		if config.SyncMessageFallbackDelay < 0 {
+			return errors.New("flag 'sync-message-fallback-delay' can not be negative")
+		}
+
+		if config.AttestationFallbackKeysDir != "" && !app.FileExists(config.AttestationFallbackKeysDir) {
+			return errors.New("directory attestation-fallback-keys-dir does not exist", z.Str("dir", config.AttestationFallbackKeysDir))
+		}
+
+		if config.AttestationFallbackDelay < 0 {
+			return errors.New("flag 'attestation-fallback-delay' can not be negative")
+		}
+
+		if config.ProposalRehearsalInterval < 0 {
+			return errors.New("flag 'proposal-rehearsal-interval' can not be negative")
+		}
+
+		if config.ProposalRehearsalKeysDir != "" && !app.FileExists(config.ProposalRehearsalKeysDir) {
+			return errors.New("directory proposal-rehearsal-keys-dir does not exist", z.Str("dir", config.ProposalRehearsalKeysDir))
+		}
+
+		if config.EpochWorkSpreadSlots < 0 {
+			return errors.New("flag 'epoch-work-spread-slots' can not be negative")
+		}
+
+		if config.ClockSkewThreshold < 0 || config.ClockSkewThreshold > 1 {
+			return errors.New("flag 'clock-skew-threshold' must be between 0 and 1")
+		}
+
+		if config.SandboxChroot != "" && !app.FileExists(config.SandboxChroot) {
+			return errors.New("directory sandbox-chroot does not exist", z.Str("dir", config.SandboxChroot))
+		}
+
+		if config.MinPeerVersion != "" {
+			if _, err := version.Parse(config.MinPeerVersion); err != nil {
+				return errors.Wrap(err, "invalid flag 'min-peer-version'")
+			}
+		}
+
 		return nil
 	})
 }
 
 func bindUnsafeRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().BoolVar(&config.TestConfig.P2PFuzz, "p2p-fuzz", false, "Configures charon to send fuzzed data via p2p network to its peers.")
+	cmd.Flags().BoolVar(&config.TestConfig.P2PChaos, "p2p-chaos", false, "Enables chaos testing hooks injecting latency, drops and reordering into p2p messages sent to peers. Rules are controlled at runtime via the /debug/p2p/chaos endpoint of the debug API.")
 }
 
 func bindPrivKeyFlag(cmd *cobra.Command, privKeyFile *string, privkeyLockEnabled *bool) {
@@ -167,10 +362,11 @@ func bindLogFlags(flags *pflag.FlagSet, config *log.Config) {
 
 func bindP2PFlags(cmd *cobra.Command, config *p2p.Config) {
 	cmd.Flags().StringSliceVar(&config.Relays, "p2p-relays", []string{"https://0.relay.obol.tech", "https://2.relay.obol.dev", "https://1.relay.obol.tech"}, "Comma-separated list of libp2p relay URLs or multiaddrs.")
-	cmd.Flags().StringVar(&config.ExternalIP, "p2p-external-ip", "", "The IP address advertised by libp2p. This may be used to advertise an external IP.")
+	cmd.Flags().StringVar(&config.ExternalIP, "p2p-external-ip", "", "The IPv4 or IPv6 address advertised by libp2p. This may be used to advertise an external IP.")
 	cmd.Flags().StringVar(&config.ExternalHost, "p2p-external-hostname", "", "The DNS hostname advertised by libp2p. This may be used to advertise an external DNS.")
-	cmd.Flags().StringSliceVar(&config.TCPAddrs, "p2p-tcp-address", nil, "Comma-separated list of listening TCP addresses (ip and port) for libP2P traffic. Empty default doesn't bind to local port therefore only supports outgoing connections.")
+	cmd.Flags().StringSliceVar(&config.TCPAddrs, "p2p-tcp-address", nil, "Comma-separated list of listening TCP addresses (ip and port) for libP2P traffic. Empty default doesn't bind to local port therefore only supports outgoing connections. IPv6 addresses must be enclosed in square brackets, specify both IPv4 and IPv6 addresses for dual-stack, e.g. \"0.0.0.0:3610,[::]:3610\".")
 	cmd.Flags().BoolVar(&config.DisableReuseport, "p2p-disable-reuseport", false, "Disables TCP port reuse for outgoing libp2p connections.")
+	cmd.Flags().BoolVar(&config.PortMapping, "p2p-port-mapping", false, "Enables automatic mapping of the TCP listen ports on the local router via NAT-PMP or UPnP, so nodes behind consumer routers are directly reachable instead of only via relays. Mapped addresses are advertised and included in the runtime ENR.")
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
 		for _, relay := range config.Relays {
@@ -184,6 +380,10 @@ func bindP2PFlags(cmd *cobra.Command, config *p2p.Config) {
 			}
 		}
 
+		if config.ExternalIP != "" && net.ParseIP(config.ExternalIP) == nil {
+			return errors.New("invalid flag 'p2p-external-ip', expect IPv4 or IPv6 address", z.Str("ip", config.ExternalIP))
+		}
+
 		return nil
 	})
 }
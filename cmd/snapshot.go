@@ -0,0 +1,96 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/snapshot"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+type snapshotConfig struct {
+	DebugAddr  string
+	OutputFile string
+	Timeout    time.Duration
+}
+
+func newSnapshotCmd(runFunc func(context.Context, io.Writer, snapshotConfig) error) *cobra.Command {
+	var config snapshotConfig
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Dump the in-memory state of a running charon node",
+		Long: `Dumps the current in-memory state of the DutyDB, AggSigDB, scheduler timetable and validator cache ` +
+			`of a running charon node to a JSON file, enabling offline reproduction of production incidents. ` +
+			`Requires the node's debug API to be enabled via --debug-address.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.DebugAddr, "debug-address", "", "Address (ip and port) of the running node's debug API. [REQUIRED]")
+	cmd.Flags().StringVar(&config.OutputFile, "output-file", "charon-snapshot.json", "The path to the snapshot file to write.")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 30*time.Second, "Timeout for fetching the snapshot.")
+
+	mustMarkFlagRequired(cmd, "debug-address")
+
+	return cmd
+}
+
+func runSnapshot(ctx context.Context, out io.Writer, config snapshotConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	addr := config.DebugAddr
+	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+		addr = "http://" + addr
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+"/debug/snapshot", nil)
+	if err != nil {
+		return errors.Wrap(err, "create snapshot request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "fetch snapshot", z.Str("address", config.DebugAddr))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read snapshot")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("fetch snapshot failed", z.Int("status", resp.StatusCode), z.Str("body", string(body)))
+	}
+
+	var snap snapshot.Snapshot
+	if err := json.Unmarshal(body, &snap); err != nil {
+		return errors.Wrap(err, "unmarshal snapshot")
+	}
+
+	if err := snapshot.Write(config.OutputFile, snap); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "Snapshot written to %s: duty_db_attestations=%d duty_db_proposals=%d agg_sig_db=%d scheduler=%d validators=%d\n",
+		config.OutputFile, len(snap.DutyDB.Attestations), len(snap.DutyDB.Proposals), len(snap.AggSigDB), len(snap.Scheduler), len(snap.Validators))
+	if err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	return nil
+}
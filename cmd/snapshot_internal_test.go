@@ -0,0 +1,60 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/snapshot"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRunSnapshot(t *testing.T) {
+	expected := snapshot.Snapshot{
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+		DutyDB: dutydb.Snapshot{
+			AttesterPubKeys: []dutydb.AttesterPubKeyEntry{{Slot: 1, CommIdx: 2, ValIdx: 3, PubKey: testutil.RandomCorePubKey(t)}},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/debug/snapshot" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+
+	var out bytes.Buffer
+	err := runSnapshot(t.Context(), &out, snapshotConfig{
+		DebugAddr:  srv.Listener.Addr().String(),
+		OutputFile: file,
+		Timeout:    time.Second,
+	})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Snapshot written to")
+
+	actual, err := snapshot.Load(file)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	err = runSnapshot(t.Context(), &out, snapshotConfig{
+		DebugAddr:  srv.URL + "/unknown",
+		OutputFile: file,
+		Timeout:    time.Second,
+	})
+	require.ErrorContains(t, err, "fetch snapshot failed")
+}
@@ -103,6 +103,8 @@ func listTestCases(cmd *cobra.Command) []string {
 		testCaseNames = slices.Collect(maps.Keys(supportedBeaconTestCases()))
 	case validatorTestCategory:
 		testCaseNames = slices.Collect(maps.Keys(supportedValidatorTestCases()))
+	case validatorAPITestCategory:
+		testCaseNames = slices.Collect(maps.Keys(supportedValidatorAPITestCases()))
 	case mevTestCategory:
 		testCaseNames = slices.Collect(maps.Keys(supportedMEVTestCases()))
 	case infraTestCategory:
@@ -217,11 +219,12 @@ type testCategoryResult struct {
 }
 
 type allCategoriesResult struct {
-	Peers     testCategoryResult `json:"charon_peers,omitempty"`
-	Beacon    testCategoryResult `json:"beacon_node,omitempty"`
-	Validator testCategoryResult `json:"validator_client,omitempty"`
-	MEV       testCategoryResult `json:"mev,omitempty"`
-	Infra     testCategoryResult `json:"infra,omitempty"`
+	Peers        testCategoryResult `json:"charon_peers,omitempty"`
+	Beacon       testCategoryResult `json:"beacon_node,omitempty"`
+	Validator    testCategoryResult `json:"validator_client,omitempty"`
+	ValidatorAPI testCategoryResult `json:"validator_api,omitempty"`
+	MEV          testCategoryResult `json:"mev,omitempty"`
+	Infra        testCategoryResult `json:"infra,omitempty"`
 }
 
 func appendScore(cat []string, score []string) []string {
@@ -340,6 +343,8 @@ func writeResultToFile(res testCategoryResult, path string) error {
 		file.Beacon = res
 	case validatorTestCategory:
 		file.Validator = res
+	case validatorAPITestCategory:
+		file.ValidatorAPI = res
 	case mevTestCategory:
 		file.MEV = res
 	case infraTestCategory:
@@ -458,6 +458,8 @@ func testWriteFile(t *testing.T, expectedRes testCategoryResult, path string) {
 		actualRes = res.Beacon
 	case validatorTestCategory:
 		actualRes = res.Validator
+	case validatorAPITestCategory:
+		actualRes = res.ValidatorAPI
 	case mevTestCategory:
 		actualRes = res.MEV
 	case infraTestCategory:
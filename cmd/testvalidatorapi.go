@@ -0,0 +1,636 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const validatorAPITestCategory = "validator-api"
+
+type testValidatorAPIConfig struct {
+	testConfig
+
+	APIAddress string
+	APIToken   string
+}
+
+func newTestValidatorAPICmd(runFunc func(context.Context, io.Writer, testValidatorAPIConfig) (testCategoryResult, error)) *cobra.Command {
+	var config testValidatorAPIConfig
+
+	cmd := &cobra.Command{
+		Use:   validatorAPITestCategory,
+		Short: "Run Beacon-API conformance tests towards a running charon validator API",
+		Long: `Run Beacon-API conformance tests towards a running charon validator API. Verify content types, error codes, ` +
+			`SSZ/JSON parity and response metadata fields served to validator clients, e.g. after upgrades. ` +
+			`Only endpoints terminated by charon are tested, not those proxied to the beacon node.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return mustOutputToFileOnQuiet(cmd)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+			return err
+		},
+	}
+
+	bindTestFlags(cmd, &config.testConfig)
+	cmd.Flags().StringVar(&config.APIAddress, "validator-api-address", "http://127.0.0.1:3600", "Address of the charon validator API to test.")
+	cmd.Flags().StringVar(&config.APIToken, "validator-api-token", "", "Optional bearer token authenticating requests to the charon validator API.")
+
+	return cmd
+}
+
+func supportedValidatorAPITestCases() map[testCaseName]func(context.Context, *testValidatorAPIConfig) testResult {
+	return map[testCaseName]func(context.Context, *testValidatorAPIConfig) testResult{
+		{name: "NodeVersion", order: 1}:                       validatorAPINodeVersionTest,
+		{name: "AttestationDataErrorResponse", order: 2}:      validatorAPIAttestationDataErrorTest,
+		{name: "ProposalErrorResponse", order: 3}:             validatorAPIProposalErrorTest,
+		{name: "AggregateAttestationErrorResponse", order: 4}: validatorAPIAggregateAttestationErrorTest,
+		{name: "UnsupportedMediaType", order: 5}:              validatorAPIUnsupportedMediaTypeTest,
+		{name: "BlockHeaderMetadata", order: 6}:               validatorAPIBlockHeaderMetadataTest,
+		{name: "ProposerDutiesMetadata", order: 7}:            validatorAPIProposerDutiesMetadataTest,
+		{name: "AttesterDutiesMetadata", order: 8}:            validatorAPIAttesterDutiesMetadataTest,
+		{name: "SignedBlockSSZJSONParity", order: 9}:          validatorAPISignedBlockParityTest,
+	}
+}
+
+func runTestValidatorAPI(ctx context.Context, w io.Writer, cfg testValidatorAPIConfig) (res testCategoryResult, err error) {
+	log.Info(ctx, "Starting validator API conformance test")
+
+	testCases := supportedValidatorAPITestCases()
+
+	queuedTests := filterTests(slices.Collect(maps.Keys(testCases)), cfg.testConfig)
+	if len(queuedTests) == 0 {
+		err = errors.New("test case not supported")
+		return res, err
+	}
+
+	sortTests(queuedTests)
+
+	if !strings.HasPrefix(cfg.APIAddress, "http") {
+		cfg.APIAddress = "http://" + cfg.APIAddress
+	}
+
+	cfg.APIAddress = strings.TrimSuffix(cfg.APIAddress, "/")
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	startTime := time.Now()
+
+	var results []testResult
+
+	for _, tc := range queuedTests {
+		if timeoutCtx.Err() != nil {
+			results = append(results, testResult{Name: tc.name, Verdict: testVerdictFail, Error: errTimeoutInterrupted})
+			break
+		}
+
+		results = append(results, testCases[tc](timeoutCtx, &cfg))
+	}
+
+	res = testCategoryResult{
+		CategoryName:  validatorAPITestCategory,
+		Targets:       map[string][]testResult{cfg.APIAddress: results},
+		ExecutionTime: Duration{time.Since(startTime)},
+		Score:         calculateScore(results),
+	}
+
+	if !cfg.Quiet {
+		err = writeResultToWriter(res, w)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	if cfg.OutputJSON != "" {
+		err = writeResultToFile(res, cfg.OutputJSON)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	if cfg.Publish {
+		err = publishResultToObolAPI(ctx, allCategoriesResult{ValidatorAPI: res}, cfg.PublishAddr, cfg.PublishPrivateKeyFile)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// validator API conformance tests
+
+func validatorAPINodeVersionTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "NodeVersion"}
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, "/eth/v1/node/version", "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	var body struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := resp.decodeJSON(http.StatusOK, &body); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if body.Data.Version == "" {
+		return failedTestResult(testRes, errors.New("missing version"))
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIAttestationDataErrorTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "AttestationDataErrorResponse"}
+
+	// Attestation data requires the slot and committee_index query parameters.
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, "/eth/v1/validator/attestation_data", "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireErrorResponse(http.StatusBadRequest); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIProposalErrorTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "ProposalErrorResponse"}
+
+	_, slot, err := headBlockHeader(ctx, conf)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	// Proposals require the randao_reveal query parameter.
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, fmt.Sprintf("/eth/v3/validator/blocks/%d", slot+1), "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireErrorResponse(http.StatusBadRequest); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIAggregateAttestationErrorTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "AggregateAttestationErrorResponse"}
+
+	_, slot, err := headBlockHeader(ctx, conf)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	// Aggregate attestations require the attestation_data_root query parameter.
+	path := fmt.Sprintf("/eth/v2/validator/aggregate_attestation?slot=%d&committee_index=0", slot)
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, path, "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireErrorResponse(http.StatusBadRequest); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIUnsupportedMediaTypeTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "UnsupportedMediaType"}
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodPost, "/eth/v1/beacon/pool/sync_committees", "text/plain", []byte("[]"))
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireErrorResponse(http.StatusUnsupportedMediaType); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIBlockHeaderMetadataTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "BlockHeaderMetadata"}
+
+	if _, _, err := headBlockHeader(ctx, conf); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIProposerDutiesMetadataTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "ProposerDutiesMetadata"}
+
+	epoch, err := headEpoch(ctx, conf)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireDutiesMetadata(); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+func validatorAPIAttesterDutiesMetadataTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "AttesterDutiesMetadata"}
+
+	epoch, err := headEpoch(ctx, conf)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodPost, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), "application/json", []byte("[]"))
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := resp.requireDutiesMetadata(); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+// validatorAPISignedBlockParityTest verifies that the head block charon returns when preferring SSZ matches the block returned
+// as JSON, with the Eth-Consensus-Version header matching the version in the JSON response. Responding with JSON when
+// SSZ is preferred is allowed as long as the Content-Type header reflects it.
+func validatorAPISignedBlockParityTest(ctx context.Context, conf *testValidatorAPIConfig) testResult {
+	testRes := testResult{Name: "SignedBlockSSZJSONParity"}
+
+	root, _, err := headBlockHeader(ctx, conf)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	path := "/eth/v2/beacon/blocks/" + root
+
+	jsonResp, err := validatorAPIRequest(ctx, conf, http.MethodGet, path, "", nil)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	var body struct {
+		Version             string          `json:"version"`
+		ExecutionOptimistic *bool           `json:"execution_optimistic"`
+		Finalized           *bool           `json:"finalized"`
+		Data                json.RawMessage `json:"data"`
+	}
+	if err := jsonResp.decodeJSON(http.StatusOK, &body); err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if body.ExecutionOptimistic == nil || body.Finalized == nil {
+		return failedTestResult(testRes, errors.New("missing execution_optimistic or finalized metadata"))
+	}
+
+	if header := jsonResp.Header.Get("Eth-Consensus-Version"); header != "" && !strings.EqualFold(header, body.Version) {
+		return failedTestResult(testRes, errors.New("consensus version header mismatch", z.Str("header", header), z.Str("version", body.Version)))
+	}
+
+	jsonBlock, err := newSignedBlock(body.Version)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if err := json.Unmarshal(body.Data, jsonBlock); err != nil {
+		return failedTestResult(testRes, errors.Wrap(err, "unmarshal json block"))
+	}
+
+	sszResp, err := validatorAPIRequest(ctx, conf, http.MethodGet, path, "", nil, "application/octet-stream;q=1,application/json;q=0.9")
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	if sszResp.StatusCode != http.StatusOK {
+		return failedTestResult(testRes, errors.New(httpStatusError(sszResp.StatusCode)))
+	}
+
+	sszBlock, err := newSignedBlock(body.Version)
+	if err != nil {
+		return failedTestResult(testRes, err)
+	}
+
+	contentType := sszResp.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/octet-stream"):
+		if err := sszBlock.UnmarshalSSZ(sszResp.Body); err != nil {
+			return failedTestResult(testRes, errors.Wrap(err, "unmarshal ssz block"))
+		}
+	case strings.HasPrefix(contentType, "application/json"):
+		var sszBody struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := sszResp.decodeJSON(http.StatusOK, &sszBody); err != nil {
+			return failedTestResult(testRes, err)
+		}
+
+		if err := json.Unmarshal(sszBody.Data, sszBlock); err != nil {
+			return failedTestResult(testRes, errors.Wrap(err, "unmarshal json block"))
+		}
+	default:
+		return failedTestResult(testRes, errors.New("unexpected content type", z.Str("content_type", contentType)))
+	}
+
+	jsonRoot, err := jsonBlock.HashTreeRoot()
+	if err != nil {
+		return failedTestResult(testRes, errors.Wrap(err, "hash json block"))
+	}
+
+	sszRoot, err := sszBlock.HashTreeRoot()
+	if err != nil {
+		return failedTestResult(testRes, errors.Wrap(err, "hash ssz block"))
+	}
+
+	if jsonRoot != sszRoot {
+		return failedTestResult(testRes, errors.New("ssz and json blocks differ", z.Hex("json_root", jsonRoot[:]), z.Hex("ssz_root", sszRoot[:])))
+	}
+
+	testRes.Verdict = testVerdictOk
+
+	return testRes
+}
+
+// helper functions
+
+// validatorAPIResponse is a buffered validator API response.
+type validatorAPIResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// decodeJSON decodes the JSON response body into v, returning an error if the status code or content type is unexpected.
+func (r validatorAPIResponse) decodeJSON(expectStatus int, v any) error {
+	if r.StatusCode != expectStatus {
+		return errors.New(httpStatusError(r.StatusCode), z.Int("expected", expectStatus))
+	}
+
+	if contentType := r.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+		return errors.New("unexpected content type", z.Str("content_type", contentType))
+	}
+
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return errors.Wrap(err, "unmarshal json response")
+	}
+
+	return nil
+}
+
+// requireErrorResponse returns an error if the response isn't a Beacon-API error response with the expected status code.
+func (r validatorAPIResponse) requireErrorResponse(expectStatus int) error {
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := r.decodeJSON(expectStatus, &body); err != nil {
+		return err
+	}
+
+	if body.Code != expectStatus {
+		return errors.New("error response code mismatch", z.Int("code", body.Code), z.Int("status", expectStatus))
+	}
+
+	if body.Message == "" {
+		return errors.New("missing error response message")
+	}
+
+	return nil
+}
+
+// requireDutiesMetadata returns an error if the response isn't a duties response including metadata fields.
+func (r validatorAPIResponse) requireDutiesMetadata() error {
+	var body struct {
+		DependentRoot       string            `json:"dependent_root"`
+		ExecutionOptimistic *bool             `json:"execution_optimistic"`
+		Data                []json.RawMessage `json:"data"`
+	}
+	if err := r.decodeJSON(http.StatusOK, &body); err != nil {
+		return err
+	}
+
+	if !isHexRoot(body.DependentRoot) {
+		return errors.New("invalid dependent_root", z.Str("dependent_root", body.DependentRoot))
+	}
+
+	if body.ExecutionOptimistic == nil {
+		return errors.New("missing execution_optimistic")
+	}
+
+	if body.Data == nil {
+		return errors.New("missing data")
+	}
+
+	return nil
+}
+
+// validatorAPIRequest performs the request and returns the buffered response. The optional accept header defaults to JSON.
+func validatorAPIRequest(ctx context.Context, conf *testValidatorAPIConfig, method, path, contentType string, body []byte, accept ...string) (validatorAPIResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, conf.APIAddress+path, bytes.NewReader(body))
+	if err != nil {
+		return validatorAPIResponse{}, errors.Wrap(err, "create request")
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if len(accept) > 0 {
+		req.Header.Set("Accept", accept[0])
+	}
+
+	if conf.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+conf.APIToken)
+	}
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return validatorAPIResponse{}, errors.Wrap(err, "request validator api", z.Str("path", path))
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return validatorAPIResponse{}, errors.Wrap(err, "read response body")
+	}
+
+	return validatorAPIResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: b}, nil
+}
+
+// headBlockHeader returns the root and slot of the head block header, verifying its metadata fields.
+func headBlockHeader(ctx context.Context, conf *testValidatorAPIConfig) (string, uint64, error) {
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, "/eth/v1/beacon/headers/head", "", nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var body struct {
+		ExecutionOptimistic *bool `json:"execution_optimistic"`
+		Finalized           *bool `json:"finalized"`
+		Data                struct {
+			Root      string `json:"root"`
+			Canonical *bool  `json:"canonical"`
+			Header    struct {
+				Message struct {
+					Slot string `json:"slot"`
+				} `json:"message"`
+			} `json:"header"`
+		} `json:"data"`
+	}
+	if err := resp.decodeJSON(http.StatusOK, &body); err != nil {
+		return "", 0, err
+	}
+
+	if body.ExecutionOptimistic == nil || body.Finalized == nil {
+		return "", 0, errors.New("missing execution_optimistic or finalized metadata")
+	}
+
+	if !isHexRoot(body.Data.Root) || body.Data.Canonical == nil {
+		return "", 0, errors.New("invalid block header root or canonical field", z.Str("root", body.Data.Root))
+	}
+
+	slot, err := strconv.ParseUint(body.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "parse block header slot")
+	}
+
+	return body.Data.Root, slot, nil
+}
+
+// headEpoch returns the epoch of the head block.
+func headEpoch(ctx context.Context, conf *testValidatorAPIConfig) (uint64, error) {
+	_, slot, err := headBlockHeader(ctx, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := validatorAPIRequest(ctx, conf, http.MethodGet, "/eth/v1/config/spec", "", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var body struct {
+		Data struct {
+			SlotsPerEpoch string `json:"SLOTS_PER_EPOCH"`
+		} `json:"data"`
+	}
+	if err := resp.decodeJSON(http.StatusOK, &body); err != nil {
+		return 0, err
+	}
+
+	slotsPerEpoch, err := strconv.ParseUint(body.Data.SlotsPerEpoch, 10, 64)
+	if err != nil || slotsPerEpoch == 0 {
+		return 0, errors.New("invalid SLOTS_PER_EPOCH", z.Str("slots_per_epoch", body.Data.SlotsPerEpoch))
+	}
+
+	return slot / slotsPerEpoch, nil
+}
+
+// signedBlock is a signed beacon block supporting both JSON and SSZ encoding.
+type signedBlock interface {
+	json.Unmarshaler
+	ssz.Unmarshaler
+	HashTreeRoot() ([32]byte, error)
+}
+
+// newSignedBlock returns a new signed beacon block of the provided consensus version.
+func newSignedBlock(version string) (signedBlock, error) {
+	var dataVersion eth2spec.DataVersion
+	if err := dataVersion.UnmarshalJSON([]byte(strconv.Quote(strings.ToLower(version)))); err != nil {
+		return nil, errors.Wrap(err, "unknown consensus version", z.Str("version", version))
+	}
+
+	switch dataVersion {
+	case eth2spec.DataVersionPhase0:
+		return new(eth2p0.SignedBeaconBlock), nil
+	case eth2spec.DataVersionAltair:
+		return new(altair.SignedBeaconBlock), nil
+	case eth2spec.DataVersionBellatrix:
+		return new(bellatrix.SignedBeaconBlock), nil
+	case eth2spec.DataVersionCapella:
+		return new(capella.SignedBeaconBlock), nil
+	case eth2spec.DataVersionDeneb:
+		return new(deneb.SignedBeaconBlock), nil
+	case eth2spec.DataVersionElectra:
+		return new(electra.SignedBeaconBlock), nil
+	default:
+		return nil, errors.New("unsupported consensus version", z.Str("version", version))
+	}
+}
+
+// isHexRoot returns true if the string is a 0x-prefixed 32 byte hex root.
+func isHexRoot(s string) bool {
+	hex, ok := strings.CutPrefix(s, "0x")
+	if !ok || len(hex) != 64 {
+		return false
+	}
+
+	for _, c := range hex {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,273 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestValidatorAPITest(t *testing.T) {
+	conformant := startMockValidatorAPI(t, true)
+	nonConformant := startMockValidatorAPI(t, false)
+
+	okResults := []testResult{
+		{Name: "NodeVersion", Verdict: testVerdictOk},
+		{Name: "AttestationDataErrorResponse", Verdict: testVerdictOk},
+		{Name: "ProposalErrorResponse", Verdict: testVerdictOk},
+		{Name: "AggregateAttestationErrorResponse", Verdict: testVerdictOk},
+		{Name: "UnsupportedMediaType", Verdict: testVerdictOk},
+		{Name: "BlockHeaderMetadata", Verdict: testVerdictOk},
+		{Name: "ProposerDutiesMetadata", Verdict: testVerdictOk},
+		{Name: "AttesterDutiesMetadata", Verdict: testVerdictOk},
+		{Name: "SignedBlockSSZJSONParity", Verdict: testVerdictOk},
+	}
+
+	tests := []struct {
+		name        string
+		config      testValidatorAPIConfig
+		expected    testCategoryResult
+		expectedErr string
+	}{
+		{
+			name: "default scenario",
+			config: testValidatorAPIConfig{
+				testConfig: testConfig{Timeout: time.Minute},
+				APIAddress: conformant,
+				APIToken:   "token",
+			},
+			expected: testCategoryResult{
+				Targets:      map[string][]testResult{conformant: okResults},
+				Score:        categoryScoreA,
+				CategoryName: validatorAPITestCategory,
+			},
+		},
+		{
+			name: "address without scheme",
+			config: testValidatorAPIConfig{
+				testConfig: testConfig{Timeout: time.Minute, TestCases: []string{"NodeVersion"}},
+				APIAddress: strings.TrimPrefix(conformant, "http://"),
+				APIToken:   "token",
+			},
+			expected: testCategoryResult{
+				Targets: map[string][]testResult{conformant: {
+					{Name: "NodeVersion", Verdict: testVerdictOk},
+				}},
+				Score:        categoryScoreA,
+				CategoryName: validatorAPITestCategory,
+			},
+		},
+		{
+			name: "non-conformant",
+			config: testValidatorAPIConfig{
+				testConfig: testConfig{Timeout: time.Minute},
+				APIAddress: nonConformant,
+				APIToken:   "token",
+			},
+			expected: testCategoryResult{
+				Targets: map[string][]testResult{nonConformant: {
+					{Name: "NodeVersion", Verdict: testVerdictOk},
+					{Name: "AttestationDataErrorResponse", Verdict: testVerdictOk},
+					{Name: "ProposalErrorResponse", Verdict: testVerdictOk},
+					{Name: "AggregateAttestationErrorResponse", Verdict: testVerdictFail, Error: testResultError{errors.New(httpStatusError(http.StatusNotFound))}},
+					{Name: "UnsupportedMediaType", Verdict: testVerdictFail, Error: testResultError{errors.New(httpStatusError(http.StatusBadRequest))}},
+					{Name: "BlockHeaderMetadata", Verdict: testVerdictOk},
+					{Name: "ProposerDutiesMetadata", Verdict: testVerdictFail, Error: testResultError{errors.New("invalid dependent_root")}},
+					{Name: "AttesterDutiesMetadata", Verdict: testVerdictOk},
+					{Name: "SignedBlockSSZJSONParity", Verdict: testVerdictFail, Error: testResultError{errors.New("ssz and json blocks differ")}},
+				}},
+				Score:        categoryScoreC,
+				CategoryName: validatorAPITestCategory,
+			},
+		},
+		{
+			name: "unauthorized",
+			config: testValidatorAPIConfig{
+				testConfig: testConfig{Timeout: time.Minute, TestCases: []string{"NodeVersion"}},
+				APIAddress: conformant,
+			},
+			expected: testCategoryResult{
+				Targets: map[string][]testResult{conformant: {
+					{Name: "NodeVersion", Verdict: testVerdictFail, Error: testResultError{errors.New(httpStatusError(http.StatusUnauthorized))}},
+				}},
+				Score:        categoryScoreC,
+				CategoryName: validatorAPITestCategory,
+			},
+		},
+		{
+			name: "unsupported test",
+			config: testValidatorAPIConfig{
+				testConfig: testConfig{Timeout: time.Minute, TestCases: []string{"notSupportedTest"}},
+				APIAddress: conformant,
+			},
+			expectedErr: "test case not supported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			res, err := runTestValidatorAPI(context.Background(), &buf, test.config)
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected.CategoryName, res.CategoryName)
+			require.Equal(t, test.expected.Score, res.Score)
+			require.Len(t, res.Targets, len(test.expected.Targets))
+
+			for target, expected := range test.expected.Targets {
+				actual, ok := res.Targets[target]
+				require.True(t, ok)
+				require.Len(t, actual, len(expected))
+
+				for i, expectedRes := range expected {
+					require.Equal(t, expectedRes.Name, actual[i].Name)
+					require.Equal(t, expectedRes.Verdict, actual[i].Verdict)
+
+					if expectedRes.Error.error != nil {
+						require.ErrorContains(t, actual[i].Error, expectedRes.Error.Error())
+					} else {
+						require.NoError(t, actual[i].Error.error)
+					}
+				}
+			}
+
+			testWriteOut(t, test.expected, buf)
+		})
+	}
+}
+
+// startMockValidatorAPI starts a mock validator API requiring the "token" bearer token and returns its address.
+// A non-conformant mock responds with wrong error codes, omits duties metadata and serves a different SSZ block.
+func startMockValidatorAPI(t *testing.T, conformant bool) string {
+	t.Helper()
+
+	block := testutil.RandomDenebVersionedSignedBeaconBlock().Deneb
+	otherBlock := testutil.RandomDenebVersionedSignedBeaconBlock().Deneb
+	root := testutil.RandomRoot()
+
+	writeJSON := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+
+	writeError := func(w http.ResponseWriter, status int, msg string) {
+		writeJSON(w, status, map[string]any{"code": status, "message": msg})
+	}
+
+	dutiesResponse := map[string]any{
+		"dependent_root":       root.String(),
+		"execution_optimistic": false,
+		"data":                 []any{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]string{"version": "charon/v1.5.0"}})
+	})
+	mux.HandleFunc("/eth/v1/validator/attestation_data", func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusBadRequest, "missing query parameter slot")
+	})
+	mux.HandleFunc("/eth/v3/validator/blocks/101", func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusBadRequest, "missing 0x-hex query parameter randao_reveal")
+	})
+	mux.HandleFunc("/eth/v2/validator/aggregate_attestation", func(w http.ResponseWriter, r *http.Request) {
+		if !conformant || r.URL.Query().Get("slot") != "100" {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+
+		writeError(w, http.StatusBadRequest, "missing 0x-hex query parameter attestation_data_root")
+	})
+	mux.HandleFunc("/eth/v1/beacon/pool/sync_committees", func(w http.ResponseWriter, _ *http.Request) {
+		if !conformant {
+			writeError(w, http.StatusBadRequest, "failed parsing request body")
+			return
+		}
+
+		writeError(w, http.StatusUnsupportedMediaType, "unsupported media type")
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]string{"SLOTS_PER_EPOCH": "32"}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/headers/head", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"execution_optimistic": false,
+			"finalized":            false,
+			"data": map[string]any{
+				"root":      root.String(),
+				"canonical": true,
+				"header":    map[string]any{"message": map[string]string{"slot": "100"}},
+			},
+		})
+	})
+	mux.HandleFunc("/eth/v1/validator/duties/proposer/3", func(w http.ResponseWriter, _ *http.Request) {
+		if !conformant {
+			writeJSON(w, http.StatusOK, map[string]any{"data": []any{}})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, dutiesResponse)
+	})
+	mux.HandleFunc("/eth/v1/validator/duties/attester/3", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, dutiesResponse)
+	})
+	mux.HandleFunc("/eth/v2/beacon/blocks/"+root.String(), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Eth-Consensus-Version", "deneb")
+
+		if strings.HasPrefix(r.Header.Get("Accept"), "application/octet-stream") {
+			sszBlock := block
+			if !conformant {
+				sszBlock = otherBlock
+			}
+
+			b, err := sszBlock.MarshalSSZ()
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(b)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{
+			"version":              "deneb",
+			"execution_optimistic": false,
+			"finalized":            false,
+			"data":                 block,
+		})
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			_, _ = io.ReadAll(r.Body)
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
@@ -3,6 +3,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"runtime/debug"
@@ -15,19 +16,22 @@ import (
 )
 
 type versionConfig struct {
-	Verbose bool
+	Verbose        bool
+	Verify         bool
+	ManifestURL    string
+	ManifestPubKey string
 }
 
 // newVersionCmd returns the version command.
-func newVersionCmd(runFunc func(io.Writer, versionConfig)) *cobra.Command {
+func newVersionCmd(runFunc func(context.Context, io.Writer, versionConfig) error) *cobra.Command {
 	var conf versionConfig
 
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print version and exit",
 		Long:  "Output version info",
-		Run: func(cmd *cobra.Command, args []string) { //nolint:revive // keep args variable name for clarity
-			runFunc(cmd.OutOrStdout(), conf)
+		RunE: func(cmd *cobra.Command, args []string) error { //nolint:revive // keep args variable name for clarity
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), conf)
 		},
 	}
 
@@ -38,16 +42,28 @@ func newVersionCmd(runFunc func(io.Writer, versionConfig)) *cobra.Command {
 
 func bindVersionFlags(flags *pflag.FlagSet, config *versionConfig) {
 	flags.BoolVar(&config.Verbose, "verbose", false, "Includes detailed module version info and supported protocols")
+	flags.BoolVar(&config.Verify, "verify", false, "Verifies the running binary's version, git commit and checksum against the signed release manifest. Requires --manifest-url and --manifest-public-key.")
+	flags.StringVar(&config.ManifestURL, "manifest-url", "", "URL of the signed release manifest used by --verify.")
+	flags.StringVar(&config.ManifestPubKey, "manifest-public-key", "", "Hex encoded secp256k1 public key of the release manifest signer used by --verify.")
 }
 
-func runVersionCmd(out io.Writer, config versionConfig) {
+func runVersionCmd(ctx context.Context, out io.Writer, config versionConfig) error {
 	hash, timestamp := version.GitCommit()
 	_, _ = fmt.Fprintf(out, "%v [git_commit_hash=%s,git_commit_time=%s]\n", version.Version, hash, timestamp)
 
-	if !config.Verbose {
-		return
+	if config.Verbose {
+		writeVersionDetails(out)
+	}
+
+	if config.Verify {
+		return verifyBinary(ctx, out, config)
 	}
 
+	return nil
+}
+
+// writeVersionDetails writes the module dependencies and supported consensus protocols.
+func writeVersionDetails(out io.Writer) {
 	buildInfo, ok := debug.ReadBuildInfo()
 	if !ok {
 		_, _ = fmt.Fprint(out, "\nFailed to gather build info")
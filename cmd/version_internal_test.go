@@ -4,20 +4,30 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/core/consensus/protocols"
+	"github.com/obolnetwork/charon/testutil"
 )
 
 func TestRunVersionCmd(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		var buf bytes.Buffer
 
-		runVersionCmd(&buf, versionConfig{Verbose: false})
+		err := runVersionCmd(context.Background(), &buf, versionConfig{Verbose: false})
+		require.NoError(t, err)
 
 		str := buf.String()
 		require.Contains(t, str, "git_commit_hash")
@@ -36,7 +46,8 @@ func TestRunVersionCmd(t *testing.T) {
 	t.Run("verbose", func(t *testing.T) {
 		var buf bytes.Buffer
 
-		runVersionCmd(&buf, versionConfig{Verbose: true})
+		err := runVersionCmd(context.Background(), &buf, versionConfig{Verbose: true})
+		require.NoError(t, err)
 
 		str := buf.String()
 		require.Contains(t, str, "git_commit_hash")
@@ -47,3 +58,116 @@ func TestRunVersionCmd(t *testing.T) {
 		require.Contains(t, str, protocols.Protocols()[0])
 	})
 }
+
+func TestVerifyBinary(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+	pubkey := hex.EncodeToString(key.PubKey().SerializeCompressed())
+
+	checksum, err := executableChecksum()
+	require.NoError(t, err)
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+
+	signManifest := func(t *testing.T, manifest releaseManifest) signedReleaseManifest {
+		t.Helper()
+
+		b, err := json.Marshal(manifest)
+		require.NoError(t, err)
+
+		hash := sha256.Sum256(b)
+		sig, err := k1util.Sign(key, hash[:])
+		require.NoError(t, err)
+
+		return signedReleaseManifest{Manifest: b, Signature: "0x" + hex.EncodeToString(sig)}
+	}
+
+	valid := releaseManifest{
+		Version:   version.Version.String(),
+		Checksums: map[string]string{platform: checksum},
+	}
+
+	tamperedSig := signManifest(t, valid)
+	tamperedSig.Manifest = []byte(strings.Replace(string(tamperedSig.Manifest), checksum, strings.Repeat("0", 64), 1))
+
+	tests := []struct {
+		Name     string
+		Manifest signedReleaseManifest
+		PubKey   string
+		ErrMsg   string
+	}{
+		{
+			Name:     "valid",
+			Manifest: signManifest(t, valid),
+			PubKey:   pubkey,
+		},
+		{
+			Name:     "missing public key",
+			Manifest: signManifest(t, valid),
+			ErrMsg:   "--verify requires --manifest-url and --manifest-public-key",
+		},
+		{
+			Name:     "other signer",
+			Manifest: signManifest(t, valid),
+			PubKey:   hex.EncodeToString(testutil.GenerateInsecureK1Key(t, 1).PubKey().SerializeCompressed()),
+			ErrMsg:   "invalid release manifest signature",
+		},
+		{
+			Name:     "tampered manifest",
+			Manifest: tamperedSig,
+			PubKey:   pubkey,
+			ErrMsg:   "invalid release manifest signature",
+		},
+		{
+			Name: "other version",
+			Manifest: signManifest(t, releaseManifest{
+				Version:   "v0.1.0",
+				Checksums: map[string]string{platform: checksum},
+			}),
+			PubKey: pubkey,
+			ErrMsg: "binary version not in manifest",
+		},
+		{
+			Name: "other platform",
+			Manifest: signManifest(t, releaseManifest{
+				Version:   version.Version.String(),
+				Checksums: map[string]string{"plan9/mips": checksum},
+			}),
+			PubKey: pubkey,
+			ErrMsg: "platform not in manifest",
+		},
+		{
+			Name: "checksum mismatch",
+			Manifest: signManifest(t, releaseManifest{
+				Version:   version.Version.String(),
+				Checksums: map[string]string{platform: strings.Repeat("0", 64)},
+			}),
+			PubKey: pubkey,
+			ErrMsg: "binary checksum mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				require.NoError(t, json.NewEncoder(w).Encode(test.Manifest))
+			}))
+			defer srv.Close()
+
+			var buf bytes.Buffer
+
+			err := runVersionCmd(context.Background(), &buf, versionConfig{
+				Verify:         true,
+				ManifestURL:    srv.URL,
+				ManifestPubKey: test.PubKey,
+			})
+			if test.ErrMsg != "" {
+				require.ErrorContains(t, err, test.ErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, buf.String(), "Binary verified against signed release manifest")
+			require.Contains(t, buf.String(), checksum)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// signedReleaseManifest is a release manifest signed by the release signing key.
+type signedReleaseManifest struct {
+	// Manifest is the raw JSON encoded releaseManifest, kept raw since the signature is over its exact bytes.
+	Manifest json.RawMessage `json:"manifest"`
+	// Signature is the 0x-prefixed 65 byte secp256k1 signature of the SHA256 hash of the manifest bytes.
+	Signature string `json:"signature"`
+}
+
+// releaseManifest lists the checksums of the release binaries.
+type releaseManifest struct {
+	Version   string            `json:"version"`
+	GitCommit string            `json:"git_commit,omitempty"`
+	Checksums map[string]string `json:"checksums"` // Hex encoded SHA256 checksums by "<os>/<arch>".
+}
+
+// verifyBinary verifies the running binary's version, git commit and checksum against the signed
+// release manifest fetched from the configured URL.
+func verifyBinary(ctx context.Context, out io.Writer, config versionConfig) error {
+	if config.ManifestURL == "" || config.ManifestPubKey == "" {
+		return errors.New("--verify requires --manifest-url and --manifest-public-key")
+	}
+
+	pubkey, err := parseManifestPubKey(config.ManifestPubKey)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	signed, err := fetchReleaseManifest(ctx, config.ManifestURL)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := verifyReleaseManifest(signed, pubkey)
+	if err != nil {
+		return err
+	}
+
+	if manifest.Version != version.Version.String() {
+		return errors.New("binary version not in manifest", z.Str("version", version.Version.String()), z.Str("manifest_version", manifest.Version))
+	}
+
+	if manifest.GitCommit != "" {
+		hash, _ := version.GitCommit()
+		if len(hash) < 7 || !strings.HasPrefix(manifest.GitCommit, hash) {
+			return errors.New("binary git commit mismatch", z.Str("git_commit", hash), z.Str("manifest_git_commit", manifest.GitCommit))
+		}
+	}
+
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+
+	expected, ok := manifest.Checksums[platform]
+	if !ok {
+		return errors.New("platform not in manifest", z.Str("platform", platform))
+	}
+
+	checksum, err := executableChecksum()
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(strings.TrimPrefix(expected, "0x"), checksum) {
+		return errors.New("binary checksum mismatch", z.Str("checksum", checksum), z.Str("manifest_checksum", expected))
+	}
+
+	_, _ = fmt.Fprintf(out, "Binary verified against signed release manifest [platform=%s,sha256=%s]\n", platform, checksum)
+
+	return nil
+}
+
+// parseManifestPubKey returns the secp256k1 public key of the hex encoded compressed or uncompressed key.
+func parseManifestPubKey(hexKey string) (*k1.PublicKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode manifest public key hex")
+	}
+
+	pubkey, err := k1.ParsePubKey(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse manifest public key")
+	}
+
+	return pubkey, nil
+}
+
+// fetchReleaseManifest returns the signed release manifest served at the URL.
+func fetchReleaseManifest(ctx context.Context, url string) (signedReleaseManifest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return signedReleaseManifest{}, errors.Wrap(err, "new request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return signedReleaseManifest{}, errors.Wrap(err, "get release manifest")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return signedReleaseManifest{}, errors.New("unexpected release manifest status", z.Int("status", resp.StatusCode))
+	}
+
+	var signed signedReleaseManifest
+	if err := json.NewDecoder(resp.Body).Decode(&signed); err != nil {
+		return signedReleaseManifest{}, errors.Wrap(err, "decode release manifest")
+	}
+
+	return signed, nil
+}
+
+// verifyReleaseManifest returns the release manifest after verifying its signature.
+func verifyReleaseManifest(signed signedReleaseManifest, pubkey *k1.PublicKey) (releaseManifest, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signed.Signature, "0x"))
+	if err != nil {
+		return releaseManifest{}, errors.Wrap(err, "decode release manifest signature hex")
+	}
+
+	hash := sha256.Sum256(signed.Manifest)
+
+	ok, err := k1util.Verify65(pubkey, hash[:], sig)
+	if err != nil {
+		return releaseManifest{}, errors.Wrap(err, "verify release manifest signature")
+	} else if !ok {
+		return releaseManifest{}, errors.New("invalid release manifest signature")
+	}
+
+	var manifest releaseManifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		return releaseManifest{}, errors.Wrap(err, "unmarshal release manifest")
+	}
+
+	return manifest, nil
+}
+
+// executableChecksum returns the hex encoded SHA256 checksum of the running binary.
+func executableChecksum() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", errors.Wrap(err, "get executable path")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "open executable")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "hash executable")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
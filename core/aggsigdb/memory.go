@@ -19,6 +19,7 @@ func NewMemDB(deadliner core.Deadliner) *MemDB {
 		keysByDuty:     make(map[core.Duty][]memDBKey),
 		commands:       make(chan writeCommand),
 		queries:        make(chan readQuery),
+		snapshots:      make(chan chan []Entry),
 		blockedQueries: []readQuery{},
 		queryCallback:  func([]readQuery) {},
 		quit:           make(chan struct{}),
@@ -33,6 +34,7 @@ type MemDB struct {
 
 	commands       chan writeCommand
 	queries        chan readQuery
+	snapshots      chan chan []Entry
 	blockedQueries []readQuery
 	queryCallback  func([]readQuery) // Callback for testing.
 
@@ -113,6 +115,21 @@ func (db *MemDB) Await(ctx context.Context, duty core.Duty, pubKey core.PubKey)
 	}
 }
 
+// Snapshot returns a point-in-time copy of the database entries.
+func (db *MemDB) Snapshot(ctx context.Context) ([]Entry, error) {
+	response := make(chan []Entry, 1)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-db.quit:
+		return nil, ErrStopped
+	case db.snapshots <- response:
+	}
+
+	return <-response, nil
+}
+
 // Run blocks and runs the database process until the context is cancelled.
 func (db *MemDB) Run(ctx context.Context) {
 	defer close(db.quit)
@@ -128,6 +145,8 @@ func (db *MemDB) Run(ctx context.Context) {
 				db.blockedQueries = append(db.blockedQueries, query)
 				db.callbackBlockedQueriesForT()
 			}
+		case response := <-db.snapshots:
+			response <- snapshot(db.data)
 		case duty := <-db.deadliner.C():
 			for _, key := range db.keysByDuty[duty] {
 				delete(db.data, key)
@@ -21,6 +21,14 @@ type MemDBV2 struct {
 	closed     chan struct{}
 }
 
+// Snapshot returns a point-in-time copy of the database entries.
+func (m *MemDBV2) Snapshot(context.Context) ([]Entry, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	return snapshot(m.data), nil
+}
+
 // NewMemDBV2 creates a basic memory based AggSigDB.
 func NewMemDBV2(deadliner core.Deadliner) *MemDBV2 {
 	return &MemDBV2{
@@ -0,0 +1,28 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package aggsigdb
+
+import (
+	"github.com/obolnetwork/charon/core"
+)
+
+// Entry is an aggregated signed data entry of the database, used for debugging snapshots.
+type Entry struct {
+	Duty   core.Duty
+	PubKey core.PubKey
+	Data   core.SignedData
+}
+
+// snapshot returns the entries of the data.
+func snapshot(data map[memDBKey]core.SignedData) []Entry {
+	resp := make([]Entry, 0, len(data))
+	for key, signed := range data {
+		resp = append(resp, Entry{
+			Duty:   key.duty,
+			PubKey: key.pubKey,
+			Data:   signed,
+		})
+	}
+
+	return resp
+}
@@ -23,22 +23,80 @@ import (
 	"github.com/obolnetwork/charon/tbls"
 )
 
+// Option configures the broadcaster.
+type Option func(*options)
+
+type options struct {
+	attTiming AttestationTiming
+	relays    RelayClient
+}
+
+// RelayClient talks to MEV-Boost relays directly via the builder API.
+type RelayClient interface {
+	// SubmitValidatorRegistrations submits validator registrations to the relays.
+	SubmitValidatorRegistrations(ctx context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error
+	// UnblindProposal returns the proposal unblinded by the relay that provided its payload header.
+	UnblindProposal(ctx context.Context, proposal *eth2api.VersionedSignedBlindedProposal) (*eth2api.VersionedSignedProposal, error)
+}
+
+// WithAttestationTiming returns an option configuring when aggregated attestations are submitted to the beacon node.
+func WithAttestationTiming(timing AttestationTiming) Option {
+	return func(o *options) {
+		o.attTiming = timing
+	}
+}
+
+// WithRelays returns an option submitting validator registrations to the relays instead of the beacon node
+// and unblinding blinded proposals via the relays that served their bid before submitting them to the beacon node,
+// so that beacon nodes do not require mev-boost sidecars. Blinded proposals are submitted to the beacon node
+// if unblinding fails.
+func WithRelays(relays RelayClient) Option {
+	return func(o *options) {
+		o.relays = relays
+	}
+}
+
 // New returns a new broadcaster instance.
-func New(ctx context.Context, eth2Cl eth2wrap.Client) (Broadcaster, error) {
+func New(ctx context.Context, eth2Cl eth2wrap.Client, opts ...Option) (Broadcaster, error) {
+	o := options{attTiming: AttestationTimingImmediate}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	delayFunc, err := newDelayFunc(ctx, eth2Cl)
 	if err != nil {
 		return Broadcaster{}, err
 	}
 
+	genesisTime, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+	if err != nil {
+		return Broadcaster{}, err
+	}
+
+	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return Broadcaster{}, err
+	}
+
 	return Broadcaster{
 		eth2Cl:    eth2Cl,
 		delayFunc: delayFunc,
+		attTimer:  newAttTimer(o.attTiming, genesisTime, slotDuration),
+		relays:    o.relays,
 	}, nil
 }
 
 type Broadcaster struct {
 	eth2Cl    eth2wrap.Client
 	delayFunc func(slot uint64, duty core.DutyType) time.Duration
+	attTimer  *attTimer
+	relays    RelayClient // Nil if relays are not called directly.
+}
+
+// HeadReceived informs the broadcaster of the arrival of a new beacon chain head,
+// used by the adaptive attestation timing strategy.
+func (b Broadcaster) HeadReceived(ctx context.Context, slot uint64, received time.Time) {
+	b.attTimer.HeadReceived(ctx, slot, received)
 }
 
 // Broadcast broadcasts the aggregated signed duty data object to the beacon-node.
@@ -149,6 +207,13 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			}
 		}
 
+		waited, err := b.attTimer.Wait(ctx, duty.Slot)
+		if err != nil {
+			return errors.Wrap(err, "wait for attestation submission")
+		}
+
+		attTimingDelay.WithLabelValues(string(b.attTimer.timing)).Observe(waited.Seconds())
+
 		err = b.eth2Cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 		if err != nil && strings.Contains(err.Error(), "PriorAttestationKnown") {
 			// Lighthouse isn't idempotent, so just swallow this non-issue.
@@ -189,9 +254,7 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 				return errors.Wrap(err, "cannot broadcast, expected blinded proposal")
 			}
 
-			err = b.eth2Cl.SubmitBlindedProposal(ctx, &eth2api.SubmitBlindedProposalOpts{
-				Proposal: &blinded,
-			})
+			err = b.submitBlindedProposal(ctx, &blinded)
 		} else {
 			err = b.eth2Cl.SubmitProposal(ctx, &eth2api.SubmitProposalOpts{
 				Proposal: &block.VersionedSignedProposal,
@@ -226,6 +289,18 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			return err
 		}
 
+		if b.relays != nil {
+			// Relays are called directly, so beacon nodes don't need to forward registrations to a mev-boost sidecar.
+			err = b.relays.SubmitValidatorRegistrations(ctx, registrations)
+			if err == nil {
+				log.Info(ctx, "Successfully submitted validator registrations to relays",
+					z.Any("delay", b.delayFunc(duty.Slot, core.DutyBuilderRegistration)),
+				)
+			}
+
+			return err
+		}
+
 		err = b.eth2Cl.SubmitValidatorRegistrations(ctx, registrations)
 		if err == nil {
 			log.Info(ctx, "Successfully submitted validator registrations to beacon node",
@@ -359,6 +434,26 @@ func setToAggAndProof(set core.SignedDataSet) (*eth2api.SubmitAggregateAttestati
 	return &eth2api.SubmitAggregateAttestationsOpts{SignedAggregateAndProofs: resp}, nil
 }
 
+// submitBlindedProposal unblinds the blinded proposal via the relays, if configured, and submits the
+// unblinded proposal to the beacon node. Otherwise, or if unblinding fails, the blinded proposal is
+// submitted to the beacon node which unblinds it via its builder sidecar.
+func (b Broadcaster) submitBlindedProposal(ctx context.Context, blinded *eth2api.VersionedSignedBlindedProposal) error {
+	if b.relays != nil {
+		proposal, err := b.relays.UnblindProposal(ctx, blinded)
+		if err == nil {
+			return b.eth2Cl.SubmitProposal(ctx, &eth2api.SubmitProposalOpts{
+				Proposal: proposal,
+			})
+		}
+
+		log.Warn(ctx, "Failed to unblind proposal via relays, submitting blinded proposal to beacon node", err)
+	}
+
+	return b.eth2Cl.SubmitBlindedProposal(ctx, &eth2api.SubmitBlindedProposalOpts{
+		Proposal: blinded,
+	})
+}
+
 // setToRegistrations converts a set of signed data into a list of registrations.
 func setToRegistrations(set core.SignedDataSet) ([]*eth2api.VersionedSignedValidatorRegistration, error) {
 	var resp []*eth2api.VersionedSignedValidatorRegistration
@@ -451,21 +546,15 @@ func firstSlotInCurrentEpoch(ctx context.Context, eth2Cl eth2wrap.Client) (uint6
 
 // resolveActiveValidatorsIndices returns the active validators (including their validator index) for the slot.
 func resolveActiveValidatorsIndices(ctx context.Context, eth2Cl eth2wrap.Client, epoch eth2p0.Epoch) ([]eth2p0.ValidatorIndex, error) {
-	eth2Resp, err := eth2Cl.CompleteValidators(ctx)
+	states, err := eth2Cl.ValidatorStates(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var indices []eth2p0.ValidatorIndex
 
-	for index, val := range eth2Resp {
-		if val == nil || val.Validator == nil {
-			return nil, errors.New("validator data cannot be nil")
-		}
-
-		// Check for active validators for the given epoch.
-		// The activation epoch needs to be checked in cases where this function is called before the epoch starts.
-		if !val.Status.IsActive() && val.Validator.ActivationEpoch != epoch {
+	for index, state := range states {
+		if !state.ActiveOrActivating(epoch) {
 			continue
 		}
 
@@ -316,3 +316,68 @@ func syncCommitteeContribution(t *testing.T, mock *beaconmock.Mock) test {
 		asserted: asserted,
 	}
 }
+
+// testRelays is a stub relay client.
+type testRelays struct {
+	registrations []*eth2api.VersionedSignedValidatorRegistration
+	unblindErr    error
+	unblinded     *eth2api.VersionedSignedProposal
+}
+
+func (r *testRelays) SubmitValidatorRegistrations(_ context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error {
+	r.registrations = registrations
+	return nil
+}
+
+func (r *testRelays) UnblindProposal(context.Context, *eth2api.VersionedSignedBlindedProposal) (*eth2api.VersionedSignedProposal, error) {
+	return r.unblinded, r.unblindErr
+}
+
+func TestBroadcastRelays(t *testing.T) {
+	mock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var (
+		submitted        []*eth2api.VersionedSignedProposal
+		submittedBlinded []*eth2api.VersionedSignedBlindedProposal
+		relays           = &testRelays{unblinded: &eth2api.VersionedSignedProposal{Version: eth2spec.DataVersionDeneb}}
+	)
+
+	mock.SubmitProposalFunc = func(_ context.Context, opts *eth2api.SubmitProposalOpts) error {
+		submitted = append(submitted, opts.Proposal)
+		return nil
+	}
+	mock.SubmitBlindedProposalFunc = func(_ context.Context, opts *eth2api.SubmitBlindedProposalOpts) error {
+		submittedBlinded = append(submittedBlinded, opts.Proposal)
+		return nil
+	}
+
+	bcaster, err := bcast.New(t.Context(), mock, bcast.WithRelays(relays))
+	require.NoError(t, err)
+
+	set := core.SignedDataSet{testutil.RandomCorePubKey(t): testutil.RandomDenebVersionedSignedBlindedProposal()}
+
+	// Blinded proposals are unblinded via the relays and submitted to the beacon node.
+	require.NoError(t, bcaster.Broadcast(t.Context(), core.Duty{Type: core.DutyProposer}, set))
+	require.Equal(t, []*eth2api.VersionedSignedProposal{relays.unblinded}, submitted)
+	require.Empty(t, submittedBlinded)
+
+	// Blinded proposals are submitted to the beacon node if unblinding fails.
+	relays.unblindErr = errors.New("unknown payload")
+
+	require.NoError(t, bcaster.Broadcast(t.Context(), core.Duty{Type: core.DutyProposer}, set))
+	require.Len(t, submitted, 1)
+	require.Len(t, submittedBlinded, 1)
+
+	// Validator registrations are submitted to the relays only.
+	mock.SubmitValidatorRegistrationsFunc = func(context.Context, []*eth2api.VersionedSignedValidatorRegistration) error {
+		require.Fail(t, "unexpected beacon node registration")
+		return nil
+	}
+
+	registration := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+
+	require.NoError(t, bcaster.Broadcast(t.Context(), core.Duty{Type: core.DutyBuilderRegistration},
+		core.SignedDataSet{testutil.RandomCorePubKey(t): registration}))
+	require.Equal(t, []*eth2api.VersionedSignedValidatorRegistration{&registration.VersionedSignedValidatorRegistration}, relays.registrations)
+}
@@ -27,6 +27,14 @@ var (
 		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60},
 	}, []string{"duty"})
 
+	attTimingDelay = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "bcast",
+		Name:      "attestation_timing_delay_seconds",
+		Help:      "Time aggregated attestations were held back before submission in seconds by attestation timing strategy",
+		Buckets:   []float64{0, .1, .25, .5, 1, 2, 3, 4, 6, 8},
+	}, []string{"strategy"})
+
 	recastRegistrationCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "bcast",
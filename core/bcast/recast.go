@@ -115,6 +115,38 @@ func (r *Recaster) SlotTicked(ctx context.Context, slot core.Slot) error {
 		return errors.Wrap(err, "get active validator")
 	}
 
+	r.recast(ctx, func(pubkey core.PubKey) bool {
+		ethPk, err := pubkey.ToETH2()
+		if err != nil {
+			log.Error(ctx, "Can't convert pubkey to eth2 format", err)
+			return false
+		}
+
+		_, found := activeVals[ethPk]
+
+		return found
+	})
+
+	return nil
+}
+
+// NewValidators rebroadcasts the registrations of validators that became active after the epoch's rebroadcast,
+// so they are submitted without waiting for the next epoch.
+func (r *Recaster) NewValidators(ctx context.Context, _ core.Slot, pubkeys []core.PubKey) error {
+	newVals := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		newVals[pubkey] = true
+	}
+
+	r.recast(log.WithTopic(ctx, "bcast"), func(pubkey core.PubKey) bool {
+		return newVals[pubkey]
+	})
+
+	return nil
+}
+
+// recast rebroadcasts the stored registrations of the included validators.
+func (r *Recaster) recast(ctx context.Context, include func(core.PubKey) bool) {
 	// Copy locked things before doing IO.
 	var (
 		clonedSets = make(map[core.Duty]map[core.PubKey]core.SignedData)
@@ -125,13 +157,7 @@ func (r *Recaster) SlotTicked(ctx context.Context, slot core.Slot) error {
 
 	clonedSubs = append(clonedSubs, r.subs...)
 	for pubkey, tuple := range r.tuples {
-		ethPk, err := pubkey.ToETH2()
-		if err != nil {
-			log.Error(ctx, "Can't convert pubkey to eth2 format", err)
-			continue
-		}
-
-		if _, found := activeVals[ethPk]; !found {
+		if !include(pubkey) {
 			continue
 		}
 
@@ -159,8 +185,6 @@ func (r *Recaster) SlotTicked(ctx context.Context, slot core.Slot) error {
 			incRegCounter(duty, recastTotal)
 		}
 	}
-
-	return nil
 }
 
 // incRegCounter increments the registration counter if applicable.
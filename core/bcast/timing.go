@@ -0,0 +1,158 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// AttestationTiming defines when aggregated attestations are submitted to the beacon node.
+type AttestationTiming string
+
+const (
+	// AttestationTimingImmediate submits attestations as soon as threshold signatures are aggregated.
+	AttestationTimingImmediate AttestationTiming = "immediate"
+	// AttestationTimingOneThird submits attestations no earlier than 1/3 into the slot.
+	AttestationTimingOneThird AttestationTiming = "one_third"
+	// AttestationTimingAdaptive submits attestations once the slot's head is received,
+	// or otherwise no later than the recently observed head arrival time, capped at 1/3 into the slot.
+	AttestationTimingAdaptive AttestationTiming = "adaptive"
+)
+
+const (
+	// maxHeadArrivals is the number of recent head arrival delays considered by the adaptive strategy.
+	maxHeadArrivals = 32
+	// headArrivalPercentile is the percentile of recent head arrival delays the adaptive strategy waits for.
+	headArrivalPercentile = 0.9
+)
+
+// AttestationTimings returns all supported attestation timing strategies.
+func AttestationTimings() []AttestationTiming {
+	return []AttestationTiming{
+		AttestationTimingImmediate,
+		AttestationTimingOneThird,
+		AttestationTimingAdaptive,
+	}
+}
+
+// ParseAttestationTiming returns the attestation timing strategy of the provided string.
+func ParseAttestationTiming(s string) (AttestationTiming, error) {
+	timing := AttestationTiming(s)
+	if !slices.Contains(AttestationTimings(), timing) {
+		return "", errors.New("invalid attestation timing strategy", z.Str("timing", s))
+	}
+
+	return timing, nil
+}
+
+// newAttTimer returns a new attestation timer for the provided strategy.
+func newAttTimer(timing AttestationTiming, genesisTime time.Time, slotDuration time.Duration) *attTimer {
+	return &attTimer{
+		timing:       timing,
+		genesisTime:  genesisTime,
+		slotDuration: slotDuration,
+		headCh:       make(chan struct{}),
+		nowFunc:      time.Now,
+	}
+}
+
+// attTimer delays the submission of attestations according to the configured strategy.
+type attTimer struct {
+	timing       AttestationTiming
+	genesisTime  time.Time
+	slotDuration time.Duration
+	nowFunc      func() time.Time
+
+	mu       sync.Mutex
+	headSlot uint64
+	delays   []time.Duration
+	headCh   chan struct{} // Closed and replaced on each new head.
+}
+
+// HeadReceived records the arrival of a new head, releasing attestations waiting for it.
+func (t *attTimer) HeadReceived(_ context.Context, slot uint64, received time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slot <= t.headSlot {
+		return // Only the first head event per slot is relevant.
+	}
+
+	t.headSlot = slot
+
+	if delay := received.Sub(t.slotStart(slot)); delay >= 0 && delay < t.slotDuration {
+		t.delays = append(t.delays, delay)
+		if len(t.delays) > maxHeadArrivals {
+			t.delays = t.delays[1:]
+		}
+	}
+
+	close(t.headCh)
+	t.headCh = make(chan struct{})
+}
+
+// Wait blocks until the attestations of the slot should be submitted, returning the time waited.
+func (t *attTimer) Wait(ctx context.Context, slot uint64) (time.Duration, error) {
+	start := t.nowFunc()
+
+	for {
+		release, headCh := t.releaseTime(slot)
+
+		wait := release.Sub(t.nowFunc())
+		if wait <= 0 {
+			return t.nowFunc().Sub(start), nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		case <-headCh:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// releaseTime returns the time attestations of the slot should be submitted and a channel closed on the next head.
+func (t *attTimer) releaseTime(slot uint64) (time.Time, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	slotStart := t.slotStart(slot)
+	oneThird := slotStart.Add(t.slotDuration / 3)
+
+	switch t.timing {
+	case AttestationTimingOneThird:
+		return oneThird, t.headCh
+	case AttestationTimingAdaptive:
+		if t.headSlot >= slot {
+			return slotStart, t.headCh // Head already received.
+		} else if len(t.delays) == 0 {
+			return oneThird, t.headCh
+		}
+
+		sorted := slices.Clone(t.delays)
+		slices.Sort(sorted)
+		expected := slotStart.Add(sorted[int(float64(len(sorted)-1)*headArrivalPercentile)])
+
+		if expected.After(oneThird) {
+			return oneThird, t.headCh
+		}
+
+		return expected, t.headCh
+	default:
+		return slotStart, t.headCh
+	}
+}
+
+func (t *attTimer) slotStart(slot uint64) time.Time {
+	return t.genesisTime.Add(t.slotDuration * time.Duration(slot))
+}
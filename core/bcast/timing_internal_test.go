@@ -0,0 +1,120 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package bcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttestationTiming(t *testing.T) {
+	for _, timing := range AttestationTimings() {
+		parsed, err := ParseAttestationTiming(string(timing))
+		require.NoError(t, err)
+		require.Equal(t, timing, parsed)
+	}
+
+	_, err := ParseAttestationTiming("late")
+	require.ErrorContains(t, err, "invalid attestation timing strategy")
+}
+
+func TestAttTimerReleaseTime(t *testing.T) {
+	const (
+		slot         = 10
+		slotDuration = 12 * time.Second
+	)
+
+	genesis := time.Unix(0, 0)
+	slotStart := genesis.Add(slot * slotDuration)
+	oneThird := slotStart.Add(slotDuration / 3)
+
+	tests := []struct {
+		name     string
+		timing   AttestationTiming
+		heads    []time.Duration // Head arrival delays of the preceding slots.
+		headSlot bool            // Whether the head of the slot was received.
+		expected time.Time
+	}{
+		{
+			name:     "immediate",
+			timing:   AttestationTimingImmediate,
+			expected: slotStart,
+		},
+		{
+			name:     "one third",
+			timing:   AttestationTimingOneThird,
+			headSlot: true,
+			expected: oneThird,
+		},
+		{
+			name:     "adaptive without history",
+			timing:   AttestationTimingAdaptive,
+			expected: oneThird,
+		},
+		{
+			name:     "adaptive with history",
+			timing:   AttestationTimingAdaptive,
+			heads:    []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			expected: slotStart.Add(2 * time.Second),
+		},
+		{
+			name:     "adaptive with late history",
+			timing:   AttestationTimingAdaptive,
+			heads:    []time.Duration{6 * time.Second, 7 * time.Second},
+			expected: oneThird,
+		},
+		{
+			name:     "adaptive with head received",
+			timing:   AttestationTimingAdaptive,
+			heads:    []time.Duration{3 * time.Second},
+			headSlot: true,
+			expected: slotStart,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timer := newAttTimer(test.timing, genesis, slotDuration)
+
+			for i, delay := range test.heads {
+				headSlot := uint64(slot - len(test.heads) + i)
+				timer.HeadReceived(t.Context(), headSlot, timer.slotStart(headSlot).Add(delay))
+			}
+
+			if test.headSlot {
+				timer.HeadReceived(t.Context(), slot, slotStart.Add(time.Second))
+			}
+
+			release, _ := timer.releaseTime(slot)
+			require.Equal(t, test.expected, release)
+		})
+	}
+}
+
+func TestAttTimerWaitForHead(t *testing.T) {
+	const slotDuration = 12 * time.Second
+
+	// Current slot started just now, so the adaptive strategy waits for the head or 1/3 into the slot.
+	slot := uint64(100)
+	genesis := time.Now().Add(-slotDuration * time.Duration(slot))
+
+	timer := newAttTimer(AttestationTimingAdaptive, genesis, slotDuration)
+
+	done := make(chan time.Duration)
+	go func() {
+		waited, err := timer.Wait(t.Context(), slot)
+		require.NoError(t, err)
+		done <- waited
+	}()
+
+	timer.HeadReceived(t.Context(), slot, time.Now())
+
+	select {
+	case waited := <-done:
+		require.Less(t, waited, slotDuration/3)
+	case <-time.After(slotDuration / 3):
+		require.Fail(t, "timeout waiting for head release")
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// defaultExplainRounds is the default number of rounds explained by the leader handler.
+	defaultExplainRounds = 3
+	// maxExplainRounds is the maximum number of rounds explained by the leader handler.
+	maxExplainRounds = 100
+)
+
+// LeaderSelection describes the deterministic inputs and the resulting leader of a consensus instance round.
+type LeaderSelection struct {
+	Slot        uint64 `json:"slot"`
+	DutyType    string `json:"duty_type"`
+	DutyTypeID  int    `json:"duty_type_id"`
+	Round       int64  `json:"round"`
+	Nodes       int    `json:"nodes"`
+	LeaderIndex int64  `json:"leader_index"`
+	LeaderName  string `json:"leader_name,omitempty"`
+	Formula     string `json:"formula"`
+}
+
+// ExplainLeader returns the leader selection of the duty's consensus round among the cluster peers.
+func ExplainLeader(duty core.Duty, round int64, peers []p2p.Peer) LeaderSelection {
+	nodes := len(peers)
+	index := leader(duty, round, nodes)
+
+	return LeaderSelection{
+		Slot:        duty.Slot,
+		DutyType:    duty.Type.String(),
+		DutyTypeID:  int(duty.Type),
+		Round:       round,
+		Nodes:       nodes,
+		LeaderIndex: index,
+		LeaderName:  peers[index].Name,
+		Formula:     leaderFormula(duty, round, nodes),
+	}
+}
+
+// LeaderIndex returns the peer index of the duty's consensus round leader among the cluster nodes.
+func LeaderIndex(duty core.Duty, round int64, nodes int) int {
+	return int(leader(duty, round, nodes))
+}
+
+// ExplainLeaderRounds returns the leader selections of the first rounds of the duty's consensus instance.
+func ExplainLeaderRounds(duty core.Duty, rounds int64, peers []p2p.Peer) []LeaderSelection {
+	var resp []LeaderSelection
+	for round := int64(1); round <= rounds; round++ {
+		resp = append(resp, ExplainLeader(duty, round, peers))
+	}
+
+	return resp
+}
+
+// leaderFormula returns a human-readable representation of the leader selection calculation.
+func leaderFormula(duty core.Duty, round int64, nodes int) string {
+	return fmt.Sprintf("(slot %d + duty_type %d + round %d) %% nodes %d = %d",
+		duty.Slot, int(duty.Type), round, nodes, leader(duty, round, nodes))
+}
+
+// NewLeaderHandler returns a http handler explaining the leader selection of the cluster peers.
+// It expects the "duty" and "slot" query parameters and optionally "rounds" (defaults to 3).
+func NewLeaderHandler(peers []p2p.Peer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		duty, rounds, err := parseLeaderQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		b, err := json.Marshal(ExplainLeaderRounds(duty, rounds, peers))
+		if err != nil {
+			log.Warn(r.Context(), "Error serving leader selection", err)
+			http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+// parseLeaderQuery returns the duty and number of rounds of the leader selection request.
+func parseLeaderQuery(r *http.Request) (core.Duty, int64, error) {
+	query := r.URL.Query()
+
+	typ, ok := core.DutyTypeFromString(query.Get("duty"))
+	if !ok {
+		return core.Duty{}, 0, errors.New("invalid duty type", z.Str("duty", query.Get("duty")))
+	}
+
+	slot, err := strconv.ParseUint(query.Get("slot"), 10, 64)
+	if err != nil {
+		return core.Duty{}, 0, errors.New("invalid slot", z.Str("slot", query.Get("slot")))
+	}
+
+	rounds := int64(defaultExplainRounds)
+	if s := query.Get("rounds"); s != "" {
+		rounds, err = strconv.ParseInt(s, 10, 64)
+		if err != nil || rounds < 1 || rounds > maxExplainRounds {
+			return core.Duty{}, 0, errors.New("invalid rounds", z.Str("rounds", s))
+		}
+	}
+
+	return core.Duty{Slot: slot, Type: typ}, rounds, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package qbft
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+func TestExplainLeader(t *testing.T) {
+	peers := []p2p.Peer{
+		{Index: 0, Name: "alpha"},
+		{Index: 1, Name: "bravo"},
+		{Index: 2, Name: "charlie"},
+		{Index: 3, Name: "delta"},
+	}
+
+	duty := core.Duty{Slot: 10, Type: core.DutyProposer}
+
+	selections := ExplainLeaderRounds(duty, 3, peers)
+	require.Len(t, selections, 3)
+
+	for i, selection := range selections {
+		round := int64(i + 1)
+		require.Equal(t, leader(duty, round, len(peers)), selection.LeaderIndex)
+		require.EqualValues(t, LeaderIndex(duty, round, len(peers)), selection.LeaderIndex)
+		require.Equal(t, peers[selection.LeaderIndex].Name, selection.LeaderName)
+		require.Equal(t, round, selection.Round)
+		require.Equal(t, "proposer", selection.DutyType)
+		require.Equal(t, int(core.DutyProposer), selection.DutyTypeID)
+		require.Equal(t, len(peers), selection.Nodes)
+	}
+
+	// (10 + 1 + 1) % 4 = 0
+	require.Equal(t, "alpha", selections[0].LeaderName)
+	require.Equal(t, "(slot 10 + duty_type 1 + round 1) % nodes 4 = 0", selections[0].Formula)
+}
+
+func TestLeaderHandler(t *testing.T) {
+	peers := []p2p.Peer{
+		{Index: 0, Name: "alpha"},
+		{Index: 1, Name: "bravo"},
+		{Index: 2, Name: "charlie"},
+	}
+
+	handler := NewLeaderHandler(peers)
+
+	tests := []struct {
+		Name   string
+		Query  string
+		Status int
+		Rounds int
+	}{
+		{Name: "default rounds", Query: "duty=attester&slot=100", Status: http.StatusOK, Rounds: defaultExplainRounds},
+		{Name: "explicit rounds", Query: "duty=attester&slot=100&rounds=5", Status: http.StatusOK, Rounds: 5},
+		{Name: "invalid duty", Query: "duty=foo&slot=100", Status: http.StatusBadRequest},
+		{Name: "missing slot", Query: "duty=attester", Status: http.StatusBadRequest},
+		{Name: "too many rounds", Query: "duty=attester&slot=100&rounds=1000", Status: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/consensus/leader?"+test.Query, nil))
+			require.Equal(t, test.Status, rec.Code)
+
+			if test.Status != http.StatusOK {
+				return
+			}
+
+			var selections []LeaderSelection
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &selections))
+			require.Len(t, selections, test.Rounds)
+			require.Equal(t, ExplainLeaderRounds(core.Duty{Slot: 100, Type: core.DutyAttester}, int64(test.Rounds), peers), selections)
+		})
+	}
+}
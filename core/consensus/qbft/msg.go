@@ -8,6 +8,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/obolnetwork/charon/app/bufpool"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
@@ -138,12 +139,17 @@ func hashProto(msg proto.Message) ([32]byte, error) {
 
 	index := hh.Index()
 
-	// Do deterministic marshalling.
-	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	buf := bufpool.Get()
+	defer bufpool.Put(buf)
+
+	// Do deterministic marshalling into a pooled buffer, the hasher copies the bytes.
+	b, err := proto.MarshalOptions{Deterministic: true}.MarshalAppend(*buf, msg)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "marshal proto")
 	}
 
+	*buf = b
+
 	hh.PutBytes(b)
 
 	hh.Merkleize(index)
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -37,6 +39,28 @@ func TestHashProto(t *testing.T) {
 	)
 }
 
+// BenchmarkHashProto benchmarks hashing the consensus value of a large cluster's attester duty,
+// marshalled into pooled buffers.
+func BenchmarkHashProto(b *testing.B) {
+	set := make(core.UnsignedDataSet)
+	for i := range 1000 {
+		set[core.PubKeyFrom48Bytes([48]byte(testutil.RandomBytes48()))] = core.AttestationData{
+			Data: *testutil.RandomAttestationDataPhase0(),
+			Duty: eth2v1.AttesterDuty{ValidatorIndex: eth2p0.ValidatorIndex(i)},
+		}
+	}
+
+	setPB, err := core.UnsignedDataSetToProto(set)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err := hashProto(setPB)
+		require.NoError(b, err)
+	}
+}
+
 //go:generate go test . -update
 
 func TestSigning(t *testing.T) {
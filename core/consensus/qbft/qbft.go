@@ -94,6 +94,8 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer timer.RoundTi
 				z.Any("rule", uponRule),
 				z.I64("round", round),
 				z.I64("new_round", newRound),
+				z.I64("new_leader_index", leader(duty, newRound, nodes)),
+				z.Str("new_leader_inputs", leaderFormula(duty, newRound, nodes)),
 			}
 
 			steps := groupRoundMessages(msgs, nodes, round, int(leader(duty, round, nodes)))
@@ -369,10 +371,20 @@ func (c *Consensus) runInstance(parent context.Context, duty core.Duty) (err err
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	log.Debug(ctx, "QBFT consensus instance starting",
+	fields := []z.Field{
 		z.Any("peers", c.peerLabels),
 		z.Any("timer", string(roundTimer.Type())),
-	)
+	}
+	if len(c.peers) > 0 {
+		firstLeader := ExplainLeader(duty, 1, c.peers)
+		fields = append(fields,
+			z.I64("leader_index", firstLeader.LeaderIndex),
+			z.Str("leader_name", firstLeader.LeaderName),
+			z.Str("leader_inputs", firstLeader.Formula),
+		)
+	}
+
+	log.Debug(ctx, "QBFT consensus instance starting", fields...)
 
 	inst := c.getInstanceIO(duty)
 
@@ -423,7 +435,8 @@ func (c *Consensus) runInstance(parent context.Context, duty core.Duty) (err err
 			z.U64("slot", duty.Slot),
 			z.I64("round", round),
 			z.I64("leader_index", leaderIndex),
-			z.Str("leader_name", leaderName))
+			z.Str("leader_name", leaderName),
+			z.Str("leader_inputs", leaderFormula(duty, round, nodes)))
 
 		c.metrics.SetDecidedLeaderIndex(duty.Type.String(), leaderIndex)
 		c.metrics.SetDecidedRounds(duty.Type.String(), string(roundTimer.Type()), round)
@@ -477,6 +490,8 @@ func (c *Consensus) runInstance(parent context.Context, duty core.Duty) (err err
 }
 
 // handle processes an incoming consensus wire message.
+// Messages are signed including the duty and round, so replays are rejected by the duty gater and deadliner
+// once the duty expires, while duplicates of an ongoing instance are ignored by the qbft upon rule deduplication.
 func (c *Consensus) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
 	t0 := time.Now()
 
@@ -9,6 +9,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -73,14 +74,79 @@ func (x *ParSigExMsg) GetDataSet() *ParSignedDataSet {
 	return nil
 }
 
+// ParSigExMsgV3 is the partial signature exchange message of protocol version 3.
+type ParSigExMsgV3 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Duty          *Duty                  `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	DataSet       *ParSignedDataSet      `protobuf:"bytes,2,opt,name=data_set,json=dataSet,proto3" json:"data_set,omitempty"`
+	SentAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParSigExMsgV3) Reset() {
+	*x = ParSigExMsgV3{}
+	mi := &file_core_corepb_v1_parsigex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParSigExMsgV3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParSigExMsgV3) ProtoMessage() {}
+
+func (x *ParSigExMsgV3) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_parsigex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParSigExMsgV3.ProtoReflect.Descriptor instead.
+func (*ParSigExMsgV3) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_parsigex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParSigExMsgV3) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *ParSigExMsgV3) GetDataSet() *ParSignedDataSet {
+	if x != nil {
+		return x.DataSet
+	}
+	return nil
+}
+
+func (x *ParSigExMsgV3) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
 var File_core_corepb_v1_parsigex_proto protoreflect.FileDescriptor
 
 const file_core_corepb_v1_parsigex_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcore/corepb/v1/parsigex.proto\x12\x0ecore.corepb.v1\x1a\x19core/corepb/v1/core.proto\"t\n" +
+	"\x1dcore/corepb/v1/parsigex.proto\x12\x0ecore.corepb.v1\x1a\x19core/corepb/v1/core.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"t\n" +
 	"\vParSigExMsg\x12(\n" +
 	"\x04duty\x18\x01 \x01(\v2\x14.core.corepb.v1.DutyR\x04duty\x12;\n" +
-	"\bdata_set\x18\x02 \x01(\v2 .core.corepb.v1.ParSignedDataSetR\adataSetB.Z,github.com/obolnetwork/charon/core/corepb/v1b\x06proto3"
+	"\bdata_set\x18\x02 \x01(\v2 .core.corepb.v1.ParSignedDataSetR\adataSet\"\xab\x01\n" +
+	"\rParSigExMsgV3\x12(\n" +
+	"\x04duty\x18\x01 \x01(\v2\x14.core.corepb.v1.DutyR\x04duty\x12;\n" +
+	"\bdata_set\x18\x02 \x01(\v2 .core.corepb.v1.ParSignedDataSetR\adataSet\x123\n" +
+	"\asent_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAtB.Z,github.com/obolnetwork/charon/core/corepb/v1b\x06proto3"
 
 var (
 	file_core_corepb_v1_parsigex_proto_rawDescOnce sync.Once
@@ -94,20 +160,25 @@ func file_core_corepb_v1_parsigex_proto_rawDescGZIP() []byte {
 	return file_core_corepb_v1_parsigex_proto_rawDescData
 }
 
-var file_core_corepb_v1_parsigex_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_core_corepb_v1_parsigex_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_core_corepb_v1_parsigex_proto_goTypes = []any{
-	(*ParSigExMsg)(nil),      // 0: core.corepb.v1.ParSigExMsg
-	(*Duty)(nil),             // 1: core.corepb.v1.Duty
-	(*ParSignedDataSet)(nil), // 2: core.corepb.v1.ParSignedDataSet
+	(*ParSigExMsg)(nil),           // 0: core.corepb.v1.ParSigExMsg
+	(*ParSigExMsgV3)(nil),         // 1: core.corepb.v1.ParSigExMsgV3
+	(*Duty)(nil),                  // 2: core.corepb.v1.Duty
+	(*ParSignedDataSet)(nil),      // 3: core.corepb.v1.ParSignedDataSet
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
 }
 var file_core_corepb_v1_parsigex_proto_depIdxs = []int32{
-	1, // 0: core.corepb.v1.ParSigExMsg.duty:type_name -> core.corepb.v1.Duty
-	2, // 1: core.corepb.v1.ParSigExMsg.data_set:type_name -> core.corepb.v1.ParSignedDataSet
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: core.corepb.v1.ParSigExMsg.duty:type_name -> core.corepb.v1.Duty
+	3, // 1: core.corepb.v1.ParSigExMsg.data_set:type_name -> core.corepb.v1.ParSignedDataSet
+	2, // 2: core.corepb.v1.ParSigExMsgV3.duty:type_name -> core.corepb.v1.Duty
+	3, // 3: core.corepb.v1.ParSigExMsgV3.data_set:type_name -> core.corepb.v1.ParSignedDataSet
+	4, // 4: core.corepb.v1.ParSigExMsgV3.sent_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_core_corepb_v1_parsigex_proto_init() }
@@ -122,7 +193,7 @@ func file_core_corepb_v1_parsigex_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_corepb_v1_parsigex_proto_rawDesc), len(file_core_corepb_v1_parsigex_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,167 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// NewDrainer returns a new drainer tracking in-flight duties until broadcast or expired by the deadliner.
+func NewDrainer(ctx context.Context, deadliner Deadliner) *Drainer {
+	d := &Drainer{
+		deadliner: deadliner,
+		inflight:  make(map[Duty]map[PubKey]bool),
+		emptyCh:   make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case duty := <-deadliner.C():
+				d.expire(duty)
+			}
+		}
+	}()
+
+	return d
+}
+
+// Drainer tracks duties that were partially signed by the local validator client but not yet broadcast.
+// It allows waiting for in-flight duties to complete on graceful shutdown.
+type Drainer struct {
+	deadliner Deadliner
+
+	mu       sync.Mutex
+	inflight map[Duty]map[PubKey]bool
+	emptyCh  chan struct{} // Closed and replaced whenever no duties are in-flight.
+}
+
+// add marks the duty's validators as in-flight. Expired or never expiring duties are ignored.
+func (d *Drainer) add(duty Duty, set ParSignedDataSet) {
+	if !d.deadliner.Add(duty) {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	pubkeys, ok := d.inflight[duty]
+	if !ok {
+		pubkeys = make(map[PubKey]bool)
+		d.inflight[duty] = pubkeys
+	}
+
+	for pubkey := range set {
+		pubkeys[pubkey] = true
+	}
+}
+
+// remove marks the duty's validators as complete.
+func (d *Drainer) remove(duty Duty, pubkeys []PubKey) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	inflight, ok := d.inflight[duty]
+	if !ok {
+		return
+	}
+
+	for _, pubkey := range pubkeys {
+		delete(inflight, pubkey)
+	}
+
+	if len(inflight) == 0 {
+		delete(d.inflight, duty)
+	}
+
+	d.maybeSignalEmpty()
+}
+
+// expire removes the duty since it can no longer complete.
+func (d *Drainer) expire(duty Duty) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.inflight, duty)
+	d.maybeSignalEmpty()
+}
+
+// maybeSignalEmpty signals waiters if no duties are in-flight. It must be called with the lock held.
+func (d *Drainer) maybeSignalEmpty() {
+	if len(d.inflight) > 0 {
+		return
+	}
+
+	close(d.emptyCh)
+	d.emptyCh = make(chan struct{})
+}
+
+// Inflight returns the number of in-flight duties.
+func (d *Drainer) Inflight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.inflight)
+}
+
+// Drain blocks until no duties are in-flight or the context is cancelled.
+// It returns the number of duties still in-flight.
+func (d *Drainer) Drain(ctx context.Context) int {
+	for {
+		d.mu.Lock()
+		if len(d.inflight) == 0 {
+			d.mu.Unlock()
+			return 0
+		}
+		emptyCh := d.emptyCh
+		d.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return d.Inflight()
+		case <-emptyCh:
+		}
+	}
+}
+
+// WithDrainer wraps component input functions to track in-flight duties from partial signature
+// submission by the local validator client until successful broadcast.
+func WithDrainer(drainer *Drainer) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			drainer.add(duty, set)
+
+			err := clone.ParSigDBStoreInternal(ctx, duty, set)
+			if err != nil {
+				var pubkeys []PubKey
+				for pubkey := range set {
+					pubkeys = append(pubkeys, pubkey)
+				}
+
+				drainer.remove(duty, pubkeys)
+			}
+
+			return err
+		}
+		w.BroadcasterBroadcast = func(ctx context.Context, duty Duty, set SignedDataSet) error {
+			err := clone.BroadcasterBroadcast(ctx, duty, set)
+			if err != nil {
+				return err
+			}
+
+			var pubkeys []PubKey
+			for pubkey := range set {
+				pubkeys = append(pubkeys, pubkey)
+			}
+
+			drainer.remove(duty, pubkeys)
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestWithDrainer(t *testing.T) {
+	var (
+		pubkey1 = PubKeyFrom48Bytes([48]byte{1})
+		pubkey2 = PubKeyFrom48Bytes([48]byte{2})
+		duty    = NewAttesterDuty(1)
+	)
+
+	deadliner := newTestDeadliner()
+	drainer := NewDrainer(t.Context(), deadliner)
+
+	var bcastErr error
+
+	w := wireFuncs{
+		ParSigDBStoreInternal: func(context.Context, Duty, ParSignedDataSet) error {
+			return nil
+		},
+		BroadcasterBroadcast: func(context.Context, Duty, SignedDataSet) error {
+			return bcastErr
+		},
+	}
+	WithDrainer(drainer)(&w)
+
+	// Nothing in-flight drains immediately.
+	require.Zero(t, drainer.Drain(t.Context()))
+
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), duty, ParSignedDataSet{pubkey1: {}, pubkey2: {}}))
+	require.Equal(t, 1, drainer.Inflight())
+
+	// Failed broadcast remains in-flight.
+	bcastErr = errors.New("failed")
+	require.Error(t, w.BroadcasterBroadcast(t.Context(), duty, SignedDataSet{pubkey1: nil}))
+	require.Equal(t, 1, drainer.Inflight())
+
+	bcastErr = nil
+	require.NoError(t, w.BroadcasterBroadcast(t.Context(), duty, SignedDataSet{pubkey1: nil}))
+	require.Equal(t, 1, drainer.Inflight())
+
+	drained := make(chan int)
+	go func() {
+		drained <- drainer.Drain(t.Context())
+	}()
+
+	require.NoError(t, w.BroadcasterBroadcast(t.Context(), duty, SignedDataSet{pubkey2: nil}))
+	require.Zero(t, <-drained)
+
+	// Expired duties are no longer in-flight.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), duty, ParSignedDataSet{pubkey1: {}}))
+	deadliner.ch <- duty
+	require.Zero(t, drainer.Drain(t.Context()))
+
+	// Drain returns in-flight duties on timeout.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewProposerDuty(2), ParSignedDataSet{pubkey1: {}}))
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+
+	require.Equal(t, 1, drainer.Drain(ctx))
+}
+
+func newTestDeadliner() *testDeadliner {
+	return &testDeadliner{ch: make(chan Duty)}
+}
+
+type testDeadliner struct {
+	ch chan Duty
+}
+
+func (*testDeadliner) Add(Duty) bool {
+	return true
+}
+
+func (d *testDeadliner) C() <-chan Duty {
+	return d.ch
+}
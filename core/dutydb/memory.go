@@ -60,6 +60,15 @@ type MemDB struct {
 
 	shutdown  chan struct{}
 	deadliner core.Deadliner
+	pending   *Pending
+}
+
+// RegisterPending registers the tracker of stored duties awaiting partial signatures.
+func (db *MemDB) RegisterPending(pending *Pending) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.pending = pending
 }
 
 // Shutdown results in all blocking queries to return shutdown errors.
@@ -126,6 +135,8 @@ func (db *MemDB) Store(_ context.Context, duty core.Duty, unsignedSet core.Unsig
 		return errors.New("unsupported duty type", z.Str("type", duty.Type.String()))
 	}
 
+	db.pending.stored(duty, unsignedSet)
+
 	// Delete all expired duties.
 	for {
 		var deleted bool
@@ -615,6 +626,8 @@ func (db *MemDB) resolveContribQueriesUnsafe() {
 
 // deleteDutyUnsafe deletes the duty from the database. It is unsafe since it assumes the lock is held.
 func (db *MemDB) deleteDutyUnsafe(duty core.Duty) error {
+	db.pending.expired(duty)
+
 	switch duty.Type {
 	case core.DutyProposer:
 		delete(db.proDuties, duty.Slot)
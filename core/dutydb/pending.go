@@ -0,0 +1,178 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// PendingDuty is an unsigned duty stored in the DutyDB awaiting partial signatures from the validator client.
+type PendingDuty struct {
+	Duty      string    `json:"duty"`
+	Slot      uint64    `json:"slot"`
+	Type      string    `json:"type"`
+	StoredAt  time.Time `json:"stored_at"`
+	Waiting   string    `json:"waiting"`
+	Pending   []string  `json:"pending_pubkeys"`   // DV public keys not submitted by the validator client yet.
+	PubShares []string  `json:"pending_pubshares"` // This node's public shares of the pending DV public keys.
+	Submitted int       `json:"submitted"`
+}
+
+// NewPending returns a new tracker of duties awaiting partial signatures.
+func NewPending() *Pending {
+	return &Pending{
+		duties:    make(map[core.Duty]*pendingDuty),
+		pubshares: make(map[core.PubKey]string),
+		now:       time.Now,
+	}
+}
+
+// Pending tracks the unsigned duties stored in the DutyDB until all validators' partial signatures are submitted
+// by the validator client or the duties expire, enabling a "who is holding up this duty" view during incidents.
+type Pending struct {
+	mu        sync.Mutex
+	duties    map[core.Duty]*pendingDuty
+	pubshares map[core.PubKey]string
+	now       func() time.Time
+}
+
+// pendingDuty is a duty awaiting partial signatures.
+type pendingDuty struct {
+	StoredAt  time.Time
+	Pending   map[core.PubKey]bool
+	Submitted int
+}
+
+// SetPubShares sets this node's public shares by DV public key.
+func (p *Pending) SetPubShares(pubshares map[core.PubKey]tbls.PublicKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for pubkey, pubshare := range pubshares {
+		p.pubshares[pubkey] = string(core.PubKeyFrom48Bytes(pubshare))
+	}
+}
+
+// stored records the duty's validators as awaiting partial signatures.
+func (p *Pending) stored(duty core.Duty, unsignedSet core.UnsignedDataSet) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pd, ok := p.duties[duty]
+	if !ok {
+		pd = &pendingDuty{StoredAt: p.now(), Pending: make(map[core.PubKey]bool)}
+		p.duties[duty] = pd
+	}
+
+	for pubkey := range unsignedSet {
+		pd.Pending[pubkey] = true
+	}
+}
+
+// expired removes the duty.
+func (p *Pending) expired(duty core.Duty) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.duties, duty)
+}
+
+// Submitted records the partial signatures submitted by the validator client, removing duties once all
+// validators submitted. It is a validatorapi subscriber and always returns nil.
+func (p *Pending) Submitted(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pd, ok := p.duties[duty]
+	if !ok {
+		return nil
+	}
+
+	for pubkey := range set {
+		if pd.Pending[pubkey] {
+			delete(pd.Pending, pubkey)
+			pd.Submitted++
+		}
+	}
+
+	if len(pd.Pending) == 0 {
+		delete(p.duties, duty)
+	}
+
+	return nil
+}
+
+// Duties returns the duties awaiting partial signatures, sorted by slot and duty type.
+func (p *Pending) Duties() []PendingDuty {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	resp := []PendingDuty{}
+
+	for duty, pd := range p.duties {
+		entry := PendingDuty{
+			Duty:      duty.String(),
+			Slot:      duty.Slot,
+			Type:      duty.Type.String(),
+			StoredAt:  pd.StoredAt,
+			Waiting:   now.Sub(pd.StoredAt).Truncate(time.Millisecond).String(),
+			Pending:   []string{},
+			PubShares: []string{},
+			Submitted: pd.Submitted,
+		}
+
+		for pubkey := range pd.Pending {
+			entry.Pending = append(entry.Pending, string(pubkey))
+			if pubshare, ok := p.pubshares[pubkey]; ok {
+				entry.PubShares = append(entry.PubShares, pubshare)
+			}
+		}
+
+		sort.Strings(entry.Pending)
+		sort.Strings(entry.PubShares)
+
+		resp = append(resp, entry)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Slot != resp[j].Slot {
+			return resp[i].Slot < resp[j].Slot
+		}
+
+		return resp[i].Type < resp[j].Type
+	})
+
+	return resp
+}
+
+// ServeHTTP serves the duties awaiting partial signatures as JSON.
+func (p *Pending) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(p.Duties())
+	if err != nil {
+		log.Warn(r.Context(), "Error serving pending duties", err)
+		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestPending(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	pending := NewPending()
+	pending.now = func() time.Time { return now }
+
+	db := NewMemDB(noopDeadliner{})
+	db.RegisterPending(pending)
+
+	pubkeyA := testutil.RandomCorePubKey(t)
+	pubkeyB := testutil.RandomCorePubKey(t)
+	pubshareA := tbls.PublicKey(testutil.RandomBytes48())
+	pending.SetPubShares(map[core.PubKey]tbls.PublicKey{pubkeyA: pubshareA})
+
+	const slot = 123
+	duty := core.NewAttesterDuty(slot)
+
+	attA := testutil.RandomCoreAttestationData(t)
+	attA.Duty.Slot = slot
+	attB := testutil.RandomCoreAttestationData(t)
+	attB.Duty.Slot = slot
+	attB.Data = attA.Data
+	attB.Duty.CommitteeIndex = attA.Duty.CommitteeIndex
+	attB.Duty.ValidatorIndex = attA.Duty.ValidatorIndex + 1
+	attB.Duty.ValidatorCommitteeIndex = attA.Duty.ValidatorCommitteeIndex + 1
+
+	err := db.Store(ctx, duty, core.UnsignedDataSet{pubkeyA: attA, pubkeyB: attB})
+	require.NoError(t, err)
+
+	now = now.Add(1500 * time.Millisecond)
+
+	duties := pending.Duties()
+	require.Len(t, duties, 1)
+	require.Equal(t, duty.String(), duties[0].Duty)
+	require.EqualValues(t, slot, duties[0].Slot)
+	require.Equal(t, "1.5s", duties[0].Waiting)
+	require.ElementsMatch(t, []string{string(pubkeyA), string(pubkeyB)}, duties[0].Pending)
+	require.Equal(t, []string{string(core.PubKeyFrom48Bytes(pubshareA))}, duties[0].PubShares)
+
+	// Submitting a validator's partial signature removes it from the pending validators.
+	require.NoError(t, pending.Submitted(ctx, duty, core.ParSignedDataSet{pubkeyA: core.ParSignedData{}}))
+
+	rec := httptest.NewRecorder()
+	pending.ServeHTTP(rec, httptest.NewRequest("GET", "/duties/pending", nil))
+
+	var resp []PendingDuty
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, []string{string(pubkeyB)}, resp[0].Pending)
+	require.Empty(t, resp[0].PubShares)
+	require.Equal(t, 1, resp[0].Submitted)
+
+	// Submitting all validators' partial signatures removes the duty.
+	require.NoError(t, pending.Submitted(ctx, duty, core.ParSignedDataSet{pubkeyB: core.ParSignedData{}}))
+	require.Empty(t, pending.Duties())
+
+	// Expired duties are removed.
+	duty2 := core.NewAttesterDuty(slot + 1)
+	attA.Duty.Slot = slot + 1
+	attA.Data.Slot = eth2p0.Slot(slot + 1)
+	require.NoError(t, db.Store(ctx, duty2, core.UnsignedDataSet{pubkeyA: attA}))
+	require.Len(t, pending.Duties(), 1)
+
+	db.mu.Lock()
+	require.NoError(t, db.deleteDutyUnsafe(duty2))
+	db.mu.Unlock()
+	require.Empty(t, pending.Duties())
+}
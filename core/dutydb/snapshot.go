@@ -0,0 +1,142 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+// Snapshot is a point-in-time copy of the MemDB state, used for debugging.
+type Snapshot struct {
+	Attestations    []AttestationEntry    `json:"attestations"`
+	AttesterPubKeys []AttesterPubKeyEntry `json:"attester_pubkeys"`
+	Proposals       []ProposalEntry       `json:"proposals"`
+	AggAttestations []AggAttestationEntry `json:"aggregated_attestations"`
+	Contributions   []ContributionEntry   `json:"sync_contributions"`
+}
+
+// AttestationEntry is the attestation data of a committee.
+type AttestationEntry struct {
+	Slot    uint64                  `json:"slot"`
+	CommIdx uint64                  `json:"committee_index"`
+	Data    *eth2p0.AttestationData `json:"data"`
+}
+
+// AttesterPubKeyEntry is the DV pubkey of a validator in a committee.
+type AttesterPubKeyEntry struct {
+	Slot    uint64      `json:"slot"`
+	CommIdx uint64      `json:"committee_index"`
+	ValIdx  uint64      `json:"validator_index"`
+	PubKey  core.PubKey `json:"pubkey"`
+}
+
+// ProposalEntry is the unsigned proposal of a slot.
+type ProposalEntry struct {
+	Slot     uint64                 `json:"slot"`
+	Proposal core.VersionedProposal `json:"proposal"`
+}
+
+// AggAttestationEntry is the aggregated attestation of an attestation data root.
+type AggAttestationEntry struct {
+	Slot uint64                              `json:"slot"`
+	Root eth2p0.Root                         `json:"root"`
+	Data core.VersionedAggregatedAttestation `json:"data"`
+}
+
+// ContributionEntry is the sync committee contribution of a subcommittee and block root.
+type ContributionEntry struct {
+	Slot       uint64                            `json:"slot"`
+	SubcommIdx uint64                            `json:"subcommittee_index"`
+	Root       eth2p0.Root                       `json:"root"`
+	Data       *altair.SyncCommitteeContribution `json:"data"`
+}
+
+// Snapshot returns a point-in-time copy of the database state.
+// Note the returned data is shared with the database and must not be mutated.
+func (db *MemDB) Snapshot() Snapshot {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var resp Snapshot
+
+	for key, data := range db.attDuties {
+		resp.Attestations = append(resp.Attestations, AttestationEntry{
+			Slot:    key.Slot,
+			CommIdx: key.CommIdx,
+			Data:    data,
+		})
+	}
+
+	for key, pubkey := range db.attPubKeys {
+		resp.AttesterPubKeys = append(resp.AttesterPubKeys, AttesterPubKeyEntry{
+			Slot:    key.Slot,
+			CommIdx: key.CommIdx,
+			ValIdx:  key.ValIdx,
+			PubKey:  *pubkey,
+		})
+	}
+
+	for slot, proposal := range db.proDuties {
+		resp.Proposals = append(resp.Proposals, ProposalEntry{
+			Slot:     slot,
+			Proposal: core.VersionedProposal{VersionedProposal: *proposal},
+		})
+	}
+
+	for key, data := range db.aggDuties {
+		resp.AggAttestations = append(resp.AggAttestations, AggAttestationEntry{
+			Slot: key.Slot,
+			Root: key.Root,
+			Data: data,
+		})
+	}
+
+	for key, data := range db.contribDuties {
+		resp.Contributions = append(resp.Contributions, ContributionEntry{
+			Slot:       key.Slot,
+			SubcommIdx: key.SubcommIdx,
+			Root:       key.Root,
+			Data:       data,
+		})
+	}
+
+	return resp
+}
+
+// Restore populates the database with the snapshot state, enabling offline reproduction of
+// production issues. Restored duties are not added to the deadliner, so they never expire.
+func (db *MemDB) Restore(snapshot Snapshot) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, entry := range snapshot.Attestations {
+		db.attDuties[attKey{Slot: entry.Slot, CommIdx: entry.CommIdx}] = entry.Data
+	}
+
+	for _, entry := range snapshot.AttesterPubKeys {
+		key := pkKey{Slot: entry.Slot, CommIdx: entry.CommIdx, ValIdx: entry.ValIdx}
+		pubkey := entry.PubKey
+		db.attPubKeys[key] = &pubkey
+		db.attKeysBySlot[entry.Slot] = append(db.attKeysBySlot[entry.Slot], key)
+	}
+
+	for _, entry := range snapshot.Proposals {
+		proposal := entry.Proposal.VersionedProposal
+		db.proDuties[entry.Slot] = &proposal
+	}
+
+	for _, entry := range snapshot.AggAttestations {
+		key := aggKey{Slot: entry.Slot, Root: entry.Root}
+		db.aggDuties[key] = entry.Data
+		db.aggKeysBySlot[entry.Slot] = append(db.aggKeysBySlot[entry.Slot], key)
+	}
+
+	for _, entry := range snapshot.Contributions {
+		key := contribKey{Slot: entry.Slot, SubcommIdx: entry.SubcommIdx, Root: entry.Root}
+		db.contribDuties[key] = entry.Data
+		db.contribKeysBySlot[entry.Slot] = append(db.contribKeysBySlot[entry.Slot], key)
+	}
+}
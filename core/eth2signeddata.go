@@ -9,6 +9,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
@@ -44,6 +45,98 @@ func VerifyEth2SignedData(ctx context.Context, eth2Cl eth2wrap.Client, data Eth2
 	return signing.Verify(ctx, eth2Cl, data.DomainName(), epoch, sigRoot, data.Signature().ToETH2(), pubkey)
 }
 
+// VerifyEth2SignedDataBatch verifies the signatures of the Eth2SignedData with the public keys at the same index
+// using BLS batch verification. If the batch fails, each signature is verified individually to identify the invalid one.
+func VerifyEth2SignedDataBatch(ctx context.Context, eth2Cl eth2wrap.Client, datas []Eth2SignedData, pubkeys []tbls.PublicKey) error {
+	invalid, err := VerifyEth2SignedDataBatchInvalid(ctx, eth2Cl, datas, pubkeys)
+	if err != nil {
+		return err
+	}
+
+	for i := range datas {
+		if err, ok := invalid[i]; ok {
+			return errors.Wrap(err, "batch signature verification", z.Int("index", i))
+		}
+	}
+
+	return nil
+}
+
+// VerifyEth2SignedDataBatchInvalid verifies the signatures of the Eth2SignedData with the public keys at the same index
+// using BLS batch verification and returns the errors of the invalid ones by index. If the batch fails, each signature
+// is verified individually exactly once to identify all invalid ones.
+func VerifyEth2SignedDataBatchInvalid(ctx context.Context, eth2Cl eth2wrap.Client, datas []Eth2SignedData, pubkeys []tbls.PublicKey) (map[int]error, error) {
+	if len(datas) != len(pubkeys) {
+		return nil, errors.New("mismatching data and pubkeys lengths")
+	}
+
+	var (
+		invalid  = make(map[int]error)
+		indices  []int
+		keys     []tbls.PublicKey
+		sigDatas [][]byte
+		sigs     []tbls.Signature
+		zeroSig  tbls.Signature
+	)
+
+	for i, data := range datas {
+		epoch, err := data.Epoch(ctx, eth2Cl)
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+
+		sigRoot, err := data.MessageRoot()
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+
+		sigData, err := signing.GetDataRoot(ctx, eth2Cl, data.DomainName(), epoch, sigRoot)
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+
+		sig := tbls.Signature(data.Signature().ToETH2())
+		if sig == zeroSig {
+			invalid[i] = errors.New("no signature found")
+			continue
+		}
+
+		indices = append(indices, i)
+		keys = append(keys, pubkeys[i])
+		sigDatas = append(sigDatas, sigData[:])
+		sigs = append(sigs, sig)
+	}
+
+	switch len(sigs) {
+	case 0:
+		return invalid, nil
+	case 1:
+		if err := tbls.Verify(keys[0], sigDatas[0], sigs[0]); err != nil {
+			invalid[indices[0]] = err
+		}
+
+		return invalid, nil
+	}
+
+	if err := tbls.BatchVerify(keys, sigDatas, sigs); err == nil {
+		batchVerifyCounter.WithLabelValues("ok").Inc()
+		return invalid, nil
+	}
+
+	batchVerifyCounter.WithLabelValues("fallback").Inc()
+
+	for j := range sigs {
+		if err := tbls.Verify(keys[j], sigDatas[j], sigs[j]); err != nil {
+			invalid[indices[j]] = err
+		}
+	}
+
+	return invalid, nil
+}
+
 // Implement Eth2SignedData for VersionedSignedProposal.
 
 func (VersionedSignedProposal) DomainName() signing.DomainName {
@@ -122,3 +122,59 @@ func sign(t *testing.T, secret tbls.PrivateKey, data []byte) core.Signature {
 
 	return tblsconv.SigToCore(sig)
 }
+
+func TestVerifyEth2SignedDataBatch(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var (
+		datas   []core.Eth2SignedData
+		pubkeys []tbls.PublicKey
+	)
+
+	for range 20 {
+		data := testutil.RandomDenebCoreVersionedAttestation()
+
+		epoch, err := data.Epoch(ctx, bmock)
+		require.NoError(t, err)
+
+		root, err := data.MessageRoot()
+		require.NoError(t, err)
+
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		sigData, err := signing.GetDataRoot(ctx, bmock, data.DomainName(), epoch, root)
+		require.NoError(t, err)
+
+		s, err := data.SetSignature(sign(t, secret, sigData[:]))
+		require.NoError(t, err)
+
+		eth2Signed, ok := s.(core.Eth2SignedData)
+		require.True(t, ok)
+
+		datas = append(datas, eth2Signed)
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	require.NoError(t, core.VerifyEth2SignedDataBatch(ctx, bmock, datas, pubkeys))
+	require.Error(t, core.VerifyEth2SignedDataBatch(ctx, bmock, datas, pubkeys[1:]))
+
+	// The invalid signature is identified by falling back to individual verification.
+	pubkeys[7], pubkeys[8] = pubkeys[8], pubkeys[7]
+	err = core.VerifyEth2SignedDataBatch(ctx, bmock, datas, pubkeys)
+	require.ErrorContains(t, err, "batch signature verification")
+	require.ErrorIs(t, err, tbls.ErrSigNotVerified)
+
+	// All invalid signatures are reported by index.
+	invalid, err := core.VerifyEth2SignedDataBatchInvalid(ctx, bmock, datas, pubkeys)
+	require.NoError(t, err)
+	require.Len(t, invalid, 2)
+	require.ErrorIs(t, invalid[7], tbls.ErrSigNotVerified)
+	require.ErrorIs(t, invalid[8], tbls.ErrSigNotVerified)
+}
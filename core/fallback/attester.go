@@ -0,0 +1,228 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"context"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// NewAttester returns a new failsafe attester producing and partially signing attestations of scheduled
+// validators from the cluster's decided attestation data if no validator client submission is seen by delay
+// into the slot. Attestations of validators blocked by the blocked function or slashable according to the
+// attestations signed since startup are never produced.
+func NewAttester(eth2Cl eth2wrap.Client, shareIdx int, secrets map[core.PubKey]tbls.PrivateKey,
+	dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error),
+	awaitAttFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error),
+	blocked func(context.Context, core.PubKey) bool, electraSlot eth2p0.Slot, delay time.Duration,
+) *Attester {
+	return &Attester{
+		eth2Cl:       eth2Cl,
+		shareIdx:     shareIdx,
+		secrets:      secrets,
+		dutyDefFunc:  dutyDefFunc,
+		awaitAttFunc: awaitAttFunc,
+		blocked:      blocked,
+		electraSlot:  electraSlot,
+		delay:        delay,
+		submissions:  newSubmissions(),
+		slashingDB:   newSlashingDB(),
+	}
+}
+
+// Attester is the failsafe attester, protecting attestation rewards against validator clients
+// failing to submit attestations.
+type Attester struct {
+	eth2Cl       eth2wrap.Client
+	shareIdx     int
+	secrets      map[core.PubKey]tbls.PrivateKey
+	dutyDefFunc  func(context.Context, core.Duty) (core.DutyDefinitionSet, error)
+	awaitAttFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
+	blocked      func(context.Context, core.PubKey) bool
+	electraSlot  eth2p0.Slot
+	delay        time.Duration
+	submissions  *submissions
+	slashingDB   *slashingDB
+	subs         []func(context.Context, core.Duty, core.ParSignedDataSet) error
+}
+
+// Subscribe registers a callback for fallback partially signed attestations.
+func (a *Attester) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
+	a.subs = append(a.subs, fn)
+}
+
+// Submitted records partially signed attestations submitted by validator clients,
+// including them in slashing protection.
+// It is intended to subscribe to the validator API.
+func (a *Attester) Submitted(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	if duty.Type != core.DutyAttester {
+		return nil
+	}
+
+	for pubkey, parSig := range set {
+		att, ok := parSig.SignedData.(core.VersionedAttestation)
+		if !ok {
+			continue
+		}
+
+		data, err := att.Data()
+		if err != nil {
+			return errors.Wrap(err, "get attestation data")
+		}
+
+		if err := a.slashingDB.Record(pubkey, data); err != nil {
+			return err
+		}
+	}
+
+	return a.submissions.Add(ctx, duty, set)
+}
+
+// HandleSlot waits until delay into the slot and produces attestations for all scheduled
+// validators without validator client submissions.
+// It is intended to subscribe to the scheduler's slots.
+func (a *Attester) HandleSlot(ctx context.Context, slot core.Slot) error {
+	defer a.submissions.Trim(slot.Slot)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Until(slot.Time.Add(a.delay))):
+	}
+
+	duty := core.NewAttesterDuty(slot.Slot)
+
+	defSet, err := a.dutyDefFunc(ctx, duty)
+	if errors.Is(err, core.ErrNotFound) {
+		return nil // No attester duties in this slot.
+	} else if err != nil {
+		return err
+	}
+
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	// Attestation data is only available once consensus completes, so don't wait beyond the slot.
+	ctx, cancel := context.WithDeadline(ctx, slot.Time.Add(slot.SlotDuration))
+	defer cancel()
+
+	set := make(core.ParSignedDataSet)
+	for pubkey, def := range defSet {
+		if a.submissions.Seen(duty, pubkey) {
+			continue
+		}
+
+		secret, ok := a.secrets[pubkey]
+		if !ok {
+			continue
+		}
+
+		if a.blocked != nil && a.blocked(ctx, pubkey) {
+			continue
+		}
+
+		attDef, ok := def.(core.AttesterDefinition)
+		if !ok {
+			return errors.New("invalid attester definition")
+		}
+
+		data, err := a.awaitAttFunc(ctx, slot.Slot, uint64(attDef.CommitteeIndex))
+		if err != nil {
+			return errors.Wrap(err, "await attestation data")
+		}
+
+		if err := a.slashingDB.CheckAndRecord(pubkey, data); err != nil {
+			log.Error(ctx, "Not producing fallback attestation", err, z.Any("pubkey", pubkey))
+			continue
+		}
+
+		att, err := a.sign(ctx, attDef, data, secret)
+		if err != nil {
+			return err
+		}
+
+		parSig, err := core.NewPartialVersionedAttestation(att, a.shareIdx)
+		if err != nil {
+			return err
+		}
+
+		set[pubkey] = parSig
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+
+	log.Warn(ctx, "No validator client attestations seen, producing fallback attestations", nil,
+		z.Int("validators", len(set)), z.Str("delay", a.delay.String()))
+	producedCounter.WithLabelValues(duty.Type.String()).Add(float64(len(set)))
+
+	for _, sub := range a.subs {
+		// No need to clone since sub auto clones.
+		if err := sub(ctx, duty, set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sign returns the attestation of the validator partially signed with its secret key share.
+func (a *Attester) sign(ctx context.Context, attDef core.AttesterDefinition, data *eth2p0.AttestationData,
+	secret tbls.PrivateKey,
+) (*eth2spec.VersionedAttestation, error) {
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "hash attestation data")
+	}
+
+	sigData, err := signing.GetDataRoot(ctx, a.eth2Cl, signing.DomainBeaconAttester, data.Target.Epoch, root)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := tbls.Sign(secret, sigData[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aggBits := bitfield.NewBitlist(attDef.CommitteeLength)
+	aggBits.SetBitAt(attDef.ValidatorCommitteeIndex, true)
+
+	if data.Slot < a.electraSlot {
+		return &eth2spec.VersionedAttestation{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb: &eth2p0.Attestation{
+				AggregationBits: aggBits,
+				Data:            data,
+				Signature:       eth2p0.BLSSignature(sig),
+			},
+		}, nil
+	}
+
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(uint64(attDef.CommitteeIndex), true)
+
+	return &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &attDef.ValidatorIndex,
+		Electra: &electra.Attestation{
+			AggregationBits: aggBits,
+			Data:            data,
+			Signature:       eth2p0.BLSSignature(sig),
+			CommitteeBits:   commBits,
+		},
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestAttester(t *testing.T) {
+	const (
+		shareIdx = 3
+		commIdx  = 4
+	)
+
+	tests := []struct {
+		Name        string
+		ElectraSlot eth2p0.Slot
+		Version     eth2spec.DataVersion
+	}{
+		{Name: "deneb", ElectraSlot: 100, Version: eth2spec.DataVersionDeneb},
+		{Name: "electra", ElectraSlot: 0, Version: eth2spec.DataVersionElectra},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ctx := t.Context()
+
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+
+			secret, err := tbls.GenerateSecretKey()
+			require.NoError(t, err)
+			pubshare, err := tbls.SecretToPublicKey(secret)
+			require.NoError(t, err)
+
+			var (
+				pubkey          = testutil.RandomCorePubKey(t)
+				submittedPubkey = testutil.RandomCorePubKey(t)
+				blockedPubkey   = testutil.RandomCorePubKey(t)
+				secrets         = map[core.PubKey]tbls.PrivateKey{pubkey: secret, submittedPubkey: secret, blockedPubkey: secret}
+			)
+
+			slot := core.Slot{
+				Slot:          10,
+				Time:          time.Now(),
+				SlotDuration:  time.Second,
+				SlotsPerEpoch: 16,
+			}
+
+			attDef := func(vIdx eth2p0.ValidatorIndex) core.AttesterDefinition {
+				return core.NewAttesterDefinition(&eth2v1.AttesterDuty{
+					Slot:                    eth2p0.Slot(slot.Slot),
+					ValidatorIndex:          vIdx,
+					CommitteeIndex:          commIdx,
+					CommitteeLength:         8,
+					ValidatorCommitteeIndex: uint64(vIdx),
+				})
+			}
+
+			dutyDefFunc := func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+				if duty != core.NewAttesterDuty(slot.Slot) {
+					return nil, core.ErrNotFound
+				}
+
+				return core.DutyDefinitionSet{
+					pubkey:          attDef(1),
+					submittedPubkey: attDef(2),
+					blockedPubkey:   attDef(3),
+				}, nil
+			}
+
+			data := testutil.RandomAttestationDataPhase0()
+			data.Slot = eth2p0.Slot(slot.Slot)
+			data.Index = commIdx
+
+			awaitAttFunc := func(_ context.Context, s, c uint64) (*eth2p0.AttestationData, error) {
+				require.Equal(t, slot.Slot, s)
+				require.EqualValues(t, commIdx, c)
+
+				return data, nil
+			}
+
+			blocked := func(_ context.Context, pk core.PubKey) bool {
+				return pk == blockedPubkey
+			}
+
+			attester := NewAttester(bmock, shareIdx, secrets, dutyDefFunc, awaitAttFunc, blocked, test.ElectraSlot, 0)
+
+			var outputSet core.ParSignedDataSet
+			attester.Subscribe(func(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+				require.Equal(t, core.NewAttesterDuty(slot.Slot), duty)
+				outputSet = set
+
+				return nil
+			})
+
+			// Validator client submitted for one of the validators.
+			submitted := testutil.RandomDenebVersionedAttestation()
+			submitted.Deneb.Data = data
+			submittedParSig, err := core.NewPartialVersionedAttestation(submitted, shareIdx)
+			require.NoError(t, err)
+			require.NoError(t, attester.Submitted(ctx, core.NewAttesterDuty(slot.Slot), core.ParSignedDataSet{submittedPubkey: submittedParSig}))
+
+			require.NoError(t, attester.HandleSlot(ctx, slot))
+			require.Len(t, outputSet, 1)
+
+			parSig, ok := outputSet[pubkey]
+			require.True(t, ok)
+			require.Equal(t, shareIdx, parSig.ShareIdx)
+
+			att, ok := parSig.SignedData.(core.VersionedAttestation)
+			require.True(t, ok)
+			require.Equal(t, test.Version, att.Version)
+
+			attData, err := att.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, attData)
+
+			aggBits, err := att.AggregationBits()
+			require.NoError(t, err)
+			require.Equal(t, []int{1}, aggBits.BitIndices())
+
+			root, err := data.HashTreeRoot()
+			require.NoError(t, err)
+			sigData, err := signing.GetDataRoot(ctx, bmock, signing.DomainBeaconAttester, data.Target.Epoch, root)
+			require.NoError(t, err)
+			require.NoError(t, tbls.Verify(pubshare, sigData[:], tbls.Signature(parSig.Signature())))
+
+			// Slashable attestations are not produced.
+			outputSet = nil
+			data = testutil.RandomAttestationDataPhase0()
+			data.Slot = eth2p0.Slot(slot.Slot)
+			data.Index = commIdx
+			data.Target.Epoch = attData.Target.Epoch
+
+			require.NoError(t, attester.HandleSlot(ctx, slot))
+			require.Nil(t, outputSet)
+		})
+	}
+}
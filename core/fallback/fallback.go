@@ -0,0 +1,96 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package fallback provides core workflow components that produce and partially sign duty data
+// with this node's key shares when validator clients do not submit it in time.
+package fallback
+
+import (
+	"context"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// LoadSecrets returns this node's secret key shares by validator public key, loaded from the keystores in dir.
+// The pubshares map contains this node's public key share by validator public key.
+func LoadSecrets(dir string, pubshares map[core.PubKey]tbls.PublicKey) (map[core.PubKey]tbls.PrivateKey, error) {
+	keyFiles, err := keystore.LoadFilesUnordered(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secretsByShare := make(map[tbls.PublicKey]tbls.PrivateKey)
+	for _, secret := range keyFiles.Keys() {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "secret to public key share")
+		}
+
+		secretsByShare[pubshare] = secret
+	}
+
+	resp := make(map[core.PubKey]tbls.PrivateKey)
+	for pubkey, pubshare := range pubshares {
+		secret, ok := secretsByShare[pubshare]
+		if !ok {
+			return nil, errors.New("key share missing for validator", z.Any("pubkey", pubkey), z.Str("dir", dir))
+		}
+
+		resp[pubkey] = secret
+	}
+
+	return resp, nil
+}
+
+// newSubmissions returns a new empty validator client submission tracker.
+func newSubmissions() *submissions {
+	return &submissions{
+		seen: make(map[core.Duty]map[core.PubKey]bool),
+	}
+}
+
+// submissions tracks the validators per duty partially signed by validator clients.
+type submissions struct {
+	mu   sync.Mutex
+	seen map[core.Duty]map[core.PubKey]bool
+}
+
+// Add records the partially signed duty data submitted by validator clients.
+func (s *submissions) Add(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[duty] == nil {
+		s.seen[duty] = make(map[core.PubKey]bool)
+	}
+
+	for pubkey := range set {
+		s.seen[duty][pubkey] = true
+	}
+
+	return nil
+}
+
+// Seen returns true if a validator client submitted the duty for the validator.
+func (s *submissions) Seen(duty core.Duty, pubkey core.PubKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen[duty][pubkey]
+}
+
+// Trim deletes all submissions of duties up to and including the slot.
+func (s *submissions) Trim(slot uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for duty := range s.seen {
+		if duty.Slot <= slot {
+			delete(s.seen, duty)
+		}
+	}
+}
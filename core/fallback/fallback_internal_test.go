@@ -0,0 +1,72 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLoadSecrets(t *testing.T) {
+	var (
+		secrets   []tbls.PrivateKey
+		pubshares = make(map[core.PubKey]tbls.PublicKey)
+	)
+
+	for range 3 {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		secrets = append(secrets, secret)
+		pubshares[testutil.RandomCorePubKey(t)] = pubshare
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, keystore.StoreKeysInsecure(secrets, dir, keystore.ConfirmInsecureKeys))
+
+	resp, err := LoadSecrets(dir, pubshares)
+	require.NoError(t, err)
+	require.Len(t, resp, len(pubshares))
+
+	for pubkey, secret := range resp {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+		require.Equal(t, pubshares[pubkey], pubshare)
+	}
+
+	// Missing key shares are not allowed.
+	missing, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	missingShare, err := tbls.SecretToPublicKey(missing)
+	require.NoError(t, err)
+
+	pubshares[testutil.RandomCorePubKey(t)] = missingShare
+
+	_, err = LoadSecrets(dir, pubshares)
+	require.ErrorContains(t, err, "key share missing for validator")
+}
+
+func TestSubmissions(t *testing.T) {
+	submissions := newSubmissions()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	duty := core.NewSyncMessageDuty(10)
+	require.NoError(t, submissions.Add(t.Context(), duty, core.ParSignedDataSet{pubkey: core.ParSignedData{}}))
+	require.True(t, submissions.Seen(duty, pubkey))
+	require.False(t, submissions.Seen(core.NewSyncMessageDuty(11), pubkey))
+
+	submissions.Trim(9)
+	require.True(t, submissions.Seen(duty, pubkey))
+
+	submissions.Trim(10)
+	require.False(t, submissions.Seen(duty, pubkey))
+}
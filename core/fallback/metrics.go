@@ -0,0 +1,16 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var producedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "core",
+	Subsystem: "fallback",
+	Name:      "produced_total",
+	Help:      "The total number of partially signed duty data produced by charon since no validator client submission was seen in time, by duty type",
+}, []string{"duty"})
@@ -0,0 +1,113 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"sync"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// maxAttRecords is the number of most recent attestations retained per validator for slashing protection.
+const maxAttRecords = 256
+
+// attRecord is an attestation signed by this node's key share.
+type attRecord struct {
+	Source eth2p0.Epoch
+	Target eth2p0.Epoch
+	Root   eth2p0.Root
+}
+
+// newSlashingDB returns a new empty in-memory attestation slashing protection database.
+func newSlashingDB() *slashingDB {
+	return &slashingDB{
+		records: make(map[core.PubKey][]attRecord),
+	}
+}
+
+// slashingDB protects against signing double and surround votes by tracking the most recent attestations
+// signed by this node's key shares, both by validator clients and charon.
+// Note it is in-memory only, so only protects against slashable attestations signed since startup.
+type slashingDB struct {
+	mu      sync.Mutex
+	records map[core.PubKey][]attRecord
+}
+
+// Record records the attestation data signed for the validator.
+func (db *slashingDB) Record(pubkey core.PubKey, data *eth2p0.AttestationData) error {
+	record, err := newAttRecord(data)
+	if err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.record(pubkey, record)
+
+	return nil
+}
+
+// CheckAndRecord returns an error if signing the attestation data for the validator is slashable,
+// otherwise it records it.
+func (db *slashingDB) CheckAndRecord(pubkey core.PubKey, data *eth2p0.AttestationData) error {
+	record, err := newAttRecord(data)
+	if err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, prev := range db.records[pubkey] {
+		if prev == record {
+			return nil // Identical attestations are not slashable.
+		}
+
+		if prev.Target == record.Target {
+			return errors.New("slashable double vote", z.U64("target", uint64(record.Target)))
+		}
+
+		if (prev.Source < record.Source && prev.Target > record.Target) ||
+			(prev.Source > record.Source && prev.Target < record.Target) {
+			return errors.New("slashable surround vote",
+				z.U64("source", uint64(record.Source)), z.U64("target", uint64(record.Target)),
+				z.U64("prev_source", uint64(prev.Source)), z.U64("prev_target", uint64(prev.Target)))
+		}
+	}
+
+	db.record(pubkey, record)
+
+	return nil
+}
+
+// record appends the record, trimming old records. It assumes the lock is held.
+func (db *slashingDB) record(pubkey core.PubKey, record attRecord) {
+	records := append(db.records[pubkey], record)
+	if len(records) > maxAttRecords {
+		records = records[len(records)-maxAttRecords:]
+	}
+
+	db.records[pubkey] = records
+}
+
+func newAttRecord(data *eth2p0.AttestationData) (attRecord, error) {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return attRecord{}, errors.New("invalid attestation data")
+	}
+
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return attRecord{}, errors.Wrap(err, "hash attestation data")
+	}
+
+	return attRecord{
+		Source: data.Source.Epoch,
+		Target: data.Target.Epoch,
+		Root:   root,
+	}, nil
+}
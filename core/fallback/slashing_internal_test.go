@@ -0,0 +1,48 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestSlashingDB(t *testing.T) {
+	attData := func(source, target eth2p0.Epoch) *eth2p0.AttestationData {
+		data := testutil.RandomAttestationDataPhase0()
+		data.Source.Epoch = source
+		data.Target.Epoch = target
+
+		return data
+	}
+
+	db := newSlashingDB()
+	pubkey := testutil.RandomCorePubKey(t)
+
+	signed := attData(10, 11)
+	require.NoError(t, db.Record(pubkey, signed))
+
+	// Identical attestation is allowed.
+	require.NoError(t, db.CheckAndRecord(pubkey, signed))
+
+	// Double vote.
+	require.ErrorContains(t, db.CheckAndRecord(pubkey, attData(10, 11)), "slashable double vote")
+
+	// Surrounding vote.
+	require.ErrorContains(t, db.CheckAndRecord(pubkey, attData(9, 12)), "slashable surround vote")
+
+	// Surrounded vote.
+	require.NoError(t, db.Record(pubkey, attData(5, 20)))
+	require.ErrorContains(t, db.CheckAndRecord(pubkey, attData(6, 19)), "slashable surround vote")
+
+	// Subsequent attestation is allowed.
+	require.NoError(t, db.CheckAndRecord(pubkey, attData(20, 21)))
+	require.ErrorContains(t, db.CheckAndRecord(pubkey, attData(20, 21)), "slashable double vote")
+
+	// Other validators are not affected.
+	require.NoError(t, db.CheckAndRecord(testutil.RandomCorePubKey(t), attData(10, 11)))
+}
@@ -0,0 +1,197 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// NewSyncMessage returns a new sync committee message fallback producing and partially signing
+// sync committee messages for scheduled validators if no validator client submission is seen
+// by delay into the slot.
+func NewSyncMessage(eth2Cl eth2wrap.Client, shareIdx int, secrets map[core.PubKey]tbls.PrivateKey,
+	dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error), delay time.Duration,
+) *SyncMessage {
+	return &SyncMessage{
+		eth2Cl:      eth2Cl,
+		shareIdx:    shareIdx,
+		secrets:     secrets,
+		dutyDefFunc: dutyDefFunc,
+		delay:       delay,
+		submissions: newSubmissions(),
+	}
+}
+
+// SyncMessage is the sync committee message fallback, protecting sync committee rewards against
+// validator clients failing to submit sync committee messages.
+type SyncMessage struct {
+	eth2Cl      eth2wrap.Client
+	shareIdx    int
+	secrets     map[core.PubKey]tbls.PrivateKey
+	dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error)
+	delay       time.Duration
+	submissions *submissions
+	subs        []func(context.Context, core.Duty, core.ParSignedDataSet) error
+
+	mu       sync.Mutex
+	headSlot uint64
+	headRoot eth2p0.Root
+	hasHead  bool
+}
+
+// Subscribe registers a callback for fallback partially signed sync committee messages.
+func (m *SyncMessage) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
+	m.subs = append(m.subs, fn)
+}
+
+// Submitted records partially signed duty data submitted by validator clients.
+// It is intended to subscribe to the validator API.
+func (m *SyncMessage) Submitted(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	if duty.Type != core.DutySyncMessage {
+		return nil
+	}
+
+	return m.submissions.Add(ctx, duty, set)
+}
+
+// HeadReceived records the latest head block root from the beacon node event stream.
+func (m *SyncMessage) HeadReceived(_ context.Context, slot uint64, root eth2p0.Root) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.hasHead && slot < m.headSlot {
+		return
+	}
+
+	m.headSlot = slot
+	m.headRoot = root
+	m.hasHead = true
+}
+
+// HandleSlot waits until delay into the slot and produces sync committee messages for
+// all scheduled validators without validator client submissions.
+// It is intended to subscribe to the scheduler's slots.
+func (m *SyncMessage) HandleSlot(ctx context.Context, slot core.Slot) error {
+	defer m.submissions.Trim(slot.Slot)
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Until(slot.Time.Add(m.delay))):
+	}
+
+	// Sync committee members are scheduled for sync contribution duties every slot.
+	defSet, err := m.dutyDefFunc(ctx, core.NewSyncContributionDuty(slot.Slot))
+	if errors.Is(err, core.ErrNotFound) {
+		return nil // No sync committee members in this slot.
+	} else if err != nil {
+		return err
+	}
+
+	duty := core.NewSyncMessageDuty(slot.Slot)
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	var root eth2p0.Root
+
+	set := make(core.ParSignedDataSet)
+	for pubkey, def := range defSet {
+		if m.submissions.Seen(duty, pubkey) {
+			continue
+		}
+
+		secret, ok := m.secrets[pubkey]
+		if !ok {
+			continue
+		}
+
+		syncDef, ok := def.(core.SyncCommitteeDefinition)
+		if !ok {
+			return errors.New("invalid sync committee definition")
+		}
+
+		if root == (eth2p0.Root{}) {
+			root, err = m.getHeadRoot(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		msg, err := m.sign(ctx, slot, root, syncDef.ValidatorIndex, secret)
+		if err != nil {
+			return err
+		}
+
+		set[pubkey] = core.NewPartialSignedSyncMessage(msg, m.shareIdx)
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+
+	log.Warn(ctx, "No validator client sync committee messages seen, producing fallback sync committee messages", nil,
+		z.Int("validators", len(set)), z.Str("delay", m.delay.String()))
+	producedCounter.WithLabelValues(duty.Type.String()).Add(float64(len(set)))
+
+	for _, sub := range m.subs {
+		// No need to clone since sub auto clones.
+		if err := sub(ctx, duty, set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getHeadRoot returns the latest head block root from the event stream, or queries the beacon node if none was received.
+func (m *SyncMessage) getHeadRoot(ctx context.Context) (eth2p0.Root, error) {
+	m.mu.Lock()
+	root, ok := m.headRoot, m.hasHead
+	m.mu.Unlock()
+
+	if ok {
+		return root, nil
+	}
+
+	resp, err := m.eth2Cl.BeaconBlockRoot(ctx, &eth2api.BeaconBlockRootOpts{Block: "head"})
+	if err != nil {
+		return eth2p0.Root{}, err
+	}
+
+	return *resp.Data, nil
+}
+
+// sign returns the sync committee message of the validator partially signed with its secret key share.
+func (m *SyncMessage) sign(ctx context.Context, slot core.Slot, root eth2p0.Root, vIdx eth2p0.ValidatorIndex,
+	secret tbls.PrivateKey,
+) (*altair.SyncCommitteeMessage, error) {
+	sigData, err := signing.GetDataRoot(ctx, m.eth2Cl, signing.DomainSyncCommittee, eth2p0.Epoch(slot.Epoch()), root)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := tbls.Sign(secret, sigData[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &altair.SyncCommitteeMessage{
+		Slot:            eth2p0.Slot(slot.Slot),
+		BeaconBlockRoot: root,
+		ValidatorIndex:  vIdx,
+		Signature:       eth2p0.BLSSignature(sig),
+	}, nil
+}
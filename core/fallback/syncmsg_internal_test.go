@@ -0,0 +1,102 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fallback
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestSyncMessage(t *testing.T) {
+	const (
+		shareIdx = 2
+		vIdx     = 7
+	)
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubkey := testutil.RandomCorePubKey(t)
+	submittedPubkey := testutil.RandomCorePubKey(t)
+	secrets := map[core.PubKey]tbls.PrivateKey{pubkey: secret, submittedPubkey: secret}
+
+	slot := core.Slot{
+		Slot:          10,
+		Time:          time.Now(),
+		SlotDuration:  10 * time.Millisecond,
+		SlotsPerEpoch: 16,
+	}
+
+	dutyDefFunc := func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+		if duty != core.NewSyncContributionDuty(slot.Slot) {
+			return nil, core.ErrNotFound
+		}
+
+		return core.DutyDefinitionSet{
+			pubkey:          core.NewSyncCommitteeDefinition(&eth2v1.SyncCommitteeDuty{ValidatorIndex: vIdx}),
+			submittedPubkey: core.NewSyncCommitteeDefinition(&eth2v1.SyncCommitteeDuty{ValidatorIndex: vIdx + 1}),
+		}, nil
+	}
+
+	fallback := NewSyncMessage(bmock, shareIdx, secrets, dutyDefFunc, 0)
+
+	var (
+		outputDuty core.Duty
+		outputSet  core.ParSignedDataSet
+	)
+	fallback.Subscribe(func(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		outputDuty = duty
+		outputSet = set
+
+		return nil
+	})
+
+	ctx := t.Context()
+	root := testutil.RandomRoot()
+
+	fallback.HeadReceived(ctx, slot.Slot, root)
+	fallback.HeadReceived(ctx, slot.Slot-1, testutil.RandomRoot()) // Older heads are ignored.
+
+	// Validator client submitted for one of the validators.
+	require.NoError(t, fallback.Submitted(ctx, core.NewSyncMessageDuty(slot.Slot), core.ParSignedDataSet{submittedPubkey: core.ParSignedData{}}))
+
+	require.NoError(t, fallback.HandleSlot(ctx, slot))
+	require.Equal(t, core.NewSyncMessageDuty(slot.Slot), outputDuty)
+	require.Len(t, outputSet, 1)
+
+	parSig, ok := outputSet[pubkey]
+	require.True(t, ok)
+	require.Equal(t, shareIdx, parSig.ShareIdx)
+
+	msg, ok := parSig.SignedData.(core.SignedSyncMessage)
+	require.True(t, ok)
+	require.Equal(t, eth2p0.Slot(slot.Slot), msg.Slot)
+	require.Equal(t, root, msg.BeaconBlockRoot)
+	require.EqualValues(t, vIdx, msg.ValidatorIndex)
+
+	sigData, err := signing.GetDataRoot(ctx, bmock, signing.DomainSyncCommittee, eth2p0.Epoch(slot.Epoch()), root)
+	require.NoError(t, err)
+	require.NoError(t, tbls.Verify(pubshare, sigData[:], tbls.Signature(msg.SyncCommitteeMessage.Signature)))
+
+	// No fallback without scheduled sync committee members.
+	outputSet = nil
+
+	require.NoError(t, fallback.HandleSlot(ctx, slot.Next()))
+	require.Nil(t, outputSet)
+}
@@ -0,0 +1,291 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// Builder proposal fields validated when fetching blinded proposals.
+const (
+	headerValue         = "value"
+	headerProposerIndex = "proposer_index"
+	headerBlockHash     = "block_hash"
+	headerParentHash    = "parent_hash"
+	headerGasLimit      = "gas_limit"
+)
+
+// builderValidation configures validation of builder payload headers.
+type builderValidation struct {
+	targetGasLimitFunc func(epoch uint64) uint64 // Returning zero disables gas limit validation.
+	slotsPerEpoch      uint64
+	fallback           bool
+}
+
+// WithBuilderValidation returns an option enabling validation of the builder bid value, proposer index and payload
+// header of fetched blinded proposals against the proposer duty, the parent execution payload and the target gas limit.
+// Discrepancies are logged and counted; if fallback is enabled, builder blocks with discrepancies are replaced by
+// locally built blocks.
+func WithBuilderValidation(targetGasLimitFunc func(epoch uint64) uint64, slotsPerEpoch uint64, fallback bool) Option {
+	return func(f *Fetcher) {
+		f.builderValidation = &builderValidation{
+			targetGasLimitFunc: targetGasLimitFunc,
+			slotsPerEpoch:      slotsPerEpoch,
+			fallback:           fallback,
+		}
+	}
+}
+
+// RelayBids requests builder bids from relays directly.
+type RelayBids interface {
+	// BuilderBid returns the highest value builder bid of all relays or false if no relay provided a bid.
+	BuilderBid(ctx context.Context, slot eth2p0.Slot, parentHash eth2p0.Hash32, pubkey eth2p0.BLSPubKey) (*builderspec.VersionedSignedBuilderBid, bool, error)
+}
+
+// WithRelayBids returns an option requesting builder bids from the relays for fetched blinded proposals,
+// so that the relays serving the proposal's bid are known and able to unblind it.
+func WithRelayBids(relays RelayBids) Option {
+	return func(f *Fetcher) {
+		f.relays = relays
+	}
+}
+
+// requestRelayBids requests builder bids for the blinded proposal from the relays, logging if the
+// relays didn't serve the proposal's bid, in which case it is unblinded via the beacon node.
+func requestRelayBids(ctx context.Context, relays RelayBids, pubkey core.PubKey, proposal *eth2api.VersionedProposal) error {
+	header, err := blindedPayloadHeader(proposal)
+	if err != nil {
+		return err
+	}
+
+	slot, err := proposal.Slot()
+	if err != nil {
+		return errors.Wrap(err, "get proposal slot")
+	}
+
+	eth2Pubkey, err := pubkey.ToETH2()
+	if err != nil {
+		return err
+	}
+
+	bid, ok, err := relays.BuilderBid(ctx, slot, header.ParentHash, eth2Pubkey)
+	if err != nil {
+		return err
+	} else if !ok {
+		log.Debug(ctx, "No relay bid for blinded proposal", z.Any("pubkey", pubkey))
+		return nil
+	}
+
+	blockHash, err := bid.BlockHash()
+	if err != nil {
+		return errors.Wrap(err, "get bid block hash")
+	}
+
+	if blockHash != header.BlockHash {
+		log.Debug(ctx, "Best relay bid differs from blinded proposal", z.Any("pubkey", pubkey),
+			z.Str("bid_block_hash", blockHash.String()), z.Str("proposal_block_hash", header.BlockHash.String()))
+	}
+
+	return nil
+}
+
+// payloadHeader contains the execution payload header fields validated by the fetcher.
+type payloadHeader struct {
+	BlockHash  eth2p0.Hash32
+	ParentHash eth2p0.Hash32
+	GasLimit   uint64
+}
+
+// validateBuilderHeader validates the blinded proposal's bid value, proposer index and execution payload header
+// against the proposer duty, the parent execution payload and the target gas limit, as a defense against relays
+// equivocating on the payload header. It returns the mismatching fields after logging and counting them.
+func validateBuilderHeader(ctx context.Context, eth2Cl eth2client.SignedBeaconBlockProvider, validation builderValidation,
+	pubkey core.PubKey, dutyDef core.DutyDefinition, proposal *eth2api.VersionedProposal,
+) ([]string, error) {
+	header, err := blindedPayloadHeader(proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []string
+	mismatch := func(field string, expected, actual any) {
+		log.Warn(ctx, "Builder proposal mismatch", nil,
+			z.Str("field", field),
+			z.Str("expected", fmt.Sprint(expected)),
+			z.Str("actual", fmt.Sprint(actual)),
+			z.Any("pubkey", pubkey),
+		)
+		builderHeaderMismatchCounter.WithLabelValues(field).Inc()
+
+		mismatches = append(mismatches, field)
+	}
+
+	if proposal.ExecutionValue == nil || proposal.ExecutionValue.Sign() <= 0 {
+		mismatch(headerValue, "positive value", proposal.ExecutionValue)
+	}
+
+	proposerIdx, err := proposal.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "get proposer index")
+	} else if duty, ok := dutyDef.(core.ProposerDefinition); ok && proposerIdx != duty.ValidatorIndex {
+		mismatch(headerProposerIndex, duty.ValidatorIndex, proposerIdx)
+	}
+
+	if header.BlockHash == (eth2p0.Hash32{}) || header.BlockHash == header.ParentHash {
+		mismatch(headerBlockHash, "non-zero unique hash", header.BlockHash)
+	}
+
+	parent, ok, err := parentPayloadHeader(ctx, eth2Cl, proposal)
+	if err != nil {
+		log.Warn(ctx, "Cannot validate builder payload header against parent", err, z.Any("pubkey", pubkey))
+	} else if ok {
+		if header.ParentHash != parent.BlockHash {
+			mismatch(headerParentHash, parent.BlockHash, header.ParentHash)
+		}
+
+		if target := validation.targetGasLimit(proposal); target != 0 {
+			expected := expectedGasLimit(parent.GasLimit, target)
+			if header.GasLimit != expected {
+				mismatch(headerGasLimit, expected, header.GasLimit)
+			}
+		}
+	}
+
+	return mismatches, nil
+}
+
+// targetGasLimit returns the target gas limit at the epoch of the proposal or zero if not available.
+func (v builderValidation) targetGasLimit(proposal *eth2api.VersionedProposal) uint64 {
+	if v.targetGasLimitFunc == nil || v.slotsPerEpoch == 0 {
+		return 0
+	}
+
+	slot, err := proposal.Slot()
+	if err != nil {
+		return 0
+	}
+
+	return v.targetGasLimitFunc(uint64(slot) / v.slotsPerEpoch)
+}
+
+// expectedGasLimit returns the gas limit of a block with the parent gas limit moving towards the target
+// gas limit by the maximum allowed delta, as defined by the builder specs.
+func expectedGasLimit(parentGasLimit, targetGasLimit uint64) uint64 {
+	maxDelta := parentGasLimit/1024 - 1
+
+	switch {
+	case parentGasLimit < targetGasLimit:
+		return min(parentGasLimit+maxDelta, targetGasLimit)
+	case parentGasLimit > targetGasLimit:
+		return max(parentGasLimit-maxDelta, targetGasLimit)
+	default:
+		return parentGasLimit
+	}
+}
+
+// parentPayloadHeader returns the execution payload header fields of the proposal's parent block
+// or false if the parent block doesn't contain an execution payload.
+func parentPayloadHeader(ctx context.Context, eth2Cl eth2client.SignedBeaconBlockProvider, proposal *eth2api.VersionedProposal) (payloadHeader, bool, error) {
+	parentRoot, err := proposal.ParentRoot()
+	if err != nil {
+		return payloadHeader{}, false, errors.Wrap(err, "get parent root")
+	}
+
+	resp, err := eth2Cl.SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: parentRoot.String()})
+	if err != nil {
+		return payloadHeader{}, false, errors.Wrap(err, "fetch parent block")
+	}
+
+	block := resp.Data
+
+	switch block.Version {
+	case eth2spec.DataVersionPhase0, eth2spec.DataVersionAltair:
+		return payloadHeader{}, false, nil
+	case eth2spec.DataVersionBellatrix:
+		if block.Bellatrix == nil || block.Bellatrix.Message == nil || block.Bellatrix.Message.Body == nil || block.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return payloadHeader{}, false, errors.New("no bellatrix parent block")
+		}
+
+		payload := block.Bellatrix.Message.Body.ExecutionPayload
+
+		return payloadHeader{BlockHash: payload.BlockHash, GasLimit: payload.GasLimit}, true, nil
+	case eth2spec.DataVersionCapella:
+		if block.Capella == nil || block.Capella.Message == nil || block.Capella.Message.Body == nil || block.Capella.Message.Body.ExecutionPayload == nil {
+			return payloadHeader{}, false, errors.New("no capella parent block")
+		}
+
+		payload := block.Capella.Message.Body.ExecutionPayload
+
+		return payloadHeader{BlockHash: payload.BlockHash, GasLimit: payload.GasLimit}, true, nil
+	case eth2spec.DataVersionDeneb:
+		if block.Deneb == nil || block.Deneb.Message == nil || block.Deneb.Message.Body == nil || block.Deneb.Message.Body.ExecutionPayload == nil {
+			return payloadHeader{}, false, errors.New("no deneb parent block")
+		}
+
+		payload := block.Deneb.Message.Body.ExecutionPayload
+
+		return payloadHeader{BlockHash: payload.BlockHash, GasLimit: payload.GasLimit}, true, nil
+	case eth2spec.DataVersionElectra:
+		if block.Electra == nil || block.Electra.Message == nil || block.Electra.Message.Body == nil || block.Electra.Message.Body.ExecutionPayload == nil {
+			return payloadHeader{}, false, errors.New("no electra parent block")
+		}
+
+		payload := block.Electra.Message.Body.ExecutionPayload
+
+		return payloadHeader{BlockHash: payload.BlockHash, GasLimit: payload.GasLimit}, true, nil
+	default:
+		return payloadHeader{}, false, errors.New("unsupported parent block version", z.Str("version", block.Version.String()))
+	}
+}
+
+// blindedPayloadHeader returns the execution payload header fields of the blinded proposal.
+func blindedPayloadHeader(proposal *eth2api.VersionedProposal) (payloadHeader, error) {
+	switch proposal.Version {
+	case eth2spec.DataVersionBellatrix:
+		if proposal.BellatrixBlinded == nil || proposal.BellatrixBlinded.Body == nil || proposal.BellatrixBlinded.Body.ExecutionPayloadHeader == nil {
+			return payloadHeader{}, errors.New("no bellatrix blinded proposal")
+		}
+
+		header := proposal.BellatrixBlinded.Body.ExecutionPayloadHeader
+
+		return payloadHeader{BlockHash: header.BlockHash, ParentHash: header.ParentHash, GasLimit: header.GasLimit}, nil
+	case eth2spec.DataVersionCapella:
+		if proposal.CapellaBlinded == nil || proposal.CapellaBlinded.Body == nil || proposal.CapellaBlinded.Body.ExecutionPayloadHeader == nil {
+			return payloadHeader{}, errors.New("no capella blinded proposal")
+		}
+
+		header := proposal.CapellaBlinded.Body.ExecutionPayloadHeader
+
+		return payloadHeader{BlockHash: header.BlockHash, ParentHash: header.ParentHash, GasLimit: header.GasLimit}, nil
+	case eth2spec.DataVersionDeneb:
+		if proposal.DenebBlinded == nil || proposal.DenebBlinded.Body == nil || proposal.DenebBlinded.Body.ExecutionPayloadHeader == nil {
+			return payloadHeader{}, errors.New("no deneb blinded proposal")
+		}
+
+		header := proposal.DenebBlinded.Body.ExecutionPayloadHeader
+
+		return payloadHeader{BlockHash: header.BlockHash, ParentHash: header.ParentHash, GasLimit: header.GasLimit}, nil
+	case eth2spec.DataVersionElectra:
+		if proposal.ElectraBlinded == nil || proposal.ElectraBlinded.Body == nil || proposal.ElectraBlinded.Body.ExecutionPayloadHeader == nil {
+			return payloadHeader{}, errors.New("no electra blinded proposal")
+		}
+
+		header := proposal.ElectraBlinded.Body.ExecutionPayloadHeader
+
+		return payloadHeader{BlockHash: header.BlockHash, ParentHash: header.ParentHash, GasLimit: header.GasLimit}, nil
+	default:
+		return payloadHeader{}, errors.New("unsupported blinded proposal version", z.Str("version", proposal.Version.String()))
+	}
+}
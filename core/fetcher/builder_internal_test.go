@@ -0,0 +1,140 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	builderspec "github.com/attestantio/go-builder-client/spec"
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestExpectedGasLimit(t *testing.T) {
+	require.Equal(t, uint64(30_000_000), expectedGasLimit(30_000_000, 30_000_000))
+	require.Equal(t, uint64(30_029_295), expectedGasLimit(30_000_000, 36_000_000))
+	require.Equal(t, uint64(29_970_705), expectedGasLimit(30_000_000, 20_000_000))
+	require.Equal(t, uint64(30_010_000), expectedGasLimit(30_000_000, 30_010_000))
+}
+
+func TestValidateBuilderHeader(t *testing.T) {
+	const targetGasLimit = 36_000_000
+
+	parent := testutil.RandomCapellaVersionedSignedBeaconBlock()
+	parent.Capella.Message.Body.ExecutionPayload.GasLimit = 30_000_000
+
+	mock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	mock.SignedBeaconBlockFunc = func(context.Context, string) (*eth2spec.VersionedSignedBeaconBlock, error) {
+		return parent, nil
+	}
+
+	pubkey := testutil.RandomCorePubKey(t)
+	dutyDef := core.NewProposerDefinition(&eth2v1.ProposerDuty{ValidatorIndex: 42})
+
+	newProposal := func() *eth2api.VersionedProposal {
+		proposal := testutil.RandomCapellaVersionedBlindedProposal().VersionedProposal
+		proposal.ExecutionValue = big.NewInt(1)
+		proposal.CapellaBlinded.ProposerIndex = 42
+
+		header := proposal.CapellaBlinded.Body.ExecutionPayloadHeader
+		header.ParentHash = parent.Capella.Message.Body.ExecutionPayload.BlockHash
+		header.GasLimit = expectedGasLimit(30_000_000, targetGasLimit)
+
+		return &proposal
+	}
+
+	validation := builderValidation{
+		targetGasLimitFunc: func(uint64) uint64 { return targetGasLimit },
+		slotsPerEpoch:      16,
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*eth2api.VersionedProposal)
+		field  string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*eth2api.VersionedProposal) {},
+		},
+		{
+			name:   "zero value",
+			mutate: func(p *eth2api.VersionedProposal) { p.ExecutionValue = big.NewInt(0) },
+			field:  headerValue,
+		},
+		{
+			name:   "proposer index mismatch",
+			mutate: func(p *eth2api.VersionedProposal) { p.CapellaBlinded.ProposerIndex = 43 },
+			field:  headerProposerIndex,
+		},
+		{
+			name: "parent hash mismatch",
+			mutate: func(p *eth2api.VersionedProposal) {
+				p.CapellaBlinded.Body.ExecutionPayloadHeader.ParentHash = eth2p0.Hash32(testutil.RandomRoot())
+			},
+			field: headerParentHash,
+		},
+		{
+			name: "gas limit mismatch",
+			mutate: func(p *eth2api.VersionedProposal) {
+				p.CapellaBlinded.Body.ExecutionPayloadHeader.GasLimit = targetGasLimit
+			},
+			field: headerGasLimit,
+		},
+		{
+			name: "fee recipient not validated",
+			mutate: func(p *eth2api.VersionedProposal) {
+				p.CapellaBlinded.Body.ExecutionPayloadHeader.FeeRecipient = testutil.RandomExecutionAddress()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proposal := newProposal()
+			test.mutate(proposal)
+
+			mismatches, err := validateBuilderHeader(t.Context(), mock, validation, pubkey, dutyDef, proposal)
+			require.NoError(t, err)
+
+			if test.field == "" {
+				require.Empty(t, mismatches)
+			} else {
+				require.Equal(t, []string{test.field}, mismatches)
+			}
+		})
+	}
+}
+
+// testRelayBids is a stub relay bids provider.
+type testRelayBids struct {
+	slot       eth2p0.Slot
+	parentHash eth2p0.Hash32
+}
+
+func (r *testRelayBids) BuilderBid(_ context.Context, slot eth2p0.Slot, parentHash eth2p0.Hash32, _ eth2p0.BLSPubKey) (*builderspec.VersionedSignedBuilderBid, bool, error) {
+	r.slot = slot
+	r.parentHash = parentHash
+
+	return nil, false, nil
+}
+
+func TestRequestRelayBids(t *testing.T) {
+	proposal := testutil.RandomCapellaVersionedBlindedProposal().VersionedProposal
+	relays := new(testRelayBids)
+
+	require.NoError(t, requestRelayBids(t.Context(), relays, testutil.RandomCorePubKey(t), &proposal))
+	require.Equal(t, proposal.CapellaBlinded.Slot, relays.slot)
+	require.Equal(t, proposal.CapellaBlinded.Body.ExecutionPayloadHeader.ParentHash, relays.parentHash)
+}
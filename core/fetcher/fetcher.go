@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/big"
+	"strconv"
 	"strings"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
@@ -20,27 +22,56 @@ import (
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 )
 
+// Option configures the fetcher.
+type Option func(*Fetcher)
+
+// WithBuilderMinBid returns an option configuring the minimum builder bid in ETH. Proposals of builder blocks
+// with a lower execution value are replaced by locally built blocks. Zero disables the minimum bid.
+func WithBuilderMinBid(minBidETH float64) Option {
+	return func(f *Fetcher) {
+		f.builderMinBid = ethToWei(minBidETH)
+	}
+}
+
+// WithHeaderCache returns an option checking fetched attestation data against the canonical block roots of the cache.
+func WithHeaderCache(cache *eth2wrap.HeaderCache) Option {
+	return func(f *Fetcher) {
+		f.headerCache = cache
+	}
+}
+
 // New returns a new fetcher instance.
-func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderEnabled bool, graffitiBuilder *GraffitiBuilder, electraSlot eth2p0.Slot) (*Fetcher, error) {
-	return &Fetcher{
+func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderEnabled bool, graffitiBuilder *GraffitiBuilder, electraSlot eth2p0.Slot, opts ...Option) (*Fetcher, error) {
+	f := &Fetcher{
 		eth2Cl:           eth2Cl,
 		feeRecipientFunc: feeRecipientFunc,
 		builderEnabled:   builderEnabled,
 		graffitiBuilder:  graffitiBuilder,
 		electraSlot:      electraSlot,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f, nil
 }
 
 // Fetcher fetches proposed duty data.
 type Fetcher struct {
-	eth2Cl           eth2wrap.Client
-	feeRecipientFunc func(core.PubKey) string
-	subs             []func(context.Context, core.Duty, core.UnsignedDataSet) error
-	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
-	awaitAttDataFunc func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
-	builderEnabled   bool
-	graffitiBuilder  *GraffitiBuilder
-	electraSlot      eth2p0.Slot
+	eth2Cl            eth2wrap.Client
+	feeRecipientFunc  func(core.PubKey) string
+	subs              []func(context.Context, core.Duty, core.UnsignedDataSet) error
+	aggSigDBFunc      func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
+	awaitAttDataFunc  func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)
+	aggSelectedFunc   func(slot uint64) bool
+	builderEnabled    bool
+	graffitiBuilder   *GraffitiBuilder
+	electraSlot       eth2p0.Slot
+	builderMinBid     *big.Int              // Nil if no minimum builder bid is configured.
+	builderValidation *builderValidation    // Nil if builder payload header validation is disabled.
+	relays            RelayBids             // Nil if relays are not called directly.
+	headerCache       *eth2wrap.HeaderCache // Nil if canonical block roots aren't cached.
 }
 
 // Subscribe registers a callback for fetched duties.
@@ -70,6 +101,12 @@ func (f *Fetcher) Fetch(ctx context.Context, duty core.Duty, defSet core.DutyDef
 	case core.DutyBuilderProposer:
 		return core.ErrDeprecatedDutyBuilderProposer
 	case core.DutyAggregator:
+		if f.aggSelectedFunc != nil && !f.aggSelectedFunc(duty.Slot) {
+			// Aggregate attestations are fetched by other nodes, only participate in consensus.
+			log.Debug(ctx, "Node not selected to fetch aggregate attestations")
+			return nil
+		}
+
 		unsignedSet, err = f.fetchAggregatorData(ctx, duty.Slot, defSet)
 		if err != nil {
 			return errors.Wrap(err, "fetch aggregator data")
@@ -113,6 +150,12 @@ func (f *Fetcher) RegisterAwaitAttData(fn func(ctx context.Context, slot uint64,
 	f.awaitAttDataFunc = fn
 }
 
+// RegisterAggregatorSelection registers a function returning true if this node is selected to fetch aggregate attestations for the slot.
+// Note: This is not thread safe and should only be called *before* Fetch.
+func (f *Fetcher) RegisterAggregatorSelection(fn func(slot uint64) bool) {
+	f.aggSelectedFunc = fn
+}
+
 // fetchAttesterData returns the fetched attestation data set for committees and validators in the arg set.
 func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet,
 ) (core.UnsignedDataSet, error) {
@@ -161,6 +204,8 @@ func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet cor
 			}
 
 			dataByCommIdx[commIdx] = eth2AttData
+
+			f.checkAttestationHead(ctx, eth2AttData)
 		}
 
 		resp[pubkey] = core.AttestationData{
@@ -172,6 +217,25 @@ func (f *Fetcher) fetchAttesterData(ctx context.Context, slot uint64, defSet cor
 	return resp, nil
 }
 
+// checkAttestationHead logs a warning if the attestation data votes for a head block other than the canonical block
+// of its slot, indicating the beacon node is on a different fork than the head event stream.
+func (f *Fetcher) checkAttestationHead(ctx context.Context, data *eth2p0.AttestationData) {
+	if f.headerCache == nil {
+		return
+	}
+
+	root, ok := f.headerCache.Root(data.Slot)
+	if !ok || root == data.BeaconBlockRoot {
+		return
+	}
+
+	log.Warn(ctx, "Attestation data head block isn't the canonical block of its slot", nil,
+		z.U64("slot", uint64(data.Slot)),
+		z.Str("head_root", data.BeaconBlockRoot.String()),
+		z.Str("canonical_root", root.String()),
+	)
+}
+
 // fetchAggregatorData fetches the attestation aggregation data.
 func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	pt := newPubkeysTracker("attester aggregation")
@@ -260,7 +324,7 @@ func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot uint64, defSet c
 
 func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	resp := make(core.UnsignedDataSet)
-	for pubkey := range defSet {
+	for pubkey, dutyDef := range defSet {
 		// Fetch previously aggregated randao reveal from AggSigDB
 		dutyRandao := core.NewRandaoDuty(slot)
 
@@ -292,6 +356,48 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 
 		proposal := eth2Resp.Data
 
+		fallback := f.belowMinBid(proposal)
+		if fallback {
+			log.Warn(ctx, "Builder block bid below minimum, falling back to locally built block", nil,
+				z.Str("bid_wei", proposal.ExecutionValue.String()),
+				z.Str("min_bid_wei", f.builderMinBid.String()),
+				z.Any("pubkey", pubkey),
+			)
+			builderMinBidFallbacks.Inc()
+		} else if f.builderValidation != nil && proposal.Blinded {
+			mismatches, err := validateBuilderHeader(ctx, f.eth2Cl, *f.builderValidation, pubkey, dutyDef, proposal)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(mismatches) > 0 && f.builderValidation.fallback {
+				log.Warn(ctx, "Builder proposal mismatch, falling back to locally built block", nil,
+					z.Any("fields", mismatches),
+					z.Any("pubkey", pubkey),
+				)
+
+				fallback = true
+			}
+		}
+
+		if fallback {
+			localBBF := uint64(0) // Zero builder boost factor always prefers the local execution payload.
+			opts.BuilderBoostFactor = &localBBF
+
+			eth2Resp, err = f.eth2Cl.Proposal(ctx, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "fetch local block proposal")
+			}
+
+			proposal = eth2Resp.Data
+		}
+
+		if f.relays != nil && proposal.Blinded {
+			if err := requestRelayBids(ctx, f.relays, pubkey, proposal); err != nil {
+				log.Warn(ctx, "Failed requesting relay bids, unblinding via beacon node", err, z.Any("pubkey", pubkey))
+			}
+		}
+
 		// Ensure fee recipient is correctly populated in proposal.
 		verifyFeeRecipient(ctx, proposal, f.feeRecipientFunc(pubkey))
 
@@ -306,6 +412,32 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot uint64, defSet cor
 	return resp, nil
 }
 
+// belowMinBid returns true if the proposal is a builder block with an execution value below the minimum builder bid.
+func (f *Fetcher) belowMinBid(proposal *eth2api.VersionedProposal) bool {
+	if f.builderMinBid == nil || !proposal.Blinded {
+		return false
+	}
+
+	return proposal.ExecutionValue == nil || proposal.ExecutionValue.Cmp(f.builderMinBid) < 0
+}
+
+// ethToWei returns the amount of ETH in wei or nil if not positive.
+func ethToWei(eth float64) *big.Int {
+	if eth <= 0 {
+		return nil
+	}
+
+	// Parse the shortest decimal representation to avoid binary floating point rounding errors.
+	amount, ok := new(big.Float).SetPrec(256).SetString(strconv.FormatFloat(eth, 'f', -1, 64))
+	if !ok {
+		return nil
+	}
+
+	wei, _ := amount.Mul(amount, new(big.Float).SetPrec(256).SetInt64(1e18)).Int(nil)
+
+	return wei
+}
+
 // fetchContributionData fetches the sync committee contribution data.
 func (f *Fetcher) fetchContributionData(ctx context.Context, slot uint64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	pt := newPubkeysTracker("sync committee contribution")
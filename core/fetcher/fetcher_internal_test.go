@@ -97,3 +97,11 @@ func TestVerifyFeeRecipient(t *testing.T) {
 		})
 	}
 }
+
+func TestEthToWei(t *testing.T) {
+	require.Nil(t, ethToWei(0))
+	require.Nil(t, ethToWei(-1))
+	require.Equal(t, "1", ethToWei(1e-18).String())
+	require.Equal(t, "50000000000000000", ethToWei(0.05).String())
+	require.Equal(t, "1230000000000000000", ethToWei(1.23).String())
+}
@@ -245,6 +245,31 @@ func TestFetchAggregator(t *testing.T) {
 	}
 }
 
+func TestFetchAggregatorNotSelected(t *testing.T) {
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	bmock.AggregateAttestationFunc = func(context.Context, eth2p0.Slot, eth2p0.Root) (*eth2spec.VersionedAttestation, error) {
+		require.Fail(t, "unexpected aggregate attestation query")
+		return nil, nil //nolint:nilnil // Unreachable
+	}
+
+	fetch := mustCreateFetcher(t, bmock)
+	fetch.RegisterAggregatorSelection(func(uint64) bool {
+		return false
+	})
+	fetch.Subscribe(func(context.Context, core.Duty, core.UnsignedDataSet) error {
+		require.Fail(t, "unexpected unsigned data")
+		return nil
+	})
+
+	defSet := core.DutyDefinitionSet{
+		testutil.RandomCorePubKey(t): core.NewAttesterDefinition(testutil.RandomAttestationDuty(t)),
+	}
+
+	require.NoError(t, fetch.Fetch(t.Context(), core.NewAggregatorDuty(1), defSet))
+}
+
 func TestFetchBlocks(t *testing.T) {
 	ctx := context.Background()
 
@@ -316,11 +341,9 @@ func TestFetchBlocks(t *testing.T) {
 			require.NoError(t, err)
 			require.EqualValues(t, slot, slotA)
 
-			if dutyDataA.Blinded {
-				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataA.CapellaBlinded.Body.ExecutionPayloadHeader.FeeRecipient))
-			} else {
-				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataA.Capella.Body.ExecutionPayload.FeeRecipient))
-			}
+			feeRecipientA, err := dutyDataA.FeeRecipient()
+			require.NoError(t, err)
+			require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", feeRecipientA))
 
 			assertRandao(t, randaoByPubKey[pubkeysByIdx[vIdxA]].Signature().ToETH2(), dutyDataA)
 			graffitiDutyA, err := dutyDataA.Graffiti()
@@ -332,11 +355,9 @@ func TestFetchBlocks(t *testing.T) {
 			require.NoError(t, err)
 			require.EqualValues(t, slot, slotB)
 
-			if dutyDataB.Blinded {
-				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataB.CapellaBlinded.Body.ExecutionPayloadHeader.FeeRecipient))
-			} else {
-				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", dutyDataB.Capella.Body.ExecutionPayload.FeeRecipient))
-			}
+			feeRecipientB, err := dutyDataB.FeeRecipient()
+			require.NoError(t, err)
+			require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", feeRecipientB))
 
 			assertRandao(t, randaoByPubKey[pubkeysByIdx[vIdxB]].Signature().ToETH2(), dutyDataB)
 			graffitiDutyB, err := dutyDataB.Graffiti()
@@ -351,6 +372,57 @@ func TestFetchBlocks(t *testing.T) {
 	})
 }
 
+func TestFetchBlocksBuilderMinBid(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		slot  = 1
+		vIdxA = 2
+	)
+
+	pubkey := testutil.RandomCorePubKey(t)
+	defSet := core.DutyDefinitionSet{
+		pubkey: core.NewProposerDefinition(&eth2v1.ProposerDuty{Slot: slot, ValidatorIndex: vIdxA}),
+	}
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		minBid  float64
+		blinded bool
+	}{
+		{name: "no min bid", minBid: 0, blinded: true},
+		{name: "bid above min bid", minBid: 1e-18, blinded: true},
+		{name: "bid below min bid", minBid: 0.01, blinded: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fetch, err := fetcher.New(bmock, func(core.PubKey) string { return "" }, true, &fetcher.GraffitiBuilder{}, 5, fetcher.WithBuilderMinBid(test.minBid))
+			require.NoError(t, err)
+
+			fetch.RegisterAggSigDB(func(context.Context, core.Duty, core.PubKey) (core.SignedData, error) {
+				return testutil.RandomCoreSignature(), nil
+			})
+
+			var called bool
+			fetch.Subscribe(func(_ context.Context, _ core.Duty, resDataSet core.UnsignedDataSet) error {
+				called = true
+
+				proposal := resDataSet[pubkey].(core.VersionedProposal)
+				require.Equal(t, test.blinded, proposal.Blinded)
+
+				return nil
+			})
+
+			require.NoError(t, fetch.Fetch(ctx, core.NewProposerDuty(slot), defSet))
+			require.True(t, called)
+		})
+	}
+}
+
 func TestFetchSyncContribution(t *testing.T) {
 	ctx := context.Background()
 
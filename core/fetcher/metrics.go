@@ -0,0 +1,25 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	builderMinBidFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "builder_min_bid_fallback_total",
+		Help:      "The total count of builder blocks below the minimum bid replaced by locally built blocks",
+	})
+
+	builderHeaderMismatchCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "builder_header_mismatch_total",
+		Help:      "The total count of builder proposal discrepancies detected when fetching blinded proposals by field",
+	}, []string{"field"})
+)
@@ -10,12 +10,16 @@ import (
 	"github.com/obolnetwork/charon/app/eth2wrap"
 )
 
-const defaultAllowedFutureEpochs = 2
+const (
+	defaultAllowedFutureEpochs = 2
+	defaultAllowedPastEpochs   = 2
+)
 
 // DutyGaterFunc is a function that returns true if the duty is allowed to be processed.
-// It checks whether duties received from peers over the wire are too far in the future
-// or whether the type is invalid. It doesn't check whether the duty
-// is in the past, that is done by Deadliner.
+// It checks whether duties received from peers over the wire are too far in the future,
+// too far in the past or whether the type is invalid. Rejecting duties older than the replay protection window
+// ensures captured slot-bound messages can't be replayed after network partitions, while
+// duties that are only slightly in the past are handled by Deadliner.
 type DutyGaterFunc func(Duty) bool
 
 // WithDutyGaterForT returns a function that sets the nowFunc and allowedFutureEpochs in
@@ -27,8 +31,17 @@ func WithDutyGaterForT(_ *testing.T, nowFunc func() time.Time, allowedFutureEpoc
 	}
 }
 
+// WithDutyGaterReplayWindow returns a function that sets the number of past epochs
+// of duties allowed by the DutyGaterFunc.
+func WithDutyGaterReplayWindow(allowedPastEpochs int) func(*dutyGaterOptions) {
+	return func(o *dutyGaterOptions) {
+		o.allowedPastEpochs = allowedPastEpochs
+	}
+}
+
 type dutyGaterOptions struct {
 	allowedFutureEpochs int
+	allowedPastEpochs   int
 	nowFunc             func() time.Time
 }
 
@@ -36,6 +49,7 @@ type dutyGaterOptions struct {
 func NewDutyGater(ctx context.Context, eth2Cl eth2wrap.Client, opts ...func(*dutyGaterOptions)) (DutyGaterFunc, error) {
 	o := dutyGaterOptions{
 		allowedFutureEpochs: defaultAllowedFutureEpochs,
+		allowedPastEpochs:   defaultAllowedPastEpochs,
 		nowFunc:             time.Now,
 	}
 	for _, opt := range opts {
@@ -54,6 +68,7 @@ func NewDutyGater(ctx context.Context, eth2Cl eth2wrap.Client, opts ...func(*dut
 
 	return func(duty Duty) bool {
 		if !duty.Type.Valid() {
+			gaterRejectedCounter.WithLabelValues(duty.Type.String(), "invalid_type").Inc()
 			return false
 		}
 
@@ -62,6 +77,21 @@ func NewDutyGater(ctx context.Context, eth2Cl eth2wrap.Client, opts ...func(*dut
 
 		dutyEpoch := duty.Slot / slotsPerEpoch
 
-		return dutyEpoch <= currentEpoch+uint64(o.allowedFutureEpochs)
+		if dutyEpoch > currentEpoch+uint64(o.allowedFutureEpochs) {
+			gaterRejectedCounter.WithLabelValues(duty.Type.String(), "future").Inc()
+			return false
+		}
+
+		// Exit and builder registration duties aren't slot-bound, they don't expire.
+		if duty.Type == DutyExit || duty.Type == DutyBuilderRegistration {
+			return true
+		}
+
+		if dutyEpoch+uint64(o.allowedPastEpochs) < currentEpoch {
+			gaterRejectedCounter.WithLabelValues(duty.Type.String(), "replay").Inc()
+			return false
+		}
+
+		return true
 	}, nil
 }
@@ -53,3 +53,37 @@ func TestDutyGater(t *testing.T) {
 	require.False(t, gater(core.Duty{Slot: 2, Type: 100}))
 	require.False(t, gater(core.Duty{Slot: 3, Type: 1000}))
 }
+
+func TestDutyGaterReplayWindow(t *testing.T) {
+	genesis := time.Now()
+	slotDuration := time.Second
+
+	bmock, err := beaconmock.New(
+		beaconmock.WithGenesisTime(genesis),
+		beaconmock.WithSlotDuration(slotDuration),
+		beaconmock.WithSlotsPerEpoch(2),
+	)
+	require.NoError(t, err)
+
+	// Current epoch 5, slots 10-11.
+	now := genesis.Add(10 * slotDuration)
+
+	gater, err := core.NewDutyGater(context.Background(), bmock,
+		core.WithDutyGaterForT(t, func() time.Time { return now }, 2),
+		core.WithDutyGaterReplayWindow(1),
+	)
+	require.NoError(t, err)
+
+	// Allow slots 8-15, epochs N-1 to N+2.
+	require.True(t, gater(core.Duty{Slot: 8, Type: core.DutyAttester}))
+	require.True(t, gater(core.Duty{Slot: 10, Type: core.DutyAttester}))
+	require.True(t, gater(core.Duty{Slot: 15, Type: core.DutyAttester}))
+
+	// Disallow replays of slots before the window.
+	require.False(t, gater(core.Duty{Slot: 7, Type: core.DutyAttester}))
+	require.False(t, gater(core.Duty{Slot: 0, Type: core.DutyProposer}))
+
+	// Exits and registrations don't expire.
+	require.True(t, gater(core.Duty{Slot: 0, Type: core.DutyExit}))
+	require.True(t, gater(core.Duty{Slot: 0, Type: core.DutyBuilderRegistration}))
+}
@@ -0,0 +1,102 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// rootCacheSize is the maximum number of memoized hash tree roots,
+// sufficient for the proposals of a few slots.
+const rootCacheSize = 128
+
+var rootCache = newHashRootCache(rootCacheSize)
+
+// sszObject is an SSZ object that can be both marshalled and hashed.
+type sszObject interface {
+	ssz.Marshaler
+	ssz.HashRoot
+}
+
+// CachedHashTreeRoot returns the hash tree root of the object memoized by its SSZ encoding, since hashing large
+// objects like proposals takes multiple milliseconds and is repeated by multiple components.
+// Encoding and digesting the object is an order of magnitude cheaper than merkleizing it, and since the cache is
+// keyed by content, mutated objects are never served stale roots.
+func CachedHashTreeRoot(obj interface {
+	ssz.Marshaler
+	ssz.HashRoot
+},
+) ([32]byte, error) {
+	return rootCache.HashTreeRoot(obj)
+}
+
+// newHashRootCache returns a new hash tree root cache evicting the oldest roots when exceeding size.
+func newHashRootCache(size int) *hashRootCache {
+	return &hashRootCache{
+		size:  size,
+		roots: make(map[[32]byte][32]byte),
+	}
+}
+
+// hashRootCache memoizes hash tree roots by digest of the object's type and SSZ encoding.
+type hashRootCache struct {
+	mu    sync.Mutex
+	size  int
+	roots map[[32]byte][32]byte
+	order [][32]byte // Insertion order for eviction.
+}
+
+// HashTreeRoot returns the memoized hash tree root of the object, hashing and memoizing it if not present.
+func (c *hashRootCache) HashTreeRoot(obj sszObject) ([32]byte, error) {
+	if obj == nil {
+		return [32]byte{}, errors.New("nil hash root object")
+	}
+
+	b, err := obj.MarshalSSZ()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "marshal ssz")
+	}
+
+	// Include the type since different types can have identical encodings but different roots.
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%T:", obj)
+	_, _ = h.Write(b)
+
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+
+	c.mu.Lock()
+	root, ok := c.roots[key]
+	c.mu.Unlock()
+
+	if ok {
+		return root, nil
+	}
+
+	// Hash outside the lock, concurrently hashing the same object is benign.
+	root, err = obj.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "hash tree root")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.roots[key]; !ok {
+		c.roots[key] = root
+		c.order = append(c.order, key)
+	}
+
+	for len(c.order) > c.size {
+		delete(c.roots, c.order[0])
+		c.order = c.order[1:]
+	}
+
+	return root, nil
+}
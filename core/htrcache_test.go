@@ -0,0 +1,60 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestCachedHashTreeRoot(t *testing.T) {
+	data := testutil.RandomAttestationDataPhase0()
+	expect, err := data.HashTreeRoot()
+	require.NoError(t, err)
+
+	root, err := core.CachedHashTreeRoot(data)
+	require.NoError(t, err)
+	require.Equal(t, expect, root)
+
+	// Roots are memoized by content, so mutations after hashing are reflected.
+	data.Slot++
+	expect, err = data.HashTreeRoot()
+	require.NoError(t, err)
+
+	root, err = core.CachedHashTreeRoot(data)
+	require.NoError(t, err)
+	require.Equal(t, expect, root)
+
+	// Copies share the memoized root.
+	clone := *data
+	root, err = core.CachedHashTreeRoot(&clone)
+	require.NoError(t, err)
+	require.Equal(t, expect, root)
+}
+
+// BenchmarkMessageRoot benchmarks repeatedly hashing a proposal, e.g. by parsigdb, tracker and signature verification.
+func BenchmarkMessageRoot(b *testing.B) {
+	proposal := testutil.RandomDenebCoreVersionedSignedProposal()
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, err := proposal.Deneb.SignedBlock.Message.HashTreeRoot()
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			_, err := proposal.MessageRoot()
+			require.NoError(b, err)
+		}
+	})
+}
@@ -6,7 +6,9 @@ package infosync
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/protocol"
 
@@ -23,15 +25,36 @@ const (
 	topicProtocol = "protocol"
 	topicProposal = "proposal"
 
+	topicAggregators = "aggregators"
+
+	// aggLatencyStep is the beacon node latency step demoting a peer by one position in its aggregators proposal.
+	aggLatencyStep = 50 * time.Millisecond
+
 	// maxResults limits the number of results to keep.
 	maxResults = 100
 
 	TopicProtocol = topicProtocol
 )
 
+// Option configures the infosync component.
+type Option func(*Component)
+
+// WithAggregatorSelection enables cluster wide selection of the k best-connected nodes
+// fetching aggregate attestations for each aggregation duty, ordered by recent beacon node latency.
+func WithAggregatorSelection(peerIdx, numPeers, k int, bnLatency func(context.Context) (time.Duration, error)) Option {
+	return func(c *Component) {
+		c.aggSelection = &aggSelection{
+			peerIdx:   peerIdx,
+			numPeers:  numPeers,
+			k:         k,
+			bnLatency: bnLatency,
+		}
+	}
+}
+
 // New returns a new infosync component.
 func New(prioritiser *priority.Component, versions []version.SemVer, protocols []protocol.ID,
-	proposals []core.ProposalType,
+	proposals []core.ProposalType, opts ...Option,
 ) *Component {
 	// Add a mock alpha protocol if alpha features enabled in order to test infosync in prod.
 	// TODO(corver): Remove this once we have an actual use case.
@@ -46,6 +69,10 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 		proposals:   proposals,
 	}
 
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
 		res := result{slot: duty.Slot}
 
@@ -61,6 +88,14 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 					res.protocols = append(res.protocols, protocol.ID(prio))
 				case topicProposal:
 					res.proposals = append(res.proposals, core.ProposalType(prio))
+				case topicAggregators:
+					peerIdx, err := strconv.Atoi(prio)
+					if err != nil {
+						log.Warn(ctx, "Ignoring invalid aggregator peer index", err, z.Str("priority", prio))
+						continue
+					}
+
+					res.aggregators = append(res.aggregators, peerIdx)
 				}
 			}
 		}
@@ -78,10 +113,11 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 }
 
 type Component struct {
-	prioritiser *priority.Component
-	versions    []version.SemVer
-	protocols   []protocol.ID
-	proposals   []core.ProposalType
+	prioritiser  *priority.Component
+	versions     []version.SemVer
+	protocols    []protocol.ID
+	proposals    []core.ProposalType
+	aggSelection *aggSelection
 
 	mu      sync.Mutex
 	results []result
@@ -125,6 +161,43 @@ func (c *Component) Proposals(slot uint64) []core.ProposalType {
 	return resp
 }
 
+// Aggregator returns true if this node should fetch aggregate attestations for the slot, since it is one of the
+// k best-connected nodes agreed upon cluster wide. It returns true if aggregator selection isn't enabled or
+// no results before the slot are available.
+func (c *Component) Aggregator(slot uint64) bool {
+	if c.aggSelection == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var aggregators []int
+	for _, result := range c.results {
+		if result.slot > slot {
+			break
+		}
+
+		aggregators = result.aggregators
+	}
+
+	if len(aggregators) == 0 {
+		return true
+	}
+
+	for i, peerIdx := range aggregators {
+		if i >= c.aggSelection.k {
+			break
+		}
+
+		if peerIdx == c.aggSelection.peerIdx {
+			return true
+		}
+	}
+
+	return false
+}
+
 // addResult adds the result to the results if it is different from the last result.
 func (c *Component) addResult(result result) {
 	c.mu.Lock()
@@ -144,19 +217,29 @@ func (c *Component) addResult(result result) {
 }
 
 func (c *Component) Trigger(ctx context.Context, slot uint64) error {
-	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot),
-		priority.TopicProposal{
+	topics := []priority.TopicProposal{
+		{
 			Topic:      topicVersion,
 			Priorities: versionsToStrings(c.versions),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProtocol,
 			Priorities: protocolsToStrings(c.protocols),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProposal,
 			Priorities: proposalsToStrings(c.proposals),
+		},
+	}
+
+	if c.aggSelection != nil {
+		topics = append(topics, priority.TopicProposal{
+			Topic:      topicAggregators,
+			Priorities: c.aggSelection.proposal(ctx),
 		})
+	}
+
+	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot), topics...)
 }
 
 // versionsToStrings returns the versions as strings.
@@ -189,12 +272,42 @@ func proposalsToStrings(proposals []core.ProposalType) []string {
 	return resp
 }
 
+// aggSelection configures the selection of the best-connected nodes fetching aggregate attestations.
+type aggSelection struct {
+	peerIdx   int
+	numPeers  int
+	k         int
+	bnLatency func(context.Context) (time.Duration, error)
+}
+
+// proposal returns this node's proposed aggregators priorities; all peer indices starting after this node,
+// with this node inserted at a position proportional to its recent beacon node latency, or last if unhealthy.
+func (s *aggSelection) proposal(ctx context.Context) []string {
+	var others []string
+	for i := 1; i < s.numPeers; i++ {
+		others = append(others, strconv.Itoa((s.peerIdx+i)%s.numPeers))
+	}
+
+	pos := len(others)
+	if latency, err := s.bnLatency(ctx); err != nil {
+		log.Warn(ctx, "Beacon node unhealthy, deprioritising node as aggregator", err)
+	} else {
+		pos = min(int(latency/aggLatencyStep), len(others))
+	}
+
+	resp := append([]string{}, others[:pos]...)
+	resp = append(resp, strconv.Itoa(s.peerIdx))
+
+	return append(resp, others[pos:]...)
+}
+
 // result is a cluster-wide agreed-upon infosync result.
 type result struct {
-	slot      uint64
-	versions  []string
-	protocols []protocol.ID
-	proposals []core.ProposalType
+	slot        uint64
+	versions    []string
+	protocols   []protocol.ID
+	proposals   []core.ProposalType
+	aggregators []int
 }
 
 // Equal returns true if the results are equal.
@@ -202,5 +315,6 @@ func (x result) Equal(y result) bool {
 	return x.slot == y.slot &&
 		fmt.Sprint(x.versions) == fmt.Sprint(y.versions) &&
 		fmt.Sprint(x.protocols) == fmt.Sprint(y.protocols) &&
-		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals)
+		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals) &&
+		fmt.Sprint(x.aggregators) == fmt.Sprint(y.aggregators)
 }
@@ -0,0 +1,74 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package infosync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+func TestAggSelectionProposal(t *testing.T) {
+	tests := []struct {
+		name     string
+		latency  time.Duration
+		err      error
+		expected []string
+	}{
+		{
+			name:     "fast",
+			latency:  10 * time.Millisecond,
+			expected: []string{"1", "2", "3", "0"},
+		},
+		{
+			name:     "slower",
+			latency:  120 * time.Millisecond,
+			expected: []string{"2", "3", "1", "0"},
+		},
+		{
+			name:     "slowest",
+			latency:  time.Second,
+			expected: []string{"2", "3", "0", "1"},
+		},
+		{
+			name:     "unhealthy",
+			err:      errors.New("syncing"),
+			expected: []string{"2", "3", "0", "1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := aggSelection{
+				peerIdx:  1,
+				numPeers: 4,
+				k:        2,
+				bnLatency: func(context.Context) (time.Duration, error) {
+					return test.latency, test.err
+				},
+			}
+
+			require.Equal(t, test.expected, selection.proposal(t.Context()))
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	c := &Component{}
+	require.True(t, c.Aggregator(1), "selection disabled")
+
+	WithAggregatorSelection(1, 4, 2, nil)(c)
+	require.True(t, c.Aggregator(1), "no results")
+
+	c.addResult(result{slot: 10, aggregators: []int{0, 1, 2, 3}})
+	c.addResult(result{slot: 20, aggregators: []int{2, 3, 0, 1}})
+
+	require.True(t, c.Aggregator(5), "no results before slot")
+	require.True(t, c.Aggregator(15))
+	require.False(t, c.Aggregator(20))
+	require.False(t, c.Aggregator(25))
+}
@@ -341,7 +341,13 @@ func Wire(sched Scheduler,
 	w.FetcherSubscribe(w.ConsensusPropose)
 	w.FetcherRegisterAggSigDB(w.AggSigDBAwait)
 	w.FetcherRegisterAwaitAttData(w.DutyDBAwaitAttestation)
-	w.ConsensusSubscribe(w.DutyDBStore)
+	w.ConsensusSubscribe(func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+		if duty.Type == DutyRehearsal {
+			return nil // Rehearsal proposals are discarded, never served to validator clients.
+		}
+
+		return w.DutyDBStore(ctx, duty, set)
+	})
 	w.VAPIRegisterAwaitProposal(w.DutyDBAwaitProposal)
 	w.VAPIRegisterAwaitAttestation(w.DutyDBAwaitAttestation)
 	w.VAPIRegisterAwaitSyncContribution(w.DutyDBAwaitSyncContribution)
@@ -0,0 +1,302 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// LatencyPhase is a phase of the core workflow with a latency budget.
+type LatencyPhase string
+
+const (
+	// PhaseFetch is from the scheduler triggering the duty until the duty data is fetched from the beacon node.
+	PhaseFetch LatencyPhase = "fetch"
+	// PhaseConsensus is from proposing the fetched duty data until consensus is reached.
+	PhaseConsensus LatencyPhase = "consensus"
+	// PhaseParSigWait is from the first partial signature submitted by the local validator client
+	// until threshold partial signatures are aggregated.
+	PhaseParSigWait LatencyPhase = "parsig_wait"
+	// PhaseBroadcast is from aggregating threshold partial signatures until broadcast to the beacon node.
+	PhaseBroadcast LatencyPhase = "broadcast"
+)
+
+// latencyPhases are all latency phases in the order they occur in the core workflow.
+var latencyPhases = []LatencyPhase{PhaseFetch, PhaseConsensus, PhaseParSigWait, PhaseBroadcast}
+
+// DefaultLatencyBudgets returns the default latency budgets per phase as fractions of the slot duration,
+// e.g. 1s fetch, 2s consensus, 2s parsig wait and 1s broadcast for 12s slots.
+func DefaultLatencyBudgets(slotDuration time.Duration) map[LatencyPhase]time.Duration {
+	return map[LatencyPhase]time.Duration{
+		PhaseFetch:      slotDuration / 12,
+		PhaseConsensus:  slotDuration / 6,
+		PhaseParSigWait: slotDuration / 6,
+		PhaseBroadcast:  slotDuration / 12,
+	}
+}
+
+// latencyMarks are the times a duty reached each step of the core workflow.
+type latencyMarks struct {
+	FetchStart     time.Time // First fetch triggered.
+	FetchEnd       time.Time // First consensus proposed.
+	ConsensusEnd   time.Time // First consensus decided and stored in DutyDB.
+	ParSigStart    time.Time // First partial signature stored from the local validator client.
+	AggregateStart time.Time // Last threshold partial signatures aggregated.
+	BroadcastEnd   time.Time // Last successful broadcast.
+}
+
+// durations returns the observed duration of each latency phase.
+func (m latencyMarks) durations() map[LatencyPhase]time.Duration {
+	resp := make(map[LatencyPhase]time.Duration)
+
+	add := func(phase LatencyPhase, start, end time.Time) {
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			return
+		}
+
+		resp[phase] = end.Sub(start)
+	}
+
+	add(PhaseFetch, m.FetchStart, m.FetchEnd)
+	add(PhaseConsensus, m.FetchEnd, m.ConsensusEnd)
+	add(PhaseParSigWait, m.ParSigStart, m.AggregateStart)
+	add(PhaseBroadcast, m.AggregateStart, m.BroadcastEnd)
+
+	return resp
+}
+
+// LatencyReport is the SLA report of a duty indicating whether each phase of the core workflow met its latency budget.
+type LatencyReport struct {
+	Duty      Duty
+	Durations map[LatencyPhase]time.Duration
+	Violated  []LatencyPhase
+}
+
+// Met returns true if all observed phases met their latency budgets.
+func (r LatencyReport) Met() bool {
+	return len(r.Violated) == 0
+}
+
+// NewLatencyBudget returns a new latency budget tracker that reports per duty SLA reports
+// for broadcast duties when expired by the deadliner.
+func NewLatencyBudget(ctx context.Context, deadliner Deadliner, budgets map[LatencyPhase]time.Duration) *LatencyBudget {
+	l := &LatencyBudget{
+		deadliner: deadliner,
+		budgets:   budgets,
+		nowFunc:   time.Now,
+		marks:     make(map[Duty]*latencyMarks),
+	}
+
+	ctx = log.WithTopic(ctx, "latency")
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case duty := <-deadliner.C():
+				if report, ok := l.expire(duty); ok {
+					reportLatency(ctx, report, budgets)
+
+					for _, sub := range l.getSubs() {
+						sub(ctx, report)
+					}
+				}
+			}
+		}
+	}()
+
+	return l
+}
+
+// LatencyBudget tracks the time duties spend in each phase of the core workflow against target latency budgets.
+type LatencyBudget struct {
+	deadliner Deadliner
+	budgets   map[LatencyPhase]time.Duration
+	nowFunc   func() time.Time
+
+	mu    sync.Mutex
+	marks map[Duty]*latencyMarks
+	subs  []func(context.Context, LatencyReport)
+}
+
+// Subscribe registers a callback for each duty SLA report.
+// Callbacks are invoked synchronously and should not block.
+func (l *LatencyBudget) Subscribe(fn func(context.Context, LatencyReport)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.subs = append(l.subs, fn)
+}
+
+// getSubs returns the registered subscribers.
+func (l *LatencyBudget) getSubs() []func(context.Context, LatencyReport) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]func(context.Context, LatencyReport){}, l.subs...)
+}
+
+// mark updates the marks of the duty at the current time.
+func (l *LatencyBudget) mark(duty Duty, fn func(now time.Time, marks *latencyMarks)) {
+	now := l.nowFunc()
+
+	l.mu.Lock()
+	_, ok := l.marks[duty]
+	l.mu.Unlock()
+
+	if !ok && !l.deadliner.Add(duty) {
+		return // Ignore expired or never expiring duties.
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	marks, ok := l.marks[duty]
+	if !ok {
+		marks = new(latencyMarks)
+		l.marks[duty] = marks
+	}
+
+	fn(now, marks)
+}
+
+// expire deletes the duty and returns its report if it was broadcast.
+func (l *LatencyBudget) expire(duty Duty) (LatencyReport, bool) {
+	l.mu.Lock()
+	marks, ok := l.marks[duty]
+	delete(l.marks, duty)
+	l.mu.Unlock()
+
+	if !ok || marks.BroadcastEnd.IsZero() {
+		return LatencyReport{}, false
+	}
+
+	return newLatencyReport(duty, *marks, l.budgets), true
+}
+
+// newLatencyReport returns the SLA report of the duty's latency marks against the budgets.
+func newLatencyReport(duty Duty, marks latencyMarks, budgets map[LatencyPhase]time.Duration) LatencyReport {
+	report := LatencyReport{
+		Duty:      duty,
+		Durations: marks.durations(),
+	}
+
+	for _, phase := range latencyPhases {
+		duration, ok := report.Durations[phase]
+		if !ok {
+			continue
+		}
+
+		if budget, ok := budgets[phase]; ok && duration > budget {
+			report.Violated = append(report.Violated, phase)
+		}
+	}
+
+	return report
+}
+
+// reportLatency logs and instruments the SLA report.
+func reportLatency(ctx context.Context, report LatencyReport, budgets map[LatencyPhase]time.Duration) {
+	fields := []z.Field{z.Any("duty", report.Duty)}
+
+	for _, phase := range latencyPhases {
+		duration, ok := report.Durations[phase]
+		if !ok {
+			continue
+		}
+
+		latencyPhaseHistogram.WithLabelValues(report.Duty.Type.String(), string(phase)).Observe(duration.Seconds())
+		fields = append(fields, z.Str(string(phase), duration.String()))
+	}
+
+	if report.Met() {
+		latencyReportCounter.WithLabelValues(report.Duty.Type.String(), "met").Inc()
+		log.Debug(ctx, "Duty latency budget met", fields...)
+
+		return
+	}
+
+	latencyReportCounter.WithLabelValues(report.Duty.Type.String(), "violated").Inc()
+
+	var violated []string
+	for _, phase := range report.Violated {
+		latencyViolationCounter.WithLabelValues(report.Duty.Type.String(), string(phase)).Inc()
+		violated = append(violated, string(phase))
+		fields = append(fields, z.Str(string(phase)+"_budget", budgets[phase].String()))
+	}
+
+	fields = append(fields, z.Any("violated", violated))
+
+	log.Info(ctx, "Duty latency budget violated", fields...)
+}
+
+// WithLatencyBudget wraps component input functions to record the time duties spend in each phase
+// of the core workflow, reporting SLA reports against the latency budgets.
+func WithLatencyBudget(budget *LatencyBudget) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.FetcherFetch = func(ctx context.Context, duty Duty, set DutyDefinitionSet) error {
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				if m.FetchStart.IsZero() {
+					m.FetchStart = now
+				}
+			})
+
+			return clone.FetcherFetch(ctx, duty, set)
+		}
+		w.ConsensusPropose = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			// Note that fetched data is proposed synchronously, so this marks the end of fetching.
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				if m.FetchEnd.IsZero() {
+					m.FetchEnd = now
+				}
+			})
+
+			return clone.ConsensusPropose(ctx, duty, set)
+		}
+		w.DutyDBStore = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				if m.ConsensusEnd.IsZero() {
+					m.ConsensusEnd = now
+				}
+			})
+
+			return clone.DutyDBStore(ctx, duty, set)
+		}
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				if m.ParSigStart.IsZero() {
+					m.ParSigStart = now
+				}
+			})
+
+			return clone.ParSigDBStoreInternal(ctx, duty, set)
+		}
+		w.SigAggAggregate = func(ctx context.Context, duty Duty, set map[PubKey][]ParSignedData) error {
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				m.AggregateStart = now
+			})
+
+			return clone.SigAggAggregate(ctx, duty, set)
+		}
+		w.BroadcasterBroadcast = func(ctx context.Context, duty Duty, set SignedDataSet) error {
+			err := clone.BroadcasterBroadcast(ctx, duty, set)
+			if err != nil {
+				return err
+			}
+
+			budget.mark(duty, func(now time.Time, m *latencyMarks) {
+				m.BroadcastEnd = now
+			})
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultLatencyBudgets(t *testing.T) {
+	require.Equal(t, map[LatencyPhase]time.Duration{
+		PhaseFetch:      time.Second,
+		PhaseConsensus:  2 * time.Second,
+		PhaseParSigWait: 2 * time.Second,
+		PhaseBroadcast:  time.Second,
+	}, DefaultLatencyBudgets(12*time.Second))
+}
+
+func TestWithLatencyBudget(t *testing.T) {
+	duty := NewAttesterDuty(1)
+	budgets := DefaultLatencyBudgets(12 * time.Second)
+
+	budget := NewLatencyBudget(t.Context(), newTestDeadliner(), budgets)
+
+	now := time.Unix(0, 0)
+	budget.nowFunc = func() time.Time { return now }
+
+	noop := func(context.Context, Duty, UnsignedDataSet) error { return nil }
+	w := wireFuncs{
+		FetcherFetch:          func(context.Context, Duty, DutyDefinitionSet) error { return nil },
+		ConsensusPropose:      noop,
+		DutyDBStore:           noop,
+		ParSigDBStoreInternal: func(context.Context, Duty, ParSignedDataSet) error { return nil },
+		SigAggAggregate:       func(context.Context, Duty, map[PubKey][]ParSignedData) error { return nil },
+		BroadcasterBroadcast:  func(context.Context, Duty, SignedDataSet) error { return nil },
+	}
+	WithLatencyBudget(budget)(&w)
+
+	ctx := t.Context()
+	step := func(d time.Duration) { now = now.Add(d) }
+
+	require.NoError(t, w.FetcherFetch(ctx, duty, nil))
+	step(500 * time.Millisecond)
+	require.NoError(t, w.ConsensusPropose(ctx, duty, nil))
+	step(3 * time.Second) // Slow consensus
+	require.NoError(t, w.DutyDBStore(ctx, duty, nil))
+	step(time.Second)
+	require.NoError(t, w.ParSigDBStoreInternal(ctx, duty, nil))
+	step(time.Second)
+	require.NoError(t, w.ParSigDBStoreInternal(ctx, duty, nil))
+	step(500 * time.Millisecond)
+	require.NoError(t, w.SigAggAggregate(ctx, duty, nil))
+	step(200 * time.Millisecond)
+	require.NoError(t, w.BroadcasterBroadcast(ctx, duty, nil))
+
+	report, ok := budget.expire(duty)
+	require.True(t, ok)
+	require.False(t, report.Met())
+	require.Equal(t, []LatencyPhase{PhaseConsensus}, report.Violated)
+	require.Equal(t, map[LatencyPhase]time.Duration{
+		PhaseFetch:      500 * time.Millisecond,
+		PhaseConsensus:  3 * time.Second,
+		PhaseParSigWait: 1500 * time.Millisecond,
+		PhaseBroadcast:  200 * time.Millisecond,
+	}, report.Durations)
+
+	// Expired duties are deleted.
+	_, ok = budget.expire(duty)
+	require.False(t, ok)
+
+	// Duties not broadcast aren't reported.
+	require.NoError(t, w.FetcherFetch(ctx, NewAttesterDuty(2), nil))
+
+	_, ok = budget.expire(NewAttesterDuty(2))
+	require.False(t, ok)
+
+	// Duties without consensus only report observed phases.
+	exit := NewVoluntaryExit(1)
+	require.NoError(t, w.ParSigDBStoreInternal(ctx, exit, nil))
+	step(time.Second)
+	require.NoError(t, w.SigAggAggregate(ctx, exit, nil))
+	step(100 * time.Millisecond)
+	require.NoError(t, w.BroadcasterBroadcast(ctx, exit, nil))
+
+	report, ok = budget.expire(exit)
+	require.True(t, ok)
+	require.True(t, report.Met())
+	require.Equal(t, map[LatencyPhase]time.Duration{
+		PhaseParSigWait: time.Second,
+		PhaseBroadcast:  100 * time.Millisecond,
+	}, report.Durations)
+}
+
+func TestLatencyBudgetSubscribe(t *testing.T) {
+	deadliner := newTestDeadliner()
+	budget := NewLatencyBudget(t.Context(), deadliner, DefaultLatencyBudgets(12*time.Second))
+
+	reports := make(chan LatencyReport, 1)
+	budget.Subscribe(func(_ context.Context, report LatencyReport) {
+		reports <- report
+	})
+
+	duty := NewAttesterDuty(1)
+	budget.mark(duty, func(now time.Time, marks *latencyMarks) {
+		marks.AggregateStart = now.Add(-2 * time.Second)
+		marks.BroadcastEnd = now
+	})
+
+	deadliner.ch <- duty
+
+	report := <-reports
+	require.Equal(t, duty, report.Duty)
+	require.Equal(t, []LatencyPhase{PhaseBroadcast}, report.Violated)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	latencyPhaseHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "latency",
+		Name:      "phase_duration_seconds",
+		Help:      "Duration in seconds of each core workflow phase (fetch, consensus, parsig_wait, broadcast) of broadcast duties by type",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 1.5, 2, 3, 4, 6, 8, 12},
+	}, []string{"duty", "phase"})
+
+	latencyReportCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "latency",
+		Name:      "budget_reports_total",
+		Help:      "Total number of duty latency budget SLA reports by type and result (met or violated)",
+	}, []string{"duty", "result"})
+
+	latencyViolationCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "latency",
+		Name:      "budget_violations_total",
+		Help:      "Total number of duty latency budget violations by type and phase",
+	}, []string{"duty", "phase"})
+
+	batchVerifyCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "verify",
+		Name:      "batch_total",
+		Help:      "Total number of batched signature verifications by result (ok or fallback). Fallback indicates the batch contained an invalid signature and was verified individually",
+	}, []string{"result"})
+
+	gaterRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "duty_gater",
+		Name:      "rejected_total",
+		Help:      "Total number of duties received from peers rejected by the duty gater by type and reason (invalid_type, future or replay). Replays are duties older than the replay protection window",
+	}, []string{"duty", "reason"})
+
+	queueDepthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "queue",
+		Name:      "depth",
+		Help:      "Number of duty data sets waiting or being processed by the inter-component queue",
+	}, []string{"queue"})
+
+	queueHighWaterGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "queue",
+		Name:      "depth_high_water",
+		Help:      "Maximum depth of the inter-component queue since startup",
+	}, []string{"queue"})
+
+	queueLagHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "queue",
+		Name:      "lag_seconds",
+		Help:      "Duration in seconds duty data sets waited in the inter-component queue before being processed",
+		Buckets:   []float64{.001, .01, .05, .1, .25, .5, 1, 2, 4},
+	}, []string{"queue"})
+
+	queueShedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "queue",
+		Name:      "shed_total",
+		Help:      "Total number of stale duty data sets shed by the overloaded inter-component queue by duty type",
+	}, []string{"queue", "duty"})
+)
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
@@ -14,14 +15,37 @@ import (
 	"github.com/obolnetwork/charon/core"
 )
 
+// errMismatch is returned when partial signed data of a share index mismatches previously stored data.
+var errMismatch = errors.NewSentinel("mismatching partial signed data")
+
+// Option configures the partial signature database.
+type Option func(*MemDB)
+
+// WithOvercollection returns an option delaying the threshold subscribers of each validator's duty until target
+// matching partial signed data is received, or until the wait duration elapsed since threshold was reached,
+// whichever comes first. Targets not above threshold disable overcollection.
+func WithOvercollection(target int, wait time.Duration) Option {
+	return func(db *MemDB) {
+		db.overcollectTarget = target
+		db.overcollectWait = wait
+	}
+}
+
 // NewMemDB returns a new in-memory partial signature database instance.
-func NewMemDB(threshold int, deadliner core.Deadliner) *MemDB {
-	return &MemDB{
-		entries:    make(map[key][]core.ParSignedData),
-		keysByDuty: make(map[core.Duty][]key),
-		threshold:  threshold,
-		deadliner:  deadliner,
+func NewMemDB(threshold int, deadliner core.Deadliner, opts ...Option) *MemDB {
+	db := &MemDB{
+		entries:        make(map[key][]core.ParSignedData),
+		keysByDuty:     make(map[core.Duty][]key),
+		overcollecting: make(map[key]*time.Timer),
+		threshold:      threshold,
+		deadliner:      deadliner,
 	}
+
+	for _, opt := range opts {
+		opt(db)
+	}
+
+	return db
 }
 
 // MemDB is a placeholder in-memory partial signature database.
@@ -35,6 +59,10 @@ type MemDB struct {
 	keysByDuty map[core.Duty][]key
 	threshold  int
 	deadliner  core.Deadliner
+
+	overcollectTarget int
+	overcollectWait   time.Duration
+	overcollecting    map[key]*time.Timer // Nil timers once the threshold subscribers were called.
 }
 
 // SubscribeInternal registers a callback when an internal
@@ -59,7 +87,7 @@ func (db *MemDB) SubscribeThreshold(fn func(context.Context, core.Duty, map[core
 func (db *MemDB) StoreInternal(ctx context.Context, duty core.Duty, signedSet core.ParSignedDataSet) error {
 	ctx = log.WithCtx(ctx, z.Any("duty", duty))
 
-	if err := db.StoreExternal(ctx, duty, signedSet); err != nil {
+	if err := db.storeSet(ctx, duty, signedSet, true); err != nil {
 		return err
 	}
 
@@ -80,13 +108,22 @@ func (db *MemDB) StoreInternal(ctx context.Context, duty core.Duty, signedSet co
 
 // StoreExternal stores an externally received partially signed duty data set.
 func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet core.ParSignedDataSet) error {
+	return db.storeSet(ctx, duty, signedSet, false)
+}
+
+// storeSet stores the partially signed duty data set and calls the threshold subscribers
+// for validators that reached threshold matching partial signed data.
+// Internal data sets mismatching previously stored data are conflicting validator client submissions.
+func (db *MemDB) storeSet(ctx context.Context, duty core.Duty, signedSet core.ParSignedDataSet, internal bool) error {
 	_ = db.deadliner.Add(duty) // TODO(corver): Distinguish between no deadline supported vs already expired.
 
 	output := make(map[core.PubKey][]core.ParSignedData)
 
 	for pubkey, sig := range signedSet {
 		sigs, ok, err := db.store(key{Duty: duty, PubKey: pubkey}, sig)
-		if err != nil {
+		if internal && errors.Is(err, errMismatch) {
+			return conflictingSubmission(ctx, duty, pubkey)
+		} else if err != nil {
 			return err
 		} else if !ok {
 			log.Debug(ctx, "Partial signed data ignored since duplicate")
@@ -95,13 +132,14 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 		}
 
 		// Check if sufficient matching partial signed data has been received.
-		psigs, ok, err := getThresholdMatching(duty.Type, sigs, db.threshold)
+		psigs, ok, err := db.thresholdMatching(ctx, key{Duty: duty, PubKey: pubkey}, sigs)
 		if err != nil {
 			return err
 		} else if !ok {
 			continue
 		}
 
+		thresholdPartialsHistogram.WithLabelValues(duty.Type.String()).Observe(float64(len(psigs)))
 		output[pubkey] = psigs
 	}
 
@@ -120,6 +158,88 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 	return nil
 }
 
+// thresholdMatching returns true and the matching partial signed data to call the threshold subscribers with, or false.
+// With overcollection, it returns true once target matching partial signed data is received,
+// and calls the threshold subscribers after the overcollection wait once threshold is reached.
+func (db *MemDB) thresholdMatching(ctx context.Context, k key, sigs []core.ParSignedData) ([]core.ParSignedData, bool, error) {
+	if db.overcollectTarget <= db.threshold {
+		return getThresholdMatching(k.Duty.Type, sigs, db.threshold)
+	}
+
+	psigs, ok, err := getThresholdMatching(k.Duty.Type, sigs, db.overcollectTarget)
+	if err != nil {
+		return nil, false, err
+	} else if ok {
+		return psigs, db.claimOvercollection(k), nil
+	}
+
+	_, ok, err = getThresholdMatching(k.Duty.Type, sigs, db.threshold)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.overcollecting[k]; !ok {
+		ctx = context.WithoutCancel(ctx)
+		db.overcollecting[k] = time.AfterFunc(db.overcollectWait, func() {
+			db.overcollectionTimeout(ctx, k)
+		})
+	}
+
+	return nil, false, nil
+}
+
+// claimOvercollection returns true if the threshold subscribers of the key were not called yet,
+// stopping the overcollection wait. Only the first call per key returns true.
+func (db *MemDB) claimOvercollection(k key) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	timer, ok := db.overcollecting[k]
+	if ok && timer == nil {
+		return false
+	} else if ok {
+		timer.Stop()
+	}
+
+	db.overcollecting[k] = nil
+
+	return true
+}
+
+// overcollectionTimeout calls the threshold subscribers with all matching partial signed data received
+// when the overcollection wait elapsed before target was reached.
+func (db *MemDB) overcollectionTimeout(ctx context.Context, k key) {
+	if !db.claimOvercollection(k) {
+		return
+	}
+
+	db.mu.Lock()
+	sigs := append([]core.ParSignedData(nil), db.entries[k]...)
+	db.mu.Unlock()
+
+	psigs, ok, err := getMatching(k.Duty.Type, sigs, db.threshold)
+	if err != nil {
+		log.Warn(ctx, "Overcollection matching partial signed data", err)
+		return
+	} else if !ok {
+		return // Duty expired.
+	}
+
+	overcollectTimeoutCounter.WithLabelValues(k.Duty.Type.String()).Inc()
+	thresholdPartialsHistogram.WithLabelValues(k.Duty.Type.String()).Observe(float64(len(psigs)))
+
+	output := map[core.PubKey][]core.ParSignedData{k.PubKey: psigs}
+	for _, sub := range db.threshSubs {
+		if err := sub(ctx, k.Duty, clone(output)); err != nil {
+			log.Warn(ctx, "Threshold subscriber failed after overcollection wait", err)
+			return
+		}
+	}
+}
+
 // Trim blocks until the context is closed, it deletes state for expired duties.
 // It should only be called once.
 func (db *MemDB) Trim(ctx context.Context) {
@@ -132,6 +252,12 @@ func (db *MemDB) Trim(ctx context.Context) {
 
 			for _, key := range db.keysByDuty[duty] {
 				delete(db.entries, key)
+
+				if timer := db.overcollecting[key]; timer != nil {
+					timer.Stop()
+				}
+
+				delete(db.overcollecting, key)
 			}
 
 			delete(db.keysByDuty, duty)
@@ -152,7 +278,7 @@ func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, b
 			if err != nil {
 				return nil, false, err
 			} else if !equal {
-				return nil, false, errors.New("mismatching partial signed data",
+				return nil, false, errors.Wrap(errMismatch, "mismatching partial signed data",
 					z.Any("pubkey", k.PubKey), z.Int("share_idx", s.ShareIdx))
 			}
 
@@ -176,6 +302,18 @@ func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, b
 	return append([]core.ParSignedData(nil), db.entries[k]...), true, nil
 }
 
+// conflictingSubmission instruments and returns the error of a validator client submission conflicting with
+// a previous submission for the same duty and validator. This indicates multiple validator clients
+// with the same keys, a dangerous misconfiguration.
+func conflictingSubmission(ctx context.Context, duty core.Duty, pubkey core.PubKey) error {
+	conflictCounter.WithLabelValues(duty.Type.String()).Inc()
+
+	err := errors.Wrap(core.ErrConflictingSubmission, "mismatching partial signed data", z.Any("duty", duty), z.Any("pubkey", pubkey))
+	log.Error(ctx, "Rejected conflicting validator client submission, check for multiple validator clients with the same keys", err)
+
+	return err
+}
+
 // clone returns a deep copy of the provided map.
 func clone(output map[core.PubKey][]core.ParSignedData) map[core.PubKey][]core.ParSignedData {
 	clone := make(map[core.PubKey][]core.ParSignedData)
@@ -229,6 +367,38 @@ func getThresholdMatching(typ core.DutyType, sigs []core.ParSignedData, threshol
 	return nil, false, nil
 }
 
+// getMatching returns true and the largest set of at least min partial signed data with identical data or false.
+func getMatching(typ core.DutyType, sigs []core.ParSignedData, minimum int) ([]core.ParSignedData, bool, error) {
+	if len(sigs) < minimum {
+		return nil, false, nil
+	}
+
+	if typ == core.DutySignature {
+		// Signatures do not support message roots.
+		return sigs, true, nil
+	}
+
+	sigsByMsgRoot := make(map[[32]byte][]core.ParSignedData) // map[Root][]ParSignedData
+
+	for _, sig := range sigs {
+		root, err := sig.MessageRoot()
+		if err != nil {
+			return nil, false, err
+		}
+
+		sigsByMsgRoot[root] = append(sigsByMsgRoot[root], sig)
+	}
+
+	var largest []core.ParSignedData
+	for _, set := range sigsByMsgRoot {
+		if len(set) > len(largest) {
+			largest = set
+		}
+	}
+
+	return largest, len(largest) >= minimum, nil
+}
+
 func parSignedDataEqual(x, y core.ParSignedData) (bool, error) {
 	xjson, err := json.Marshal(x)
 	if err != nil {
@@ -5,9 +5,11 @@ package parsigdb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/cluster"
@@ -153,6 +155,38 @@ func TestMemDBThreshold(t *testing.T) {
 	require.Equal(t, 2, timesCalled)
 }
 
+func TestMemDBConflictingSubmission(t *testing.T) {
+	db := NewMemDB(2, newTestDeadliner())
+	duty := core.NewAttesterDuty(123)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	store := func(internal bool) error {
+		parAtt, err := core.NewPartialVersionedAttestation(testutil.RandomDenebVersionedAttestation(), 1)
+		require.NoError(t, err)
+
+		set := core.ParSignedDataSet{pubkey: parAtt}
+		if internal {
+			return db.StoreInternal(context.Background(), duty, set)
+		}
+
+		return db.StoreExternal(context.Background(), duty, set)
+	}
+
+	before := promtestutil.ToFloat64(conflictCounter.WithLabelValues(duty.Type.String()))
+
+	require.NoError(t, store(true))
+
+	// Conflicting validator client submissions are rejected.
+	err := store(true)
+	require.ErrorIs(t, err, core.ErrConflictingSubmission)
+	require.InDelta(t, before+1, promtestutil.ToFloat64(conflictCounter.WithLabelValues(duty.Type.String())), 0)
+
+	// Mismatching peer partial signatures are not conflicting submissions.
+	err = store(false)
+	require.ErrorIs(t, err, errMismatch)
+	require.NotErrorIs(t, err, core.ErrConflictingSubmission)
+}
+
 func newTestDeadliner() *testDeadliner {
 	return &testDeadliner{
 		ch: make(chan core.Duty),
@@ -184,3 +218,66 @@ func (t *testDeadliner) Add(duty core.Duty) bool {
 func (t *testDeadliner) C() <-chan core.Duty {
 	return t.ch
 }
+
+func TestMemDBOvercollection(t *testing.T) {
+	const (
+		th     = 2
+		target = 3
+	)
+
+	ctx := context.Background()
+	duty := core.NewAttesterDuty(123)
+	att := testutil.RandomDenebVersionedAttestation()
+
+	newDB := func(wait time.Duration) (*MemDB, chan int) {
+		db := NewMemDB(th, newTestDeadliner(), WithOvercollection(target, wait))
+
+		counts := make(chan int, 10)
+		db.SubscribeThreshold(func(_ context.Context, _ core.Duty, set map[core.PubKey][]core.ParSignedData) error {
+			for _, psigs := range set {
+				counts <- len(psigs)
+			}
+
+			return nil
+		})
+
+		return db, counts
+	}
+
+	store := func(db *MemDB, pubkey core.PubKey, shareIdx int) {
+		parAtt, err := core.NewPartialVersionedAttestation(att, shareIdx)
+		require.NoError(t, err)
+		require.NoError(t, db.StoreExternal(ctx, duty, core.ParSignedDataSet{pubkey: parAtt}))
+	}
+
+	t.Run("target reached", func(t *testing.T) {
+		db, counts := newDB(time.Hour)
+		pubkey := testutil.RandomCorePubKey(t)
+
+		store(db, pubkey, 1)
+		store(db, pubkey, 2)
+		require.Empty(t, counts)
+
+		store(db, pubkey, 3)
+		require.Equal(t, target, <-counts)
+
+		store(db, pubkey, 4)
+		require.Empty(t, counts)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		db, counts := newDB(time.Millisecond)
+		pubkey := testutil.RandomCorePubKey(t)
+
+		before := promtestutil.ToFloat64(overcollectTimeoutCounter.WithLabelValues(duty.Type.String()))
+
+		store(db, pubkey, 1)
+		store(db, pubkey, 2)
+		require.Equal(t, th, <-counts)
+		require.InDelta(t, before+1, promtestutil.ToFloat64(overcollectTimeoutCounter.WithLabelValues(duty.Type.String())), 0)
+
+		// Late partial signatures reaching target are ignored.
+		store(db, pubkey, 3)
+		require.Empty(t, counts)
+	})
+}
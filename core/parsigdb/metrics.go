@@ -8,9 +8,33 @@ import (
 	"github.com/obolnetwork/charon/app/promauto"
 )
 
-var exitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
-	Namespace: "core",
-	Subsystem: "parsigdb",
-	Name:      "exit_total",
-	Help:      "Total number of partially signed voluntary exits per public key",
-}, []string{"pubkey"}) // Ok to use pubkey (high cardinality) here since these are very rare
+var (
+	exitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "parsigdb",
+		Name:      "exit_total",
+		Help:      "Total number of partially signed voluntary exits per public key",
+	}, []string{"pubkey"}) // Ok to use pubkey (high cardinality) here since these are very rare
+
+	conflictCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "parsigdb",
+		Name:      "conflicting_submission_total",
+		Help:      "Total number of rejected validator client submissions conflicting with a previous submission of the same duty and validator, indicating multiple validator clients with the same keys",
+	}, []string{"duty"})
+
+	thresholdPartialsHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "parsigdb",
+		Name:      "threshold_partials",
+		Help:      "Number of matching partial signatures per validator passed to aggregation, exceeding threshold when overcollecting",
+		Buckets:   prometheus.LinearBuckets(1, 1, 12),
+	}, []string{"duty"})
+
+	overcollectTimeoutCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "parsigdb",
+		Name:      "overcollect_timeout_total",
+		Help:      "Total number of validator duties aggregated after the overcollection wait elapsed before the target number of partial signatures was received",
+	}, []string{"duty"})
+)
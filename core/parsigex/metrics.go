@@ -0,0 +1,33 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigex
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	receivedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "parsigex",
+		Name:      "received_total",
+		Help:      "Total number of received partial signature exchange messages by protocol version",
+	}, []string{"protocol"})
+
+	receiveLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "parsigex",
+		Name:      "receive_latency_seconds",
+		Help:      "Latency of received partial signature exchange messages since sent by the peer in seconds by duty type, including peer clock offset",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"duty"})
+
+	replayRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "parsigex",
+		Name:      "replay_rejected_total",
+		Help:      "Total number of received partial signature exchange messages rejected as duplicates of previously received messages by duty type",
+	}, []string{"duty"})
+)
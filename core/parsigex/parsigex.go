@@ -4,12 +4,16 @@ package parsigex
 
 import (
 	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -21,15 +25,25 @@ import (
 	"github.com/obolnetwork/charon/tbls"
 )
 
-const protocolID2 = "/charon/parsigex/2.0.0"
+const (
+	protocolID2 = "/charon/parsigex/2.0.0"
+	protocolID3 = "/charon/parsigex/3.0.0"
+
+	// replayRetention is the duration received messages are remembered for replay protection.
+	// It exceeds the duty gater window of past and future epochs, so slot-bound duties are rejected
+	// by the gater before being forgotten.
+	replayRetention = time.Hour
+)
 
 // Protocols returns the supported protocols of this package in order of precedence.
+// Messages are sent using the most recent protocol supported by each peer, so clusters
+// can be upgraded one node at a time.
 func Protocols() []protocol.ID {
-	return []protocol.ID{protocolID2}
+	return []protocol.ID{protocolID3, protocolID2}
 }
 
 func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []peer.ID,
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error,
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error,
 	gaterFunc core.DutyGaterFunc, p2pOpts ...p2p.SendRecvOption,
 ) *ParSigEx {
 	parSigEx := &ParSigEx{
@@ -39,18 +53,27 @@ func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []
 		peers:      peers,
 		verifyFunc: verifyFunc,
 		gaterFunc:  gaterFunc,
+		replays:    newReplayGuard(replayRetention, time.Now),
 	}
 
-	newReq := func() proto.Message { return new(pbv1.ParSigExMsg) }
 	p2p.RegisterHandler(
 		"parsigex",
 		tcpNode,
 		protocolID2,
-		newReq,
+		func() proto.Message { return new(pbv1.ParSigExMsg) },
 		parSigEx.handle,
 		p2pOpts...,
 	)
 
+	p2p.RegisterHandler(
+		"parsigex",
+		tcpNode,
+		protocolID3,
+		func() proto.Message { return new(pbv1.ParSigExMsgV3) },
+		parSigEx.handleV3,
+		p2pOpts...,
+	)
+
 	return parSigEx
 }
 
@@ -61,17 +84,49 @@ type ParSigEx struct {
 	sendFunc   p2p.SendFunc
 	peerIdx    int
 	peers      []peer.ID
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error
 	gaterFunc  core.DutyGaterFunc
+	replays    *replayGuard
 	subs       []func(context.Context, core.Duty, core.ParSignedDataSet) error
 }
 
-func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+// handleV3 handles a protocol version 3 message.
+// The sent at timestamp isn't signed, so it is only used for latency metrics, not replay protection.
+func (m *ParSigEx) handleV3(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
+	pb, ok := req.(*pbv1.ParSigExMsgV3)
+	if !ok {
+		return nil, false, errors.New("invalid request type")
+	}
+
+	receivedCounter.WithLabelValues(protocolID3).Inc()
+
+	if pb.GetSentAt() != nil {
+		dutyType := core.DutyType(pb.GetDuty().GetType()).String()
+		receiveLatency.WithLabelValues(dutyType).Observe(time.Since(pb.GetSentAt().AsTime()).Seconds())
+	}
+
+	return m.handleMsg(ctx, peerID, &pbv1.ParSigExMsg{
+		Duty:    pb.GetDuty(),
+		DataSet: pb.GetDataSet(),
+	})
+}
+
+// handle handles a protocol version 2 message.
+func (m *ParSigEx) handle(ctx context.Context, peerID peer.ID, req proto.Message) (proto.Message, bool, error) {
 	pb, ok := req.(*pbv1.ParSigExMsg)
 	if !ok {
 		return nil, false, errors.New("invalid request type")
 	}
 
+	receivedCounter.WithLabelValues(protocolID2).Inc()
+
+	return m.handleMsg(ctx, peerID, pb)
+}
+
+// handleMsg handles a message independent of the protocol version.
+// Replays of old duties are rejected by the duty gater, which only allows duties within a window of epochs
+// around the current epoch, while replays of recent messages are rejected by the replay guard.
+func (m *ParSigEx) handleMsg(ctx context.Context, peerID peer.ID, pb *pbv1.ParSigExMsg) (proto.Message, bool, error) {
 	if pb == nil || pb.GetDuty() == nil || pb.GetDataSet() == nil {
 		return nil, false, errors.New("invalid parsigex msg fields", z.Any("msg", pb))
 	}
@@ -80,7 +135,18 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 	ctx = log.WithCtx(ctx, z.Any("duty", duty))
 
 	if !m.gaterFunc(duty) {
-		return nil, false, errors.New("invalid duty")
+		return nil, false, errors.New("invalid duty or outside allowed epochs, possible replay")
+	}
+
+	// Reject replays before the expensive partial signature verification.
+	replay, err := m.replays.Seen(peerID, pb)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if replay {
+		replayRejectedCounter.WithLabelValues(duty.Type.String()).Inc()
+		return nil, false, errors.New("duplicate parsigex message, possible replay")
 	}
 
 	set, err := core.ParSignedDataSetFromProto(duty.Type, pb.GetDataSet())
@@ -95,11 +161,9 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 		defer span.End()
 	}
 
-	// Verify partial signature
-	for pubkey, data := range set {
-		if err = m.verifyFunc(ctx, duty, pubkey, data); err != nil {
-			return nil, false, errors.Wrap(err, "invalid partial signature")
-		}
+	// Verify partial signatures
+	if err = m.verifyFunc(ctx, duty, set); err != nil {
+		return nil, false, errors.Wrap(err, "invalid partial signature")
 	}
 
 	for _, sub := range m.subs {
@@ -133,7 +197,7 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 			continue
 		}
 
-		if err := m.sendFunc(ctx, m.tcpNode, protocolID2, p, &msg); err != nil {
+		if err := m.sendFunc(ctx, m.tcpNode, protocolID2, p, &msg, p2p.WithVersionedProtocol(protocolID3, toV3)); err != nil {
 			return err
 		}
 	}
@@ -141,6 +205,72 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 	return nil
 }
 
+// toV3 converts a protocol version 2 message to version 3, setting the sent at timestamp.
+func toV3(msg proto.Message) (proto.Message, error) {
+	pb, ok := msg.(*pbv1.ParSigExMsg)
+	if !ok {
+		return nil, errors.New("invalid parsigex message type")
+	}
+
+	return &pbv1.ParSigExMsgV3{
+		Duty:    pb.GetDuty(),
+		DataSet: pb.GetDataSet(),
+		SentAt:  timestamppb.Now(),
+	}, nil
+}
+
+// newReplayGuard returns a new replay guard remembering messages for the retention duration.
+func newReplayGuard(retention time.Duration, nowFunc func() time.Time) *replayGuard {
+	return &replayGuard{
+		retention: retention,
+		nowFunc:   nowFunc,
+		seen:      make(map[[32]byte]bool),
+	}
+}
+
+// replayGuard detects replayed messages by remembering the hashes of messages received from each peer.
+type replayGuard struct {
+	mu        sync.Mutex
+	retention time.Duration
+	nowFunc   func() time.Time
+	seen      map[[32]byte]bool
+	expiries  []replayExpiry // Ordered by expiry time.
+}
+
+// replayExpiry is the time a message hash is forgotten.
+type replayExpiry struct {
+	hash   [32]byte
+	expiry time.Time
+}
+
+// Seen returns true if the message was previously received from the peer, otherwise it remembers the message.
+func (g *replayGuard) Seen(peerID peer.ID, pb *pbv1.ParSigExMsg) (bool, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(pb)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal parsigex msg")
+	}
+
+	hash := sha256.Sum256(append([]byte(peerID), b...))
+	now := g.nowFunc()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for len(g.expiries) > 0 && !g.expiries[0].expiry.After(now) {
+		delete(g.seen, g.expiries[0].hash)
+		g.expiries = g.expiries[1:]
+	}
+
+	if g.seen[hash] {
+		return true, nil
+	}
+
+	g.seen[hash] = true
+	g.expiries = append(g.expiries, replayExpiry{hash: hash, expiry: now.Add(g.retention)})
+
+	return false, nil
+}
+
 // Subscribe registers a callback when a partially signed duty set
 // is received from a peer. This is not thread safe, it must be called before starting to use parsigex.
 func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
@@ -148,24 +278,35 @@ func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedD
 }
 
 // NewEth2Verifier returns a partial signature verification function for core workflow eth2 signatures.
-func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
-	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
-		pubshares, ok := pubSharesByKey[pubkey]
-		if !ok {
-			return errors.New("unknown pubkey, not part of cluster lock")
-		}
-
-		pubshare, ok := pubshares[data.ShareIdx]
-		if !ok {
-			return errors.New("invalid shareIdx")
-		}
-
-		eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
-		if !ok {
-			return errors.New("invalid eth2 signed data")
+// All partial signatures in the set are verified together using BLS batch verification.
+func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.ParSignedDataSet) error, error) {
+	return func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		var (
+			datas     []core.Eth2SignedData
+			pubshares []tbls.PublicKey
+		)
+
+		for pubkey, data := range set {
+			shares, ok := pubSharesByKey[pubkey]
+			if !ok {
+				return errors.New("unknown pubkey, not part of cluster lock", z.Any("pubkey", pubkey))
+			}
+
+			pubshare, ok := shares[data.ShareIdx]
+			if !ok {
+				return errors.New("invalid shareIdx", z.Any("pubkey", pubkey))
+			}
+
+			eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
+			if !ok {
+				return errors.New("invalid eth2 signed data", z.Any("pubkey", pubkey))
+			}
+
+			datas = append(datas, eth2Signed)
+			pubshares = append(pubshares, pubshare)
 		}
 
-		err := core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, pubshare)
+		err := core.VerifyEth2SignedDataBatch(ctx, eth2Cl, datas, pubshares)
 		if err != nil {
 			return errors.Wrap(err, "invalid signature", z.Str("duty", duty.String()))
 		}
@@ -0,0 +1,77 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestReplayGuard(t *testing.T) {
+	now := time.Now()
+	guard := newReplayGuard(time.Minute, func() time.Time { return now })
+
+	set, err := core.ParSignedDataSetToProto(core.ParSignedDataSet{
+		testutil.RandomCorePubKey(t): core.NewPartialSignedRandao(1, testutil.RandomEth2Signature(), 1),
+	})
+	require.NoError(t, err)
+
+	msg := &pbv1.ParSigExMsg{
+		Duty:    core.DutyToProto(core.Duty{Slot: 2, Type: core.DutyRandao}),
+		DataSet: set,
+	}
+
+	peer1, peer2 := peer.ID("peer1"), peer.ID("peer2")
+
+	seen, err := guard.Seen(peer1, msg)
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	// Replays from the same peer are detected.
+	seen, err = guard.Seen(peer1, msg)
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	// Identical messages from other peers aren't replays.
+	seen, err = guard.Seen(peer2, msg)
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	// Messages are forgotten after the retention.
+	now = now.Add(time.Minute)
+	seen, err = guard.Seen(peer1, msg)
+	require.NoError(t, err)
+	require.False(t, seen)
+	require.Len(t, guard.seen, 1)
+}
+
+func TestReceiveLatencySentAtUnset(t *testing.T) {
+	m := &ParSigEx{gaterFunc: func(core.Duty) bool { return false }}
+
+	msg := &pbv1.ParSigExMsgV3{
+		Duty:    core.DutyToProto(core.Duty{Slot: 1, Type: core.DutyBuilderRegistration}),
+		DataSet: &pbv1.ParSignedDataSet{},
+	}
+
+	before := promtestutil.CollectAndCount(receiveLatency)
+
+	// Latency isn't observed without the sent at timestamp.
+	_, _, err := m.handleV3(t.Context(), "", msg)
+	require.Error(t, err)
+	require.Equal(t, before, promtestutil.CollectAndCount(receiveLatency))
+
+	msg.SentAt = timestamppb.Now()
+
+	_, _, err = m.handleV3(t.Context(), "", msg)
+	require.Error(t, err)
+	require.Equal(t, before+1, promtestutil.CollectAndCount(receiveLatency))
+}
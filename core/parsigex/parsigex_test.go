@@ -5,7 +5,9 @@ package parsigex_test
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
@@ -15,8 +17,11 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/core/parsigex"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
@@ -73,7 +78,7 @@ func TestParSigEx(t *testing.T) {
 		}
 	}
 
-	verifyFunc := func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error {
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
 		return nil
 	}
 
@@ -119,6 +124,140 @@ func TestParSigEx(t *testing.T) {
 	wg.Wait()
 }
 
+func TestParSigExLegacyPeer(t *testing.T) {
+	duty := core.Duty{Slot: 123, Type: core.DutyRandao}
+	data := core.ParSignedDataSet{
+		testutil.RandomCorePubKey(t): core.NewPartialSignedRandao(123, testutil.RandomEth2Signature(), 0),
+	}
+
+	host := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	legacy := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	host.Peerstore().AddAddrs(legacy.ID(), legacy.Addrs(), peerstore.PermanentAddrTTL)
+
+	// Legacy peer only supporting protocol version 2.
+	received := make(chan *pbv1.ParSigExMsg, 1)
+	p2p.RegisterHandler("parsigex", legacy, "/charon/parsigex/2.0.0",
+		func() proto.Message { return new(pbv1.ParSigExMsg) },
+		func(_ context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+			received <- req.(*pbv1.ParSigExMsg)
+			return nil, false, nil
+		},
+	)
+
+	noopVerifier := func(context.Context, core.Duty, core.ParSignedDataSet) error { return nil }
+	gaterFunc := func(core.Duty) bool { return true }
+
+	sigex := parsigex.NewParSigEx(host, p2p.Send, 0, []peer.ID{host.ID(), legacy.ID()}, noopVerifier, gaterFunc)
+	require.NoError(t, sigex.Broadcast(context.Background(), duty, data))
+
+	msg := <-received
+	require.Equal(t, duty, core.DutyFromProto(msg.GetDuty()))
+
+	set, err := core.ParSignedDataSetFromProto(duty.Type, msg.GetDataSet())
+	require.NoError(t, err)
+	require.Equal(t, data, set)
+}
+
+func TestParSigExReplay(t *testing.T) {
+	const slotDuration = time.Second
+
+	// Current slot 100, epoch 50.
+	bmock, err := beaconmock.New(
+		beaconmock.WithGenesisTime(time.Now().Add(-100*slotDuration)),
+		beaconmock.WithSlotDuration(slotDuration),
+		beaconmock.WithSlotsPerEpoch(2),
+	)
+	require.NoError(t, err)
+
+	gater, err := core.NewDutyGater(context.Background(), bmock, core.WithDutyGaterReplayWindow(1))
+	require.NoError(t, err)
+
+	var (
+		rejected = make(chan core.Duty, 3)
+		received = make(chan core.Duty, 3)
+	)
+
+	gaterFunc := func(duty core.Duty) bool {
+		ok := gater(duty)
+		if !ok {
+			rejected <- duty
+		}
+
+		return ok
+	}
+
+	host := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	sender := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	sender.Peerstore().AddAddrs(host.ID(), host.Addrs(), peerstore.PermanentAddrTTL)
+
+	var verified atomic.Int32
+	countVerifier := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		verified.Add(1)
+		return nil
+	}
+
+	sigex := parsigex.NewParSigEx(host, p2p.Send, 0, []peer.ID{host.ID(), sender.ID()}, countVerifier, gaterFunc)
+	sigex.Subscribe(func(_ context.Context, duty core.Duty, _ core.ParSignedDataSet) error {
+		received <- duty
+		return nil
+	})
+
+	newMsg := func(slot uint64) *pbv1.ParSigExMsg {
+		set, err := core.ParSignedDataSetToProto(core.ParSignedDataSet{
+			testutil.RandomCorePubKey(t): core.NewPartialSignedRandao(eth2p0.Epoch(slot/2), testutil.RandomEth2Signature(), 1),
+		})
+		require.NoError(t, err)
+
+		return &pbv1.ParSigExMsg{
+			Duty:    core.DutyToProto(core.Duty{Slot: slot, Type: core.DutyRandao}),
+			DataSet: set,
+		}
+	}
+
+	ctx := context.Background()
+	staleSlot, freshSlot := uint64(10), uint64(100)
+
+	// Stale duty via the legacy protocol version 2 without a sent at timestamp.
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/2.0.0", host.ID(), newMsg(staleSlot)))
+	require.Equal(t, staleSlot, (<-rejected).Slot)
+
+	// Stale duty via protocol version 3 with a forged recent sent at timestamp.
+	stale := newMsg(staleSlot)
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/3.0.0", host.ID(), &pbv1.ParSigExMsgV3{
+		Duty:    stale.GetDuty(),
+		DataSet: stale.GetDataSet(),
+		SentAt:  timestamppb.Now(),
+	}))
+	require.Equal(t, staleSlot, (<-rejected).Slot)
+
+	// Fresh duty is accepted, independent of the unsigned sent at timestamp.
+	fresh := newMsg(freshSlot)
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/3.0.0", host.ID(), &pbv1.ParSigExMsgV3{
+		Duty:    fresh.GetDuty(),
+		DataSet: fresh.GetDataSet(),
+		SentAt:  timestamppb.New(time.Now().Add(-time.Hour)),
+	}))
+	require.Equal(t, freshSlot, (<-received).Slot)
+
+	// Replayed fresh duty within the window is rejected as a duplicate, even with a different sent at timestamp.
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/3.0.0", host.ID(), &pbv1.ParSigExMsgV3{
+		Duty:    fresh.GetDuty(),
+		DataSet: fresh.GetDataSet(),
+		SentAt:  timestamppb.Now(),
+	}))
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/2.0.0", host.ID(), fresh))
+
+	// A different message for the same duty is accepted.
+	require.NoError(t, p2p.Send(ctx, sender, "/charon/parsigex/2.0.0", host.ID(), newMsg(freshSlot)))
+	require.Equal(t, freshSlot, (<-received).Slot)
+
+	require.Empty(t, received)
+	require.Empty(t, rejected)
+
+	// Only the accepted messages were verified, replays are rejected before verification.
+	require.EqualValues(t, 2, verified.Load())
+}
+
 func TestParSigExVerifier(t *testing.T) {
 	ctx := context.Background()
 
@@ -171,7 +310,7 @@ func TestParSigExVerifier(t *testing.T) {
 		att.Deneb.Signature = sign(sigData[:])
 		data, err := core.NewPartialVersionedAttestation(att, shareIdx)
 		require.NoError(t, err)
-		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify proposal", func(t *testing.T) {
@@ -186,7 +325,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedProposal(proposal, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify blinded proposal", func(t *testing.T) {
@@ -204,7 +343,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedBlindedProposal(&eth2apiBlinded, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify Randao", func(t *testing.T) {
@@ -216,7 +355,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		randao := core.NewPartialSignedRandao(epoch, sign(sigData[:]), shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), pubkey, randao))
+		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), core.ParSignedDataSet{pubkey: randao}))
 	})
 
 	t.Run("Verify Voluntary Exit", func(t *testing.T) {
@@ -232,7 +371,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify validator registration", func(t *testing.T) {
@@ -249,7 +388,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedValidatorRegistration(&reg.VersionedSignedValidatorRegistration, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify beacon committee selection", func(t *testing.T) {
@@ -263,7 +402,7 @@ func TestParSigExVerifier(t *testing.T) {
 		selection.SelectionProof = sign(sigData[:])
 		data := core.NewPartialSignedBeaconCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify aggregate and proof", func(t *testing.T) {
@@ -286,7 +425,7 @@ func TestParSigExVerifier(t *testing.T) {
 		agg.Deneb.Signature = sign(sigData[:])
 		data := core.NewPartialVersionedSignedAggregateAndProof(agg, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("verify sync committee message", func(t *testing.T) {
@@ -299,11 +438,11 @@ func TestParSigExVerifier(t *testing.T) {
 		msg.Signature = sign(sigData[:])
 
 		data := core.NewPartialSignedSyncMessage(msg, shareIdx)
-		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data}))
 
 		// Invalid sync committee message.
 		data = core.NewPartialSignedRandao(epoch, testutil.RandomEth2Signature(), shareIdx)
-		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data)
+		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data})
 		require.Error(t, err)
 		require.ErrorContains(t, err, "invalid signature")
 	})
@@ -326,7 +465,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 
 	t.Run("verify sync committee contribution and proof", func(t *testing.T) {
@@ -343,7 +482,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncContributionAndProof(proof, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 }
 
@@ -0,0 +1,186 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// proposalGuardRetainSlots is the number of slots before the most recent proposal for which records are retained.
+// Older proposals can't be broadcast anymore, so equivocating on them isn't possible.
+const proposalGuardRetainSlots = 1024
+
+// proposalRecord is a proposal partially signed by this node.
+type proposalRecord struct {
+	Slot      uint64 `json:"slot"`
+	PubKey    PubKey `json:"pubkey"`
+	BlockRoot string `json:"block_root"`
+}
+
+// proposalKey identifies a proposal of a validator in a slot.
+type proposalKey struct {
+	Slot   uint64
+	PubKey PubKey
+}
+
+// NewProposalGuard returns a new proposal guard persisting records to the file at path,
+// loading the records of previous runs if the file exists.
+func NewProposalGuard(path string) (*ProposalGuard, error) {
+	g := &ProposalGuard{
+		path:    path,
+		records: make(map[proposalKey]string),
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Create the file on startup to fail fast if it isn't writable.
+		if err := g.persistLocked(); err != nil {
+			return nil, err
+		}
+
+		return g, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read proposal guard file", z.Str("path", path))
+	}
+
+	var records []proposalRecord
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, errors.Wrap(err, "unmarshal proposal guard file", z.Str("path", path))
+	}
+
+	for _, record := range records {
+		g.records[proposalKey{Slot: record.Slot, PubKey: record.PubKey}] = record.BlockRoot
+	}
+
+	return g, nil
+}
+
+// ProposalGuard is a minimal anti-equivocation backstop refusing to partially sign two different
+// blocks for the same validator and slot. Records are persisted before signing, so this also holds across restarts.
+type ProposalGuard struct {
+	path string
+
+	mu      sync.Mutex
+	records map[proposalKey]string
+}
+
+// CheckAndRecord returns an error if a different block was already partially signed for the validator in the slot,
+// otherwise it persists the block root.
+func (g *ProposalGuard) CheckAndRecord(slot uint64, pubkey PubKey, blockRoot [32]byte) error {
+	root := "0x" + hex.EncodeToString(blockRoot[:])
+	key := proposalKey{Slot: slot, PubKey: pubkey}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if prev, ok := g.records[key]; ok {
+		if prev != root {
+			return errors.New("refusing to sign equivocating proposal", z.U64("slot", slot),
+				z.Str("block_root", root), z.Str("signed_block_root", prev))
+		}
+
+		return nil // Identical proposals are not slashable.
+	}
+
+	g.records[key] = root
+	g.trimLocked(slot)
+
+	if err := g.persistLocked(); err != nil {
+		delete(g.records, key) // Only sign persisted proposals.
+		return err
+	}
+
+	return nil
+}
+
+// trimLocked deletes records of slots too old to be proposed. It must be called with the lock held.
+func (g *ProposalGuard) trimLocked(slot uint64) {
+	if slot < proposalGuardRetainSlots {
+		return
+	}
+
+	for key := range g.records {
+		if key.Slot < slot-proposalGuardRetainSlots {
+			delete(g.records, key)
+		}
+	}
+}
+
+// persistLocked atomically writes all records to the file. It must be called with the lock held.
+func (g *ProposalGuard) persistLocked() error {
+	records := make([]proposalRecord, 0, len(g.records))
+	for key, root := range g.records {
+		records = append(records, proposalRecord{Slot: key.Slot, PubKey: key.PubKey, BlockRoot: root})
+	}
+
+	b, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, "marshal proposal guard records")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(g.path), filepath.Base(g.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "create proposal guard file")
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Only fails if already renamed.
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "write proposal guard file")
+	} else if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "sync proposal guard file")
+	} else if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close proposal guard file")
+	}
+
+	if err := os.Rename(tmp.Name(), g.path); err != nil {
+		return errors.Wrap(err, "rename proposal guard file")
+	}
+
+	return nil
+}
+
+// WithProposalGuard wraps the validator API output to drop partial signed proposals equivocating
+// on proposals previously partially signed by this node, preventing them from being broadcast.
+func WithProposalGuard(guard *ProposalGuard) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			if duty.Type != DutyProposer && duty.Type != DutyBuilderProposer {
+				return clone.ParSigDBStoreInternal(ctx, duty, set)
+			}
+
+			filtered := make(ParSignedDataSet)
+			for pubkey, data := range set {
+				root, err := data.MessageRoot()
+				if err != nil {
+					return err
+				}
+
+				if err := guard.CheckAndRecord(duty.Slot, pubkey, root); err != nil {
+					log.Error(ctx, "Dropping partial signature of proposal", err, z.Any("duty", duty), z.Any("pubkey", pubkey))
+					continue
+				}
+
+				filtered[pubkey] = data
+			}
+
+			if len(filtered) == 0 {
+				return nil
+			}
+
+			return clone.ParSigDBStoreInternal(ctx, duty, filtered)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rootSignedData is a stub signed data with a fixed message root.
+type rootSignedData struct {
+	SignedData
+
+	root [32]byte
+}
+
+func (d rootSignedData) MessageRoot() ([32]byte, error) {
+	return d.root, nil
+}
+
+func TestProposalGuard(t *testing.T) {
+	var (
+		path   = filepath.Join(t.TempDir(), "proposal-guard.json")
+		pubkey = PubKeyFrom48Bytes([48]byte{1})
+		root1  = [32]byte{1}
+		root2  = [32]byte{2}
+	)
+
+	guard, err := NewProposalGuard(path)
+	require.NoError(t, err)
+
+	require.NoError(t, guard.CheckAndRecord(100, pubkey, root1))
+	require.NoError(t, guard.CheckAndRecord(100, pubkey, root1))
+	require.ErrorContains(t, guard.CheckAndRecord(100, pubkey, root2), "refusing to sign equivocating proposal")
+	require.NoError(t, guard.CheckAndRecord(101, pubkey, root2))
+
+	// Records are persisted across restarts.
+	guard, err = NewProposalGuard(path)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, guard.CheckAndRecord(100, pubkey, root2), "refusing to sign equivocating proposal")
+	require.NoError(t, guard.CheckAndRecord(100, pubkey, root1))
+
+	// Old records are trimmed.
+	require.NoError(t, guard.CheckAndRecord(100+proposalGuardRetainSlots+1, pubkey, root1))
+	require.NoError(t, guard.CheckAndRecord(100, pubkey, root2))
+}
+
+func TestWithProposalGuard(t *testing.T) {
+	guard, err := NewProposalGuard(filepath.Join(t.TempDir(), "proposal-guard.json"))
+	require.NoError(t, err)
+
+	var stored []ParSignedDataSet
+
+	w := wireFuncs{
+		ParSigDBStoreInternal: func(_ context.Context, _ Duty, set ParSignedDataSet) error {
+			stored = append(stored, set)
+			return nil
+		},
+	}
+
+	WithProposalGuard(guard)(&w)
+
+	newSet := func(root byte) ParSignedDataSet {
+		return ParSignedDataSet{PubKeyFrom48Bytes([48]byte{1}): ParSignedData{SignedData: rootSignedData{root: [32]byte{root}}, ShareIdx: 1}}
+	}
+
+	set := newSet(1)
+
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewProposerDuty(1), set))
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewProposerDuty(1), set))
+	require.Len(t, stored, 2)
+
+	// A different block for the same slot is dropped.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewProposerDuty(1), newSet(2)))
+	require.Len(t, stored, 2)
+
+	// Other duties are not guarded.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewAttesterDuty(1), newSet(2)))
+	require.Len(t, stored, 3)
+}
@@ -0,0 +1,157 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Names of the bounded inter-component duty queues.
+const (
+	QueueFetch = "scheduler_fetcher"
+	// QueueConsensus limits the number of concurrent consensus instances proposed by this node,
+	// since proposing blocks until the instance is decided or times out.
+	QueueConsensus = "fetcher_consensus"
+	QueueAggregate = "parsigdb_sigagg"
+	QueueBroadcast = "sigagg_bcast"
+)
+
+// defaultQueueLimit is the default maximum number of duty data sets processed concurrently per queue.
+const defaultQueueLimit = 256
+
+// errShed is returned when a stale duty is shed from a queue. It is a permanent error that isn't retried
+// by the async retryer, since retrying would only queue the stale duty again.
+var errShed = errors.NewSentinel("stale duty shed from queue")
+
+// NewQueues returns new bounded inter-component duty queues shedding duties that become stale
+// according to the deadline function while waiting.
+func NewQueues(deadlineFunc DeadlineFunc) *Queues {
+	return newQueues(deadlineFunc, defaultQueueLimit)
+}
+
+func newQueues(deadlineFunc DeadlineFunc, limit int) *Queues {
+	resp := &Queues{
+		deadlineFunc: deadlineFunc,
+		queues:       make(map[string]*dutyQueue),
+	}
+
+	for _, name := range []string{QueueFetch, QueueConsensus, QueueAggregate, QueueBroadcast} {
+		resp.queues[name] = &dutyQueue{
+			name: name,
+			sem:  make(chan struct{}, limit),
+		}
+
+		queueDepthGauge.WithLabelValues(name).Set(0)
+		queueHighWaterGauge.WithLabelValues(name).Set(0)
+	}
+
+	return resp
+}
+
+// Queues bounds the number of duty data sets processed concurrently by each inter-component edge of the
+// core workflow, instrumenting queue depths, high-water marks and processing lag. Duties that are stale
+// on arrival or become stale while waiting are shed, so an overloaded node prioritises current duties.
+type Queues struct {
+	deadlineFunc DeadlineFunc
+	queues       map[string]*dutyQueue
+}
+
+// dutyQueue is a bounded queue of an inter-component edge.
+type dutyQueue struct {
+	name string
+	sem  chan struct{}
+
+	mu        sync.Mutex
+	depth     int
+	highWater int
+}
+
+// add updates the queue depth by delta and instruments it.
+func (q *dutyQueue) add(delta int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.depth += delta
+	q.highWater = max(q.highWater, q.depth)
+
+	queueDepthGauge.WithLabelValues(q.name).Set(float64(q.depth))
+	queueHighWaterGauge.WithLabelValues(q.name).Set(float64(q.highWater))
+}
+
+// do waits for capacity in the named queue and then processes the duty with fn,
+// or sheds the duty if it is or becomes stale before capacity is available.
+func (q *Queues) do(ctx context.Context, name string, duty Duty, fn func(context.Context) error) error {
+	queue := q.queues[name]
+	enqueued := time.Now()
+
+	queue.add(1)
+	defer queue.add(-1)
+
+	var staleCh <-chan time.Time
+	if deadline, ok := q.deadlineFunc(duty); ok {
+		if !enqueued.Before(deadline) {
+			return q.shed(name, duty)
+		}
+
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+
+		staleCh = timer.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-staleCh:
+		return q.shed(name, duty)
+	case queue.sem <- struct{}{}:
+	}
+	defer func() { <-queue.sem }()
+
+	queueLagHistogram.WithLabelValues(name).Observe(time.Since(enqueued).Seconds())
+
+	return fn(ctx)
+}
+
+// shed instruments and returns the error of a stale duty shed from the queue.
+func (*Queues) shed(name string, duty Duty) error {
+	queueShedCounter.WithLabelValues(name, duty.Type.String()).Inc()
+
+	return errors.Wrap(errShed, "queue overloaded", z.Str("queue", name), z.Any("duty", duty))
+}
+
+// WithQueues wraps the scheduler to fetcher, fetcher to consensus, parsigdb to sigagg and sigagg to broadcaster
+// component input functions with bounded queues. It must be applied before WithAsyncRetry, so the queues
+// bound the retried component input functions themselves instead of the immediately returning async calls.
+// Note the fetcher to consensus queue therefore limits concurrent consensus instances, see QueueConsensus.
+func WithQueues(queues *Queues) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.FetcherFetch = func(ctx context.Context, duty Duty, set DutyDefinitionSet) error {
+			return queues.do(ctx, QueueFetch, duty, func(ctx context.Context) error {
+				return clone.FetcherFetch(ctx, duty, set)
+			})
+		}
+		w.ConsensusPropose = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			return queues.do(ctx, QueueConsensus, duty, func(ctx context.Context) error {
+				return clone.ConsensusPropose(ctx, duty, set)
+			})
+		}
+		w.SigAggAggregate = func(ctx context.Context, duty Duty, set map[PubKey][]ParSignedData) error {
+			return queues.do(ctx, QueueAggregate, duty, func(ctx context.Context) error {
+				return clone.SigAggAggregate(ctx, duty, set)
+			})
+		}
+		w.BroadcasterBroadcast = func(ctx context.Context, duty Duty, set SignedDataSet) error {
+			return queues.do(ctx, QueueBroadcast, duty, func(ctx context.Context) error {
+				return clone.BroadcasterBroadcast(ctx, duty, set)
+			})
+		}
+	}
+}
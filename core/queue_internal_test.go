@@ -0,0 +1,126 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/retry"
+)
+
+func TestWithQueues(t *testing.T) {
+	var (
+		stale   = NewAttesterDuty(1)
+		expires = NewAttesterDuty(2)
+		current = NewAttesterDuty(3)
+		exit    = NewVoluntaryExit(4)
+	)
+
+	deadlines := map[Duty]time.Time{
+		stale:   time.Now().Add(-time.Second),
+		expires: time.Now().Add(50 * time.Millisecond),
+		current: time.Now().Add(time.Hour),
+	}
+	deadlineFunc := func(duty Duty) (time.Time, bool) {
+		deadline, ok := deadlines[duty]
+		return deadline, ok
+	}
+
+	queues := newQueues(deadlineFunc, 1)
+	shedBefore := promtestutil.ToFloat64(queueShedCounter.WithLabelValues(QueueFetch, "attester"))
+
+	var (
+		started = make(chan Duty, 4)
+		release = make(chan struct{})
+	)
+
+	w := wireFuncs{
+		FetcherFetch: func(_ context.Context, duty Duty, _ DutyDefinitionSet) error {
+			started <- duty
+			<-release
+
+			return nil
+		},
+	}
+	WithQueues(queues)(&w)
+
+	// Stale duties are shed on arrival.
+	err := w.FetcherFetch(t.Context(), stale, nil)
+	require.ErrorIs(t, err, errShed)
+
+	// The current duty fills the queue.
+	currentErr := make(chan error)
+	go func() {
+		currentErr <- w.FetcherFetch(t.Context(), current, nil)
+	}()
+	require.Equal(t, current, <-started)
+
+	// Never expiring duties wait for capacity.
+	exitErr := make(chan error)
+	go func() {
+		exitErr <- w.FetcherFetch(t.Context(), exit, nil)
+	}()
+
+	// Duties expiring while waiting are shed.
+	err = w.FetcherFetch(t.Context(), expires, nil)
+	require.ErrorIs(t, err, errShed)
+
+	require.InDelta(t, shedBefore+2, promtestutil.ToFloat64(queueShedCounter.WithLabelValues(QueueFetch, "attester")), 0)
+	require.InDelta(t, 3, promtestutil.ToFloat64(queueHighWaterGauge.WithLabelValues(QueueFetch)), 0)
+
+	close(release)
+	require.NoError(t, <-currentErr)
+	require.NoError(t, <-exitErr)
+	require.Equal(t, exit, <-started)
+
+	require.Eventually(t, func() bool {
+		return promtestutil.ToFloat64(queueDepthGauge.WithLabelValues(QueueFetch)) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestWithQueuesAsyncRetry(t *testing.T) {
+	stale := NewAttesterDuty(1)
+
+	attempts := make(chan Duty, 1)
+	deadlineFunc := func(duty Duty) (time.Time, bool) {
+		attempts <- duty
+		return time.Now().Add(-time.Second), true
+	}
+
+	// The retryer doesn't time out, so only the error determines whether the duty is retried.
+	retried := make(chan int, 1)
+	retryer := retry.NewForT[Duty](t,
+		func(ctx context.Context, _ Duty) (context.Context, context.CancelFunc) {
+			return context.WithCancel(ctx)
+		},
+		func() func(int) <-chan time.Time {
+			return func(i int) <-chan time.Time {
+				retried <- i
+				return make(chan time.Time)
+			}
+		},
+	)
+	defer retryer.Shutdown(t.Context())
+
+	w := wireFuncs{
+		ConsensusPropose: func(context.Context, Duty, UnsignedDataSet) error {
+			require.Fail(t, "stale duty proposed")
+			return nil
+		},
+	}
+	WithQueues(newQueues(deadlineFunc, 1))(&w)
+	WithAsyncRetry(retryer)(&w)
+
+	require.NoError(t, w.ConsensusPropose(t.Context(), stale, nil))
+
+	// The stale duty is shed and not retried.
+	require.Equal(t, stale, <-attempts)
+	require.Never(t, func() bool {
+		return len(retried) > 0 || len(attempts) > 0
+	}, 100*time.Millisecond, time.Millisecond)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+)
+
+// Recorder records core workflow inputs enabling deterministic replay of the core pipeline.
+type Recorder interface {
+	// RecordUnsigned records unsigned data decided by consensus, based on beacon node responses.
+	RecordUnsigned(Duty, UnsignedDataSet)
+
+	// RecordParSigInternal records partially signed data submitted by the local validator client.
+	RecordParSigInternal(Duty, ParSignedDataSet)
+
+	// RecordParSigExternal records partially signed data received from peers.
+	RecordParSigExternal(Duty, ParSignedDataSet)
+}
+
+// WithRecorder wraps component input functions to record core workflow inputs.
+func WithRecorder(recorder Recorder) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.DutyDBStore = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			recorder.RecordUnsigned(duty, set)
+			return clone.DutyDBStore(ctx, duty, set)
+		}
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			recorder.RecordParSigInternal(duty, set)
+			return clone.ParSigDBStoreInternal(ctx, duty, set)
+		}
+		w.ParSigDBStoreExternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			recorder.RecordParSigExternal(duty, set)
+			return clone.ParSigDBStoreExternal(ctx, duty, set)
+		}
+	}
+}
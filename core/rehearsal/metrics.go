@@ -0,0 +1,25 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rehearsal
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	stepGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "rehearsal",
+		Name:      "step_success",
+		Help:      "Set to 1 if the step (produce, consensus or sign) of the latest proposal rehearsal succeeded, or 0 if it failed",
+	}, []string{"step"})
+
+	stepDurationGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "rehearsal",
+		Name:      "step_duration_seconds",
+		Help:      "Duration in seconds of the step (produce, consensus or sign) of the latest successful proposal rehearsal",
+	}, []string{"step"})
+)
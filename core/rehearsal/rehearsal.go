@@ -0,0 +1,242 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package rehearsal provides the opt-in proposal rehearsal, periodically running the block proposal
+// pipeline against a block that is never broadcast, validating the proposal infrastructure before
+// a rare real proposal arrives.
+package rehearsal
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const (
+	stepProduce   = "produce"
+	stepConsensus = "consensus"
+	stepSign      = "sign"
+)
+
+// signingPrefix domain separates rehearsal signatures from any beacon chain signing root,
+// so rehearsal signatures can never be used as block proposal signatures.
+var signingPrefix = []byte("charon_proposal_rehearsal")
+
+// New returns a new proposal rehearsal running every interval slots. The rehearsal proposal is keyed by the
+// validator pubkey and only signed if the secret key share is provided.
+func New(eth2Cl eth2wrap.Client, interval uint64, pubkey core.PubKey, pubshare tbls.PublicKey, secret *tbls.PrivateKey,
+	dutyDefFunc func(context.Context, core.Duty) (core.DutyDefinitionSet, error),
+	proposeFunc func(context.Context, core.Duty, core.UnsignedDataSet) error,
+	deadlineFunc func(core.Duty) (time.Time, bool),
+) *Rehearsal {
+	return &Rehearsal{
+		eth2Cl:       eth2Cl,
+		interval:     max(interval, 1),
+		pubkey:       pubkey,
+		pubshare:     pubshare,
+		secret:       secret,
+		dutyDefFunc:  dutyDefFunc,
+		proposeFunc:  proposeFunc,
+		deadlineFunc: deadlineFunc,
+		decided:      make(map[uint64]chan core.UnsignedDataSet),
+	}
+}
+
+// Rehearsal runs the block proposal pipeline, producing, deciding and partially signing a block proposal
+// which is then discarded, never broadcast.
+type Rehearsal struct {
+	eth2Cl       eth2wrap.Client
+	interval     uint64
+	pubkey       core.PubKey
+	pubshare     tbls.PublicKey
+	secret       *tbls.PrivateKey
+	dutyDefFunc  func(context.Context, core.Duty) (core.DutyDefinitionSet, error)
+	proposeFunc  func(context.Context, core.Duty, core.UnsignedDataSet) error
+	deadlineFunc func(core.Duty) (time.Time, bool)
+
+	mu      sync.Mutex
+	decided map[uint64]chan core.UnsignedDataSet
+}
+
+// Decided records consensus decisions of rehearsal duties.
+// It is intended to subscribe to consensus.
+func (r *Rehearsal) Decided(_ context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+	if duty.Type != core.DutyRehearsal {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch, ok := r.decided[duty.Slot]
+	if !ok {
+		return nil
+	}
+
+	select {
+	case ch <- set:
+	default:
+	}
+
+	return nil
+}
+
+// HandleSlot runs the proposal rehearsal every interval slots. All nodes rehearse the same slots, rehearsals
+// are skipped in slots with real block proposals of cluster validators.
+// It is intended to subscribe to the scheduler's slots.
+func (r *Rehearsal) HandleSlot(ctx context.Context, slot core.Slot) error {
+	if slot.Slot%r.interval != 0 {
+		return nil
+	}
+
+	_, err := r.dutyDefFunc(ctx, core.NewProposerDuty(slot.Slot))
+	if err == nil {
+		log.Info(ctx, "Skipping proposal rehearsal, block proposal scheduled", z.U64("slot", slot.Slot))
+		return nil
+	} else if !errors.Is(err, core.ErrNotFound) {
+		return err
+	}
+
+	duty := core.NewRehearsalDuty(slot.Slot)
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	if deadline, ok := r.deadlineFunc(duty); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	r.run(ctx, duty)
+
+	return nil
+}
+
+// run runs and instruments all steps of the rehearsal, stopping at the first failing step.
+func (r *Rehearsal) run(ctx context.Context, duty core.Duty) {
+	var proposal core.VersionedProposal
+
+	steps := []struct {
+		Name string
+		Func func(context.Context) error
+	}{
+		{Name: stepProduce, Func: func(ctx context.Context) (err error) {
+			proposal, err = r.produce(ctx, duty)
+			return err
+		}},
+		{Name: stepConsensus, Func: func(ctx context.Context) (err error) {
+			proposal, err = r.consensus(ctx, duty, proposal)
+			return err
+		}},
+		{Name: stepSign, Func: func(context.Context) error {
+			return r.sign(proposal)
+		}},
+	}
+
+	for _, step := range steps {
+		t0 := time.Now()
+		if err := step.Func(ctx); err != nil {
+			stepGauge.WithLabelValues(step.Name).Set(0)
+			log.Warn(ctx, "Proposal rehearsal failed; real block proposals are at risk", err, z.Str("step", step.Name))
+
+			return
+		}
+
+		stepGauge.WithLabelValues(step.Name).Set(1)
+		stepDurationGauge.WithLabelValues(step.Name).Set(time.Since(t0).Seconds())
+	}
+
+	log.Info(ctx, "Proposal rehearsal succeeded, discarding block", z.Bool("signed", r.secret != nil))
+}
+
+// produce returns a block proposal for the slot from the beacon node. The randao reveal is not verified
+// since the rehearsal has no signed randao, preferring a local execution payload to not involve builders.
+func (r *Rehearsal) produce(ctx context.Context, duty core.Duty) (core.VersionedProposal, error) {
+	var (
+		randao eth2p0.BLSSignature
+		bbf    uint64
+	)
+
+	randao[0] = 0xc0 // Point at infinity.
+
+	eth2Resp, err := r.eth2Cl.Proposal(ctx, &eth2api.ProposalOpts{
+		Slot:                   eth2p0.Slot(duty.Slot),
+		RandaoReveal:           randao,
+		SkipRandaoVerification: true,
+		BuilderBoostFactor:     &bbf,
+	})
+	if err != nil {
+		return core.VersionedProposal{}, err
+	}
+
+	return core.NewVersionedProposal(eth2Resp.Data)
+}
+
+// consensus proposes the block proposal and returns the cluster's decided block proposal.
+func (r *Rehearsal) consensus(ctx context.Context, duty core.Duty, proposal core.VersionedProposal) (core.VersionedProposal, error) {
+	ch := make(chan core.UnsignedDataSet, 1)
+
+	r.mu.Lock()
+	r.decided[duty.Slot] = ch
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.decided, duty.Slot)
+		r.mu.Unlock()
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.proposeFunc(ctx, duty, core.UnsignedDataSet{r.pubkey: proposal})
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return core.VersionedProposal{}, errors.Wrap(ctx.Err(), "consensus timeout")
+		case err := <-errCh:
+			if err != nil {
+				return core.VersionedProposal{}, err
+			}
+		case set := <-ch:
+			decided, ok := set[r.pubkey].(core.VersionedProposal)
+			if !ok {
+				return core.VersionedProposal{}, errors.New("invalid decided rehearsal proposal")
+			}
+
+			return decided, nil
+		}
+	}
+}
+
+// sign partially signs the decided block root with this node's key share, verifying the signature
+// against the public key share. The block root is domain separated from beacon chain signing roots.
+func (r *Rehearsal) sign(proposal core.VersionedProposal) error {
+	if r.secret == nil {
+		return nil
+	}
+
+	root, err := proposal.Root()
+	if err != nil {
+		return errors.Wrap(err, "proposal root")
+	}
+
+	msg := sha256.Sum256(append(signingPrefix, root[:]...))
+
+	sig, err := tbls.Sign(*r.secret, msg[:])
+	if err != nil {
+		return err
+	}
+
+	return tbls.Verify(r.pubshare, msg[:], sig)
+}
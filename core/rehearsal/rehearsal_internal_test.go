@@ -0,0 +1,98 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package rehearsal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestRehearsal(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var randaoSkipped bool
+	bmock.ProposalFunc = func(_ context.Context, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error) {
+		randaoSkipped = opts.SkipRandaoVerification
+
+		block := testutil.RandomDenebVersionedProposal()
+		block.Deneb.Block.Slot = opts.Slot
+
+		return block, nil
+	}
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubkey := testutil.RandomCorePubKey(t)
+	scheduled := map[uint64]bool{20: true}
+
+	var proposed []core.Duty
+
+	var r *Rehearsal
+	r = New(bmock, 10, pubkey, pubshare, &secret,
+		func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+			require.Equal(t, core.DutyProposer, duty.Type)
+			if scheduled[duty.Slot] {
+				return core.DutyDefinitionSet{}, nil
+			}
+
+			return nil, errors.Wrap(core.ErrNotFound, "no proposer")
+		},
+		func(ctx context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+			proposed = append(proposed, duty)
+			require.Contains(t, set, pubkey)
+
+			return r.Decided(ctx, duty, set)
+		},
+		func(core.Duty) (time.Time, bool) { return time.Now().Add(time.Minute), true },
+	)
+
+	for _, slot := range []uint64{9, 10, 11, 20} {
+		require.NoError(t, r.HandleSlot(ctx, core.Slot{Slot: slot}))
+	}
+
+	// Only slot 10 rehearsed, slot 20 has a real block proposal.
+	require.Equal(t, []core.Duty{core.NewRehearsalDuty(10)}, proposed)
+	require.True(t, randaoSkipped)
+	require.Empty(t, r.decided)
+
+	for _, step := range []string{stepProduce, stepConsensus, stepSign} {
+		require.InDelta(t, 1, promtestutil.ToFloat64(stepGauge.WithLabelValues(step)), 0, step)
+	}
+}
+
+func TestRehearsalConsensusFailure(t *testing.T) {
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	r := New(bmock, 1, testutil.RandomCorePubKey(t), tbls.PublicKey{}, nil,
+		func(context.Context, core.Duty) (core.DutyDefinitionSet, error) {
+			return nil, core.ErrNotFound
+		},
+		func(context.Context, core.Duty, core.UnsignedDataSet) error {
+			return errors.New("consensus failed")
+		},
+		func(core.Duty) (time.Time, bool) { return time.Time{}, false },
+	)
+
+	require.NoError(t, r.HandleSlot(context.Background(), core.Slot{Slot: 1}))
+
+	require.InDelta(t, 1, promtestutil.ToFloat64(stepGauge.WithLabelValues(stepProduce)), 0)
+	require.InDelta(t, 0, promtestutil.ToFloat64(stepGauge.WithLabelValues(stepConsensus)), 0)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+var _ core.Recorder = (*FileRecorder)(nil)
+
+// NewFileRecorder returns a recorder appending core workflow input events as JSON lines to the file.
+func NewFileRecorder(path string) (*FileRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "open replay record file", z.Str("path", path))
+	}
+
+	return &FileRecorder{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// FileRecorder records core workflow input events to a file.
+type FileRecorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	closed bool
+}
+
+// RecordUnsigned implements core.Recorder.
+func (r *FileRecorder) RecordUnsigned(duty core.Duty, set core.UnsignedDataSet) {
+	pb, err := core.UnsignedDataSetToProto(set)
+	if err != nil {
+		r.warn(duty, err)
+		return
+	}
+
+	r.record(KindUnsigned, duty, pb)
+}
+
+// RecordParSigInternal implements core.Recorder.
+func (r *FileRecorder) RecordParSigInternal(duty core.Duty, set core.ParSignedDataSet) {
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		r.warn(duty, err)
+		return
+	}
+
+	r.record(KindParSigInternal, duty, pb)
+}
+
+// RecordParSigExternal implements core.Recorder.
+func (r *FileRecorder) RecordParSigExternal(duty core.Duty, set core.ParSignedDataSet) {
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		r.warn(duty, err)
+		return
+	}
+
+	r.record(KindParSigExternal, duty, pb)
+}
+
+// Close closes the file, subsequent events are dropped.
+func (r *FileRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	r.closed = true
+
+	if err := r.file.Close(); err != nil {
+		return errors.Wrap(err, "close replay record file")
+	}
+
+	return nil
+}
+
+func (r *FileRecorder) record(kind string, duty core.Duty, pb proto.Message) {
+	data, err := protojson.Marshal(pb)
+	if err != nil {
+		r.warn(duty, errors.Wrap(err, "marshal event data"))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	err = r.enc.Encode(Event{
+		Time: time.Now(),
+		Kind: kind,
+		Duty: duty,
+		Data: data,
+	})
+	if err != nil {
+		r.warn(duty, errors.Wrap(err, "write event"))
+	}
+}
+
+func (*FileRecorder) warn(duty core.Duty, err error) {
+	log.Warn(log.WithTopic(context.Background(), "replay"), "Failed recording replay event", err, z.Any("duty", duty))
+}
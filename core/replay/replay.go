@@ -0,0 +1,167 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package replay records core workflow inputs (decided unsigned data based on beacon node responses,
+// validator client submissions and peer messages) and deterministically replays them through the core
+// pipeline for debugging consensus or aggregation bugs.
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/core/parsigdb"
+	"github.com/obolnetwork/charon/core/sigagg"
+)
+
+// Event kinds.
+const (
+	KindUnsigned       = "unsigned"
+	KindParSigInternal = "parsig_internal"
+	KindParSigExternal = "parsig_external"
+)
+
+// maxLineSize is the maximum size of a recorded event line.
+const maxLineSize = 16 << 20
+
+// Event is a recorded core workflow input.
+type Event struct {
+	Time time.Time       `json:"time"`
+	Kind string          `json:"kind"`
+	Duty core.Duty       `json:"duty"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Step is the outcome of replaying a recorded event.
+type Step struct {
+	Event Event
+	// Aggregated is the aggregated signed data set of the event's duty if threshold was reached.
+	Aggregated core.SignedDataSet
+	Err        error
+}
+
+// Load returns the events recorded in the file.
+func Load(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open replay record file", z.Str("path", path))
+	}
+	defer file.Close()
+
+	var resp []Event
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxLineSize)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, errors.Wrap(err, "unmarshal replay event", z.Int("line", len(resp)+1))
+		}
+
+		resp = append(resp, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read replay record file")
+	}
+
+	return resp, nil
+}
+
+// Replay replays the events in order through fresh instances of the DutyDB, ParSigDB and SigAgg core components,
+// returning the outcome of each event. Events are replayed sequentially so the outcome is deterministic.
+// Aggregated signatures are not verified if verifyFunc is nil.
+func Replay(ctx context.Context, events []Event, threshold int, verifyFunc func(context.Context, core.PubKey, core.SignedData) error) ([]Step, error) {
+	if verifyFunc == nil {
+		verifyFunc = func(context.Context, core.PubKey, core.SignedData) error { return nil }
+	}
+
+	dutyDB := dutydb.NewMemDB(noExpiry{})
+	parSigDB := parsigdb.NewMemDB(threshold, noExpiry{})
+
+	sigAgg, err := sigagg.New(threshold, verifyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregated core.SignedDataSet
+
+	parSigDB.SubscribeThreshold(sigAgg.Aggregate)
+	sigAgg.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+		aggregated = set
+		return nil
+	})
+
+	var resp []Step
+
+	for _, event := range events {
+		aggregated = nil
+
+		err := replayEvent(ctx, event, dutyDB, parSigDB)
+
+		resp = append(resp, Step{
+			Event:      event,
+			Aggregated: aggregated,
+			Err:        err,
+		})
+	}
+
+	return resp, nil
+}
+
+// replayEvent replays a single event.
+func replayEvent(ctx context.Context, event Event, dutyDB *dutydb.MemDB, parSigDB *parsigdb.MemDB) error {
+	switch event.Kind {
+	case KindUnsigned:
+		pb := new(pbv1.UnsignedDataSet)
+		if err := protojson.Unmarshal(event.Data, pb); err != nil {
+			return errors.Wrap(err, "unmarshal unsigned data set")
+		}
+
+		set, err := core.UnsignedDataSetFromProto(event.Duty.Type, pb)
+		if err != nil {
+			return err
+		}
+
+		return dutyDB.Store(ctx, event.Duty, set)
+	case KindParSigInternal, KindParSigExternal:
+		pb := new(pbv1.ParSignedDataSet)
+		if err := protojson.Unmarshal(event.Data, pb); err != nil {
+			return errors.Wrap(err, "unmarshal partial signed data set")
+		}
+
+		set, err := core.ParSignedDataSetFromProto(event.Duty.Type, pb)
+		if err != nil {
+			return err
+		}
+
+		if event.Kind == KindParSigInternal {
+			return parSigDB.StoreInternal(ctx, event.Duty, set)
+		}
+
+		return parSigDB.StoreExternal(ctx, event.Duty, set)
+	default:
+		return errors.New("unknown replay event kind", z.Str("kind", event.Kind))
+	}
+}
+
+// noExpiry is a deadliner that never expires duties.
+type noExpiry struct{}
+
+func (noExpiry) Add(core.Duty) bool { return true }
+
+func (noExpiry) C() <-chan core.Duty { return nil }
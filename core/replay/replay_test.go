@@ -0,0 +1,146 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package replay_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/replay"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRecordReplay(t *testing.T) {
+	const (
+		threshold = 3
+		peers     = 4
+		epoch     = 10
+	)
+
+	att := testutil.RandomPhase0Attestation()
+	exit := testutil.RandomExit()
+	syncMsg := testutil.RandomSyncCommitteeMessage()
+
+	tests := []struct {
+		name     string
+		duty     core.Duty
+		unsigned core.UnsignedData
+		parSig   func(sig eth2p0.BLSSignature, shareIdx int) core.ParSignedData
+	}{
+		{
+			name: "randao",
+			duty: core.NewRandaoDuty(epoch * 16),
+			parSig: func(sig eth2p0.BLSSignature, shareIdx int) core.ParSignedData {
+				return core.NewPartialSignedRandao(epoch, sig, shareIdx)
+			},
+		},
+		{
+			name:     "attester",
+			duty:     core.NewAttesterDuty(epoch * 16),
+			unsigned: core.AttestationData{Data: *att.Data, Duty: *testutil.RandomAttestationDuty(t)},
+			parSig: func(sig eth2p0.BLSSignature, shareIdx int) core.ParSignedData {
+				clone := *att
+				clone.Signature = sig
+
+				return core.NewPartialAttestation(&clone, shareIdx)
+			},
+		},
+		{
+			name: "exit",
+			duty: core.NewVoluntaryExit(epoch * 16),
+			parSig: func(sig eth2p0.BLSSignature, shareIdx int) core.ParSignedData {
+				clone := *exit
+				clone.Signature = sig
+
+				return core.NewPartialSignedVoluntaryExit(&clone, shareIdx)
+			},
+		},
+		{
+			name: "sync message",
+			duty: core.NewSyncMessageDuty(epoch * 16),
+			parSig: func(sig eth2p0.BLSSignature, shareIdx int) core.ParSignedData {
+				clone := *syncMsg
+				clone.Signature = sig
+
+				return core.NewPartialSignedSyncMessage(&clone, shareIdx)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret, err := tbls.GenerateSecretKey()
+			require.NoError(t, err)
+
+			shares, err := tbls.ThresholdSplit(secret, peers, threshold)
+			require.NoError(t, err)
+
+			pubkey := testutil.RandomCorePubKey(t)
+			msg := []byte(test.name)
+
+			parSig := func(shareIdx int) core.ParSignedDataSet {
+				sig, err := tbls.Sign(shares[shareIdx], msg)
+				require.NoError(t, err)
+
+				return core.ParSignedDataSet{pubkey: test.parSig(eth2p0.BLSSignature(sig), shareIdx)}
+			}
+
+			file := filepath.Join(t.TempDir(), "replay.jsonl")
+
+			recorder, err := replay.NewFileRecorder(file)
+			require.NoError(t, err)
+
+			var offset int
+			if test.unsigned != nil {
+				recorder.RecordUnsigned(test.duty, core.UnsignedDataSet{pubkey: test.unsigned})
+				offset = 1
+			}
+
+			recorder.RecordParSigInternal(test.duty, parSig(1))
+			recorder.RecordParSigExternal(test.duty, parSig(2))
+			recorder.RecordParSigExternal(test.duty, parSig(3))
+			recorder.RecordParSigExternal(test.duty, parSig(4))
+			require.NoError(t, recorder.Close())
+
+			// Events after close are dropped.
+			recorder.RecordParSigExternal(test.duty, parSig(4))
+
+			events, err := replay.Load(file)
+			require.NoError(t, err)
+			require.Len(t, events, offset+4)
+			require.Equal(t, replay.KindParSigInternal, events[offset].Kind)
+			require.Equal(t, test.duty, events[offset].Duty)
+
+			if offset > 0 {
+				require.Equal(t, replay.KindUnsigned, events[0].Kind)
+			}
+
+			expectedSig, err := tbls.Sign(secret, msg)
+			require.NoError(t, err)
+
+			// Replay twice to ensure determinism.
+			for range 2 {
+				steps, err := replay.Replay(t.Context(), events, threshold, nil)
+				require.NoError(t, err)
+				require.Len(t, steps, offset+4)
+
+				for i, step := range steps {
+					require.NoError(t, step.Err)
+
+					if i != offset+threshold-1 {
+						require.Empty(t, step.Aggregated, i)
+						continue
+					}
+
+					require.Len(t, step.Aggregated, 1)
+					require.Equal(t, core.SigFromETH2(eth2p0.BLSSignature(expectedSig)), step.Aggregated[pubkey].Signature())
+				}
+			}
+		})
+	}
+}
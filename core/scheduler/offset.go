@@ -3,12 +3,15 @@
 package scheduler
 
 import (
+	"strings"
 	"time"
 
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 )
 
-// slotOffsets defines the offsets at which the duties should be triggered.
+// slotOffsets defines the default offsets at which the duties should be triggered.
 var slotOffsets = map[core.DutyType]func(time.Duration) time.Duration{
 	core.DutyAttester:         fraction(1, 3), // 1/3 slot duration
 	core.DutyAggregator:       fraction(2, 3), // 2/3 slot duration
@@ -21,3 +24,66 @@ func fraction(x, y int64) func(time.Duration) time.Duration {
 		return (total * time.Duration(x)) / time.Duration(y)
 	}
 }
+
+// fixed returns a function that returns a fixed slot offset irrespective of total slot duration.
+func fixed(offset time.Duration) func(time.Duration) time.Duration {
+	return func(time.Duration) time.Duration {
+		return offset
+	}
+}
+
+// ParseSlotOffsets parses duty trigger slot offset overrides formatted as "duty=duration", e.g. "attester=3500ms".
+// Only duties triggered at a slot offset (attester, aggregator and sync_contribution) can be overridden.
+func ParseSlotOffsets(offsets []string) (map[core.DutyType]time.Duration, error) {
+	resp := make(map[core.DutyType]time.Duration)
+
+	for _, offset := range offsets {
+		duty, durStr, ok := strings.Cut(offset, "=")
+		if !ok {
+			return nil, errors.New("invalid slot offset, expect duty=duration", z.Str("offset", offset))
+		}
+
+		dutyType, ok := core.DutyTypeFromString(duty)
+		if _, hasOffset := slotOffsets[dutyType]; !ok || !hasOffset {
+			return nil, errors.New("invalid slot offset duty, expect attester, aggregator or sync_contribution", z.Str("offset", offset))
+		} else if _, ok := resp[dutyType]; ok {
+			return nil, errors.New("duplicate slot offset duty", z.Str("offset", offset))
+		}
+
+		dur, err := time.ParseDuration(durStr)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse slot offset duration", z.Str("offset", offset))
+		} else if dur < 0 {
+			return nil, errors.New("negative slot offset", z.Str("offset", offset))
+		}
+
+		resp[dutyType] = dur
+	}
+
+	return resp, nil
+}
+
+// VerifySlotOffsets returns an error if the slot offset overrides are not within safe bounds of the slot duration.
+// Offsets must be within the slot and aggregation may not be triggered before attestation.
+func VerifySlotOffsets(offsets map[core.DutyType]time.Duration, slotDuration time.Duration) error {
+	resolved := make(map[core.DutyType]time.Duration)
+	for dutyType, fn := range slotOffsets {
+		resolved[dutyType] = fn(slotDuration)
+	}
+
+	for dutyType, offset := range offsets {
+		if offset >= slotDuration {
+			return errors.New("slot offset exceeds slot duration",
+				z.Any("duty", dutyType), z.Str("offset", offset.String()), z.Str("slot_duration", slotDuration.String()))
+		}
+
+		resolved[dutyType] = offset
+	}
+
+	if resolved[core.DutyAggregator] < resolved[core.DutyAttester] {
+		return errors.New("aggregator slot offset before attester slot offset",
+			z.Str("aggregator", resolved[core.DutyAggregator].String()), z.Str("attester", resolved[core.DutyAttester].String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestParseSlotOffsets(t *testing.T) {
+	offsets, err := ParseSlotOffsets([]string{"attester=3500ms", "aggregator=7s"})
+	require.NoError(t, err)
+	require.Equal(t, map[core.DutyType]time.Duration{
+		core.DutyAttester:   3500 * time.Millisecond,
+		core.DutyAggregator: 7 * time.Second,
+	}, offsets)
+
+	tests := []struct {
+		Offset      string
+		ErrContains string
+	}{
+		{Offset: "attester", ErrContains: "expect duty=duration"},
+		{Offset: "proposer=1s", ErrContains: "invalid slot offset duty"},
+		{Offset: "foo=1s", ErrContains: "invalid slot offset duty"},
+		{Offset: "attester=foo", ErrContains: "parse slot offset duration"},
+		{Offset: "attester=-1s", ErrContains: "negative slot offset"},
+	}
+	for _, test := range tests {
+		t.Run(test.Offset, func(t *testing.T) {
+			_, err := ParseSlotOffsets([]string{test.Offset})
+			require.ErrorContains(t, err, test.ErrContains)
+		})
+	}
+
+	_, err = ParseSlotOffsets([]string{"attester=1s", "attester=2s"})
+	require.ErrorContains(t, err, "duplicate slot offset duty")
+}
+
+func TestVerifySlotOffsets(t *testing.T) {
+	const slotDuration = 12 * time.Second
+
+	require.NoError(t, VerifySlotOffsets(nil, slotDuration))
+	require.NoError(t, VerifySlotOffsets(map[core.DutyType]time.Duration{core.DutyAttester: 6 * time.Second}, slotDuration))
+
+	err := VerifySlotOffsets(map[core.DutyType]time.Duration{core.DutySyncContribution: slotDuration}, slotDuration)
+	require.ErrorContains(t, err, "slot offset exceeds slot duration")
+
+	// Default aggregator offset is 8s.
+	err = VerifySlotOffsets(map[core.DutyType]time.Duration{core.DutyAttester: 9 * time.Second}, slotDuration)
+	require.ErrorContains(t, err, "aggregator slot offset before attester slot offset")
+}
+
+func TestWithSlotOffsets(t *testing.T) {
+	s, err := New(nil, nil, false, WithSlotOffsets(map[core.DutyType]time.Duration{core.DutyAttester: 2 * time.Second}))
+	require.NoError(t, err)
+
+	require.Equal(t, 2*time.Second, s.slotOffsets[core.DutyAttester](12*time.Second))
+	require.Equal(t, 8*time.Second, s.slotOffsets[core.DutyAggregator](12*time.Second))
+	// Default offsets are not modified.
+	require.Equal(t, 4*time.Second, slotOffsets[core.DutyAttester](12*time.Second))
+}
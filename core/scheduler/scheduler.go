@@ -4,6 +4,7 @@ package scheduler
 
 import (
 	"context"
+	"maps"
 	"math"
 	"sort"
 	"sync"
@@ -50,14 +51,32 @@ func NewForT(t *testing.T, clock clockwork.Clock, delayFunc delayFunc, pubkeys [
 	return s
 }
 
+// Option configures a scheduler.
+type Option func(*Scheduler)
+
+// WithSlotOffsets returns an option overriding the default slot offsets at which duties are triggered.
+func WithSlotOffsets(offsets map[core.DutyType]time.Duration) Option {
+	return func(s *Scheduler) {
+		for dutyType, offset := range offsets {
+			s.slotOffsets[dutyType] = fixed(offset)
+		}
+	}
+}
+
 // New returns a new scheduler.
-func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled bool) (*Scheduler, error) {
-	return &Scheduler{
+func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled bool, opts ...Option) (*Scheduler, error) {
+	offsets := make(map[core.DutyType]func(time.Duration) time.Duration)
+	for dutyType, fn := range slotOffsets {
+		offsets[dutyType] = fn
+	}
+
+	s := &Scheduler{
 		eth2Cl:        eth2Cl,
 		pubkeys:       pubkeys,
 		quit:          make(chan struct{}),
 		duties:        make(map[core.Duty]core.DutyDefinitionSet),
 		dutiesByEpoch: make(map[uint64][]core.Duty),
+		valsByEpoch:   make(map[uint64]map[core.PubKey]bool),
 		clock:         clockwork.NewRealClock(),
 		delayFunc: func(_ core.Duty, deadline time.Time) <-chan time.Time {
 			return time.After(time.Until(deadline))
@@ -66,7 +85,14 @@ func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled bool) (*S
 		resolvedEpoch:   math.MaxInt64,
 		resolvingEpoch:  math.MaxInt64,
 		builderEnabled:  builderEnabled,
-	}, nil
+		slotOffsets:     offsets,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
 }
 
 type Scheduler struct {
@@ -80,11 +106,14 @@ type Scheduler struct {
 	resolvingEpoch  uint64
 	duties          map[core.Duty]core.DutyDefinitionSet
 	dutiesByEpoch   map[uint64][]core.Duty
+	valsByEpoch     map[uint64]map[core.PubKey]bool // Active validators the epoch's duties were resolved for.
 	dutiesMutex     sync.RWMutex
 	dutySubs        []func(context.Context, core.Duty, core.DutyDefinitionSet) error
 	slotSubs        []func(context.Context, core.Slot) error
+	newValSubs      []func(context.Context, core.Slot, []core.PubKey) error
 	builderEnabled  bool
 	schedSlotFunc   schedSlotFunc
+	slotOffsets     map[core.DutyType]func(time.Duration) time.Duration
 }
 
 // SubscribeDuties subscribes a callback function for triggered duties.
@@ -100,6 +129,13 @@ func (s *Scheduler) SubscribeSlots(fn func(context.Context, core.Slot) error) {
 	s.slotSubs = append(s.slotSubs, fn)
 }
 
+// SubscribeNewValidators subscribes a callback function for validators that became active after their epoch's duties
+// were resolved, e.g., to submit their builder registrations and fee recipients.
+// Note this should be called *before* Start.
+func (s *Scheduler) SubscribeNewValidators(fn func(context.Context, core.Slot, []core.PubKey) error) {
+	s.newValSubs = append(s.newValSubs, fn)
+}
+
 func (s *Scheduler) Stop() {
 	close(s.quit)
 }
@@ -166,6 +202,18 @@ func (s *Scheduler) emitCoreSlot(ctx context.Context, slot core.Slot) {
 	}
 }
 
+// emitNewValidators calls all new validator subscriptions asynchronously with the provided slot and validators.
+func (s *Scheduler) emitNewValidators(ctx context.Context, slot core.Slot, pubkeys []core.PubKey) {
+	for _, sub := range s.newValSubs {
+		go func(sub func(context.Context, core.Slot, []core.PubKey) error) {
+			err := sub(ctx, slot, pubkeys)
+			if err != nil {
+				log.Error(ctx, "Emit new validators event", err, z.U64("slot", slot.Slot))
+			}
+		}(sub)
+	}
+}
+
 // GetDutyDefinition returns the definition for a duty or core.ErrNotFound if no definitions exist for a resolved epoch
 // or another error.
 func (s *Scheduler) GetDutyDefinition(ctx context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
@@ -238,7 +286,7 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 
 		// Trigger duty async
 		go func() {
-			if !delaySlotOffset(ctx, slot, duty, s.delayFunc) {
+			if !delaySlotOffset(ctx, slot, duty, s.slotOffsets, s.delayFunc) {
 				return // context cancelled
 			}
 
@@ -276,8 +324,10 @@ func (s *Scheduler) scheduleSlot(ctx context.Context, slot core.Slot) {
 
 // delaySlotOffset blocks until the slot offset for the duty has been reached and return true.
 // It returns false if the context is cancelled.
-func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, delayFunc delayFunc) bool {
-	fn, ok := slotOffsets[duty.Type]
+func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty,
+	offsets map[core.DutyType]func(time.Duration) time.Duration, delayFunc delayFunc,
+) bool {
+	fn, ok := offsets[duty.Type]
 	if !ok {
 		return true
 	}
@@ -313,7 +363,58 @@ func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
 		return nil
 	}
 
-	err = s.resolveAttDuties(ctx, slot, vals)
+	err = s.resolveValDuties(ctx, slot, vals)
+	if err != nil {
+		return err
+	}
+
+	s.setResolvedEpoch(slot.Epoch())
+	s.trimDuties(slot.Epoch() - trimEpochOffset)
+
+	return nil
+}
+
+// ResolveNewValidators resolves the remaining duties of the slot's epoch for validators that became active after
+// the epoch's duties were resolved, e.g., once their deposits are processed. It should be called after refreshing
+// the validator cache, so newly activated validators perform duties without restarting charon.
+// New validator subscribers are notified once their duties are resolved.
+func (s *Scheduler) ResolveNewValidators(ctx context.Context, slot core.Slot) error {
+	if !s.isEpochResolved(slot.Epoch()) || s.isResolvingEpoch(slot.Epoch()) {
+		return nil // Duties are resolved for all active validators once the epoch is resolved.
+	}
+
+	vals, err := resolveActiveValidators(ctx, s.eth2Cl, s.metricSubmitter, slot.Epoch())
+	if err != nil {
+		return err
+	}
+
+	var newVals validators
+	for _, val := range vals {
+		if !s.isValResolved(slot.Epoch(), val.PubKey) {
+			newVals = append(newVals, val)
+		}
+	}
+
+	if len(newVals) == 0 {
+		return nil
+	}
+
+	activeValsGauge.Set(float64(len(vals)))
+	log.Info(ctx, "Resolving duties of newly active validators",
+		z.U64("epoch", slot.Epoch()), z.Int("validators", len(newVals)))
+
+	if err := s.resolveValDuties(ctx, slot, newVals); err != nil {
+		return err
+	}
+
+	s.emitNewValidators(ctx, slot, newVals.PubKeys())
+
+	return nil
+}
+
+// resolveValDuties resolves the attester, proposer and sync committee duties of the validators for the slot's epoch.
+func (s *Scheduler) resolveValDuties(ctx context.Context, slot core.Slot, vals validators) error {
+	err := s.resolveAttDuties(ctx, slot, vals)
 	if err != nil {
 		return err
 	}
@@ -328,8 +429,16 @@ func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
 		return err
 	}
 
-	s.setResolvedEpoch(slot.Epoch())
-	s.trimDuties(slot.Epoch() - trimEpochOffset)
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	if s.valsByEpoch[slot.Epoch()] == nil {
+		s.valsByEpoch[slot.Epoch()] = make(map[core.PubKey]bool)
+	}
+
+	for _, val := range vals {
+		s.valsByEpoch[slot.Epoch()][val.PubKey] = true
+	}
 
 	return nil
 }
@@ -527,6 +636,19 @@ func (s *Scheduler) resolveSyncCommDuties(ctx context.Context, slot core.Slot, v
 	return nil
 }
 
+// DutyDefinitions returns a copy of the resolved duty definitions, used for debugging snapshots.
+func (s *Scheduler) DutyDefinitions() map[core.Duty]core.DutyDefinitionSet {
+	s.dutiesMutex.RLock()
+	defer s.dutiesMutex.RUnlock()
+
+	resp := make(map[core.Duty]core.DutyDefinitionSet, len(s.duties))
+	for duty, defSet := range s.duties {
+		resp[duty] = maps.Clone(defSet)
+	}
+
+	return resp
+}
+
 func (s *Scheduler) getDutyDefinitionSet(duty core.Duty) (core.DutyDefinitionSet, bool) {
 	s.dutiesMutex.RLock()
 	defer s.dutiesMutex.RUnlock()
@@ -589,6 +711,14 @@ func (s *Scheduler) isResolvingEpoch(epoch uint64) bool {
 	return s.resolvingEpoch == epoch
 }
 
+// isValResolved returns true if the epoch's duties were resolved for the validator.
+func (s *Scheduler) isValResolved(epoch uint64, pubkey core.PubKey) bool {
+	s.dutiesMutex.RLock()
+	defer s.dutiesMutex.RUnlock()
+
+	return s.valsByEpoch[epoch][pubkey]
+}
+
 // isEpochResolved returns true if the epoch is resolved.
 func (s *Scheduler) isEpochResolved(epoch uint64) bool {
 	if s.getResolvedEpoch() == math.MaxInt64 {
@@ -612,6 +742,8 @@ func (s *Scheduler) trimDuties(epoch uint64) {
 	s.dutiesMutex.Lock()
 	defer s.dutiesMutex.Unlock()
 
+	delete(s.valsByEpoch, epoch)
+
 	duties := s.dutiesByEpoch[epoch]
 	if len(duties) == 0 {
 		return
@@ -692,28 +824,22 @@ func newSlotTicker(ctx context.Context, eth2Cl eth2wrap.Client, clock clockwork.
 // resolveActiveValidators returns the active validators (including their validator index) for the slot.
 func resolveActiveValidators(ctx context.Context, eth2Cl eth2wrap.Client, submitter metricSubmitter, epoch uint64,
 ) (validators, error) {
-	eth2Resp, err := eth2Cl.CompleteValidators(ctx)
+	states, err := eth2Cl.ValidatorStates(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp []validator
 
-	for index, val := range eth2Resp {
-		if val == nil || val.Validator == nil {
-			return nil, errors.New("validator data cannot be nil")
-		}
-
-		pubkey, err := core.PubKeyFromBytes(val.Validator.PublicKey[:])
+	for index, state := range states {
+		pubkey, err := core.PubKeyFromBytes(state.PubKey[:])
 		if err != nil {
 			return nil, err
 		}
 
-		submitter(pubkey, val.Balance, val.Status.String())
+		submitter(pubkey, state.Balance, state.Status.String())
 
-		// Check for active validators for the given epoch.
-		// The activation epoch needs to be checked in cases where this function is called before the epoch starts.
-		if !val.Status.IsActive() && val.Validator.ActivationEpoch != eth2p0.Epoch(epoch) {
+		if !state.ActiveOrActivating(eth2p0.Epoch(epoch)) {
 			continue
 		}
 
@@ -805,3 +931,13 @@ func (v validators) Indexes() []eth2p0.ValidatorIndex {
 
 	return resp
 }
+
+// PubKeys is a convenience function that extracts the public keys from the validators.
+func (v validators) PubKeys() []core.PubKey {
+	var resp []core.PubKey
+	for _, val := range v {
+		resp = append(resp, val.PubKey)
+	}
+
+	return resp
+}
@@ -11,6 +11,7 @@ import (
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
@@ -139,3 +140,67 @@ func TestResolvingEpoch(t *testing.T) {
 	require.False(t, sched.isResolvingEpoch(10))
 	require.True(t, sched.isResolvingEpoch(11))
 }
+
+func TestResolveNewValidators(t *testing.T) {
+	ctx := context.Background()
+	valSet := beaconmock.ValidatorSetA
+
+	eth2Cl, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithDeterministicAttesterDuties(0),
+		beaconmock.WithSlotsPerEpoch(4),
+	)
+	require.NoError(t, err)
+
+	// Only the first validator is active initially.
+	allVals := eth2Cl.CachedValidatorsFunc
+	eth2Cl.CachedValidatorsFunc = func(ctx context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
+		active, complete, err := allVals(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return eth2wrap.ActiveValidators{1: active[1]}, eth2wrap.CompleteValidators{1: complete[1]}, nil
+	}
+
+	sched, err := New(nil, &eth2Cl, false)
+	require.NoError(t, err)
+
+	newVals := make(chan []core.PubKey, 1)
+	sched.SubscribeNewValidators(func(_ context.Context, _ core.Slot, pubkeys []core.PubKey) error {
+		newVals <- pubkeys
+		return nil
+	})
+
+	slot := core.Slot{Slot: 0, SlotDuration: time.Second, SlotsPerEpoch: 4}
+
+	resolvedPubkeys := func() map[core.PubKey]bool {
+		resp := make(map[core.PubKey]bool)
+		for _, defSet := range sched.DutyDefinitions() {
+			for pubkey := range defSet {
+				resp[pubkey] = true
+			}
+		}
+
+		return resp
+	}
+
+	require.NoError(t, sched.resolveDuties(ctx, slot))
+	require.Len(t, resolvedPubkeys(), 1)
+
+	// Remaining validators are activated and resolved once the validator cache is refreshed.
+	eth2Cl.CachedValidatorsFunc = allVals
+
+	require.NoError(t, sched.ResolveNewValidators(ctx, slot))
+	require.Len(t, resolvedPubkeys(), len(valSet))
+
+	// Subscribers are notified of the newly active validators.
+	pubkeys := <-newVals
+	require.Len(t, pubkeys, len(valSet)-1)
+	require.NotContains(t, pubkeys, core.PubKeyFrom48Bytes(valSet[1].Validator.PublicKey))
+
+	// Resolving again is a no-op.
+	require.NoError(t, sched.ResolveNewValidators(ctx, slot))
+	require.Len(t, resolvedPubkeys(), len(valSet))
+	require.Empty(t, newVals)
+}
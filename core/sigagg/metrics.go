@@ -0,0 +1,25 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package sigagg
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	invalidPartialCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "sigagg",
+		Name:      "invalid_partial_total",
+		Help:      "Total number of invalid partial signatures excluded from aggregation by duty and peer",
+	}, []string{"duty", "peer"})
+
+	retriedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "sigagg",
+		Name:      "retried_total",
+		Help:      "Total number of aggregations retried excluding invalid partial signatures by duty",
+	}, []string{"duty"})
+)
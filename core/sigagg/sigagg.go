@@ -21,24 +21,45 @@ import (
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
 
+// Option configures the aggregator.
+type Option func(*Aggregator)
+
+// WithPartialVerification returns an option verifying each partial signature if the aggregate signature is invalid,
+// excluding invalid partial signatures and retrying aggregation if threshold valid partial signatures remain.
+// The peers of invalid partial signatures are reported by name, see peerName.
+func WithPartialVerification(verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error, peerName func(shareIdx int) string) Option {
+	return func(a *Aggregator) {
+		a.parVerifyFunc = verifyFunc
+		a.peerName = peerName
+	}
+}
+
 // New returns a new aggregator instance.
-func New(threshold int, verifyFunc func(context.Context, core.PubKey, core.SignedData) error) (*Aggregator, error) {
+func New(threshold int, verifyFunc func(context.Context, core.PubKey, core.SignedData) error, opts ...Option) (*Aggregator, error) {
 	if threshold <= 0 {
 		return nil, errors.New("invalid threshold", z.Int("threshold", threshold))
 	}
 
-	return &Aggregator{
+	a := &Aggregator{
 		threshold:  threshold,
 		verifyFunc: verifyFunc,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a, nil
 }
 
 // Aggregator aggregates *threshold* partial signed duty data objects
 // into an aggregated signed duty data object ready to be broadcasted.
 type Aggregator struct {
-	threshold  int
-	verifyFunc func(context.Context, core.PubKey, core.SignedData) error
-	subs       []func(context.Context, core.Duty, core.SignedDataSet) error
+	threshold     int
+	verifyFunc    func(context.Context, core.PubKey, core.SignedData) error
+	parVerifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error // Nil if partial verification is disabled.
+	peerName      func(shareIdx int) string
+	subs          []func(context.Context, core.Duty, core.SignedDataSet) error
 }
 
 // Subscribe registers a callback for aggregated signed duty data.
@@ -57,7 +78,7 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 	output := make(core.SignedDataSet)
 
 	for pubkey, parSigs := range set {
-		signed, err := a.aggregate(ctx, pubkey, parSigs)
+		signed, err := a.aggregate(ctx, duty, pubkey, parSigs)
 		if err != nil {
 			return errors.Wrap(err, "threshold aggregate", z.Any("pubkey", pubkey))
 		}
@@ -83,10 +104,54 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 	return nil
 }
 
-// aggregate threshold aggregates the partial signed data for a provided DV.
-func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, error) {
+// aggregate threshold aggregates the partial signed data for a provided DV. If the aggregate signature is invalid and
+// partial verification is enabled, invalid partial signatures are excluded and aggregation retried.
+func (a *Aggregator) aggregate(ctx context.Context, duty core.Duty, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, error) {
+	aggSig, verified, err := a.thresholdAggregate(ctx, pubkey, parSigs)
+	if err == nil || !verified || a.parVerifyFunc == nil {
+		return aggSig, err
+	}
+
+	valid := a.excludeInvalid(ctx, duty, pubkey, parSigs)
+	if len(valid) == len(parSigs) {
+		return nil, errors.Wrap(err, "no invalid partial signature identified")
+	} else if len(valid) < a.threshold {
+		return nil, errors.Wrap(err, "insufficient valid partial signatures",
+			z.Int("threshold", a.threshold), z.Int("valid", len(valid)))
+	}
+
+	log.Info(ctx, "Retrying aggregation excluding invalid partial signatures", z.Any("pubkey", pubkey), z.Int("valid", len(valid)))
+	retriedCounter.WithLabelValues(duty.Type.String()).Inc()
+
+	aggSig, _, err = a.thresholdAggregate(ctx, pubkey, valid)
+
+	return aggSig, err
+}
+
+// excludeInvalid returns the partial signed data with valid partial signatures, reporting the peers of invalid ones.
+func (a *Aggregator) excludeInvalid(ctx context.Context, duty core.Duty, pubkey core.PubKey, parSigs []core.ParSignedData) []core.ParSignedData {
+	var valid []core.ParSignedData
+	for _, parSig := range parSigs {
+		err := a.parVerifyFunc(ctx, duty, core.ParSignedDataSet{pubkey: parSig})
+		if err == nil {
+			valid = append(valid, parSig)
+			continue
+		}
+
+		peer := a.peerName(parSig.ShareIdx)
+		invalidPartialCounter.WithLabelValues(duty.Type.String(), peer).Inc()
+		log.Warn(ctx, "Excluding invalid partial signature from aggregation", err,
+			z.Any("pubkey", pubkey), z.Int("share_idx", parSig.ShareIdx), z.Str("peer", peer))
+	}
+
+	return valid
+}
+
+// thresholdAggregate threshold aggregates and verifies the partial signed data for a provided DV.
+// It returns true if the aggregate signature was verified, i.e., if a returned error is a verification error.
+func (a *Aggregator) thresholdAggregate(ctx context.Context, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, bool, error) {
 	if len(parSigs) < a.threshold {
-		return nil, errors.New("require threshold signatures")
+		return nil, false, errors.New("require threshold signatures")
 	}
 
 	// Get all partial signatures.
@@ -94,14 +159,14 @@ func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs
 	for _, parSig := range parSigs {
 		sig, err := tblsconv.SigFromCore(parSig.Signature())
 		if err != nil {
-			return nil, errors.Wrap(err, "signature from core")
+			return nil, false, errors.Wrap(err, "signature from core")
 		}
 
 		blsSigs[parSig.ShareIdx] = sig
 	}
 
 	if len(blsSigs) < a.threshold {
-		return nil, errors.New("number of partial signatures less than threshold", z.Int("threshold", a.threshold), z.Int("got", len(blsSigs)))
+		return nil, false, errors.New("number of partial signatures less than threshold", z.Int("threshold", a.threshold), z.Int("got", len(blsSigs)))
 	}
 
 	// Aggregate signatures
@@ -113,7 +178,7 @@ func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return nil, err
+		return nil, false, err
 	}
 
 	// Fix for validator index sent only by validator client and not peers.
@@ -140,19 +205,19 @@ func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return nil, err
+		return nil, false, err
 	}
 
 	if err := a.verifyFunc(ctx, pubkey, aggSig); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return nil, err
+		return nil, true, err
 	}
 
 	span.SetStatus(codes.Ok, "success")
 
-	return aggSig, nil
+	return aggSig, true, nil
 }
 
 // NewVerifier returns a signature verification function for aggregated signatures.
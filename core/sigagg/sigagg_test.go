@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/parsigex"
 	"github.com/obolnetwork/charon/core/sigagg"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
@@ -239,6 +240,114 @@ func TestSigAgg_DutyRandao(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSigAgg_ExcludeInvalidPartials(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		threshold = 3
+		peers     = 4
+		epoch     = 123
+	)
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	randao := core.NewSignedRandao(epoch, eth2p0.BLSSignature{})
+	randaoRoot, err := randao.MessageRoot()
+	require.NoError(t, err)
+
+	msg, err := signing.GetDataRoot(ctx, bmock, randao.DomainName(), epoch, randaoRoot)
+	require.NoError(t, err)
+
+	secretKey, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubKey, err := tbls.SecretToPublicKey(secretKey)
+	require.NoError(t, err)
+
+	secrets, err := tbls.ThresholdSplit(secretKey, peers, threshold)
+	require.NoError(t, err)
+
+	corruptKey, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	corePubkey := core.PubKeyFrom48Bytes(pubKey)
+	pubshares := make(map[int]tbls.PublicKey)
+
+	var parsigs []core.ParSignedData
+	for idx, secret := range secrets {
+		pubshares[idx], err = tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		if idx == 2 {
+			secret = corruptKey // Corrupt partial signature of share 2.
+		}
+
+		sig, err := tbls.Sign(secret, msg[:])
+		require.NoError(t, err)
+
+		parsigs = append(parsigs, core.NewPartialSignedRandao(epoch, tblsconv.SigToETH2(sig), idx))
+	}
+
+	parVerifyFunc, err := parsigex.NewEth2Verifier(bmock, map[core.PubKey]map[int]tbls.PublicKey{corePubkey: pubshares})
+	require.NoError(t, err)
+
+	var reported []int
+	peerName := func(shareIdx int) string {
+		reported = append(reported, shareIdx)
+		return "peer"
+	}
+
+	t.Run("threshold valid", func(t *testing.T) {
+		reported = nil
+
+		agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock), sigagg.WithPartialVerification(parVerifyFunc, peerName))
+		require.NoError(t, err)
+
+		var aggregated bool
+		agg.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+			sig, err := tblsconv.SigFromCore(set[corePubkey].Signature())
+			require.NoError(t, err)
+			require.NoError(t, tbls.Verify(pubKey, msg[:], sig))
+
+			aggregated = true
+
+			return nil
+		})
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, parsigs))
+		require.NoError(t, err)
+		require.True(t, aggregated)
+		require.Equal(t, []int{2}, reported)
+	})
+
+	t.Run("below threshold valid", func(t *testing.T) {
+		reported = nil
+
+		agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock), sigagg.WithPartialVerification(parVerifyFunc, peerName))
+		require.NoError(t, err)
+
+		var subset []core.ParSignedData
+		for _, parsig := range parsigs {
+			if parsig.ShareIdx != 1 { // Exclude a valid partial signature.
+				subset = append(subset, parsig)
+			}
+		}
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, subset))
+		require.ErrorContains(t, err, "insufficient valid partial signatures")
+		require.Equal(t, []int{2}, reported)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+		require.NoError(t, err)
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, parsigs))
+		require.ErrorContains(t, err, "aggregate signature verification failed")
+	})
+}
+
 func TestSigAgg_DutyExit(t *testing.T) {
 	ctx := context.Background()
 
@@ -333,28 +333,29 @@ func (p VersionedSignedProposal) SetSignature(sig Signature) (SignedData, error)
 	return resp, nil
 }
 
+// MessageRoot returns the memoized hash tree root of the block, see CachedHashTreeRoot.
 func (p VersionedSignedProposal) MessageRoot() ([32]byte, error) {
 	switch p.Version {
 	// No block nil checks since `NewVersionedSignedProposal` assumed.
 	case eth2spec.DataVersionPhase0:
-		return p.Phase0.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Phase0.Message)
 	case eth2spec.DataVersionAltair:
-		return p.Altair.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Altair.Message)
 	case eth2spec.DataVersionBellatrix:
 		if p.Blinded {
-			return p.BellatrixBlinded.Message.HashTreeRoot()
+			return CachedHashTreeRoot(p.BellatrixBlinded.Message)
 		}
 
-		return p.Bellatrix.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Bellatrix.Message)
 	case eth2spec.DataVersionCapella:
 		if p.Blinded {
-			return p.CapellaBlinded.Message.HashTreeRoot()
+			return CachedHashTreeRoot(p.CapellaBlinded.Message)
 		}
 
-		return p.Capella.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Capella.Message)
 	case eth2spec.DataVersionDeneb:
 		if p.Blinded {
-			return p.DenebBlinded.Message.HashTreeRoot()
+			return CachedHashTreeRoot(p.DenebBlinded.Message)
 		}
 		// if featureset.Enabled(featureset.GnosisBlockHotfix) {
 		// 	// translate p.Deneb.SignedBlock to its Gnosis associate and return its hash tree root
@@ -362,13 +363,13 @@ func (p VersionedSignedProposal) MessageRoot() ([32]byte, error) {
 		// 	return sbGnosis.HashTreeRoot()
 		// }
 
-		return p.Deneb.SignedBlock.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Deneb.SignedBlock.Message)
 	case eth2spec.DataVersionElectra:
 		if p.Blinded {
-			return p.ElectraBlinded.Message.HashTreeRoot()
+			return CachedHashTreeRoot(p.ElectraBlinded.Message)
 		}
 
-		return p.Electra.SignedBlock.Message.HashTreeRoot()
+		return CachedHashTreeRoot(p.Electra.SignedBlock.Message)
 	default:
 		panic("unknown version") // Note this is avoided by using `NewVersionedSignedProposal`.
 	}
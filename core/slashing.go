@@ -0,0 +1,49 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// slashableDuties are the duties blocked by the slashing breaker.
+var slashableDuties = map[DutyType]bool{
+	DutyProposer:        true,
+	DutyBuilderProposer: true,
+	DutyAttester:        true,
+	DutyAggregator:      true,
+}
+
+// WithSlashingBreaker wraps the validator API output to drop partial signed attestations and proposals
+// of validators blocked by the breaker function, preventing them from being broadcast.
+func WithSlashingBreaker(blocked func(context.Context, PubKey) bool) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+		w.ParSigDBStoreInternal = func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+			if !slashableDuties[duty.Type] {
+				return clone.ParSigDBStoreInternal(ctx, duty, set)
+			}
+
+			filtered := make(ParSignedDataSet)
+			for pubkey, data := range set {
+				if blocked(ctx, pubkey) {
+					log.Error(ctx, "Dropping partial signature of slashed validator, acknowledge to resume signing", nil,
+						z.Any("duty", duty), z.Any("pubkey", pubkey))
+
+					continue
+				}
+
+				filtered[pubkey] = data
+			}
+
+			if len(filtered) == 0 {
+				return nil
+			}
+
+			return clone.ParSigDBStoreInternal(ctx, duty, filtered)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSlashingBreaker(t *testing.T) {
+	var (
+		slashed = PubKeyFrom48Bytes([48]byte{1})
+		healthy = PubKeyFrom48Bytes([48]byte{2})
+		stored  []ParSignedDataSet
+	)
+
+	w := wireFuncs{
+		ParSigDBStoreInternal: func(_ context.Context, _ Duty, set ParSignedDataSet) error {
+			stored = append(stored, set)
+			return nil
+		},
+	}
+
+	WithSlashingBreaker(func(_ context.Context, pubkey PubKey) bool {
+		return pubkey == slashed
+	})(&w)
+
+	set := ParSignedDataSet{
+		slashed: ParSignedData{ShareIdx: 1},
+		healthy: ParSignedData{ShareIdx: 1},
+	}
+
+	// Slashed validator is dropped from attestations.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewAttesterDuty(1), set))
+	require.Len(t, stored, 1)
+	require.Contains(t, stored[0], healthy)
+	require.NotContains(t, stored[0], slashed)
+
+	// Nothing is stored if only slashed validators remain.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewProposerDuty(1), ParSignedDataSet{slashed: ParSignedData{ShareIdx: 1}}))
+	require.Len(t, stored, 1)
+
+	// Other duties are not blocked.
+	require.NoError(t, w.ParSigDBStoreInternal(t.Context(), NewVoluntaryExit(1), set))
+	require.Len(t, stored, 2)
+	require.Len(t, stored[1], 2)
+}
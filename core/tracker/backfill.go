@@ -0,0 +1,295 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/statecomm"
+)
+
+// Backfill duty results.
+const (
+	backfillIncluded = "included"
+	backfillMissed   = "missed"
+)
+
+// NewBackfiller returns a life cycle hook that reconstructs the on-chain outcome of the validators' attester and
+// proposer duties of the most recent completed epochs on startup, so metrics aren't blind to a restart window.
+func NewBackfiller(eth2Cl eth2wrap.Client, epochs uint64) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ctx = log.WithTopic(ctx, "tracker")
+
+		if err := backfill(ctx, eth2Cl, epochs, time.Now()); err != nil {
+			log.Warn(ctx, "Failed to backfill duty history", err)
+		}
+	}
+}
+
+// backfill reconstructs the on-chain outcome of the validators' duties of the epochs before the current epoch.
+func backfill(ctx context.Context, eth2Cl eth2wrap.Client, epochs uint64, now time.Time) error {
+	genesis, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	slotDuration, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	if now.Before(genesis) {
+		return nil
+	}
+
+	currentSlot := uint64(now.Sub(genesis) / slotDuration)
+	currentEpoch := currentSlot / slotsPerEpoch
+
+	if epochs == 0 || currentEpoch == 0 {
+		return nil
+	}
+
+	firstEpoch := currentEpoch - min(epochs, currentEpoch)
+
+	states, err := eth2Cl.ValidatorStates(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch validator states")
+	}
+
+	var indices []eth2p0.ValidatorIndex
+	for index := range states {
+		indices = append(indices, index)
+	}
+
+	if len(indices) == 0 {
+		return nil
+	}
+
+	counts := make(map[core.DutyType]map[string]int)
+	count := func(dutyType core.DutyType, result string) {
+		// Reuse the tracker's duty metrics so the epochs before startup are accounted like any other.
+		dutyExpect.WithLabelValues(dutyType.String()).Inc()
+		if result == backfillIncluded {
+			dutySuccess.WithLabelValues(dutyType.String()).Inc()
+		} else {
+			dutyFailed.WithLabelValues(dutyType.String()).Inc()
+		}
+
+		if counts[dutyType] == nil {
+			counts[dutyType] = make(map[string]int)
+		}
+
+		counts[dutyType][result]++
+	}
+
+	var (
+		proDuties []*eth2v1.ProposerDuty
+		attDuties []*eth2v1.AttesterDuty
+	)
+
+	for epoch := firstEpoch; epoch < currentEpoch; epoch++ {
+		proResp, err := eth2Cl.ProposerDuties(ctx, &eth2api.ProposerDutiesOpts{Epoch: eth2p0.Epoch(epoch), Indices: indices})
+		if err != nil {
+			return errors.Wrap(err, "fetch proposer duties", z.U64("epoch", epoch))
+		}
+
+		proDuties = append(proDuties, proResp.Data...)
+
+		attResp, err := eth2Cl.AttesterDuties(ctx, &eth2api.AttesterDutiesOpts{Epoch: eth2p0.Epoch(epoch), Indices: indices})
+		if err != nil {
+			return errors.Wrap(err, "fetch attester duties", z.U64("epoch", epoch))
+		}
+
+		attDuties = append(attDuties, attResp.Data...)
+	}
+
+	// Proposals are included at their slot and attestations after their slot up to the current slot.
+	incl, err := dutiesIncluded(ctx, eth2Cl, proDuties, attDuties, firstEpoch*slotsPerEpoch, currentSlot)
+	if err != nil {
+		return err
+	}
+
+	for _, duty := range proDuties {
+		if incl.proposals[duty] {
+			count(core.DutyProposer, backfillIncluded)
+		} else {
+			count(core.DutyProposer, backfillMissed)
+			log.Info(ctx, "Backfilled block proposal not included on-chain",
+				z.U64("slot", uint64(duty.Slot)), z.U64("vidx", uint64(duty.ValidatorIndex)))
+		}
+	}
+
+	for _, duty := range attDuties {
+		if incl.attestations[duty] {
+			count(core.DutyAttester, backfillIncluded)
+		} else {
+			count(core.DutyAttester, backfillMissed)
+			log.Info(ctx, "Backfilled attestation not included on-chain",
+				z.U64("slot", uint64(duty.Slot)), z.U64("vidx", uint64(duty.ValidatorIndex)))
+		}
+	}
+
+	log.Info(ctx, "Backfilled duty history",
+		z.U64("from_epoch", firstEpoch),
+		z.U64("to_epoch", currentEpoch-1),
+		z.Int("proposals_included", counts[core.DutyProposer][backfillIncluded]),
+		z.Int("proposals_missed", counts[core.DutyProposer][backfillMissed]),
+		z.Int("attestations_included", counts[core.DutyAttester][backfillIncluded]),
+		z.Int("attestations_missed", counts[core.DutyAttester][backfillMissed]),
+	)
+
+	return nil
+}
+
+// inclusions are the proposer and attester duties included on-chain.
+type inclusions struct {
+	proposals    map[*eth2v1.ProposerDuty]bool
+	attestations map[*eth2v1.AttesterDuty]bool
+}
+
+// dutiesIncluded returns the proposer and attester duties included in the canonical blocks of the slot range
+// (inclusive), fetching each block only once.
+func dutiesIncluded(ctx context.Context, eth2Cl eth2wrap.Client, proDuties []*eth2v1.ProposerDuty,
+	attDuties []*eth2v1.AttesterDuty, fromSlot, toSlot uint64,
+) (inclusions, error) {
+	proposersBySlot := make(map[eth2p0.Slot][]*eth2v1.ProposerDuty)
+	for _, duty := range proDuties {
+		proposersBySlot[duty.Slot] = append(proposersBySlot[duty.Slot], duty)
+	}
+
+	attestersBySlot := make(map[eth2p0.Slot][]*eth2v1.AttesterDuty)
+	for _, duty := range attDuties {
+		attestersBySlot[duty.Slot] = append(attestersBySlot[duty.Slot], duty)
+	}
+
+	committees := make(map[eth2p0.Slot][]*statecomm.StateCommittee)
+	committeesFunc := func(slot eth2p0.Slot) ([]*statecomm.StateCommittee, error) {
+		if comms, ok := committees[slot]; ok {
+			return comms, nil
+		}
+
+		comms, err := eth2Cl.BeaconStateCommittees(ctx, uint64(slot))
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch beacon committees", z.U64("slot", uint64(slot)))
+		}
+
+		committees[slot] = comms
+
+		return comms, nil
+	}
+
+	resp := inclusions{
+		proposals:    make(map[*eth2v1.ProposerDuty]bool),
+		attestations: make(map[*eth2v1.AttesterDuty]bool),
+	}
+
+	if len(proDuties) == 0 && len(attDuties) == 0 {
+		return resp, nil
+	}
+
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		block, err := eth2Cl.Block(ctx, strconv.FormatUint(slot, 10))
+		if err != nil {
+			return inclusions{}, errors.Wrap(err, "fetch block", z.U64("slot", slot))
+		} else if block == nil {
+			continue // Missed slot.
+		}
+
+		if duties := proposersBySlot[eth2p0.Slot(slot)]; len(duties) > 0 {
+			proposer, err := block.ProposerIndex()
+			if err != nil {
+				return inclusions{}, errors.Wrap(err, "get block proposer index")
+			}
+
+			for _, duty := range duties {
+				resp.proposals[duty] = proposer == duty.ValidatorIndex
+			}
+		}
+
+		atts, err := block.Attestations()
+		if err != nil {
+			return inclusions{}, errors.Wrap(err, "get block attestations", z.U64("slot", slot))
+		}
+
+		for _, att := range atts {
+			data, err := att.Data()
+			if err != nil {
+				return inclusions{}, errors.Wrap(err, "get attestation data")
+			}
+
+			for _, duty := range attestersBySlot[data.Slot] {
+				if resp.attestations[duty] {
+					continue
+				}
+
+				ok, err := attestationCovers(att, data, duty, committeesFunc)
+				if err != nil {
+					return inclusions{}, err
+				}
+
+				resp.attestations[duty] = ok
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// attestationCovers returns true if the (aggregate) attestation includes the attester duty's validator.
+func attestationCovers(att *eth2spec.VersionedAttestation, data *eth2p0.AttestationData, duty *eth2v1.AttesterDuty,
+	committeesFunc func(eth2p0.Slot) ([]*statecomm.StateCommittee, error),
+) (bool, error) {
+	aggBits, err := att.AggregationBits()
+	if err != nil {
+		return false, errors.Wrap(err, "get attestation aggregation bits")
+	}
+
+	if att.Version < eth2spec.DataVersionElectra {
+		if data.Index != duty.CommitteeIndex || duty.ValidatorCommitteeIndex >= aggBits.Len() {
+			return false, nil
+		}
+
+		return aggBits.BitAt(duty.ValidatorCommitteeIndex), nil
+	}
+
+	commBits, err := att.CommitteeBits()
+	if err != nil {
+		return false, errors.Wrap(err, "get attestation committee bits")
+	}
+
+	if !commBits.BitAt(uint64(duty.CommitteeIndex)) {
+		return false, nil
+	}
+
+	comms, err := committeesFunc(duty.Slot)
+	if err != nil {
+		return false, err
+	}
+
+	// Aggregation bits are the concatenation of the bits of all committees in the committee bits.
+	var offset uint64
+	for _, comm := range comms {
+		if comm.Slot == duty.Slot && comm.Index < duty.CommitteeIndex && commBits.BitAt(uint64(comm.Index)) {
+			offset += uint64(len(comm.Validators))
+		}
+	}
+
+	bit := offset + duty.ValidatorCommitteeIndex
+	if bit >= aggBits.Len() {
+		return false, nil
+	}
+
+	return aggBits.BitAt(bit), nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/eth2util/statecomm"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	genesis := time.Unix(1_600_000_000, 0)
+
+	bmock, err := beaconmock.New(
+		beaconmock.WithGenesisTime(genesis),
+		beaconmock.WithSlotDuration(time.Second),
+		beaconmock.WithSlotsPerEpoch(4),
+	)
+	require.NoError(t, err)
+
+	bmock.CachedValidatorsFunc = func(context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
+		return nil, eth2wrap.CompleteValidators{
+			1: {Index: 1, Validator: &eth2p0.Validator{}},
+			2: {Index: 2, Validator: &eth2p0.Validator{}},
+		}, nil
+	}
+
+	// Current slot 12 (epoch 3), backfilling epochs 1 and 2 (slots 4-11).
+	bmock.ProposerDutiesFunc = func(_ context.Context, epoch eth2p0.Epoch, _ []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error) {
+		switch epoch {
+		case 1:
+			return []*eth2v1.ProposerDuty{{Slot: 5, ValidatorIndex: 1}}, nil // Included
+		case 2:
+			return []*eth2v1.ProposerDuty{{Slot: 9, ValidatorIndex: 2}}, nil // Missed slot
+		default:
+			require.Fail(t, "unexpected epoch")
+			return nil, nil
+		}
+	}
+
+	bmock.AttesterDutiesFunc = func(_ context.Context, epoch eth2p0.Epoch, _ []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error) {
+		switch epoch {
+		case 1:
+			return []*eth2v1.AttesterDuty{
+				{Slot: 6, ValidatorIndex: 1, CommitteeIndex: 0, ValidatorCommitteeIndex: 1}, // Included pre-electra
+			}, nil
+		case 2:
+			return []*eth2v1.AttesterDuty{
+				{Slot: 10, ValidatorIndex: 2, CommitteeIndex: 1, ValidatorCommitteeIndex: 0}, // Included electra
+				{Slot: 10, ValidatorIndex: 1, CommitteeIndex: 0, ValidatorCommitteeIndex: 2}, // Missed
+			}, nil
+		default:
+			require.Fail(t, "unexpected epoch")
+			return nil, nil
+		}
+	}
+
+	fetched := make(map[string]int)
+	bmock.BlockFunc = func(_ context.Context, stateID string) (*eth2spec.VersionedSignedBeaconBlock, error) {
+		fetched[stateID]++
+
+		slot, err := strconv.ParseUint(stateID, 10, 64)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, slot, uint64(4))
+		require.LessOrEqual(t, slot, uint64(12))
+
+		switch slot {
+		case 5:
+			return denebBlock(5, 1), nil
+		case 7:
+			aggBits := bitfield.NewBitlist(4)
+			aggBits.SetBitAt(1, true)
+
+			block := denebBlock(7, 3)
+			block.Deneb.Message.Body.Attestations = []*eth2p0.Attestation{{
+				AggregationBits: aggBits,
+				Data:            &eth2p0.AttestationData{Slot: 6, Index: 0},
+			}}
+
+			return block, nil
+		case 9:
+			return nil, nil // Missed slot
+		case 11:
+			// Committee 0 (3 validators) and committee 1 (2 validators), only the first validator of committee 1 attested.
+			commBits := bitfield.NewBitvector64()
+			commBits.SetBitAt(0, true)
+			commBits.SetBitAt(1, true)
+
+			aggBits := bitfield.NewBitlist(5)
+			aggBits.SetBitAt(3, true)
+
+			return &eth2spec.VersionedSignedBeaconBlock{
+				Version: eth2spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{Message: &electra.BeaconBlock{
+					Slot:          11,
+					ProposerIndex: 3,
+					Body: &electra.BeaconBlockBody{Attestations: []*electra.Attestation{{
+						AggregationBits: aggBits,
+						CommitteeBits:   commBits,
+						Data:            &eth2p0.AttestationData{Slot: 10},
+					}}},
+				}},
+			}, nil
+		default:
+			return denebBlock(eth2p0.Slot(slot), 3), nil
+		}
+	}
+
+	bmock.BeaconStateCommitteesFunc = func(_ context.Context, slot uint64) ([]*statecomm.StateCommittee, error) {
+		require.Equal(t, uint64(10), slot)
+
+		return []*statecomm.StateCommittee{
+			{Index: 0, Slot: 10, Validators: []eth2p0.ValidatorIndex{1, 3, 4}},
+			{Index: 1, Slot: 10, Validators: []eth2p0.ValidatorIndex{2, 5}},
+		}, nil
+	}
+
+	proIncluded := promtestutil.ToFloat64(dutySuccess.WithLabelValues("proposer"))
+	proMissed := promtestutil.ToFloat64(dutyFailed.WithLabelValues("proposer"))
+	attIncluded := promtestutil.ToFloat64(dutySuccess.WithLabelValues("attester"))
+	attMissed := promtestutil.ToFloat64(dutyFailed.WithLabelValues("attester"))
+	attExpected := promtestutil.ToFloat64(dutyExpect.WithLabelValues("attester"))
+
+	require.NoError(t, backfill(ctx, bmock, 2, genesis.Add(12*time.Second)))
+
+	require.InDelta(t, proIncluded+1, promtestutil.ToFloat64(dutySuccess.WithLabelValues("proposer")), 0)
+	require.InDelta(t, proMissed+1, promtestutil.ToFloat64(dutyFailed.WithLabelValues("proposer")), 0)
+	require.InDelta(t, attIncluded+2, promtestutil.ToFloat64(dutySuccess.WithLabelValues("attester")), 0)
+	require.InDelta(t, attMissed+1, promtestutil.ToFloat64(dutyFailed.WithLabelValues("attester")), 0)
+	require.InDelta(t, attExpected+3, promtestutil.ToFloat64(dutyExpect.WithLabelValues("attester")), 0)
+
+	// Each block of the backfilled slots is fetched exactly once.
+	require.Len(t, fetched, 9)
+	for stateID, n := range fetched {
+		require.Equal(t, 1, n, stateID)
+	}
+
+	// Nothing to backfill in the first epoch.
+	require.NoError(t, backfill(ctx, bmock, 2, genesis.Add(3*time.Second)))
+	require.InDelta(t, proIncluded+1, promtestutil.ToFloat64(dutySuccess.WithLabelValues("proposer")), 0)
+}
+
+func denebBlock(slot eth2p0.Slot, proposer eth2p0.ValidatorIndex) *eth2spec.VersionedSignedBeaconBlock {
+	return &eth2spec.VersionedSignedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb: &deneb.SignedBeaconBlock{Message: &deneb.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			Body:          &deneb.BeaconBlockBody{},
+		}},
+	}
+}
@@ -78,9 +78,11 @@ type inclusionCore struct {
 	submissions     map[subkey]submission
 	stateCommittees map[eth2p0.Slot][]*statecomm.StateCommittee
 
-	trackerInclFunc trackerInclFunc
-	missedFunc      func(context.Context, submission)
-	attIncludedFunc func(context.Context, submission, block)
+	trackerInclFunc      trackerInclFunc
+	missedFunc           func(context.Context, submission)
+	attIncludedFunc      func(context.Context, submission, block)
+	proposalIncludedFunc func(context.Context, submission)
+	headerCache          *eth2wrap.HeaderCache // Nil if canonical block roots aren't cached.
 }
 
 // inclSupported defines duty types for which inclusion checks are supported.
@@ -263,6 +265,8 @@ func (i *inclusionCore) CheckBlock(ctx context.Context, slot uint64, found bool)
 					z.Any("pubkey", sub.Pubkey),
 					z.Any("broadcast_delay", sub.Delay),
 				)
+
+				i.proposalIncludedFunc(ctx, sub)
 			} else {
 				i.missedFunc(ctx, sub)
 			}
@@ -328,6 +332,8 @@ func (i *inclusionCore) CheckBlockAndAtts(ctx context.Context, block block) {
 				z.Any("broadcast_delay", sub.Delay),
 			)
 
+			i.proposalIncludedFunc(ctx, sub)
+
 			// Just report block inclusions to tracker and trim
 			i.trackerInclFunc(sub.Duty, sub.Pubkey, sub.Data, nil)
 			delete(i.submissions, key)
@@ -513,10 +519,46 @@ func reportAttInclusion(ctx context.Context, sub submission, block block) {
 	)
 
 	inclusionDelay.Set(float64(blockSlot - attSlot))
+	inclusionDistance.WithLabelValues(sub.Duty.Type.String()).Observe(float64(inclDelay))
+}
+
+// InclusionOption configures an InclusionChecker.
+type InclusionOption func(*inclusionCore)
+
+// WithInclusionPerformance returns an option recording attestation inclusion distances in the validator performance metrics.
+func WithInclusionPerformance(performance *Performance) InclusionOption {
+	return func(i *inclusionCore) {
+		attIncludedFunc := i.attIncludedFunc
+		i.attIncludedFunc = func(ctx context.Context, sub submission, block block) {
+			attIncludedFunc(ctx, sub, block)
+
+			if sub.Duty.Type == core.DutyAttester && block.Slot > sub.Duty.Slot {
+				performance.attestationIncluded(sub.Pubkey, sub.Duty.Slot, block.Slot-sub.Duty.Slot)
+			}
+		}
+	}
+}
+
+// WithInclusionRewards returns an option recording the realized execution rewards of included proposals.
+func WithInclusionRewards(rewards *Rewards) InclusionOption {
+	return func(i *inclusionCore) {
+		i.proposalIncludedFunc = func(ctx context.Context, sub submission) {
+			// Determining rewards queries the execution layer or relays, so don't block inclusion checks.
+			go rewards.proposalIncluded(ctx, sub)
+		}
+	}
+}
+
+// WithInclusionHeaderCache returns an option checking block proposals against the canonical block roots of the cache
+// before querying the beacon node.
+func WithInclusionHeaderCache(cache *eth2wrap.HeaderCache) InclusionOption {
+	return func(i *inclusionCore) {
+		i.headerCache = cache
+	}
 }
 
 // NewInclusion returns a new InclusionChecker.
-func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc trackerInclFunc) (*InclusionChecker, error) {
+func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc trackerInclFunc, opts ...InclusionOption) (*InclusionChecker, error) {
 	genesisTime, err := eth2wrap.FetchGenesisTime(ctx, eth2Cl)
 	if err != nil {
 		return nil, err
@@ -528,11 +570,16 @@ func NewInclusion(ctx context.Context, eth2Cl eth2wrap.Client, trackerInclFunc t
 	}
 
 	inclCore := &inclusionCore{
-		attIncludedFunc: reportAttInclusion,
-		missedFunc:      reportMissed,
-		trackerInclFunc: trackerInclFunc,
-		submissions:     make(map[subkey]submission),
-		stateCommittees: make(map[eth2p0.Slot][]*statecomm.StateCommittee),
+		attIncludedFunc:      reportAttInclusion,
+		proposalIncludedFunc: func(context.Context, submission) {},
+		missedFunc:           reportMissed,
+		trackerInclFunc:      trackerInclFunc,
+		submissions:          make(map[subkey]submission),
+		stateCommittees:      make(map[eth2p0.Slot][]*statecomm.StateCommittee),
+	}
+
+	for _, opt := range opts {
+		opt(inclCore)
 	}
 
 	return &InclusionChecker{
@@ -651,6 +698,14 @@ func (a *InclusionChecker) checkBlock(ctx context.Context, slot uint64, attDutie
 		return a.checkBlockAndAtts(ctx, slot, attDuties)
 	}
 
+	// Blocks of the slot seen as head are included, only unknown slots are queried.
+	if a.core.headerCache != nil {
+		if _, ok := a.core.headerCache.Root(eth2p0.Slot(slot)); ok {
+			a.checkBlockFunc(ctx, slot, true)
+			return nil
+		}
+	}
+
 	block, err := a.eth2Cl.Block(ctx, strconv.FormatUint(slot, 10))
 	if err != nil {
 		return err
@@ -230,7 +230,7 @@ func TestDuplicateAttData(t *testing.T) {
 func TestInclusion(t *testing.T) {
 	featureset.EnableForT(t, featureset.AttestationInclusion)
 	// Setup inclusion with a mock missedFunc and attIncludedFunc
-	var missed, included []core.Duty
+	var missed, included, proposed []core.Duty
 
 	incl := &inclusionCore{
 		missedFunc: func(ctx context.Context, sub submission) {
@@ -239,6 +239,9 @@ func TestInclusion(t *testing.T) {
 		attIncludedFunc: func(ctx context.Context, sub submission, block block) {
 			included = append(included, sub.Duty)
 		},
+		proposalIncludedFunc: func(ctx context.Context, sub submission) {
+			proposed = append(proposed, sub.Duty)
+		},
 		trackerInclFunc: func(duty core.Duty, key core.PubKey, data core.SignedData, err error) {},
 		submissions:     make(map[subkey]submission),
 	}
@@ -301,6 +304,7 @@ func TestInclusion(t *testing.T) {
 	// Assert that the 1st and 2nd duty was included
 	duties := []core.Duty{att1Duty, agg2Duty, att3Duty}
 	require.ElementsMatch(t, included, duties)
+	require.Equal(t, []core.Duty{block4Duty}, proposed)
 }
 
 func addRandomBits(list bitfield.Bitlist) {
@@ -311,12 +315,15 @@ func addRandomBits(list bitfield.Bitlist) {
 
 func TestBlockInclusion(t *testing.T) {
 	t.Run("block found", func(t *testing.T) {
-		var missed []core.Duty
+		var missed, proposed []core.Duty
 
 		incl := &inclusionCore{
 			missedFunc: func(ctx context.Context, sub submission) {
 				missed = append(missed, sub.Duty)
 			},
+			proposalIncludedFunc: func(ctx context.Context, sub submission) {
+				proposed = append(proposed, sub.Duty)
+			},
 			trackerInclFunc: func(duty core.Duty, key core.PubKey, data core.SignedData, err error) {},
 			submissions:     make(map[subkey]submission),
 		}
@@ -333,6 +340,7 @@ func TestBlockInclusion(t *testing.T) {
 
 		incl.CheckBlock(context.Background(), blockDuty.Slot, true)
 		require.Empty(t, missed)
+		require.Equal(t, []core.Duty{blockDuty}, proposed)
 	})
 
 	t.Run("block not found", func(t *testing.T) {
@@ -410,3 +418,36 @@ func TestBlockInclusion(t *testing.T) {
 		require.Empty(t, missed)
 	})
 }
+
+func TestCheckBlockHeaderCache(t *testing.T) {
+	ctx := t.Context()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var queried []string
+	bmock.BlockFunc = func(_ context.Context, stateID string) (*eth2spec.VersionedSignedBeaconBlock, error) {
+		queried = append(queried, stateID)
+		return nil, nil
+	}
+
+	cache := eth2wrap.NewHeaderCache(32, eth2wrap.DefaultHeaderCacheSize)
+	cache.HeadReceived(ctx, 10, testutil.RandomRoot())
+
+	noopTrackerInclFunc := func(duty core.Duty, key core.PubKey, data core.SignedData, err error) {}
+
+	incl, err := NewInclusion(ctx, bmock, noopTrackerInclFunc, WithInclusionHeaderCache(cache))
+	require.NoError(t, err)
+
+	found := make(map[uint64]bool)
+	incl.checkBlockFunc = func(_ context.Context, slot uint64, ok bool) {
+		found[slot] = ok
+	}
+
+	// Slots seen as head aren't queried.
+	require.NoError(t, incl.checkBlock(ctx, 10, nil))
+	require.NoError(t, incl.checkBlock(ctx, 11, nil))
+
+	require.Equal(t, map[uint64]bool{10: true, 11: false}, found)
+	require.Equal(t, []string{"11"}, queried)
+}
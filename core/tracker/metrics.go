@@ -96,10 +96,67 @@ var (
 		Help:      "Cluster's average attestation inclusion delay in slots. Available only when attestation_inclusion feature flag is enabled.",
 	})
 
+	inclusionDistance = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "inclusion_distance_slots",
+		Help:      "Distribution of attestation inclusion distances in slots by duty type. Available only when attestation_inclusion feature flag is enabled.",
+		Buckets:   []float64{1, 2, 3, 4, 8, 16, 32},
+	}, []string{"duty"})
+
 	inclusionMisses = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "tracker",
 		Name:      "inclusion_missed_total",
 		Help:      "Total number of broadcast duties never included in any block by type",
 	}, []string{"duty"})
+
+	scoreboardGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "scoreboard_participations",
+		Help:      "Number of on-time, late and missing participations by peer in the most recent duties by type and stage (consensus or parsig_ex)",
+	}, []string{"duty", "stage", "peer", "status"})
+
+	scoreboardScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "scoreboard_score",
+		Help:      "Ratio of on-time participations by peer in the most recent duties by type and stage (consensus or parsig_ex)",
+	}, []string{"duty", "stage", "peer"})
+
+	attHitRateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "validator_attestation_hit_rate",
+		Help:      "Ratio of successful attestations by validator over the trailing 225 epochs. Attestations are successful if included on-chain when the attestation_inclusion feature flag is enabled, else if broadcast.",
+	}, []string{"pubkey_full", "pubkey"})
+
+	avgInclDistanceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "validator_inclusion_distance_avg",
+		Help:      "Average attestation inclusion distance in slots by validator over the trailing 225 epochs. Available only when attestation_inclusion feature flag is enabled.",
+	}, []string{"pubkey_full", "pubkey"})
+
+	proposalsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "validator_proposals",
+		Help:      "Number of block proposals by validator and result (assigned or made) over the trailing 225 epochs",
+	}, []string{"pubkey_full", "pubkey", "result"})
+
+	syncParticipationGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "validator_sync_participation_rate",
+		Help:      "Ratio of broadcast sync committee messages by validator over the trailing 225 epochs",
+	}, []string{"pubkey_full", "pubkey"})
+
+	proposalRewardsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "tracker",
+		Name:      "validator_proposal_rewards_gwei_total",
+		Help:      "Total realized execution rewards in gwei, i.e. priority fees and MEV payments, of proposals included on-chain by validator and source (fee_recipient_balance or relay)",
+	}, []string{"pubkey_full", "pubkey", "source"})
 )
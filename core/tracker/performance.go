@@ -0,0 +1,204 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"sync"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+// performanceWindowEpochs is the number of trailing epochs considered by the validator performance metrics, about a day.
+const performanceWindowEpochs = 225
+
+// epochPerformance is a validator's duty outcomes of a single epoch.
+type epochPerformance struct {
+	AttAssigned   int
+	AttHits       int
+	InclDistSum   uint64
+	InclDistCount int
+	PropAssigned  int
+	PropMade      int
+	SyncAssigned  int
+	SyncHits      int
+}
+
+// validatorPerformance is a validator's effectiveness over the trailing window of epochs.
+type validatorPerformance struct {
+	AttHitRate           float64
+	AvgInclusionDistance float64
+	ProposalsAssigned    int
+	ProposalsMade        int
+	SyncParticipation    float64
+}
+
+// NewPerformance returns a new validator performance tracker.
+func NewPerformance(slotsPerEpoch uint64) *Performance {
+	return &Performance{
+		slotsPerEpoch: slotsPerEpoch,
+		validators:    make(map[core.PubKey]map[uint64]*epochPerformance),
+	}
+}
+
+// Performance computes per-validator effectiveness metrics over the trailing window of epochs from the tracker's
+// duty outcomes and inclusion data: attestation hit rate, average inclusion distance, proposals made vs assigned
+// and sync committee participation rate.
+type Performance struct {
+	slotsPerEpoch uint64
+
+	mu         sync.Mutex
+	latest     uint64                                       // Latest epoch seen.
+	validators map[core.PubKey]map[uint64]*epochPerformance // Duty outcomes by epoch by validator.
+}
+
+// addDuty records the outcome of each validator of the analysed duty. Validators with any event are
+// considered assigned; their duty was successful if the last step of the duty type succeeded.
+func (p *Performance) addDuty(duty core.Duty, events []event) {
+	if duty.Type != core.DutyAttester && duty.Type != core.DutyProposer && duty.Type != core.DutySyncMessage {
+		return
+	}
+
+	success := make(map[core.PubKey]bool)
+	for _, e := range events {
+		if e.pubkey == "" {
+			continue
+		}
+
+		if e.step == lastStep(duty.Type) && e.stepErr == nil {
+			success[e.pubkey] = true
+		} else if _, ok := success[e.pubkey]; !ok {
+			success[e.pubkey] = false
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for pubkey, ok := range success {
+		perf := p.epochLocked(pubkey, duty.Slot)
+		if perf == nil {
+			continue
+		}
+
+		switch duty.Type {
+		case core.DutyAttester:
+			perf.AttAssigned++
+			if ok {
+				perf.AttHits++
+			}
+		case core.DutyProposer:
+			perf.PropAssigned++
+			if ok {
+				perf.PropMade++
+			}
+		case core.DutySyncMessage:
+			perf.SyncAssigned++
+			if ok {
+				perf.SyncHits++
+			}
+		default:
+		}
+
+		p.instrumentLocked(pubkey)
+	}
+}
+
+// attestationIncluded records the inclusion distance of the validator's attestation of the slot.
+func (p *Performance) attestationIncluded(pubkey core.PubKey, slot uint64, distance uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	perf := p.epochLocked(pubkey, slot)
+	if perf == nil {
+		return
+	}
+
+	perf.InclDistSum += distance
+	perf.InclDistCount++
+
+	p.instrumentLocked(pubkey)
+}
+
+// epochLocked returns the validator's performance of the slot's epoch, trimming epochs outside the window.
+// It returns nil if the epoch is outside the window. It must be called with the lock held.
+func (p *Performance) epochLocked(pubkey core.PubKey, slot uint64) *epochPerformance {
+	epoch := slot / max(p.slotsPerEpoch, 1)
+	if epoch+performanceWindowEpochs <= p.latest {
+		return nil
+	}
+
+	if epoch > p.latest {
+		p.latest = epoch
+		for _, epochs := range p.validators {
+			for e := range epochs {
+				if e+performanceWindowEpochs <= p.latest {
+					delete(epochs, e)
+				}
+			}
+		}
+	}
+
+	epochs, ok := p.validators[pubkey]
+	if !ok {
+		epochs = make(map[uint64]*epochPerformance)
+		p.validators[pubkey] = epochs
+	}
+
+	perf, ok := epochs[epoch]
+	if !ok {
+		perf = new(epochPerformance)
+		epochs[epoch] = perf
+	}
+
+	return perf
+}
+
+// validator returns the validator's performance over the trailing window of epochs.
+func (p *Performance) validator(pubkey core.PubKey) validatorPerformance {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.validatorLocked(pubkey)
+}
+
+// validatorLocked returns the validator's performance. It must be called with the lock held.
+func (p *Performance) validatorLocked(pubkey core.PubKey) validatorPerformance {
+	var sum epochPerformance
+	for _, perf := range p.validators[pubkey] {
+		sum.AttAssigned += perf.AttAssigned
+		sum.AttHits += perf.AttHits
+		sum.InclDistSum += perf.InclDistSum
+		sum.InclDistCount += perf.InclDistCount
+		sum.PropAssigned += perf.PropAssigned
+		sum.PropMade += perf.PropMade
+		sum.SyncAssigned += perf.SyncAssigned
+		sum.SyncHits += perf.SyncHits
+	}
+
+	ratio := func(a int, b int) float64 {
+		if b == 0 {
+			return 0
+		}
+
+		return float64(a) / float64(b)
+	}
+
+	return validatorPerformance{
+		AttHitRate:           ratio(sum.AttHits, sum.AttAssigned),
+		AvgInclusionDistance: ratio(int(sum.InclDistSum), sum.InclDistCount),
+		ProposalsAssigned:    sum.PropAssigned,
+		ProposalsMade:        sum.PropMade,
+		SyncParticipation:    ratio(sum.SyncHits, sum.SyncAssigned),
+	}
+}
+
+// instrumentLocked updates the validator's performance metrics. It must be called with the lock held.
+func (p *Performance) instrumentLocked(pubkey core.PubKey) {
+	perf := p.validatorLocked(pubkey)
+
+	attHitRateGauge.WithLabelValues(string(pubkey), pubkey.String()).Set(perf.AttHitRate)
+	avgInclDistanceGauge.WithLabelValues(string(pubkey), pubkey.String()).Set(perf.AvgInclusionDistance)
+	proposalsGauge.WithLabelValues(string(pubkey), pubkey.String(), "assigned").Set(float64(perf.ProposalsAssigned))
+	proposalsGauge.WithLabelValues(string(pubkey), pubkey.String(), "made").Set(float64(perf.ProposalsMade))
+	syncParticipationGauge.WithLabelValues(string(pubkey), pubkey.String()).Set(perf.SyncParticipation)
+}
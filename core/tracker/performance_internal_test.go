@@ -0,0 +1,89 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestPerformance(t *testing.T) {
+	const slotsPerEpoch = 16
+
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+
+	perf := NewPerformance(slotsPerEpoch)
+
+	// Both validators attest in slot 1, only pubkey1 succeeds.
+	attester := core.NewAttesterDuty(1)
+	perf.addDuty(attester, []event{
+		{duty: attester, step: fetcher, pubkey: pubkey1},
+		{duty: attester, step: fetcher, pubkey: pubkey2},
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey1},
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey2, stepErr: errors.New("failed")},
+	})
+	perf.attestationIncluded(pubkey1, 1, 1)
+
+	// Both validators attest in slot 17, both succeed.
+	attester = core.NewAttesterDuty(17)
+	perf.addDuty(attester, []event{
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey1},
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey2},
+	})
+	perf.attestationIncluded(pubkey1, 17, 3)
+
+	// pubkey1 is assigned two proposals, only one made.
+	for slot, err := range map[uint64]error{2: nil, 3: errors.New("missed")} {
+		proposer := core.NewProposerDuty(slot)
+		perf.addDuty(proposer, []event{
+			{duty: proposer, step: fetcher, pubkey: pubkey1},
+			{duty: proposer, step: chainInclusion, pubkey: pubkey1, stepErr: err},
+		})
+	}
+
+	// pubkey2 broadcasts a sync committee message.
+	syncMsg := core.NewSyncMessageDuty(4)
+	perf.addDuty(syncMsg, []event{
+		{duty: syncMsg, step: bcast, pubkey: pubkey2},
+	})
+
+	require.Equal(t, validatorPerformance{
+		AttHitRate:           1,
+		AvgInclusionDistance: 2,
+		ProposalsAssigned:    2,
+		ProposalsMade:        1,
+	}, perf.validator(pubkey1))
+
+	require.Equal(t, validatorPerformance{
+		AttHitRate:        0.5,
+		SyncParticipation: 1,
+	}, perf.validator(pubkey2))
+
+	// Epochs outside the trailing window are trimmed.
+	attester = core.NewAttesterDuty(slotsPerEpoch * performanceWindowEpochs)
+	perf.addDuty(attester, []event{
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey2},
+	})
+
+	require.Equal(t, validatorPerformance{
+		AttHitRate:           1,
+		AvgInclusionDistance: 3,
+	}, perf.validator(pubkey1))
+
+	require.Equal(t, validatorPerformance{
+		AttHitRate: 1,
+	}, perf.validator(pubkey2))
+
+	// Duties older than the window are ignored.
+	attester = core.NewAttesterDuty(1)
+	perf.addDuty(attester, []event{
+		{duty: attester, step: lastStep(core.DutyAttester), pubkey: pubkey2, stepErr: errors.New("failed")},
+	})
+	require.InDelta(t, 1, perf.validator(pubkey2).AttHitRate, 0)
+}
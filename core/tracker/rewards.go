@@ -0,0 +1,351 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// rewardMonthFormat is the format of the calendar months (in UTC) rewards are reported by.
+const rewardMonthFormat = "2006-01"
+
+// weiPerGwei converts wei to gwei.
+var weiPerGwei = big.NewInt(1e9)
+
+// ExecutionPayload identifies the execution payload of a proposal included on-chain.
+type ExecutionPayload struct {
+	BlockNumber  uint64
+	BlockHash    eth2p0.Hash32
+	FeeRecipient string
+	// Blinded is true if the payload was built by a builder, i.e. delivered by a relay.
+	Blinded bool
+	// Withdrawals are the consensus layer withdrawals of the payload, not known for blinded payloads.
+	Withdrawals []Withdrawal
+}
+
+// Withdrawal is a consensus layer withdrawal processed by an execution payload.
+type Withdrawal struct {
+	Address    string
+	AmountGwei uint64
+}
+
+// RewardFunc returns the realized execution reward in wei of the execution payload proposed by the validator
+// at the slot and the source it was determined from.
+type RewardFunc func(ctx context.Context, pubkey core.PubKey, slot uint64, payload ExecutionPayload) (*big.Int, string, error)
+
+// ProposalReward is the realized execution reward of a proposal made by the cluster.
+type ProposalReward struct {
+	Slot         uint64      `json:"slot"`
+	Time         time.Time   `json:"time"`
+	Pubkey       core.PubKey `json:"pubkey"`
+	Blinded      bool        `json:"blinded"`
+	BlockNumber  uint64      `json:"block_number"`
+	BlockHash    string      `json:"block_hash"`
+	FeeRecipient string      `json:"fee_recipient"`
+	RewardWei    string      `json:"reward_wei"`
+	Source       string      `json:"source"`
+}
+
+// MonthlyReward is a validator's aggregate realized execution rewards of a calendar month.
+type MonthlyReward struct {
+	Pubkey    core.PubKey `json:"pubkey"`
+	Month     string      `json:"month"`
+	Proposals int         `json:"proposals"`
+	RewardWei string      `json:"reward_wei"`
+}
+
+// RewardsReport is the execution rewards report of the cluster's proposals.
+type RewardsReport struct {
+	Monthly   []MonthlyReward  `json:"monthly"`
+	Proposals []ProposalReward `json:"proposals"`
+}
+
+// NewRewards returns a new proposal rewards tracker. If file is not empty, previously recorded
+// rewards are loaded from it and new rewards are appended to it, preserving them across restarts.
+func NewRewards(genesis time.Time, slotDuration time.Duration, rewardFunc RewardFunc, file string) (*Rewards, error) {
+	r := &Rewards{
+		genesis:      genesis,
+		slotDuration: slotDuration,
+		rewardFunc:   rewardFunc,
+		file:         file,
+	}
+
+	if file == "" {
+		return r, nil
+	}
+
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "open proposal rewards file")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var reward ProposalReward
+		if err := json.Unmarshal(scanner.Bytes(), &reward); err != nil {
+			return nil, errors.Wrap(err, "unmarshal proposal reward", z.Str("file", file))
+		}
+
+		r.proposals = append(r.proposals, reward)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read proposal rewards file")
+	}
+
+	return r, nil
+}
+
+// Rewards tracks the realized execution rewards, i.e. priority fees and MEV payments, of each proposal
+// made by the cluster and included on-chain, reporting them by validator and month.
+type Rewards struct {
+	genesis      time.Time
+	slotDuration time.Duration
+	rewardFunc   RewardFunc
+	file         string
+
+	mu        sync.Mutex
+	proposals []ProposalReward
+}
+
+// proposalIncluded determines and records the realized execution reward of the proposal included on-chain.
+func (r *Rewards) proposalIncluded(ctx context.Context, sub submission) {
+	proposal, ok := sub.Data.(core.VersionedSignedProposal)
+	if !ok {
+		return
+	}
+
+	payload, ok := executionPayload(proposal)
+	if !ok {
+		return // Pre-merge proposal or missing payload.
+	}
+
+	reward, source, err := r.rewardFunc(ctx, sub.Pubkey, sub.Duty.Slot, payload)
+	if err != nil {
+		log.Warn(ctx, "Failed determining proposal execution reward", err, z.U64("slot", sub.Duty.Slot), z.Any("pubkey", sub.Pubkey))
+		return
+	}
+
+	proposalReward := ProposalReward{
+		Slot:         sub.Duty.Slot,
+		Time:         r.genesis.Add(r.slotDuration * time.Duration(sub.Duty.Slot)).UTC(),
+		Pubkey:       sub.Pubkey,
+		Blinded:      proposal.Blinded,
+		BlockNumber:  payload.BlockNumber,
+		BlockHash:    payload.BlockHash.String(),
+		FeeRecipient: payload.FeeRecipient,
+		RewardWei:    reward.String(),
+		Source:       source,
+	}
+
+	if err := r.add(proposalReward); err != nil {
+		log.Warn(ctx, "Failed recording proposal execution reward", err, z.U64("slot", sub.Duty.Slot))
+	}
+
+	gwei, _ := new(big.Int).Quo(reward, weiPerGwei).Float64()
+	proposalRewardsCounter.WithLabelValues(string(sub.Pubkey), sub.Pubkey.String(), source).Add(gwei)
+
+	log.Info(ctx, "Proposal execution reward determined",
+		z.U64("slot", sub.Duty.Slot),
+		z.Any("pubkey", sub.Pubkey),
+		z.Str("reward_wei", proposalReward.RewardWei),
+		z.Str("source", source),
+	)
+}
+
+// add records the proposal reward, appending it to the file if configured.
+func (r *Rewards) add(reward ProposalReward) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.proposals = append(r.proposals, reward)
+
+	if r.file == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(reward)
+	if err != nil {
+		return errors.Wrap(err, "marshal proposal reward")
+	}
+
+	f, err := os.OpenFile(r.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "open proposal rewards file")
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "write proposal rewards file")
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close proposal rewards file")
+	}
+
+	return nil
+}
+
+// Report returns the rewards report, optionally filtered by month ("2006-01") and validator.
+func (r *Rewards) Report(month string, pubkey core.PubKey) RewardsReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type key struct {
+		Pubkey core.PubKey
+		Month  string
+	}
+
+	var (
+		resp    RewardsReport
+		monthly = make(map[key]*MonthlyReward)
+		totals  = make(map[key]*big.Int)
+	)
+
+	for _, proposal := range r.proposals {
+		proposalMonth := proposal.Time.UTC().Format(rewardMonthFormat)
+		if (month != "" && proposalMonth != month) || (pubkey != "" && proposal.Pubkey != pubkey) {
+			continue
+		}
+
+		resp.Proposals = append(resp.Proposals, proposal)
+
+		reward, ok := new(big.Int).SetString(proposal.RewardWei, 10)
+		if !ok {
+			continue
+		}
+
+		k := key{Pubkey: proposal.Pubkey, Month: proposalMonth}
+		if _, ok := monthly[k]; !ok {
+			monthly[k] = &MonthlyReward{Pubkey: proposal.Pubkey, Month: proposalMonth}
+			totals[k] = new(big.Int)
+		}
+
+		monthly[k].Proposals++
+		totals[k].Add(totals[k], reward)
+	}
+
+	for k, m := range monthly {
+		m.RewardWei = totals[k].String()
+		resp.Monthly = append(resp.Monthly, *m)
+	}
+
+	slices.SortFunc(resp.Monthly, func(a, b MonthlyReward) int {
+		return cmp.Or(cmp.Compare(a.Month, b.Month), cmp.Compare(a.Pubkey, b.Pubkey))
+	})
+
+	return resp
+}
+
+// ServeHTTP serves the rewards report as JSON, optionally filtered by the "month" (e.g. 2025-01) and "pubkey" query parameters.
+func (r *Rewards) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	month := req.URL.Query().Get("month")
+	if month != "" {
+		if _, err := time.Parse(rewardMonthFormat, month); err != nil {
+			http.Error(w, "invalid month, expected YYYY-MM", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pubkey := core.PubKey(strings.ToLower(req.URL.Query().Get("pubkey")))
+	if _, err := pubkey.Bytes(); pubkey != "" && err != nil {
+		http.Error(w, "invalid pubkey", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(r.Report(month, pubkey)); err != nil {
+		log.Warn(req.Context(), "Failed writing proposal rewards report", err)
+	}
+}
+
+// executionPayload returns the execution payload identifiers of the proposal, or false if it has none.
+func executionPayload(proposal core.VersionedSignedProposal) (ExecutionPayload, bool) {
+	var (
+		blockNumber  uint64
+		blockHash    eth2p0.Hash32
+		feeRecipient fmt.Stringer
+		withdrawals  []*capella.Withdrawal
+	)
+
+	switch proposal.Version {
+	case eth2spec.DataVersionBellatrix:
+		if proposal.Blinded {
+			header := proposal.BellatrixBlinded.Message.Body.ExecutionPayloadHeader
+			blockNumber, blockHash, feeRecipient = header.BlockNumber, header.BlockHash, header.FeeRecipient
+		} else {
+			payload := proposal.Bellatrix.Message.Body.ExecutionPayload
+			blockNumber, blockHash, feeRecipient = payload.BlockNumber, payload.BlockHash, payload.FeeRecipient
+		}
+	case eth2spec.DataVersionCapella:
+		if proposal.Blinded {
+			header := proposal.CapellaBlinded.Message.Body.ExecutionPayloadHeader
+			blockNumber, blockHash, feeRecipient = header.BlockNumber, header.BlockHash, header.FeeRecipient
+		} else {
+			payload := proposal.Capella.Message.Body.ExecutionPayload
+			blockNumber, blockHash, feeRecipient, withdrawals = payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.Withdrawals
+		}
+	case eth2spec.DataVersionDeneb:
+		if proposal.Blinded {
+			header := proposal.DenebBlinded.Message.Body.ExecutionPayloadHeader
+			blockNumber, blockHash, feeRecipient = header.BlockNumber, header.BlockHash, header.FeeRecipient
+		} else {
+			payload := proposal.Deneb.SignedBlock.Message.Body.ExecutionPayload
+			blockNumber, blockHash, feeRecipient, withdrawals = payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.Withdrawals
+		}
+	case eth2spec.DataVersionElectra:
+		if proposal.Blinded {
+			header := proposal.ElectraBlinded.Message.Body.ExecutionPayloadHeader
+			blockNumber, blockHash, feeRecipient = header.BlockNumber, header.BlockHash, header.FeeRecipient
+		} else {
+			payload := proposal.Electra.SignedBlock.Message.Body.ExecutionPayload
+			blockNumber, blockHash, feeRecipient, withdrawals = payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.Withdrawals
+		}
+	default:
+		return ExecutionPayload{}, false
+	}
+
+	resp := ExecutionPayload{
+		BlockNumber:  blockNumber,
+		BlockHash:    blockHash,
+		FeeRecipient: feeRecipient.String(),
+		Blinded:      proposal.Blinded,
+	}
+
+	for _, withdrawal := range withdrawals {
+		resp.Withdrawals = append(resp.Withdrawals, Withdrawal{
+			Address:    withdrawal.Address.String(),
+			AmountGwei: uint64(withdrawal.Amount),
+		})
+	}
+
+	return resp, true
+}
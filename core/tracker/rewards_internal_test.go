@@ -0,0 +1,109 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestExecutionPayload(t *testing.T) {
+	full := testutil.RandomElectraCoreVersionedSignedProposal()
+	payload, ok := executionPayload(full)
+	require.True(t, ok)
+	require.Equal(t, uint64(full.Electra.SignedBlock.Message.Body.ExecutionPayload.BlockNumber), payload.BlockNumber)
+	require.Equal(t, full.Electra.SignedBlock.Message.Body.ExecutionPayload.BlockHash, payload.BlockHash)
+	require.Equal(t, full.Electra.SignedBlock.Message.Body.ExecutionPayload.FeeRecipient.String(), payload.FeeRecipient)
+
+	blinded := testutil.RandomDenebVersionedSignedBlindedProposal()
+	payload, ok = executionPayload(blinded)
+	require.True(t, ok)
+	require.Equal(t, uint64(blinded.DenebBlinded.Message.Body.ExecutionPayloadHeader.BlockNumber), payload.BlockNumber)
+	require.Equal(t, blinded.DenebBlinded.Message.Body.ExecutionPayloadHeader.BlockHash, payload.BlockHash)
+
+	_, ok = executionPayload(core.VersionedSignedProposal{
+		VersionedSignedProposal: eth2api.VersionedSignedProposal{Version: eth2spec.DataVersionPhase0},
+	})
+	require.False(t, ok)
+}
+
+func TestRewards(t *testing.T) {
+	// Slot 0 is in January and slot 2 in February.
+	genesis := time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC)
+	file := filepath.Join(t.TempDir(), "rewards.jsonl")
+
+	rewardFunc := func(_ context.Context, _ core.PubKey, slot uint64, _ ExecutionPayload) (*big.Int, string, error) {
+		return big.NewInt(int64(slot+1) * 1e9), "test", nil
+	}
+
+	rewards, err := NewRewards(genesis, time.Hour, rewardFunc, file)
+	require.NoError(t, err)
+
+	pubkey1 := testutil.RandomCorePubKey(t)
+	pubkey2 := testutil.RandomCorePubKey(t)
+
+	for _, sub := range []submission{
+		{Duty: core.NewProposerDuty(0), Pubkey: pubkey1, Data: testutil.RandomElectraCoreVersionedSignedProposal()},
+		{Duty: core.NewProposerDuty(2), Pubkey: pubkey1, Data: testutil.RandomElectraVersionedSignedBlindedProposal()},
+		{Duty: core.NewProposerDuty(3), Pubkey: pubkey1, Data: testutil.RandomElectraCoreVersionedSignedProposal()},
+		{Duty: core.NewProposerDuty(0), Pubkey: pubkey2, Data: testutil.RandomDenebCoreVersionedSignedProposal()},
+	} {
+		rewards.proposalIncluded(context.Background(), sub)
+	}
+
+	report := rewards.Report("", "")
+	require.Len(t, report.Proposals, 4)
+	require.Len(t, report.Monthly, 3)
+
+	report = rewards.Report("2025-02", pubkey1)
+	require.Len(t, report.Proposals, 2)
+	require.True(t, report.Proposals[0].Blinded)
+	require.Equal(t, []MonthlyReward{{
+		Pubkey:    pubkey1,
+		Month:     "2025-02",
+		Proposals: 2,
+		RewardWei: "7000000000",
+	}}, report.Monthly)
+
+	// Rewards are loaded from the file after a restart.
+	reloaded, err := NewRewards(genesis, time.Hour, rewardFunc, file)
+	require.NoError(t, err)
+	require.Equal(t, rewards.Report("", ""), reloaded.Report("", ""))
+
+	t.Run("serve http", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rewards.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validators/proposal_rewards?month=2025-01&pubkey="+string(pubkey2), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp RewardsReport
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Proposals, 1)
+		require.Equal(t, []MonthlyReward{{
+			Pubkey:    pubkey2,
+			Month:     "2025-01",
+			Proposals: 1,
+			RewardWei: "1000000000",
+		}}, resp.Monthly)
+
+		rec = httptest.NewRecorder()
+		rewards.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validators/proposal_rewards?month=january", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = httptest.NewRecorder()
+		rewards.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validators/proposal_rewards?pubkey=0x1234", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
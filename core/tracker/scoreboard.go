@@ -0,0 +1,242 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// scoreboardWindow is the number of most recent duties per duty type and stage considered by the scoreboard.
+const scoreboardWindow = 64
+
+// Scoreboard participation stages.
+const (
+	stageConsensus = "consensus"
+	stageParSigEx  = "parsig_ex"
+)
+
+// Scoreboard participation statuses.
+const (
+	statusOnTime  = "on_time"
+	statusLate    = "late"
+	statusMissing = "missing"
+)
+
+// scoreKey identifies a scoreboard sliding window.
+type scoreKey struct {
+	dutyType core.DutyType
+	stage    string
+}
+
+// PeerScore is the participation of a peer in the most recent duties of a type and stage.
+type PeerScore struct {
+	Peer    string  `json:"peer"`
+	OnTime  int     `json:"on_time"`
+	Late    int     `json:"late"`
+	Missing int     `json:"missing"`
+	Score   float64 `json:"score"`
+}
+
+// StageScores is the participation of all peers in the most recent duties of a type and stage.
+type StageScores struct {
+	Duty   string      `json:"duty"`
+	Stage  string      `json:"stage"`
+	Duties int         `json:"duties"`
+	Peers  []PeerScore `json:"peers"`
+}
+
+// NewScoreboard returns a new scoreboard for the cluster peers.
+func NewScoreboard(peers []p2p.Peer) *Scoreboard {
+	return &Scoreboard{
+		peers:   peers,
+		windows: make(map[scoreKey][]map[int]string),
+	}
+}
+
+// Scoreboard tracks each peer's consensus and partial signature exchange participation (on-time, late or missing)
+// over a sliding window of duties per duty type, exposing it as metrics and serving it as JSON.
+type Scoreboard struct {
+	peers []p2p.Peer
+
+	mu      sync.Mutex
+	windows map[scoreKey][]map[int]string // Participation status by peer index per duty.
+}
+
+// AddConsensusInstance records the consensus participation of each peer in the sniffed consensus instance.
+// A peer participated on-time if it sent any message in the first round, late if it only sent messages
+// in subsequent rounds.
+func (s *Scoreboard) AddConsensusInstance(instance *pbv1.SniffedConsensusInstance) {
+	if len(instance.GetMsgs()) == 0 {
+		return
+	}
+
+	duty := core.DutyFromProto(instance.GetMsgs()[0].GetMsg().GetMsg().GetDuty())
+	if !duty.Type.Valid() {
+		return
+	}
+
+	firstRounds := make(map[int]int64) // First round of a message by peer index.
+	for _, msg := range instance.GetMsgs() {
+		qbftMsg := msg.GetMsg().GetMsg()
+
+		peerIdx := int(qbftMsg.GetPeerIdx())
+		if round, ok := firstRounds[peerIdx]; !ok || qbftMsg.GetRound() < round {
+			firstRounds[peerIdx] = qbftMsg.GetRound()
+		}
+	}
+
+	statuses := make(map[int]string)
+	for i := range s.peers {
+		round, ok := firstRounds[i]
+		switch {
+		case !ok:
+			statuses[i] = statusMissing
+		case round <= 1:
+			statuses[i] = statusOnTime
+		default:
+			statuses[i] = statusLate
+		}
+	}
+
+	s.add(scoreKey{dutyType: duty.Type, stage: stageConsensus}, statuses)
+}
+
+// addParSigs records the partial signature exchange participation of each peer by share index.
+func (s *Scoreboard) addParSigs(duty core.Duty, statusByShare map[int]string) {
+	statuses := make(map[int]string)
+	for i, peer := range s.peers {
+		status, ok := statusByShare[peer.ShareIdx()]
+		if !ok {
+			status = statusMissing
+		}
+
+		statuses[i] = status
+	}
+
+	s.add(scoreKey{dutyType: duty.Type, stage: stageParSigEx}, statuses)
+}
+
+// add appends the statuses to the sliding window, trimming old duties and updating the metrics.
+func (s *Scoreboard) add(key scoreKey, statuses map[int]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window := append(s.windows[key], statuses)
+	if len(window) > scoreboardWindow {
+		window = window[len(window)-scoreboardWindow:]
+	}
+
+	s.windows[key] = window
+
+	for _, peer := range s.scores(window) {
+		scoreboardGauge.WithLabelValues(key.dutyType.String(), key.stage, peer.Peer, statusOnTime).Set(float64(peer.OnTime))
+		scoreboardGauge.WithLabelValues(key.dutyType.String(), key.stage, peer.Peer, statusLate).Set(float64(peer.Late))
+		scoreboardGauge.WithLabelValues(key.dutyType.String(), key.stage, peer.Peer, statusMissing).Set(float64(peer.Missing))
+		scoreboardScore.WithLabelValues(key.dutyType.String(), key.stage, peer.Peer).Set(peer.Score)
+	}
+}
+
+// scores returns the participation scores of all peers in the window.
+func (s *Scoreboard) scores(window []map[int]string) []PeerScore {
+	var resp []PeerScore
+	for i, peer := range s.peers {
+		score := PeerScore{Peer: peer.Name}
+		for _, statuses := range window {
+			switch statuses[i] {
+			case statusOnTime:
+				score.OnTime++
+			case statusLate:
+				score.Late++
+			default:
+				score.Missing++
+			}
+		}
+
+		if len(window) > 0 {
+			score.Score = float64(score.OnTime) / float64(len(window))
+		}
+
+		resp = append(resp, score)
+	}
+
+	return resp
+}
+
+// Scores returns the participation scores of all peers by duty type and stage, ordered by duty type.
+func (s *Scoreboard) Scores() []StageScores {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var resp []StageScores
+	for _, dutyType := range core.AllDutyTypes() {
+		for _, stage := range []string{stageConsensus, stageParSigEx} {
+			window, ok := s.windows[scoreKey{dutyType: dutyType, stage: stage}]
+			if !ok {
+				continue
+			}
+
+			resp = append(resp, StageScores{
+				Duty:   dutyType.String(),
+				Stage:  stage,
+				Duties: len(window),
+				Peers:  s.scores(window),
+			})
+		}
+	}
+
+	return resp
+}
+
+// ServeHTTP serves the scoreboard as JSON.
+func (s *Scoreboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(s.Scores())
+	if err != nil {
+		log.Warn(r.Context(), "Error serving peer scoreboard", err)
+		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// analyseParSigTimeliness returns the partial signature exchange participation status by share index of the duty.
+// A peer participated on-time if any of its expected partial signatures was stored before the first
+// threshold aggregation, late if all were stored after it. Peers without partial signatures are omitted.
+func analyseParSigTimeliness(duty core.Duty, allEvents map[core.Duty][]event) map[int]string {
+	resp := make(map[int]string)
+
+	aggregated := false
+	for _, e := range allEvents[duty] {
+		if e.step == sigAgg && e.stepErr == nil {
+			aggregated = true
+			continue
+		}
+
+		if e.step != parSigDBInternal && e.step != parSigDBExternal {
+			continue
+		}
+
+		if !isParSigEventExpected(duty, e.pubkey, allEvents) {
+			continue
+		}
+
+		if aggregated {
+			if _, ok := resp[e.parSig.ShareIdx]; !ok {
+				resp[e.parSig.ShareIdx] = statusLate
+			}
+		} else {
+			resp[e.parSig.ShareIdx] = statusOnTime
+		}
+	}
+
+	return resp
+}
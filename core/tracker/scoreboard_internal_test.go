@@ -0,0 +1,147 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestAnalyseParSigTimeliness(t *testing.T) {
+	const slot = 123
+
+	duty := core.NewAttesterDuty(slot)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	parSigEvent := func(step step, shareIdx int) event {
+		return event{
+			duty:   duty,
+			step:   step,
+			pubkey: pubkey,
+			parSig: &core.ParSignedData{ShareIdx: shareIdx},
+		}
+	}
+
+	events := map[core.Duty][]event{
+		duty: {
+			{duty: duty, step: fetcher, pubkey: pubkey},
+			parSigEvent(parSigDBInternal, 1),
+			parSigEvent(parSigDBExternal, 2),
+			parSigEvent(parSigDBExternal, 3),
+			{duty: duty, step: sigAgg, pubkey: pubkey},
+			parSigEvent(parSigDBExternal, 4),
+			parSigEvent(parSigDBExternal, 2), // Duplicate after aggregation doesn't make it late.
+		},
+	}
+
+	require.Equal(t, map[int]string{
+		1: statusOnTime,
+		2: statusOnTime,
+		3: statusOnTime,
+		4: statusLate,
+	}, analyseParSigTimeliness(duty, events))
+
+	// Unexpected partial signatures are ignored.
+	unexpected := map[core.Duty][]event{
+		duty: {parSigEvent(parSigDBExternal, 1)},
+	}
+	require.Empty(t, analyseParSigTimeliness(duty, unexpected))
+}
+
+func TestScoreboard(t *testing.T) {
+	peers := []p2p.Peer{
+		{Index: 0, Name: "alpha"},
+		{Index: 1, Name: "bravo"},
+		{Index: 2, Name: "charlie"},
+		{Index: 3, Name: "delta"},
+	}
+
+	scoreboard := NewScoreboard(peers)
+
+	duty := core.NewProposerDuty(10)
+
+	consensusMsg := func(peerIdx, round int64) *pbv1.SniffedConsensusMsg {
+		return &pbv1.SniffedConsensusMsg{
+			Msg: &pbv1.QBFTConsensusMsg{
+				Msg: &pbv1.QBFTMsg{
+					Duty:    core.DutyToProto(duty),
+					PeerIdx: peerIdx,
+					Round:   round,
+				},
+			},
+		}
+	}
+
+	scoreboard.AddConsensusInstance(&pbv1.SniffedConsensusInstance{
+		Msgs: []*pbv1.SniffedConsensusMsg{
+			consensusMsg(0, 1),
+			consensusMsg(1, 1),
+			consensusMsg(2, 2),
+			consensusMsg(0, 2),
+		},
+	})
+
+	// Peer shares are 1-indexed.
+	scoreboard.addParSigs(duty, map[int]string{1: statusOnTime, 2: statusLate, 4: statusOnTime})
+	scoreboard.addParSigs(duty, map[int]string{1: statusOnTime, 2: statusOnTime})
+
+	scores := scoreboard.Scores()
+	require.Equal(t, []StageScores{
+		{
+			Duty:   "proposer",
+			Stage:  stageConsensus,
+			Duties: 1,
+			Peers: []PeerScore{
+				{Peer: "alpha", OnTime: 1, Score: 1},
+				{Peer: "bravo", OnTime: 1, Score: 1},
+				{Peer: "charlie", Late: 1},
+				{Peer: "delta", Missing: 1},
+			},
+		},
+		{
+			Duty:   "proposer",
+			Stage:  stageParSigEx,
+			Duties: 2,
+			Peers: []PeerScore{
+				{Peer: "alpha", OnTime: 2, Score: 1},
+				{Peer: "bravo", OnTime: 1, Late: 1, Score: 0.5},
+				{Peer: "charlie", Missing: 2},
+				{Peer: "delta", OnTime: 1, Missing: 1, Score: 0.5},
+			},
+		},
+	}, scores)
+
+	// Only the most recent duties are considered.
+	for range scoreboardWindow {
+		scoreboard.addParSigs(duty, map[int]string{1: statusOnTime, 2: statusOnTime, 3: statusOnTime, 4: statusOnTime})
+	}
+
+	for _, stage := range scoreboard.Scores() {
+		if stage.Stage != stageParSigEx {
+			continue
+		}
+
+		require.Equal(t, scoreboardWindow, stage.Duties)
+
+		for _, peer := range stage.Peers {
+			require.InDelta(t, 1.0, peer.Score, 0)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	scoreboard.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/peers/scoreboard", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var served []StageScores
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, scoreboard.Scores(), served)
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+// timelineLabels are the human-readable labels of the steps included in the duty timeline.
+var timelineLabels = map[step]string{
+	fetcher:          "fetched",
+	consensus:        "consensus decided",
+	dutyDB:           "stored",
+	parSigDBInternal: "signed by vc",
+	parSigEx:         "partials exchanged",
+	sigAgg:           "threshold reached",
+	bcast:            "broadcast",
+	chainInclusion:   "included",
+}
+
+// WithDutyTimeline returns an option logging a single consolidated debug line per duty summarizing
+// when each core workflow step first completed relative to the duty's slot start.
+func WithDutyTimeline(genesis time.Time, slotDuration time.Duration) Option {
+	return func(t *Tracker) {
+		t.slotStart = func(duty core.Duty) time.Time {
+			return genesis.Add(slotDuration * time.Duration(duty.Slot))
+		}
+	}
+}
+
+// dutyTimeline returns a human-readable summary of the duty's lifecycle, e.g.
+// "t0 scheduled, +120ms fetched, +310ms consensus decided, +450ms threshold reached, +520ms broadcast".
+// Each step is listed once at the time of its first event, failed steps are suffixed with the error
+// and the number of distinct shares exchanged with peers is included.
+func dutyTimeline(events []event, slotStart time.Time) string {
+	type entry struct {
+		time time.Time
+		err  error
+	}
+
+	var (
+		steps  = make(map[step]entry)
+		shares = make(map[int]bool) // Share indexes of partial signatures exchanged with peers.
+	)
+
+	for _, e := range events {
+		if e.parSig != nil && (e.step == parSigEx || e.step == parSigDBExternal) {
+			shares[e.parSig.ShareIdx] = true
+		}
+
+		prev, ok := steps[e.step]
+		if !ok || (prev.err != nil && e.stepErr == nil) {
+			// Record the first event, or the first successful event after failures.
+			steps[e.step] = entry{time: e.time, err: e.stepErr}
+		}
+	}
+
+	items := []string{"t0 scheduled"}
+
+	for s := fetcher; s < sentinel; s++ {
+		label, ok := timelineLabels[s]
+		if !ok {
+			continue
+		}
+
+		e, ok := steps[s]
+		if !ok {
+			continue
+		}
+
+		if s == parSigEx && len(shares) > 0 {
+			label = fmt.Sprintf("%s (%d shares)", label, len(shares))
+		}
+
+		offset := e.time.Sub(slotStart).Milliseconds()
+		if e.err != nil {
+			items = append(items, fmt.Sprintf("%+dms %s failed (%v)", offset, s, e.err))
+		} else {
+			items = append(items, fmt.Sprintf("%+dms %s", offset, label))
+		}
+	}
+
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,48 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestDutyTimeline(t *testing.T) {
+	slotStart := time.Unix(1000, 0)
+	at := func(ms int) time.Time {
+		return slotStart.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	duty := core.NewAttesterDuty(1)
+
+	events := []event{
+		{duty: duty, step: fetcher, time: at(120)},
+		{duty: duty, step: fetcher, time: at(130)},
+		{duty: duty, step: consensus, time: at(310)},
+		{duty: duty, step: dutyDB, time: at(311)},
+		{duty: duty, step: parSigEx, time: at(400), parSig: &core.ParSignedData{ShareIdx: 1}},
+		{duty: duty, step: parSigDBExternal, time: at(420), parSig: &core.ParSignedData{ShareIdx: 2}},
+		{duty: duty, step: parSigDBExternal, time: at(430), parSig: &core.ParSignedData{ShareIdx: 2}},
+		{duty: duty, step: sigAgg, time: at(450)},
+		{duty: duty, step: bcast, time: at(500), stepErr: errors.New("bn down")},
+		{duty: duty, step: bcast, time: at(520)},
+	}
+
+	require.Equal(t,
+		"t0 scheduled, +120ms fetched, +310ms consensus decided, +311ms stored, +400ms partials exchanged (2 shares), +450ms threshold reached, +520ms broadcast",
+		dutyTimeline(events, slotStart))
+
+	events = []event{
+		{duty: duty, step: fetcher, time: at(-50)},
+		{duty: duty, step: consensus, time: at(2000), stepErr: errors.New("timeout")},
+	}
+
+	require.Equal(t,
+		"t0 scheduled, -50ms fetched, +2000ms consensus failed (timeout)",
+		dutyTimeline(events, slotStart))
+}
@@ -6,9 +6,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 
+	"github.com/obolnetwork/charon/app/alert"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
@@ -76,6 +79,7 @@ type event struct {
 	step    step
 	pubkey  core.PubKey
 	stepErr error
+	time    time.Time // Time the event was received by the tracker.
 
 	// parSig is an optional field only set by validatorAPI, parSigDBInternal and parSigExReceive events.
 	parSig *core.ParSignedData
@@ -104,10 +108,46 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]int, unexpectedPeers map[int]int, expectedPerPeer int)
+
+	// scoreboard optionally records peer partial signature exchange participation timeliness.
+	scoreboard *Scoreboard
+
+	// performance optionally records per-validator duty outcomes.
+	performance *Performance
+
+	// alerts optionally notifies missed proposals.
+	alerts *alert.Notifier
+
+	// slotStart optionally enables logging duty timelines relative to the returned duty slot start time.
+	slotStart func(core.Duty) time.Time
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithScoreboard returns an option recording peer partial signature exchange participation in the scoreboard.
+func WithScoreboard(scoreboard *Scoreboard) Option {
+	return func(t *Tracker) {
+		t.scoreboard = scoreboard
+	}
+}
+
+// WithPerformance returns an option recording per-validator duty outcomes in the validator performance metrics.
+func WithPerformance(performance *Performance) Option {
+	return func(t *Tracker) {
+		t.performance = performance
+	}
+}
+
+// WithAlerts returns an option notifying missed block proposals.
+func WithAlerts(alerts *alert.Notifier) Option {
+	return func(t *Tracker) {
+		t.alerts = alerts
+	}
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
-func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64) *Tracker {
+func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot uint64, opts ...Option) *Tracker {
 	t := &Tracker{
 		input:                 make(chan event),
 		events:                make(map[core.Duty][]event),
@@ -120,6 +160,10 @@ func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, from
 		participationReporter: newParticipationReporter(peers),
 	}
 
+	for _, opt := range opts {
+		opt(t)
+	}
+
 	return t
 }
 
@@ -145,6 +189,7 @@ func (t *Tracker) Run(ctx context.Context) error {
 				continue // Ignore expired or never expiring duties
 			}
 
+			e.time = time.Now()
 			t.events[e.duty] = append(t.events[e.duty], e)
 		case duty := <-t.analyser.C():
 			ctx := log.WithCtx(ctx, z.Any("duty", duty))
@@ -160,9 +205,38 @@ func (t *Tracker) Run(ctx context.Context) error {
 
 			t.failedDutyReporter(ctx, duty, failed, failedStep, reason, failedErr)
 
+			if failed && duty.Type == core.DutyProposer && t.alerts != nil {
+				details := map[string]string{
+					"slot":        strconv.FormatUint(duty.Slot, 10),
+					"step":        failedStep.String(),
+					"reason_code": reason.Code,
+					"reason":      reason.Short,
+				}
+				for _, e := range t.events[duty] {
+					if e.pubkey != "" {
+						details["pubkey"] = e.pubkey.String()
+						break
+					}
+				}
+
+				t.alerts.Notify(ctx, alert.TypeMissedProposal, "Block proposal missed", details)
+			}
+
 			// Analyse peer participation
 			participatedShares, unexpectedShares, expectedPerPeer := analyseParticipation(duty, t.events)
 			t.participationReporter(ctx, duty, failed, participatedShares, unexpectedShares, expectedPerPeer)
+
+			if t.scoreboard != nil && (len(participatedShares) > 0 || failed) {
+				t.scoreboard.addParSigs(duty, analyseParSigTimeliness(duty, t.events))
+			}
+
+			if t.performance != nil {
+				t.performance.addDuty(duty, t.events[duty])
+			}
+
+			if t.slotStart != nil {
+				log.Debug(ctx, "Duty timeline", z.Str("timeline", dutyTimeline(t.events[duty], t.slotStart(duty))))
+			}
 		case duty := <-t.deleter.C():
 			delete(t.events, duty)
 		}
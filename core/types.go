@@ -20,6 +20,10 @@ var (
 	// ErrNotFound is returned by a component when a resource is not found.
 	ErrNotFound = errors.NewSentinel("not found")
 
+	// ErrConflictingSubmission is returned when a validator client submits partial signed data conflicting with
+	// a previous submission of the same duty and validator.
+	ErrConflictingSubmission = errors.NewSentinel("conflicting validator client submission")
+
 	// ErrDeprecatedDutyBuilderProposer is returned when attempting to use the deprecated DutyBuilderProposer.
 	ErrDeprecatedDutyBuilderProposer = errors.NewSentinel("deprecated duty DutyBuilderProposer")
 )
@@ -44,9 +48,10 @@ const (
 	DutyPrepareSyncContribution DutyType = 11
 	DutySyncContribution        DutyType = 12
 	DutyInfoSync                DutyType = 13
+	DutyRehearsal               DutyType = 14
 	// Only ever append new types here...
 
-	dutySentinel DutyType = 14 // Must always be last
+	dutySentinel DutyType = 15 // Must always be last
 )
 
 func (d DutyType) Valid() bool {
@@ -69,6 +74,7 @@ func (d DutyType) String() string {
 		DutyPrepareSyncContribution: "prepare_sync_contribution",
 		DutySyncContribution:        "sync_contribution",
 		DutyInfoSync:                "info_sync",
+		DutyRehearsal:               "rehearsal",
 	}[d]
 }
 
@@ -82,6 +88,17 @@ func AllDutyTypes() []DutyType {
 	return resp
 }
 
+// DutyTypeFromString returns the valid duty type with the provided string representation or false if not found.
+func DutyTypeFromString(s string) (DutyType, bool) {
+	for _, typ := range AllDutyTypes() {
+		if typ.String() == s {
+			return typ, true
+		}
+	}
+
+	return DutyUnknown, false
+}
+
 // Duty is the unit of work of the core workflow.
 type Duty struct {
 	// Slot is the Ethereum consensus layer slot.
@@ -260,6 +277,15 @@ func NewInfoSyncDuty(slot uint64) Duty {
 	}
 }
 
+// NewRehearsalDuty returns a new proposal rehearsal duty. It is a convenience function that is
+// slightly more readable and concise than the struct literal equivalent.
+func NewRehearsalDuty(slot uint64) Duty {
+	return Duty{
+		Slot: slot,
+		Type: DutyRehearsal,
+	}
+}
+
 const (
 	pkLen  = 98 // "0x" + hex.Encode([48]byte) = 2+2*48
 	sigLen = 96
@@ -32,9 +32,10 @@ func TestBackwardsCompatability(t *testing.T) {
 	require.EqualValues(t, 11, core.DutyPrepareSyncContribution)
 	require.EqualValues(t, 12, core.DutySyncContribution)
 	require.EqualValues(t, 13, core.DutyInfoSync)
+	require.EqualValues(t, 14, core.DutyRehearsal)
 	// Add more types here.
 
-	const sentinel = core.DutyType(14)
+	const sentinel = core.DutyType(15)
 	for i := core.DutyUnknown; i <= sentinel; i++ {
 		switch i {
 		case core.DutyUnknown:
@@ -77,13 +78,27 @@ func TestWithDutySpanCtx(t *testing.T) {
 func TestAllDutyTypes(t *testing.T) {
 	adt := core.AllDutyTypes()
 
-	require.Len(t, adt, 13)
+	require.Len(t, adt, 14)
 
 	for i, dt := range adt {
 		require.Equal(t, i, slices.Index(adt, dt))
 	}
 }
 
+func TestDutyTypeFromString(t *testing.T) {
+	for _, typ := range core.AllDutyTypes() {
+		parsed, ok := core.DutyTypeFromString(typ.String())
+		require.True(t, ok)
+		require.Equal(t, typ, parsed)
+	}
+
+	_, ok := core.DutyTypeFromString("unknown")
+	require.False(t, ok)
+
+	_, ok = core.DutyTypeFromString("invalid")
+	require.False(t, ok)
+}
+
 func TestNewBuilderRegistrationDuty(t *testing.T) {
 	d := core.NewBuilderRegistrationDuty(1)
 
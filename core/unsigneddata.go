@@ -616,7 +616,7 @@ func unmarshalUnsignedData(typ DutyType, data []byte) (UnsignedData, error) {
 		}
 
 		return resp, nil
-	case DutyProposer:
+	case DutyProposer, DutyRehearsal:
 		var resp VersionedProposal
 		if err := unmarshal(data, &resp); err != nil {
 			return nil, errors.Wrap(err, "unmarshal proposal")
@@ -0,0 +1,186 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// VCToken authenticates a validator client connecting to the validator API with a bearer token.
+type VCToken struct {
+	// Token is the bearer token of the validator client.
+	Token string `json:"token"`
+	// Name identifies the validator client in logs and metrics.
+	Name string `json:"name"`
+	// PubShares optionally restricts the validators (by public share) the validator client may submit for.
+	// All validators are allowed if empty.
+	PubShares []string `json:"pubshares,omitempty"`
+}
+
+// vcIdentity is an authenticated validator client.
+type vcIdentity struct {
+	name      string
+	pubshares map[core.PubKey]bool // Nil if all validators are allowed.
+}
+
+// allowed returns true if the validator client may submit for the public share.
+func (i vcIdentity) allowed(pubshare core.PubKey) bool {
+	return i.pubshares == nil || i.pubshares[pubshare]
+}
+
+type vcIdentityKey struct{}
+
+// withVCIdentity returns a copy of the context with the authenticated validator client.
+func withVCIdentity(ctx context.Context, identity vcIdentity) context.Context {
+	return context.WithValue(ctx, vcIdentityKey{}, identity)
+}
+
+// vcIdentityFromCtx returns the authenticated validator client of the context or false if not authenticated.
+func vcIdentityFromCtx(ctx context.Context) (vcIdentity, bool) {
+	identity, ok := ctx.Value(vcIdentityKey{}).(vcIdentity)
+	return identity, ok
+}
+
+// LoadVCTokens returns the validator client tokens from the JSON file at path, formatted as
+// [{"token": "...", "name": "...", "pubshares": ["0x..."]}].
+func LoadVCTokens(path string) ([]VCToken, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read validator api tokens file", z.Str("path", path))
+	}
+
+	var tokens []VCToken
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, errors.Wrap(err, "unmarshal validator api tokens file", z.Str("path", path))
+	}
+
+	if _, err := newVCIdentities(tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// newVCIdentities returns the validator client identities by token, validating the tokens.
+func newVCIdentities(tokens []VCToken) (map[string]vcIdentity, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("no validator api tokens")
+	}
+
+	resp := make(map[string]vcIdentity)
+	names := make(map[string]bool)
+
+	for _, token := range tokens {
+		if token.Token == "" || token.Name == "" {
+			return nil, errors.New("validator api token and name required", z.Str("name", token.Name))
+		} else if _, ok := resp[token.Token]; ok {
+			return nil, errors.New("duplicate validator api token", z.Str("name", token.Name))
+		} else if names[token.Name] {
+			return nil, errors.New("duplicate validator api token name", z.Str("name", token.Name))
+		}
+
+		names[token.Name] = true
+
+		identity := vcIdentity{name: token.Name}
+		for _, pubshare := range token.PubShares {
+			b, err := hex.DecodeString(strings.TrimPrefix(pubshare, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, "decode validator api token pubshare", z.Str("name", token.Name))
+			}
+
+			pk, err := core.PubKeyFromBytes(b)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid validator api token pubshare", z.Str("name", token.Name))
+			}
+
+			if identity.pubshares == nil {
+				identity.pubshares = make(map[core.PubKey]bool)
+			}
+
+			identity.pubshares[pk] = true
+		}
+
+		resp[token.Token] = identity
+	}
+
+	return resp, nil
+}
+
+// authenticate returns a middleware rejecting requests without a valid bearer token.
+// Authenticated validator client identities are added to the request context and logs.
+func authenticate(identities map[string]vcIdentity) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := log.WithTopic(r.Context(), "vapi")
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				vcAuthFailures.Inc()
+				writeError(ctx, w, "auth", apiError{
+					StatusCode: http.StatusUnauthorized,
+					Message:    "missing bearer token",
+				})
+
+				return
+			}
+
+			identity, ok := lookupVCIdentity(identities, token)
+			if !ok {
+				vcAuthFailures.Inc()
+				writeError(ctx, w, "auth", apiError{
+					StatusCode: http.StatusUnauthorized,
+					Message:    "invalid bearer token",
+				})
+
+				return
+			}
+
+			ctx = withVCIdentity(r.Context(), identity)
+			ctx = log.WithCtx(ctx, z.Str("vc", identity.name))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// lookupVCIdentity returns the identity of the token, comparing all tokens in constant time.
+func lookupVCIdentity(identities map[string]vcIdentity, token string) (vcIdentity, bool) {
+	var (
+		resp  vcIdentity
+		found bool
+	)
+
+	for t, identity := range identities {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			resp = identity
+			found = true
+		}
+	}
+
+	return resp, found
+}
+
+// verifyVCAllowed returns an error if the authenticated validator client may not submit for the public share.
+func verifyVCAllowed(ctx context.Context, pubshare core.PubKey) error {
+	identity, ok := vcIdentityFromCtx(ctx)
+	if !ok || identity.allowed(pubshare) {
+		return nil
+	}
+
+	return apiError{
+		StatusCode: http.StatusForbidden,
+		Message:    "validator client not allowed to submit for validator",
+		Err:        errors.New("validator not allowed for validator client", z.Str("vc", identity.name), z.Str("pubshare", pubshare.String())),
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLoadVCTokens(t *testing.T) {
+	pubshare := testutil.RandomCorePubKey(t)
+
+	tests := []struct {
+		Name   string
+		Tokens []VCToken
+		Err    string
+	}{
+		{
+			Name: "valid",
+			Tokens: []VCToken{
+				{Token: "token1", Name: "vc1"},
+				{Token: "token2", Name: "vc2", PubShares: []string{string(pubshare)}},
+			},
+		},
+		{
+			Name: "empty",
+			Err:  "no validator api tokens",
+		},
+		{
+			Name:   "missing name",
+			Tokens: []VCToken{{Token: "token1"}},
+			Err:    "validator api token and name required",
+		},
+		{
+			Name:   "duplicate token",
+			Tokens: []VCToken{{Token: "token1", Name: "vc1"}, {Token: "token1", Name: "vc2"}},
+			Err:    "duplicate validator api token",
+		},
+		{
+			Name:   "duplicate name",
+			Tokens: []VCToken{{Token: "token1", Name: "vc1"}, {Token: "token2", Name: "vc1"}},
+			Err:    "duplicate validator api token name",
+		},
+		{
+			Name:   "invalid pubshare",
+			Tokens: []VCToken{{Token: "token1", Name: "vc1", PubShares: []string{"0x1234"}}},
+			Err:    "invalid validator api token pubshare",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := json.Marshal(test.Tokens)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), "tokens.json")
+			require.NoError(t, os.WriteFile(path, b, 0o644))
+
+			tokens, err := LoadVCTokens(path)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.Tokens, tokens)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	allowed := testutil.RandomCorePubKey(t)
+	other := testutil.RandomCorePubKey(t)
+
+	identities, err := newVCIdentities([]VCToken{
+		{Token: "token1", Name: "vc1"},
+		{Token: "token2", Name: "vc2", PubShares: []string{string(allowed)}},
+	})
+	require.NoError(t, err)
+
+	var gotCtx context.Context
+	handler := authenticate(identities)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotCtx = r.Context()
+	}))
+
+	serve := func(auth string) int {
+		gotCtx = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/eth/v1/node/version", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve(""))
+	require.Nil(t, gotCtx)
+	require.Equal(t, http.StatusUnauthorized, serve("Bearer invalid"))
+	require.Nil(t, gotCtx)
+
+	require.Equal(t, http.StatusOK, serve("Bearer token1"))
+	identity, ok := vcIdentityFromCtx(gotCtx)
+	require.True(t, ok)
+	require.Equal(t, "vc1", identity.name)
+	require.NoError(t, verifyVCAllowed(gotCtx, allowed))
+	require.NoError(t, verifyVCAllowed(gotCtx, other))
+
+	require.Equal(t, http.StatusOK, serve("Bearer token2"))
+	identity, ok = vcIdentityFromCtx(gotCtx)
+	require.True(t, ok)
+	require.Equal(t, "vc2", identity.name)
+	require.NoError(t, verifyVCAllowed(gotCtx, allowed))
+
+	err = verifyVCAllowed(gotCtx, other)
+	var apiErr apiError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+
+	// Unauthenticated contexts are allowed.
+	require.NoError(t, verifyVCAllowed(context.Background(), other))
+}
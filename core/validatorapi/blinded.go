@@ -0,0 +1,400 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"net/http"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	maxTransactionsPerPayload = 1048576
+	maxBytesPerTransaction    = 1073741824
+	maxWithdrawalsPerPayload  = 16
+)
+
+// ProposalType defines the type of proposal returned to a validator client.
+type ProposalType string
+
+const (
+	// ProposalTypeFull forces full (unblinded) proposals.
+	ProposalTypeFull ProposalType = "full"
+	// ProposalTypeBlinded forces blinded proposals.
+	ProposalTypeBlinded ProposalType = "blinded"
+)
+
+// ProposalTypeOverride forces a specific proposal type for validator clients
+// matching the client identifier. The identifier matches either a case-insensitive
+// substring of the User-Agent header or the exact bearer token of the request.
+type ProposalTypeOverride struct {
+	Client string
+	Type   ProposalType
+}
+
+// ParseProposalTypeOverrides parses overrides formatted as "client=type", e.g. "teku=full".
+func ParseProposalTypeOverrides(overrides []string) ([]ProposalTypeOverride, error) {
+	var resp []ProposalTypeOverride
+
+	for _, override := range overrides {
+		client, typ, ok := strings.Cut(override, "=")
+		if !ok || client == "" {
+			return nil, errors.New("invalid proposal type override, expect client=type", z.Str("override", override))
+		}
+
+		switch ProposalType(typ) {
+		case ProposalTypeFull, ProposalTypeBlinded:
+		default:
+			return nil, errors.New("invalid proposal type, expect full or blinded", z.Str("override", override))
+		}
+
+		resp = append(resp, ProposalTypeOverride{
+			Client: client,
+			Type:   ProposalType(typ),
+		})
+	}
+
+	return resp, nil
+}
+
+// proposalTypeForClient returns the forced proposal type for the validator client identified by the
+// request header or false if no override matches.
+func proposalTypeForClient(overrides []ProposalTypeOverride, header http.Header) (ProposalType, bool) {
+	userAgent := strings.ToLower(header.Get("User-Agent"))
+	token, _ := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+
+	for _, override := range overrides {
+		if token != "" && token == override.Client {
+			return override.Type, true
+		}
+
+		if userAgent != "" && strings.Contains(userAgent, strings.ToLower(override.Client)) {
+			return override.Type, true
+		}
+	}
+
+	return "", false
+}
+
+// blindProposal returns a blinded copy of the full proposal by replacing the execution payload
+// with its header. The blinded block has the same hash tree root as the full block, so signatures
+// of the blinded block are valid for the full block.
+func blindProposal(proposal *eth2api.VersionedProposal) (*eth2api.VersionedProposal, error) {
+	if proposal.Blinded {
+		return proposal, nil
+	}
+
+	resp := &eth2api.VersionedProposal{
+		Version:        proposal.Version,
+		Blinded:        true,
+		ConsensusValue: proposal.ConsensusValue,
+		ExecutionValue: proposal.ExecutionValue,
+	}
+
+	switch proposal.Version {
+	case eth2spec.DataVersionCapella:
+		if proposal.Capella == nil {
+			return nil, errors.New("no capella block")
+		}
+
+		block := proposal.Capella
+
+		header, err := capellaPayloadHeader(block.Body.ExecutionPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.CapellaBlinded = &eth2capella.BlindedBeaconBlock{
+			Slot:          block.Slot,
+			ProposerIndex: block.ProposerIndex,
+			ParentRoot:    block.ParentRoot,
+			StateRoot:     block.StateRoot,
+			Body: &eth2capella.BlindedBeaconBlockBody{
+				RANDAOReveal:           block.Body.RANDAOReveal,
+				ETH1Data:               block.Body.ETH1Data,
+				Graffiti:               block.Body.Graffiti,
+				ProposerSlashings:      block.Body.ProposerSlashings,
+				AttesterSlashings:      block.Body.AttesterSlashings,
+				Attestations:           block.Body.Attestations,
+				Deposits:               block.Body.Deposits,
+				VoluntaryExits:         block.Body.VoluntaryExits,
+				SyncAggregate:          block.Body.SyncAggregate,
+				ExecutionPayloadHeader: header,
+				BLSToExecutionChanges:  block.Body.BLSToExecutionChanges,
+			},
+		}
+	case eth2spec.DataVersionDeneb:
+		if proposal.Deneb == nil || proposal.Deneb.Block == nil {
+			return nil, errors.New("no deneb block")
+		}
+
+		block := proposal.Deneb.Block
+
+		header, err := denebPayloadHeader(block.Body.ExecutionPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.DenebBlinded = &eth2deneb.BlindedBeaconBlock{
+			Slot:          block.Slot,
+			ProposerIndex: block.ProposerIndex,
+			ParentRoot:    block.ParentRoot,
+			StateRoot:     block.StateRoot,
+			Body: &eth2deneb.BlindedBeaconBlockBody{
+				RANDAOReveal:           block.Body.RANDAOReveal,
+				ETH1Data:               block.Body.ETH1Data,
+				Graffiti:               block.Body.Graffiti,
+				ProposerSlashings:      block.Body.ProposerSlashings,
+				AttesterSlashings:      block.Body.AttesterSlashings,
+				Attestations:           block.Body.Attestations,
+				Deposits:               block.Body.Deposits,
+				VoluntaryExits:         block.Body.VoluntaryExits,
+				SyncAggregate:          block.Body.SyncAggregate,
+				ExecutionPayloadHeader: header,
+				BLSToExecutionChanges:  block.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:     block.Body.BlobKZGCommitments,
+			},
+		}
+	case eth2spec.DataVersionElectra:
+		if proposal.Electra == nil || proposal.Electra.Block == nil {
+			return nil, errors.New("no electra block")
+		}
+
+		block := proposal.Electra.Block
+
+		header, err := denebPayloadHeader(block.Body.ExecutionPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.ElectraBlinded = &eth2electra.BlindedBeaconBlock{
+			Slot:          block.Slot,
+			ProposerIndex: block.ProposerIndex,
+			ParentRoot:    block.ParentRoot,
+			StateRoot:     block.StateRoot,
+			Body: &eth2electra.BlindedBeaconBlockBody{
+				RANDAOReveal:           block.Body.RANDAOReveal,
+				ETH1Data:               block.Body.ETH1Data,
+				Graffiti:               block.Body.Graffiti,
+				ProposerSlashings:      block.Body.ProposerSlashings,
+				AttesterSlashings:      block.Body.AttesterSlashings,
+				Attestations:           block.Body.Attestations,
+				Deposits:               block.Body.Deposits,
+				VoluntaryExits:         block.Body.VoluntaryExits,
+				SyncAggregate:          block.Body.SyncAggregate,
+				ExecutionPayloadHeader: header,
+				BLSToExecutionChanges:  block.Body.BLSToExecutionChanges,
+				BlobKZGCommitments:     block.Body.BlobKZGCommitments,
+				ExecutionRequests:      block.Body.ExecutionRequests,
+			},
+		}
+	default:
+		return nil, errors.New("unsupported proposal version for blinding", z.Str("version", proposal.Version.String()))
+	}
+
+	return resp, nil
+}
+
+// unblindSignedProposal returns the signed full proposal by combining the full proposal
+// with the signature of the VC-signed blinded proposal. The caller must ensure that the blinded
+// proposal matches the full proposal.
+func unblindSignedProposal(signed *eth2api.VersionedSignedBlindedProposal, full *eth2api.VersionedProposal) (*eth2api.VersionedSignedProposal, error) {
+	if full.Blinded {
+		return nil, errors.New("proposal already blinded")
+	} else if signed.Version != full.Version {
+		return nil, errors.New("mismatching proposal versions")
+	}
+
+	switch full.Version {
+	case eth2spec.DataVersionCapella:
+		if signed.Capella == nil || full.Capella == nil {
+			return nil, errors.New("no capella block")
+		}
+
+		return &eth2api.VersionedSignedProposal{
+			Version: full.Version,
+			Capella: &capella.SignedBeaconBlock{
+				Message:   full.Capella,
+				Signature: signed.Capella.Signature,
+			},
+		}, nil
+	case eth2spec.DataVersionDeneb:
+		if signed.Deneb == nil || full.Deneb == nil {
+			return nil, errors.New("no deneb block")
+		}
+
+		return &eth2api.VersionedSignedProposal{
+			Version: full.Version,
+			Deneb: &eth2deneb.SignedBlockContents{
+				SignedBlock: &deneb.SignedBeaconBlock{
+					Message:   full.Deneb.Block,
+					Signature: signed.Deneb.Signature,
+				},
+				KZGProofs: full.Deneb.KZGProofs,
+				Blobs:     full.Deneb.Blobs,
+			},
+		}, nil
+	case eth2spec.DataVersionElectra:
+		if signed.Electra == nil || full.Electra == nil {
+			return nil, errors.New("no electra block")
+		}
+
+		return &eth2api.VersionedSignedProposal{
+			Version: full.Version,
+			Electra: &eth2electra.SignedBlockContents{
+				SignedBlock: &electra.SignedBeaconBlock{
+					Message:   full.Electra.Block,
+					Signature: signed.Electra.Signature,
+				},
+				KZGProofs: full.Electra.KZGProofs,
+				Blobs:     full.Electra.Blobs,
+			},
+		}, nil
+	default:
+		return nil, errors.New("unsupported proposal version for unblinding", z.Str("version", full.Version.String()))
+	}
+}
+
+// capellaPayloadHeader returns the execution payload header of the provided capella execution payload.
+func capellaPayloadHeader(payload *capella.ExecutionPayload) (*capella.ExecutionPayloadHeader, error) {
+	if payload == nil {
+		return nil, errors.New("no execution payload")
+	}
+
+	txRoot, err := transactionsRoot(payload.Transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawalsRoot, err := withdrawalsRoot(payload.Withdrawals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &capella.ExecutionPayloadHeader{
+		ParentHash:       payload.ParentHash,
+		FeeRecipient:     payload.FeeRecipient,
+		StateRoot:        payload.StateRoot,
+		ReceiptsRoot:     payload.ReceiptsRoot,
+		LogsBloom:        payload.LogsBloom,
+		PrevRandao:       payload.PrevRandao,
+		BlockNumber:      payload.BlockNumber,
+		GasLimit:         payload.GasLimit,
+		GasUsed:          payload.GasUsed,
+		Timestamp:        payload.Timestamp,
+		ExtraData:        payload.ExtraData,
+		BaseFeePerGas:    payload.BaseFeePerGas,
+		BlockHash:        payload.BlockHash,
+		TransactionsRoot: txRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+	}, nil
+}
+
+// denebPayloadHeader returns the execution payload header of the provided deneb (and electra) execution payload.
+func denebPayloadHeader(payload *deneb.ExecutionPayload) (*deneb.ExecutionPayloadHeader, error) {
+	if payload == nil {
+		return nil, errors.New("no execution payload")
+	}
+
+	txRoot, err := transactionsRoot(payload.Transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawalsRoot, err := withdrawalsRoot(payload.Withdrawals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deneb.ExecutionPayloadHeader{
+		ParentHash:       payload.ParentHash,
+		FeeRecipient:     payload.FeeRecipient,
+		StateRoot:        payload.StateRoot,
+		ReceiptsRoot:     payload.ReceiptsRoot,
+		LogsBloom:        payload.LogsBloom,
+		PrevRandao:       payload.PrevRandao,
+		BlockNumber:      payload.BlockNumber,
+		GasLimit:         payload.GasLimit,
+		GasUsed:          payload.GasUsed,
+		Timestamp:        payload.Timestamp,
+		ExtraData:        payload.ExtraData,
+		BaseFeePerGas:    payload.BaseFeePerGas,
+		BlockHash:        payload.BlockHash,
+		TransactionsRoot: txRoot,
+		WithdrawalsRoot:  withdrawalsRoot,
+		BlobGasUsed:      payload.BlobGasUsed,
+		ExcessBlobGas:    payload.ExcessBlobGas,
+	}, nil
+}
+
+// transactionsRoot returns the SSZ hash tree root of the execution payload transactions list.
+func transactionsRoot(txs []bellatrix.Transaction) (eth2p0.Root, error) {
+	if len(txs) > maxTransactionsPerPayload {
+		return eth2p0.Root{}, errors.New("too many transactions")
+	}
+
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	indx := hh.Index()
+
+	for _, tx := range txs {
+		if len(tx) > maxBytesPerTransaction {
+			return eth2p0.Root{}, errors.New("transaction too large")
+		}
+
+		elemIndx := hh.Index()
+		hh.AppendBytes32(tx)
+		hh.MerkleizeWithMixin(elemIndx, uint64(len(tx)), (maxBytesPerTransaction+31)/32)
+	}
+
+	hh.MerkleizeWithMixin(indx, uint64(len(txs)), maxTransactionsPerPayload)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash transactions")
+	}
+
+	return root, nil
+}
+
+// withdrawalsRoot returns the SSZ hash tree root of the execution payload withdrawals list.
+func withdrawalsRoot(withdrawals []*capella.Withdrawal) (eth2p0.Root, error) {
+	if len(withdrawals) > maxWithdrawalsPerPayload {
+		return eth2p0.Root{}, errors.New("too many withdrawals")
+	}
+
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+
+	indx := hh.Index()
+
+	for _, withdrawal := range withdrawals {
+		if err := withdrawal.HashTreeRootWith(hh); err != nil {
+			return eth2p0.Root{}, errors.Wrap(err, "hash withdrawal")
+		}
+	}
+
+	hh.MerkleizeWithMixin(indx, uint64(len(withdrawals)), maxWithdrawalsPerPayload)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash withdrawals")
+	}
+
+	return root, nil
+}
@@ -0,0 +1,164 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"net/http"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestBlindProposal(t *testing.T) {
+	txs := []bellatrix.Transaction{testutil.RandomBytes32(), []byte{1, 2, 3}}
+	withdrawals := []*capella.Withdrawal{{Index: 1, ValidatorIndex: 2, Address: testutil.RandomExecutionAddress(), Amount: 3}}
+
+	capellaProp := testutil.RandomCapellaVersionedProposal()
+	capellaProp.Capella.Body.ExecutionPayload.Transactions = txs
+	capellaProp.Capella.Body.ExecutionPayload.Withdrawals = withdrawals
+
+	denebProp := testutil.RandomDenebVersionedProposal()
+	denebProp.Deneb.Block.Body.ExecutionPayload.Transactions = txs
+	denebProp.Deneb.Block.Body.ExecutionPayload.Withdrawals = withdrawals
+
+	electraProp := testutil.RandomElectraVersionedProposal()
+	electraProp.Electra.Block.Body.ExecutionPayload.Transactions = txs
+
+	tests := []struct {
+		name     string
+		full     *eth2api.VersionedProposal
+		fullRoot func(*eth2api.VersionedProposal) ssz.HashRoot
+		blinded  func(*eth2api.VersionedProposal) ssz.HashRoot
+	}{
+		{
+			name:     "capella",
+			full:     capellaProp,
+			fullRoot: func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.Capella },
+			blinded:  func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.CapellaBlinded },
+		},
+		{
+			name:     "deneb",
+			full:     denebProp,
+			fullRoot: func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.Deneb.Block },
+			blinded:  func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.DenebBlinded },
+		},
+		{
+			name:     "electra",
+			full:     electraProp,
+			fullRoot: func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.Electra.Block },
+			blinded:  func(p *eth2api.VersionedProposal) ssz.HashRoot { return p.ElectraBlinded },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blinded, err := blindProposal(test.full)
+			require.NoError(t, err)
+			require.True(t, blinded.Blinded)
+			require.Equal(t, test.full.Version, blinded.Version)
+
+			fullRoot, err := test.fullRoot(test.full).HashTreeRoot()
+			require.NoError(t, err)
+
+			blindedRoot, err := test.blinded(blinded).HashTreeRoot()
+			require.NoError(t, err)
+
+			require.Equal(t, fullRoot, blindedRoot)
+		})
+	}
+
+	t.Run("already blinded", func(t *testing.T) {
+		prop := &eth2api.VersionedProposal{Blinded: true}
+
+		blinded, err := blindProposal(prop)
+		require.NoError(t, err)
+		require.Equal(t, prop, blinded)
+	})
+}
+
+func TestConvertProposalType(t *testing.T) {
+	full := testutil.RandomDenebVersionedProposal()
+
+	blinded, err := convertProposalType(full, ProposalTypeBlinded)
+	require.NoError(t, err)
+	require.True(t, blinded.Blinded)
+
+	resp, err := convertProposalType(full, ProposalTypeFull)
+	require.NoError(t, err)
+	require.Equal(t, full, resp)
+
+	resp, err = convertProposalType(blinded, ProposalTypeBlinded)
+	require.NoError(t, err)
+	require.Equal(t, blinded, resp)
+
+	// Blinded proposals cannot be converted to full proposals.
+	_, err = convertProposalType(blinded, ProposalTypeFull)
+
+	var apiErr apiError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestUnblindSignedProposal(t *testing.T) {
+	full := testutil.RandomDenebVersionedProposal()
+
+	blinded, err := blindProposal(full)
+	require.NoError(t, err)
+
+	sig := testutil.RandomEth2Signature()
+	signedBlinded := &eth2api.VersionedSignedBlindedProposal{
+		Version: blinded.Version,
+		Deneb: &eth2deneb.SignedBlindedBeaconBlock{
+			Message:   blinded.DenebBlinded,
+			Signature: sig,
+		},
+	}
+
+	signed, err := unblindSignedProposal(signedBlinded, full)
+	require.NoError(t, err)
+	require.False(t, signed.Blinded)
+	require.Equal(t, full.Deneb.Block, signed.Deneb.SignedBlock.Message)
+	require.Equal(t, sig, signed.Deneb.SignedBlock.Signature)
+
+	_, err = unblindSignedProposal(signedBlinded, blinded)
+	require.ErrorContains(t, err, "proposal already blinded")
+}
+
+func TestProposalTypeOverrides(t *testing.T) {
+	_, err := ParseProposalTypeOverrides([]string{"teku"})
+	require.ErrorContains(t, err, "invalid proposal type override")
+
+	_, err = ParseProposalTypeOverrides([]string{"teku=partial"})
+	require.ErrorContains(t, err, "invalid proposal type")
+
+	overrides, err := ParseProposalTypeOverrides([]string{"Teku=full", "secret=blinded"})
+	require.NoError(t, err)
+	require.Equal(t, []ProposalTypeOverride{
+		{Client: "Teku", Type: ProposalTypeFull},
+		{Client: "secret", Type: ProposalTypeBlinded},
+	}, overrides)
+
+	header := make(http.Header)
+	header.Set("User-Agent", "teku/v24.1.0")
+	typ, ok := proposalTypeForClient(overrides, header)
+	require.True(t, ok)
+	require.Equal(t, ProposalTypeFull, typ)
+
+	header = make(http.Header)
+	header.Set("Authorization", "Bearer secret")
+	typ, ok = proposalTypeForClient(overrides, header)
+	require.True(t, ok)
+	require.Equal(t, ProposalTypeBlinded, typ)
+
+	header = make(http.Header)
+	header.Set("User-Agent", "Lighthouse/v5.1.0")
+	_, ok = proposalTypeForClient(overrides, header)
+	require.False(t, ok)
+}
@@ -0,0 +1,114 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// blockCacheRootTTL is how long blocks and headers requested by root are cached, since these are immutable.
+	blockCacheRootTTL = 10 * time.Minute
+	// blockCacheTTL is how long blocks and headers requested by slot or alias (e.g. "head") are cached,
+	// since these change with new blocks and reorgs.
+	blockCacheTTL = 2 * time.Second
+
+	// headerCacheSize and blockCacheSize are the maximum number of cached headers and blocks,
+	// the least recently used are evicted first. Blocks are large, so fewer are cached.
+	headerCacheSize = 256
+	blockCacheSize  = 32
+)
+
+// newBlockCache returns a new empty block cache of the maximum size.
+func newBlockCache[T any](size int) *blockCache[T] {
+	return &blockCache[T]{
+		size:    size,
+		entries: make(map[string]blockCacheEntry[T]),
+		nowFunc: time.Now,
+	}
+}
+
+// blockCache caches beacon node block responses by block ID, avoiding repeated
+// queries by multiple validator clients polling the same blocks.
+type blockCache[T any] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]blockCacheEntry[T]
+	seq     uint64 // Incremented on each access, used for least recently used eviction.
+	nowFunc func() time.Time
+}
+
+type blockCacheEntry[T any] struct {
+	value    T
+	expiry   time.Time
+	lastUsed uint64
+}
+
+// Get returns the cached value of the block ID and true if present and not expired.
+func (c *blockCache[T]) Get(blockID string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[blockID]
+	if !ok || !c.nowFunc().Before(entry.expiry) {
+		var zero T
+		return zero, false
+	}
+
+	c.seq++
+	entry.lastUsed = c.seq
+	c.entries[blockID] = entry
+
+	return entry.value, true
+}
+
+// Set caches the value of the block ID, also trimming expired entries
+// and evicting the least recently used entry if the cache is full.
+func (c *blockCache[T]) Set(blockID string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFunc()
+	for id, entry := range c.entries {
+		if !now.Before(entry.expiry) {
+			delete(c.entries, id)
+		}
+	}
+
+	if _, ok := c.entries[blockID]; !ok && len(c.entries) >= c.size {
+		c.evictLRU()
+	}
+
+	ttl := blockCacheTTL
+	if strings.HasPrefix(blockID, "0x") {
+		ttl = blockCacheRootTTL
+	}
+
+	c.seq++
+	c.entries[blockID] = blockCacheEntry[T]{
+		value:    value,
+		expiry:   now.Add(ttl),
+		lastUsed: c.seq,
+	}
+}
+
+// evictLRU deletes the least recently used entry. It assumes the lock is held.
+func (c *blockCache[T]) evictLRU() {
+	var (
+		lruID string
+		lru   uint64
+		found bool
+	)
+
+	for id, entry := range c.entries {
+		if !found || entry.lastUsed < lru {
+			lruID, lru, found = id, entry.lastUsed, true
+		}
+	}
+
+	if found {
+		delete(c.entries, lruID)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	now := time.Now()
+	cache := newBlockCache[int](10)
+	cache.nowFunc = func() time.Time { return now }
+
+	_, ok := cache.Get("head")
+	require.False(t, ok)
+
+	cache.Set("head", 1)
+	cache.Set("0x1234", 2)
+
+	val, ok := cache.Get("head")
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+
+	// Aliases expire quickly.
+	now = now.Add(blockCacheTTL)
+	_, ok = cache.Get("head")
+	require.False(t, ok)
+
+	val, ok = cache.Get("0x1234")
+	require.True(t, ok)
+	require.Equal(t, 2, val)
+
+	// Roots expire eventually and are trimmed.
+	now = now.Add(blockCacheRootTTL)
+	_, ok = cache.Get("0x1234")
+	require.False(t, ok)
+
+	cache.Set("finalized", 3)
+	require.Len(t, cache.entries, 1)
+}
+
+func TestBlockCacheLRU(t *testing.T) {
+	cache := newBlockCache[int](2)
+
+	cache.Set("0x01", 1)
+	cache.Set("0x02", 2)
+
+	// Using 0x01 makes 0x02 the least recently used.
+	_, ok := cache.Get("0x01")
+	require.True(t, ok)
+
+	cache.Set("0x03", 3)
+	require.Len(t, cache.entries, 2)
+
+	_, ok = cache.Get("0x02")
+	require.False(t, ok)
+
+	val, ok := cache.Get("0x01")
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+
+	val, ok = cache.Get("0x03")
+	require.True(t, ok)
+	require.Equal(t, 3, val)
+}
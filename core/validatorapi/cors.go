@@ -0,0 +1,104 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const corsMaxAge = 600 // Seconds browsers may cache preflight responses.
+
+// defaultCORSHeaders are the request headers allowed cross-origin if none are configured.
+var defaultCORSHeaders = []string{"Accept", "Authorization", "Content-Type", "Eth-Consensus-Version"}
+
+// corsMethods are the request methods allowed cross-origin.
+var corsMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+
+// CORSConfig configures cross-origin requests to the validator API by browser-based tooling.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the API, "*" allows all origins.
+	AllowedOrigins []string
+	// AllowedHeaders are the request headers allowed in cross-origin requests, defaults to defaultCORSHeaders.
+	AllowedHeaders []string
+}
+
+// ValidateCORSConfig returns an error if the CORS configuration is invalid.
+func ValidateCORSConfig(conf CORSConfig) error {
+	for _, origin := range conf.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+
+		scheme, host, ok := strings.Cut(origin, "://")
+		if !ok || (scheme != "http" && scheme != "https") || host == "" || strings.Contains(host, "/") {
+			return errors.New("invalid CORS origin, expected scheme://host[:port] or *", z.Str("origin", origin))
+		}
+	}
+
+	if len(conf.AllowedHeaders) > 0 && len(conf.AllowedOrigins) == 0 {
+		return errors.New("CORS allowed headers require allowed origins")
+	}
+
+	return nil
+}
+
+// cors returns a middleware adding CORS headers to responses of requests from allowed origins
+// and responding to preflight requests.
+func cors(conf CORSConfig) func(http.Handler) http.Handler {
+	origins := make(map[string]bool)
+
+	var allowAll bool
+
+	for _, origin := range conf.AllowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+
+		origins[strings.ToLower(origin)] = true
+	}
+
+	headers := conf.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+
+	allowHeaders := strings.Join(headers, ", ")
+	allowMethods := strings.Join(corsMethods, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			allowed := allowAll || origins[strings.ToLower(origin)]
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !preflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Preflight requests are never forwarded, not even from disallowed origins.
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCORSConfig(t *testing.T) {
+	tests := []struct {
+		Name string
+		Conf CORSConfig
+		Err  string
+	}{
+		{Name: "disabled"},
+		{Name: "wildcard", Conf: CORSConfig{AllowedOrigins: []string{"*"}}},
+		{Name: "origins", Conf: CORSConfig{AllowedOrigins: []string{"https://example.com", "http://localhost:3000"}, AllowedHeaders: []string{"Content-Type"}}},
+		{Name: "missing scheme", Conf: CORSConfig{AllowedOrigins: []string{"example.com"}}, Err: "invalid CORS origin"},
+		{Name: "path", Conf: CORSConfig{AllowedOrigins: []string{"https://example.com/path"}}, Err: "invalid CORS origin"},
+		{Name: "headers without origins", Conf: CORSConfig{AllowedHeaders: []string{"Content-Type"}}, Err: "CORS allowed headers require allowed origins"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := ValidateCORSConfig(test.Conf)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	var served bool
+
+	handler := cors(CORSConfig{AllowedOrigins: []string{"https://example.com"}})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		served = true
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, origin string, preflight bool) *httptest.ResponseRecorder {
+		served = false
+
+		req := httptest.NewRequest(method, "/eth/v1/node/version", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("same origin", func(t *testing.T) {
+		rec := serve(http.MethodGet, "", false)
+		require.True(t, served)
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("allowed origin", func(t *testing.T) {
+		rec := serve(http.MethodGet, "https://example.com", false)
+		require.True(t, served)
+		require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Origin", rec.Header().Get("Vary"))
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		rec := serve(http.MethodGet, "https://other.com", false)
+		require.True(t, served)
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		rec := serve(http.MethodOptions, "https://example.com", true)
+		require.False(t, served)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Accept, Authorization, Content-Type, Eth-Consensus-Version", rec.Header().Get("Access-Control-Allow-Headers"))
+		require.Equal(t, "GET, POST, PUT, DELETE", rec.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("disallowed preflight", func(t *testing.T) {
+		rec := serve(http.MethodOptions, "https://other.com", true)
+		require.False(t, served)
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		require.Empty(t, rec.Header().Get("Access-Control-Allow-Headers"))
+	})
+}
+
+func TestRouterCORS(t *testing.T) {
+	r, err := NewRouter(context.Background(), testHandler{}, testBeaconAddr{addr: "http://localhost:0"}, true,
+		WithCORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{"Authorization"}}),
+		WithVCTokens([]VCToken{{Token: "token", Name: "vc"}}),
+	)
+	require.NoError(t, err)
+
+	// Preflight requests don't include credentials, so must not be authenticated.
+	req := httptest.NewRequest(http.MethodOptions, "/eth/v1/validator/attestation_data", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+
+	// Actual requests are still authenticated.
+	req = httptest.NewRequest(http.MethodGet, "/eth/v1/node/version", nil)
+	req.Header.Set("Origin", "https://example.com")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, "https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,88 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"fmt"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// unmarshalElectraAttestations returns the versioned attestations of an electra attestation pool submission.
+// Validator clients submit either the spec's SingleAttestation format, or the aggregated Attestation format with
+// a single aggregation bit of which the validator index is resolved from the attester duties on submission.
+func unmarshalElectraAttestations(typ contentType, body []byte) ([]*eth2spec.VersionedAttestation, error) {
+	singleAtts := new([]electra.SingleAttestation)
+
+	singleErr := unmarshal(typ, body, singleAtts)
+	if singleErr == nil {
+		var resp []*eth2spec.VersionedAttestation
+		for _, single := range *singleAtts {
+			resp = append(resp, singleToVersionedAttestation(single))
+		}
+
+		return resp, nil
+	}
+
+	aggAtts := new([]electra.Attestation)
+	if err := unmarshal(typ, body, aggAtts); err != nil {
+		// Neither format, return the error of the spec format.
+		return nil, singleErr
+	}
+
+	var resp []*eth2spec.VersionedAttestation
+	for _, att := range *aggAtts {
+		resp = append(resp, &eth2spec.VersionedAttestation{
+			Version: eth2spec.DataVersionElectra,
+			Electra: &att,
+		})
+	}
+
+	return resp, nil
+}
+
+// singleToVersionedAttestation returns the electra versioned attestation of the single attestation,
+// reconstructing the committee bits from the committee index.
+func singleToVersionedAttestation(single electra.SingleAttestation) *eth2spec.VersionedAttestation {
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(uint64(single.CommitteeIndex), true)
+
+	return &eth2spec.VersionedAttestation{
+		Version:        eth2spec.DataVersionElectra,
+		ValidatorIndex: &single.AttesterIndex,
+		Electra: &electra.Attestation{
+			// The VersionedAttestation object will be converted back to a SingleAttestation object inside go-eth2-client's
+			// SubmitAttestations, which disregards AggregationBits, so this empty Bitlist is safe.
+			AggregationBits: bitfield.NewBitlist(0),
+			Data:            single.Data,
+			Signature:       single.Signature,
+			CommitteeBits:   commBits,
+		},
+	}
+}
+
+// attestationToSingle returns the single attestation of the electra attestation of a single validator,
+// deriving the committee index from the committee bits.
+func attestationToSingle(att *electra.Attestation, valIdx eth2p0.ValidatorIndex) (*electra.SingleAttestation, error) {
+	if att == nil || att.Data == nil {
+		return nil, errors.New("missing electra attestation data")
+	}
+
+	commIndices := att.CommitteeBits.BitIndices()
+	if len(commIndices) != 1 {
+		return nil, errors.New("unexpected number of committee bits", z.Str("commbits", fmt.Sprintf("%#x", []byte(att.CommitteeBits))))
+	}
+
+	return &electra.SingleAttestation{
+		CommitteeIndex: eth2p0.CommitteeIndex(commIndices[0]),
+		AttesterIndex:  valIdx,
+		Data:           att.Data,
+		Signature:      att.Signature,
+	}, nil
+}
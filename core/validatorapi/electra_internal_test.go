@@ -0,0 +1,67 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestUnmarshalElectraAttestations(t *testing.T) {
+	data := testutil.RandomAttestationDataPhase0()
+
+	single := electra.SingleAttestation{
+		CommitteeIndex: 5,
+		AttesterIndex:  42,
+		Data:           data,
+		Signature:      testutil.RandomEth2Signature(),
+	}
+
+	// SingleAttestation format, committee bits are reconstructed.
+	b, err := json.Marshal([]electra.SingleAttestation{single})
+	require.NoError(t, err)
+
+	atts, err := unmarshalElectraAttestations(contentTypeJSON, b)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	require.EqualValues(t, 42, *atts[0].ValidatorIndex)
+	require.Equal(t, []int{5}, atts[0].Electra.CommitteeBits.BitIndices())
+
+	// And converted back.
+	converted, err := attestationToSingle(atts[0].Electra, *atts[0].ValidatorIndex)
+	require.NoError(t, err)
+	require.Equal(t, single, *converted)
+
+	// Aggregated Attestation format, the validator index is resolved on submission.
+	aggBits := bitfield.NewBitlist(8)
+	aggBits.SetBitAt(3, true)
+
+	b, err = json.Marshal([]electra.Attestation{{
+		AggregationBits: aggBits,
+		Data:            data,
+		Signature:       single.Signature,
+		CommitteeBits:   atts[0].Electra.CommitteeBits,
+	}})
+	require.NoError(t, err)
+
+	atts, err = unmarshalElectraAttestations(contentTypeJSON, b)
+	require.NoError(t, err)
+	require.Len(t, atts, 1)
+	require.Nil(t, atts[0].ValidatorIndex)
+	require.Equal(t, []int{3}, atts[0].Electra.AggregationBits.BitIndices())
+
+	_, err = unmarshalElectraAttestations(contentTypeJSON, []byte(`[{"foo":"bar"}]`))
+	require.Error(t, err)
+
+	// Attestations of multiple committees can't be converted.
+	atts[0].Electra.CommitteeBits.SetBitAt(6, true)
+	_, err = attestationToSingle(atts[0].Electra, eth2p0.ValidatorIndex(1))
+	require.ErrorContains(t, err, "unexpected number of committee bits")
+}
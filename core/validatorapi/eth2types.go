@@ -19,9 +19,16 @@ import (
 // errorResponse an error response from the beacon-node api.
 // See https://ethereum.github.io/beacon-APIs.
 type errorResponse struct {
-	Code    int    `json:"code"`
+	Code        int            `json:"code"`
+	Message     string         `json:"message"`
+	Stacktraces []string       `json:"stacktraces,omitempty"` // Always omitted, internals are not exposed.
+	Failures    []indexedError `json:"failures,omitempty"`
+}
+
+// indexedError is a failed item of a batch request, see errorResponse.
+type indexedError struct {
+	Index   int    `json:"index"`
 	Message string `json:"message"`
-	// TODO(corver): Maybe add stacktraces field for debugging.
 }
 
 // valIndexesJSON defines the request to the getAttesterDuties and getSyncCommitteeDuties endpoint.
@@ -94,6 +101,31 @@ type validatorResponse struct {
 	Data                v1Validator `json:"data"`
 }
 
+// blockHeadersResponse defines the response to the getBlockHeaders endpoint.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockHeaders.
+type blockHeadersResponse struct {
+	ExecutionOptimistic bool                        `json:"execution_optimistic"`
+	Finalized           bool                        `json:"finalized"`
+	Data                []*eth2v1.BeaconBlockHeader `json:"data"`
+}
+
+// blockHeaderResponse defines the response to the getBlockHeader endpoint.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockHeader.
+type blockHeaderResponse struct {
+	ExecutionOptimistic bool                      `json:"execution_optimistic"`
+	Finalized           bool                      `json:"finalized"`
+	Data                *eth2v1.BeaconBlockHeader `json:"data"`
+}
+
+// signedBlockResponse defines the response to the getBlockV2 endpoint.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockV2.
+type signedBlockResponse struct {
+	Version             string `json:"version"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+	Data                any    `json:"data"`
+}
+
 type aggregateAttestationV2Response struct {
 	Version string `json:"version"`
 	Data    any    `json:"data"`
@@ -149,6 +181,17 @@ type nodeVersionResponse struct {
 	} `json:"data"`
 }
 
+// nodeSyncingResponse defines the response to the node syncing endpoint.
+// See: https://ethereum.github.io/beacon-APIs/#/Node/getSyncingStatus
+type nodeSyncingResponse struct {
+	Data struct {
+		HeadSlot     string `json:"head_slot"`
+		SyncDistance string `json:"sync_distance"`
+		IsSyncing    bool   `json:"is_syncing"`
+		IsOptimistic bool   `json:"is_optimistic"`
+	} `json:"data"`
+}
+
 // SignedValidatorRegistrations defines the request body to the submit validator registration endpoint.
 // See: https://ethereum.github.io/beacon-APIs/#/Validator/registerValidator
 // Implements the ssz.Unmarshal interface
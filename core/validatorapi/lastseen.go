@@ -0,0 +1,145 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/core"
+)
+
+// defaultVCName identifies validator clients that are not authenticated with a bearer token.
+const defaultVCName = "default"
+
+// LastSeenValidatorClient is the time a validator client last referenced any validator.
+type LastSeenValidatorClient struct {
+	Name     string    `json:"name"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// LastSeenPubShare is the time a public share was last referenced by a validator client duties query or submission.
+// LastSeen is nil if the public share hasn't been referenced since startup.
+type LastSeenPubShare struct {
+	PubKey   string     `json:"pubkey"`
+	PubShare string     `json:"pubshare,omitempty"`
+	VC       string     `json:"vc,omitempty"` // Name of the validator client that last referenced the public share.
+	LastSeen *time.Time `json:"last_seen"`
+}
+
+// LastSeenResponse is the JSON response of the last seen handler.
+type LastSeenResponse struct {
+	ValidatorClients []LastSeenValidatorClient `json:"validator_clients"`
+	PubShares        []LastSeenPubShare        `json:"pubshares"`
+}
+
+// NewLastSeen returns a new last seen recorder of the cluster's validators.
+func NewLastSeen(pubkeys []core.PubKey) *LastSeen {
+	shares := make(map[core.PubKey]*LastSeenPubShare)
+	for _, pubkey := range pubkeys {
+		shares[pubkey] = &LastSeenPubShare{PubKey: string(pubkey)}
+	}
+
+	return &LastSeen{
+		vcs:    make(map[string]time.Time),
+		shares: shares,
+	}
+}
+
+// LastSeen records when each validator client and each public share was last seen in validator client
+// duties queries and submissions, detecting validator clients that silently dropped some keys
+// before missed duties reveal it.
+type LastSeen struct {
+	mu     sync.Mutex
+	vcs    map[string]time.Time
+	shares map[core.PubKey]*LastSeenPubShare
+}
+
+// setPubShares sets the public shares of the validators by root public key.
+func (l *LastSeen) setPubShares(sharesByKey map[core.PubKey]core.PubKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for pubkey, pubshare := range sharesByKey {
+		if share, ok := l.shares[pubkey]; ok {
+			share.PubShare = string(pubshare)
+		}
+	}
+}
+
+// observe records the validator (by root public key) as seen now by the validator client of the context.
+// Unknown validators are ignored.
+func (l *LastSeen) observe(ctx context.Context, pubkey core.PubKey) {
+	if l == nil {
+		return
+	}
+
+	vc := defaultVCName
+	if identity, ok := vcIdentityFromCtx(ctx); ok {
+		vc = identity.name
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	share, ok := l.shares[pubkey]
+	if !ok {
+		return
+	}
+
+	share.VC = vc
+	share.LastSeen = &now
+	l.vcs[vc] = now
+
+	vcLastSeenGauge.WithLabelValues(vc).Set(float64(now.Unix()))
+	pubshareLastSeenGauge.WithLabelValues(pubkey.String()).Set(float64(now.Unix()))
+}
+
+// Response returns the last seen validator clients and public shares, sorted by name and public key.
+func (l *LastSeen) Response() LastSeenResponse {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	resp := LastSeenResponse{
+		ValidatorClients: []LastSeenValidatorClient{},
+		PubShares:        []LastSeenPubShare{},
+	}
+
+	for name, lastSeen := range l.vcs {
+		resp.ValidatorClients = append(resp.ValidatorClients, LastSeenValidatorClient{Name: name, LastSeen: lastSeen})
+	}
+
+	for _, share := range l.shares {
+		resp.PubShares = append(resp.PubShares, *share)
+	}
+
+	sort.Slice(resp.ValidatorClients, func(i, j int) bool {
+		return resp.ValidatorClients[i].Name < resp.ValidatorClients[j].Name
+	})
+	sort.Slice(resp.PubShares, func(i, j int) bool {
+		return resp.PubShares[i].PubKey < resp.PubShares[j].PubKey
+	})
+
+	return resp
+}
+
+// ServeHTTP serves the last seen validator clients and public shares as JSON.
+func (l *LastSeen) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(l.Response())
+	if err != nil {
+		log.Warn(r.Context(), "Error serving last seen validators", err)
+		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
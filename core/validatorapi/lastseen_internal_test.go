@@ -0,0 +1,68 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLastSeen(t *testing.T) {
+	var (
+		seen    = testutil.RandomCorePubKey(t)
+		dropped = testutil.RandomCorePubKey(t)
+		share   = testutil.RandomCorePubKey(t)
+	)
+
+	lastSeen := NewLastSeen([]core.PubKey{seen, dropped})
+	lastSeen.setPubShares(map[core.PubKey]core.PubKey{seen: share})
+
+	ctx := withVCIdentity(context.Background(), vcIdentity{name: "vc1"})
+	lastSeen.observe(ctx, seen)
+	lastSeen.observe(ctx, testutil.RandomCorePubKey(t)) // Unknown validators are ignored.
+
+	var nilLastSeen *LastSeen
+	nilLastSeen.observe(ctx, seen) // Disabled recorders are ignored.
+
+	resp := lastSeen.Response()
+	require.Len(t, resp.ValidatorClients, 1)
+	require.Equal(t, "vc1", resp.ValidatorClients[0].Name)
+	require.Len(t, resp.PubShares, 2)
+
+	for _, s := range resp.PubShares {
+		if s.PubKey == string(seen) {
+			require.Equal(t, string(share), s.PubShare)
+			require.Equal(t, "vc1", s.VC)
+			require.NotNil(t, s.LastSeen)
+			require.Equal(t, resp.ValidatorClients[0].LastSeen, *s.LastSeen)
+		} else {
+			require.Equal(t, string(dropped), s.PubKey)
+			require.Nil(t, s.LastSeen)
+		}
+	}
+
+	require.InDelta(t, float64(resp.ValidatorClients[0].LastSeen.Unix()),
+		promtestutil.ToFloat64(pubshareLastSeenGauge.WithLabelValues(seen.String())), 0)
+
+	// Unauthenticated validator clients are recorded by the default name.
+	lastSeen.observe(context.Background(), dropped)
+	require.Len(t, lastSeen.Response().ValidatorClients, 2)
+
+	rec := httptest.NewRecorder()
+	lastSeen.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validators/last_seen", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var served LastSeenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Len(t, served.PubShares, 2)
+	require.Equal(t, defaultVCName, served.ValidatorClients[0].Name)
+}
@@ -0,0 +1,176 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Endpoint classes with bounded concurrency, protecting the core workflow from validator clients
+// opening many blocking requests.
+const (
+	// ClassProposal are block proposal requests awaiting the proposer duty.
+	ClassProposal = "proposal"
+	// ClassAttestationData are attestation data requests awaiting the attester duty.
+	ClassAttestationData = "attestation_data"
+	// ClassAggregation are aggregate attestation and sync committee contribution requests awaiting aggregation.
+	ClassAggregation = "aggregation"
+	// ClassDuties are attester, proposer and sync committee duty queries.
+	ClassDuties = "duties"
+	// ClassValidators are validator queries.
+	ClassValidators = "validators"
+)
+
+// endpointClasses maps endpoint names to their concurrency limited class.
+var endpointClasses = map[string]string{
+	"propose_block":               ClassProposal,
+	"propose_blinded_block":       ClassProposal,
+	"propose_block_v3":            ClassProposal,
+	"attestation_data":            ClassAttestationData,
+	"aggregate_attestation":       ClassAggregation,
+	"aggregate_attestation_v2":    ClassAggregation,
+	"sync_committee_contribution": ClassAggregation,
+	"attester_duties":             ClassDuties,
+	"proposer_duties":             ClassDuties,
+	"sync_committee_duties":       ClassDuties,
+	"get_validators":              ClassValidators,
+	"get_validator":               ClassValidators,
+}
+
+// defaultConcurrencyLimits are the default maximum concurrent requests per endpoint class.
+// An equal number of requests may queue, beyond which requests are rejected with 429.
+var defaultConcurrencyLimits = map[string]int{
+	ClassProposal:        16,
+	ClassAttestationData: 512,
+	ClassAggregation:     512,
+	ClassDuties:          64,
+	ClassValidators:      32,
+}
+
+// ParseConcurrencyLimits parses limits formatted as "class=limit", e.g. "proposal=4".
+// A limit of zero disables limiting the class.
+func ParseConcurrencyLimits(limits []string) (map[string]int, error) {
+	resp := make(map[string]int)
+
+	for _, limit := range limits {
+		class, val, ok := strings.Cut(limit, "=")
+		if !ok {
+			return nil, errors.New("invalid concurrency limit, expect class=limit", z.Str("limit", limit))
+		}
+
+		if _, ok := defaultConcurrencyLimits[class]; !ok {
+			return nil, errors.New("unknown concurrency limit class", z.Str("limit", limit), z.Str("classes", concurrencyClasses()))
+		}
+
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid concurrency limit, expect non-negative integer", z.Str("limit", limit))
+		}
+
+		if _, ok := resp[class]; ok {
+			return nil, errors.New("duplicate concurrency limit class", z.Str("class", class))
+		}
+
+		resp[class] = n
+	}
+
+	return resp, nil
+}
+
+// concurrencyClasses returns the comma-separated sorted endpoint classes.
+func concurrencyClasses() string {
+	var classes []string
+	for class := range defaultConcurrencyLimits {
+		classes = append(classes, class)
+	}
+
+	sort.Strings(classes)
+
+	return strings.Join(classes, ",")
+}
+
+// newConcurrencyLimiters returns the limiters by endpoint class, overriding the defaults with the limits.
+func newConcurrencyLimiters(limits map[string]int) map[string]*concurrencyLimiter {
+	resp := make(map[string]*concurrencyLimiter)
+
+	for class, limit := range defaultConcurrencyLimits {
+		if override, ok := limits[class]; ok {
+			limit = override
+		}
+
+		if limit == 0 {
+			continue
+		}
+
+		resp[class] = &concurrencyLimiter{
+			class:   class,
+			running: make(chan struct{}, limit),
+			pending: make(chan struct{}, 2*limit), // Running plus queued.
+		}
+	}
+
+	return resp
+}
+
+// concurrencyLimiter bounds the number of concurrent requests of an endpoint class,
+// queueing requests beyond the limit and rejecting requests once the queue is full.
+type concurrencyLimiter struct {
+	class   string
+	running chan struct{}
+	pending chan struct{}
+}
+
+// Wrap returns a handler limiting the concurrency of the endpoint handler.
+func (l *concurrencyLimiter) Wrap(endpoint string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := log.WithTopic(r.Context(), "vapi")
+		ctx = log.WithCtx(ctx, z.Str("vapi_endpoint", endpoint))
+
+		select {
+		case l.pending <- struct{}{}:
+			defer func() { <-l.pending }()
+		default:
+			concurrencyRejected.WithLabelValues(l.class).Inc()
+			writeError(ctx, w, endpoint, apiError{
+				StatusCode: http.StatusTooManyRequests,
+				Message:    "too many concurrent requests",
+				Err:        errors.New("concurrency limit exceeded", z.Str("class", l.class)),
+			})
+
+			return
+		}
+
+		timer := time.NewTimer(defaultRequestTimeout)
+		defer timer.Stop()
+
+		select {
+		case l.running <- struct{}{}:
+			defer func() { <-l.running }()
+		case <-timer.C:
+			concurrencyRejected.WithLabelValues(l.class).Inc()
+			writeError(ctx, w, endpoint, apiError{
+				StatusCode: http.StatusTooManyRequests,
+				Message:    "too many concurrent requests",
+				Err:        errors.New("concurrency limit queue timeout", z.Str("class", l.class)),
+			})
+
+			return
+		case <-ctx.Done():
+			writeError(ctx, w, endpoint, ctx.Err())
+			return
+		}
+
+		concurrentRequests.WithLabelValues(l.class).Inc()
+		defer concurrentRequests.WithLabelValues(l.class).Dec()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConcurrencyLimits(t *testing.T) {
+	limits, err := ParseConcurrencyLimits([]string{"proposal=4", "validators=0"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{ClassProposal: 4, ClassValidators: 0}, limits)
+
+	_, err = ParseConcurrencyLimits([]string{"proposal"})
+	require.ErrorContains(t, err, "expect class=limit")
+
+	_, err = ParseConcurrencyLimits([]string{"unknown=1"})
+	require.ErrorContains(t, err, "unknown concurrency limit class")
+
+	_, err = ParseConcurrencyLimits([]string{"proposal=-1"})
+	require.ErrorContains(t, err, "expect non-negative integer")
+
+	_, err = ParseConcurrencyLimits([]string{"proposal=1", "proposal=2"})
+	require.ErrorContains(t, err, "duplicate concurrency limit class")
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	limiters := newConcurrencyLimiters(map[string]int{ClassProposal: 1, ClassValidators: 0})
+	require.NotContains(t, limiters, ClassValidators)
+	require.Contains(t, limiters, ClassDuties)
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	handler := limiters[ClassProposal].Wrap("propose_block_v3", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func() <-chan int {
+		codes := make(chan int, 1)
+		go func() {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v3/validator/blocks/1", nil))
+			codes <- rec.Code
+		}()
+
+		return codes
+	}
+
+	// First request runs, second request queues.
+	first := serve()
+	<-started
+
+	second := serve()
+
+	// Wait for the second request to queue.
+	require.Eventually(t, func() bool {
+		return len(limiters[ClassProposal].pending) == 2
+	}, time.Second, time.Millisecond)
+
+	// Third request exceeds the queue.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v3/validator/blocks/1", nil))
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	var errRes errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errRes))
+	require.Equal(t, errorResponse{Code: http.StatusTooManyRequests, Message: "too many concurrent requests"}, errRes)
+
+	release <- struct{}{}
+	require.Equal(t, http.StatusOK, <-first)
+
+	<-started
+	release <- struct{}{}
+	require.Equal(t, http.StatusOK, <-second)
+}
@@ -27,6 +27,34 @@ var (
 		Help:      "The validatorapi proxy request latencies in seconds by path",
 	}, []string{"path"})
 
+	proxyCircuitOpenCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "proxy_circuit_open_total",
+		Help:      "The total number of times a beacon node circuit breaker opened after consecutive proxied request failures",
+	})
+
+	proxyHedgedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "proxy_hedged_total",
+		Help:      "The total number of proxied requests hedged to a secondary beacon node",
+	})
+
+	proxyStreamedBytesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "proxy_streamed_bytes_total",
+		Help:      "The total number of response bytes streamed from the beacon node to validator clients by the proxy",
+	})
+
+	proxyResponseTooLargeCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "proxy_response_too_large_total",
+		Help:      "The total number of proxied responses rejected or aborted for exceeding the maximum response size",
+	})
+
 	apiErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "validatorapi",
@@ -47,6 +75,69 @@ var (
 		Name:      "vc_user_agent",
 		Help:      "Gauge with label set to user agent string of requests made by VC",
 	}, []string{"user_agent"})
+
+	proposalTypeConversions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "proposal_type_conversions_total",
+		Help:      "The total number of proposals converted to the type forced for the validator client",
+	}, []string{"type"})
+
+	vcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_request_total",
+		Help:      "The total number of requests per endpoint by authenticated validator client name",
+	}, []string{"endpoint", "vc"})
+
+	mismatchedShareCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "mismatched_keyshare_total",
+		Help:      "The total number of validator client submissions of key shares belonging to another charon node by its 0-indexed key share index",
+	}, []string{"key_share_index"})
+
+	dedupedRegistrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "registration_deduplicated_total",
+		Help:      "The total number of builder registrations skipped since identical to the last accepted registration of the validator",
+	})
+
+	concurrentRequests = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "concurrent_requests",
+		Help:      "The number of concurrently handled requests by concurrency limited endpoint class",
+	}, []string{"class"})
+
+	concurrencyRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "concurrency_rejected_total",
+		Help:      "The total number of requests rejected with 429 due to exceeding the concurrency limit of the endpoint class",
+	}, []string{"class"})
+
+	vcAuthFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_auth_failures_total",
+		Help:      "The total number of validator client requests rejected due to a missing or invalid bearer token",
+	})
+
+	vcLastSeenGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "vc_last_seen_timestamp_seconds",
+		Help:      "Unix timestamp of the last duties query or submission of any validator by validator client name",
+	}, []string{"vc"})
+
+	pubshareLastSeenGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "validatorapi",
+		Name:      "pubshare_last_seen_timestamp_seconds",
+		Help:      "Unix timestamp of the last validator client duties query or submission by validator",
+	}, []string{"pubkey"})
 )
 
 func incAPIErrors(endpoint string, statusCode int) {
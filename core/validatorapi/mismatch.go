@@ -0,0 +1,102 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// maxMismatchedShares is the number of most recent mismatched key share submissions retained.
+const maxMismatchedShares = 100
+
+// MismatchedShare is a validator client submission of a public share belonging to another charon node,
+// the common "validator client loaded another node's keys" misconfiguration.
+type MismatchedShare struct {
+	Time            time.Time `json:"time"`
+	PubShare        string    `json:"pubshare"`
+	PubKey          string    `json:"pubkey"`
+	KeyShareIndex   int       `json:"key_share_index"`   // 0-indexed charon node the submitted key share belongs to.
+	CharonPeerIndex int       `json:"charon_peer_index"` // 0-indexed charon node the key share was submitted to.
+}
+
+// mismatchedShareError is returned when a validator client submits a public share belonging to another charon node.
+type mismatchedShareError struct {
+	share MismatchedShare
+}
+
+func (e mismatchedShareError) Error() string {
+	return fmt.Sprintf("mismatching validator client key share index, Mth key share submitted to Nth charon peer: "+
+		"key_share_index=%d, charon_peer_index=%d", e.share.KeyShareIndex, e.share.CharonPeerIndex)
+}
+
+// NewMismatchedShares returns a new empty mismatched key share recorder.
+func NewMismatchedShares() *MismatchedShares {
+	return &MismatchedShares{}
+}
+
+// MismatchedShares records the most recent mismatched key share submissions and serves them as JSON.
+type MismatchedShares struct {
+	mu     sync.Mutex
+	shares []MismatchedShare
+}
+
+// record records and logs the mismatched key share submission and returns an actionable error for the validator client.
+func (m *MismatchedShares) record(ctx context.Context, mismatch mismatchedShareError) error {
+	share := mismatch.share
+
+	log.Warn(ctx, "Validator client submitted key share of another charon node, check validator client keys", nil,
+		z.Int("key_share_index", share.KeyShareIndex),
+		z.Int("charon_peer_index", share.CharonPeerIndex),
+		z.Str("pubshare", share.PubShare),
+		z.Str("pubkey", share.PubKey))
+
+	mismatchedShareCounter.WithLabelValues(strconv.Itoa(share.KeyShareIndex)).Inc()
+
+	m.mu.Lock()
+	m.shares = append(m.shares, share)
+	if len(m.shares) > maxMismatchedShares {
+		m.shares = m.shares[len(m.shares)-maxMismatchedShares:]
+	}
+	m.mu.Unlock()
+
+	return apiError{
+		StatusCode: http.StatusBadRequest,
+		Message: fmt.Sprintf("mismatching validator client key share: key share of charon node %d submitted to charon node %d, "+
+			"configure the validator client of charon node %d with its own key shares (0-indexed)",
+			share.KeyShareIndex, share.CharonPeerIndex, share.CharonPeerIndex),
+		Err: errors.Wrap(mismatch, "mismatching validator client key share",
+			z.Str("pubshare", share.PubShare), z.Str("pubkey", share.PubKey)),
+	}
+}
+
+// Shares returns the most recent mismatched key share submissions, oldest first.
+func (m *MismatchedShares) Shares() []MismatchedShare {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MismatchedShare(nil), m.shares...)
+}
+
+// ServeHTTP serves the most recent mismatched key share submissions as JSON.
+func (m *MismatchedShares) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(m.Shares())
+	if err != nil {
+		log.Warn(r.Context(), "Error serving mismatched key shares", err)
+		http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMismatchedShares(t *testing.T) {
+	mismatches := NewMismatchedShares()
+
+	err := mismatches.record(context.Background(), mismatchedShareError{share: MismatchedShare{
+		PubShare:        "0x01",
+		PubKey:          "0x02",
+		KeyShareIndex:   2,
+		CharonPeerIndex: 0,
+	}})
+
+	var apiErr apiError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Contains(t, apiErr.Message, "key share of charon node 2 submitted to charon node 0")
+
+	var mismatch mismatchedShareError
+	require.ErrorAs(t, apiErr.Err, &mismatch)
+
+	// Only the most recent submissions are retained.
+	for i := range maxMismatchedShares {
+		_ = mismatches.record(context.Background(), mismatchedShareError{share: MismatchedShare{KeyShareIndex: i}})
+	}
+
+	shares := mismatches.Shares()
+	require.Len(t, shares, maxMismatchedShares)
+	require.Equal(t, 0, shares[0].KeyShareIndex)
+	require.Equal(t, maxMismatchedShares-1, shares[len(shares)-1].KeyShareIndex)
+
+	rec := httptest.NewRecorder()
+	mismatches.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validators/mismatched_keyshares", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var served []MismatchedShare
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Equal(t, shares, served)
+}
@@ -176,6 +176,36 @@ func (_m *Handler) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesO
 	return r0, r1
 }
 
+// BeaconBlockHeader provides a mock function with given fields: ctx, opts
+func (_m *Handler) BeaconBlockHeader(ctx context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*v1.BeaconBlockHeader], error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeaconBlockHeader")
+	}
+
+	var r0 *api.Response[*v1.BeaconBlockHeader]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.BeaconBlockHeaderOpts) (*api.Response[*v1.BeaconBlockHeader], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.BeaconBlockHeaderOpts) *api.Response[*v1.BeaconBlockHeader]); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Response[*v1.BeaconBlockHeader])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.BeaconBlockHeaderOpts) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NodeVersion provides a mock function with given fields: ctx, opts
 func (_m *Handler) NodeVersion(ctx context.Context, opts *api.NodeVersionOpts) (*api.Response[string], error) {
 	ret := _m.Called(ctx, opts)
@@ -296,6 +326,36 @@ func (_m *Handler) ProposerDuties(ctx context.Context, opts *api.ProposerDutiesO
 	return r0, r1
 }
 
+// SignedBeaconBlock provides a mock function with given fields: ctx, opts
+func (_m *Handler) SignedBeaconBlock(ctx context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignedBeaconBlock")
+	}
+
+	var r0 *api.Response[*spec.VersionedSignedBeaconBlock]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *api.SignedBeaconBlockOpts) *api.Response[*spec.VersionedSignedBeaconBlock]); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Response[*spec.VersionedSignedBeaconBlock])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *api.SignedBeaconBlockOpts) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitAggregateAttestations provides a mock function with given fields: ctx, opts
 func (_m *Handler) SubmitAggregateAttestations(ctx context.Context, opts *api.SubmitAggregateAttestationsOpts) error {
 	ret := _m.Called(ctx, opts)
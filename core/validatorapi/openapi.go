@@ -0,0 +1,145 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/version"
+)
+
+const (
+	// openAPIPath is the path the OpenAPI spec is served at.
+	openAPIPath = "/eth/v1/charon/openapi"
+
+	// extHandled annotates operations terminated by charon (true) or reverse-proxied to the beacon node (false).
+	extHandled = "x-charon-handled"
+)
+
+// pathParamRegex matches path parameters like "{epoch}".
+var pathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// openAPIParameter is an OpenAPI parameter object.
+type openAPIParameter struct {
+	Name     string            `json:"name"`
+	In       string            `json:"in"`
+	Required bool              `json:"required"`
+	Schema   map[string]string `json:"schema"`
+}
+
+// openAPIOperation is an OpenAPI operation object including the charon extensions.
+type openAPIOperation struct {
+	OperationID string                    `json:"operationId"`
+	Parameters  []openAPIParameter        `json:"parameters,omitempty"`
+	RequestBody *openAPIContent           `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIContent `json:"responses"`
+	Handled     bool                      `json:"x-charon-handled"`
+	// Partial annotates operations terminated by charon, of which some requests are reverse-proxied to the beacon node.
+	Partial bool `json:"x-charon-partially-proxied,omitempty"`
+}
+
+// openAPIContent is an OpenAPI request body or response object.
+type openAPIContent struct {
+	Description string              `json:"description,omitempty"`
+	Content     map[string]struct{} `json:"content,omitempty"`
+}
+
+// openAPISpec is the OpenAPI document describing the validator API surface of this charon version.
+type openAPISpec struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    map[string]string                      `json:"info"`
+	Proxy   string                                 `json:"x-charon-proxy"` // Describes the reverse-proxying of unlisted paths.
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+// newOpenAPISpec returns a handler serving the OpenAPI spec of the endpoints, annotating
+// which are terminated by charon and which are reverse-proxied to the beacon node.
+func newOpenAPISpec(endpoints []endpoint, nodeStatus bool) (http.Handler, error) {
+	spec := openAPISpec{
+		OpenAPI: "3.0.3",
+		Info: map[string]string{
+			"title":       "Charon Validator API",
+			"version":     version.Version.String(),
+			"description": "The beacon node API endpoints terminated by the charon distributed validator middleware.",
+		},
+		Proxy: "All paths not listed or not annotated with " + extHandled + " are reverse-proxied to the beacon node.",
+		Paths: make(map[string]map[string]openAPIOperation),
+	}
+
+	addOperation := func(path, method string, op openAPIOperation) {
+		if spec.Paths[path] == nil {
+			spec.Paths[path] = make(map[string]openAPIOperation)
+		}
+
+		spec.Paths[path][strings.ToLower(method)] = op
+	}
+
+	for _, e := range endpoints {
+		handled, partial := true, false
+		if e.Matcher != nil {
+			// Requests without query parameters are representative of whether the endpoint is enabled.
+			req, err := http.NewRequest(http.MethodGet, e.Path, nil)
+			if err != nil {
+				return nil, errors.Wrap(err, "new request")
+			}
+
+			handled, partial = e.Matcher(req, nil), true
+		}
+
+		var params []openAPIParameter
+		for _, match := range pathParamRegex.FindAllStringSubmatch(e.Path, -1) {
+			params = append(params, openAPIParameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   map[string]string{"type": "string"},
+			})
+		}
+
+		content := make(map[string]struct{})
+		for _, encoding := range e.Encodings {
+			content[string(encoding)] = struct{}{}
+		}
+
+		for _, method := range e.Methods {
+			op := openAPIOperation{
+				OperationID: e.Name,
+				Parameters:  params,
+				Responses:   map[string]openAPIContent{"200": {Description: "Success"}},
+				Handled:     handled,
+				Partial:     handled && partial,
+			}
+
+			if method == http.MethodPost {
+				op.RequestBody = &openAPIContent{Content: content}
+			}
+
+			addOperation(e.Path, method, op)
+		}
+	}
+
+	addOperation("/eth/v1/node/health", http.MethodGet, openAPIOperation{
+		OperationID: "node_health",
+		Responses:   map[string]openAPIContent{"200": {Description: "Node is ready"}},
+		Handled:     nodeStatus,
+	})
+	addOperation(openAPIPath, http.MethodGet, openAPIOperation{
+		OperationID: "charon_openapi",
+		Responses:   map[string]openAPIContent{"200": {Description: "This OpenAPI spec"}},
+		Handled:     true,
+	})
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal openapi spec")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}), nil
+}
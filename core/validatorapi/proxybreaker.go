@@ -0,0 +1,385 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// errCircuitOpen is returned for proxied requests failed fast since the beacon node's circuit breaker is open.
+var errCircuitOpen = errors.New("beacon node circuit breaker open")
+
+// circuitBreaker fails requests to a beacon node fast after consecutive failures. It is closed (allowing all requests)
+// until the failure threshold is reached, then open (rejecting all requests) until the cooldown elapsed,
+// then half-open allowing a single probe request that either closes or re-opens it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time // Zero if closed.
+	probing  bool
+}
+
+// allow returns true if a request may be sent to the beacon node, and whether it is the half-open probe request.
+func (b *circuitBreaker) allow() (ok bool, probe bool) {
+	if b == nil {
+		return true, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true, false
+	}
+
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false, false
+	}
+
+	// Half-open, allow a single probe request.
+	b.probing = true
+
+	return true, true
+}
+
+// report records the outcome of a request sent to the beacon node.
+func (b *circuitBreaker) report(success bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		b.probing = false
+
+		return
+	}
+
+	b.failures++
+
+	if b.probing || (b.openedAt.IsZero() && b.failures >= b.threshold) {
+		if b.openedAt.IsZero() {
+			proxyCircuitOpenCounter.Inc()
+		}
+
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// release allows another probe request since the half-open probe request was cancelled without outcome.
+// It must only be called for the probe request, since other requests don't hold the probe.
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// proxyResilience makes requests proxied to a flapping primary beacon node more resilient, by failing fast
+// using per beacon node circuit breakers, and by hedging latency sensitive requests to a secondary beacon node.
+type proxyResilience struct {
+	failures   int
+	cooldown   time.Duration
+	hedgeAddrs []*url.URL
+	hedgeDelay time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// newProxyResilience returns a new proxy resilience or nil if both the circuit breaker (zero failures)
+// and hedging (zero delay) are disabled. The hedge addresses are the beacon nodes used as secondary.
+func newProxyResilience(failures int, cooldown time.Duration, hedgeAddrs []string, hedgeDelay time.Duration) (*proxyResilience, error) {
+	if failures <= 0 && hedgeDelay <= 0 {
+		return nil, nil //nolint:nilnil // Disabled.
+	}
+
+	var urls []*url.URL
+	for _, addr := range hedgeAddrs {
+		u, err := url.ParseRequestURI(addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hedge beacon node address", z.Str("address", addr))
+		}
+
+		urls = append(urls, u)
+	}
+
+	return &proxyResilience{
+		failures:   failures,
+		cooldown:   cooldown,
+		hedgeAddrs: urls,
+		hedgeDelay: hedgeDelay,
+		breakers:   make(map[string]*circuitBreaker),
+	}, nil
+}
+
+// breaker returns the circuit breaker of the beacon node or nil if circuit breaking is disabled.
+func (p *proxyResilience) breaker(target *url.URL) *circuitBreaker {
+	if p.failures <= 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.breakers[target.Host]
+	if !ok {
+		b = &circuitBreaker{threshold: p.failures, cooldown: p.cooldown, now: time.Now}
+		p.breakers[target.Host] = b
+	}
+
+	return b
+}
+
+// transport returns the round tripper of requests proxied to the primary beacon node.
+// It returns nil, i.e., the default transport, if resilience is disabled.
+func (p *proxyResilience) transport(primary *url.URL) http.RoundTripper {
+	if p == nil {
+		return nil
+	}
+
+	var secondary *url.URL
+	for _, addr := range p.hedgeAddrs {
+		if addr.Host != primary.Host {
+			secondary = addr
+			break
+		}
+	}
+
+	return proxyTransport{
+		resilience: p,
+		base:       http.DefaultTransport,
+		primary:    primary,
+		secondary:  secondary,
+	}
+}
+
+// proxyTransport is a round tripper of requests proxied to the primary beacon node,
+// failing over or hedging to the secondary beacon node if configured.
+type proxyTransport struct {
+	resilience *proxyResilience
+	base       http.RoundTripper
+	primary    *url.URL
+	secondary  *url.URL // Nil if no secondary beacon node is configured.
+}
+
+func (t proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ok, probe := t.resilience.breaker(t.primary).allow()
+	if !ok {
+		if t.secondary == nil {
+			return nil, errCircuitOpen
+		}
+
+		// Fail over to the secondary beacon node, unless its circuit breaker is open too.
+		ok, probe = t.resilience.breaker(t.secondary).allow()
+		if !ok {
+			return nil, errCircuitOpen
+		}
+
+		return t.roundTrip(retarget(req.Context(), req, t.primary, t.secondary), t.secondary, probe)
+	}
+
+	if t.secondary == nil || t.resilience.hedgeDelay <= 0 || !hedgeable(req) {
+		return t.roundTrip(req, t.primary, probe)
+	}
+
+	return t.hedge(req, probe)
+}
+
+// roundTrip sends the request to the beacon node, reporting the outcome to its circuit breaker.
+// Probe is true if the request is the beacon node's half-open probe request.
+func (t proxyTransport) roundTrip(req *http.Request, target *url.URL, probe bool) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if req.Context().Err() != nil {
+		// Cancelled requests, e.g. hedged requests that lost, aren't beacon node failures.
+		if probe {
+			t.resilience.breaker(target).release()
+		}
+
+		return resp, err
+	}
+
+	t.resilience.breaker(target).report(err == nil && resp.StatusCode < http.StatusInternalServerError)
+
+	return resp, err
+}
+
+// hedgeResult is the result of a hedged request.
+type hedgeResult struct {
+	target *url.URL
+	resp   *http.Response
+	err    error
+}
+
+// failed returns true if the beacon node failed to respond successfully.
+func (r hedgeResult) failed() bool {
+	return r.err != nil || r.resp.StatusCode >= http.StatusInternalServerError
+}
+
+// close closes the response body, if any.
+func (r hedgeResult) close() {
+	if r.resp != nil {
+		_ = r.resp.Body.Close()
+	}
+}
+
+// hedge sends the request to the primary beacon node, and also to the secondary beacon node if
+// the primary fails or doesn't respond within the hedge delay and the secondary's circuit breaker allows it.
+// The first successful response is returned. Probe is true if the request is the primary's half-open probe request.
+func (t proxyTransport) hedge(req *http.Request, probe bool) (*http.Response, error) {
+	var (
+		results = make(chan hedgeResult, 2)
+		cancels = make(map[*url.URL]context.CancelFunc)
+		pending int
+	)
+
+	send := func(target *url.URL, probe bool) {
+		ctx, cancel := context.WithCancel(req.Context())
+		cancels[target] = cancel
+		pending++
+
+		out := req.WithContext(ctx)
+		if target != t.primary {
+			out = retarget(ctx, req, t.primary, target)
+		}
+
+		go func() {
+			resp, err := t.roundTrip(out, target, probe)
+			results <- hedgeResult{target: target, resp: resp, err: err}
+		}()
+	}
+
+	// hedge sends the request to the secondary beacon node, returning false if its circuit breaker is open.
+	hedge := func() bool {
+		ok, probe := t.resilience.breaker(t.secondary).allow()
+		if !ok {
+			return false
+		}
+
+		proxyHedgedCounter.Inc()
+		send(t.secondary, probe)
+
+		return true
+	}
+
+	send(t.primary, probe)
+
+	timer := time.NewTimer(t.resilience.hedgeDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if len(cancels) == 1 {
+				hedge()
+			}
+		case res := <-results:
+			pending--
+
+			if res.failed() && len(cancels) == 1 && hedge() {
+				// Primary failed before the hedge delay, hedged immediately.
+				res.close()
+				cancels[res.target]()
+
+				continue
+			} else if res.failed() && pending > 0 {
+				// Wait for the other request.
+				res.close()
+				cancels[res.target]()
+
+				continue
+			}
+
+			// Cancel and drain the other request, if still pending.
+			for target, cancel := range cancels {
+				if target != res.target {
+					cancel()
+				}
+			}
+
+			go func(pending int) {
+				for range pending {
+					(<-results).close()
+				}
+			}(pending)
+
+			if res.err != nil {
+				cancels[res.target]()
+				return nil, res.err
+			}
+
+			// Cancel the request context once the response body is consumed.
+			res.resp.Body = cancelBody{ReadCloser: res.resp.Body, cancel: cancels[res.target]}
+
+			return res.resp, nil
+		}
+	}
+}
+
+// hedgeable returns true if the request is latency sensitive and safe to send to multiple beacon nodes.
+func hedgeable(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	// Event streams are long-lived.
+	return !strings.Contains(req.Header.Get("Accept"), "text/event-stream") && !strings.HasSuffix(req.URL.Path, "/events")
+}
+
+// retarget returns a clone of the request proxied to the beacon node address from, proxied to the beacon node address to.
+func retarget(ctx context.Context, req *http.Request, from, to *url.URL) *http.Request {
+	out := req.Clone(ctx)
+	out.URL.Scheme = to.Scheme
+	out.URL.Host = to.Host
+	out.URL.Path = strings.TrimSuffix(to.Path, "/") + "/" + strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(from.Path, "/")), "/")
+	out.URL.RawPath = ""
+
+	if out.Host == from.Host {
+		out.Host = to.Host
+	}
+
+	if from.User != nil {
+		out.Header.Del("Authorization")
+	}
+
+	if to.User != nil {
+		password, _ := to.User.Password()
+		out.SetBasicAuth(to.User.Username(), password)
+	}
+
+	return out
+}
+
+// cancelBody cancels the request context when the response body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
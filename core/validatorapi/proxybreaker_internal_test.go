@@ -0,0 +1,243 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{threshold: 2, cooldown: time.Minute, now: func() time.Time { return now }}
+
+	requireAllow := func(b *circuitBreaker, expectOK, expectProbe bool) {
+		t.Helper()
+
+		ok, probe := b.allow()
+		require.Equal(t, expectOK, ok)
+		require.Equal(t, expectProbe, probe)
+	}
+
+	b.report(false)
+	requireAllow(b, true, false)
+
+	// Opens after consecutive failures.
+	b.report(false)
+	requireAllow(b, false, false)
+
+	// Half-open after the cooldown, allowing a single probe.
+	now = now.Add(time.Minute)
+	requireAllow(b, true, true)
+	requireAllow(b, false, false)
+
+	// Failed probe re-opens.
+	b.report(false)
+	requireAllow(b, false, false)
+
+	// Cancelled probe allows another probe.
+	now = now.Add(time.Minute)
+	requireAllow(b, true, true)
+	b.release()
+	requireAllow(b, true, true)
+
+	// Successful probe closes.
+	b.report(true)
+	requireAllow(b, true, false)
+	b.report(false)
+	requireAllow(b, true, false)
+
+	var disabled *circuitBreaker
+	disabled.report(false)
+	requireAllow(disabled, true, false)
+}
+
+func TestProxyCancelledRequestKeepsProbe(t *testing.T) {
+	release := make(chan struct{})
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer target.Close()
+	defer close(release)
+
+	resilience, err := newProxyResilience(1, time.Hour, nil, 0)
+	require.NoError(t, err)
+
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+
+	transport := resilience.transport(targetURL)
+	breaker := resilience.breaker(targetURL)
+
+	// Send a slow request while the circuit is closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	slowReq, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL+"/slow", nil)
+	require.NoError(t, err)
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+
+		resp, err := transport.RoundTrip(slowReq)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	// Open the circuit and start the half-open probe.
+	failReq, err := http.NewRequest(http.MethodGet, target.URL+"/fail", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(failReq)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	breaker.mu.Lock()
+	breaker.openedAt = breaker.openedAt.Add(-time.Hour)
+	breaker.mu.Unlock()
+
+	ok, probe := breaker.allow()
+	require.True(t, ok)
+	require.True(t, probe)
+
+	// Cancelling the slow request that isn't the probe doesn't allow another probe.
+	cancel()
+	<-slowDone
+
+	ok, _ = breaker.allow()
+	require.False(t, ok)
+}
+
+func TestProxyCircuitBreaker(t *testing.T) {
+	var hits atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer target.Close()
+
+	resilience, err := newProxyResilience(2, time.Hour, nil, 0)
+	require.NoError(t, err)
+
+	proxy := httptest.NewServer(proxyHandler(context.Background(), addr(target.URL), nil, nil, resilience, 0))
+	defer proxy.Close()
+
+	get := func() int {
+		resp, err := http.Get(proxy.URL + "/eth/v1/node/version")
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusInternalServerError, get())
+	require.Equal(t, http.StatusInternalServerError, get())
+
+	// Circuit open, fail fast.
+	require.Equal(t, http.StatusServiceUnavailable, get())
+	require.EqualValues(t, 2, hits.Load())
+}
+
+func TestProxyHedging(t *testing.T) {
+	primaryDown := make(chan struct{})
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		select {
+		case <-primaryDown:
+		case <-r.Context().Done():
+		}
+	}))
+	defer primary.Close()
+	defer close(primaryDown)
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("secondary " + r.URL.Path))
+	}))
+	defer secondary.Close()
+
+	resilience, err := newProxyResilience(1, time.Hour, []string{primary.URL, secondary.URL}, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	proxy := httptest.NewServer(proxyHandler(context.Background(), addr(primary.URL), nil, nil, resilience, 0))
+	defer proxy.Close()
+
+	// Slow GET requests are hedged to the secondary beacon node.
+	resp, err := http.Get(proxy.URL + "/eth/v1/node/version")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, "secondary /eth/v1/node/version", string(body))
+
+	// POST requests aren't hedged, but fail over to the secondary beacon node once the primary's circuit is open.
+	resp, err = http.Post(proxy.URL+"/eth/v1/beacon/pool/attestations", "application/json", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	resp, err = http.Post(proxy.URL+"/eth/v1/beacon/pool/attestations", "application/json", nil)
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, "secondary /eth/v1/beacon/pool/attestations", string(body))
+}
+
+func TestProxyFailoverCircuitBreaker(t *testing.T) {
+	var primaryHits, secondaryHits atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		primaryHits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		secondaryHits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer secondary.Close()
+
+	resilience, err := newProxyResilience(1, time.Hour, []string{primary.URL, secondary.URL}, 0)
+	require.NoError(t, err)
+
+	proxy := httptest.NewServer(proxyHandler(context.Background(), addr(primary.URL), nil, nil, resilience, 0))
+	defer proxy.Close()
+
+	post := func() int {
+		resp, err := http.Post(proxy.URL+"/eth/v1/beacon/pool/attestations", "application/json", nil)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	// Opens the primary's circuit.
+	require.Equal(t, http.StatusInternalServerError, post())
+
+	// Fails over to the secondary, opening its circuit.
+	require.Equal(t, http.StatusInternalServerError, post())
+
+	// Both circuits open, fail fast.
+	require.Equal(t, http.StatusServiceUnavailable, post())
+	require.EqualValues(t, 1, primaryHits.Load())
+	require.EqualValues(t, 1, secondaryHits.Load())
+}
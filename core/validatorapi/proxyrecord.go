@@ -0,0 +1,285 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// maxRecordedBodySize is the maximum size of request and response bodies recorded by the proxy recorder.
+const maxRecordedBodySize = 10 << 20
+
+// recordedHeaders are the only headers recorded, all others are dropped since they may contain credentials.
+var recordedHeaders = []string{"Accept", "Content-Type", "Eth-Consensus-Version", "Eth-Execution-Payload-Blinded", "Eth-Execution-Payload-Value", "Eth-Consensus-Block-Value"}
+
+// sensitiveQueryKeys are query parameter key substrings whose values are redacted.
+var sensitiveQueryKeys = []string{"key", "token", "secret", "auth", "pass"}
+
+// ProxyRecording is a sanitized beacon node request/response pair proxied by the validator API.
+type ProxyRecording struct {
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     json.RawMessage   `json:"request_body,omitempty"`
+	RequestBodyRaw  []byte            `json:"request_body_raw,omitempty"` // Non-JSON request body, e.g. SSZ.
+	StatusCode      int               `json:"status_code"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    json.RawMessage   `json:"response_body,omitempty"`
+	ResponseBodyRaw []byte            `json:"response_body_raw,omitempty"` // Non-JSON response body, e.g. SSZ.
+}
+
+// NewProxyRecorder returns a recorder appending proxied request/response pairs as JSON lines to the file.
+func NewProxyRecorder(path string) (*ProxyRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "open proxy record file", z.Str("path", path))
+	}
+
+	return &ProxyRecorder{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// ProxyRecorder records sanitized beacon node request/response pairs proxied by the validator API,
+// so beacon node quirks can be reproduced as test fixtures, see NewProxyReplayHandler.
+type ProxyRecorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	closed bool
+}
+
+// Close closes the file, subsequent recordings are dropped.
+func (r *ProxyRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	r.closed = true
+
+	if err := r.file.Close(); err != nil {
+		return errors.Wrap(err, "close proxy record file")
+	}
+
+	return nil
+}
+
+// record writes the recording.
+func (r *ProxyRecorder) record(ctx context.Context, recording ProxyRecording) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	if err := r.enc.Encode(recording); err != nil {
+		log.Warn(ctx, "Failed recording proxied request", err, z.Str("path", recording.Path))
+	}
+}
+
+// responseModifier returns a proxy response modifier recording the request and response.
+// The request body is read and restored. Streaming responses like events aren't recorded.
+func (r *ProxyRecorder) responseModifier(ctx context.Context, req *http.Request) (func(*http.Response) error, error) {
+	reqBody, err := readAndRestore(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	modify := func(resp *http.Response) error {
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			return nil
+		}
+
+		respBody, err := readAndRestore(&resp.Body)
+		if err != nil {
+			return err
+		}
+
+		recording := ProxyRecording{
+			Method:          req.Method,
+			Path:            req.URL.Path,
+			Query:           sanitizeQuery(req.URL.Query()),
+			RequestHeaders:  sanitizeHeaders(req.Header),
+			StatusCode:      resp.StatusCode,
+			ResponseHeaders: sanitizeHeaders(resp.Header),
+		}
+		recording.RequestBody, recording.RequestBodyRaw = splitBody(reqBody)
+		recording.ResponseBody, recording.ResponseBodyRaw = splitBody(respBody)
+
+		r.record(ctx, recording)
+
+		return nil
+	}
+
+	return modify, nil
+}
+
+// readAndRestore returns the bytes of the body, replacing it with an equivalent reader.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(*body, maxRecordedBodySize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+
+	// Restore the full body, including any remainder beyond the limit.
+	*body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), *body), *body}
+
+	if len(b) > maxRecordedBodySize {
+		return nil, nil
+	}
+
+	return b, nil
+}
+
+// splitBody returns the body as raw JSON if valid JSON, else as raw bytes.
+func splitBody(b []byte) (json.RawMessage, []byte) {
+	if len(b) == 0 {
+		return nil, nil
+	} else if json.Valid(b) {
+		return b, nil
+	}
+
+	return nil, b
+}
+
+// sanitizeHeaders returns the recorded headers.
+func sanitizeHeaders(header http.Header) map[string]string {
+	resp := make(map[string]string)
+
+	for _, key := range recordedHeaders {
+		if val := header.Get(key); val != "" {
+			resp[key] = val
+		}
+	}
+
+	if len(resp) == 0 {
+		return nil
+	}
+
+	return resp
+}
+
+// sanitizeQuery returns the encoded query with sensitive values redacted.
+func sanitizeQuery(query url.Values) string {
+	for key, vals := range query {
+		for _, sensitive := range sensitiveQueryKeys {
+			if !strings.Contains(strings.ToLower(key), sensitive) {
+				continue
+			}
+
+			for i := range vals {
+				vals[i] = "redacted"
+			}
+		}
+	}
+
+	return query.Encode()
+}
+
+// LoadProxyRecordings returns the proxy recordings in the file.
+func LoadProxyRecordings(path string) ([]ProxyRecording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open proxy record file", z.Str("path", path))
+	}
+	defer file.Close()
+
+	var resp []ProxyRecording
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 2*maxRecordedBodySize+1<<20)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var recording ProxyRecording
+		if err := json.Unmarshal(scanner.Bytes(), &recording); err != nil {
+			return nil, errors.Wrap(err, "unmarshal proxy recording", z.Int("line", len(resp)+1))
+		}
+
+		resp = append(resp, recording)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read proxy record file")
+	}
+
+	return resp, nil
+}
+
+// NewProxyReplayHandler returns a beacon node handler serving the recorded responses of requests matching
+// method, path and query. Repeated requests are served subsequent matching recordings, repeating the last one.
+// Unmatched requests are served 404. It is intended as a test fixture reproducing beacon node quirks.
+func NewProxyReplayHandler(recordings []ProxyRecording) http.HandlerFunc {
+	type key struct {
+		Method string
+		Path   string
+		Query  string
+	}
+
+	var (
+		mu      sync.Mutex
+		byKey   = make(map[key][]ProxyRecording)
+		counter = make(map[key]int)
+	)
+
+	for _, recording := range recordings {
+		k := key{Method: recording.Method, Path: recording.Path, Query: recording.Query}
+		byKey[k] = append(byKey[k], recording)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		k := key{Method: r.Method, Path: r.URL.Path, Query: sanitizeQuery(r.URL.Query())}
+
+		mu.Lock()
+		matches := byKey[k]
+		idx := min(counter[k], len(matches)-1)
+		counter[k]++
+		mu.Unlock()
+
+		if len(matches) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+
+		recording := matches[idx]
+		for key, val := range recording.ResponseHeaders {
+			w.Header().Set(key, val)
+		}
+
+		w.WriteHeader(recording.StatusCode)
+		if len(recording.ResponseBodyRaw) > 0 {
+			_, _ = w.Write(recording.ResponseBodyRaw)
+		} else {
+			_, _ = w.Write(recording.ResponseBody)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyRecordReplay(t *testing.T) {
+	const (
+		peersPath = "/eth/v1/node/peers?api_key=secret&state=connected"
+		peersResp = `{"data":[{"peer_id":"16Uiu2","state":"connected"}],"meta":{"count":1}}`
+		quirkResp = `{"code":500,"message":"quirky beacon node"}`
+	)
+
+	bnHandler := testHandler{
+		ProxyHandler: func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(quirkResp))
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(peersResp))
+		},
+	}
+
+	bn := httptest.NewServer(bnHandler.newBeaconHandler(t))
+	defer bn.Close()
+
+	file := filepath.Join(t.TempDir(), "proxy.jsonl")
+
+	recorder, err := NewProxyRecorder(file)
+	require.NoError(t, err)
+
+	r, err := NewRouter(context.Background(), testHandler{}, testBeaconAddr{addr: bn.URL}, true, WithProxyRecorder(recorder))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	doRequests := func(baseURL string) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+peersPath, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, peersResp, string(body))
+
+		resp, err = http.Post(baseURL+"/eth/v1/foo", "application/json", strings.NewReader(`{"foo":"bar"}`))
+		require.NoError(t, err)
+		body, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		require.JSONEq(t, quirkResp, string(body))
+	}
+
+	doRequests(server.URL)
+	require.NoError(t, recorder.Close())
+
+	recordings, err := LoadProxyRecordings(file)
+	require.NoError(t, err)
+	require.Len(t, recordings, 2)
+
+	// Recordings are sanitized.
+	require.Equal(t, http.MethodGet, recordings[0].Method)
+	require.Equal(t, "/eth/v1/node/peers", recordings[0].Path)
+	require.Equal(t, "api_key=redacted&state=connected", recordings[0].Query)
+	require.NotContains(t, recordings[0].RequestHeaders, "Authorization")
+	require.Equal(t, map[string]string{"Content-Type": "application/json"}, recordings[0].ResponseHeaders)
+	require.JSONEq(t, peersResp, string(recordings[0].ResponseBody))
+	require.JSONEq(t, `{"foo":"bar"}`, string(recordings[1].RequestBody))
+
+	// Replay recordings as beacon node fixtures.
+	testRawRouter(t, testHandler{ProxyHandler: NewProxyReplayHandler(recordings)}, func(_ context.Context, baseURL string) {
+		doRequests(baseURL)
+
+		resp, err := http.Get(baseURL + "/eth/v1/unknown")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// ProxyRewrite rewrites requests proxied to the beacon node, e.g. for beacon node gateways
+// requiring additional authentication headers or non-standard paths.
+type ProxyRewrite struct {
+	// PathPrefix matches proxied requests by path prefix, all requests are matched if empty.
+	PathPrefix string `json:"path_prefix,omitempty"`
+	// RewritePathPrefix replaces the matched path prefix, the path isn't rewritten if empty.
+	RewritePathPrefix string `json:"rewrite_path_prefix,omitempty"`
+	// SetHeaders are request headers set on matched requests, overriding existing values.
+	SetHeaders map[string]string `json:"set_headers,omitempty"`
+	// RemoveHeaders are request headers removed from matched requests.
+	RemoveHeaders []string `json:"remove_headers,omitempty"`
+	// Host overrides the host header of matched requests, defaults to the beacon node host.
+	Host string `json:"host,omitempty"`
+}
+
+// matches returns true if the rewrite applies to the request path.
+func (p ProxyRewrite) matches(path string) bool {
+	return strings.HasPrefix(path, p.PathPrefix)
+}
+
+// apply rewrites the request if it matches the current request path, so the path prefix is matched and trimmed
+// on the same path. It must be called before the request URL is resolved against the beacon node address.
+func (p ProxyRewrite) apply(req *http.Request) {
+	if !p.matches(req.URL.Path) {
+		return
+	}
+
+	if p.RewritePathPrefix != "" {
+		req.URL.Path = p.RewritePathPrefix + strings.TrimPrefix(req.URL.Path, p.PathPrefix)
+		req.URL.RawPath = ""
+	}
+
+	for _, header := range p.RemoveHeaders {
+		req.Header.Del(header)
+	}
+
+	for header, value := range p.SetHeaders {
+		req.Header.Set(header, value)
+	}
+
+	if p.Host != "" {
+		req.Host = p.Host
+	}
+}
+
+// LoadProxyRewrites returns the proxy rewrites from the JSON file at path, formatted as
+// [{"path_prefix": "/eth/v1/events", "rewrite_path_prefix": "/gateway/eth/v1/events", "set_headers": {"X-Api-Key": "..."},
+// "remove_headers": ["..."], "host": "..."}].
+func LoadProxyRewrites(path string) ([]ProxyRewrite, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read proxy rewrites file", z.Str("path", path))
+	}
+
+	var rewrites []ProxyRewrite
+	if err := json.Unmarshal(b, &rewrites); err != nil {
+		return nil, errors.Wrap(err, "unmarshal proxy rewrites file", z.Str("path", path))
+	}
+
+	if err := validateProxyRewrites(rewrites); err != nil {
+		return nil, err
+	}
+
+	return rewrites, nil
+}
+
+// validateProxyRewrites returns an error if any of the proxy rewrites is invalid.
+func validateProxyRewrites(rewrites []ProxyRewrite) error {
+	if len(rewrites) == 0 {
+		return errors.New("no proxy rewrites")
+	}
+
+	for i, rewrite := range rewrites {
+		if rewrite.PathPrefix != "" && !strings.HasPrefix(rewrite.PathPrefix, "/") {
+			return errors.New("proxy rewrite path prefix must start with /", z.Int("index", i))
+		} else if rewrite.RewritePathPrefix != "" && !strings.HasPrefix(rewrite.RewritePathPrefix, "/") {
+			return errors.New("proxy rewrite path must start with /", z.Int("index", i))
+		} else if rewrite.RewritePathPrefix == "" && len(rewrite.SetHeaders) == 0 && len(rewrite.RemoveHeaders) == 0 && rewrite.Host == "" {
+			return errors.New("proxy rewrite without effect", z.Int("index", i))
+		}
+
+		for header := range rewrite.SetHeaders {
+			if header == "" {
+				return errors.New("empty proxy rewrite header name", z.Int("index", i))
+			}
+		}
+
+		for _, header := range rewrite.RemoveHeaders {
+			if header == "" {
+				return errors.New("empty proxy rewrite header name", z.Int("index", i))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProxyRewrites(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Rewrites []ProxyRewrite
+		Err      string
+	}{
+		{
+			Name: "valid",
+			Rewrites: []ProxyRewrite{
+				{SetHeaders: map[string]string{"X-Api-Key": "secret"}},
+				{PathPrefix: "/eth/v1/events", RewritePathPrefix: "/stream/eth/v1/events", Host: "gateway.example.com"},
+			},
+		},
+		{
+			Name: "empty",
+			Err:  "no proxy rewrites",
+		},
+		{
+			Name:     "invalid path prefix",
+			Rewrites: []ProxyRewrite{{PathPrefix: "eth/v1", Host: "gateway.example.com"}},
+			Err:      "proxy rewrite path prefix must start with /",
+		},
+		{
+			Name:     "invalid rewrite path",
+			Rewrites: []ProxyRewrite{{PathPrefix: "/eth/v1", RewritePathPrefix: "gateway"}},
+			Err:      "proxy rewrite path must start with /",
+		},
+		{
+			Name:     "without effect",
+			Rewrites: []ProxyRewrite{{PathPrefix: "/eth/v1"}},
+			Err:      "proxy rewrite without effect",
+		},
+		{
+			Name:     "empty header",
+			Rewrites: []ProxyRewrite{{RemoveHeaders: []string{""}}},
+			Err:      "empty proxy rewrite header name",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b, err := json.Marshal(test.Rewrites)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), "rewrites.json")
+			require.NoError(t, os.WriteFile(path, b, 0o600))
+
+			rewrites, err := LoadProxyRewrites(path)
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.Rewrites, rewrites)
+		})
+	}
+}
+
+func TestProxyRewrites(t *testing.T) {
+	type received struct {
+		Path   string
+		Host   string
+		Header http.Header
+	}
+
+	receivedCh := make(chan received, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		receivedCh <- received{Path: r.URL.Path, Host: r.Host, Header: r.Header}
+	}))
+	defer target.Close()
+
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+
+	rewrites := []ProxyRewrite{
+		{SetHeaders: map[string]string{"X-Api-Key": "secret"}, RemoveHeaders: []string{"X-Internal"}},
+		{PathPrefix: "/eth/v1/events", RewritePathPrefix: "/stream/events", Host: "gateway.example.com"},
+		{PathPrefix: "/eth/v1/", RewritePathPrefix: "/v1/"},             // Doesn't match rewritten event stream paths.
+		{PathPrefix: "/stream/", RewritePathPrefix: "/gateway/stream/"}, // Matches rewritten event stream paths.
+	}
+
+	proxy := httptest.NewServer(proxyHandler(context.Background(), addr(target.URL), nil, rewrites, nil, 0))
+	defer proxy.Close()
+
+	get := func(path string) received {
+		req, err := http.NewRequest(http.MethodGet, proxy.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Internal", "true")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		return <-receivedCh
+	}
+
+	resp := get("/eth/v1/node/syncing")
+	require.Equal(t, "/v1/node/syncing", resp.Path)
+	require.Equal(t, targetURL.Host, resp.Host)
+	require.Equal(t, "secret", resp.Header.Get("X-Api-Key"))
+	require.Empty(t, resp.Header.Get("X-Internal"))
+
+	resp = get("/eth/v1/events/head")
+	require.Equal(t, "/gateway/stream/events/head", resp.Path)
+	require.Equal(t, "gateway.example.com", resp.Host)
+	require.Equal(t, "secret", resp.Header.Get("X-Api-Key"))
+}
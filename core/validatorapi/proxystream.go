@@ -0,0 +1,92 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// errResponseTooLarge is returned for proxied responses exceeding the maximum response size.
+var errResponseTooLarge = errors.New("proxied response exceeds maximum size")
+
+// proxyStream instruments the bytes of a proxied response body streamed from the beacon node to the validator client.
+// Responses exceeding the maximum size (if non-zero) are rejected before responding to the validator client.
+// Responses of known size are streamed without buffering the body, while responses of unknown size are buffered
+// up to the maximum size.
+type proxyStream struct {
+	maxSize int64
+}
+
+// modifyResponse is the proxy response modifier wrapping the response body.
+func (s *proxyStream) modifyResponse(resp *http.Response) error {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+
+	if s.maxSize > 0 && resp.ContentLength > s.maxSize {
+		proxyResponseTooLargeCounter.Inc()
+		return errors.Wrap(errResponseTooLarge, "content length", z.I64("content_length", resp.ContentLength), z.I64("max", s.maxSize))
+	}
+
+	if s.maxSize > 0 && resp.ContentLength < 0 {
+		// Unknown size, so read up to the maximum size to enforce it before responding.
+		b, err := io.ReadAll(io.LimitReader(resp.Body, s.maxSize+1))
+		if err != nil {
+			return errors.Wrap(err, "read proxied response body")
+		}
+
+		if int64(len(b)) > s.maxSize {
+			proxyResponseTooLargeCounter.Inc()
+			return errors.Wrap(errResponseTooLarge, "response body", z.I64("max", s.maxSize))
+		}
+
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{Reader: bytes.NewReader(b), Closer: resp.Body}
+	}
+
+	resp.Body = streamBody{ReadCloser: resp.Body}
+
+	return nil
+}
+
+// streamBody is a proxied response body instrumenting the streamed bytes.
+type streamBody struct {
+	io.ReadCloser
+}
+
+func (b streamBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	proxyStreamedBytesCounter.Add(float64(n))
+
+	return n, err //nolint:wrapcheck // Pass through io.EOF.
+}
+
+// proxyErrorHandler writes the response of requests that failed to be proxied to the beacon node.
+func proxyErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := log.WithTopic(r.Context(), "vapi")
+
+	switch {
+	case errors.Is(err, errCircuitOpen):
+		writeError(ctx, w, "proxy", apiError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "beacon node unavailable",
+			Err:        err,
+		})
+	case errors.Is(err, errResponseTooLarge):
+		writeError(ctx, w, "proxy", apiError{
+			StatusCode: http.StatusBadGateway,
+			Message:    "beacon node response too large",
+			Err:        err,
+		})
+	default:
+		w.WriteHeader(http.StatusBadGateway)
+	}
+}
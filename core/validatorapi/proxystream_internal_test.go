@@ -0,0 +1,69 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyStreaming(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), 100)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Flushing before writing the body results in chunked encoding without content length.
+			w.(http.Flusher).Flush()
+		}
+
+		_, _ = w.Write(body)
+	}))
+	defer target.Close()
+
+	get := func(maxSize int64, path string) (*http.Response, []byte, error) {
+		proxy := httptest.NewServer(proxyHandler(context.Background(), addr(target.URL), nil, nil, nil, maxSize))
+		defer proxy.Close()
+
+		resp, err := http.Get(proxy.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+
+		return resp, b, err
+	}
+
+	before := promtestutil.ToFloat64(proxyStreamedBytesCounter)
+
+	// Responses are streamed and instrumented.
+	resp, b, err := get(0, "/chunked")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, body, b)
+	require.InDelta(t, before+100, promtestutil.ToFloat64(proxyStreamedBytesCounter), 0)
+
+	tooLarge := promtestutil.ToFloat64(proxyResponseTooLargeCounter)
+
+	// Responses exceeding the maximum size are rejected before responding, whether the content length is known or not.
+	for _, path := range []string{"/", "/chunked"} {
+		resp, b, err = get(50, path)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		require.Contains(t, string(b), "beacon node response too large")
+	}
+
+	require.InDelta(t, tooLarge+2, promtestutil.ToFloat64(proxyResponseTooLargeCounter), 0)
+
+	// Responses of unknown size not exceeding the maximum size are proxied.
+	resp, b, err = get(100, "/chunked")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, body, b)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+)
+
+// registrationResubmitInterval is the interval after which identical registrations are processed again,
+// i.e., one mainnet epoch. This ensures registrations are eventually re-submitted, e.g. after relays
+// restarted, or validators activated, while skipping most identical registrations.
+const registrationResubmitInterval = 384 * time.Second
+
+// newRegistrationCache returns a new empty registration cache.
+func newRegistrationCache() *registrationCache {
+	return &registrationCache{
+		entries:  make(map[core.PubKey]registrationCacheEntry),
+		accepted: make(map[core.PubKey]time.Time),
+		nowFunc:  time.Now,
+	}
+}
+
+// registrationCache caches the last accepted builder registration per validator, allowing identical
+// registrations re-submitted by validator clients every epoch to be skipped until the resubmit interval elapsed.
+type registrationCache struct {
+	mu       sync.Mutex
+	entries  map[core.PubKey]registrationCacheEntry
+	accepted map[core.PubKey]time.Time
+	nowFunc  func() time.Time
+}
+
+type registrationCacheEntry struct {
+	root      eth2p0.Root
+	signature eth2p0.BLSSignature
+}
+
+// newRegistrationCacheEntry returns the cache entry of the registration, identified by its message root and signature.
+// Any change of fee recipient, gas limit or timestamp changes the message root.
+func newRegistrationCacheEntry(registration *eth2api.VersionedSignedValidatorRegistration) (registrationCacheEntry, error) {
+	root, err := registration.Root()
+	if err != nil {
+		return registrationCacheEntry{}, errors.Wrap(err, "registration root")
+	}
+
+	if registration.V1 == nil {
+		return registrationCacheEntry{}, errors.New("no V1 registration")
+	}
+
+	return registrationCacheEntry{
+		root:      root,
+		signature: registration.V1.Signature,
+	}, nil
+}
+
+// Duplicate returns true if the registration is identical to the last accepted registration of the validator
+// accepted less than the resubmit interval ago.
+func (c *registrationCache) Duplicate(pubkey core.PubKey, registration *eth2api.VersionedSignedValidatorRegistration) (bool, error) {
+	entry, err := newRegistrationCacheEntry(registration)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.entries[pubkey]
+	if !ok || prev != entry {
+		return false, nil
+	}
+
+	return c.nowFunc().Sub(c.accepted[pubkey]) < registrationResubmitInterval, nil
+}
+
+// Accept caches the registration as the last accepted registration of the validator,
+// also trimming registrations accepted more than the resubmit interval ago.
+func (c *registrationCache) Accept(pubkey core.PubKey, registration *eth2api.VersionedSignedValidatorRegistration) error {
+	entry, err := newRegistrationCacheEntry(registration)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFunc()
+	for key, accepted := range c.accepted {
+		if now.Sub(accepted) >= registrationResubmitInterval {
+			delete(c.entries, key)
+			delete(c.accepted, key)
+		}
+	}
+
+	c.entries[pubkey] = entry
+	c.accepted[pubkey] = now
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"testing"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestRegistrationCache(t *testing.T) {
+	now := time.Now()
+	cache := newRegistrationCache()
+	cache.nowFunc = func() time.Time { return now }
+	pubkey := testutil.RandomCorePubKey(t)
+
+	newRegistration := func() *eth2api.VersionedSignedValidatorRegistration {
+		return &eth2api.VersionedSignedValidatorRegistration{
+			Version: eth2spec.BuilderVersionV1,
+			V1: &eth2v1.SignedValidatorRegistration{
+				Message:   testutil.RandomValidatorRegistration(t),
+				Signature: testutil.RandomEth2Signature(),
+			},
+		}
+	}
+
+	reg := newRegistration()
+
+	duplicate, err := cache.Duplicate(pubkey, reg)
+	require.NoError(t, err)
+	require.False(t, duplicate)
+
+	require.NoError(t, cache.Accept(pubkey, reg))
+
+	duplicate, err = cache.Duplicate(pubkey, reg)
+	require.NoError(t, err)
+	require.True(t, duplicate)
+
+	// Other validators are not affected.
+	duplicate, err = cache.Duplicate(testutil.RandomCorePubKey(t), reg)
+	require.NoError(t, err)
+	require.False(t, duplicate)
+
+	// Changed fee recipient, gas limit or timestamp are not duplicates.
+	changes := []func(*eth2v1.ValidatorRegistration){
+		func(msg *eth2v1.ValidatorRegistration) { msg.FeeRecipient[0]++ },
+		func(msg *eth2v1.ValidatorRegistration) { msg.GasLimit++ },
+		func(msg *eth2v1.ValidatorRegistration) { msg.Timestamp = msg.Timestamp.Add(time.Second) },
+	}
+
+	for _, change := range changes {
+		changed := *reg.V1.Message
+		change(&changed)
+
+		duplicate, err = cache.Duplicate(pubkey, &eth2api.VersionedSignedValidatorRegistration{
+			Version: eth2spec.BuilderVersionV1,
+			V1: &eth2v1.SignedValidatorRegistration{
+				Message:   &changed,
+				Signature: reg.V1.Signature,
+			},
+		})
+		require.NoError(t, err)
+		require.False(t, duplicate)
+	}
+
+	// Latest accepted registration replaces the previous.
+	next := newRegistration()
+	require.NoError(t, cache.Accept(pubkey, next))
+
+	duplicate, err = cache.Duplicate(pubkey, reg)
+	require.NoError(t, err)
+	require.False(t, duplicate)
+
+	// Identical registrations are processed again after the resubmit interval and expired registrations trimmed.
+	require.NoError(t, cache.Accept(pubkey, reg))
+
+	now = now.Add(registrationResubmitInterval)
+
+	duplicate, err = cache.Duplicate(pubkey, reg)
+	require.NoError(t, err)
+	require.False(t, duplicate)
+
+	other := testutil.RandomCorePubKey(t)
+	require.NoError(t, cache.Accept(other, next))
+	require.Len(t, cache.entries, 1)
+	require.Len(t, cache.accepted, 1)
+}
@@ -17,6 +17,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -36,8 +37,8 @@ import (
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/gorilla/mux"
-	"github.com/prysmaticlabs/go-bitfield"
 
+	"github.com/obolnetwork/charon/app/bufpool"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/log"
@@ -69,12 +70,14 @@ type Handler interface {
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
 	eth2client.AttesterDutiesProvider
+	eth2client.BeaconBlockHeadersProvider
 	eth2client.ProposalProvider
 	eth2client.ProposalSubmitter
 	eth2exp.BeaconCommitteeSelectionAggregator
 	eth2client.BlindedProposalSubmitter
 	eth2client.NodeVersionProvider
 	eth2client.ProposerDutiesProvider
+	eth2client.SignedBeaconBlockProvider
 	eth2client.SyncCommitteeContributionProvider
 	eth2client.SyncCommitteeContributionsSubmitter
 	eth2client.SyncCommitteeDutiesProvider
@@ -87,18 +90,139 @@ type Handler interface {
 	// Above sorted alphabetically.
 }
 
+// RouterOption configures optional validator API router behaviour.
+type RouterOption func(*routerOptions)
+
+// routerOptions contains optional validator API router configuration.
+type routerOptions struct {
+	proposalTypeOverrides []ProposalTypeOverride
+	vcTokens              []VCToken
+	concurrencyLimits     map[string]int
+	draining              func() bool
+	proxyRecorder         *ProxyRecorder
+	cors                  *CORSConfig
+	proxyRewrites         []ProxyRewrite
+	pipelineReady         func() bool
+	breakerFailures       int
+	breakerCooldown       time.Duration
+	hedgeAddrs            []string
+	hedgeDelay            time.Duration
+	maxResponseSize       int64
+}
+
+// WithProposalTypeOverrides returns a router option that forces full or blinded
+// proposals for matching validator clients.
+func WithProposalTypeOverrides(overrides []ProposalTypeOverride) RouterOption {
+	return func(o *routerOptions) {
+		o.proposalTypeOverrides = overrides
+	}
+}
+
+// WithVCTokens returns a router option that requires validator clients to authenticate
+// with one of the bearer tokens, optionally restricting the validators each may submit for.
+func WithVCTokens(tokens []VCToken) RouterOption {
+	return func(o *routerOptions) {
+		o.vcTokens = tokens
+	}
+}
+
+// WithConcurrencyLimits returns a router option that overrides the default maximum concurrent
+// requests by endpoint class, see ParseConcurrencyLimits.
+func WithConcurrencyLimits(limits map[string]int) RouterOption {
+	return func(o *routerOptions) {
+		o.concurrencyLimits = limits
+	}
+}
+
+// WithDraining returns a router option that rejects all requests with 503 Service Unavailable
+// while the draining function returns true, i.e., while the node is shutting down.
+func WithDraining(draining func() bool) RouterOption {
+	return func(o *routerOptions) {
+		o.draining = draining
+	}
+}
+
+// WithProxyRecorder returns a router option that records sanitized beacon node request/response
+// pairs proxied by the router.
+func WithProxyRecorder(recorder *ProxyRecorder) RouterOption {
+	return func(o *routerOptions) {
+		o.proxyRecorder = recorder
+	}
+}
+
+// WithProxyRewrites returns a router option that rewrites requests proxied to the beacon node.
+func WithProxyRewrites(rewrites []ProxyRewrite) RouterOption {
+	return func(o *routerOptions) {
+		o.proxyRewrites = rewrites
+	}
+}
+
+// WithProxyCircuitBreaker returns a router option that fails requests proxied to a beacon node fast after the number of
+// consecutive failures, until a single probe request succeeds after the cooldown.
+func WithProxyCircuitBreaker(failures int, cooldown time.Duration) RouterOption {
+	return func(o *routerOptions) {
+		o.breakerFailures = failures
+		o.breakerCooldown = cooldown
+	}
+}
+
+// WithProxyHedging returns a router option that also sends latency sensitive GET requests proxied to the primary
+// beacon node to a secondary beacon node if no response is received within the delay, using the first response.
+// The secondary is the first of the beacon node addresses other than the primary, it is also used while
+// the primary's circuit breaker is open.
+func WithProxyHedging(addrs []string, delay time.Duration) RouterOption {
+	return func(o *routerOptions) {
+		o.hedgeAddrs = addrs
+		o.hedgeDelay = delay
+	}
+}
+
+// WithProxyMaxResponseSize returns a router option that rejects responses proxied from the beacon node
+// exceeding the maximum size in bytes.
+func WithProxyMaxResponseSize(size int64) RouterOption {
+	return func(o *routerOptions) {
+		o.maxResponseSize = size
+	}
+}
+
+// WithNodeStatus returns a router option that serves the node health and syncing endpoints locally instead of
+// proxying them to a single beacon node. The node is reported healthy and synced only if any upstream beacon node
+// is synced and the ready function returns true, i.e., charon's pipeline is ready.
+func WithNodeStatus(ready func() bool) RouterOption {
+	return func(o *routerOptions) {
+		o.pipelineReady = ready
+	}
+}
+
+// WithCORS returns a router option that allows cross-origin requests from the configured origins.
+func WithCORS(conf CORSConfig) RouterOption {
+	return func(o *routerOptions) {
+		o.cors = &conf
+	}
+}
+
+// endpoint is a validator API endpoint terminated by charon.
+type endpoint struct {
+	Name      string
+	Path      string
+	Handler   handlerFunc
+	Methods   []string
+	Encodings []contentType
+	// Matcher optionally restricts the requests terminated by charon, all other requests are proxied.
+	Matcher mux.MatcherFunc
+}
+
 // NewRouter returns a new validator http server router. The http router
 // translates http requests related to the distributed validator to the Handler.
 // All other requests are reverse-proxied to the beacon-node address.
-func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled bool) (*mux.Router, error) {
+func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEnabled bool, opts ...RouterOption) (*mux.Router, error) {
+	var o routerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Register subset of distributed validator related endpoints.
-	endpoints := []struct {
-		Name      string
-		Path      string
-		Handler   handlerFunc
-		Methods   []string
-		Encodings []contentType
-	}{
+	endpoints := []endpoint{
 		{
 			Name:      "attester_duties",
 			Path:      "/eth/v1/validator/duties/attester/{epoch}",
@@ -172,7 +296,7 @@ func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEn
 		{
 			Name:      "propose_block_v3",
 			Path:      "/eth/v3/validator/blocks/{slot}",
-			Handler:   proposeBlockV3(h, builderEnabled),
+			Handler:   proposeBlockV3(h, builderEnabled, o.proposalTypeOverrides),
 			Methods:   []string{http.MethodGet},
 			Encodings: []contentType{contentTypeJSON, contentTypeSSZ},
 		},
@@ -309,22 +433,122 @@ func NewRouter(ctx context.Context, h Handler, eth2Cl eth2wrap.Client, builderEn
 			Methods:   []string{http.MethodGet},
 			Encodings: []contentType{contentTypeJSON},
 		},
+		{
+			Name:      "node_syncing",
+			Path:      "/eth/v1/node/syncing",
+			Handler:   nodeSyncing(eth2Cl, o.pipelineReady),
+			Methods:   []string{http.MethodGet},
+			Encodings: []contentType{contentTypeJSON},
+			Matcher: func(*http.Request, *mux.RouteMatch) bool {
+				// Only served locally if enabled, else proxied.
+				return o.pipelineReady != nil
+			},
+		},
+		{
+			Name:      "block_headers",
+			Path:      "/eth/v1/beacon/headers",
+			Handler:   blockHeaders(h),
+			Methods:   []string{http.MethodGet},
+			Encodings: []contentType{contentTypeJSON},
+			Matcher: func(r *http.Request, _ *mux.RouteMatch) bool {
+				// Querying headers by parent root isn't supported, so proxy those.
+				return !r.URL.Query().Has("parent_root")
+			},
+		},
+		{
+			Name:      "block_header",
+			Path:      "/eth/v1/beacon/headers/{block_id}",
+			Handler:   blockHeader(h),
+			Methods:   []string{http.MethodGet},
+			Encodings: []contentType{contentTypeJSON},
+		},
+		{
+			Name:      "signed_block_v2",
+			Path:      "/eth/v2/beacon/blocks/{block_id}",
+			Handler:   signedBlock(h),
+			Methods:   []string{http.MethodGet},
+			Encodings: []contentType{contentTypeJSON},
+		},
 	}
 
 	r := mux.NewRouter()
+	if o.cors != nil {
+		// CORS is applied first since preflight requests are never authenticated.
+		r.Use(cors(*o.cors))
+	}
+
+	if o.draining != nil {
+		r.Use(rejectDraining(o.draining))
+	}
+
+	if len(o.vcTokens) > 0 {
+		identities, err := newVCIdentities(o.vcTokens)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Use(authenticate(identities))
+	}
+
+	if o.pipelineReady != nil {
+		r.Handle("/eth/v1/node/health", nodeHealth(eth2Cl, o.pipelineReady)).Methods(http.MethodGet)
+	}
+
+	limiters := newConcurrencyLimiters(o.concurrencyLimits)
+
 	for _, e := range endpoints {
-		handler := r.Handle(e.Path, wrap(e.Name, e.Handler, e.Encodings))
+		h := wrap(e.Name, e.Handler, e.Encodings)
+		if limiter, ok := limiters[endpointClasses[e.Name]]; ok {
+			h = limiter.Wrap(e.Name, h)
+		}
+
+		handler := r.Handle(e.Path, h)
 		if len(e.Methods) != 0 {
 			handler.Methods(e.Methods...)
 		}
+
+		if e.Matcher != nil {
+			handler.MatcherFunc(e.Matcher)
+		}
+	}
+
+	spec, err := newOpenAPISpec(endpoints, o.pipelineReady != nil)
+	if err != nil {
+		return nil, err
 	}
 
+	// Serve the OpenAPI spec of the endpoints terminated by charon.
+	r.Handle(openAPIPath, spec).Methods(http.MethodGet)
+
 	// Everything else is proxied
-	r.PathPrefix("/").Handler(proxyHandler(ctx, eth2Cl))
+	resilience, err := newProxyResilience(o.breakerFailures, o.breakerCooldown, o.hedgeAddrs, o.hedgeDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	r.PathPrefix("/").Handler(proxyHandler(ctx, eth2Cl, o.proxyRecorder, o.proxyRewrites, resilience, o.maxResponseSize))
 
 	return r, nil
 }
 
+// rejectDraining returns a middleware rejecting requests while draining.
+func rejectDraining(draining func() bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !draining() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			writeError(log.WithTopic(r.Context(), "vapi"), w, "draining", apiError{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    "node shutting down",
+				Err:        errors.New("validator api draining"),
+			})
+		})
+	}
+}
+
 // apiErr defines a validator api error that is converted to an eth2 errorResponse.
 type apiError struct {
 	// StatusCode is the http status code to return, defaults to 500.
@@ -333,6 +557,8 @@ type apiError struct {
 	Message string
 	// Err is the original error, returned in debug mode.
 	Err error
+	// Failures are the individual failed items of batch requests, returned to the client.
+	Failures []indexedError
 }
 
 func (a apiError) Error() string {
@@ -379,6 +605,10 @@ func wrap(endpoint string, handler handlerFunc, encodings []contentType) http.Ha
 
 		vcContentType.WithLabelValues(endpoint, string(typ)).Inc()
 
+		if identity, ok := vcIdentityFromCtx(ctx); ok {
+			vcRequests.WithLabelValues(endpoint, identity.name).Inc()
+		}
+
 		if !slices.Contains(encodings, typ) {
 			writeError(ctx, w, endpoint, apiError{
 				StatusCode: http.StatusUnsupportedMediaType,
@@ -393,7 +623,12 @@ func wrap(endpoint string, handler handlerFunc, encodings []contentType) http.Ha
 			vcUserAgentGauge.WithLabelValues(userAgent).Set(1)
 		}
 
-		body, err := io.ReadAll(r.Body)
+		// Decode the request body from a pooled buffer, handlers copy the bytes when unmarshalling.
+		buf := bufpool.Get()
+		defer bufpool.Put(buf)
+
+		body, err := bufpool.ReadAll(*buf, r.Body)
+		*buf = body
 		if err != nil {
 			writeError(ctx, w, endpoint, err)
 			return
@@ -411,19 +646,56 @@ func wrap(endpoint string, handler handlerFunc, encodings []contentType) http.Ha
 	return http.HandlerFunc(wrap)
 }
 
-// writeResponse writes the 200 OK response and json response body.
+// sszResponse is an ssz encoded response body.
+type sszResponse []byte
+
+// prefersSSZ returns true if the accept header prefers ssz over json encoded responses.
+func prefersSSZ(accept string) bool {
+	var sszQ, jsonQ float64
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(mediaRange, ";")
+
+		q := 1.0
+		if qParam, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(qParam, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		switch contentType(strings.TrimSpace(mediaType)) {
+		case contentTypeSSZ:
+			sszQ = q
+		case contentTypeJSON:
+			jsonQ = q
+		}
+	}
+
+	return sszQ > 0 && sszQ >= jsonQ
+}
+
+// writeResponse writes the 200 OK response and json response body, or the ssz response body.
 func writeResponse(ctx context.Context, w http.ResponseWriter, endpoint string, response any, headers http.Header) {
 	if response == nil {
 		return
 	}
 
-	b, err := json.Marshal(response)
-	if err != nil {
-		writeError(ctx, w, endpoint, errors.Wrap(err, "marshal response body"))
-		return
+	typ := contentTypeJSON
+
+	b, ok := response.(sszResponse)
+	if ok {
+		typ = contentTypeSSZ
+	} else {
+		var err error
+
+		b, err = json.Marshal(response)
+		if err != nil {
+			writeError(ctx, w, endpoint, errors.Wrap(err, "marshal response body"))
+			return
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", string(typ))
 
 	for name, values := range headers {
 		for _, val := range values {
@@ -431,7 +703,7 @@ func writeResponse(ctx context.Context, w http.ResponseWriter, endpoint string,
 		}
 	}
 
-	if _, err = w.Write(b); err != nil {
+	if _, err := w.Write(b); err != nil {
 		// Too late to also try to writeError at this point, so just log.
 		log.Error(ctx, "Failed writing api response", err)
 	}
@@ -448,7 +720,11 @@ func getValidators(p eth2client.ValidatorsProvider) handlerFunc {
 		if len(ids) == 0 && len(body) > 0 {
 			postIDs, err := getValidatorIDsFromJSON(body)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "getting validator ids from request body")
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid validator ids in request body",
+					Err:        err,
+				}
 			}
 
 			ids = postIDs
@@ -529,7 +805,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 		err := version.UnmarshalJSON([]byte("\"" + header.Get(versionHeader) + "\""))
 		if err != nil {
-			return nil, nil, errors.New("missing consensus version header", z.Hex("body", body))
+			return nil, nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "missing or invalid consensus version header",
+				Err:        err,
+			}
 		}
 
 		switch version {
@@ -538,7 +818,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 			err = unmarshal(typ, body, p0Atts)
 			if err != nil {
-				return nil, nil, errors.New("invalid phase0 attestations", z.Hex("body", body))
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid phase0 attestations",
+					Err:        err,
+				}
 			}
 
 			for _, p0Att := range *p0Atts {
@@ -553,7 +837,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 			err = unmarshal(typ, body, p0Atts)
 			if err != nil {
-				return nil, nil, errors.New("invalid altair attestations", z.Hex("body", body))
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid altair attestations",
+					Err:        err,
+				}
 			}
 
 			for _, p0Att := range *p0Atts {
@@ -568,7 +856,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 			err = unmarshal(typ, body, p0Atts)
 			if err != nil {
-				return nil, nil, errors.New("invalid bellatrix attestations", z.Hex("body", body))
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid bellatrix attestations",
+					Err:        err,
+				}
 			}
 
 			for _, p0Att := range *p0Atts {
@@ -583,7 +875,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 			err = unmarshal(typ, body, p0Atts)
 			if err != nil {
-				return nil, nil, errors.New("invalid capella attestations", z.Hex("body", body))
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid capella attestations",
+					Err:        err,
+				}
 			}
 
 			for _, p0Att := range *p0Atts {
@@ -598,7 +894,11 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 
 			err = unmarshal(typ, body, p0Atts)
 			if err != nil {
-				return nil, nil, errors.New("invalid deneb attestations", z.Hex("body", body))
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid deneb attestations",
+					Err:        err,
+				}
 			}
 
 			for _, p0Att := range *p0Atts {
@@ -609,32 +909,22 @@ func submitAttestations(p eth2client.AttestationsSubmitter) handlerFunc {
 				versionedAtts = append(versionedAtts, &versionedAtt)
 			}
 		case eth2spec.DataVersionElectra:
-			electraAtts := new([]electra.SingleAttestation)
-
-			err = unmarshal(typ, body, electraAtts)
+			electraAtts, err := unmarshalElectraAttestations(typ, body)
 			if err != nil {
-				return nil, nil, errors.New("invalid attestations", z.Hex("body", body))
-			}
-
-			for _, electraAtt := range *electraAtts {
-				commBits := bitfield.NewBitvector64()
-				commBits.SetBitAt(uint64(electraAtt.CommitteeIndex), true)
-				versionedAtt := eth2spec.VersionedAttestation{
-					Version:        eth2spec.DataVersionElectra,
-					ValidatorIndex: &electraAtt.AttesterIndex,
-					Electra: &electra.Attestation{
-						// the VersionedAttestation object will be converted back to SingleAttestation object inside go-eth2-client's SubmitAttestations,
-						// SingleAttestation object disregards AggregationBits, so this empty Bitlist is safe
-						AggregationBits: bitfield.NewBitlist(0),
-						Data:            electraAtt.Data,
-						Signature:       electraAtt.Signature,
-						CommitteeBits:   commBits,
-					},
+				return nil, nil, apiError{
+					StatusCode: http.StatusBadRequest,
+					Message:    "invalid electra attestations",
+					Err:        err,
 				}
-				versionedAtts = append(versionedAtts, &versionedAtt)
 			}
+
+			versionedAtts = append(versionedAtts, electraAtts...)
 		default:
-			return nil, nil, errors.New("invalid attestations version", z.Hex("body", body), z.Str("version", version.String()))
+			return nil, nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "invalid attestations version",
+				Err:        errors.New("invalid attestations version", z.Str("version", version.String())),
+			}
 		}
 
 		return nil, nil, p.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: versionedAtts})
@@ -826,8 +1116,9 @@ func respond404(endpoint string) handlerFunc {
 }
 
 // proposeBlockV3 returns a handler function returning an unsigned BeaconBlock or BlindedBeaconBlock.
-func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool) handlerFunc {
-	return func(ctx context.Context, params map[string]string, _ http.Header, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+// The proposal type is converted for validator clients matching the provided overrides.
+func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool, overrides []ProposalTypeOverride) handlerFunc {
+	return func(ctx context.Context, params map[string]string, header http.Header, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
 		slot, randao, graffiti, err := getProposeBlockParams(params, query)
 		if err != nil {
 			return nil, nil, err
@@ -854,6 +1145,13 @@ func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool) handlerF
 
 		proposal := eth2Resp.Data
 
+		if typ, ok := proposalTypeForClient(overrides, header); ok {
+			proposal, err = convertProposalType(proposal, typ)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		proposedBlock, err := createProposeBlockResponse(proposal)
 		if err != nil {
 			return nil, nil, err
@@ -869,6 +1167,31 @@ func proposeBlockV3(p eth2client.ProposalProvider, builderEnabled bool) handlerF
 	}
 }
 
+// convertProposalType returns the proposal converted to the provided type.
+// Full proposals can always be blinded, but blinded proposals cannot be unblinded since
+// the execution payload is only known to the builder, so these are rejected with a bad request error.
+func convertProposalType(proposal *eth2api.VersionedProposal, typ ProposalType) (*eth2api.VersionedProposal, error) {
+	switch {
+	case typ == ProposalTypeBlinded && !proposal.Blinded:
+		blinded, err := blindProposal(proposal)
+		if err != nil {
+			return nil, errors.Wrap(err, "blind proposal")
+		}
+
+		proposalTypeConversions.WithLabelValues(string(typ)).Inc()
+
+		return blinded, nil
+	case typ == ProposalTypeFull && proposal.Blinded:
+		return nil, apiError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "full proposal requested but only a blinded proposal is available",
+			Err:        errors.New("cannot convert blinded proposal to full proposal"),
+		}
+	default:
+		return proposal, nil
+	}
+}
+
 // getProposeBlockParams returns slot, randao and graffiti from propose block request params.
 func getProposeBlockParams(params map[string]string, query url.Values) (uint64, eth2p0.BLSSignature, [32]byte, error) {
 	slot, err := uintParam(params, "slot")
@@ -1089,7 +1412,11 @@ func submitProposal(p eth2client.ProposalSubmitter) handlerFunc {
 			})
 		}
 
-		return nil, nil, errors.New("invalid submitted block", z.Hex("body", body))
+		return nil, nil, apiError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "invalid submitted block",
+			Err:        err,
+		}
 	}
 }
 
@@ -1152,7 +1479,11 @@ func submitBlindedBlock(p eth2client.BlindedProposalSubmitter) handlerFunc {
 			})
 		}
 
-		return nil, nil, errors.New("invalid block")
+		return nil, nil, apiError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "invalid submitted blinded block",
+			Err:        err,
+		}
 	}
 }
 
@@ -1331,7 +1662,11 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 		err := version.UnmarshalJSON([]byte("\"" + header.Get(versionHeader) + "\""))
 		if err != nil {
-			return nil, nil, errors.New("missing consensus version header", z.Hex("body", body))
+			return nil, nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "missing or invalid consensus version header",
+				Err:        err,
+			}
 		}
 
 		switch version {
@@ -1340,7 +1675,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &p0Aggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal phase0 signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal phase0 signed aggregate and proofs")
 			}
 
 			for _, p0Agg := range p0Aggs {
@@ -1355,7 +1690,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &p0Aggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal altair signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal altair signed aggregate and proofs")
 			}
 
 			for _, p0Agg := range p0Aggs {
@@ -1370,7 +1705,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &p0Aggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal bellatrix signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal bellatrix signed aggregate and proofs")
 			}
 
 			for _, p0Agg := range p0Aggs {
@@ -1385,7 +1720,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &p0Aggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal capella signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal capella signed aggregate and proofs")
 			}
 
 			for _, p0Agg := range p0Aggs {
@@ -1400,7 +1735,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &p0Aggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal deneb signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal deneb signed aggregate and proofs")
 			}
 
 			for _, p0Agg := range p0Aggs {
@@ -1415,7 +1750,7 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 
 			err := unmarshal(typ, body, &electraAggs)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "unmarshal electra signed aggregate and proofs", z.Hex("body", body))
+				return nil, nil, errors.Wrap(err, "unmarshal electra signed aggregate and proofs")
 			}
 
 			for _, electraAgg := range electraAggs {
@@ -1426,7 +1761,11 @@ func submitAggregateAttestations(s eth2client.AggregateAttestationsSubmitter) ha
 				aggs = append(aggs, &versionedAgg)
 			}
 		default:
-			return nil, nil, errors.Wrap(err, "unknown signed aggregate and proofs version", z.Hex("body", body), z.Str("version", version.String()))
+			return nil, nil, apiError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "invalid signed aggregate and proofs version",
+				Err:        errors.New("invalid signed aggregate and proofs version", z.Str("version", version.String())),
+			}
 		}
 
 		return nil, nil, s.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{
@@ -1479,6 +1818,151 @@ func nodeVersion(p eth2client.NodeVersionProvider) handlerFunc {
 	}
 }
 
+// nodeSyncing returns a handler function for the node syncing endpoint. It reports the sync state of a single
+// upstream beacon node, preferring a synced one, but always syncing while charon's pipeline isn't ready.
+func nodeSyncing(eth2Cl eth2wrap.Client, ready func() bool) handlerFunc {
+	return func(ctx context.Context, _ map[string]string, _ http.Header, _ url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+		eth2Resp, err := eth2Cl.NodeSyncing(ctx, &eth2api.NodeSyncingOpts{})
+		if err != nil {
+			return nil, nil, apiError{
+				StatusCode: http.StatusServiceUnavailable,
+				Message:    "beacon nodes unavailable",
+				Err:        err,
+			}
+		}
+
+		var resp nodeSyncingResponse
+		resp.Data.HeadSlot = strconv.FormatUint(uint64(eth2Resp.Data.HeadSlot), 10)
+		resp.Data.SyncDistance = strconv.FormatUint(uint64(eth2Resp.Data.SyncDistance), 10)
+		resp.Data.IsOptimistic = eth2Resp.Data.IsOptimistic
+		resp.Data.IsSyncing = eth2Resp.Data.IsSyncing || !ready()
+
+		return resp, nil, nil
+	}
+}
+
+// nodeHealth returns a handler for the node health endpoint. It responds with 503 if no upstream beacon node is
+// active or charon's pipeline isn't ready, with 206 (or the syncing_status query parameter) if no upstream beacon
+// node is synced, or else with 200.
+func nodeHealth(eth2Cl eth2wrap.Client, ready func() bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !eth2Cl.IsActive() || !ready():
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case !eth2Cl.IsSynced():
+			status := http.StatusPartialContent
+			if code, err := strconv.Atoi(r.URL.Query().Get("syncing_status")); err == nil && code >= 100 && code <= 599 {
+				status = code
+			}
+
+			w.WriteHeader(status)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+// blockHeaders returns a handler function for the block headers endpoint. It returns the canonical
+// header of the optional slot query parameter, or the head header.
+func blockHeaders(p eth2client.BeaconBlockHeadersProvider) handlerFunc {
+	return func(ctx context.Context, _ map[string]string, _ http.Header, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+		blockID := "head"
+		if query.Has("slot") {
+			slot, err := uintQuery(query, "slot")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			blockID = strconv.FormatUint(slot, 10)
+		}
+
+		eth2Resp, err := p.BeaconBlockHeader(ctx, &eth2api.BeaconBlockHeaderOpts{Block: blockID})
+		if query.Has("slot") && z.ContainsField(err, z.Int("status_code", http.StatusNotFound)) {
+			// Empty slots have no header, which is an empty list rather than an error.
+			return blockHeadersResponse{Data: []*eth2v1.BeaconBlockHeader{}}, nil, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		return blockHeadersResponse{
+			ExecutionOptimistic: getBoolFromMetadata(eth2Resp.Metadata, "execution_optimistic"),
+			Finalized:           getBoolFromMetadata(eth2Resp.Metadata, "finalized"),
+			Data:                []*eth2v1.BeaconBlockHeader{eth2Resp.Data},
+		}, nil, nil
+	}
+}
+
+// blockHeader returns a handler function for the block header by block ID endpoint.
+func blockHeader(p eth2client.BeaconBlockHeadersProvider) handlerFunc {
+	return func(ctx context.Context, params map[string]string, _ http.Header, _ url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+		eth2Resp, err := p.BeaconBlockHeader(ctx, &eth2api.BeaconBlockHeaderOpts{Block: params["block_id"]})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return blockHeaderResponse{
+			ExecutionOptimistic: getBoolFromMetadata(eth2Resp.Metadata, "execution_optimistic"),
+			Finalized:           getBoolFromMetadata(eth2Resp.Metadata, "finalized"),
+			Data:                eth2Resp.Data,
+		}, nil, nil
+	}
+}
+
+// signedBlock returns a handler function for the signed block by block ID endpoint.
+func signedBlock(p eth2client.SignedBeaconBlockProvider) handlerFunc {
+	return func(ctx context.Context, params map[string]string, header http.Header, _ url.Values, _ contentType, _ []byte) (any, http.Header, error) {
+		eth2Resp, err := p.SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: params["block_id"]})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		block := eth2Resp.Data
+
+		var data any
+
+		switch block.Version {
+		case eth2spec.DataVersionPhase0:
+			data = block.Phase0
+		case eth2spec.DataVersionAltair:
+			data = block.Altair
+		case eth2spec.DataVersionBellatrix:
+			data = block.Bellatrix
+		case eth2spec.DataVersionCapella:
+			data = block.Capella
+		case eth2spec.DataVersionDeneb:
+			data = block.Deneb
+		case eth2spec.DataVersionElectra:
+			data = block.Electra
+		default:
+			return nil, nil, errors.New("invalid block version", z.Str("version", block.Version.String()))
+		}
+
+		resHeaders := make(http.Header)
+		resHeaders.Add(versionHeader, block.Version.String())
+
+		if prefersSSZ(header.Get("Accept")) {
+			marshaller, ok := data.(ssz.Marshaler)
+			if !ok {
+				return nil, nil, errors.New("block doesn't support ssz marshalling", z.Str("version", block.Version.String()))
+			}
+
+			b, err := marshaller.MarshalSSZ()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "marshal block ssz")
+			}
+
+			return sszResponse(b), resHeaders, nil
+		}
+
+		return signedBlockResponse{
+			Version:             block.Version.String(),
+			ExecutionOptimistic: getBoolFromMetadata(eth2Resp.Metadata, "execution_optimistic"),
+			Finalized:           getBoolFromMetadata(eth2Resp.Metadata, "finalized"),
+			Data:                data,
+		}, resHeaders, nil
+	}
+}
+
 // addressProvider provides the address of the active beacon node.
 type addressProvider interface {
 	Address() string
@@ -1486,7 +1970,9 @@ type addressProvider interface {
 
 // proxyHandler returns a reverse proxy handler.
 // Proxied requests use the provided context, so are cancelled when the context is cancelled.
-func proxyHandler(ctx context.Context, addrProvider addressProvider) http.HandlerFunc {
+func proxyHandler(ctx context.Context, addrProvider addressProvider, recorder *ProxyRecorder, rewrites []ProxyRewrite,
+	resilience *proxyResilience, maxResponseSize int64,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get active beacon node address.
 		targetURL, err := getBeaconNodeAddress(addrProvider)
@@ -1497,25 +1983,63 @@ func proxyHandler(ctx context.Context, addrProvider addressProvider) http.Handle
 
 			return
 		}
+		// Validator client bearer tokens authenticate with charon, not the beacon node.
+		_, authenticated := vcIdentityFromCtx(r.Context())
+
 		// Get address for active beacon node
 		proxy := httputil.NewSingleHostReverseProxy(targetURL)
 		// Extend default proxy director with basic auth and host header.
 		defaultDirector := proxy.Director
 		proxy.Director = func(req *http.Request) {
+			if authenticated {
+				req.Header.Del("Authorization")
+			}
+
 			if targetURL.User != nil {
 				password, _ := targetURL.User.Password()
 				req.SetBasicAuth(targetURL.User.Username(), password)
 			}
 
 			req.Host = targetURL.Host
+
+			// Apply the rewrites in order, each matching the path rewritten by the previous ones.
+			for _, rewrite := range rewrites {
+				rewrite.apply(req)
+			}
+
 			defaultDirector(req)
 		}
 		proxy.ErrorLog = stdlog.New(io.Discard, "", 0)
 
+		proxy.ErrorHandler = proxyErrorHandler
+		stream := &proxyStream{maxSize: maxResponseSize}
+		proxy.ModifyResponse = stream.modifyResponse
+
+		if resilience != nil {
+			proxy.Transport = resilience.transport(targetURL)
+		}
+
 		// Use provided context for proxied requests, so long running
 		// requests are cancelled when this context is cancelled (soft shutdown).
 		clonedReq := r.Clone(ctx)
 
+		if recorder != nil {
+			recordResponse, err := recorder.responseModifier(ctx, clonedReq)
+			if err != nil {
+				writeError(log.WithTopic(r.Context(), "vapi"), w, "proxy", err)
+				return
+			}
+
+			streamResponse := proxy.ModifyResponse
+			proxy.ModifyResponse = func(resp *http.Response) error {
+				if err := streamResponse(resp); err != nil {
+					return err
+				}
+
+				return recordResponse(resp)
+			}
+		}
+
 		log.Debug(ctx, "Proxying request to beacon node", z.Str("method", clonedReq.Method), z.Str("path", clonedReq.URL.Path))
 
 		defer observeProxyAPILatency(clonedReq.URL.Path)()
@@ -1548,14 +2072,7 @@ func writeError(ctx context.Context, w http.ResponseWriter, endpoint string, err
 		}
 	}
 
-	var aerr apiError
-	if !errors.As(err, &aerr) {
-		aerr = apiError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "Internal server error",
-			Err:        err,
-		}
-	}
+	aerr := toAPIError(err)
 
 	if aerr.StatusCode/100 == 4 {
 		// 4xx status codes are client errors (not server), so log as debug only.
@@ -1575,9 +2092,9 @@ func writeError(ctx context.Context, w http.ResponseWriter, endpoint string, err
 	incAPIErrors(endpoint, aerr.StatusCode)
 
 	res := errorResponse{
-		Code:    aerr.StatusCode,
-		Message: aerr.Message,
-		// TODO(corver): Add support for debug mode error and stacktraces.
+		Code:     aerr.StatusCode,
+		Message:  aerr.Message,
+		Failures: aerr.Failures,
 	}
 
 	b, err2 := json.Marshal(res)
@@ -1594,6 +2111,109 @@ func writeError(ctx context.Context, w http.ResponseWriter, endpoint string, err
 	}
 }
 
+// toAPIError returns the api error of err. Upstream beacon node errors retain their status code since
+// validator clients key retry behaviour off it, conflicting submissions are returned as 409, beacon node
+// timeouts as 503 and all other errors as 500.
+func toAPIError(err error) apiError {
+	var aerr apiError
+	if errors.As(err, &aerr) {
+		return aerr
+	}
+
+	var eth2Err *eth2api.Error
+	if errors.As(err, &eth2Err) && (eth2Err.StatusCode/100 == 4 || eth2Err.StatusCode == http.StatusServiceUnavailable) {
+		message := http.StatusText(eth2Err.StatusCode)
+
+		var bnRes errorResponse
+		if json.Unmarshal(eth2Err.Data, &bnRes) == nil && bnRes.Message != "" {
+			message = bnRes.Message
+		}
+
+		return apiError{
+			StatusCode: eth2Err.StatusCode,
+			Message:    message,
+			Err:        err,
+		}
+	}
+
+	if errors.Is(err, core.ErrConflictingSubmission) {
+		return apiError{
+			StatusCode: http.StatusConflict,
+			Message:    "conflicting submission for the same duty and validator, check for multiple validator clients with the same keys",
+			Err:        err,
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apiError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "Beacon node unavailable",
+			Err:        err,
+		}
+	}
+
+	return apiError{
+		StatusCode: http.StatusInternalServerError,
+		Message:    "Internal server error",
+		Err:        err,
+	}
+}
+
+// batchError returns an api error for the failed items of a batch request or nil if there are none.
+// The highest status code of the failures (e.g. 403) is returned, or 500 if any failure is a server error.
+// Failures without a status code are client errors, returned as 400 like the beacon node.
+func batchError(msg string, failures map[int]error) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	indexes := make([]int, 0, len(failures))
+	for i := range failures {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	resp := apiError{
+		StatusCode: http.StatusBadRequest,
+		Message:    msg,
+		Err:        errors.Wrap(failures[indexes[0]], msg, z.Int("index", indexes[0]), z.Int("failures", len(failures))),
+	}
+
+	for _, i := range indexes {
+		err := failures[i]
+
+		message := err.Error()
+		if aerr := toAPIError(err); aerr.StatusCode != http.StatusInternalServerError {
+			message = aerr.Message
+		}
+
+		resp.Failures = append(resp.Failures, indexedError{Index: i, Message: message})
+		resp.StatusCode = max(resp.StatusCode, batchFailureStatus(err))
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		resp.StatusCode = http.StatusInternalServerError
+	}
+
+	return resp
+}
+
+// batchFailureStatus returns the status code of an individual batch item failure.
+func batchFailureStatus(err error) int {
+	var aerr apiError
+	if errors.As(err, &aerr) {
+		return aerr.StatusCode
+	}
+
+	var eth2Err *eth2api.Error
+	if errors.As(err, &eth2Err) && eth2Err.StatusCode >= http.StatusBadRequest {
+		return eth2Err.StatusCode
+	}
+
+	return http.StatusBadRequest
+}
+
 // unmarshal parses body with the appropriate unmarshaler based on the contentType and stores the result
 // in the value pointed to by v.
 func unmarshal(typ contentType, body []byte, v any) error {
@@ -1896,6 +2516,19 @@ func getExecutionOptimisticFromMetadata(metadata map[string]any) (bool, error) {
 	return false, errors.New("metadata has missing execution_optimistic value")
 }
 
+// getBoolFromMetadata returns the boolean metadata value of the key, or false if it is missing or malformed.
+// Values are booleans if decoded from a json response body or strings if decoded from response headers.
+func getBoolFromMetadata(metadata map[string]any, key string) bool {
+	switch v := metadata[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
 // getDependentRootFromMetadata returns dependent_root value from metadata,
 // or error if it is missing, has a wrong type or a malformed value.
 // Default value `0x00..` is returned in case metadata is nil.
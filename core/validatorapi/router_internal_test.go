@@ -40,8 +40,10 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
 
 const (
@@ -66,7 +68,7 @@ func TestProxyShutdown(t *testing.T) {
 
 	// Start a proxy server that will proxy to the target server.
 	ctx, cancel := context.WithCancel(context.Background())
-	proxy := httptest.NewServer(proxyHandler(ctx, addr(target.URL)))
+	proxy := httptest.NewServer(proxyHandler(ctx, addr(target.URL), nil, nil, nil, 0))
 
 	// Make a request to the proxy server, this will block until the proxy is shutdown.
 	errCh := make(chan error, 1)
@@ -249,6 +251,114 @@ func TestRawRouter(t *testing.T) {
 		testRawRouter(t, handler, callback)
 	})
 
+	t.Run("missing consensus version header", func(t *testing.T) {
+		handler := testHandler{}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Post(baseURL+"/eth/v2/beacon/pool/attestations", "application/json", strings.NewReader("[]"))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+			var errRes errorResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+			require.Equal(t, errorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "missing or invalid consensus version header",
+			}, errRes)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("upstream beacon node error", func(t *testing.T) {
+		handler := testHandler{
+			ValidatorsFunc: func(context.Context, *eth2api.ValidatorsOpts) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
+				return nil, errors.Wrap(&eth2api.Error{
+					Method:     http.MethodGet,
+					Endpoint:   "/eth/v1/beacon/states/unknown/validators",
+					StatusCode: http.StatusNotFound,
+					Data:       []byte(`{"code":404,"message":"State not found"}`),
+				}, "get validators")
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Get(baseURL + "/eth/v1/beacon/states/unknown/validators/12")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+			var errRes errorResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+			require.Equal(t, errorResponse{
+				Code:    http.StatusNotFound,
+				Message: "State not found",
+			}, errRes)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("batch failures", func(t *testing.T) {
+		handler := testHandler{
+			SubmitSyncCommitteeMessagesFunc: func(context.Context, []*altair.SyncCommitteeMessage) error {
+				return batchError("error processing sync committee messages", map[int]error{
+					2: errors.New("validator not found"),
+					0: errors.New("invalid signature"),
+				})
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Post(baseURL+"/eth/v1/beacon/pool/sync_committees", "application/json", strings.NewReader("[]"))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+			var errRes errorResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&errRes))
+			require.Equal(t, errorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "error processing sync committee messages",
+				Failures: []indexedError{
+					{Index: 0, Message: "invalid signature"},
+					{Index: 2, Message: "validator not found"},
+				},
+			}, errRes)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("batch failures status codes", func(t *testing.T) {
+		forbidden := apiError{StatusCode: http.StatusForbidden, Message: "forbidden", Err: errors.New("forbidden")}
+		unavailable := &eth2api.Error{StatusCode: http.StatusServiceUnavailable, Data: []byte(`{"message":"syncing"}`)}
+
+		tests := []struct {
+			name     string
+			failures map[int]error
+			status   int
+		}{
+			{
+				name:     "max client error",
+				failures: map[int]error{0: errors.New("invalid signature"), 1: forbidden},
+				status:   http.StatusForbidden,
+			},
+			{
+				name:     "any server error",
+				failures: map[int]error{0: forbidden, 1: errors.Wrap(unavailable, "submit")},
+				status:   http.StatusInternalServerError,
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var aerr apiError
+				require.ErrorAs(t, batchError("error", test.failures), &aerr)
+				require.Equal(t, test.status, aerr.StatusCode)
+				require.Len(t, aerr.Failures, len(test.failures))
+			})
+		}
+	})
+
 	t.Run("valid content type in 2xx response", func(t *testing.T) {
 		handler := testHandler{}
 
@@ -1433,6 +1543,115 @@ func TestRouter(t *testing.T) {
 
 		testRouter(t, handler, callback)
 	})
+
+	t.Run("block header", func(t *testing.T) {
+		expected := testutil.RandomBeaconBlockHeader()
+
+		handler := testHandler{
+			BeaconBlockHeaderFunc: func(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+				require.Equal(t, "head", opts.Block)
+				return wrapResponse(expected), nil
+			},
+		}
+
+		callback := func(ctx context.Context, cl *eth2http.Service) {
+			eth2Resp, err := cl.BeaconBlockHeader(ctx, &eth2api.BeaconBlockHeaderOpts{Block: "head"})
+			require.NoError(t, err)
+			require.Equal(t, expected, eth2Resp.Data)
+		}
+
+		testRouter(t, handler, callback)
+	})
+
+	t.Run("block headers by slot", func(t *testing.T) {
+		handler := testHandler{
+			BeaconBlockHeaderFunc: func(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+				require.Equal(t, "123", opts.Block)
+				return wrapResponseWithMetadata(testutil.RandomBeaconBlockHeader(), map[string]any{"finalized": true}), nil
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Get(baseURL + "/eth/v1/beacon/headers?slot=123")
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			var resp blockHeadersResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			require.True(t, resp.Finalized)
+			require.Len(t, resp.Data, 1)
+			require.True(t, resp.Data[0].Canonical)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("block headers of empty slot", func(t *testing.T) {
+		handler := testHandler{
+			BeaconBlockHeaderFunc: func(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+				return nil, errors.New("not found", z.Int("status_code", http.StatusNotFound))
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Get(baseURL + "/eth/v1/beacon/headers?slot=123")
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var resp blockHeadersResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			require.NotNil(t, resp.Data)
+			require.Empty(t, resp.Data)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("signed block json", func(t *testing.T) {
+		handler := testHandler{
+			SignedBeaconBlockFunc: func(ctx context.Context, opts *eth2api.SignedBeaconBlockOpts) (*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock], error) {
+				return wrapResponse(testutil.RandomDenebVersionedSignedBeaconBlock()), nil
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			res, err := http.Get(baseURL + "/eth/v2/beacon/blocks/123")
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			require.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+			var resp signedBlockResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			require.Equal(t, "deneb", resp.Version)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("signed block", func(t *testing.T) {
+		expected := testutil.RandomDenebVersionedSignedBeaconBlock()
+
+		handler := testHandler{
+			SignedBeaconBlockFunc: func(ctx context.Context, opts *eth2api.SignedBeaconBlockOpts) (*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock], error) {
+				require.Equal(t, "123", opts.Block)
+				return wrapResponse(expected), nil
+			},
+		}
+
+		callback := func(ctx context.Context, cl *eth2http.Service) {
+			eth2Resp, err := cl.SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: "123"})
+			require.NoError(t, err)
+			require.Equal(t, expected, eth2Resp.Data)
+		}
+
+		testRouter(t, handler, callback)
+	})
 }
 
 func TestBeaconCommitteeSelections(t *testing.T) {
@@ -2000,6 +2219,8 @@ type testHandler struct {
 	SubmitBlindedProposalFunc              func(ctx context.Context, proposal *eth2api.SubmitBlindedProposalOpts) error
 	ProposerDutiesFunc                     func(ctx context.Context, opts *eth2api.ProposerDutiesOpts) (*eth2api.Response[[]*eth2v1.ProposerDuty], error)
 	NodeVersionFunc                        func(ctx context.Context, opts *eth2api.NodeVersionOpts) (*eth2api.Response[string], error)
+	BeaconBlockHeaderFunc                  func(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error)
+	SignedBeaconBlockFunc                  func(ctx context.Context, opts *eth2api.SignedBeaconBlockOpts) (*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock], error)
 	ValidatorsFunc                         func(ctx context.Context, opts *eth2api.ValidatorsOpts) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error)
 	BeaconStateFunc                        func(ctx context.Context, stateId string) (*eth2spec.VersionedBeaconState, error)
 	ValidatorsByPubKeyFunc                 func(ctx context.Context, stateID string, pubkeys []eth2p0.BLSPubKey) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error)
@@ -2060,6 +2281,14 @@ func (h testHandler) NodeVersion(ctx context.Context, opts *eth2api.NodeVersionO
 	return wrapResponse("mock_version"), nil
 }
 
+func (h testHandler) BeaconBlockHeader(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+	return h.BeaconBlockHeaderFunc(ctx, opts)
+}
+
+func (h testHandler) SignedBeaconBlock(ctx context.Context, opts *eth2api.SignedBeaconBlockOpts) (*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock], error) {
+	return h.SignedBeaconBlockFunc(ctx, opts)
+}
+
 func (h testHandler) SubmitVoluntaryExit(ctx context.Context, exit *eth2p0.SignedVoluntaryExit) error {
 	return h.SubmitVoluntaryExitFunc(ctx, exit)
 }
@@ -2164,3 +2393,137 @@ type testBeaconAddr struct {
 func (t testBeaconAddr) Address() string {
 	return t.addr
 }
+
+func TestRejectDraining(t *testing.T) {
+	var draining atomic.Bool
+
+	handler := rejectDraining(draining.Load)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v1/validator/duties/attester/1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	draining.Store(true)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v1/validator/duties/attester/1", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var errRes errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errRes))
+	require.Equal(t, errorResponse{Code: http.StatusServiceUnavailable, Message: "node shutting down"}, errRes)
+}
+
+func TestNodeStatus(t *testing.T) {
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	var (
+		ready  atomic.Bool
+		synced atomic.Bool
+	)
+
+	bmock.NodeSyncingFunc = func(context.Context, *eth2api.NodeSyncingOpts) (*eth2v1.SyncState, error) {
+		if synced.Load() {
+			return &eth2v1.SyncState{HeadSlot: 100}, nil
+		}
+
+		return &eth2v1.SyncState{HeadSlot: 90, SyncDistance: 10, IsSyncing: true}, nil
+	}
+	bmock.IsActiveFunc = func() bool { return true }
+	bmock.IsSyncedFunc = synced.Load
+
+	r, err := NewRouter(t.Context(), Handler(nil), bmock, true, WithNodeStatus(ready.Load))
+	require.NoError(t, err)
+
+	health := func(query string) int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v1/node/health"+query, nil))
+
+		return rec.Code
+	}
+
+	syncing := func() nodeSyncingResponse {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/eth/v1/node/syncing", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp nodeSyncingResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+		return resp
+	}
+
+	// Pipeline not ready.
+	require.Equal(t, http.StatusServiceUnavailable, health(""))
+	require.True(t, syncing().Data.IsSyncing)
+
+	// Pipeline ready, but no upstream beacon node synced.
+	ready.Store(true)
+	require.Equal(t, http.StatusPartialContent, health(""))
+	require.Equal(t, http.StatusOK, health("?syncing_status=200"))
+	resp := syncing()
+	require.True(t, resp.Data.IsSyncing)
+	require.Equal(t, "90", resp.Data.HeadSlot)
+	require.Equal(t, "10", resp.Data.SyncDistance)
+
+	// Pipeline ready and an upstream beacon node synced.
+	synced.Store(true)
+	require.Equal(t, http.StatusOK, health(""))
+
+	// All fields are from the same synced beacon node response.
+	resp = syncing()
+	require.False(t, resp.Data.IsSyncing)
+	require.Equal(t, "100", resp.Data.HeadSlot)
+	require.Equal(t, "0", resp.Data.SyncDistance)
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	spec := func(opts ...RouterOption) openAPISpec {
+		r, err := NewRouter(t.Context(), Handler(nil), bmock, true, opts...)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp openAPISpec
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+		return resp
+	}
+
+	resp := spec()
+	require.Equal(t, "3.0.3", resp.OpenAPI)
+
+	duties := resp.Paths["/eth/v1/validator/duties/attester/{epoch}"]["post"]
+	require.Equal(t, "attester_duties", duties.OperationID)
+	require.True(t, duties.Handled)
+	require.False(t, duties.Partial)
+	require.Equal(t, "epoch", duties.Parameters[0].Name)
+	require.Contains(t, duties.RequestBody.Content, string(contentTypeJSON))
+
+	require.Contains(t, resp.Paths["/eth/v1/beacon/states/{state_id}/validators"], "get")
+	require.True(t, resp.Paths["/eth/v1/beacon/headers"]["get"].Partial)
+
+	// Node status endpoints are proxied unless enabled.
+	require.False(t, resp.Paths["/eth/v1/node/syncing"]["get"].Handled)
+	require.False(t, resp.Paths["/eth/v1/node/health"]["get"].Handled)
+
+	resp = spec(WithNodeStatus(func() bool { return true }))
+	require.True(t, resp.Paths["/eth/v1/node/syncing"]["get"].Handled)
+	require.True(t, resp.Paths["/eth/v1/node/health"]["get"].Handled)
+}
+
+func TestPrefersSSZ(t *testing.T) {
+	require.False(t, prefersSSZ(""))
+	require.False(t, prefersSSZ("application/json"))
+	require.False(t, prefersSSZ("application/octet-stream;q=0.5,application/json"))
+	require.True(t, prefersSSZ("application/octet-stream"))
+	require.True(t, prefersSSZ("application/octet-stream;q=1,application/json;q=0.9"))
+}
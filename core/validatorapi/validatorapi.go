@@ -28,6 +28,7 @@ import (
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -79,6 +80,9 @@ func NewComponentInsecure(_ *testing.T, eth2Cl eth2wrap.Client, shareIdx int) (*
 		shareIdx:       shareIdx,
 		builderEnabled: false,
 		insecureTest:   true,
+		regCache:       newRegistrationCache(),
+		headerCache:    newBlockCache[*eth2api.Response[*eth2v1.BeaconBlockHeader]](headerCacheSize),
+		blockCache:     newBlockCache[*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock]](blockCacheSize),
 	}, nil
 }
 
@@ -142,11 +146,16 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 	getPubKeyFunc := func(share eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error) {
 		key, ok := keysByShare[share]
 		if !ok {
-			for _, shares := range allPubSharesByKey {
+			for corePubkey, shares := range allPubSharesByKey {
 				for keyshareIdx, pubshare := range shares {
 					if eth2p0.BLSPubKey(pubshare) == share {
-						return eth2p0.BLSPubKey{}, errors.New("mismatching validator client key share index, Mth key share submitted to Nth charon peer",
-							z.Int("key_share_index", keyshareIdx-1), z.Int("charon_peer_index", shareIdx-1)) // 0-indexed
+						return eth2p0.BLSPubKey{}, mismatchedShareError{share: MismatchedShare{
+							Time:            time.Now(),
+							PubShare:        fmt.Sprintf("%#x", share),
+							PubKey:          string(corePubkey),
+							KeyShareIndex:   keyshareIdx - 1, // 0-indexed
+							CharonPeerIndex: shareIdx - 1,
+						}}
 					}
 				}
 			}
@@ -165,6 +174,7 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		getVerifyShareFunc: getVerifyShareFunc,
 		getPubShareFunc:    getPubShareFunc,
 		getPubKeyFunc:      getPubKeyFunc,
+		mismatches:         NewMismatchedShares(),
 		sharesByKey:        coreSharesByKey,
 		eth2Cl:             eth2Cl,
 		shareIdx:           shareIdx,
@@ -172,6 +182,9 @@ func NewComponent(eth2Cl eth2wrap.Client, allPubSharesByKey map[core.PubKey]map[
 		builderEnabled:     builderEnabled,
 		targetGasLimit:     targetGasLimit,
 		swallowRegFilter:   log.Filter(),
+		regCache:           newRegistrationCache(),
+		headerCache:        newBlockCache[*eth2api.Response[*eth2v1.BeaconBlockHeader]](headerCacheSize),
+		blockCache:         newBlockCache[*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock]](blockCacheSize),
 	}, nil
 }
 
@@ -184,6 +197,9 @@ type Component struct {
 	targetGasLimit   uint
 	swallowRegFilter z.Field
 
+	// gasLimitRamp progressively changes the target gas limit, overriding targetGasLimit if not nil.
+	gasLimitRamp *registration.GasLimitRamp
+
 	// getVerifyShareFunc maps public shares (what the VC thinks as its public key)
 	// to public keys (the DV root public key)
 	getVerifyShareFunc func(core.PubKey) (tbls.PublicKey, error)
@@ -193,6 +209,17 @@ type Component struct {
 	getPubKeyFunc func(eth2p0.BLSPubKey) (eth2p0.BLSPubKey, error)
 	// sharesByKey contains this node's public shares (value) by root public (key)
 	sharesByKey map[core.PubKey]core.PubKey
+	// mismatches records submissions of public shares belonging to other charon nodes.
+	mismatches *MismatchedShares
+	// lastSeen records when validators were last seen in duties queries and submissions, it may be nil.
+	lastSeen *LastSeen
+
+	// regCache caches the last accepted builder registration per validator.
+	regCache *registrationCache
+
+	// headerCache and blockCache cache beacon block headers and blocks by block ID.
+	headerCache *blockCache[*eth2api.Response[*eth2v1.BeaconBlockHeader]]
+	blockCache  *blockCache[*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock]]
 
 	// Registered input functions
 	pubKeyByAttFunc           func(ctx context.Context, slot, commIdx, valIdx uint64) (core.PubKey, error)
@@ -211,6 +238,25 @@ func (c *Component) RegisterAwaitProposal(fn func(ctx context.Context, slot uint
 	c.awaitProposalFunc = fn
 }
 
+// RegisterGasLimitRamp registers a gas limit ramp progressively changing the target gas limit
+// of the proposer configuration.
+func (c *Component) RegisterGasLimitRamp(ramp registration.GasLimitRamp) {
+	c.gasLimitRamp = &ramp
+}
+
+// RegisterMismatchedShares registers the recorder of validator client submissions of key shares
+// belonging to other charon nodes.
+func (c *Component) RegisterMismatchedShares(mismatches *MismatchedShares) {
+	c.mismatches = mismatches
+}
+
+// RegisterLastSeen registers the recorder of when validators were last seen in validator client
+// duties queries and submissions.
+func (c *Component) RegisterLastSeen(lastSeen *LastSeen) {
+	lastSeen.setPubShares(c.sharesByKey)
+	c.lastSeen = lastSeen
+}
+
 // RegisterAwaitAttestation registers a function to query attestation data.
 // It only supports a single function, since it is an input of the component.
 func (c *Component) RegisterAwaitAttestation(fn func(ctx context.Context, slot, commIdx uint64) (*eth2p0.AttestationData, error)) {
@@ -271,114 +317,172 @@ func (c Component) AttestationData(ctx context.Context, opts *eth2api.Attestatio
 }
 
 // SubmitAttestations implements the eth2client.AttestationsSubmitter for the router.
+// Valid attestations are submitted even if some are invalid, the invalid ones are returned as batch failures.
 func (c Component) SubmitAttestations(ctx context.Context, attestationOpts *eth2api.SubmitAttestationsOpts) error {
-	attestations := attestationOpts.Attestations
 	setsBySlot := make(map[uint64]core.ParSignedDataSet)
+	failures := make(map[int]error)
+
+	var (
+		indices []int
+		slots   []uint64
+		pubkeys []core.PubKey
+		parSigs []core.ParSignedData
+	)
 
-	for _, att := range attestations {
-		attData, err := att.Data()
+	for i, att := range attestationOpts.Attestations {
+		slot, pubkey, parSigData, err := c.parSignedAttestation(ctx, att)
 		if err != nil {
-			return errors.Wrap(err, "get attestation data")
+			failures[i] = err
+			continue
 		}
 
-		slot := uint64(attData.Slot)
+		indices = append(indices, i)
+		slots = append(slots, slot)
+		pubkeys = append(pubkeys, pubkey)
+		parSigs = append(parSigs, parSigData)
+	}
 
-		attCommitteeIndex, err := att.CommitteeIndex()
-		if err != nil {
-			return errors.Wrap(err, "get attestation committee index")
+	// Verify attestation signatures
+	invalid := c.verifyPartialSigs(ctx, parSigs, pubkeys)
+
+	for j, parSigData := range parSigs {
+		if err, ok := invalid[j]; ok {
+			failures[indices[j]] = err
+			continue
+		}
+
+		// Encode partial signed data and add to a set
+		set, ok := setsBySlot[slots[j]]
+		if !ok {
+			set = make(core.ParSignedDataSet)
+			setsBySlot[slots[j]] = set
 		}
 
-		var valIdx eth2p0.ValidatorIndex
+		set[pubkeys[j]] = parSigData
+	}
 
-		switch att.Version {
-		// In pre-electra attestations ValidatorIndex is not part of the VersionedAttestation structure.
-		// Try to fetch it by matching the aggregation bits and validator's committee index from the payload to an attester duty from the scheduler.
-		case eth2spec.DataVersionPhase0, eth2spec.DataVersionAltair, eth2spec.DataVersionBellatrix, eth2spec.DataVersionCapella, eth2spec.DataVersionDeneb:
-			dutyDefSet, err := c.dutyDefFunc(ctx, core.Duty{Slot: uint64(attData.Slot), Type: core.DutyAttester})
+	// Send sets to subscriptions.
+	for slot, set := range setsBySlot {
+		duty := core.NewAttesterDuty(slot)
+		ctx := log.WithCtx(ctx, z.Any("duty", duty))
+
+		for _, sub := range c.subs {
+			// No need to clone since sub auto clones.
+			err := sub(ctx, duty, set)
 			if err != nil {
-				return errors.Wrap(err, "duty def set")
+				return err
 			}
+		}
+	}
 
-			for _, dutyDef := range dutyDefSet {
-				attDef, ok := dutyDef.(core.AttesterDefinition)
-				if !ok {
-					return errors.New("parse duty definition to attester definition")
-				}
+	return batchError("error processing attestations", failures)
+}
 
-				if attDef.CommitteeIndex != attData.Index {
-					continue
-				}
+// attesterIndexByAggBits returns the validator index of the attestation with a single aggregation bit
+// by matching the aggregation bit and the committee index to an attester duty from the scheduler.
+func (c Component) attesterIndexByAggBits(ctx context.Context, att *eth2spec.VersionedAttestation, commIdx eth2p0.CommitteeIndex) (eth2p0.ValidatorIndex, error) {
+	attData, err := att.Data()
+	if err != nil {
+		return 0, errors.Wrap(err, "get attestation data")
+	}
 
-				aggBits, err := att.AggregationBits()
-				if err != nil {
-					return errors.Wrap(err, "get attestation aggregation bits")
-				}
+	aggBits, err := att.AggregationBits()
+	if err != nil {
+		return 0, errors.Wrap(err, "get attestation aggregation bits")
+	}
 
-				indices := aggBits.BitIndices()
-				if len(indices) != 1 {
-					return errors.New("unexpected number of aggregation bits",
-						z.Str("aggbits", fmt.Sprintf("%#x", []byte(aggBits))))
-				}
+	indices := aggBits.BitIndices()
+	if len(indices) != 1 {
+		return 0, errors.New("unexpected number of aggregation bits",
+			z.Str("aggbits", fmt.Sprintf("%#x", []byte(aggBits))))
+	}
 
-				if attDef.ValidatorCommitteeIndex == uint64(indices[0]) {
-					valIdx = attDef.ValidatorIndex
-					break
-				}
-			}
-		case eth2spec.DataVersionElectra:
-			if att.ValidatorIndex == nil {
-				return errors.New("missing attestation validator index from electra attestation")
-			}
+	dutyDefSet, err := c.dutyDefFunc(ctx, core.Duty{Slot: uint64(attData.Slot), Type: core.DutyAttester})
+	if err != nil {
+		return 0, errors.Wrap(err, "duty def set")
+	}
 
-			valIdx = *att.ValidatorIndex
-		default:
-			return errors.New("invalid attestations version", z.Str("version", att.Version.String()))
+	var valIdx eth2p0.ValidatorIndex
+
+	for _, dutyDef := range dutyDefSet {
+		attDef, ok := dutyDef.(core.AttesterDefinition)
+		if !ok {
+			return 0, errors.New("parse duty definition to attester definition")
 		}
 
-		var pubkey core.PubKey
+		if attDef.CommitteeIndex != commIdx {
+			continue
+		}
 
-		pubkey, err = c.pubKeyByAttFunc(ctx, slot, uint64(attCommitteeIndex), uint64(valIdx))
-		if err != nil {
-			return errors.Wrap(err, "failed to find pubkey", z.U64("slot", slot),
-				z.U64("commIdx", uint64(attCommitteeIndex)), z.U64("valIdx", uint64(valIdx)))
+		if attDef.ValidatorCommitteeIndex == uint64(indices[0]) {
+			valIdx = attDef.ValidatorIndex
+			break
 		}
+	}
+
+	return valIdx, nil
+}
+
+// parSignedAttestation returns the slot, validator public key and unverified partial signed data of the attestation.
+func (c Component) parSignedAttestation(ctx context.Context, att *eth2spec.VersionedAttestation) (uint64, core.PubKey, core.ParSignedData, error) {
+	attData, err := att.Data()
+	if err != nil {
+		return 0, "", core.ParSignedData{}, errors.Wrap(err, "get attestation data")
+	}
 
-		parSigData, err := core.NewPartialVersionedAttestation(att, c.shareIdx)
+	slot := uint64(attData.Slot)
+
+	attCommitteeIndex, err := att.CommitteeIndex()
+	if err != nil {
+		return 0, "", core.ParSignedData{}, errors.Wrap(err, "get attestation committee index")
+	}
+
+	var valIdx eth2p0.ValidatorIndex
+
+	switch att.Version {
+	// In pre-electra attestations ValidatorIndex is not part of the VersionedAttestation structure.
+	// Try to fetch it by matching the aggregation bits and validator's committee index from the payload to an attester duty from the scheduler.
+	case eth2spec.DataVersionPhase0, eth2spec.DataVersionAltair, eth2spec.DataVersionBellatrix, eth2spec.DataVersionCapella, eth2spec.DataVersionDeneb:
+		valIdx, err = c.attesterIndexByAggBits(ctx, att, attData.Index)
 		if err != nil {
-			return err
+			return 0, "", core.ParSignedData{}, err
+		}
+	case eth2spec.DataVersionElectra:
+		if att.ValidatorIndex != nil {
+			valIdx = *att.ValidatorIndex
+			break
 		}
 
-		// Verify attestation signature
-		err = c.verifyPartialSig(ctx, parSigData, pubkey)
+		// Electra attestations submitted in the aggregated Attestation format instead of the SingleAttestation format
+		// lack the validator index, resolve it like pre-electra attestations but by the committee bits' index.
+		valIdx, err = c.attesterIndexByAggBits(ctx, att, attCommitteeIndex)
 		if err != nil {
-			return err
+			return 0, "", core.ParSignedData{}, err
 		}
 
-		// Encode partial signed data and add to a set
-		set, ok := setsBySlot[slot]
-		if !ok {
-			set = make(core.ParSignedDataSet)
-			setsBySlot[slot] = set
+		// Ensure the attestation converts to a SingleAttestation when submitted to the beacon node.
+		single, err := attestationToSingle(att.Electra, valIdx)
+		if err != nil {
+			return 0, "", core.ParSignedData{}, err
 		}
 
-		set[pubkey] = parSigData
+		att.ValidatorIndex = &single.AttesterIndex
+	default:
+		return 0, "", core.ParSignedData{}, errors.New("invalid attestations version", z.Str("version", att.Version.String()))
 	}
 
-	// Send sets to subscriptions.
-	for slot, set := range setsBySlot {
-		duty := core.NewAttesterDuty(slot)
-		ctx := log.WithCtx(ctx, z.Any("duty", duty))
+	pubkey, err := c.pubKeyByAttFunc(ctx, slot, uint64(attCommitteeIndex), uint64(valIdx))
+	if err != nil {
+		return 0, "", core.ParSignedData{}, errors.Wrap(err, "failed to find pubkey", z.U64("slot", slot),
+			z.U64("commIdx", uint64(attCommitteeIndex)), z.U64("valIdx", uint64(valIdx)))
+	}
 
-		for _, sub := range c.subs {
-			// No need to clone since sub auto clones.
-			err := sub(ctx, duty, set)
-			if err != nil {
-				return err
-			}
-		}
+	parSigData, err := core.NewPartialVersionedAttestation(att, c.shareIdx)
+	if err != nil {
+		return 0, "", core.ParSignedData{}, err
 	}
 
-	return nil
+	return slot, pubkey, parSigData, nil
 }
 
 func (c Component) Proposal(ctx context.Context, opts *eth2api.ProposalOpts) (*eth2api.Response[*eth2api.VersionedProposal], error) {
@@ -482,8 +586,12 @@ func propDataMatchesDuty(opts *eth2api.SubmitProposalOpts, prop *eth2api.Version
 		)
 	}
 
-	checkHashes := func(d1, d2 ssz.HashRoot) error {
-		ddb, err := d1.HashTreeRoot()
+	checkHashes := func(d1, d2 interface {
+		ssz.Marshaler
+		ssz.HashRoot
+	},
+	) error {
+		ddb, err := core.CachedHashTreeRoot(d1)
 		if err != nil {
 			return errors.Wrap(err, "hash tree root dutydb")
 		}
@@ -492,7 +600,7 @@ func propDataMatchesDuty(opts *eth2api.SubmitProposalOpts, prop *eth2api.Version
 			return errors.New("validator client proposal data for the associated dutydb proposal is nil")
 		}
 
-		vc, err := d2.HashTreeRoot()
+		vc, err := core.CachedHashTreeRoot(d2)
 		if err != nil {
 			return errors.Wrap(err, "hash tree root dutydb")
 		}
@@ -618,6 +726,15 @@ func (c Component) SubmitBlindedProposal(ctx context.Context, opts *eth2api.Subm
 		return errors.Wrap(err, "could not fetch block definition from dutydb")
 	}
 
+	// The VC may have been served a blinded copy of a full proposal, see ProposalTypeOverride.
+	fullProp := prop
+	if !prop.Blinded {
+		prop, err = blindProposal(prop)
+		if err != nil {
+			return errors.Wrap(err, "blind dutydb proposal")
+		}
+	}
+
 	if err := propDataMatchesDuty(&eth2api.SubmitProposalOpts{
 		Common: opts.Common,
 		Proposal: &eth2api.VersionedSignedProposal{
@@ -634,9 +751,23 @@ func (c Component) SubmitBlindedProposal(ctx context.Context, opts *eth2api.Subm
 	}
 
 	// Save Partially Signed Blinded Block to ParSigDB
-	signedData, err := core.NewPartialVersionedSignedBlindedProposal(opts.Proposal, c.shareIdx)
-	if err != nil {
-		return err
+	var signedData core.ParSignedData
+	if fullProp.Blinded {
+		signedData, err = core.NewPartialVersionedSignedBlindedProposal(opts.Proposal, c.shareIdx)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Blinded and full blocks share the same signing root, so convert back to the full proposal agreed in consensus.
+		signedProp, err := unblindSignedProposal(opts.Proposal, fullProp)
+		if err != nil {
+			return errors.Wrap(err, "unblind signed proposal")
+		}
+
+		signedData, err = core.NewPartialVersionedSignedProposal(signedProp, c.shareIdx)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Verify Blinded block signature
@@ -679,6 +810,14 @@ func (c Component) submitRegistration(ctx context.Context, registration *eth2api
 		return nil
 	}
 
+	if duplicate, err := c.regCache.Duplicate(pubkey, registration); err != nil {
+		return err
+	} else if duplicate {
+		// Validator clients re-submit identical registrations every epoch, only changes are processed.
+		dedupedRegistrations.Inc()
+		return nil
+	}
+
 	timestamp, err := registration.Timestamp()
 	if err != nil {
 		return err
@@ -713,7 +852,7 @@ func (c Component) submitRegistration(ctx context.Context, registration *eth2api
 		}
 	}
 
-	return nil
+	return c.regCache.Accept(pubkey, registration)
 }
 
 // SubmitValidatorRegistrations receives the partially signed validator (builder) registration.
@@ -739,17 +878,17 @@ func (c Component) SubmitValidatorRegistrations(ctx context.Context, registratio
 
 // SubmitVoluntaryExit receives the partially signed voluntary exit.
 func (c Component) SubmitVoluntaryExit(ctx context.Context, exit *eth2p0.SignedVoluntaryExit) error {
-	vals, err := c.eth2Cl.ActiveValidators(ctx)
+	states, err := c.eth2Cl.ValidatorStates(ctx)
 	if err != nil {
 		return err
 	}
 
-	eth2Pubkey, ok := vals[exit.Message.ValidatorIndex]
-	if !ok {
+	state, ok := states[exit.Message.ValidatorIndex]
+	if !ok || !state.Status.IsActive() {
 		return errors.New("validator not found")
 	}
 
-	pubkey, err := core.PubKeyFromBytes(eth2Pubkey[:])
+	pubkey, err := core.PubKeyFromBytes(state.PubKey[:])
 	if err != nil {
 		return err
 	}
@@ -855,49 +994,70 @@ func (c Component) SubmitAggregateAttestations(ctx context.Context, opts *eth2ap
 	}
 
 	psigsBySlot := make(map[eth2p0.Slot]core.ParSignedDataSet)
-	for _, agg := range aggsAndProofs {
+	failures := make(map[int]error)
+
+	var (
+		indices []int
+		slots   []eth2p0.Slot
+		pubkeys []core.PubKey
+		parSigs []core.ParSignedData
+	)
+
+	for i, agg := range aggsAndProofs {
 		slot, err := agg.Slot()
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		aggregatorIndex, err := agg.AggregatorIndex()
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		eth2Pubkey, ok := vals[aggregatorIndex]
 		if !ok {
-			return errors.New("validator not found")
+			failures[i] = errors.New("validator not found")
+			continue
 		}
 
 		pk, err := core.PubKeyFromBytes(eth2Pubkey[:])
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		// Verify inner selection proof (outcome of DutyPrepareAggregator).
 		if !c.insecureTest {
 			err = signing.VerifyAggregateAndProofSelection(ctx, c.eth2Cl, tbls.PublicKey(eth2Pubkey), agg)
 			if err != nil {
-				return err
+				failures[i] = err
+				continue
 			}
 		}
 
-		parSigData := core.NewPartialVersionedSignedAggregateAndProof(agg, c.shareIdx)
+		indices = append(indices, i)
+		slots = append(slots, slot)
+		pubkeys = append(pubkeys, pk)
+		parSigs = append(parSigs, core.NewPartialVersionedSignedAggregateAndProof(agg, c.shareIdx))
+	}
 
-		// Verify outer partial signature.
-		err = c.verifyPartialSig(ctx, parSigData, pk)
-		if err != nil {
-			return err
+	// Verify outer partial signatures.
+	invalid := c.verifyPartialSigs(ctx, parSigs, pubkeys)
+
+	for j, parSigData := range parSigs {
+		if err, ok := invalid[j]; ok {
+			failures[indices[j]] = err
+			continue
 		}
 
-		_, ok = psigsBySlot[slot]
+		_, ok := psigsBySlot[slots[j]]
 		if !ok {
-			psigsBySlot[slot] = make(core.ParSignedDataSet)
+			psigsBySlot[slots[j]] = make(core.ParSignedDataSet)
 		}
 
-		psigsBySlot[slot][pk] = parSigData
+		psigsBySlot[slots[j]][pubkeys[j]] = parSigData
 	}
 
 	for slot, data := range psigsBySlot {
@@ -910,7 +1070,7 @@ func (c Component) SubmitAggregateAttestations(ctx context.Context, opts *eth2ap
 		}
 	}
 
-	return nil
+	return batchError("error processing aggregate and proofs", failures)
 }
 
 // SyncCommitteeContribution returns sync committee contribution data for the given subcommittee and beacon block root.
@@ -931,24 +1091,29 @@ func (c Component) SubmitSyncCommitteeMessages(ctx context.Context, messages []*
 	}
 
 	psigsBySlot := make(map[eth2p0.Slot]core.ParSignedDataSet)
-	for _, msg := range messages {
+	failures := make(map[int]error)
+
+	for i, msg := range messages {
 		slot := msg.Slot
 
 		eth2Pubkey, ok := vals[msg.ValidatorIndex]
 		if !ok {
-			return errors.New("validator not found")
+			failures[i] = errors.New("validator not found")
+			continue
 		}
 
 		pk, err := core.PubKeyFromBytes(eth2Pubkey[:])
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		parSigData := core.NewPartialSignedSyncMessage(msg, c.shareIdx)
 
 		err = c.verifyPartialSig(ctx, parSigData, pk)
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		_, ok = psigsBySlot[slot]
@@ -969,7 +1134,7 @@ func (c Component) SubmitSyncCommitteeMessages(ctx context.Context, messages []*
 		}
 	}
 
-	return nil
+	return batchError("error processing sync committee messages", failures)
 }
 
 // SubmitSyncCommitteeContributions receives partially signed altair.SignedContributionAndProof.
@@ -982,7 +1147,9 @@ func (c Component) SubmitSyncCommitteeContributions(ctx context.Context, contrib
 	}
 
 	psigsBySlot := make(map[eth2p0.Slot]core.ParSignedDataSet)
-	for _, contrib := range contributionAndProofs {
+	failures := make(map[int]error)
+
+	for i, contrib := range contributionAndProofs {
 		var (
 			slot = contrib.Message.Contribution.Slot
 			vIdx = contrib.Message.AggregatorIndex
@@ -990,12 +1157,14 @@ func (c Component) SubmitSyncCommitteeContributions(ctx context.Context, contrib
 
 		eth2Pubkey, ok := vals[vIdx]
 		if !ok {
-			return errors.New("validator not found")
+			failures[i] = errors.New("validator not found")
+			continue
 		}
 
 		pk, err := core.PubKeyFromBytes(eth2Pubkey[:])
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		// Verify inner selection proof.
@@ -1004,7 +1173,8 @@ func (c Component) SubmitSyncCommitteeContributions(ctx context.Context, contrib
 
 			err = core.VerifyEth2SignedData(ctx, c.eth2Cl, msg, tbls.PublicKey(eth2Pubkey))
 			if err != nil {
-				return err
+				failures[i] = err
+				continue
 			}
 		}
 
@@ -1013,7 +1183,8 @@ func (c Component) SubmitSyncCommitteeContributions(ctx context.Context, contrib
 
 		err = c.verifyPartialSig(ctx, parSigData, pk)
 		if err != nil {
-			return err
+			failures[i] = err
+			continue
 		}
 
 		_, ok = psigsBySlot[slot]
@@ -1034,7 +1205,7 @@ func (c Component) SubmitSyncCommitteeContributions(ctx context.Context, contrib
 		}
 	}
 
-	return nil
+	return batchError("error processing contribution and proofs", failures)
 }
 
 // AggregateSyncCommitteeSelections returns aggregate sync committee selection proofs.
@@ -1132,6 +1303,7 @@ func (c Component) AttesterDuties(ctx context.Context, opts *eth2api.AttesterDut
 			return nil, errors.New("pubshare not found")
 		}
 
+		c.lastSeen.observe(ctx, core.PubKeyFrom48Bytes(duties[i].PubKey))
 		duties[i].PubKey = pubshare
 	}
 
@@ -1158,12 +1330,46 @@ func (c Component) SyncCommitteeDuties(ctx context.Context, opts *eth2api.SyncCo
 			return nil, errors.New("pubshare not found")
 		}
 
+		c.lastSeen.observe(ctx, core.PubKeyFrom48Bytes(duties[i].PubKey))
 		duties[i].PubKey = pubshare
 	}
 
 	return wrapResponse(duties), nil
 }
 
+// BeaconBlockHeader returns the beacon block header of the block ID, queried from all beacon nodes and cached.
+// Note that validator indices are identical for distributed validators, so no translation is required.
+func (c Component) BeaconBlockHeader(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+	if resp, ok := c.headerCache.Get(opts.Block); ok {
+		return resp, nil
+	}
+
+	resp, err := c.eth2Cl.BeaconBlockHeader(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.headerCache.Set(opts.Block, resp)
+
+	return resp, nil
+}
+
+// SignedBeaconBlock returns the signed beacon block of the block ID, queried from all beacon nodes and cached.
+func (c Component) SignedBeaconBlock(ctx context.Context, opts *eth2api.SignedBeaconBlockOpts) (*eth2api.Response[*eth2spec.VersionedSignedBeaconBlock], error) {
+	if resp, ok := c.blockCache.Get(opts.Block); ok {
+		return resp, nil
+	}
+
+	resp, err := c.eth2Cl.SignedBeaconBlock(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.blockCache.Set(opts.Block, resp)
+
+	return resp, nil
+}
+
 func (c Component) Validators(ctx context.Context, opts *eth2api.ValidatorsOpts) (*eth2api.Response[map[eth2p0.ValidatorIndex]*eth2v1.Validator], error) {
 	if len(opts.PubKeys) == 0 && len(opts.Indices) == 0 {
 		// fetch all validators
@@ -1190,10 +1396,15 @@ func (c Component) Validators(ctx context.Context, opts *eth2api.ValidatorsOpts)
 
 	for _, pubshare := range opts.PubKeys {
 		pubkey, err := c.getPubKeyFunc(pubshare)
-		if err != nil {
+
+		var mismatch mismatchedShareError
+		if errors.As(err, &mismatch) {
+			return nil, c.mismatches.record(ctx, mismatch)
+		} else if err != nil {
 			return nil, err
 		}
 
+		c.lastSeen.observe(ctx, core.PubKeyFrom48Bytes(pubkey))
 		pubkeys = append(pubkeys, pubkey)
 	}
 
@@ -1303,17 +1514,91 @@ func (c Component) verifyPartialSig(ctx context.Context, parSig core.ParSignedDa
 		return nil
 	}
 
-	pubshare, err := c.getVerifyShareFunc(pubkey)
+	eth2Signed, pubshare, err := c.eth2SignedShare(ctx, parSig, pubkey)
 	if err != nil {
 		return err
 	}
 
+	if err := core.VerifyEth2SignedData(ctx, c.eth2Cl, eth2Signed, pubshare); err != nil {
+		return err
+	}
+
+	c.lastSeen.observe(ctx, pubkey)
+
+	return nil
+}
+
+// verifyPartialSigs verifies the partial signatures together using BLS batch verification,
+// returning the errors of the invalid ones by index. If the batch is invalid, the partial signatures
+// are verified individually once to identify the invalid ones.
+func (c Component) verifyPartialSigs(ctx context.Context, parSigs []core.ParSignedData, pubkeys []core.PubKey) map[int]error {
+	invalid := make(map[int]error)
+	if c.insecureTest {
+		return invalid
+	}
+
+	var (
+		indices   []int
+		datas     []core.Eth2SignedData
+		pubshares []tbls.PublicKey
+	)
+
+	for i, parSig := range parSigs {
+		eth2Signed, pubshare, err := c.eth2SignedShare(ctx, parSig, pubkeys[i])
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+
+		indices = append(indices, i)
+		datas = append(datas, eth2Signed)
+		pubshares = append(pubshares, pubshare)
+	}
+
+	if len(datas) == 0 {
+		return invalid
+	}
+
+	batchInvalid, err := core.VerifyEth2SignedDataBatchInvalid(ctx, c.eth2Cl, datas, pubshares)
+	if err != nil {
+		for _, i := range indices {
+			invalid[i] = err
+		}
+
+		return invalid
+	}
+
+	for j, err := range batchInvalid {
+		invalid[indices[j]] = err
+	}
+
+	for _, i := range indices {
+		if _, ok := invalid[i]; !ok {
+			c.lastSeen.observe(ctx, pubkeys[i])
+		}
+	}
+
+	return invalid
+}
+
+// eth2SignedShare returns the eth2 signed data of the partial signature and the validator's public share
+// of this node, or an error if the authenticated validator client may not submit for it.
+func (c Component) eth2SignedShare(ctx context.Context, parSig core.ParSignedData, pubkey core.PubKey) (core.Eth2SignedData, tbls.PublicKey, error) {
+	pubshare, err := c.getVerifyShareFunc(pubkey)
+	if err != nil {
+		return nil, tbls.PublicKey{}, err
+	}
+
+	if err := verifyVCAllowed(ctx, core.PubKeyFrom48Bytes(pubshare)); err != nil {
+		return nil, tbls.PublicKey{}, err
+	}
+
 	eth2Signed, ok := parSig.SignedData.(core.Eth2SignedData)
 	if !ok {
-		return errors.New("invalid eth2 signed data")
+		return nil, tbls.PublicKey{}, errors.New("invalid eth2 signed data")
 	}
 
-	return core.VerifyEth2SignedData(ctx, c.eth2Cl, eth2Signed, pubshare)
+	return eth2Signed, pubshare, nil
 }
 
 func (c Component) getAggregateBeaconCommSelection(ctx context.Context, psigsBySlot map[eth2p0.Slot]core.ParSignedDataSet) ([]*eth2exp.BeaconCommitteeSelection, error) {
@@ -1366,12 +1651,34 @@ func (c Component) getAggregateSyncCommSelection(ctx context.Context, psigsBySlo
 
 // ProposerConfig returns the proposer configuration for all validators.
 func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigResponse, error) {
-	var targetGasLimit uint
-	if c.targetGasLimit == 0 {
-		log.Warn(ctx, "", errors.New("custom target gas limit not supported, setting to default", z.Uint("default_gas_limit", defaultGasLimit)))
+	genesisTime, err := eth2wrap.FetchGenesisTime(ctx, c.eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	slotDuration, slotsPerEpoch, err := eth2wrap.FetchSlotsConfig(ctx, c.eth2Cl)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := genesisTime
+	timestamp = timestamp.Add(slotDuration) // Use slot 1 for timestamp to override pre-generated registrations.
+
+	targetGasLimit := c.targetGasLimit
+	if c.gasLimitRamp != nil && time.Now().After(genesisTime) {
+		epoch := uint64(time.Since(genesisTime)/slotDuration) / slotsPerEpoch
+		targetGasLimit = uint(c.gasLimitRamp.GasLimit(epoch))
+
+		// Use the start of the ramp epoch for timestamp to override registrations of previous ramp epochs.
+		rampEpoch := min(epoch, c.gasLimitRamp.StartEpoch+c.gasLimitRamp.Epochs)
+		if rampTimestamp := genesisTime.Add(time.Duration(rampEpoch*slotsPerEpoch) * slotDuration); epoch >= c.gasLimitRamp.StartEpoch && rampTimestamp.After(timestamp) {
+			timestamp = rampTimestamp
+		}
+	}
+
+	if targetGasLimit == 0 {
+		// Cluster locks of versions before target gas limit support use the default.
 		targetGasLimit = defaultGasLimit
-	} else {
-		targetGasLimit = c.targetGasLimit
 	}
 
 	resp := eth2exp.ProposerConfigResponse{
@@ -1385,19 +1692,6 @@ func (c Component) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigR
 		},
 	}
 
-	genesisTime, err := eth2wrap.FetchGenesisTime(ctx, c.eth2Cl)
-	if err != nil {
-		return nil, err
-	}
-
-	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, c.eth2Cl)
-	if err != nil {
-		return nil, err
-	}
-
-	timestamp := genesisTime
-	timestamp = timestamp.Add(slotDuration) // Use slot 1 for timestamp to override pre-generated registrations.
-
 	for pubkey, pubshare := range c.sharesByKey {
 		eth2Share, err := pubshare.ToETH2()
 		if err != nil {
@@ -55,6 +55,12 @@ func TestMismatchKeysFunc(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, resp, eth2p0.BLSPubKey{})
 		require.ErrorContains(t, err, "mismatching validator client key share index, Mth key share submitted to Nth charon peer")
+
+		var mismatch mismatchedShareError
+		require.ErrorAs(t, err, &mismatch)
+		require.Equal(t, shareIdx, mismatch.share.KeyShareIndex)
+		require.Equal(t, shareIdx-1, mismatch.share.CharonPeerIndex)
+		require.Equal(t, string(corePubKey), mismatch.share.PubKey)
 	})
 
 	t.Run("unknown public key", func(t *testing.T) {
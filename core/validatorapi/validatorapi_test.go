@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"sort"
 	"strconv"
 	"sync"
@@ -34,6 +35,7 @@ import (
 	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -215,6 +217,79 @@ func TestComponent_ValidSubmitAttestations(t *testing.T) {
 	}
 }
 
+func TestComponent_SubmitElectraAggregatedFormatAttestations(t *testing.T) {
+	const (
+		slot       = 123
+		commIdx    = 12
+		vIdx       = 7
+		valCommIdx = 3
+		commLen    = 8
+	)
+
+	eth2Cl, err := beaconmock.New()
+	require.NoError(t, err)
+
+	component, err := validatorapi.NewComponentInsecure(t, eth2Cl, 0)
+	require.NoError(t, err)
+
+	pubkey := testutil.RandomCorePubKey(t)
+
+	component.RegisterPubKeyByAttestation(func(_ context.Context, _, attCommIdx, valIdx uint64) (core.PubKey, error) {
+		require.EqualValues(t, commIdx, attCommIdx)
+		require.EqualValues(t, vIdx, valIdx)
+
+		return pubkey, nil
+	})
+
+	component.RegisterGetDutyDefinition(func(context.Context, core.Duty) (core.DutyDefinitionSet, error) {
+		return core.DutyDefinitionSet{
+			pubkey: core.AttesterDefinition{
+				AttesterDuty: eth2v1.AttesterDuty{
+					Slot:                    slot,
+					ValidatorIndex:          vIdx,
+					CommitteeIndex:          commIdx,
+					CommitteeLength:         commLen,
+					ValidatorCommitteeIndex: valCommIdx,
+				},
+			},
+		}, nil
+	})
+
+	var submitted core.ParSignedDataSet
+	component.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		submitted = set
+		return nil
+	})
+
+	aggBits := bitfield.NewBitlist(commLen)
+	aggBits.SetBitAt(valCommIdx, true)
+
+	commBits := bitfield.NewBitvector64()
+	commBits.SetBitAt(commIdx, true)
+
+	// Electra attestation in the aggregated Attestation format, lacking the validator index.
+	att := &eth2spec.VersionedAttestation{
+		Version: eth2spec.DataVersionElectra,
+		Electra: &electra.Attestation{
+			AggregationBits: aggBits,
+			Data: &eth2p0.AttestationData{
+				Slot:   slot,
+				Source: &eth2p0.Checkpoint{},
+				Target: &eth2p0.Checkpoint{},
+			},
+			CommitteeBits: commBits,
+		},
+	}
+
+	err = component.SubmitAttestations(t.Context(), &eth2api.SubmitAttestationsOpts{Attestations: []*eth2spec.VersionedAttestation{att}})
+	require.NoError(t, err)
+
+	parSig, ok := submitted[pubkey].SignedData.(core.VersionedAttestation)
+	require.True(t, ok)
+	require.NotNil(t, parSig.ValidatorIndex)
+	require.EqualValues(t, vIdx, *parSig.ValidatorIndex)
+}
+
 func TestComponent_InvalidSubmitAttestations(t *testing.T) {
 	ctx := context.Background()
 	eth2Cl, err := beaconmock.New()
@@ -289,6 +364,7 @@ func TestSubmitAttestations_Verify(t *testing.T) {
 	bmock, err := beaconmock.New(
 		beaconmock.WithValidatorSet(beaconmock.ValidatorSet{vIdx: validator}),
 		beaconmock.WithDeterministicAttesterDuties(0), // All duties in first slot of epoch.
+		beaconmock.WithForkEpoch("electra", 0),
 	)
 	require.NoError(t, err)
 
@@ -334,6 +410,92 @@ func TestSubmitAttestations_Verify(t *testing.T) {
 	require.NoError(t, attester.Attest(ctx))
 }
 
+func TestSubmitAttestations_VerifyBatch(t *testing.T) {
+	ctx := context.Background()
+
+	const shareIdx = 1
+
+	var (
+		validators        = make(beaconmock.ValidatorSet)
+		pubkeysByIdx      = make(map[uint64]core.PubKey)
+		allPubSharesByKey = make(map[core.PubKey]map[int]tbls.PublicKey)
+		secrets           []tbls.PrivateKey
+		eth2Pubkeys       []eth2p0.BLSPubKey
+		pubshares         []tbls.PublicKey
+	)
+
+	// Configure validators 1-3 using normal keys, not split tbls.
+	for vIdx := eth2p0.ValidatorIndex(1); vIdx <= 3; vIdx++ {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		validator := *beaconmock.ValidatorSetA[1]
+		validatorData := *validator.Validator
+		validator.Index = vIdx
+		validator.Validator = &validatorData
+		validator.Validator.PublicKey = eth2p0.BLSPubKey(pubkey)
+		validators[vIdx] = &validator
+
+		corePubKey, err := core.PubKeyFromBytes(pubkey[:])
+		require.NoError(t, err)
+
+		pubkeysByIdx[uint64(vIdx)] = corePubKey
+		secrets = append(secrets, secret)
+		eth2Pubkeys = append(eth2Pubkeys, eth2p0.BLSPubKey(pubkey))
+		pubshares = append(pubshares, pubkey)
+	}
+
+	// Maps self to self since not tbls, except validator 2 which maps to the wrong share, so its signature is invalid.
+	for i, pubshare := range pubshares {
+		if i == 1 {
+			pubshare = pubshares[0]
+		}
+
+		allPubSharesByKey[pubkeysByIdx[uint64(i+1)]] = map[int]tbls.PublicKey{shareIdx: pubshare}
+	}
+
+	bmock, err := beaconmock.New(
+		beaconmock.WithValidatorSet(validators),
+		beaconmock.WithDeterministicAttesterDuties(0), // All duties in first slot of epoch.
+		beaconmock.WithForkEpoch("electra", 0),
+	)
+	require.NoError(t, err)
+
+	epochSlot, err := bmock.SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+
+	vapi, err := validatorapi.NewComponent(bmock, allPubSharesByKey, shareIdx, nil, false, 30000000, nil)
+	require.NoError(t, err)
+
+	vapi.RegisterPubKeyByAttestation(func(_ context.Context, _, _, valIdx uint64) (core.PubKey, error) {
+		return pubkeysByIdx[valIdx], nil
+	})
+
+	var submitted core.ParSignedDataSet
+	vapi.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		submitted = set
+		return nil
+	})
+
+	bmock.SubmitAttestationsFunc = vapi.SubmitAttestations
+
+	signer, err := validatormock.NewSigner(secrets...)
+	require.NoError(t, err)
+
+	attester := validatormock.NewSlotAttester(bmock, eth2p0.Slot(epochSlot), signer, eth2Pubkeys)
+	require.NoError(t, attester.Prepare(ctx))
+
+	// The invalid attestation is excluded while the valid ones are still submitted.
+	err = attester.Attest(ctx)
+	require.ErrorContains(t, err, "error processing attestations")
+	require.Len(t, submitted, 2)
+	require.Contains(t, submitted, pubkeysByIdx[1])
+	require.Contains(t, submitted, pubkeysByIdx[3])
+}
+
 // TestSignAndVerify signs and verifies the signature.
 // Test input and output obtained from prysm/validator/client/attest_test.go#TestSignAttestation.
 func TestSignAndVerify(t *testing.T) {
@@ -1822,6 +1984,11 @@ func TestComponent_SubmitValidatorRegistration(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, *signed, registration.VersionedSignedValidatorRegistration)
 
+	// Assert identical re-submitted registration is skipped
+	err = vapi.SubmitValidatorRegistrations(ctx, []*eth2api.VersionedSignedValidatorRegistration{signed})
+	require.NoError(t, err)
+	require.Empty(t, output)
+
 	// Assert incorrect pubkey registration is swallowed
 	close(output) // Panic if registration is not swallowed
 
@@ -1958,6 +2125,74 @@ func TestComponent_TekuProposerConfig(t *testing.T) {
 	}, resp)
 }
 
+func TestComponent_TekuProposerConfigGasLimitRamp(t *testing.T) {
+	ctx := context.Background()
+
+	const shareIdx = 1
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	corePubKey, err := core.PubKeyFromBytes(pubkey[:])
+	require.NoError(t, err)
+
+	eth2pk, err := corePubKey.ToETH2()
+	require.NoError(t, err)
+
+	allPubSharesByKey := map[core.PubKey]map[int]tbls.PublicKey{corePubKey: {shareIdx: pubkey}}
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	genesis, err := bmock.Genesis(ctx, &eth2api.GenesisOpts{})
+	require.NoError(t, err)
+
+	slotDuration, _, err := eth2wrap.FetchSlotsConfig(ctx, bmock)
+	require.NoError(t, err)
+
+	slot1Timestamp := strconv.FormatInt(genesis.Data.GenesisTime.Add(slotDuration).Unix(), 10)
+
+	tests := []struct {
+		name          string
+		ramp          registration.GasLimitRamp
+		gasLimit      uint
+		slot1Override bool
+	}{
+		{
+			name:          "before ramp",
+			ramp:          registration.GasLimitRamp{From: 30000000, To: 60000000, StartEpoch: math.MaxUint32, Epochs: 10},
+			gasLimit:      30000000,
+			slot1Override: true,
+		},
+		{
+			name:     "after ramp",
+			ramp:     registration.GasLimitRamp{From: 30000000, To: 60000000, StartEpoch: 1, Epochs: 10},
+			gasLimit: 60000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vapi, err := validatorapi.NewComponent(bmock, allPubSharesByKey, shareIdx, func(core.PubKey) string {
+				return "0x123456"
+			}, true, 30000000, nil)
+			require.NoError(t, err)
+
+			vapi.RegisterGasLimitRamp(test.ramp)
+
+			resp, err := vapi.ProposerConfig(ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, test.gasLimit, resp.Default.Builder.GasLimit)
+			require.Equal(t, test.gasLimit, resp.Proposers[eth2pk].Builder.GasLimit)
+			require.Equal(t, test.slot1Override, resp.Proposers[eth2pk].Builder.Overrides["timestamp"] == slot1Timestamp)
+		})
+	}
+}
+
 func TestComponent_AggregateBeaconCommitteeSelections(t *testing.T) {
 	ctx := context.Background()
 
@@ -2094,6 +2329,38 @@ func TestComponent_SubmitSyncCommitteeMessages(t *testing.T) {
 	require.Equal(t, count, 1)
 }
 
+func TestComponent_SubmitSyncCommitteeMessagesPartialFailure(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		valid   = testutil.RandomSyncCommitteeMessage()
+		unknown = testutil.RandomSyncCommitteeMessage()
+		count   = 0 // No of times the subscription function is called.
+	)
+
+	valid.ValidatorIndex = 1
+	unknown.ValidatorIndex = 999
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSetA))
+	require.NoError(t, err)
+
+	vapi, err := validatorapi.NewComponentInsecure(t, bmock, 0)
+	require.NoError(t, err)
+
+	vapi.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		require.Len(t, set, 1)
+
+		count++
+
+		return nil
+	})
+
+	// Valid messages are submitted while invalid ones are returned as failures.
+	err = vapi.SubmitSyncCommitteeMessages(ctx, []*altair.SyncCommitteeMessage{unknown, valid})
+	require.ErrorContains(t, err, "error processing sync committee messages")
+	require.ErrorContains(t, err, "validator not found")
+	require.Equal(t, 1, count)
+}
+
 func TestComponent_SubmitSyncCommitteeContributions(t *testing.T) {
 	const vIdx = 1
 
@@ -22,7 +22,6 @@ import (
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth1wrap"
 	"github.com/obolnetwork/charon/app/log"
-	"github.com/obolnetwork/charon/app/obolapi"
 	"github.com/obolnetwork/charon/app/peerinfo"
 	"github.com/obolnetwork/charon/app/privkeylock"
 	"github.com/obolnetwork/charon/app/version"
@@ -52,9 +51,10 @@ type Config struct {
 	KeymanagerAddr      string
 	KeymanagerAuthToken string
 
-	PublishAddr    string
-	PublishTimeout time.Duration
-	Publish        bool
+	PublishAddr     string
+	PublishTimeout  time.Duration
+	PublishAttempts int
+	Publish         bool
 
 	ExecutionEngineAddr string
 
@@ -351,18 +351,22 @@ func Run(ctx context.Context, conf Config) (err error) {
 	// the generated lock file.
 	var dashboardURL string
 
-	if conf.Publish {
-		if dashboardURL, err = writeLockToAPI(ctx, conf.PublishAddr, lock, conf.PublishTimeout); err != nil {
-			log.Warn(ctx, "Couldn't publish lock file to Obol API", err)
-		}
-	}
-
 	if err = writeLock(conf.DataDir, lock); err != nil {
 		return err
 	}
 
 	log.Debug(ctx, "Saved lock file to disk")
 
+	if conf.Publish {
+		if dashboardURL, err = PublishLock(ctx, conf.PublishAddr, lock, conf.PublishTimeout, conf.PublishAttempts); err != nil {
+			log.Warn(ctx, "Couldn't publish lock file to Obol API, publish it later using `charon publish-lock`", err)
+
+			if err := writePendingPublish(conf.DataDir, conf.PublishAddr, lock, err); err != nil {
+				log.Warn(ctx, "Couldn't save pending lock file publish to disk", err)
+			}
+		}
+	}
+
 	// The loop across partial amounts (shall be unique)
 	for _, dd := range depositDatas {
 		if err := deposit.WriteDepositDataFile(dd, network, conf.DataDir); err != nil {
@@ -1085,22 +1089,6 @@ func createDistValidators(shares []share, depositDatas [][]eth2p0.DepositData, v
 	return dvs, nil
 }
 
-// writeLockToAPI posts the lock file to obol-api and returns the Launchpad dashboard URL.
-func writeLockToAPI(ctx context.Context, publishAddr string, lock cluster.Lock, timeout time.Duration) (string, error) {
-	cl, err := obolapi.New(publishAddr, obolapi.WithTimeout(timeout))
-	if err != nil {
-		return "", err
-	}
-
-	if err := cl.PublishLock(ctx, lock); err != nil {
-		return "", err
-	}
-
-	log.Debug(ctx, "Published lock file to api")
-
-	return cl.LaunchpadURLForLock(lock), nil
-}
-
 // validateKeymanagerFlags returns an error if one keymanager flag is present but the other is not.
 func validateKeymanagerFlags(ctx context.Context, addr, authToken string) error {
 	if addr != "" && authToken == "" {
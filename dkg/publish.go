@@ -0,0 +1,78 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/expbackoff"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/obolapi"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+// PendingPublishFile is the name of the file written next to the cluster lock if publishing
+// it to the Obol API failed. It can be published later via `charon publish-lock`.
+const PendingPublishFile = "cluster-lock-publish-pending.json"
+
+// PendingPublish is the offline artifact of a cluster lock that couldn't be published.
+type PendingPublish struct {
+	PublishAddr string    `json:"publish_address"`
+	LockHash    string    `json:"lock_hash"`
+	Error       string    `json:"error"`
+	FailedAt    time.Time `json:"failed_at"`
+}
+
+// PublishLock posts the lock file to the Obol API, retrying attempts failing with network errors or server errors
+// with exponential backoff, and returns the Launchpad dashboard URL. The timeout applies to each attempt.
+func PublishLock(ctx context.Context, publishAddr string, lock cluster.Lock, timeout time.Duration, attempts int) (string, error) {
+	cl, err := obolapi.New(publishAddr, obolapi.WithTimeout(timeout))
+	if err != nil {
+		return "", err
+	}
+
+	backoff := expbackoff.New(ctx)
+
+	for attempt := 1; ; attempt++ {
+		err = cl.PublishLock(ctx, lock)
+		if err == nil {
+			break
+		} else if attempt >= attempts || ctx.Err() != nil || errors.Is(err, obolapi.ErrClientStatus) {
+			return "", errors.Wrap(err, "publish lock", z.Int("attempts", attempt))
+		}
+
+		log.Warn(ctx, "Publishing lock file to api failed, retrying", err, z.Int("attempt", attempt))
+		backoff()
+	}
+
+	log.Debug(ctx, "Published lock file to api")
+
+	return cl.LaunchpadURLForLock(lock), nil
+}
+
+// writePendingPublish writes the offline publish artifact of the lock to the data directory.
+func writePendingPublish(datadir string, publishAddr string, lock cluster.Lock, publishErr error) error {
+	b, err := json.MarshalIndent(PendingPublish{
+		PublishAddr: publishAddr,
+		LockHash:    fmt.Sprintf("%#x", lock.LockHash),
+		Error:       publishErr.Error(),
+		FailedAt:    time.Now().UTC(),
+	}, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal pending publish")
+	}
+
+	//nolint:gosec // File needs to be readable by the publish-lock command run by any user.
+	if err := os.WriteFile(path.Join(datadir, PendingPublishFile), b, 0o644); err != nil {
+		return errors.Wrap(err, "write pending publish")
+	}
+
+	return nil
+}
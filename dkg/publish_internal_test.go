@@ -0,0 +1,103 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/expbackoff"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster"
+)
+
+func TestPublishLock(t *testing.T) {
+	expbackoff.SetAfterForT(t, func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+
+		return ch
+	})
+
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+
+	newServer := func(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) <= failures {
+				w.WriteHeader(status)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv, &calls
+	}
+
+	t.Run("retries", func(t *testing.T) {
+		srv, calls := newServer(2, http.StatusServiceUnavailable)
+
+		dashboardURL, err := PublishLock(t.Context(), srv.URL, lock, time.Second, 3)
+		require.NoError(t, err)
+		require.Contains(t, dashboardURL, "/launchpad")
+		require.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		srv, calls := newServer(3, http.StatusServiceUnavailable)
+
+		_, err := PublishLock(t.Context(), srv.URL, lock, time.Second, 3)
+		require.ErrorContains(t, err, "publish lock")
+		require.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("client errors not retried", func(t *testing.T) {
+		srv, calls := newServer(3, http.StatusBadRequest)
+
+		_, err := PublishLock(t.Context(), srv.URL, lock, time.Second, 3)
+		require.ErrorContains(t, err, "publish lock")
+		require.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("network errors retried", func(t *testing.T) {
+		srv, calls := newServer(0, http.StatusOK)
+		addr := srv.URL
+		srv.Close()
+
+		_, err := PublishLock(t.Context(), addr, lock, time.Second, 2)
+		require.ErrorContains(t, err, "publish lock")
+		require.True(t, z.ContainsField(err, z.Int("attempts", 2)))
+		require.Zero(t, calls.Load())
+	})
+}
+
+func TestWritePendingPublish(t *testing.T) {
+	lock, _, _ := cluster.NewForT(t, 1, 3, 4, 0, rand.New(rand.NewSource(0)))
+	dir := t.TempDir()
+
+	err := writePendingPublish(dir, "https://api.obol.tech/v1", lock, errors.New("api unavailable"))
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path.Join(dir, PendingPublishFile))
+	require.NoError(t, err)
+
+	var pending PendingPublish
+	require.NoError(t, json.Unmarshal(b, &pending))
+	require.Equal(t, "https://api.obol.tech/v1", pending.PublishAddr)
+	require.Equal(t, fmt.Sprintf("%#x", lock.LockHash), pending.LockHash)
+	require.Equal(t, "api unavailable", pending.Error)
+}
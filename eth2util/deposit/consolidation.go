@@ -0,0 +1,126 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package deposit
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+// ConsolidationSource is a validator with 0x01 withdrawal credentials to be migrated to compounding 0x02 credentials.
+type ConsolidationSource struct {
+	// PubKey is the validator BLS public key.
+	PubKey eth2p0.BLSPubKey
+	// WithdrawalAddress is the validator's 0x01 withdrawal address, the only address allowed to submit consolidation requests.
+	WithdrawalAddress string
+	// Amount is the validator's balance being consolidated.
+	Amount eth2p0.Gwei
+}
+
+// NewSelfConsolidationRequests returns the EIP-7251 self-consolidation requests switching each of the provided
+// validators from 0x01 to compounding 0x02 withdrawal credentials.
+func NewSelfConsolidationRequests(sources []ConsolidationSource) ([]electra.ConsolidationRequest, error) {
+	var resp []electra.ConsolidationRequest
+	for _, source := range sources {
+		if err := VerifyConsolidationAmounts([]eth2p0.Gwei{source.Amount}); err != nil {
+			return nil, errors.Wrap(err, "verify consolidation amount", z.Hex("pubkey", source.PubKey[:]))
+		}
+
+		req, err := newConsolidationRequest(source.WithdrawalAddress, source.PubKey, source.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, req)
+	}
+
+	return resp, nil
+}
+
+// NewConsolidationRequests returns the EIP-7251 consolidation requests merging all the provided source validators into
+// the target validator. The first request is the target's self-consolidation, since consolidation targets must have
+// compounding 0x02 withdrawal credentials. All validators must share the same withdrawal address.
+func NewConsolidationRequests(target ConsolidationSource, sources []ConsolidationSource) ([]electra.ConsolidationRequest, error) {
+	amounts := []eth2p0.Gwei{target.Amount}
+	for _, source := range sources {
+		if source.PubKey == target.PubKey {
+			return nil, errors.New("consolidation source equals target", z.Hex("pubkey", source.PubKey[:]))
+		}
+
+		if !strings.EqualFold(source.WithdrawalAddress, target.WithdrawalAddress) {
+			return nil, errors.New("consolidation source and target withdrawal addresses mismatch",
+				z.Hex("pubkey", source.PubKey[:]), z.Str("source_address", source.WithdrawalAddress), z.Str("target_address", target.WithdrawalAddress))
+		}
+
+		amounts = append(amounts, source.Amount)
+	}
+
+	if err := VerifyConsolidationAmounts(amounts); err != nil {
+		return nil, err
+	}
+
+	resp, err := NewSelfConsolidationRequests([]ConsolidationSource{target})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		req, err := newConsolidationRequest(source.WithdrawalAddress, source.PubKey, target.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, req)
+	}
+
+	return resp, nil
+}
+
+// VerifyConsolidationAmounts verifies that the resulting balance of consolidating the provided amounts
+// doesn't exceed MaxCompoundingDepositAmount.
+func VerifyConsolidationAmounts(amounts []eth2p0.Gwei) error {
+	var sum eth2p0.Gwei
+	for _, amount := range amounts {
+		if amount < MinDepositAmount {
+			return errors.New("consolidation amount must be at least 1ETH", z.U64("amount", uint64(amount)))
+		}
+
+		sum += amount
+	}
+
+	if sum > MaxCompoundingDepositAmount {
+		return errors.New("sum of consolidation amounts exceeds maximum compounding amount",
+			z.U64("sum", uint64(sum)), z.U64("max", uint64(MaxCompoundingDepositAmount)))
+	}
+
+	return nil
+}
+
+// newConsolidationRequest returns a consolidation request submitted by the provided withdrawal address.
+func newConsolidationRequest(withdrawalAddr string, source, target eth2p0.BLSPubKey) (electra.ConsolidationRequest, error) {
+	if _, err := eth2util.ChecksumAddress(withdrawalAddr); err != nil {
+		return electra.ConsolidationRequest{}, errors.Wrap(err, "invalid withdrawal address", z.Str("addr", withdrawalAddr))
+	}
+
+	addrBytes, err := hex.DecodeString(strings.TrimPrefix(withdrawalAddr, "0x"))
+	if err != nil {
+		return electra.ConsolidationRequest{}, errors.Wrap(err, "decode address")
+	}
+
+	var addr bellatrix.ExecutionAddress
+	copy(addr[:], addrBytes)
+
+	return electra.ConsolidationRequest{
+		SourceAddress: addr,
+		SourcePubkey:  source,
+		TargetPubkey:  target,
+	}, nil
+}
@@ -358,6 +358,87 @@ func WriteDepositDataFile(depositDatas []eth2p0.DepositData, network string, dat
 	return nil
 }
 
+// WriteClusterMixedDepositDataFiles writes a deposit-data-mixed.json file containing the provided depositDatas
+// with per-validator amounts to each node directory.
+func WriteClusterMixedDepositDataFiles(depositDatas []eth2p0.DepositData, network string, clusterDir string, numNodes int) error {
+	for n := range numNodes {
+		nodeDir := path.Join(clusterDir, fmt.Sprintf("node%d", n))
+		if err := WriteMixedDepositDataFile(depositDatas, network, nodeDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteMixedDepositDataFile writes a deposit-data-mixed.json file for the provided depositDatas
+// which may have different amounts per validator, e.g. to bootstrap validators at different initial balances.
+// Each row is validated against the deposit amount limits of its withdrawal credentials type.
+func WriteMixedDepositDataFile(depositDatas []eth2p0.DepositData, network string, dataDir string) error {
+	if len(depositDatas) == 0 {
+		return errors.New("empty deposit data")
+	}
+
+	pubkeys := make(map[eth2p0.BLSPubKey]bool)
+	for i, dd := range depositDatas {
+		if pubkeys[dd.PublicKey] {
+			return errors.New("duplicate deposit data pubkey", z.Int("index", i))
+		}
+
+		pubkeys[dd.PublicKey] = true
+
+		if err := verifyDepositAmount(dd); err != nil {
+			return errors.Wrap(err, "invalid deposit data", z.Int("index", i))
+		}
+	}
+
+	bytes, err := MarshalDepositData(depositDatas, network)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // File needs to be read-only for everybody
+	err = os.WriteFile(GetMixedDepositFilePath(dataDir), bytes, 0o444)
+	if err != nil {
+		return errors.Wrap(err, "write deposit data")
+	}
+
+	return nil
+}
+
+// verifyDepositAmount returns an error if the deposit data amount is outside the limits
+// of its withdrawal credentials type.
+func verifyDepositAmount(dd eth2p0.DepositData) error {
+	if len(dd.WithdrawalCredentials) != 32 {
+		return errors.New("invalid withdrawal credentials length", z.Int("length", len(dd.WithdrawalCredentials)))
+	}
+
+	var compounding bool
+	switch dd.WithdrawalCredentials[0] {
+	case eth1AddressWithdrawalPrefix[0]:
+	case eip7251AddressWithdrawalPrefix[0]:
+		compounding = true
+	default:
+		return errors.New("unsupported withdrawal credentials prefix", z.U64("prefix", uint64(dd.WithdrawalCredentials[0])))
+	}
+
+	if dd.Amount < MinDepositAmount {
+		return errors.New("deposit amount must be >= 1ETH", z.U64("amount", uint64(dd.Amount)))
+	}
+
+	if maxAmount := MaxDepositAmount(compounding); dd.Amount > maxAmount {
+		return errors.New("deposit amount exceeds maximum for withdrawal credentials type",
+			z.U64("amount", uint64(dd.Amount)), z.U64("max", uint64(maxAmount)))
+	}
+
+	return nil
+}
+
+// GetMixedDepositFilePath returns the path of the deposit-data file containing mixed amounts.
+func GetMixedDepositFilePath(dataDir string) string {
+	return path.Join(dataDir, "deposit-data-mixed.json")
+}
+
 // GetDepositFilePath constructs and return deposit-data file path.
 func GetDepositFilePath(dataDir string, amount eth2p0.Gwei) string {
 	var filename string
@@ -267,6 +267,58 @@ func TestWriteClusterDepositDataFiles(t *testing.T) {
 	}
 }
 
+func TestWriteMixedDepositDataFile(t *testing.T) {
+	dir := t.TempDir()
+
+	small := mustGenerateDepositDatas(t, deposit.MinDepositAmount)
+	large := mustGenerateDepositDatas(t, deposit.MaxCompoundingDepositAmount)
+	depositDatas := []eth2p0.DepositData{small[0], small[1], large[2], large[3]}
+
+	err := deposit.WriteMixedDepositDataFile(depositDatas, eth2util.Goerli.Name, dir)
+	require.NoError(t, err)
+
+	expected, err := deposit.MarshalDepositData(depositDatas, eth2util.Goerli.Name)
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(deposit.GetMixedDepositFilePath(dir))
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	t.Run("empty deposit datas", func(t *testing.T) {
+		err := deposit.WriteMixedDepositDataFile(nil, eth2util.Goerli.Name, t.TempDir())
+		require.ErrorContains(t, err, "empty deposit data")
+	})
+
+	t.Run("duplicate pubkey", func(t *testing.T) {
+		err := deposit.WriteMixedDepositDataFile([]eth2p0.DepositData{small[0], large[0]}, eth2util.Goerli.Name, t.TempDir())
+		require.ErrorContains(t, err, "duplicate deposit data pubkey")
+	})
+
+	t.Run("amount exceeds standard maximum", func(t *testing.T) {
+		dd := large[0]
+		dd.WithdrawalCredentials = append([]byte{0x01}, dd.WithdrawalCredentials[1:]...)
+
+		err := deposit.WriteMixedDepositDataFile([]eth2p0.DepositData{dd}, eth2util.Goerli.Name, t.TempDir())
+		require.ErrorContains(t, err, "deposit amount exceeds maximum for withdrawal credentials type")
+	})
+
+	t.Run("amount below minimum", func(t *testing.T) {
+		dd := small[0]
+		dd.Amount--
+
+		err := deposit.WriteMixedDepositDataFile([]eth2p0.DepositData{dd}, eth2util.Goerli.Name, t.TempDir())
+		require.ErrorContains(t, err, "deposit amount must be >= 1ETH")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		dd := small[0]
+		dd.Amount++
+
+		err := deposit.WriteMixedDepositDataFile([]eth2p0.DepositData{dd}, eth2util.Goerli.Name, t.TempDir())
+		require.ErrorContains(t, err, "invalid deposit data signature")
+	})
+}
+
 func mustGenerateDepositDatas(t *testing.T, amount eth2p0.Gwei) []eth2p0.DepositData {
 	t.Helper()
 
@@ -318,3 +370,58 @@ func TestDedupAmounts(t *testing.T) {
 
 	require.Equal(t, []eth2p0.Gwei{0, 100, 300, 500}, amounts)
 }
+
+func TestConsolidationRequests(t *testing.T) {
+	const addr = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+
+	source := func(amount eth2p0.Gwei) deposit.ConsolidationSource {
+		return deposit.ConsolidationSource{
+			PubKey:            testutil.RandomEth2PubKey(t),
+			WithdrawalAddress: addr,
+			Amount:            amount,
+		}
+	}
+
+	t.Run("self consolidation", func(t *testing.T) {
+		sources := []deposit.ConsolidationSource{source(deposit.DefaultDepositAmount), source(deposit.DefaultDepositAmount)}
+
+		reqs, err := deposit.NewSelfConsolidationRequests(sources)
+		require.NoError(t, err)
+		require.Len(t, reqs, 2)
+
+		for i, req := range reqs {
+			require.Equal(t, sources[i].PubKey, req.SourcePubkey)
+			require.Equal(t, sources[i].PubKey, req.TargetPubkey)
+			require.Equal(t, addr, fmt.Sprintf("%#x", req.SourceAddress[:]))
+		}
+	})
+
+	t.Run("consolidation into target", func(t *testing.T) {
+		target := source(deposit.DefaultDepositAmount)
+		sources := []deposit.ConsolidationSource{source(deposit.DefaultDepositAmount), source(deposit.DefaultDepositAmount)}
+
+		reqs, err := deposit.NewConsolidationRequests(target, sources)
+		require.NoError(t, err)
+		require.Len(t, reqs, 3)
+		require.Equal(t, target.PubKey, reqs[0].SourcePubkey)
+		require.Equal(t, target.PubKey, reqs[0].TargetPubkey)
+
+		for i, req := range reqs[1:] {
+			require.Equal(t, sources[i].PubKey, req.SourcePubkey)
+			require.Equal(t, target.PubKey, req.TargetPubkey)
+		}
+	})
+
+	t.Run("exceeds max compounding amount", func(t *testing.T) {
+		_, err := deposit.NewConsolidationRequests(source(deposit.MaxCompoundingDepositAmount), []deposit.ConsolidationSource{source(deposit.MinDepositAmount)})
+		require.ErrorContains(t, err, "sum of consolidation amounts exceeds maximum compounding amount")
+	})
+
+	t.Run("withdrawal address mismatch", func(t *testing.T) {
+		other := source(deposit.DefaultDepositAmount)
+		other.WithdrawalAddress = "0x0000000000000000000000000000000000000001"
+
+		_, err := deposit.NewConsolidationRequests(source(deposit.DefaultDepositAmount), []deposit.ConsolidationSource{other})
+		require.ErrorContains(t, err, "consolidation source and target withdrawal addresses mismatch")
+	})
+}
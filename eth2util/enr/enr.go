@@ -28,8 +28,12 @@ const (
 
 	// keyIP is the key used to store the IP v4 address in the record.
 	keyIP = "ip"
+	// keyIP6 is the key used to store the IP v6 address in the record.
+	keyIP6 = "ip6"
 	// keyTCP is the key used to store the TCP port in the record.
 	keyTCP = "tcp"
+	// keyTCP6 is the key used to store the IPv6-specific TCP port in the record.
+	keyTCP6 = "tcp6"
 	// keyUDP is the key used to store the UDP port in the record.
 	keyUDP = "udp"
 )
@@ -103,10 +107,23 @@ func Parse(enrStr string) (Record, error) {
 // Option is a function that sets a key-value pair in the record.
 type Option func(elements map[string][]byte)
 
-// WithIP returns an option that sets the IP address of the record.
+// WithIP returns an option that sets the IP v4 or IP v6 address of the record.
+// Apply it twice to set both addresses of a dual-stack node.
 func WithIP(ip net.IP) Option {
 	return func(kvs map[string][]byte) {
-		kvs[keyIP] = ip.To4()
+		if ip4 := ip.To4(); ip4 != nil {
+			kvs[keyIP] = ip4
+		} else if ip6 := ip.To16(); ip6 != nil {
+			kvs[keyIP6] = ip6
+		}
+	}
+}
+
+// WithTCP6 returns an option that sets the IPv6-specific TCP port of the record.
+// It is only required if it differs from the TCP port.
+func WithTCP6(port int) Option {
+	return func(kvs map[string][]byte) {
+		kvs[keyTCP6] = toBigEndian(port)
 	}
 }
 
@@ -163,6 +180,21 @@ func (r Record) IP() (net.IP, bool) {
 	return ip, ok
 }
 
+// IP6 returns the IP v6 address of the record or false if not present.
+func (r Record) IP6() (net.IP, bool) {
+	ip, ok := r.kvs[keyIP6]
+	return ip, ok
+}
+
+// TCP6 returns the IPv6-specific TCP port of the record, defaulting to the TCP port, or false if neither is present.
+func (r Record) TCP6() (int, bool) {
+	if b, ok := r.kvs[keyTCP6]; ok {
+		return fromBigEndian(b), true
+	}
+
+	return r.TCP()
+}
+
 // TCP returns the TCP port of the record or false if not present.
 func (r Record) TCP() (int, bool) {
 	b, ok := r.kvs[keyTCP]
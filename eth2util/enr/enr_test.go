@@ -95,6 +95,54 @@ func TestIPTCP(t *testing.T) {
 	require.Equal(t, expectUDP, udp)
 }
 
+func TestIP6TCP6(t *testing.T) {
+	privkey, err := k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	expectIP6 := net.ParseIP("2001:db8::1")
+
+	// IPv6-only record, TCP6 defaults to TCP.
+	r1, err := enr.New(privkey, enr.WithIP(expectIP6), enr.WithTCP(8000))
+	require.NoError(t, err)
+
+	r2, err := enr.Parse(r1.String())
+	require.NoError(t, err)
+
+	_, ok := r2.IP()
+	require.False(t, ok)
+
+	ip6, ok := r2.IP6()
+	require.True(t, ok)
+	require.Equal(t, expectIP6, ip6)
+
+	tcp6, ok := r2.TCP6()
+	require.True(t, ok)
+	require.Equal(t, 8000, tcp6)
+
+	// Dual-stack record.
+	r1, err = enr.New(privkey, enr.WithIP(net.IPv4(1, 2, 3, 4)), enr.WithTCP(8000), enr.WithIP(expectIP6), enr.WithTCP6(8001))
+	require.NoError(t, err)
+
+	r2, err = enr.Parse(r1.String())
+	require.NoError(t, err)
+
+	ip, ok := r2.IP()
+	require.True(t, ok)
+	require.Equal(t, net.IPv4(1, 2, 3, 4).To4(), ip)
+
+	tcp, ok := r2.TCP()
+	require.True(t, ok)
+	require.Equal(t, 8000, tcp)
+
+	ip6, ok = r2.IP6()
+	require.True(t, ok)
+	require.Equal(t, expectIP6, ip6)
+
+	tcp6, ok = r2.TCP6()
+	require.True(t, ok)
+	require.Equal(t, 8001, tcp6)
+}
+
 func TestNew(t *testing.T) {
 	privkey := testutil.GenerateInsecureK1Key(t, 0)
 
@@ -0,0 +1,95 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package registration
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Gas limit ramp configuration keys.
+const (
+	rampTarget     = "target"
+	rampStartEpoch = "start_epoch"
+	rampEpochs     = "epochs"
+)
+
+// GasLimitRamp progressively moves the target gas limit from From to To, starting at StartEpoch
+// and reaching To after Epochs epochs. A ramp with From equal to To is a static target gas limit.
+type GasLimitRamp struct {
+	From       uint64
+	To         uint64
+	StartEpoch uint64
+	Epochs     uint64
+}
+
+// GasLimit returns the target gas limit at the epoch.
+func (r GasLimitRamp) GasLimit(epoch uint64) uint64 {
+	switch {
+	case epoch < r.StartEpoch:
+		return r.From
+	case epoch-r.StartEpoch >= r.Epochs:
+		return r.To
+	}
+
+	elapsed := epoch - r.StartEpoch
+	if r.To > r.From {
+		return r.From + (r.To-r.From)*elapsed/r.Epochs
+	}
+
+	return r.From - (r.From-r.To)*elapsed/r.Epochs
+}
+
+// ParseGasLimitRamp returns a gas limit ramp from the current target gas limit to the target configured by
+// key=value pairs, e.g. ["target=60000000", "start_epoch=350000", "epochs=225"]. Epochs defaults to zero,
+// which switches to the target at the start epoch. A static ramp of the current target gas limit is returned
+// if no pairs are provided.
+func ParseGasLimitRamp(current uint64, pairs []string) (GasLimitRamp, error) {
+	resp := GasLimitRamp{From: current, To: current}
+	if len(pairs) == 0 {
+		return resp, nil
+	}
+
+	vals := make(map[string]uint64)
+
+	for _, pair := range pairs {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return GasLimitRamp{}, errors.New("invalid gas limit ramp, expect key=value", z.Str("pair", pair))
+		}
+
+		if key != rampTarget && key != rampStartEpoch && key != rampEpochs {
+			return GasLimitRamp{}, errors.New("unknown gas limit ramp key, expect target, start_epoch or epochs", z.Str("pair", pair))
+		}
+
+		if _, ok := vals[key]; ok {
+			return GasLimitRamp{}, errors.New("duplicate gas limit ramp key", z.Str("key", key))
+		}
+
+		n, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return GasLimitRamp{}, errors.New("invalid gas limit ramp value, expect non-negative integer", z.Str("pair", pair))
+		}
+
+		vals[key] = n
+	}
+
+	target, ok := vals[rampTarget]
+	if !ok || target == 0 {
+		return GasLimitRamp{}, errors.New("gas limit ramp requires a non-zero target")
+	}
+
+	startEpoch, ok := vals[rampStartEpoch]
+	if !ok {
+		return GasLimitRamp{}, errors.New("gas limit ramp requires a start_epoch")
+	}
+
+	resp.To = target
+	resp.StartEpoch = startEpoch
+	resp.Epochs = vals[rampEpochs]
+
+	return resp, nil
+}
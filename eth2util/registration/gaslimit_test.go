@@ -0,0 +1,56 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package registration_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/registration"
+)
+
+func TestGasLimitRamp(t *testing.T) {
+	up := registration.GasLimitRamp{From: 30_000_000, To: 60_000_000, StartEpoch: 100, Epochs: 10}
+	require.EqualValues(t, 30_000_000, up.GasLimit(0))
+	require.EqualValues(t, 30_000_000, up.GasLimit(100))
+	require.EqualValues(t, 33_000_000, up.GasLimit(101))
+	require.EqualValues(t, 45_000_000, up.GasLimit(105))
+	require.EqualValues(t, 60_000_000, up.GasLimit(110))
+	require.EqualValues(t, 60_000_000, up.GasLimit(1000))
+
+	down := registration.GasLimitRamp{From: 60_000_000, To: 30_000_000, StartEpoch: 100, Epochs: 10}
+	require.EqualValues(t, 60_000_000, down.GasLimit(99))
+	require.EqualValues(t, 45_000_000, down.GasLimit(105))
+	require.EqualValues(t, 30_000_000, down.GasLimit(110))
+
+	immediate := registration.GasLimitRamp{From: 30_000_000, To: 60_000_000, StartEpoch: 100}
+	require.EqualValues(t, 30_000_000, immediate.GasLimit(99))
+	require.EqualValues(t, 60_000_000, immediate.GasLimit(100))
+}
+
+func TestParseGasLimitRamp(t *testing.T) {
+	ramp, err := registration.ParseGasLimitRamp(30_000_000, nil)
+	require.NoError(t, err)
+	require.Equal(t, registration.GasLimitRamp{From: 30_000_000, To: 30_000_000}, ramp)
+
+	ramp, err = registration.ParseGasLimitRamp(30_000_000, []string{"target=60000000", "start_epoch=350000", "epochs=225"})
+	require.NoError(t, err)
+	require.Equal(t, registration.GasLimitRamp{From: 30_000_000, To: 60_000_000, StartEpoch: 350000, Epochs: 225}, ramp)
+
+	ramp, err = registration.ParseGasLimitRamp(30_000_000, []string{"target=60000000", "start_epoch=350000"})
+	require.NoError(t, err)
+	require.Equal(t, registration.GasLimitRamp{From: 30_000_000, To: 60_000_000, StartEpoch: 350000}, ramp)
+
+	for _, invalid := range [][]string{
+		{"target"},
+		{"target=60000000", "start_epoch=1", "foo=1"},
+		{"target=60000000", "target=60000000", "start_epoch=1"},
+		{"target=-1", "start_epoch=1"},
+		{"target=0", "start_epoch=1"},
+		{"target=60000000"},
+	} {
+		_, err := registration.ParseGasLimitRamp(30_000_000, invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -78,6 +78,16 @@ func (v DataVersion) ToUint64() uint64 {
 	return uint64(dataVersionValues[v])
 }
 
+// SupportedDataVersions returns all data versions (forks) supported by this binary in fork order.
+func SupportedDataVersions() []DataVersion {
+	resp := make([]DataVersion, len(dataVersionValues))
+	for version, val := range dataVersionValues {
+		resp[val] = version
+	}
+
+	return resp
+}
+
 // ToETH2 returns a eth2spec.DataVersion equivalent to the DataVersion.
 func (v DataVersion) ToETH2() eth2spec.DataVersion {
 	switch v {
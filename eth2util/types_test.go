@@ -147,3 +147,14 @@ func TestDataVersionFromETH2(t *testing.T) {
 		)
 	}
 }
+
+func TestSupportedDataVersions(t *testing.T) {
+	require.Equal(t, []eth2util.DataVersion{
+		eth2util.DataVersionPhase0,
+		eth2util.DataVersionAltair,
+		eth2util.DataVersionBellatrix,
+		eth2util.DataVersionCapella,
+		eth2util.DataVersionDeneb,
+		eth2util.DataVersionElectra,
+	}, eth2util.SupportedDataVersions())
+}
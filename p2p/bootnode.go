@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"sort"
@@ -177,13 +178,13 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 		}
 
 		if strings.HasPrefix(string(b), "enr:") {
-			addr, err := multiAddrFromENRStr(string(b))
+			addrs, err := multiAddrsFromENRStr(string(b))
 			if err != nil {
 				log.Warn(ctx, "Failure parsing relay address from ENR (will try again)", err)
 				continue
 			}
 
-			return []ma.Multiaddr{addr}, nil
+			return addrs, nil
 		}
 
 		var addrs []string
@@ -209,32 +210,57 @@ func queryRelayAddrs(ctx context.Context, relayURL string, backoff func(), lockH
 	return nil, errors.Wrap(ctx.Err(), "timeout querying relay addresses")
 }
 
-// multiAddrFromENRStr returns the multiaddr from the ENR string.
-func multiAddrFromENRStr(enrStr string) (ma.Multiaddr, error) {
+// multiAddrsFromENRStr returns the IP v4 and/or IP v6 multiaddrs from the ENR string.
+func multiAddrsFromENRStr(enrStr string) ([]ma.Multiaddr, error) {
 	r, err := enr.Parse(enrStr)
 	if err != nil {
 		return nil, errors.Wrap(err, "parse ENR")
 	}
 
-	ip, ok := r.IP()
-	if !ok {
-		return nil, errors.New("enr does not have an IP")
-	}
-
-	port, ok := r.TCP()
-	if !ok {
-		return nil, errors.New("enr does not have a TCP port")
-	}
-
 	id, err := PeerIDFromKey(r.PubKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "get peer ID from ENR key")
 	}
 
-	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", ip.String(), port, id))
+	p2pAddr, err := ma.NewMultiaddr("/p2p/" + id.String())
 	if err != nil {
-		return nil, errors.Wrap(err, "create multiaddr")
+		return nil, errors.Wrap(err, "create p2p multiaddr")
+	}
+
+	var resp []ma.Multiaddr
+
+	add := func(ip net.IP, hasIP bool, port int, hasPort bool) error {
+		if !hasIP {
+			return nil
+		} else if !hasPort {
+			return errors.New("enr does not have a TCP port")
+		}
+
+		addr, err := multiAddrFromIPPort(ip, port)
+		if err != nil {
+			return errors.Wrap(err, "create multiaddr")
+		}
+
+		resp = append(resp, addr.Encapsulate(p2pAddr))
+
+		return nil
+	}
+
+	ip, hasIP := r.IP()
+	port, hasPort := r.TCP()
+	if err := add(ip, hasIP, port, hasPort); err != nil {
+		return nil, err
+	}
+
+	ip6, hasIP6 := r.IP6()
+	port6, hasPort6 := r.TCP6()
+	if err := add(ip6, hasIP6, port6, hasPort6); err != nil {
+		return nil, err
+	}
+
+	if len(resp) == 0 {
+		return nil, errors.New("enr does not have an IP")
 	}
 
-	return addr, nil
+	return resp, nil
 }
@@ -0,0 +1,81 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestMultiAddrsFromENRStr(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+
+	id, err := PeerIDFromKey(key.PubKey())
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name   string
+		Opts   []enr.Option
+		Expect []string
+		Err    string
+	}{
+		{
+			Name:   "ipv4",
+			Opts:   []enr.Option{enr.WithIP(net.IPv4(1, 2, 3, 4)), enr.WithTCP(3640)},
+			Expect: []string{"/ip4/1.2.3.4/tcp/3640/p2p/" + id.String()},
+		},
+		{
+			Name:   "ipv6",
+			Opts:   []enr.Option{enr.WithIP(net.ParseIP("2001:db8::1")), enr.WithTCP(3640)},
+			Expect: []string{"/ip6/2001:db8::1/tcp/3640/p2p/" + id.String()},
+		},
+		{
+			Name: "dual-stack",
+			Opts: []enr.Option{
+				enr.WithIP(net.IPv4(1, 2, 3, 4)), enr.WithTCP(3640),
+				enr.WithIP(net.ParseIP("2001:db8::1")), enr.WithTCP6(3641),
+			},
+			Expect: []string{
+				"/ip4/1.2.3.4/tcp/3640/p2p/" + id.String(),
+				"/ip6/2001:db8::1/tcp/3641/p2p/" + id.String(),
+			},
+		},
+		{
+			Name: "no ip",
+			Opts: []enr.Option{enr.WithTCP(3640)},
+			Err:  "enr does not have an IP",
+		},
+		{
+			Name: "no tcp",
+			Opts: []enr.Option{enr.WithIP(net.IPv4(1, 2, 3, 4))},
+			Err:  "enr does not have a TCP port",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			r, err := enr.New(key, test.Opts...)
+			require.NoError(t, err)
+
+			addrs, err := multiAddrsFromENRStr(r.String())
+			if test.Err != "" {
+				require.ErrorContains(t, err, test.Err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var addrStrs []string
+			for _, addr := range addrs {
+				addrStrs = append(addrStrs, addr.String())
+			}
+
+			require.Equal(t, test.Expect, addrStrs)
+		})
+	}
+}
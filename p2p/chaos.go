@@ -0,0 +1,283 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Chaos faults injected into p2p messages.
+const (
+	chaosFaultLatency = "latency"
+	chaosFaultDrop    = "drop"
+)
+
+// errChaosDropped is returned by SendReceive when chaos testing dropped the request.
+var errChaosDropped = errors.New("message dropped by chaos testing")
+
+// activeChaos is the chaos testing hooks applied to all sent p2p messages, nil if disabled.
+var activeChaos atomic.Pointer[Chaos]
+
+// SetChaosUnsafe enables the chaos testing hooks for all p2p messages sent by this node.
+// It should only be used in test and staging environments.
+func SetChaosUnsafe(chaos *Chaos) {
+	activeChaos.Store(chaos)
+}
+
+// ChaosRule defines the faults injected into p2p messages sent to a peer.
+type ChaosRule struct {
+	// Latency delays all messages.
+	Latency time.Duration
+	// Jitter delays each message by an additional random duration up to Jitter, reordering concurrent messages.
+	Jitter time.Duration
+	// DropRate is the probability (0 to 1) of dropping a message.
+	DropRate float64
+}
+
+// chaosRuleJSON is the json formatted chaos rule of a peer.
+type chaosRuleJSON struct {
+	Peer     string  `json:"peer"`
+	Latency  string  `json:"latency,omitempty"`
+	Jitter   string  `json:"jitter,omitempty"`
+	DropRate float64 `json:"drop_rate,omitempty"`
+}
+
+// NewChaos returns new chaos testing hooks for the cluster peers without any rules.
+func NewChaos(peers []peer.ID) *Chaos {
+	return &Chaos{
+		peers:  peers,
+		rules:  make(map[peer.ID]ChaosRule),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // Chaos testing doesn't require secure randomness.
+	}
+}
+
+// Chaos injects latency, drops and reordering into p2p messages sent to specific peers.
+// Rules are controlled at runtime via its http handler.
+type Chaos struct {
+	peers []peer.ID
+
+	mu     sync.Mutex
+	rules  map[peer.ID]ChaosRule
+	random *rand.Rand
+}
+
+// SetRule sets the rule for messages sent to the peer.
+func (c *Chaos) SetRule(peerID peer.ID, rule ChaosRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules[peerID] = rule
+}
+
+// DeleteRule deletes the rule for messages sent to the peer.
+func (c *Chaos) DeleteRule(peerID peer.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.rules, peerID)
+}
+
+// Rules returns a copy of the current rules.
+func (c *Chaos) Rules() map[peer.ID]ChaosRule {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make(map[peer.ID]ChaosRule)
+	for peerID, rule := range c.rules {
+		resp[peerID] = rule
+	}
+
+	return resp
+}
+
+// decide returns the delay to apply and whether to drop a message sent to the peer.
+func (c *Chaos) decide(peerID peer.ID) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rule, ok := c.rules[peerID]
+	if !ok {
+		return 0, false
+	}
+
+	if rule.DropRate > 0 && c.random.Float64() < rule.DropRate {
+		return 0, true
+	}
+
+	delay := rule.Latency
+	if rule.Jitter > 0 {
+		delay += time.Duration(c.random.Int63n(int64(rule.Jitter)))
+	}
+
+	return delay, false
+}
+
+// apply applies the rule for the peer by blocking for the configured latency.
+// It returns true if the message should be dropped.
+func (c *Chaos) apply(ctx context.Context, peerID peer.ID) (bool, error) {
+	delay, drop := c.decide(peerID)
+	if drop {
+		chaosFaultsCounter.WithLabelValues(PeerName(peerID), chaosFaultDrop).Inc()
+		return true, nil
+	}
+
+	if delay <= 0 {
+		return false, nil
+	}
+
+	chaosFaultsCounter.WithLabelValues(PeerName(peerID), chaosFaultLatency).Inc()
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(delay):
+		return false, nil
+	}
+}
+
+// applyChaos applies the active chaos testing hooks, if enabled, to a message sent to the peer.
+// It returns true if the message should be dropped.
+func applyChaos(ctx context.Context, peerID peer.ID) (bool, error) {
+	chaos := activeChaos.Load()
+	if chaos == nil {
+		return false, nil
+	}
+
+	return chaos.apply(ctx, peerID)
+}
+
+// resolvePeer returns the cluster peer identified by either its peer ID or peer name.
+func (c *Chaos) resolvePeer(s string) (peer.ID, bool) {
+	for _, peerID := range c.peers {
+		if peerID.String() == s || PeerName(peerID) == s {
+			return peerID, true
+		}
+	}
+
+	return "", false
+}
+
+// ServeHTTP serves the chaos testing rules. GET returns the current rules, PUT sets
+// the rule of a peer from a json body and DELETE deletes the rule of the "peer" query
+// parameter or all rules if not provided.
+func (c *Chaos) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.serveRules(w)
+	case http.MethodPut:
+		var req chaosRuleJSON
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json body", http.StatusBadRequest)
+			return
+		}
+
+		peerID, ok := c.resolvePeer(req.Peer)
+		if !ok {
+			http.Error(w, "unknown peer", http.StatusBadRequest)
+			return
+		}
+
+		rule, err := parseChaosRule(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		c.SetRule(peerID, rule)
+		log.Warn(r.Context(), "P2P chaos rule set", nil, z.Str("peer", PeerName(peerID)),
+			z.Str("latency", rule.Latency.String()), z.Str("jitter", rule.Jitter.String()), z.F64("drop_rate", rule.DropRate))
+
+		c.serveRules(w)
+	case http.MethodDelete:
+		if s := r.URL.Query().Get("peer"); s != "" {
+			peerID, ok := c.resolvePeer(s)
+			if !ok {
+				http.Error(w, "unknown peer", http.StatusBadRequest)
+				return
+			}
+
+			c.DeleteRule(peerID)
+		} else {
+			for peerID := range c.Rules() {
+				c.DeleteRule(peerID)
+			}
+		}
+
+		log.Info(r.Context(), "P2P chaos rules deleted")
+
+		c.serveRules(w)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveRules writes the current rules as json.
+func (c *Chaos) serveRules(w http.ResponseWriter) {
+	rules := c.Rules()
+
+	resp := make([]chaosRuleJSON, 0, len(rules))
+	for _, peerID := range c.peers {
+		rule, ok := rules[peerID]
+		if !ok {
+			continue
+		}
+
+		resp = append(resp, chaosRuleJSON{
+			Peer:     PeerName(peerID),
+			Latency:  rule.Latency.String(),
+			Jitter:   rule.Jitter.String(),
+			DropRate: rule.DropRate,
+		})
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, "something went wrong", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+// parseChaosRule returns the validated chaos rule from its json representation.
+func parseChaosRule(req chaosRuleJSON) (ChaosRule, error) {
+	var (
+		rule ChaosRule
+		err  error
+	)
+
+	if req.Latency != "" {
+		rule.Latency, err = time.ParseDuration(req.Latency)
+		if err != nil || rule.Latency < 0 {
+			return ChaosRule{}, errors.New("invalid latency")
+		}
+	}
+
+	if req.Jitter != "" {
+		rule.Jitter, err = time.ParseDuration(req.Jitter)
+		if err != nil || rule.Jitter < 0 {
+			return ChaosRule{}, errors.New("invalid jitter")
+		}
+	}
+
+	if req.DropRate < 0 || req.DropRate > 1 {
+		return ChaosRule{}, errors.New("invalid drop rate, must be between 0 and 1")
+	}
+
+	rule.DropRate = req.DropRate
+
+	return rule, nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestChaosHandler(t *testing.T) {
+	peer1 := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+	peer2 := testutil.CreateHost(t, testutil.AvailableAddr(t)).ID()
+
+	chaos := NewChaos([]peer.ID{peer1, peer2})
+
+	serve := func(method, target, body string) (int, []chaosRuleJSON) {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		chaos.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK {
+			return rec.Code, nil
+		}
+
+		var resp []chaosRuleJSON
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+		return rec.Code, resp
+	}
+
+	code, rules := serve(http.MethodGet, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, rules)
+
+	code, rules = serve(http.MethodPut, "/", `{"peer":"`+PeerName(peer1)+`","latency":"100ms","jitter":"50ms","drop_rate":0.5}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []chaosRuleJSON{{Peer: PeerName(peer1), Latency: "100ms", Jitter: "50ms", DropRate: 0.5}}, rules)
+	require.Equal(t, ChaosRule{Latency: 100 * time.Millisecond, Jitter: 50 * time.Millisecond, DropRate: 0.5}, chaos.Rules()[peer1])
+
+	code, rules = serve(http.MethodPut, "/", `{"peer":"`+peer2.String()+`","drop_rate":1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, rules, 2)
+
+	code, _ = serve(http.MethodPut, "/", `{"peer":"unknown","drop_rate":1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serve(http.MethodPut, "/", `{"peer":"`+peer2.String()+`","drop_rate":2}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serve(http.MethodPut, "/", `{"peer":"`+peer2.String()+`","latency":"-1s"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, rules = serve(http.MethodDelete, "/?peer="+PeerName(peer1), "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []chaosRuleJSON{{Peer: PeerName(peer2), Latency: "0s", Jitter: "0s", DropRate: 1}}, rules)
+
+	code, rules = serve(http.MethodDelete, "/", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, rules)
+
+	code, _ = serve(http.MethodPost, "/", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestChaosSend(t *testing.T) {
+	server := testutil.CreateHost(t, testutil.AvailableAddr(t))
+	client := testutil.CreateHost(t, testutil.AvailableAddr(t))
+
+	client.Peerstore().AddAddrs(server.ID(), server.Addrs(), time.Hour)
+
+	received := make(chan struct{}, 1)
+	protocolID := protocol.ID("testprotocol")
+	RegisterHandler("test", server, protocolID, func() proto.Message { return new(pbv1.Duty) },
+		func(context.Context, peer.ID, proto.Message) (proto.Message, bool, error) {
+			received <- struct{}{}
+			return new(pbv1.Duty), true, nil
+		})
+
+	chaos := NewChaos([]peer.ID{server.ID()})
+	SetChaosUnsafe(chaos)
+	t.Cleanup(func() { SetChaosUnsafe(nil) })
+
+	ctx := context.Background()
+
+	// Dropped messages are not sent.
+	chaos.SetRule(server.ID(), ChaosRule{DropRate: 1})
+	require.NoError(t, Send(ctx, client, protocolID, server.ID(), new(pbv1.Duty)))
+	require.ErrorIs(t, SendReceive(ctx, client, server.ID(), new(pbv1.Duty), new(pbv1.Duty), protocolID), errChaosDropped)
+	require.Empty(t, received)
+
+	// Delayed messages are sent after the latency.
+	const latency = 100 * time.Millisecond
+
+	chaos.SetRule(server.ID(), ChaosRule{Latency: latency})
+
+	t0 := time.Now()
+	require.NoError(t, SendReceive(ctx, client, server.ID(), new(pbv1.Duty), new(pbv1.Duty), protocolID))
+	require.GreaterOrEqual(t, time.Since(t0), latency)
+	<-received
+
+	// Latency is aborted when the context is cancelled.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, Send(cancelled, client, protocolID, server.ID(), new(pbv1.Duty)), context.Canceled)
+}
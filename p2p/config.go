@@ -22,6 +22,8 @@ type Config struct {
 	TCPAddrs []string
 	// DisableReuseport disables TCP port reuse for libp2p.
 	DisableReuseport bool
+	// PortMapping enables mapping the TCP listen ports on the local router via NAT-PMP or UPnP.
+	PortMapping bool
 }
 
 // ParseTCPAddrs returns the configured tcp addresses as typed net tcp addresses.
@@ -0,0 +1,224 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// connLowWater is the number of connections pruning reduces to.
+	connLowWater = 128
+	// connHighWater is the number of connections above which pruning starts.
+	connHighWater = 256
+
+	connTypeCluster = "cluster"
+	connTypeRelay   = "relay"
+	connTypeOther   = "other"
+)
+
+var _ connmgr.ConnManager = (*ConnManager)(nil)
+
+// NewConnManager returns a new connection manager that always protects cluster peer and relay connections.
+func NewConnManager(peers []peer.ID, relays []*MutablePeer) *ConnManager {
+	return newConnManager(peers, relays, connLowWater, connHighWater)
+}
+
+func newConnManager(peers []peer.ID, relays []*MutablePeer, low, high int) *ConnManager {
+	peerMap := make(map[peer.ID]bool)
+	for _, p := range peers {
+		peerMap[p] = true
+	}
+
+	return &ConnManager{
+		peers:     peerMap,
+		relays:    relays,
+		low:       low,
+		high:      high,
+		protected: make(map[peer.ID]map[string]bool),
+	}
+}
+
+// ConnManager is a libp2p connection manager that prunes connections when the number of connections exceeds
+// the high watermark, preventing file descriptor exhaustion from severing cluster quorum.
+// Cluster peers, relays and explicitly protected peers are never disconnected, all other connections are pruned first,
+// followed by redundant connections to peers with multiple connections, until the low watermark is reached.
+type ConnManager struct {
+	connmgr.NullConnMgr
+
+	peers    map[peer.ID]bool
+	relays   []*MutablePeer
+	low      int
+	high     int
+	trimming atomic.Bool
+
+	mu        sync.Mutex
+	protected map[peer.ID]map[string]bool
+}
+
+// Notifee returns a notifee that instruments connection counts and prunes connections above the high watermark.
+func (m *ConnManager) Notifee() network.Notifiee {
+	return &network.NotifyBundle{
+		ConnectedF: func(n network.Network, _ network.Conn) {
+			m.instrument(n)
+
+			if len(n.Conns()) > m.high && m.trimming.CompareAndSwap(false, true) {
+				go func() {
+					defer m.trimming.Store(false)
+					m.trim(n)
+				}()
+			}
+		},
+		DisconnectedF: func(n network.Network, _ network.Conn) {
+			m.instrument(n)
+		},
+	}
+}
+
+// TrimOpenConns prunes connections above the low watermark. The network is only known
+// via notifications, so this is a no-op, pruning is triggered by new connections instead.
+func (*ConnManager) TrimOpenConns(context.Context) {}
+
+// Protect protects the peer's connections from pruning with the tag.
+func (m *ConnManager) Protect(id peer.ID, tag string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.protected[id] == nil {
+		m.protected[id] = make(map[string]bool)
+	}
+
+	m.protected[id][tag] = true
+}
+
+// Unprotect removes the tag's protection of the peer's connections, returning true if the peer is still protected.
+func (m *ConnManager) Unprotect(id peer.ID, tag string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.protected[id], tag)
+
+	if len(m.protected[id]) == 0 {
+		delete(m.protected, id)
+		return false
+	}
+
+	return true
+}
+
+// IsProtected returns true if the peer is protected by the tag, or by any tag if the tag is empty.
+func (m *ConnManager) IsProtected(id peer.ID, tag string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tag == "" {
+		return len(m.protected[id]) > 0
+	}
+
+	return m.protected[id][tag]
+}
+
+// connType returns the connection type of the peer; cluster, relay or other.
+func (m *ConnManager) connType(id peer.ID) string {
+	if m.peers[id] {
+		return connTypeCluster
+	}
+
+	for _, relay := range m.relays {
+		if p, ok := relay.Peer(); ok && p.ID == id {
+			return connTypeRelay
+		}
+	}
+
+	return connTypeOther
+}
+
+// instrument sets the connection count gauges.
+func (m *ConnManager) instrument(n network.Network) {
+	counts := map[string]int{
+		connTypeCluster: 0,
+		connTypeRelay:   0,
+		connTypeOther:   0,
+	}
+
+	for _, conn := range n.Conns() {
+		counts[m.connType(conn.RemotePeer())]++
+	}
+
+	for typ, count := range counts {
+		connCountGauge.WithLabelValues(typ).Set(float64(count))
+	}
+}
+
+// trim closes connections until the low watermark is reached. Unprotected other connections are closed first,
+// followed by redundant connections to peers with multiple connections, both oldest first. Since the connection
+// gater only allows cluster peers and relays, redundant connections are usually the only candidates.
+func (m *ConnManager) trim(n network.Network) {
+	ctx := log.WithTopic(context.Background(), "p2p")
+	conns := n.Conns()
+
+	var (
+		others    []network.Conn
+		redundant []network.Conn
+		byPeer    = make(map[peer.ID][]network.Conn)
+	)
+
+	for _, conn := range conns {
+		if m.connType(conn.RemotePeer()) == connTypeOther && !m.IsProtected(conn.RemotePeer(), "") {
+			others = append(others, conn)
+			continue
+		}
+
+		byPeer[conn.RemotePeer()] = append(byPeer[conn.RemotePeer()], conn)
+	}
+
+	for _, peerConns := range byPeer {
+		// Keep the best connection to each peer; unlimited (direct) before limited (relayed), newest first.
+		sort.Slice(peerConns, func(i, j int) bool {
+			if peerConns[i].Stat().Limited != peerConns[j].Stat().Limited {
+				return !peerConns[i].Stat().Limited
+			}
+
+			return peerConns[i].Stat().Opened.After(peerConns[j].Stat().Opened)
+		})
+
+		redundant = append(redundant, peerConns[1:]...)
+	}
+
+	oldestFirst := func(conns []network.Conn) {
+		sort.Slice(conns, func(i, j int) bool {
+			return conns[i].Stat().Opened.Before(conns[j].Stat().Opened)
+		})
+	}
+
+	oldestFirst(others)
+	oldestFirst(redundant)
+
+	candidates := append(others, redundant...)
+
+	prune := min(len(conns)-m.low, len(candidates))
+	if prune <= 0 {
+		log.Warn(ctx, "Too many connections, but all are protected", nil,
+			z.Int("conns", len(conns)), z.Int("high_water", m.high))
+
+		return
+	}
+
+	for _, conn := range candidates[:prune] {
+		_ = conn.Close()
+	}
+
+	connPruneCounter.Add(float64(prune))
+	log.Info(ctx, "Pruned connections above high watermark",
+		z.Int("pruned", prune), z.Int("conns", len(conns)), z.Int("high_water", m.high))
+}
@@ -0,0 +1,81 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestConnManager(t *testing.T) {
+	newHost := func(opts ...libp2p.Option) host.Host {
+		key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+
+		h, err := libp2p.New(append([]libp2p.Option{
+			libp2p.Identity(key),
+			libp2p.ListenAddrs(testutil.AvailableMultiAddr(t)),
+		}, opts...)...)
+		testutil.SkipIfBindErr(t, err)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = h.Close() })
+
+		return h
+	}
+
+	clusterPeer := newHost()
+	relay := newHost()
+	other1 := newHost()
+	other2 := newHost()
+
+	relayPeer := NewMutablePeer(Peer{ID: relay.ID()})
+
+	cm := newConnManager([]peer.ID{clusterPeer.ID()}, []*MutablePeer{relayPeer}, 2, 3)
+	rm, err := NewResourceManager([]peer.ID{clusterPeer.ID()})
+	require.NoError(t, err)
+
+	node := newHost(libp2p.ConnectionManager(cm), libp2p.ResourceManager(rm))
+
+	require.Equal(t, connTypeCluster, cm.connType(clusterPeer.ID()))
+	require.Equal(t, connTypeRelay, cm.connType(relay.ID()))
+	require.Equal(t, connTypeOther, cm.connType(other1.ID()))
+
+	for _, h := range []host.Host{clusterPeer, relay, other1, other2} {
+		err := h.Connect(t.Context(), peer.AddrInfo{ID: node.ID(), Addrs: node.Addrs()})
+		require.NoError(t, err)
+	}
+
+	// Other connections are pruned down to the low watermark, cluster peer and relay connections are protected.
+	require.Eventually(t, func() bool {
+		return node.Network().Connectedness(other1.ID()) != network.Connected &&
+			node.Network().Connectedness(other2.ID()) != network.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, network.Connected, node.Network().Connectedness(clusterPeer.ID()))
+	require.Equal(t, network.Connected, node.Network().Connectedness(relay.ID()))
+}
+
+func TestConnManagerProtect(t *testing.T) {
+	cm := NewConnManager(nil, nil)
+
+	cm.Protect("peer", "a")
+	cm.Protect("peer", "b")
+	require.True(t, cm.IsProtected("peer", ""))
+	require.True(t, cm.IsProtected("peer", "a"))
+	require.False(t, cm.IsProtected("peer", "c"))
+
+	require.True(t, cm.Unprotect("peer", "a"))
+	require.False(t, cm.Unprotect("peer", "b"))
+	require.False(t, cm.IsProtected("peer", ""))
+}
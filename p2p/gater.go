@@ -3,6 +3,8 @@
 package p2p
 
 import (
+	"strings"
+
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/control"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -33,7 +35,7 @@ func NewOpenGater() ConnGater {
 	}
 }
 
-// ConnGater filters incoming connections by the cluster peers.
+// ConnGater filters connections by the cluster peer identities pinned in the lock, including relayed connections.
 type ConnGater struct {
 	peerIDs map[peer.ID]bool
 	relays  []*MutablePeer
@@ -54,7 +56,41 @@ func (ConnGater) InterceptAccept(_ network.ConnMultiaddrs) (allow bool) {
 }
 
 // InterceptSecured rejects nodes with a peer ID that isn't part of any known DV.
-func (c ConnGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+// Note that relayed connections are also secured end-to-end, so this rejects relayed unknown peers as well.
+func (c ConnGater) InterceptSecured(dir network.Direction, id peer.ID, addrs network.ConnMultiaddrs) bool {
+	if c.allowed(id) {
+		return true
+	}
+
+	incRejected(dir, addrs)
+
+	return false
+}
+
+// InterceptUpgraded rejects connections of which the authenticated remote public key doesn't match the pinned peer ID.
+// This guards against a relay or transport reporting a different identity than the one verified in the handshake.
+func (c ConnGater) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	if c.open {
+		return true, 0
+	}
+
+	pubkey := conn.RemotePublicKey()
+	if pubkey == nil {
+		incRejected(conn.Stat().Direction, conn)
+		return false, 0
+	}
+
+	id, err := peer.IDFromPublicKey(pubkey)
+	if err != nil || id != conn.RemotePeer() || !c.allowed(id) {
+		incRejected(conn.Stat().Direction, conn)
+		return false, 0
+	}
+
+	return true, 0
+}
+
+// allowed returns true if the peer ID is a cluster peer or relay or if the gater is open.
+func (c ConnGater) allowed(id peer.ID) bool {
 	if c.open {
 		return true
 	}
@@ -73,7 +109,12 @@ func (c ConnGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.C
 	return false
 }
 
-// InterceptUpgraded does nothing.
-func (ConnGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
-	return true, 0
+// incRejected increments the rejected connections counter.
+func incRejected(dir network.Direction, addrs network.ConnMultiaddrs) {
+	typ := addrTypeDirect
+	if addrs != nil && addrs.RemoteMultiaddr() != nil {
+		typ = addrType(addrs.RemoteMultiaddr())
+	}
+
+	gaterRejectedCounter.WithLabelValues(strings.ToLower(dir.String()), typ).Inc()
 }
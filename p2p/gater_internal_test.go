@@ -0,0 +1,118 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptUpgraded(t *testing.T) {
+	newKey := func(t *testing.T) (crypto.PubKey, peer.ID) {
+		t.Helper()
+
+		_, pubkey, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+
+		id, err := peer.IDFromPublicKey(pubkey)
+		require.NoError(t, err)
+
+		return pubkey, id
+	}
+
+	clusterKey, clusterID := newKey(t)
+	unknownKey, unknownID := newKey(t)
+
+	gater, err := NewConnGater([]peer.ID{clusterID}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		pubkey    crypto.PubKey
+		id        peer.ID
+		direction network.Direction
+		allowed   bool
+	}{
+		{
+			name:      "cluster peer",
+			pubkey:    clusterKey,
+			id:        clusterID,
+			direction: network.DirInbound,
+			allowed:   true,
+		},
+		{
+			name:      "unknown peer",
+			pubkey:    unknownKey,
+			id:        unknownID,
+			direction: network.DirInbound,
+		},
+		{
+			name:      "public key mismatches cluster peer id",
+			pubkey:    unknownKey,
+			id:        clusterID,
+			direction: network.DirOutbound,
+		},
+		{
+			name:      "missing public key",
+			id:        clusterID,
+			direction: network.DirOutbound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counter := gaterRejectedCounter.WithLabelValues(strings.ToLower(test.direction.String()), addrTypeDirect)
+			before := promtestutil.ToFloat64(counter)
+
+			allowed, _ := gater.InterceptUpgraded(testConn{
+				pubkey:    test.pubkey,
+				id:        test.id,
+				direction: test.direction,
+			})
+			require.Equal(t, test.allowed, allowed)
+
+			var expected float64
+			if !test.allowed {
+				expected = 1
+			}
+
+			require.InDelta(t, expected, promtestutil.ToFloat64(counter)-before, 0)
+		})
+	}
+
+	allowed, _ := NewOpenGater().InterceptUpgraded(testConn{pubkey: unknownKey, id: clusterID})
+	require.True(t, allowed)
+}
+
+// testConn is a network.Conn stub returning the remote identity and direction.
+type testConn struct {
+	network.Conn
+
+	pubkey    crypto.PubKey
+	id        peer.ID
+	direction network.Direction
+}
+
+func (c testConn) RemotePublicKey() crypto.PubKey {
+	return c.pubkey
+}
+
+func (c testConn) RemotePeer() peer.ID {
+	return c.id
+}
+
+func (c testConn) RemoteMultiaddr() multiaddr.Multiaddr {
+	return multiaddr.StringCast("/ip4/127.0.0.1/tcp/3610")
+}
+
+func (c testConn) Stat() network.ConnStats {
+	return network.ConnStats{Stats: network.Stats{Direction: c.direction}}
+}
@@ -75,3 +75,33 @@ func TestOpenGater(t *testing.T) {
 	gater := p2p.NewOpenGater()
 	require.True(t, gater.InterceptSecured(0, "", nil))
 }
+
+func TestP2PConnGatingAllowed(t *testing.T) {
+	keyA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	keyB, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	idB, err := peer.IDFromPrivateKey(keyB)
+	require.NoError(t, err)
+
+	c, err := p2p.NewConnGater([]peer.ID{idB}, nil)
+	require.NoError(t, err)
+
+	nodeA, err := libp2p.New(
+		libp2p.Identity(keyA),
+		libp2p.ConnectionGater(c),
+		libp2p.ListenAddrs(testutil.AvailableMultiAddr(t)))
+	testutil.SkipIfBindErr(t, err)
+	require.NoError(t, err)
+
+	nodeB, err := libp2p.New(
+		libp2p.Identity(keyB),
+		libp2p.ListenAddrs(testutil.AvailableMultiAddr(t)),
+	)
+	testutil.SkipIfBindErr(t, err)
+	require.NoError(t, err)
+
+	err = nodeA.Connect(context.Background(), peer.AddrInfo{ID: nodeB.ID(), Addrs: nodeB.Addrs()})
+	require.NoError(t, err)
+}
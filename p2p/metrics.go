@@ -79,6 +79,36 @@ var (
 		Name:      "peer_network_sent_bytes_total",
 		Help:      "Total number of network bytes sent to the peer by protocol.",
 	}, []string{"peer", "protocol"})
+
+	gaterRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "gater_rejected_connections_total",
+		Help:      "Total number of connections rejected by the connection gater since the remote peer ID is not pinned in the cluster, by direction ('inbound', 'outbound' or 'unknown') and type ('direct' or 'relay').",
+	}, []string{"direction", "type"})
+
+	connCountGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "p2p",
+		Name:      "connections",
+		Help:      "Current number of libp2p connections by type ('cluster', 'relay' or 'other').",
+	}, []string{"type"})
+
+	connPruneCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "connection_prunes_total",
+		Help:      "Total number of libp2p connections pruned by the connection manager since the number of connections exceeded the high watermark. Cluster peers and relays are never disconnected, only their redundant connections are pruned.",
+	})
+
+	portMappingGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "p2p",
+		Name:      "port_mapping_status",
+		Help:      "Whether the TCP listen port is mapped on the local router via NAT-PMP or UPnP (1) or not (0).",
+	}, []string{"port"})
+
+	chaosFaultsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "p2p",
+		Name:      "chaos_faults_total",
+		Help:      "Total number of chaos testing faults injected into messages sent to the peer by fault type (latency, drop).",
+	}, []string{"peer", "fault"})
 )
 
 func observePing(p peer.ID, d time.Duration) {
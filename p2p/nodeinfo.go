@@ -0,0 +1,162 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// NodeInfo is a snapshot of the node's identity and peer discovery state.
+type NodeInfo struct {
+	ENR                    string             `json:"enr"`
+	PeerID                 string             `json:"peer_id"`
+	PeerName               string             `json:"peer_name"`
+	ListenAddrs            []string           `json:"listen_addrs"`
+	AdvertisedAddrs        []string           `json:"advertised_addrs"`
+	ExternalAddrCandidates []string           `json:"external_addr_candidates"`
+	RelayReservations      []RelayReservation `json:"relay_reservations"`
+	PortMappings           []string           `json:"port_mappings"`
+	BLSBackend             string             `json:"bls_backend"`
+}
+
+// RelayReservation is an active relay circuit reservation of the node.
+type RelayReservation struct {
+	Relay      string    `json:"relay"`
+	Addrs      []string  `json:"addrs"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// reservations contains the active relay reservations by relay peer ID by host peer ID.
+var (
+	reservationsMu sync.Mutex
+	reservations   = make(map[peer.ID]map[peer.ID]RelayReservation)
+)
+
+// setReservation stores the active relay reservation of the host.
+func setReservation(hostID peer.ID, relay Peer, expiration time.Time) {
+	reservationsMu.Lock()
+	defer reservationsMu.Unlock()
+
+	if reservations[hostID] == nil {
+		reservations[hostID] = make(map[peer.ID]RelayReservation)
+	}
+
+	reservations[hostID][relay.ID] = RelayReservation{
+		Relay:      PeerName(relay.ID),
+		Addrs:      addrStrs(relay.Addrs),
+		Expiration: expiration,
+	}
+}
+
+// deleteReservation removes the relay reservation of the host.
+func deleteReservation(hostID peer.ID, relayID peer.ID) {
+	reservationsMu.Lock()
+	defer reservationsMu.Unlock()
+
+	delete(reservations[hostID], relayID)
+}
+
+// getReservations returns the active relay reservations of the host ordered by relay name.
+func getReservations(hostID peer.ID) []RelayReservation {
+	reservationsMu.Lock()
+	defer reservationsMu.Unlock()
+
+	resp := make([]RelayReservation, 0, len(reservations[hostID]))
+	for _, resv := range reservations[hostID] {
+		resp = append(resp, resv)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Relay < resp[j].Relay
+	})
+
+	return resp
+}
+
+// GetNodeInfo returns the current identity and peer discovery state of the node.
+// The ENR includes the external addresses of the ports mapped on the local router, if any.
+func GetNodeInfo(tcpNode host.Host, key *k1.PrivateKey) (NodeInfo, error) {
+	mappings := getPortMappings(tcpNode.ID())
+
+	r, err := enr.New(key, enrPortMappingOpts(mappings)...)
+	if err != nil {
+		return NodeInfo{}, err
+	}
+
+	// External address candidates are the node's addresses as observed by its peers (via identify).
+	var candidates []ma.Multiaddr
+	if idHost, ok := tcpNode.(interface{ IDService() identify.IDService }); ok && idHost.IDService() != nil {
+		candidates = idHost.IDService().OwnObservedAddrs()
+	}
+
+	return NodeInfo{
+		ENR:                    r.String(),
+		PeerID:                 tcpNode.ID().String(),
+		PeerName:               PeerName(tcpNode.ID()),
+		ListenAddrs:            addrStrs(tcpNode.Network().ListenAddresses()),
+		AdvertisedAddrs:        addrStrs(tcpNode.Addrs()),
+		ExternalAddrCandidates: addrStrs(candidates),
+		RelayReservations:      getReservations(tcpNode.ID()),
+		PortMappings:           addrPortStrs(mappings),
+		BLSBackend:             tbls.Backend(),
+	}, nil
+}
+
+// NewNodeInfoHandler returns a http handler serving the node's identity and peer discovery state as JSON.
+func NewNodeInfoHandler(tcpNode host.Host, key *k1.PrivateKey) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, err := GetNodeInfo(tcpNode, key)
+		if err != nil {
+			log.Warn(r.Context(), "Error getting node info", err)
+			http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+			return
+		}
+
+		b, err := json.Marshal(info)
+		if err != nil {
+			log.Warn(r.Context(), "Error serving node info", err)
+			http.Error(w, "something went wrong, see logs", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+// addrPortStrs returns the addresses as strings, never nil.
+func addrPortStrs(addrs []netip.AddrPort) []string {
+	resp := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		resp = append(resp, addr.String())
+	}
+
+	return resp
+}
+
+// addrStrs returns the multiaddrs as strings, never nil.
+func addrStrs(addrs []ma.Multiaddr) []string {
+	resp := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		resp = append(resp, addr.String())
+	}
+
+	return resp
+}
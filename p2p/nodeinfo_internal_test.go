@@ -0,0 +1,57 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestNodeInfoHandler(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+	tcpNode := testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), key)
+	relay := testutil.CreateHost(t, testutil.AvailableAddr(t))
+
+	relayPeer := Peer{ID: relay.ID(), Addrs: relay.Addrs()}
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+
+	setReservation(tcpNode.ID(), relayPeer, expiration)
+
+	rec := httptest.NewRecorder()
+	NewNodeInfoHandler(tcpNode, key).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/p2p/node", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var info NodeInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+
+	r, err := enr.New(key)
+	require.NoError(t, err)
+
+	require.Equal(t, r.String(), info.ENR)
+	require.Equal(t, tcpNode.ID().String(), info.PeerID)
+	require.Equal(t, PeerName(tcpNode.ID()), info.PeerName)
+	require.ElementsMatch(t, addrStrs(tcpNode.Network().ListenAddresses()), info.ListenAddrs)
+	require.ElementsMatch(t, addrStrs(tcpNode.Addrs()), info.AdvertisedAddrs)
+	require.NotNil(t, info.ExternalAddrCandidates)
+	require.Equal(t, tbls.Backend(), info.BLSBackend)
+	require.Equal(t, []RelayReservation{{
+		Relay:      PeerName(relay.ID()),
+		Addrs:      addrStrs(relay.Addrs()),
+		Expiration: expiration,
+	}}, info.RelayReservations)
+
+	deleteReservation(tcpNode.ID(), relay.ID())
+
+	info, err = GetNodeInfo(tcpNode, key)
+	require.NoError(t, err)
+	require.Empty(t, info.RelayReservations)
+}
@@ -56,6 +56,21 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		return nil, err
 	}
 
+	var mapper *portMapper
+	if cfg.PortMapping {
+		tcpAddrs, err := cfg.ParseTCPAddrs()
+		if err != nil {
+			return nil, err
+		}
+
+		var ports []int
+		for _, addr := range tcpAddrs {
+			ports = append(ports, addr.Port)
+		}
+
+		mapper = newPortMapper(ports)
+	}
+
 	var tcpOpts []any // libp2p.Transport requires empty interface options.
 	if cfg.DisableReuseport {
 		tcpOpts = append(tcpOpts, tcp.DisableReuseport())
@@ -74,6 +89,10 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		// Enable Autonat (required for hole punching)
 		libp2p.EnableNATService(),
 		libp2p.AddrsFactory(func(internalAddrs []ma.Multiaddr) []ma.Multiaddr {
+			if mapper != nil {
+				return filterAdvertisedAddrs(append(mapper.Multiaddrs(), externalAddrs...), internalAddrs, filterPrivateAddrs)
+			}
+
 			return filterAdvertisedAddrs(externalAddrs, internalAddrs, filterPrivateAddrs)
 		}),
 		libp2p.Transport(tcp.NewTCPTransport, tcpOpts...),
@@ -87,6 +106,11 @@ func NewTCPNode(ctx context.Context, cfg Config, key *k1.PrivateKey, connGater C
 		return nil, errors.Wrap(err, "new libp2p node")
 	}
 
+	if mapper != nil {
+		setPortMapper(tcpNode.ID(), mapper)
+		go mapper.Run(ctx)
+	}
+
 	return tcpNode, nil
 }
 
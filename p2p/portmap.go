@@ -0,0 +1,174 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/nat"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util/enr"
+)
+
+// portMappingRefresh is the interval at which the port mapping status is refreshed.
+const portMappingRefresh = time.Minute
+
+// portMappers contains the port mapper by host peer ID.
+var (
+	portMappersMu sync.Mutex
+	portMappers   = make(map[peer.ID]*portMapper)
+)
+
+// setPortMapper stores the port mapper of the host.
+func setPortMapper(hostID peer.ID, mapper *portMapper) {
+	portMappersMu.Lock()
+	defer portMappersMu.Unlock()
+
+	portMappers[hostID] = mapper
+}
+
+// getPortMappings returns the external addresses of the ports mapped for the host.
+func getPortMappings(hostID peer.ID) []netip.AddrPort {
+	portMappersMu.Lock()
+	mapper, ok := portMappers[hostID]
+	portMappersMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return mapper.Mappings()
+}
+
+// newPortMapper returns a new port mapper of the TCP listen ports.
+func newPortMapper(ports []int) *portMapper {
+	return &portMapper{ports: ports}
+}
+
+// portMapper maps the TCP listen ports on the local router via NAT-PMP or UPnP,
+// so nodes behind consumer routers are directly reachable instead of only via relays.
+type portMapper struct {
+	ports []int
+
+	mu       sync.Mutex
+	mappings []netip.AddrPort
+}
+
+// Run discovers the local NAT router and maps the listen ports, refreshing the mapping status until the context is closed.
+func (m *portMapper) Run(ctx context.Context) {
+	ctx = log.WithTopic(ctx, "p2p")
+
+	for _, port := range m.ports {
+		portMappingGauge.WithLabelValues(strconv.Itoa(port)).Set(0)
+	}
+
+	natDevice, err := nat.DiscoverNAT(ctx)
+	if err != nil {
+		log.Warn(ctx, "Port mapping failed, no NAT-PMP or UPnP router discovered", err)
+		return
+	}
+	defer natDevice.Close()
+
+	for _, port := range m.ports {
+		if err := natDevice.AddMapping(ctx, "tcp", port); err != nil {
+			log.Warn(ctx, "Port mapping failed", err, z.Int("port", port))
+		}
+	}
+
+	ticker := time.NewTicker(portMappingRefresh)
+	defer ticker.Stop()
+
+	for {
+		m.update(ctx, natDevice.GetMapping)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update refreshes the port mappings and status metrics, logging changes.
+func (m *portMapper) update(ctx context.Context, getMapping func(protocol string, port int) (netip.AddrPort, bool)) {
+	var mappings []netip.AddrPort
+	for _, port := range m.ports {
+		addr, ok := getMapping("tcp", port)
+		if !ok {
+			portMappingGauge.WithLabelValues(strconv.Itoa(port)).Set(0)
+			continue
+		}
+
+		portMappingGauge.WithLabelValues(strconv.Itoa(port)).Set(1)
+		mappings = append(mappings, addr)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.Equal(m.mappings, mappings) {
+		return
+	}
+
+	if len(mappings) == 0 {
+		log.Warn(ctx, "Port mapping lost", nil)
+	} else {
+		log.Info(ctx, "Port mapping updated", z.Any("external_addrs", mappings))
+	}
+
+	m.mappings = mappings
+}
+
+// Mappings returns the external addresses of the mapped ports.
+func (m *portMapper) Mappings() []netip.AddrPort {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.mappings)
+}
+
+// Multiaddrs returns the external addresses of the mapped ports as multiaddrs.
+func (m *portMapper) Multiaddrs() []ma.Multiaddr {
+	var resp []ma.Multiaddr
+	for _, addr := range m.Mappings() {
+		maddr, err := multiAddrFromIPPort(net.IP(addr.Addr().AsSlice()), int(addr.Port()))
+		if err != nil {
+			continue
+		}
+
+		resp = append(resp, maddr)
+	}
+
+	return resp
+}
+
+// enrPortMappingOpts returns the ENR options advertising the first mapped IPv4 and IPv6 addresses.
+func enrPortMappingOpts(mappings []netip.AddrPort) []enr.Option {
+	var (
+		opts           []enr.Option
+		hasIP4, hasIP6 bool
+	)
+
+	for _, addr := range mappings {
+		switch {
+		case addr.Addr().Unmap().Is4() && !hasIP4:
+			hasIP4 = true
+			opts = append(opts, enr.WithIP(net.IP(addr.Addr().Unmap().AsSlice())), enr.WithTCP(int(addr.Port())))
+		case addr.Addr().Is6() && !addr.Addr().Is4In6() && !hasIP6:
+			hasIP6 = true
+			opts = append(opts, enr.WithIP(net.IP(addr.Addr().AsSlice())), enr.WithTCP6(int(addr.Port())))
+		}
+	}
+
+	return opts
+}
@@ -0,0 +1,69 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/enr"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestPortMapper(t *testing.T) {
+	mapper := newPortMapper([]int{3610, 3611})
+	require.Empty(t, mapper.Mappings())
+
+	mapped := map[int]netip.AddrPort{
+		3610: netip.MustParseAddrPort("1.2.3.4:13610"),
+	}
+	getMapping := func(protocol string, port int) (netip.AddrPort, bool) {
+		require.Equal(t, "tcp", protocol)
+		addr, ok := mapped[port]
+
+		return addr, ok
+	}
+
+	mapper.update(context.Background(), getMapping)
+	require.Equal(t, []netip.AddrPort{mapped[3610]}, mapper.Mappings())
+
+	maddrs := mapper.Multiaddrs()
+	require.Len(t, maddrs, 1)
+	require.Equal(t, "/ip4/1.2.3.4/tcp/13610", maddrs[0].String())
+
+	// Mapping lost.
+	delete(mapped, 3610)
+	mapper.update(context.Background(), getMapping)
+	require.Empty(t, mapper.Mappings())
+	require.Empty(t, mapper.Multiaddrs())
+}
+
+func TestENRPortMappingOpts(t *testing.T) {
+	key := testutil.GenerateInsecureK1Key(t, 0)
+
+	r, err := enr.New(key, enrPortMappingOpts([]netip.AddrPort{
+		netip.MustParseAddrPort("1.2.3.4:13610"),
+		netip.MustParseAddrPort("5.6.7.8:13611"), // Ignored, only the first IPv4 mapping is advertised.
+		netip.MustParseAddrPort("[2001:db8::1]:13612"),
+	})...)
+	require.NoError(t, err)
+
+	ip, ok := r.IP()
+	require.True(t, ok)
+	require.Equal(t, "1.2.3.4", ip.String())
+
+	port, ok := r.TCP()
+	require.True(t, ok)
+	require.Equal(t, 13610, port)
+
+	ip6, ok := r.IP6()
+	require.True(t, ok)
+	require.Equal(t, "2001:db8::1", ip6.String())
+
+	port6, ok := r.TCP6()
+	require.True(t, ok)
+	require.Equal(t, 13612, port6)
+}
@@ -0,0 +1,46 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+// NewResourceManager returns a libp2p resource manager enforcing the default connection, stream, file descriptor and
+// memory limits scaled to the available system resources, preventing file descriptor exhaustion.
+// Cluster peers are exempt from the per-peer limits and the per IP connection limit allows all cluster peers to share
+// a single IP, so the limits never sever cluster quorum.
+func NewResourceManager(peers []peer.ID) (network.ResourceManager, error) {
+	limits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&limits)
+
+	config := rcmgr.PartialLimitConfig{
+		Peer: make(map[peer.ID]rcmgr.ResourceLimits),
+	}
+
+	unlimited := rcmgr.InfiniteLimits.ToPartialLimitConfig().PeerDefault
+	for _, p := range peers {
+		config.Peer[p] = unlimited
+	}
+
+	// Allow a few connections per cluster peer from the same IP.
+	perIP := max(8, 4*len(peers))
+
+	resp, err := rcmgr.NewResourceManager(
+		rcmgr.NewFixedLimiter(config.Build(limits.AutoScale())),
+		rcmgr.WithLimitPerSubnet(
+			[]rcmgr.ConnLimitPerSubnet{{PrefixLength: 32, ConnCount: perIP}},
+			[]rcmgr.ConnLimitPerSubnet{{PrefixLength: 56, ConnCount: perIP}, {PrefixLength: 48, ConnCount: 8 * perIP}},
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "new resource manager")
+	}
+
+	return resp, nil
+}
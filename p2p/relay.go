@@ -67,6 +67,7 @@ func NewRelayReserver(tcpNode host.Host, relay *MutablePeer) lifecycle.HookFuncC
 				z.Str("relay_peer", name),
 			)
 			relayConnGauge.WithLabelValues(name).Set(1)
+			setReservation(tcpNode.ID(), relayPeer, resv.Expiration)
 
 			refresh := time.After(refreshDelay)
 
@@ -92,6 +93,7 @@ func NewRelayReserver(tcpNode host.Host, relay *MutablePeer) lifecycle.HookFuncC
 			}
 
 			checkConnTicker.Stop()
+			deleteReservation(tcpNode.ID(), relayPeer.ID)
 
 			if ctx.Err() != nil {
 				return
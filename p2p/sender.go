@@ -197,6 +197,7 @@ type sendRecvOpts struct {
 	protocols         []protocol.ID // Protocols ordered by higher priority first
 	writersByProtocol map[protocol.ID]func(network.Stream) pbio.Writer
 	readersByProtocol map[protocol.ID]func(network.Stream) pbio.Reader
+	convertByProtocol map[protocol.ID]func(proto.Message) (proto.Message, error)
 	rttCallback       func(time.Duration)
 	receiveTimeout    time.Duration
 	sendTimeout       time.Duration
@@ -232,6 +233,18 @@ func WithDelimitedProtocol(pID protocol.ID) func(*sendRecvOpts) {
 	}
 }
 
+// WithVersionedProtocol returns an option that adds a newer protocol version with a different message type.
+// The newer protocol is preferred if supported by the peer, in which case Send converts the message using the
+// provided function. Peers not supporting it fall back to the original protocol, enabling rolling upgrades.
+func WithVersionedProtocol(pID protocol.ID, convert func(proto.Message) (proto.Message, error)) func(*sendRecvOpts) {
+	return func(opts *sendRecvOpts) {
+		opts.protocols = append([]protocol.ID{pID}, opts.protocols...) // Add to front
+		opts.writersByProtocol[pID] = defaultWriterFunc
+		opts.readersByProtocol[pID] = defaultReaderFunc
+		opts.convertByProtocol[pID] = convert
+	}
+}
+
 // SetFuzzerDefaultsUnsafe sets default reader and writer functions to fuzzed versions of the same if p2p fuzz is enabled.
 //
 // The fuzzReaderWriter is responsible for creating a customized reader and writer for each network stream
@@ -256,9 +269,10 @@ func defaultSendRecvOpts(pID protocol.ID) sendRecvOpts {
 		readersByProtocol: map[protocol.ID]func(s network.Stream) pbio.Reader{
 			pID: defaultReaderFunc,
 		},
-		rttCallback:    func(time.Duration) {},
-		receiveTimeout: defaultRcvTimeout,
-		sendTimeout:    defaultSendTimeout,
+		convertByProtocol: make(map[protocol.ID]func(proto.Message) (proto.Message, error)),
+		rttCallback:       func(time.Duration) {},
+		receiveTimeout:    defaultRcvTimeout,
+		sendTimeout:       defaultSendTimeout,
 	}
 }
 
@@ -278,6 +292,12 @@ func SendReceive(ctx context.Context, tcpNode host.Host, peerID peer.ID,
 		opt(&o)
 	}
 
+	if drop, err := applyChaos(ctx, peerID); err != nil {
+		return err
+	} else if drop {
+		return errChaosDropped
+	}
+
 	// Circuit relay connections are transient
 	s, err := tcpNode.NewStream(network.WithAllowLimitedConn(ctx, ""), peerID, o.protocols...)
 	if err != nil {
@@ -332,6 +352,13 @@ func Send(ctx context.Context, tcpNode host.Host, protoID protocol.ID, peerID pe
 	for _, opt := range opts {
 		opt(&o)
 	}
+
+	if drop, err := applyChaos(ctx, peerID); err != nil {
+		return err
+	} else if drop {
+		return nil // Silently drop the message.
+	}
+
 	// Circuit relay connections are transient
 	s, err := tcpNode.NewStream(network.WithAllowLimitedConn(ctx, ""), peerID, o.protocols...)
 	if err != nil {
@@ -347,6 +374,13 @@ func Send(ctx context.Context, tcpNode host.Host, protoID protocol.ID, peerID pe
 		return errors.New("no writer for protocol", z.Any("protocol", s.Protocol()))
 	}
 
+	if convert, ok := o.convertByProtocol[s.Protocol()]; ok {
+		msg, err = convert(msg)
+		if err != nil {
+			return errors.Wrap(err, "convert message", z.Any("protocol", s.Protocol()))
+		}
+	}
+
 	if err = writeFunc(s).WriteMsg(msg); err != nil {
 		return errors.Wrap(err, "write message", z.Any("protocol", s.Protocol()))
 	}
@@ -0,0 +1,372 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tbls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"sort"
+	"testing"
+
+	blst "github.com/supranational/blst/bindings/go"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// blstDST is the eth2 BLS signature domain separation tag, see
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/beacon-chain.md#bls-signatures.
+var blstDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// blstCoeffs caches the Lagrange coefficients of participant sets.
+var blstCoeffs = newCoeffCache(blstLagrangeCoefficients)
+
+// Blst is an Implementation with supranational/blst-specific inner logic.
+// It is compatible with Herumi, keys, shares and signatures can be used interchangeably.
+type Blst struct{}
+
+func (Blst) GenerateInsecureKey(t *testing.T, random io.Reader) (PrivateKey, error) {
+	t.Helper()
+
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(random, ikm); err != nil {
+		return PrivateKey{}, errors.Wrap(err, "read random")
+	}
+
+	return *(*PrivateKey)(blst.KeyGen(ikm).Serialize()), nil
+}
+
+func (Blst) GenerateSecretKey() (PrivateKey, error) {
+	secret, err := blstRandomSecret()
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	return *(*PrivateKey)(secret.Serialize()), nil
+}
+
+func (Blst) SecretToPublicKey(secret PrivateKey) (PublicKey, error) {
+	sk, err := blstSecret(secret)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	return *(*PublicKey)(new(blst.P1Affine).From(sk).Compress()), nil
+}
+
+func (Blst) ThresholdSplitInsecure(t *testing.T, secret PrivateKey, total uint, threshold uint, random io.Reader) (map[int]PrivateKey, error) {
+	t.Helper()
+
+	return blstThresholdSplit(secret, total, threshold, func() (*blst.SecretKey, error) {
+		ikm := make([]byte, 32)
+		if _, err := io.ReadFull(random, ikm); err != nil {
+			return nil, errors.Wrap(err, "read random")
+		}
+
+		return blst.KeyGen(ikm), nil
+	})
+}
+
+func (Blst) ThresholdSplit(secret PrivateKey, total uint, threshold uint) (map[int]PrivateKey, error) {
+	return blstThresholdSplit(secret, total, threshold, blstRandomSecret)
+}
+
+func (Blst) RecoverSecret(shares map[int]PrivateKey, _, _ uint) (PrivateKey, error) {
+	var ids []int
+	for idx := range shares {
+		ids = append(ids, idx)
+	}
+
+	sort.Ints(ids)
+
+	coeffs, err := blstCoeffs.Get(ids)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	resp := new(blst.Scalar)
+
+	for i, idx := range ids {
+		share := shares[idx]
+
+		sk, err := blstSecret(share)
+		if err != nil {
+			return PrivateKey{}, errors.Wrap(err, "cannot unmarshal key into blst secret key", z.Int("key_number", idx))
+		}
+
+		term, _ := sk.Mul(coeffs[i])
+		resp.AddAssign(term)
+	}
+
+	if !resp.Valid() {
+		return PrivateKey{}, errors.New("cannot recover full private key from partial keys")
+	}
+
+	return *(*PrivateKey)(resp.Serialize()), nil
+}
+
+func (Blst) Aggregate(signs []Signature) (Signature, error) {
+	sigs := make([][]byte, 0, len(signs))
+	for _, sig := range signs {
+		sigs = append(sigs, sig[:])
+	}
+
+	var agg blst.P2Aggregate
+	if !agg.AggregateCompressed(sigs, true) {
+		return Signature{}, errors.New("cannot aggregate signatures")
+	}
+
+	return *(*Signature)(agg.ToAffine().Compress()), nil
+}
+
+func (Blst) ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error) {
+	ids := sortedIndices(partialSignaturesByIndex)
+
+	coeffs, err := blstCoeffs.Get(ids)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	resp := new(blst.P2)
+
+	for i, idx := range ids {
+		rawSignature := partialSignaturesByIndex[idx]
+
+		sig := new(blst.P2Affine).Uncompress(rawSignature[:])
+		if sig == nil || !sig.SigValidate(false) {
+			return Signature{}, errors.New("cannot unmarshal signature into blst signature", z.Int("signature_number", idx))
+		}
+
+		var p blst.P2
+		p.FromAffine(sig)
+		resp.AddAssign(p.Mult(coeffs[i]))
+	}
+
+	return *(*Signature)(resp.ToAffine().Compress()), nil
+}
+
+func (Blst) ThresholdAggregatePublicKeys(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	ids := sortedIndices(publicSharesByIndex)
+
+	coeffs, err := blstCoeffs.Get(ids)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	resp := new(blst.P1)
+
+	for i, idx := range ids {
+		rawPubshare := publicSharesByIndex[idx]
+
+		pubshare := new(blst.P1Affine).Uncompress(rawPubshare[:])
+		if pubshare == nil || !pubshare.KeyValidate() {
+			return PublicKey{}, errors.New("cannot unmarshal public share into blst public key", z.Int("share_number", idx))
+		}
+
+		var p blst.P1
+		p.FromAffine(pubshare)
+		resp.AddAssign(p.Mult(coeffs[i]))
+	}
+
+	return *(*PublicKey)(resp.ToAffine().Compress()), nil
+}
+
+func (Blst) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Signature) error {
+	pubkey := new(blst.P1Affine).Uncompress(compressedPublicKey[:])
+	if pubkey == nil {
+		return errors.New("cannot set compressed public key in blst format")
+	}
+
+	sig := new(blst.P2Affine).Uncompress(rawSignature[:])
+	if sig == nil {
+		return errors.New("cannot unmarshal signature into blst signature")
+	}
+
+	if !sig.Verify(true, pubkey, true, data, blstDST) {
+		return ErrSigNotVerified
+	}
+
+	return nil
+}
+
+func (Blst) BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, rawSignatures []Signature) error {
+	if len(compressedPublicKeys) != len(data) || len(compressedPublicKeys) != len(rawSignatures) {
+		return errors.New("mismatching batch verify lengths")
+	} else if len(data) == 0 {
+		return nil
+	}
+
+	var (
+		pubkeys = make([]*blst.P1Affine, len(compressedPublicKeys))
+		sigs    = make([]*blst.P2Affine, len(rawSignatures))
+		msgs    = make([]blst.Message, len(data))
+	)
+
+	for i := range compressedPublicKeys {
+		pubkeys[i] = new(blst.P1Affine).Uncompress(compressedPublicKeys[i][:])
+		if pubkeys[i] == nil {
+			return errors.New("cannot set compressed public key in blst format")
+		}
+
+		sigs[i] = new(blst.P2Affine).Uncompress(rawSignatures[i][:])
+		if sigs[i] == nil {
+			return errors.New("cannot unmarshal signature into blst signature")
+		}
+
+		msgs[i] = data[i]
+	}
+
+	const randBits = 64
+
+	randFunc := func(s *blst.Scalar) {
+		var b [32]byte
+		_, _ = rand.Read(b[32-randBits/8:])
+		s.FromBEndian(b[:])
+	}
+
+	if !new(blst.P2Affine).MultipleAggregateVerify(sigs, true, pubkeys, true, msgs, blstDST, randFunc, randBits) {
+		return ErrSigNotVerified
+	}
+
+	return nil
+}
+
+func (Blst) Sign(privateKey PrivateKey, data []byte) (Signature, error) {
+	sk, err := blstSecret(privateKey)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return *(*Signature)(new(blst.P2Affine).Sign(sk, data, blstDST).Compress()), nil
+}
+
+func (Blst) VerifyAggregate(publicShares []PublicKey, signature Signature, data []byte) error {
+	sig := new(blst.P2Affine).Uncompress(signature[:])
+	if sig == nil {
+		return errors.New("cannot unmarshal signature into blst signature")
+	}
+
+	pubkeys := make([]*blst.P1Affine, 0, len(publicShares))
+	for _, share := range publicShares {
+		pubkey := new(blst.P1Affine).Uncompress(share[:])
+		if pubkey == nil {
+			return errors.New("cannot set compressed public key in blst format")
+		}
+
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	if !sig.FastAggregateVerify(true, pubkeys, data, blstDST) {
+		return errors.New("signature verification failed")
+	}
+
+	return nil
+}
+
+// blstSecret returns the blst secret key of the private key.
+func blstSecret(secret PrivateKey) (*blst.SecretKey, error) {
+	sk := new(blst.SecretKey).Deserialize(secret[:])
+	if sk == nil {
+		return nil, errors.New("cannot unmarshal secret into blst secret key")
+	}
+
+	return sk, nil
+}
+
+// blstRandomSecret returns a new cryptographically secure random secret key.
+func blstRandomSecret() (*blst.SecretKey, error) {
+	ikm := make([]byte, 32)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, errors.Wrap(err, "read random")
+	}
+
+	return blst.KeyGen(ikm), nil
+}
+
+// blstScalar returns the scalar of the integer.
+func blstScalar(i int) *blst.Scalar {
+	var b [32]byte
+	binary.BigEndian.PutUint64(b[24:], uint64(i))
+
+	return new(blst.Scalar).FromBEndian(b[:])
+}
+
+// blstThresholdSplit splits the secret into total shares evaluated at indexes 1 to total of a random
+// polynomial of degree threshold-1, compatible with Herumi.
+func blstThresholdSplit(secret PrivateKey, total uint, threshold uint, randomSecret func() (*blst.SecretKey, error)) (map[int]PrivateKey, error) {
+	if threshold <= 1 {
+		return nil, errors.New("threshold has to be greater than 1")
+	}
+
+	sk, err := blstSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// master key Polynomial
+	poly := []*blst.Scalar{sk}
+
+	for i := 1; i < int(threshold); i++ {
+		coeff, err := randomSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		poly = append(poly, coeff)
+	}
+
+	ret := make(map[int]PrivateKey)
+	for i := 1; i <= int(total); i++ {
+		x := blstScalar(i)
+
+		// Evaluate polynomial at x using Horner's method.
+		share := *poly[len(poly)-1]
+		for j := len(poly) - 2; j >= 0; j-- {
+			share.MulAssign(x)
+			share.AddAssign(poly[j])
+		}
+
+		if !share.Valid() {
+			return nil, errors.New("invalid share", z.Int("id_number", i))
+		}
+
+		ret[i] = *(*PrivateKey)(share.Serialize())
+	}
+
+	return ret, nil
+}
+
+// blstLagrangeCoefficients returns the Lagrange basis polynomials evaluated at zero for each index.
+func blstLagrangeCoefficients(ids []int) ([]*blst.Scalar, error) {
+	if err := validateIndices(ids); err != nil {
+		return nil, err
+	}
+
+	resp := make([]*blst.Scalar, 0, len(ids))
+
+	for i, idI := range ids {
+		var (
+			num = blstScalar(1)
+			den = blstScalar(1)
+			xi  = blstScalar(idI)
+		)
+
+		for j, idJ := range ids {
+			if i == j {
+				continue
+			}
+
+			xj := blstScalar(idJ)
+			num.MulAssign(xj)
+
+			diff, _ := xj.Sub(xi)
+			den.MulAssign(diff)
+		}
+
+		coeff, _ := num.Mul(den.Inverse())
+		resp = append(resp, coeff)
+	}
+
+	return resp, nil
+}
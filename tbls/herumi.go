@@ -37,6 +37,9 @@ func init() {
 	})
 }
 
+// herumiCoeffs caches the Lagrange coefficients of participant sets.
+var herumiCoeffs = newCoeffCache(herumiLagrangeCoefficients)
+
 // Herumi is an Implementation with Herumi-specific inner logic.
 type Herumi struct{}
 
@@ -250,12 +253,18 @@ func (Herumi) Aggregate(signs []Signature) (Signature, error) {
 }
 
 func (Herumi) ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error) {
-	var (
-		rawSigns []bls.Sign
-		rawIDs   []bls.ID
-	)
+	ids := sortedIndices(partialSignaturesByIndex)
+
+	coeffs, err := herumiCoeffs.Get(ids)
+	if err != nil {
+		return Signature{}, errors.Wrap(err, "cannot combine signatures")
+	}
+
+	rawSigns := make([]bls.G2, len(ids))
+
+	for i, idx := range ids {
+		rawSignature := partialSignaturesByIndex[idx]
 
-	for idx, rawSignature := range partialSignaturesByIndex {
 		var signature bls.Sign
 		if err := signature.Deserialize(rawSignature[:]); err != nil {
 			return Signature{}, errors.Wrap(
@@ -265,27 +274,44 @@ func (Herumi) ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Si
 			)
 		}
 
-		rawSigns = append(rawSigns, signature)
+		rawSigns[i] = *bls.CastFromSign(&signature)
+	}
 
-		var id bls.ID
-		if err := id.SetDecString(strconv.Itoa(idx)); err != nil {
-			return Signature{}, errors.Wrap(
+	var complete bls.G2
+	bls.G2MulVec(&complete, rawSigns, coeffs)
+
+	return *(*Signature)(bls.CastToSign(&complete).Serialize()), nil
+}
+
+func (Herumi) ThresholdAggregatePublicKeys(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	ids := sortedIndices(publicSharesByIndex)
+
+	coeffs, err := herumiCoeffs.Get(ids)
+	if err != nil {
+		return PublicKey{}, errors.Wrap(err, "cannot combine public shares")
+	}
+
+	rawPubshares := make([]bls.G1, len(ids))
+
+	for i, idx := range ids {
+		rawPubshare := publicSharesByIndex[idx]
+
+		var pubshare bls.PublicKey
+		if err := pubshare.Deserialize(rawPubshare[:]); err != nil {
+			return PublicKey{}, errors.Wrap(
 				err,
-				"signature id isn't a number",
-				z.Int("signature_number", idx),
+				"cannot unmarshal public share into Herumi public key",
+				z.Int("share_number", idx),
 			)
 		}
 
-		rawIDs = append(rawIDs, id)
+		rawPubshares[i] = *bls.CastFromPublicKey(&pubshare)
 	}
 
-	var complete bls.Sign
+	var complete bls.G1
+	bls.G1MulVec(&complete, rawPubshares, coeffs)
 
-	if err := complete.Recover(rawSigns, rawIDs); err != nil {
-		return Signature{}, errors.Wrap(err, "cannot combine signatures")
-	}
-
-	return *(*Signature)(complete.Serialize()), nil
+	return *(*PublicKey)(bls.CastToPublicKey(&complete).Serialize()), nil
 }
 
 func (Herumi) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Signature) error {
@@ -306,6 +332,12 @@ func (Herumi) Verify(compressedPublicKey PublicKey, data []byte, rawSignature Si
 	return nil
 }
 
+// BatchVerify verifies the signatures in a single randomized multi-pairing via blst. Herumi's multi verify converts
+// uintptrs of Go heap allocations back to pointers, which is invalid and crashes with checkptr enabled, e.g. under -race.
+func (Herumi) BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, rawSignatures []Signature) error {
+	return Blst{}.BatchVerify(compressedPublicKeys, data, rawSignatures)
+}
+
 func (Herumi) Sign(privateKey PrivateKey, data []byte) (Signature, error) {
 	var p bls.SecretKey
 
@@ -344,6 +376,41 @@ func (Herumi) VerifyAggregate(publicShares []PublicKey, signature Signature, dat
 	return nil
 }
 
+// herumiLagrangeCoefficients returns the Lagrange basis polynomials evaluated at zero for each index.
+func herumiLagrangeCoefficients(ids []int) ([]bls.Fr, error) {
+	if err := validateIndices(ids); err != nil {
+		return nil, err
+	}
+
+	resp := make([]bls.Fr, len(ids))
+
+	for i, idI := range ids {
+		var num, den, xi bls.Fr
+
+		num.SetInt64(1)
+		den.SetInt64(1)
+		xi.SetInt64(int64(idI))
+
+		for j, idJ := range ids {
+			if i == j {
+				continue
+			}
+
+			var xj, diff bls.Fr
+
+			xj.SetInt64(int64(idJ))
+			bls.FrMul(&num, &num, &xj)
+			bls.FrSub(&diff, &xj, &xi)
+			bls.FrMul(&den, &den, &diff)
+		}
+
+		bls.FrInv(&den, &den)
+		bls.FrMul(&resp[i], &num, &den)
+	}
+
+	return resp, nil
+}
+
 // generateInsecureSecret generates a secret that is not cryptographically secure using the
 // provided random number generator. This is useful for testing.
 func generateInsecureSecret(t *testing.T, random io.Reader) (bls.SecretKey, error) {
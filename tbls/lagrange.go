@@ -0,0 +1,107 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tbls
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// maxCoeffCacheSize bounds the number of cached participant sets. The number of distinct
+// participant sets of a cluster is small, e.g. 252 for 10 nodes with threshold 5.
+const maxCoeffCacheSize = 1024
+
+// newCoeffCache returns a new Lagrange coefficient cache using the compute function on cache misses.
+func newCoeffCache[T any](compute func(ids []int) ([]T, error)) *coeffCache[T] {
+	return &coeffCache[T]{
+		compute: compute,
+		entries: make(map[string][]T),
+	}
+}
+
+// coeffCache caches the Lagrange coefficients at zero of participant sets, so repeated threshold
+// aggregation with the same participants (the common case) avoids recomputing them.
+// The threshold is implied by the size of the participant set.
+type coeffCache[T any] struct {
+	compute func(ids []int) ([]T, error)
+
+	mu      sync.Mutex
+	entries map[string][]T
+	hits    int
+	misses  int
+}
+
+// Get returns the coefficients of the sorted participant share indices, in the same order.
+func (c *coeffCache[T]) Get(ids []int) ([]T, error) {
+	key := coeffCacheKey(ids)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if coeffs, ok := c.entries[key]; ok {
+		c.hits++
+		return coeffs, nil
+	}
+
+	c.misses++
+
+	coeffs, err := c.compute(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.entries) >= maxCoeffCacheSize {
+		c.entries = make(map[string][]T) // Just reset, this should never happen.
+	}
+
+	c.entries[key] = coeffs
+
+	return coeffs, nil
+}
+
+// coeffCacheKey returns the cache key of the participant share indices.
+func coeffCacheKey(ids []int) string {
+	var sb strings.Builder
+	for i, id := range ids {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(strconv.Itoa(id))
+	}
+
+	return sb.String()
+}
+
+// sortedIndices returns the sorted share indices of the partial signatures or public shares.
+func sortedIndices[T any](byIndex map[int]T) []int {
+	ids := make([]int, 0, len(byIndex))
+	for idx := range byIndex {
+		ids = append(ids, idx)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
+
+// validateIndices returns an error if any share index isn't positive or is duplicated.
+func validateIndices(ids []int) error {
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if id <= 0 {
+			return errors.New("invalid index", z.Int("index", id))
+		} else if seen[id] {
+			return errors.New("duplicate index", z.Int("index", id))
+		}
+
+		seen[id] = true
+	}
+
+	return nil
+}
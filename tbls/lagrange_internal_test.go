@@ -0,0 +1,71 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tbls
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoeffCache(t *testing.T) {
+	var computed [][]int
+
+	cache := newCoeffCache(func(ids []int) ([]int, error) {
+		if err := validateIndices(ids); err != nil {
+			return nil, err
+		}
+
+		computed = append(computed, ids)
+
+		return ids, nil
+	})
+
+	for range 3 {
+		coeffs, err := cache.Get([]int{1, 2, 3})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, coeffs)
+
+		_, err = cache.Get([]int{2, 3, 4})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}}, computed)
+	require.Equal(t, 4, cache.hits)
+	require.Equal(t, 2, cache.misses)
+
+	_, err := cache.Get([]int{1, 1, 2})
+	require.ErrorContains(t, err, "duplicate index")
+
+	_, err = cache.Get([]int{0, 1})
+	require.ErrorContains(t, err, "invalid index")
+}
+
+func TestSortedIndices(t *testing.T) {
+	require.Equal(t, []int{1, 3, 4}, sortedIndices(map[int]Signature{4: {}, 1: {}, 3: {}}))
+	require.Equal(t, "1,3,4", coeffCacheKey([]int{1, 3, 4}))
+}
+
+func BenchmarkThresholdAggregate(b *testing.B) {
+	for _, impl := range []Implementation{Herumi{}, Blst{}} {
+		secret, err := impl.GenerateSecretKey()
+		require.NoError(b, err)
+
+		shares, err := impl.ThresholdSplit(secret, 4, 3)
+		require.NoError(b, err)
+
+		signatures := make(map[int]Signature)
+		for idx := 1; idx <= 3; idx++ {
+			signatures[idx], err = impl.Sign(shares[idx], []byte("hello obol!"))
+			require.NoError(b, err)
+		}
+
+		b.Run(fmt.Sprintf("%T", impl), func(b *testing.B) {
+			for range b.N {
+				_, err := impl.ThresholdAggregate(signatures)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
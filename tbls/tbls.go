@@ -6,6 +6,15 @@ import (
 	"io"
 	"sync"
 	"testing"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// Supported BLS backends.
+const (
+	BackendHerumi = "herumi"
+	BackendBlst   = "blst"
 )
 
 var (
@@ -51,10 +60,18 @@ type Implementation interface {
 	// ThresholdAggregate aggregates the partial signatures passed in input in the final original signature.
 	ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature, error)
 
+	// ThresholdAggregatePublicKeys aggregates the public shares passed in input in the original public key.
+	ThresholdAggregatePublicKeys(publicSharesByIndex map[int]PublicKey) (PublicKey, error)
+
 	// Verify verifies that signature has been produced with the private key associated with compressedPublicKey, on
 	// the provided data.
 	Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error
 
+	// BatchVerify verifies that each signature has been produced with the private key associated with the
+	// public key at the same index, on the data at the same index. It is faster than verifying each signature
+	// individually, but doesn't identify which signature is invalid.
+	BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) error
+
 	// Sign signs data with the provided private key, and returns the resulting signature.
 	// This function works on both shares of private keys, and complete private keys.
 	Sign(privateKey PrivateKey, data []byte) (Signature, error)
@@ -76,6 +93,50 @@ func SetImplementation(newImpl Implementation) {
 	impl = newImpl
 }
 
+// Backends returns the names of the supported BLS backends.
+func Backends() []string {
+	return []string{BackendHerumi, BackendBlst}
+}
+
+// BackendImplementation returns the implementation of the named BLS backend.
+func BackendImplementation(name string) (Implementation, error) {
+	switch name {
+	case BackendHerumi:
+		return Herumi{}, nil
+	case BackendBlst:
+		return Blst{}, nil
+	default:
+		return nil, errors.New("unknown bls backend", z.Str("backend", name), z.Any("supported", Backends()))
+	}
+}
+
+// SetBackend sets the implementation of the named BLS backend as the package backing implementation.
+func SetBackend(name string) error {
+	newImpl, err := BackendImplementation(name)
+	if err != nil {
+		return err
+	}
+
+	SetImplementation(newImpl)
+
+	return nil
+}
+
+// Backend returns the name of the package backing implementation.
+func Backend() string {
+	implLock.Lock()
+	defer implLock.Unlock()
+
+	switch impl.(type) {
+	case Herumi:
+		return BackendHerumi
+	case Blst:
+		return BackendBlst
+	default:
+		return "custom"
+	}
+}
+
 // GenerateSecretKey generates a secret key and returns its compressed serialized representation.
 func GenerateSecretKey() (PrivateKey, error) {
 	return impl.GenerateSecretKey()
@@ -117,12 +178,24 @@ func ThresholdAggregate(partialSignaturesByIndex map[int]Signature) (Signature,
 	return impl.ThresholdAggregate(partialSignaturesByIndex)
 }
 
+// ThresholdAggregatePublicKeys aggregates the public shares passed in input in the original public key.
+func ThresholdAggregatePublicKeys(publicSharesByIndex map[int]PublicKey) (PublicKey, error) {
+	return impl.ThresholdAggregatePublicKeys(publicSharesByIndex)
+}
+
 // Verify verifies that signature has been produced with the private key associated with compressedPublicKey, on
 // the provided data.
 func Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error {
 	return impl.Verify(compressedPublicKey, data, signature)
 }
 
+// BatchVerify verifies that each signature has been produced with the private key associated with the
+// public key at the same index, on the data at the same index. It is faster than verifying each signature
+// individually, but doesn't identify which signature is invalid.
+func BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) error {
+	return impl.BatchVerify(compressedPublicKeys, data, signatures)
+}
+
 // Sign signs data with the provided private key, and returns the resulting signature.
 // This function works on both shares of private keys, and complete private keys.
 func Sign(privateKey PrivateKey, data []byte) (Signature, error) {
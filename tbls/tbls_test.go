@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/obolnetwork/charon/tbls"
@@ -97,6 +98,73 @@ func (ts *TestSuite) Test_ThresholdAggregate() {
 	ts.Require().Equal(totalOGSig, totalSig)
 }
 
+func (ts *TestSuite) Test_ThresholdAggregateSubsets() {
+	data := []byte("hello obol!")
+
+	secret, err := tbls.GenerateSecretKey()
+	ts.Require().NoError(err)
+
+	totalOGSig, err := tbls.Sign(secret, data)
+	ts.Require().NoError(err)
+
+	shares, err := tbls.ThresholdSplit(secret, 5, 3)
+	ts.Require().NoError(err)
+
+	// Aggregate each participant set twice, hitting cached coefficients.
+	for range 2 {
+		for _, subset := range [][]int{{1, 2, 3}, {2, 4, 5}, {1, 3, 5}, {1, 2, 3, 4}} {
+			signatures := map[int]tbls.Signature{}
+
+			for _, idx := range subset {
+				signature, err := tbls.Sign(shares[idx], data)
+				ts.Require().NoError(err)
+
+				signatures[idx] = signature
+			}
+
+			totalSig, err := tbls.ThresholdAggregate(signatures)
+			ts.Require().NoError(err)
+			ts.Require().Equal(totalOGSig, totalSig)
+		}
+	}
+}
+
+func (ts *TestSuite) Test_ThresholdAggregatePublicKeys() {
+	secret, err := tbls.GenerateSecretKey()
+	ts.Require().NoError(err)
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	ts.Require().NoError(err)
+
+	shares, err := tbls.ThresholdSplit(secret, 5, 3)
+	ts.Require().NoError(err)
+
+	for _, subset := range [][]int{{1, 2, 3}, {2, 4, 5}, {1, 2, 3, 4, 5}} {
+		pubshares := map[int]tbls.PublicKey{}
+
+		for _, idx := range subset {
+			pubshare, err := tbls.SecretToPublicKey(shares[idx])
+			ts.Require().NoError(err)
+
+			pubshares[idx] = pubshare
+		}
+
+		aggPubkey, err := tbls.ThresholdAggregatePublicKeys(pubshares)
+		ts.Require().NoError(err)
+		ts.Require().Equal(pubkey, aggPubkey)
+	}
+
+	// Too few public shares don't recombine to the public key.
+	pubshare1, err := tbls.SecretToPublicKey(shares[1])
+	ts.Require().NoError(err)
+	pubshare2, err := tbls.SecretToPublicKey(shares[2])
+	ts.Require().NoError(err)
+
+	aggPubkey, err := tbls.ThresholdAggregatePublicKeys(map[int]tbls.PublicKey{1: pubshare1, 2: pubshare2})
+	ts.Require().NoError(err)
+	ts.Require().NotEqual(pubkey, aggPubkey)
+}
+
 func (ts *TestSuite) Test_Verify() {
 	data := []byte("hello obol!")
 
@@ -115,6 +183,42 @@ func (ts *TestSuite) Test_Verify() {
 	ts.Require().NoError(tbls.Verify(pubkey, data, signature))
 }
 
+func (ts *TestSuite) Test_BatchVerify() {
+	for _, size := range []int{32, 11} { // Signing roots and arbitrary data.
+		var (
+			pubkeys []tbls.PublicKey
+			datas   [][]byte
+			sigs    []tbls.Signature
+		)
+
+		for i := range 20 {
+			secret, err := tbls.GenerateSecretKey()
+			ts.Require().NoError(err)
+
+			pubkey, err := tbls.SecretToPublicKey(secret)
+			ts.Require().NoError(err)
+
+			data := make([]byte, size)
+			data[0] = byte(i)
+
+			sig, err := tbls.Sign(secret, data)
+			ts.Require().NoError(err)
+
+			pubkeys = append(pubkeys, pubkey)
+			datas = append(datas, data)
+			sigs = append(sigs, sig)
+		}
+
+		ts.Require().NoError(tbls.BatchVerify(pubkeys, datas, sigs))
+		ts.Require().NoError(tbls.BatchVerify(nil, nil, nil))
+		ts.Require().Error(tbls.BatchVerify(pubkeys, datas[1:], sigs))
+
+		// Swap signatures.
+		sigs[3], sigs[4] = sigs[4], sigs[3]
+		ts.Require().Error(tbls.BatchVerify(pubkeys, datas, sigs))
+	}
+}
+
 func (ts *TestSuite) Test_Sign() {
 	data := []byte("hello obol!")
 
@@ -182,6 +286,10 @@ func TestHerumiImplementation(t *testing.T) {
 	runSuite(t, tbls.Herumi{})
 }
 
+func TestBlstImplementation(t *testing.T) {
+	runSuite(t, tbls.Blst{})
+}
+
 func runBenchmark(b *testing.B, impl tbls.Implementation) {
 	b.Helper()
 
@@ -202,6 +310,7 @@ func runBenchmark(b *testing.B, impl tbls.Implementation) {
 		s.Test_ThresholdSplit()
 		s.Test_RecoverSecret()
 		s.Test_ThresholdAggregate()
+		s.Test_ThresholdAggregatePublicKeys()
 		s.Test_Verify()
 		s.Test_Sign()
 		s.Test_VerifyAggregate()
@@ -212,10 +321,15 @@ func BenchmarkHerumiImplementation(b *testing.B) {
 	runBenchmark(b, tbls.Herumi{})
 }
 
+func BenchmarkBlstImplementation(b *testing.B) {
+	runBenchmark(b, tbls.Blst{})
+}
+
 func TestRandomized(t *testing.T) {
 	runSuite(t, randomizedImpl{
 		implementations: []tbls.Implementation{
 			tbls.Herumi{},
+			tbls.Blst{},
 		},
 	})
 }
@@ -309,6 +423,15 @@ func (r randomizedImpl) ThresholdAggregate(partialSignaturesByIndex map[int]tbls
 	return impl.ThresholdAggregate(partialSignaturesByIndex)
 }
 
+func (r randomizedImpl) ThresholdAggregatePublicKeys(publicSharesByIndex map[int]tbls.PublicKey) (tbls.PublicKey, error) {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return tbls.PublicKey{}, err
+	}
+
+	return impl.ThresholdAggregatePublicKeys(publicSharesByIndex)
+}
+
 func (r randomizedImpl) Verify(compressedPublicKey tbls.PublicKey, data []byte, signature tbls.Signature) error {
 	impl, err := r.selectImpl()
 	if err != nil {
@@ -318,6 +441,15 @@ func (r randomizedImpl) Verify(compressedPublicKey tbls.PublicKey, data []byte,
 	return impl.Verify(compressedPublicKey, data, signature)
 }
 
+func (r randomizedImpl) BatchVerify(compressedPublicKeys []tbls.PublicKey, data [][]byte, signatures []tbls.Signature) error {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return err
+	}
+
+	return impl.BatchVerify(compressedPublicKeys, data, signatures)
+}
+
 func (r randomizedImpl) Sign(privateKey tbls.PrivateKey, data []byte) (tbls.Signature, error) {
 	impl, err := r.selectImpl()
 	if err != nil {
@@ -350,3 +482,16 @@ func FuzzRandomImplementations(f *testing.F) {
 		TestRandomized(t)
 	})
 }
+
+func TestSetBackend(t *testing.T) {
+	t.Cleanup(func() {
+		tbls.SetImplementation(tbls.Herumi{})
+	})
+
+	for _, backend := range tbls.Backends() {
+		require.NoError(t, tbls.SetBackend(backend))
+		require.Equal(t, backend, tbls.Backend())
+	}
+
+	require.ErrorContains(t, tbls.SetBackend("invalid"), "unknown bls backend")
+}
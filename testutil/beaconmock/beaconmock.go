@@ -25,6 +25,7 @@ package beaconmock
 
 import (
 	"context"
+	"maps"
 	"net/http"
 	"strconv"
 	"time"
@@ -58,7 +59,10 @@ func New(opts ...Option) (Mock, error) {
 
 	headProducer := newHeadProducer()
 
-	httpMock, httpServer, err := newHTTPMock(headProducer.Handlers(), temp.overrides...)
+	handlers := headProducer.Handlers()
+	maps.Copy(handlers, temp.handlers)
+
+	httpMock, httpServer, err := newHTTPMock(handlers, temp.overrides...)
 	if err != nil {
 		return Mock{}, err
 	}
@@ -184,6 +188,7 @@ type Mock struct {
 
 	httpServer   *http.Server
 	overrides    []staticOverride
+	handlers     map[string]http.HandlerFunc
 	clock        clockwork.Clock
 	headProducer *headProducer
 	forkVersion  [4]byte
@@ -193,6 +198,7 @@ type Mock struct {
 	CachedValidatorsFunc                   func(ctx context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error)
 	AttestationDataFunc                    func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error)
 	AttesterDutiesFunc                     func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error)
+	BeaconBlockHeaderFunc                  func(ctx context.Context, blockID string) (*eth2v1.BeaconBlockHeader, error)
 	BlockAttestationsFunc                  func(ctx context.Context, stateID string) ([]*eth2spec.VersionedAttestation, error)
 	BlockFunc                              func(ctx context.Context, stateID string) (*eth2spec.VersionedSignedBeaconBlock, error)
 	BeaconStateCommitteesFunc              func(ctx context.Context, slot uint64) ([]*statecomm.StateCommittee, error)
@@ -235,6 +241,15 @@ func (m Mock) AggregateAttestation(ctx context.Context, opts *eth2api.AggregateA
 	return wrapResponse(aggAtt), nil
 }
 
+func (m Mock) BeaconBlockHeader(ctx context.Context, opts *eth2api.BeaconBlockHeaderOpts) (*eth2api.Response[*eth2v1.BeaconBlockHeader], error) {
+	header, err := m.BeaconBlockHeaderFunc(ctx, opts.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapResponse(header), nil
+}
+
 func (m Mock) AttestationData(ctx context.Context, opts *eth2api.AttestationDataOpts) (*eth2api.Response[*eth2p0.AttestationData], error) {
 	attData, err := m.AttestationDataFunc(ctx, opts.Slot, opts.CommitteeIndex)
 	if err != nil {
@@ -347,6 +362,15 @@ func (m Mock) CompleteValidators(ctx context.Context) (eth2wrap.CompleteValidato
 	return complete, err
 }
 
+func (m Mock) ValidatorStates(ctx context.Context) (eth2wrap.ValidatorStates, error) {
+	_, complete, err := m.CachedValidatorsFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return eth2wrap.NewValidatorStates(complete)
+}
+
 func (m Mock) Genesis(ctx context.Context, opts *eth2api.GenesisOpts) (*eth2api.Response[*eth2v1.Genesis], error) {
 	genesis, err := m.GenesisFunc(ctx, opts)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
@@ -71,11 +72,13 @@ func TestAttestationStore(t *testing.T) {
 		Slot:                0,
 		AttestationDataRoot: root,
 	}
-	bmockResp, err := bmock.AggregateAttestation(ctx, aggAttOpts) // Slot is ignored.
+	bmockResp, err := bmock.AggregateAttestation(ctx, aggAttOpts) // Slot only determines the version.
 	require.NoError(t, err)
+	require.Equal(t, eth2spec.DataVersionDeneb, bmockResp.Data.Version)
 
-	att := bmockResp.Data.Electra
-	require.Equal(t, attData, att.Data)
+	aggData, err := bmockResp.Data.Data()
+	require.NoError(t, err)
+	require.Equal(t, attData, aggData)
 
 	aggAttopts2 := &eth2api.AggregateAttestationOpts{
 		Slot:                attData.Slot,
@@ -99,3 +102,39 @@ func TestAttestationStore(t *testing.T) {
 	_, err = bmock.AggregateAttestation(ctx, aggDataOpts) // Deleted.
 	require.Error(t, err)
 }
+
+func TestForkVersionedResponses(t *testing.T) {
+	bmock, err := beaconmock.New(beaconmock.WithSlotsPerEpoch(4), beaconmock.WithForkEpoch("electra", 2))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for slot, version := range map[eth2p0.Slot]eth2spec.DataVersion{
+		7: eth2spec.DataVersionDeneb,
+		8: eth2spec.DataVersionElectra,
+	} {
+		proposal, err := bmock.Proposal(ctx, &eth2api.ProposalOpts{Slot: slot})
+		require.NoError(t, err)
+		require.Equal(t, version, proposal.Data.Version)
+
+		proposalSlot, err := proposal.Data.Slot()
+		require.NoError(t, err)
+		require.Equal(t, slot, proposalSlot)
+
+		boost := uint64(100)
+		blinded, err := bmock.Proposal(ctx, &eth2api.ProposalOpts{Slot: slot, BuilderBoostFactor: &boost})
+		require.NoError(t, err)
+		require.Equal(t, version, blinded.Data.Version)
+		require.True(t, blinded.Data.Blinded)
+
+		attData, err := bmock.AttestationData(ctx, &eth2api.AttestationDataOpts{Slot: slot})
+		require.NoError(t, err)
+
+		root, err := attData.Data.HashTreeRoot()
+		require.NoError(t, err)
+
+		agg, err := bmock.AggregateAttestation(ctx, &eth2api.AggregateAttestationOpts{Slot: slot, AttestationDataRoot: root})
+		require.NoError(t, err)
+		require.Equal(t, version, agg.Data.Version)
+	}
+}
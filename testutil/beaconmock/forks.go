@@ -0,0 +1,119 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package beaconmock
+
+import (
+	"math/big"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+// newProposal returns a random proposal of the data version for the provided proposal options.
+func newProposal(version eth2spec.DataVersion, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error) {
+	blinded := opts.BuilderBoostFactor != nil && *opts.BuilderBoostFactor != 0
+
+	block := &eth2api.VersionedProposal{
+		Version:        version,
+		Blinded:        blinded,
+		ExecutionValue: big.NewInt(1),
+		ConsensusValue: big.NewInt(1),
+	}
+
+	switch {
+	case version == eth2spec.DataVersionBellatrix && !blinded:
+		block.Bellatrix = testutil.RandomBellatrixBeaconBlock()
+		block.Bellatrix.Slot = opts.Slot
+		block.Bellatrix.Body.RANDAOReveal = opts.RandaoReveal
+		block.Bellatrix.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionBellatrix:
+		block.BellatrixBlinded = testutil.RandomBellatrixBlindedBeaconBlock()
+		block.BellatrixBlinded.Slot = opts.Slot
+		block.BellatrixBlinded.Body.RANDAOReveal = opts.RandaoReveal
+		block.BellatrixBlinded.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionCapella && !blinded:
+		block.Capella = testutil.RandomCapellaBeaconBlock()
+		block.Capella.Slot = opts.Slot
+		block.Capella.Body.RANDAOReveal = opts.RandaoReveal
+		block.Capella.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionCapella:
+		block.CapellaBlinded = testutil.RandomCapellaBlindedBeaconBlock()
+		block.CapellaBlinded.Slot = opts.Slot
+		block.CapellaBlinded.Body.RANDAOReveal = opts.RandaoReveal
+		block.CapellaBlinded.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionDeneb && !blinded:
+		block.Deneb = testutil.RandomDenebVersionedProposal().Deneb
+		block.Deneb.Block.Slot = opts.Slot
+		block.Deneb.Block.Body.RANDAOReveal = opts.RandaoReveal
+		block.Deneb.Block.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionDeneb:
+		block.DenebBlinded = testutil.RandomDenebBlindedBeaconBlock()
+		block.DenebBlinded.Slot = opts.Slot
+		block.DenebBlinded.Body.RANDAOReveal = opts.RandaoReveal
+		block.DenebBlinded.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionElectra && !blinded:
+		block.Electra = testutil.RandomElectraVersionedProposal().Electra
+		block.Electra.Block.Slot = opts.Slot
+		block.Electra.Block.Body.RANDAOReveal = opts.RandaoReveal
+		block.Electra.Block.Body.Graffiti = opts.Graffiti
+	case version == eth2spec.DataVersionElectra:
+		block.ElectraBlinded = testutil.RandomElectraBlindedBeaconBlock()
+		block.ElectraBlinded.Slot = opts.Slot
+		block.ElectraBlinded.Body.RANDAOReveal = opts.RandaoReveal
+		block.ElectraBlinded.Body.Graffiti = opts.Graffiti
+	default:
+		return nil, errors.New("unsupported proposal version", z.Str("version", version.String()))
+	}
+
+	return block, nil
+}
+
+// newAggregateAttestation returns an aggregate attestation of the data version for the attestation data.
+func newAggregateAttestation(version eth2spec.DataVersion, attData *eth2p0.AttestationData) *eth2spec.VersionedAttestation {
+	valIdx := eth2p0.ValidatorIndex(0)
+
+	resp := &eth2spec.VersionedAttestation{
+		Version:        version,
+		ValidatorIndex: &valIdx,
+	}
+
+	if version == eth2spec.DataVersionElectra {
+		commBits := bitfield.NewBitvector64()
+		commBits.SetBitAt(0, true)
+
+		resp.Electra = &electra.Attestation{
+			AggregationBits: bitfield.NewBitlist(0),
+			Data:            attData,
+			CommitteeBits:   commBits,
+		}
+
+		return resp
+	}
+
+	att := &eth2p0.Attestation{
+		AggregationBits: bitfield.NewBitlist(0),
+		Data:            attData,
+	}
+
+	switch version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = att
+	case eth2spec.DataVersionAltair:
+		resp.Altair = att
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = att
+	case eth2spec.DataVersionCapella:
+		resp.Capella = att
+	default:
+		resp.Deneb = att
+	}
+
+	return resp
+}
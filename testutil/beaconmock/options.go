@@ -7,7 +7,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math/big"
 	"net/http"
 	"slices"
 	"sort"
@@ -19,7 +18,6 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
-	"github.com/attestantio/go-eth2-client/spec/electra"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -223,9 +221,91 @@ func WithValidatorSet(set ValidatorSet) Option {
 			Key:      "",
 			Value:    string(respJSON),
 		})
+
+		if mock.handlers == nil {
+			mock.handlers = make(map[string]http.HandlerFunc)
+		}
+
+		// Requests for all validators are served from the beacon state, which is only built when requested
+		// since validator sets with large indices result in large states.
+		mock.handlers["/eth/v2/debug/beacon/states/head"] = func(w http.ResponseWriter, _ *http.Request) {
+			state, ok := validatorSetState(set)
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			stateJSON, err := json.Marshal(struct {
+				Data *eth2p0.BeaconState `json:"data"`
+			}{Data: state})
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Eth-Consensus-Version", eth2spec.DataVersionPhase0.String())
+			_, _ = w.Write(stateJSON)
+		}
 	}
 }
 
+// maxStateValidators is the maximum number of validators of a mock beacon state.
+const maxStateValidators = 1 << 16
+
+// validatorSetState returns a minimal phase0 beacon state containing the validator set,
+// or false if the validator indices are too large.
+func validatorSetState(set ValidatorSet) (*eth2p0.BeaconState, bool) {
+	state := &eth2p0.BeaconState{
+		Fork:                        &eth2p0.Fork{},
+		LatestBlockHeader:           &eth2p0.BeaconBlockHeader{},
+		BlockRoots:                  []eth2p0.Root{{}},
+		StateRoots:                  []eth2p0.Root{},
+		HistoricalRoots:             []eth2p0.Root{},
+		ETH1Data:                    &eth2p0.ETH1Data{BlockHash: make([]byte, 32)},
+		ETH1DataVotes:               []*eth2p0.ETH1Data{},
+		Validators:                  []*eth2p0.Validator{},
+		Balances:                    []eth2p0.Gwei{},
+		RANDAOMixes:                 []eth2p0.Root{},
+		Slashings:                   []eth2p0.Gwei{},
+		PreviousEpochAttestations:   []*eth2p0.PendingAttestation{},
+		CurrentEpochAttestations:    []*eth2p0.PendingAttestation{},
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &eth2p0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &eth2p0.Checkpoint{},
+		FinalizedCheckpoint:         &eth2p0.Checkpoint{},
+	}
+
+	if len(set) == 0 {
+		return state, true
+	}
+
+	// State validators are ordered by index, fill any gaps with empty validators.
+	var maxIdx eth2p0.ValidatorIndex
+	for idx := range set {
+		maxIdx = max(maxIdx, idx)
+	}
+
+	if maxIdx >= maxStateValidators {
+		return nil, false
+	}
+
+	for idx := range maxIdx + 1 {
+		val, ok := set[idx]
+		if !ok || val.Validator == nil {
+			state.Validators = append(state.Validators, &eth2p0.Validator{})
+			state.Balances = append(state.Balances, 0)
+
+			continue
+		}
+
+		state.Validators = append(state.Validators, val.Validator)
+		state.Balances = append(state.Balances, val.Balance)
+	}
+
+	return state, true
+}
+
 // cloneValidator returns a cloned value that is safe for modification.
 func cloneValidator(val *eth2v1.Validator) *eth2v1.Validator {
 	tempv1 := *val
@@ -296,6 +376,17 @@ func WithSlotsPerEpoch(slotsPerEpoch int) Option {
 	}
 }
 
+// WithForkEpoch configures the http mock with the provided epoch of the fork, e.g. "electra".
+func WithForkEpoch(fork string, epoch uint64) Option {
+	return func(mock *Mock) {
+		mock.overrides = append(mock.overrides, staticOverride{
+			Endpoint: "/eth/v1/config/spec",
+			Key:      strings.ToUpper(fork) + "_FORK_EPOCH",
+			Value:    strconv.FormatUint(epoch, 10),
+		})
+	}
+}
+
 // WithDeterministicAttesterDuties configures the mock to provide deterministic
 // duties based on provided arguments and config.
 // Note it depends on ValidatorsFunc being populated, e.g. via WithValidatorSet.
@@ -519,32 +610,16 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		HTTPMock:     httpMock,
 		httpServer:   httpServer,
 		headProducer: headProducer,
-		ProposalFunc: func(_ context.Context, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error) {
-			var block *eth2api.VersionedProposal
-			if opts.BuilderBoostFactor == nil || *opts.BuilderBoostFactor == 0 {
-				block = &eth2api.VersionedProposal{
-					Version: eth2spec.DataVersionCapella,
-					Capella: testutil.RandomCapellaBeaconBlock(),
-				}
-				block.Capella.Slot = opts.Slot
-				block.Capella.Body.RANDAOReveal = opts.RandaoReveal
-				block.Capella.Body.Graffiti = opts.Graffiti
-				block.ExecutionValue = big.NewInt(1)
-				block.ConsensusValue = big.NewInt(1)
-			} else {
-				block = &eth2api.VersionedProposal{
-					Version:        eth2spec.DataVersionCapella,
-					CapellaBlinded: testutil.RandomCapellaBlindedBeaconBlock(),
-				}
-				block.CapellaBlinded.Slot = opts.Slot
-				block.CapellaBlinded.Body.RANDAOReveal = opts.RandaoReveal
-				block.CapellaBlinded.Body.Graffiti = opts.Graffiti
-				block.ExecutionValue = big.NewInt(1)
-				block.ConsensusValue = big.NewInt(1)
-				block.Blinded = true
+		ProposalFunc: func(ctx context.Context, opts *eth2api.ProposalOpts) (*eth2api.VersionedProposal, error) {
+			version, err := eth2wrap.FetchSlotDataVersion(ctx, httpMock, opts.Slot)
+			if err != nil {
+				return nil, err
 			}
 
-			return block, nil
+			return newProposal(version, opts)
+		},
+		BeaconBlockHeaderFunc: func(context.Context, string) (*eth2v1.BeaconBlockHeader, error) {
+			return testutil.RandomBeaconBlockHeader(), nil
 		},
 		SignedBeaconBlockFunc: func(context.Context, string) (*eth2spec.VersionedSignedBeaconBlock, error) {
 			return testutil.RandomDenebVersionedSignedBeaconBlock(), nil // Note the slot is probably wrong.
@@ -567,24 +642,18 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 			return attStore.NewAttestationData(ctx, slot, index)
 		},
-		AggregateAttestationFunc: func(_ context.Context, _ eth2p0.Slot, root eth2p0.Root) (*eth2spec.VersionedAttestation, error) {
+		AggregateAttestationFunc: func(ctx context.Context, slot eth2p0.Slot, root eth2p0.Root) (*eth2spec.VersionedAttestation, error) {
 			attData, err := attStore.AttestationDataByRoot(root)
 			if err != nil {
 				return nil, err
 			}
-			valIdx := eth2p0.ValidatorIndex(0)
-			commBits := bitfield.NewBitvector64()
-			commBits.SetBitAt(0, true)
-
-			return &eth2spec.VersionedAttestation{
-				Version:        eth2spec.DataVersionElectra,
-				ValidatorIndex: &valIdx,
-				Electra: &electra.Attestation{
-					AggregationBits: bitfield.NewBitlist(0),
-					Data:            attData,
-					CommitteeBits:   commBits,
-				},
-			}, nil
+
+			version, err := eth2wrap.FetchSlotDataVersion(ctx, httpMock, slot)
+			if err != nil {
+				return nil, err
+			}
+
+			return newAggregateAttestation(version, attData), nil
 		},
 		CachedValidatorsFunc: func(context.Context) (eth2wrap.ActiveValidators, eth2wrap.CompleteValidators, error) {
 			return nil, nil, nil
@@ -124,6 +124,10 @@ type Config struct {
 
 	// BuilderAPI enables the builder API for the compose cluster.
 	BuilderAPI bool `json:"builder_api"`
+
+	// AllDuties configures simnet beacon mock to assign sync committee duties in every epoch and select all
+	// validators as aggregators, exercising all validator mock duty flows.
+	AllDuties bool `json:"all_duties"`
 }
 
 // VCStrings returns the VCs field as a slice of strings.
@@ -145,6 +145,7 @@ func newNodeEnvs(index int, conf Config, vcType VCType) []kv {
 		kv{"loki-service", fmt.Sprintf("node%d", index)},
 		kv{"synthetic-block-proposals", fmt.Sprintf(`"%v"`, conf.SyntheticBlockProposals)},
 		kv{"builder-api", fmt.Sprintf(`"%v"`, conf.BuilderAPI)},
+		kv{"simnet-all-duties", fmt.Sprintf(`"%v"`, conf.AllDuties)},
 	)
 }
 
@@ -155,6 +155,13 @@ func TestSmoke(t *testing.T) {
 				conf.VCs = []compose.VCType{compose.VCLodestar}
 			},
 		},
+		{
+			Name: "all_duties_vmock",
+			ConfigFunc: func(conf *compose.Config) {
+				conf.VCs = []compose.VCType{compose.VCMock}
+				conf.AllDuties = true
+			},
+		},
 		{
 			Name: "blinded_blocks_vmock",
 			ConfigFunc: func(conf *compose.Config) {
@@ -443,6 +443,24 @@ func RandomCapellaVersionedSignedBeaconBlock() *eth2spec.VersionedSignedBeaconBl
 	}
 }
 
+// RandomBeaconBlockHeader returns a random canonical beacon block header.
+func RandomBeaconBlockHeader() *eth2v1.BeaconBlockHeader {
+	return &eth2v1.BeaconBlockHeader{
+		Root:      RandomRoot(),
+		Canonical: true,
+		Header: &eth2p0.SignedBeaconBlockHeader{
+			Message: &eth2p0.BeaconBlockHeader{
+				Slot:          RandomSlot(),
+				ProposerIndex: RandomVIdx(),
+				ParentRoot:    RandomRoot(),
+				StateRoot:     RandomRoot(),
+				BodyRoot:      RandomRoot(),
+			},
+			Signature: RandomEth2Signature(),
+		},
+	}
+}
+
 // RandomDenebVersionedSignedBeaconBlock returns a random signed deneb beacon block.
 func RandomDenebVersionedSignedBeaconBlock() *eth2spec.VersionedSignedBeaconBlock {
 	return &eth2spec.VersionedSignedBeaconBlock{
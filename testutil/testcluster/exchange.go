@@ -0,0 +1,153 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package testcluster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+type subscriber func(context.Context, core.Duty, core.ParSignedDataSet) error
+
+// link identifies the direction of partial signature messages between two nodes.
+type link struct {
+	From int
+	To   int
+}
+
+// exchange is an in-memory partial signature exchange between the nodes of a cluster
+// that supports dropping peers and delaying messages between peers.
+type exchange struct {
+	mu      sync.Mutex
+	subs    map[int][]subscriber
+	dropped map[int]bool
+	delays  map[link]time.Duration
+}
+
+func newExchange() *exchange {
+	return &exchange{
+		subs:    make(map[int][]subscriber),
+		dropped: make(map[int]bool),
+		delays:  make(map[link]time.Duration),
+	}
+}
+
+// ParSigExFunc returns a function returning the partial signature exchange component of the node.
+func (e *exchange) ParSigExFunc(node int) func() core.ParSigEx {
+	return func() core.ParSigEx {
+		return memEx{exchange: e, node: node}
+	}
+}
+
+// SetDropped drops or restores all partial signature messages sent from and to the node.
+func (e *exchange) SetDropped(node int, dropped bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dropped[node] = dropped
+}
+
+// Dropped returns true if the node is dropped.
+func (e *exchange) Dropped(node int) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.dropped[node]
+}
+
+// SetDelay delays partial signature messages sent from one node to another. A zero delay disables delaying.
+func (e *exchange) SetDelay(from, to int, delay time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if delay <= 0 {
+		delete(e.delays, link{From: from, To: to})
+		return
+	}
+
+	e.delays[link{From: from, To: to}] = delay
+}
+
+func (e *exchange) subscribe(node int, sub subscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.subs[node] = append(e.subs[node], sub)
+}
+
+// route returns the subscribers and the delay per peer of messages sent from the node.
+func (e *exchange) route(from int) (map[int][]subscriber, map[int]time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	subs := make(map[int][]subscriber)
+	delays := make(map[int]time.Duration)
+
+	if e.dropped[from] {
+		return subs, delays
+	}
+
+	for to, s := range e.subs {
+		if to == from || e.dropped[to] {
+			continue
+		}
+
+		subs[to] = s
+		delays[to] = e.delays[link{From: from, To: to}]
+	}
+
+	return subs, delays
+}
+
+// broadcast sends the partially signed data set from the node to all other non-dropped nodes.
+func (e *exchange) broadcast(ctx context.Context, from int, duty core.Duty, set core.ParSignedDataSet) error {
+	subs, delays := e.route(from)
+
+	for to, s := range subs {
+		if delay := delays[to]; delay > 0 {
+			go func() {
+				ctx := context.WithoutCancel(ctx)
+
+				time.Sleep(delay)
+
+				for _, sub := range s {
+					if err := sub(ctx, duty, set); err != nil {
+						log.Warn(ctx, "Delayed partial signature delivery failed", err, z.Int("from", from), z.Int("to", to))
+					}
+				}
+			}()
+
+			continue
+		}
+
+		for _, sub := range s {
+			if err := sub(ctx, duty, set); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// memEx is the in-memory partial signature exchange component of a single node.
+type memEx struct {
+	exchange *exchange
+	node     int
+}
+
+// Broadcast broadcasts the partially signed duty data set to all peers.
+func (m memEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	return m.exchange.broadcast(ctx, m.node, duty, set)
+}
+
+// Subscribe registers a callback when a partially signed duty set
+// is received from a peer.
+func (m memEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
+	m.exchange.subscribe(m.node, fn)
+}
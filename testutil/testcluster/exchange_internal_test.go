@@ -0,0 +1,83 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package testcluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestExchange(t *testing.T) {
+	const n = 3
+
+	ex := newExchange()
+
+	var (
+		mu       sync.Mutex
+		received = make(map[int]int)
+	)
+
+	var parSigExs []core.ParSigEx
+	for node := range n {
+		parSigEx := ex.ParSigExFunc(node)()
+		parSigEx.Subscribe(func(context.Context, core.Duty, core.ParSignedDataSet) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			received[node]++
+
+			return nil
+		})
+
+		parSigExs = append(parSigExs, parSigEx)
+	}
+
+	count := func(node int) int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return received[node]
+	}
+
+	ctx := context.Background()
+	duty := core.NewAttesterDuty(1)
+
+	require.NoError(t, parSigExs[0].Broadcast(ctx, duty, nil))
+	require.Equal(t, 0, count(0))
+	require.Equal(t, 1, count(1))
+	require.Equal(t, 1, count(2))
+
+	// Dropped peers neither send nor receive.
+	ex.SetDropped(2, true)
+	require.NoError(t, parSigExs[0].Broadcast(ctx, duty, nil))
+	require.NoError(t, parSigExs[2].Broadcast(ctx, duty, nil))
+	require.Equal(t, 0, count(0))
+	require.Equal(t, 2, count(1))
+	require.Equal(t, 1, count(2))
+
+	// Restored peers receive again.
+	ex.SetDropped(2, false)
+	require.NoError(t, parSigExs[1].Broadcast(ctx, duty, nil))
+	require.Equal(t, 1, count(0))
+	require.Equal(t, 2, count(2))
+
+	// Delayed messages are delivered asynchronously.
+	ex.SetDelay(0, 1, 50*time.Millisecond)
+	require.NoError(t, parSigExs[0].Broadcast(ctx, duty, nil))
+	require.Equal(t, 2, count(1))
+	require.Equal(t, 3, count(2))
+	require.Eventually(t, func() bool {
+		return count(1) == 3
+	}, time.Second, time.Millisecond)
+
+	// Zero delay disables delaying.
+	ex.SetDelay(0, 1, 0)
+	require.NoError(t, parSigExs[0].Broadcast(ctx, duty, nil))
+	require.Equal(t, 4, count(1))
+}
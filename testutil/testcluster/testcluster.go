@@ -0,0 +1,434 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package testcluster provides a programmable cluster of in-process charon nodes
+// wired to beacon node mocks and validator client mocks, for use in integration tests.
+// It supports dropping peers, delaying partial signature messages between peers,
+// stopping nodes and asserting duty outcomes.
+package testcluster
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/cmd/relay"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/validatormock"
+)
+
+// Option configures a cluster.
+type Option func(*options)
+
+type options struct {
+	nodes      int
+	threshold  int
+	validators int
+	seed       int
+	vmock      bool
+	bmockOpts  []beaconmock.Option
+	vmockOpts  map[int][]validatormock.Option
+	configFunc func(node int, conf *app.Config)
+}
+
+func defaultOptions() options {
+	return options{
+		nodes:      3,
+		threshold:  3,
+		validators: 1,
+		seed:       1,
+		vmock:      true,
+		bmockOpts:  []beaconmock.Option{beaconmock.WithSlotsPerEpoch(1)},
+		vmockOpts:  make(map[int][]validatormock.Option),
+	}
+}
+
+// WithNodes configures the number of nodes and the threshold of the cluster.
+func WithNodes(nodes, threshold int) Option {
+	return func(o *options) {
+		o.nodes = nodes
+		o.threshold = threshold
+	}
+}
+
+// WithValidators configures the number of distributed validators of the cluster.
+func WithValidators(validators int) Option {
+	return func(o *options) {
+		o.validators = validators
+	}
+}
+
+// WithSeed configures the seed of the randomly generated cluster lock and keys.
+func WithSeed(seed int) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithoutValidatorMock disables the in-process validator client mocks, allowing
+// external validator clients to connect to the nodes' validator APIs instead.
+func WithoutValidatorMock() Option {
+	return func(o *options) {
+		o.vmock = false
+	}
+}
+
+// WithBeaconMockOpts appends beacon node mock options applied to all nodes.
+func WithBeaconMockOpts(opts ...beaconmock.Option) Option {
+	return func(o *options) {
+		o.bmockOpts = append(o.bmockOpts, opts...)
+	}
+}
+
+// WithValidatorMockOpts appends validator client mock options applied to the node,
+// e.g. to configure a single misbehaving validator client.
+func WithValidatorMockOpts(node int, opts ...validatormock.Option) Option {
+	return func(o *options) {
+		o.vmockOpts[node] = append(o.vmockOpts[node], opts...)
+	}
+}
+
+// WithConfigFunc configures a function that may update the config of each node before it is started.
+func WithConfigFunc(fn func(node int, conf *app.Config)) Option {
+	return func(o *options) {
+		o.configFunc = fn
+	}
+}
+
+// Result is a duty broadcast by a node.
+type Result struct {
+	Node   int
+	Duty   core.Duty
+	PubKey core.PubKey
+	Data   core.SignedData
+}
+
+// Cluster is a cluster of in-process charon nodes.
+type Cluster struct {
+	t        *testing.T
+	opts     options
+	lock     cluster.Lock
+	p2pKeys  []*k1.PrivateKey
+	shares   [][]tbls.PrivateKey
+	vapiAddr []string
+	exchange *exchange
+
+	mu      sync.Mutex
+	results []Result
+	stopped map[int]bool
+	cancels []context.CancelFunc
+	eg      *errgroup.Group
+	cancel  context.CancelFunc
+}
+
+// New returns a new cluster of in-process charon nodes that are not started yet.
+func New(t *testing.T, opts ...Option) *Cluster {
+	t.Helper()
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	random := rand.New(rand.NewSource(int64(o.seed)))
+	lock, p2pKeys, secretShares := cluster.NewForT(t, o.validators, o.threshold, o.nodes, o.seed, random, func(definition *cluster.Definition) {
+		definition.ForkVersion = []byte{0x01, 0x01, 0x70, 0x00}
+	})
+
+	// Transpose secret shares from per validator to per node.
+	shares := make([][]tbls.PrivateKey, o.nodes)
+	for _, validatorShares := range secretShares {
+		for node, share := range validatorShares {
+			shares[node] = append(shares[node], share)
+		}
+	}
+
+	var vapiAddrs []string
+	for range o.nodes {
+		vapiAddrs = append(vapiAddrs, testutil.AvailableAddr(t).String())
+	}
+
+	return &Cluster{
+		t:        t,
+		opts:     o,
+		lock:     lock,
+		p2pKeys:  p2pKeys,
+		shares:   shares,
+		vapiAddr: vapiAddrs,
+		exchange: newExchange(),
+		stopped:  make(map[int]bool),
+	}
+}
+
+// Lock returns the cluster lock.
+func (c *Cluster) Lock() cluster.Lock {
+	return c.lock
+}
+
+// Nodes returns the number of nodes in the cluster.
+func (c *Cluster) Nodes() int {
+	return c.opts.nodes
+}
+
+// Shares returns the private key shares of the node, one per distributed validator.
+func (c *Cluster) Shares(node int) []tbls.PrivateKey {
+	return c.shares[node]
+}
+
+// ValidatorAPIAddr returns the validator API address of the node.
+func (c *Cluster) ValidatorAPIAddr(node int) string {
+	return c.vapiAddr[node]
+}
+
+// Start starts a relay and all nodes of the cluster. The cluster is stopped when the test completes.
+func (c *Cluster) Start(ctx context.Context) {
+	c.t.Helper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	relayAddr := startRelay(ctx, c.t)
+
+	eg := new(errgroup.Group)
+
+	c.mu.Lock()
+	c.eg = eg
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	for node := range c.opts.nodes {
+		conf := c.config(node, relayAddr)
+
+		nodeCtx, nodeCancel := context.WithCancel(ctx)
+
+		c.mu.Lock()
+		c.cancels = append(c.cancels, nodeCancel)
+		c.mu.Unlock()
+
+		eg.Go(func() error {
+			return app.Run(nodeCtx, conf)
+		})
+	}
+
+	c.t.Cleanup(func() {
+		err := c.Stop()
+		testutil.SkipIfBindErr(c.t, err)
+		testutil.RequireNoError(c.t, err)
+	})
+}
+
+// config returns the config of the node.
+func (c *Cluster) config(node int, relayAddr string) app.Config {
+	conf := app.Config{
+		Log:              log.DefaultConfig(),
+		Feature:          featureset.DefaultConfig(),
+		SimnetBMock:      true,
+		SimnetVMock:      c.opts.vmock,
+		MonitoringAddr:   testutil.AvailableAddr(c.t).String(),
+		ValidatorAPIAddr: c.vapiAddr[node],
+		TestConfig: app.TestConfig{
+			Lock:   &c.lock,
+			P2PKey: c.p2pKeys[node],
+			TestPingConfig: p2p.TestPingConfig{
+				MaxBackoff: time.Second,
+			},
+			SimnetKeys:   c.shares[node],
+			ParSigExFunc: c.exchange.ParSigExFunc(node),
+			BroadcastCallback: func(_ context.Context, duty core.Duty, set core.SignedDataSet) error {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+
+				for pubkey, data := range set {
+					c.results = append(c.results, Result{Node: node, Duty: duty, PubKey: pubkey, Data: data})
+				}
+
+				return nil
+			},
+			SimnetBMockOpts: slices.Clone(c.opts.bmockOpts),
+			SimnetVMockOpts: slices.Clone(c.opts.vmockOpts[node]),
+		},
+		P2P: p2p.Config{
+			TCPAddrs: []string{testutil.AvailableAddr(c.t).String()},
+			Relays:   []string{relayAddr},
+		},
+	}
+
+	if c.opts.configFunc != nil {
+		c.opts.configFunc(node, &conf)
+	}
+
+	return conf
+}
+
+// Stop stops all nodes and the relay and returns the first node error.
+func (c *Cluster) Stop() error {
+	c.mu.Lock()
+	eg, cancel := c.eg, c.cancel
+	c.mu.Unlock()
+
+	if eg == nil {
+		return nil
+	}
+
+	cancel()
+
+	return eg.Wait()
+}
+
+// StopNode stops the node, simulating a peer going offline.
+func (c *Cluster) StopNode(node int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopped[node] = true
+	c.cancels[node]()
+}
+
+// DropPeer drops all partial signature messages sent from and to the node, isolating it from the cluster.
+func (c *Cluster) DropPeer(node int) {
+	c.exchange.SetDropped(node, true)
+}
+
+// RestorePeer restores partial signature messages sent from and to a previously dropped node.
+func (c *Cluster) RestorePeer(node int) {
+	c.exchange.SetDropped(node, false)
+}
+
+// DelayMessages delays partial signature messages sent from one node to another.
+// A zero delay disables delaying.
+func (c *Cluster) DelayMessages(from, to int, delay time.Duration) {
+	c.exchange.SetDelay(from, to, delay)
+}
+
+// Results returns all duties broadcast by the nodes so far.
+func (c *Cluster) Results() []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.results)
+}
+
+// active returns the nodes that are neither stopped nor dropped.
+func (c *Cluster) active() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp []int
+	for node := range c.opts.nodes {
+		if c.stopped[node] || c.exchange.Dropped(node) {
+			continue
+		}
+
+		resp = append(resp, node)
+	}
+
+	return resp
+}
+
+// RequireDuties asserts that all active (not stopped or dropped) nodes broadcast
+// the duty types within the timeout and that all nodes broadcast identical signed data per duty.
+func (c *Cluster) RequireDuties(t *testing.T, timeout time.Duration, types ...core.DutyType) {
+	t.Helper()
+
+	active := c.active()
+
+	done := func() bool {
+		broadcast := make(map[core.DutyType]map[int]bool)
+		for _, res := range c.Results() {
+			if broadcast[res.Duty.Type] == nil {
+				broadcast[res.Duty.Type] = make(map[int]bool)
+			}
+
+			broadcast[res.Duty.Type][res.Node] = true
+		}
+
+		for _, typ := range types {
+			for _, node := range active {
+				if !broadcast[typ][node] {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	require.Eventually(t, done, timeout, 100*time.Millisecond, "duties not broadcast by all active nodes")
+
+	type key struct {
+		Duty   core.Duty
+		PubKey core.PubKey
+	}
+
+	datas := make(map[key][]byte)
+	for _, res := range c.Results() {
+		actual, err := res.Data.MarshalJSON()
+		require.NoError(t, err)
+
+		k := key{Duty: res.Duty, PubKey: res.PubKey}
+
+		expect, ok := datas[k]
+		if !ok {
+			datas[k] = actual
+			continue
+		}
+
+		require.JSONEq(t, string(expect), string(actual), "mismatching signed data, duty=%v, node=%d", res.Duty, res.Node)
+	}
+}
+
+// startRelay starts a charon relay and returns its http endpoint.
+func startRelay(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	addr := testutil.AvailableAddr(t).String()
+
+	go func() {
+		err := relay.Run(ctx, relay.Config{
+			DataDir:  dir,
+			HTTPAddr: addr,
+			P2PConfig: p2p.Config{
+				TCPAddrs: []string{testutil.AvailableAddr(t).String()},
+			},
+			LogConfig: log.Config{
+				Level:  "error",
+				Format: "console",
+			},
+			AutoP2PKey:    true,
+			MaxResPerPeer: 8,
+			MaxConns:      1024,
+		})
+		if err != nil && ctx.Err() == nil {
+			t.Logf("Relay stopped: err=%v", err)
+		}
+	}()
+
+	endpoint := "http://" + addr
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(endpoint) //nolint:noctx // Simple test relay availability check.
+		if err != nil {
+			return false
+		}
+
+		_ = resp.Body.Close()
+
+		return true
+	}, 5*time.Second, 100*time.Millisecond, "relay not available")
+
+	return endpoint
+}
@@ -0,0 +1,72 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package testcluster_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/testcluster"
+	"github.com/obolnetwork/charon/testutil/validatormock"
+)
+
+var integration = flag.Bool("integration", false, "Enable this package's integration tests")
+
+func TestCluster(t *testing.T) {
+	if !*integration {
+		t.Skip("Integration tests are disabled")
+	}
+
+	tests := []struct {
+		name  string
+		opts  []testcluster.Option
+		setup func(*testcluster.Cluster)
+	}{
+		{
+			name: "all peers",
+		},
+		{
+			name: "dropped peer",
+			setup: func(c *testcluster.Cluster) {
+				c.DropPeer(3)
+			},
+		},
+		{
+			name: "delayed messages",
+			setup: func(c *testcluster.Cluster) {
+				c.DelayMessages(0, 1, 500*time.Millisecond)
+			},
+		},
+		{
+			name: "misbehaving validator clients",
+			opts: []testcluster.Option{
+				testcluster.WithValidatorMockOpts(0, validatormock.WithEnforceJSON()),
+				testcluster.WithValidatorMockOpts(1, validatormock.WithLateSubmissions(100*time.Millisecond, core.DutyAttester)),
+				testcluster.WithValidatorMockOpts(2, validatormock.WithWrongKeyshares()),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := append([]testcluster.Option{
+				testcluster.WithNodes(4, 3),
+				testcluster.WithBeaconMockOpts(
+					beaconmock.WithNoProposerDuties(),
+					beaconmock.WithNoSyncCommitteeDuties(),
+				),
+			}, test.opts...)
+
+			c := testcluster.New(t, opts...)
+			if test.setup != nil {
+				test.setup(c)
+			}
+
+			c.Start(t.Context())
+			c.RequireDuties(t, 30*time.Second, core.DutyAttester)
+		})
+	}
+}
@@ -309,6 +309,11 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 		dutyByComm[duty.CommitteeIndex] = append(dutyByComm[duty.CommitteeIndex], duty)
 	}
 
+	version, err := eth2wrap.FetchSlotDataVersion(ctx, eth2Cl, slot)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		atts  []*eth2spec.VersionedAttestation
 		datas attDatas
@@ -347,23 +352,34 @@ func attest(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, slot
 			aggBits := bitfield.NewBitlist(duty.CommitteeLength)
 			aggBits.SetBitAt(duty.ValidatorCommitteeIndex, true)
 
-			commBits := bitfield.NewBitvector64()
-			commBits.SetBitAt(uint64(duty.CommitteeIndex), true)
-
-			atts = append(atts, &eth2spec.VersionedAttestation{
-				Version:        eth2spec.DataVersionElectra,
+			att := &eth2spec.VersionedAttestation{
+				Version:        version,
 				ValidatorIndex: &duty.ValidatorIndex,
-				Electra: &electra.Attestation{
+			}
+
+			if version == eth2spec.DataVersionElectra {
+				commBits := bitfield.NewBitvector64()
+				commBits.SetBitAt(uint64(duty.CommitteeIndex), true)
+
+				att.Electra = &electra.Attestation{
 					AggregationBits: aggBits,
 					Data:            data,
 					Signature:       sig,
 					CommitteeBits:   commBits,
-				},
-			})
+				}
+			} else if err := setPhase0Attestation(att, &eth2p0.Attestation{
+				AggregationBits: aggBits,
+				Data:            data,
+				Signature:       sig,
+			}); err != nil {
+				return nil, err
+			}
+
+			atts = append(atts, att)
 		}
 	}
 
-	err := eth2Cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
+	err = eth2Cl.SubmitAttestations(ctx, &eth2api.SubmitAttestationsOpts{Attestations: atts})
 	if err != nil {
 		return nil, err
 	}
@@ -413,13 +429,9 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 			attsByComm[commIdx] = att
 		}
 
-		proof := eth2spec.VersionedAggregateAndProof{
-			Version: eth2spec.DataVersionElectra,
-			Electra: &electra.AggregateAndProof{
-				AggregatorIndex: selection.ValidatorIndex,
-				Aggregate:       att.Electra,
-				SelectionProof:  selection.SelectionProof,
-			},
+		proof, err := newAggregateAndProof(att, selection)
+		if err != nil {
+			return false, err
 		}
 
 		proofRoot, err := proof.HashTreeRoot()
@@ -442,13 +454,12 @@ func aggregate(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, s
 			return false, err
 		}
 
-		aggs = append(aggs, &eth2spec.VersionedSignedAggregateAndProof{
-			Version: eth2spec.DataVersionElectra,
-			Electra: &electra.SignedAggregateAndProof{
-				Message:   proof.Electra,
-				Signature: proofSig,
-			},
-		})
+		agg, err := newSignedAggregateAndProof(proof, proofSig)
+		if err != nil {
+			return false, err
+		}
+
+		aggs = append(aggs, agg)
 	}
 
 	if err := eth2Cl.SubmitAggregateAttestations(ctx, &eth2api.SubmitAggregateAttestationsOpts{SignedAggregateAndProofs: aggs}); err != nil {
@@ -488,3 +499,102 @@ func getAggregateAttestation(ctx context.Context, eth2Cl eth2wrap.Client, datas
 
 	return nil, errors.New("missing attestation data for committee index")
 }
+
+// setPhase0Attestation sets the pre-electra attestation of the versioned attestation's version.
+func setPhase0Attestation(versioned *eth2spec.VersionedAttestation, att *eth2p0.Attestation) error {
+	switch versioned.Version {
+	case eth2spec.DataVersionPhase0:
+		versioned.Phase0 = att
+	case eth2spec.DataVersionAltair:
+		versioned.Altair = att
+	case eth2spec.DataVersionBellatrix:
+		versioned.Bellatrix = att
+	case eth2spec.DataVersionCapella:
+		versioned.Capella = att
+	case eth2spec.DataVersionDeneb:
+		versioned.Deneb = att
+	default:
+		return errors.New("unsupported attestation version", z.Str("version", versioned.Version.String()))
+	}
+
+	return nil
+}
+
+// newAggregateAndProof returns the aggregate and proof of the aggregate attestation's version.
+func newAggregateAndProof(att *eth2spec.VersionedAttestation, selection *eth2exp.BeaconCommitteeSelection,
+) (*eth2spec.VersionedAggregateAndProof, error) {
+	resp := &eth2spec.VersionedAggregateAndProof{Version: att.Version}
+
+	if att.Version == eth2spec.DataVersionElectra {
+		resp.Electra = &electra.AggregateAndProof{
+			AggregatorIndex: selection.ValidatorIndex,
+			Aggregate:       att.Electra,
+			SelectionProof:  selection.SelectionProof,
+		}
+
+		return resp, nil
+	}
+
+	var aggregate *eth2p0.Attestation
+
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		aggregate = att.Phase0
+	case eth2spec.DataVersionAltair:
+		aggregate = att.Altair
+	case eth2spec.DataVersionBellatrix:
+		aggregate = att.Bellatrix
+	case eth2spec.DataVersionCapella:
+		aggregate = att.Capella
+	case eth2spec.DataVersionDeneb:
+		aggregate = att.Deneb
+	default:
+		return nil, errors.New("unsupported aggregate attestation version", z.Str("version", att.Version.String()))
+	}
+
+	proof := &eth2p0.AggregateAndProof{
+		AggregatorIndex: selection.ValidatorIndex,
+		Aggregate:       aggregate,
+		SelectionProof:  selection.SelectionProof,
+	}
+
+	switch att.Version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = proof
+	case eth2spec.DataVersionAltair:
+		resp.Altair = proof
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = proof
+	case eth2spec.DataVersionCapella:
+		resp.Capella = proof
+	default:
+		resp.Deneb = proof
+	}
+
+	return resp, nil
+}
+
+// newSignedAggregateAndProof returns the signed aggregate and proof of the aggregate and proof's version.
+func newSignedAggregateAndProof(proof *eth2spec.VersionedAggregateAndProof, sig eth2p0.BLSSignature,
+) (*eth2spec.VersionedSignedAggregateAndProof, error) {
+	resp := &eth2spec.VersionedSignedAggregateAndProof{Version: proof.Version}
+
+	switch proof.Version {
+	case eth2spec.DataVersionPhase0:
+		resp.Phase0 = &eth2p0.SignedAggregateAndProof{Message: proof.Phase0, Signature: sig}
+	case eth2spec.DataVersionAltair:
+		resp.Altair = &eth2p0.SignedAggregateAndProof{Message: proof.Altair, Signature: sig}
+	case eth2spec.DataVersionBellatrix:
+		resp.Bellatrix = &eth2p0.SignedAggregateAndProof{Message: proof.Bellatrix, Signature: sig}
+	case eth2spec.DataVersionCapella:
+		resp.Capella = &eth2p0.SignedAggregateAndProof{Message: proof.Capella, Signature: sig}
+	case eth2spec.DataVersionDeneb:
+		resp.Deneb = &eth2p0.SignedAggregateAndProof{Message: proof.Deneb, Signature: sig}
+	case eth2spec.DataVersionElectra:
+		resp.Electra = &electra.SignedAggregateAndProof{Message: proof.Electra, Signature: sig}
+	default:
+		return nil, errors.New("unsupported aggregate and proof version", z.Str("version", proof.Version.String()))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2http "github.com/attestantio/go-eth2-client/http"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// Option configures the validator mock behaviour, including misbehaviour
+// exercising the error paths of the validator API and core workflow.
+type Option func(*options)
+
+type options struct {
+	lateDelay   time.Duration
+	lateDuties  map[core.DutyType]bool
+	wrongShares bool
+	enforceJSON bool
+}
+
+// WithLateSubmissions delays performing the duties of the provided types, or all duties if none provided.
+func WithLateSubmissions(delay time.Duration, duties ...core.DutyType) Option {
+	return func(o *options) {
+		o.lateDelay = delay
+		o.lateDuties = make(map[core.DutyType]bool)
+
+		for _, duty := range duties {
+			o.lateDuties[duty] = true
+		}
+	}
+}
+
+// WithWrongKeyshares signs with random private keys instead of the configured key shares,
+// resulting in invalid partial signatures.
+func WithWrongKeyshares() Option {
+	return func(o *options) {
+		o.wrongShares = true
+	}
+}
+
+// WithEnforceJSON configures the validator mock http client to only use JSON encoding
+// instead of preferring SSZ encoding where supported.
+func WithEnforceJSON() Option {
+	return func(o *options) {
+		o.enforceJSON = true
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// delay returns the delay to apply before performing the duty.
+func (o options) delay(duty core.DutyType) time.Duration {
+	if len(o.lateDuties) > 0 && !o.lateDuties[duty] {
+		return 0
+	}
+
+	return o.lateDelay
+}
+
+// wrapSigner returns the sign function applying the configured signing misbehaviour.
+func (o options) wrapSigner(signFunc SignFunc) SignFunc {
+	if !o.wrongShares {
+		return signFunc
+	}
+
+	return newWrongSigner()
+}
+
+// newWrongSigner returns a sign function that signs with a random private key per public key share.
+func newWrongSigner() SignFunc {
+	var (
+		mu      sync.Mutex
+		secrets = make(map[eth2p0.BLSPubKey]tbls.PrivateKey)
+	)
+
+	return func(pubshare eth2p0.BLSPubKey, data []byte) (eth2p0.BLSSignature, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		secret, ok := secrets[pubshare]
+		if !ok {
+			var err error
+
+			secret, err = tbls.GenerateSecretKey()
+			if err != nil {
+				return eth2p0.BLSSignature{}, err
+			}
+
+			secrets[pubshare] = secret
+		}
+
+		sig, err := tbls.Sign(secret, data)
+		if err != nil {
+			return eth2p0.BLSSignature{}, err
+		}
+
+		return tblsconv.SigToETH2(sig), nil
+	}
+}
+
+// NewHTTPProvider returns a function that returns a cached eth2 http client connected to the validator API address.
+func NewHTTPProvider(addr string, pubshares []eth2p0.BLSPubKey, opts ...Option) func() (eth2wrap.Client, error) {
+	o := newOptions(opts)
+
+	var (
+		cached eth2wrap.Client
+		mu     sync.Mutex
+	)
+
+	const timeout = time.Second * 10
+
+	return func() (eth2wrap.Client, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cached != nil {
+			return cached, nil
+		}
+
+		// Try three times to reduce test startup issues.
+		var err error
+
+		for range 3 {
+			var eth2Svc eth2client.Service
+
+			eth2Svc, err = eth2http.New(context.Background(),
+				eth2http.WithLogLevel(1),
+				eth2http.WithAddress("http://"+addr),
+				eth2http.WithTimeout(timeout), // Allow sufficient time to block while fetching duties.
+				eth2http.WithEnforceJSON(o.enforceJSON),
+			)
+			if err != nil {
+				time.Sleep(time.Millisecond * 100) // Test startup backoff
+				continue
+			}
+
+			eth2Http, ok := eth2Svc.(*eth2http.Service)
+			if !ok {
+				return nil, errors.New("invalid eth2 http service")
+			}
+
+			cached = eth2wrap.AdaptEth2HTTP(eth2Http, nil, timeout)
+			valCache := eth2wrap.NewValidatorCache(cached, pubshares)
+			cached.SetValidatorCache(valCache.GetByHead)
+
+			break
+		}
+
+		return cached, err
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2022-2025 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatormock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLateSubmissions(t *testing.T) {
+	o := newOptions(nil)
+	require.Zero(t, o.delay(core.DutyAttester))
+
+	o = newOptions([]Option{WithLateSubmissions(time.Second)})
+	require.Equal(t, time.Second, o.delay(core.DutyAttester))
+	require.Equal(t, time.Second, o.delay(core.DutyProposer))
+
+	o = newOptions([]Option{WithLateSubmissions(time.Second, core.DutyAttester)})
+	require.Equal(t, time.Second, o.delay(core.DutyAttester))
+	require.Zero(t, o.delay(core.DutyProposer))
+}
+
+func TestWrongKeyshares(t *testing.T) {
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubkey, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+
+	pubshare, err := tblsconv.PubkeyToETH2(pubkey)
+	require.NoError(t, err)
+
+	signer, err := NewSigner(secret)
+	require.NoError(t, err)
+
+	msg := testutil.RandomBytes32()
+
+	verify := func(signFunc SignFunc) error {
+		sig, err := signFunc(pubshare, msg)
+		require.NoError(t, err)
+
+		return tbls.Verify(pubkey, msg, tbls.Signature(sig))
+	}
+
+	require.NoError(t, verify(newOptions(nil).wrapSigner(signer)))
+
+	wrong := newOptions([]Option{WithWrongKeyshares()}).wrapSigner(signer)
+	require.Error(t, verify(wrong))
+
+	// Wrong signer consistently uses the same key per pubshare.
+	sig1, err := wrong(pubshare, msg)
+	require.NoError(t, err)
+	sig2, err := wrong(pubshare, msg)
+	require.NoError(t, err)
+	require.Equal(t, sig1, sig2)
+}
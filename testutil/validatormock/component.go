@@ -32,6 +32,7 @@ type scheduleTuple struct {
 	startTime time.Time
 }
 
+// New returns a new validator mock component that performs duties via the validator API.
 func New(ctx context.Context,
 	eth2ClProvider func() (eth2wrap.Client, error),
 	signFunc SignFunc,
@@ -40,10 +41,14 @@ func New(ctx context.Context,
 	slotDuration time.Duration,
 	slotsPerEpoch uint64,
 	builderAPI bool,
+	opts ...Option,
 ) *Component {
+	o := newOptions(opts)
+
 	c := &Component{
 		eth2ClProvider: eth2ClProvider,
-		signFunc:       signFunc,
+		signFunc:       o.wrapSigner(signFunc),
+		opts:           o,
 		pubkeys:        pubkeys,
 		meta: specMeta{
 			GenesisTime:   genesisTime,
@@ -73,6 +78,7 @@ type Component struct {
 	meta           specMeta
 	scheduled      chan scheduleTuple
 	builderAPI     bool
+	opts           options
 
 	// Mutable state.
 	mu               sync.Mutex
@@ -134,7 +140,7 @@ func (m *Component) Run(ctx context.Context) {
 				select {
 				case <-ctx.Done():
 					return
-				case <-sleepUntil(scheduled.startTime):
+				case <-sleepUntil(scheduled.startTime.Add(m.opts.delay(scheduled.duty.Type))):
 					err := m.runDuty(ctx, scheduled.duty)
 					if err != nil {
 						log.Warn(ctx, "Duty failed", err, z.Any("duty", scheduled.duty))
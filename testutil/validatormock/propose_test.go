@@ -55,6 +55,7 @@ func TestAttest(t *testing.T) {
 				beaconmock.WithClock(clock),
 				beaconmock.WithValidatorSet(valSet),
 				beaconmock.WithDeterministicAttesterDuties(test.DutyFactor),
+				beaconmock.WithForkEpoch("electra", 0),
 			)
 			require.NoError(t, err)
 